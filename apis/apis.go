@@ -22,8 +22,10 @@ import (
 	composev1beta1 "github.com/rossigee/provider-docker/apis/compose/v1beta1"
 	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
 	containerv1beta1 "github.com/rossigee/provider-docker/apis/container/v1beta1"
+	namespacedv1beta1 "github.com/rossigee/provider-docker/apis/namespaced/v1beta1"
 	networkv1alpha1 "github.com/rossigee/provider-docker/apis/network/v1alpha1"
 	networkv1beta1 "github.com/rossigee/provider-docker/apis/network/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
 	volumev1alpha1 "github.com/rossigee/provider-docker/apis/volume/v1alpha1"
 	volumev1beta1 "github.com/rossigee/provider-docker/apis/volume/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,6 +34,8 @@ import (
 func init() {
 	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
 	AddToSchemes = append(AddToSchemes,
+		apisv1beta1.SchemeBuilder.AddToScheme,
+		namespacedv1beta1.SchemeBuilder.AddToScheme,
 		containerv1alpha1.SchemeBuilder.AddToScheme,
 		containerv1beta1.SchemeBuilder.AddToScheme,
 		composev1alpha1.SchemeBuilder.AddToScheme,
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TypePaused indicates whether an in-flight operation on the stack was
+// interrupted because the crossplane.io/paused annotation was set.
+const TypePaused xpv1.ConditionType = "Paused"
+
+// Reasons a ComposeStack is or is not paused.
+const (
+	ReasonPauseRequested xpv1.ConditionReason = "PauseRequested"
+	ReasonResumed        xpv1.ConditionReason = "Resumed"
+)
+
+// Paused returns a condition indicating that the stack stopped working on
+// its services part way through because it was paused.
+func Paused() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPauseRequested,
+	}
+}
+
+// Resumed returns a condition indicating that the stack is being
+// reconciled normally.
+func Resumed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResumed,
+	}
+}
+
+// TypeDeferred indicates whether acting on drift of the stack has been
+// deferred because its maintenance window is closed.
+const TypeDeferred xpv1.ConditionType = "Deferred"
+
+// Reasons acting on drift of the stack is or is not deferred.
+const (
+	ReasonOutsideMaintenanceWindow xpv1.ConditionReason = "OutsideMaintenanceWindow"
+	ReasonNothingDeferred          xpv1.ConditionReason = "NothingDeferred"
+)
+
+// Deferred returns a condition indicating that the stack has drifted, and
+// that acting on it waits for its maintenance window to open.
+func Deferred(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeferred,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOutsideMaintenanceWindow,
+		Message:            message,
+	}
+}
+
+// NotDeferred returns a condition indicating that nothing about the stack
+// waits for its maintenance window.
+func NotDeferred() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeferred,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNothingDeferred,
+	}
+}
+
+// TypeDegraded indicates whether the health score of the stack is below its
+// threshold.
+const TypeDegraded xpv1.ConditionType = "Degraded"
+
+// Reasons a ComposeStack is or is not degraded.
+const (
+	ReasonHealthScoreLow     xpv1.ConditionReason = "HealthScoreLow"
+	ReasonHealthScoreHealthy xpv1.ConditionReason = "HealthScoreHealthy"
+)
+
+// Degraded returns a condition indicating that the health score of the
+// stack is below its threshold.
+func Degraded(score, threshold int32) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegraded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthScoreLow,
+		Message:            fmt.Sprintf("health score %d is below %d", score, threshold),
+	}
+}
+
+// NotDegraded returns a condition indicating that the health score of the
+// stack is at or above its threshold.
+func NotDegraded() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegraded,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthScoreHealthy,
+	}
+}
+
+// TypeMissingReference indicates whether environment variables of the stack
+// reference Secrets or ConfigMaps that cannot be resolved.
+const TypeMissingReference xpv1.ConditionType = "MissingReference"
+
+// Reasons a ComposeStack is or is not missing references.
+const (
+	ReasonUnresolvedEnvironment xpv1.ConditionReason = "UnresolvedEnvironment"
+	ReasonReferencesResolved    xpv1.ConditionReason = "ReferencesResolved"
+)
+
+// MissingReference returns a condition indicating that environment
+// variables of the stack cannot be resolved, as the message lists.
+func MissingReference(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMissingReference,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnresolvedEnvironment,
+		Message:            message,
+	}
+}
+
+// ReferencesResolved returns a condition indicating that every environment
+// variable of the stack has been resolved.
+func ReferencesResolved() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMissingReference,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReferencesResolved,
+	}
+}
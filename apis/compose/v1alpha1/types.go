@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/pkg/labels"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -47,11 +49,26 @@ type ComposeStackParameters struct {
 	// +optional
 	ProjectName *string `json:"projectName,omitempty"`
 
+	// Profiles are the compose profiles that are active, like the
+	// docker compose --profile flag. Services that list profiles are only
+	// created when one of them is active, and removed when none is;
+	// services that list none are always created. "*" activates every
+	// profile.
+	// +optional
+	Profiles []string `json:"profiles,omitempty"`
+
 	// Environment variables to inject into all services.
 	// These variables are available for interpolation in the compose file.
 	// +optional
 	Environment []ComposeEnvVar `json:"environment,omitempty"`
 
+	// StrictEnvResolution fails the stack, with a true MissingReference
+	// condition, while any environment variable's Secret or ConfigMap
+	// reference cannot be resolved, rather than interpolating an empty value
+	// in its place.
+	// +optional
+	StrictEnvResolution *bool `json:"strictEnvResolution,omitempty"`
+
 	// ServiceOverrides allow overriding specific service configurations.
 	// +optional
 	ServiceOverrides map[string]ServiceOverride `json:"serviceOverrides,omitempty"`
@@ -65,8 +82,130 @@ type ComposeStackParameters struct {
 	// These can reference ConfigMaps containing environment definitions.
 	// +optional
 	EnvFiles []ComposeReference `json:"envFiles,omitempty"`
+
+	// InterruptOnPause stops an in-flight operation between services as
+	// soon as the stack is annotated with crossplane.io/paused, rather than
+	// letting it run to completion first.
+	// +optional
+	InterruptOnPause *bool `json:"interruptOnPause,omitempty"`
+
+	// Logs configures how service logs are surfaced when the stack fails.
+	// +optional
+	Logs *LogsConfig `json:"logs,omitempty"`
+
+	// MaintenanceWindow constrains when the services of the stack may be
+	// disruptively recreated. Outside the window drift is still reported,
+	// but acting on it is deferred until the window opens.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// ExportEnvironment writes the environment the compose file was
+	// interpolated with to a ConfigMap owned by the stack, named after the
+	// stack with the EnvironmentConfigMapSuffix suffix, so that the values
+	// a render used can be inspected. Variables taken from Secrets are left
+	// out.
+	// +optional
+	ExportEnvironment *bool `json:"exportEnvironment,omitempty"`
+
+	// Health scores the stack from the restarts, failed health checks and
+	// out-of-memory kills of its services over a rolling window, so that a
+	// stack that is degrading can be alerted on before its services fail.
+	// +optional
+	Health *HealthScoreConfig `json:"health,omitempty"`
+
+	// Labels are set on every container, network and volume the stack
+	// creates, so that they can be attributed to it on the host. Labels a
+	// service or network sets itself take precedence, and the labels
+	// identifying the stack cannot be overridden.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// HealthScoreConfig configures how the health of a stack is scored.
+type HealthScoreConfig struct {
+	// Window is how far back the incidents of the stack's services count
+	// against its score.
+	// +kubebuilder:default="1h"
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+
+	// DegradedBelow is the score under which the stack is Degraded.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=70
+	// +optional
+	DegradedBelow *int32 `json:"degradedBelow,omitempty"`
+}
+
+// A MaintenanceWindow is a recurring period in which disruptive operations
+// on a stack may run.
+type MaintenanceWindow struct {
+	// Schedule is a standard five-field cron expression for when each
+	// window opens, such as "0 2 * * 6" for 02:00 every Saturday.
+	Schedule string `json:"schedule"`
+
+	// Duration is how long each window stays open.
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone of the schedule. Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
+// LogsConfig configures how service logs are surfaced.
+type LogsConfig struct {
+	// Aggregate collects the last lines each service logged into the
+	// stack's status, and a single event, when any of its services fails,
+	// so that a failed deploy can be debugged without access to the host.
+	// +optional
+	Aggregate bool `json:"aggregate,omitempty"`
+
+	// TailLines is how many of the last lines of each service are
+	// collected. Defaults to 20.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=200
+	// +optional
+	TailLines *int32 `json:"tailLines,omitempty"`
+}
+
+// AnnotationExportConfigMap names a ConfigMap in the stack's namespace that
+// the observed stack is exported to as a Docker Compose file, under the
+// ExportConfigMapKey key.
+const AnnotationExportConfigMap = labels.AnnotationExportConfigMap
+
+// ExportConfigMapKey is the ConfigMap key an exported stack is stored under.
+const ExportConfigMapKey = "docker-compose.yaml"
+
+// AnnotationInspectService names a service of the stack whose container is
+// captured, as the JSON docker inspect prints, to the ConfigMap named after
+// the stack with InspectConfigMapSuffix, under the service's name with a
+// .json extension. The capture is refreshed while the annotation is set.
+const AnnotationInspectService = labels.AnnotationInspectService
+
+// InspectConfigMapSuffix is appended to the name of a stack to name the
+// ConfigMap the container of a service is captured to.
+const InspectConfigMapSuffix = "-inspect"
+
+// EnvironmentConfigMapSuffix is appended to the name of a stack to name the
+// ConfigMap its environment is exported to.
+const EnvironmentConfigMapSuffix = "-environment"
+
+// Labels stamped on the Docker objects a stack creates, alongside the Docker
+// Compose project and service labels, so that they are found by the stack
+// that owns them rather than by their names.
+const (
+	// LabelStackUID is the UID of the ComposeStack that owns the object.
+	LabelStackUID = labels.StackUID
+
+	// LabelConfigHash is a hash of the configuration a container was
+	// created with, used to detect when its service has changed.
+	LabelConfigHash = labels.ConfigHash
+
+	// LabelModelHash is a hash of the rendered compose model a container
+	// was created from, used to detect when the stack has changed.
+	LabelModelHash = labels.ModelHash
+)
+
 // ComposeReference references a ConfigMap or Secret containing compose-related data.
 type ComposeReference struct {
 	// ConfigMapRef references a ConfigMap.
@@ -161,8 +300,12 @@ type ConfigMapKeySelector struct {
 
 // ServiceOverride allows overriding specific service configurations.
 type ServiceOverride struct {
-	// Replicas overrides the number of replicas for this service.
+	// Replicas is how many containers run this service, numbered from 1
+	// as docker compose numbers them. Changing it creates or removes
+	// replicas, from the highest number down, without recreating the
+	// others. Defaults to 1.
 	// Note: This is a Crossplane-specific extension to Docker Compose.
+	// +kubebuilder:validation:Minimum=1
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
@@ -182,6 +325,49 @@ type ServiceOverride struct {
 	// +kubebuilder:validation:Enum=no;on-failure;always;unless-stopped
 	// +optional
 	RestartPolicy *string `json:"restartPolicy,omitempty"`
+
+	// WriteConnectionSecretTo publishes the connection details of this
+	// service to a Secret of its own, in the namespace of the stack.
+	// +optional
+	WriteConnectionSecretTo *ServiceConnectionSecret `json:"writeConnectionSecretTo,omitempty"`
+
+	// ReadinessProbe checks whether the service is ready. Services that
+	// depend on it are not created until it passes.
+	// +optional
+	ReadinessProbe *containerv1alpha1.ReadinessProbe `json:"readinessProbe,omitempty"`
+
+	// LogConfig configures the log driver of this service, replacing its
+	// logging in the compose file.
+	// +optional
+	LogConfig *containerv1alpha1.LogConfig `json:"logConfig,omitempty"`
+
+	// Platform is the platform of the image this service runs, such as
+	// linux/arm64, replacing its platform in the compose file. It lets a
+	// service of a multi-arch stack run emulated on a host with binfmt
+	// configured.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+/[a-z0-9_]+(/[a-z0-9]+)?$`
+	// +optional
+	Platform *string `json:"platform,omitempty"`
+}
+
+// A ServiceConnectionSecret is a Secret a service publishes its connection
+// details to. The Secret has the endpoint and port the service is reached
+// at, and any values interpolated from the environment of its container.
+type ServiceConnectionSecret struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+
+	// Port is the container port the service is reached at. The port it is
+	// published at on the Docker host is written to the Secret. Defaults to
+	// the first port the service publishes.
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// Values are written to the Secret under their keys, after
+	// interpolating the environment of the service's container, such as
+	// ${POSTGRES_PASSWORD}.
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
 }
 
 // ResourceRequirements describes resource requirements for a service.
@@ -224,6 +410,80 @@ type ComposeStackObservation struct {
 	// ComposeVersion indicates the detected compose file format version.
 	// +optional
 	ComposeVersion *string `json:"composeVersion,omitempty"`
+
+	// Warnings lists deprecated keys in the compose file, such as links,
+	// and how they were translated.
+	// +optional
+	Warnings []string `json:"warnings,omitempty"`
+
+	// ServiceLogs holds the last lines each service logged, by service
+	// name, while any service of the stack has failed and logs are
+	// aggregated.
+	// +optional
+	ServiceLogs map[string]string `json:"serviceLogs,omitempty"`
+
+	// ModelHash is a hash of the compose model last rendered from the spec,
+	// after interpolation and with the service overrides applied. The
+	// stack is out of date while its containers were created from a
+	// different model.
+	// +optional
+	ModelHash string `json:"modelHash,omitempty"`
+
+	// Health is the health score of the stack, when it is scored.
+	// +optional
+	Health *StackHealth `json:"health,omitempty"`
+}
+
+// StackHealth is the health score of a stack, computed from the Docker
+// events of its services over a rolling window.
+type StackHealth struct {
+	// Score is 100 for a stack whose services had no incidents in the
+	// window, less 10 for each restart, 5 for each failed health check and
+	// 20 for each out-of-memory kill, down to 0.
+	Score int32 `json:"score"`
+
+	// Restarts is how many times a service exited unexpectedly in the
+	// window.
+	Restarts int32 `json:"restarts"`
+
+	// UnhealthyIntervals is how many times a service turned unhealthy in
+	// the window.
+	UnhealthyIntervals int32 `json:"unhealthyIntervals"`
+
+	// OOMKills is how many times a service was killed for running out of
+	// memory in the window.
+	OOMKills int32 `json:"oomKills"`
+
+	// Incidents are the incidents in the window, oldest first.
+	// +optional
+	Incidents []HealthIncident `json:"incidents,omitempty"`
+
+	// CheckedAt is when the events of the stack were last read.
+	// +optional
+	CheckedAt *metav1.Time `json:"checkedAt,omitempty"`
+}
+
+// A HealthIncidentType is a kind of incident that counts against the health
+// score of a stack.
+type HealthIncidentType string
+
+// Health incident types.
+const (
+	HealthIncidentRestart   HealthIncidentType = "Restart"
+	HealthIncidentUnhealthy HealthIncidentType = "Unhealthy"
+	HealthIncidentOOMKill   HealthIncidentType = "OOMKill"
+)
+
+// A HealthIncident is an incident of a service of a stack.
+type HealthIncident struct {
+	// Service is the service the incident happened to.
+	Service string `json:"service"`
+
+	// Type is the kind of incident.
+	Type HealthIncidentType `json:"type"`
+
+	// At is when the incident happened.
+	At metav1.Time `json:"at"`
 }
 
 // ServiceStatus represents the status of a service within the compose stack.
@@ -258,6 +518,75 @@ type ServiceStatus struct {
 	// StartedAt indicates when the service was started.
 	// +optional
 	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// Phase is how far the service has got in being brought up.
+	// +optional
+	Phase ServicePhase `json:"phase,omitempty"`
+
+	// PhaseTransitions records when the service entered each phase of its
+	// latest bring-up, oldest first.
+	// +optional
+	PhaseTransitions []ServicePhaseTransition `json:"phaseTransitions,omitempty"`
+
+	// Message explains why the service is in the Failed phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Replicas is how many containers the service runs, when its override
+	// sets its replicas. The fields above describe its first replica.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas is how many of the service's replicas are running, or
+	// have completed for a service others wait on to complete.
+	// +optional
+	ReadyReplicas *int32 `json:"readyReplicas,omitempty"`
+
+	// ReplicaStatuses are the status of each replica of the service, by
+	// number, when its override sets its replicas.
+	// +optional
+	ReplicaStatuses []ReplicaStatus `json:"replicaStatuses,omitempty"`
+}
+
+// ReplicaStatus is the status of one of the containers of a service.
+type ReplicaStatus struct {
+	// Number of the replica, from 1.
+	Number int32 `json:"number"`
+
+	// ContainerID is the ID of the replica's container.
+	// +optional
+	ContainerID *string `json:"containerID,omitempty"`
+
+	// State indicates the current state of the replica.
+	// +kubebuilder:validation:Enum=pending;creating;running;restarting;exited;paused;dead;unknown
+	State string `json:"state"`
+
+	// StartedAt indicates when the replica was started.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+}
+
+// ServicePhase is how far a service has got in being brought up.
+// +kubebuilder:validation:Enum=Pending;Pulling;Creating;Starting;Running;Failed
+type ServicePhase string
+
+// Phases of a service being brought up.
+const (
+	ServicePhasePending  ServicePhase = "Pending"
+	ServicePhasePulling  ServicePhase = "Pulling"
+	ServicePhaseCreating ServicePhase = "Creating"
+	ServicePhaseStarting ServicePhase = "Starting"
+	ServicePhaseRunning  ServicePhase = "Running"
+	ServicePhaseFailed   ServicePhase = "Failed"
+)
+
+// A ServicePhaseTransition records when a service entered a phase.
+type ServicePhaseTransition struct {
+	// Phase the service entered.
+	Phase ServicePhase `json:"phase"`
+
+	// Time the service entered the phase.
+	Time metav1.Time `json:"time"`
 }
 
 // NetworkStatus represents the status of a network created by the compose stack.
@@ -390,6 +719,16 @@ func (cr *ComposeStack) SetManagementPolicies(p xpv1.ManagementPolicies) {
 	cr.Spec.ManagementPolicies = p
 }
 
+// GetProviderConfigReference returns the ProviderConfigReference field.
+func (cr *ComposeStack) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return cr.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference sets the ProviderConfigReference field.
+func (cr *ComposeStack) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	cr.Spec.ProviderConfigReference = p
+}
+
 // +kubebuilder:object:root=true
 
 // ComposeStackList contains a list of ComposeStack.
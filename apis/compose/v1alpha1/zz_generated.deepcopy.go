@@ -21,6 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -77,6 +79,10 @@ func (in *ComposeReference) DeepCopy() *ComposeReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComposeStack) DeepCopyInto(out *ComposeStack) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStack.
@@ -100,6 +106,15 @@ func (in *ComposeStack) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComposeStackList) DeepCopyInto(out *ComposeStackList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComposeStack, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStackList.
@@ -144,6 +159,23 @@ func (in *ComposeStackObservation) DeepCopyInto(out *ComposeStackObservation) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceLogs != nil {
+		in, out := &in.ServiceLogs, &out.ServiceLogs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(StackHealth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStackObservation.
@@ -174,6 +206,11 @@ func (in *ComposeStackParameters) DeepCopyInto(out *ComposeStackParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Environment != nil {
 		in, out := &in.Environment, &out.Environment
 		*out = make([]ComposeEnvVar, len(*in))
@@ -181,6 +218,11 @@ func (in *ComposeStackParameters) DeepCopyInto(out *ComposeStackParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StrictEnvResolution != nil {
+		in, out := &in.StrictEnvResolution, &out.StrictEnvResolution
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ServiceOverrides != nil {
 		in, out := &in.ServiceOverrides, &out.ServiceOverrides
 		*out = make(map[string]ServiceOverride, len(*in))
@@ -200,6 +242,38 @@ func (in *ComposeStackParameters) DeepCopyInto(out *ComposeStackParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InterruptOnPause != nil {
+		in, out := &in.InterruptOnPause, &out.InterruptOnPause
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(LogsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExportEnvironment != nil {
+		in, out := &in.ExportEnvironment, &out.ExportEnvironment
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(HealthScoreConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStackParameters.
@@ -215,6 +289,8 @@ func (in *ComposeStackParameters) DeepCopy() *ComposeStackParameters {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComposeStackSpec) DeepCopyInto(out *ComposeStackSpec) {
 	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStackSpec.
@@ -230,6 +306,8 @@ func (in *ComposeStackSpec) DeepCopy() *ComposeStackSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComposeStackStatus) DeepCopyInto(out *ComposeStackStatus) {
 	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStackStatus.
@@ -327,6 +405,47 @@ func (in *HealthCheckResult) DeepCopy() *HealthCheckResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthIncident) DeepCopyInto(out *HealthIncident) {
+	*out = *in
+	in.At.DeepCopyInto(&out.At)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthIncident.
+func (in *HealthIncident) DeepCopy() *HealthIncident {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthIncident)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthScoreConfig) DeepCopyInto(out *HealthScoreConfig) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DegradedBelow != nil {
+		in, out := &in.DegradedBelow, &out.DegradedBelow
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthScoreConfig.
+func (in *HealthScoreConfig) DeepCopy() *HealthScoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthScoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthStatus) DeepCopyInto(out *HealthStatus) {
 	*out = *in
@@ -354,6 +473,47 @@ func (in *HealthStatus) DeepCopy() *HealthStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsConfig) DeepCopyInto(out *LogsConfig) {
+	*out = *in
+	if in.TailLines != nil {
+		in, out := &in.TailLines, &out.TailLines
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsConfig.
+func (in *LogsConfig) DeepCopy() *LogsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LogsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
 	*out = *in
@@ -409,6 +569,30 @@ func (in *PortMapping) DeepCopy() *PortMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaStatus) DeepCopyInto(out *ReplicaStatus) {
+	*out = *in
+	if in.ContainerID != nil {
+		in, out := &in.ContainerID, &out.ContainerID
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaStatus.
+func (in *ReplicaStatus) DeepCopy() *ReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
 	*out = *in
@@ -478,6 +662,33 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceConnectionSecret) DeepCopyInto(out *ServiceConnectionSecret) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceConnectionSecret.
+func (in *ServiceConnectionSecret) DeepCopy() *ServiceConnectionSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceConnectionSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceOverride) DeepCopyInto(out *ServiceOverride) {
 	*out = *in
@@ -510,6 +721,26 @@ func (in *ServiceOverride) DeepCopyInto(out *ServiceOverride) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.WriteConnectionSecretTo != nil {
+		in, out := &in.WriteConnectionSecretTo, &out.WriteConnectionSecretTo
+		*out = new(ServiceConnectionSecret)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(containerv1alpha1.ReadinessProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogConfig != nil {
+		in, out := &in.LogConfig, &out.LogConfig
+		*out = new(containerv1alpha1.LogConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceOverride.
@@ -522,6 +753,22 @@ func (in *ServiceOverride) DeepCopy() *ServiceOverride {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePhaseTransition) DeepCopyInto(out *ServicePhaseTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePhaseTransition.
+func (in *ServicePhaseTransition) DeepCopy() *ServicePhaseTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePhaseTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceStatus) DeepCopyInto(out *ServiceStatus) {
 	*out = *in
@@ -547,6 +794,38 @@ func (in *ServiceStatus) DeepCopyInto(out *ServiceStatus) {
 		*out = new(HealthStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PhaseTransitions != nil {
+		in, out := &in.PhaseTransitions, &out.PhaseTransitions
+		*out = make([]ServicePhaseTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReadyReplicas != nil {
+		in, out := &in.ReadyReplicas, &out.ReadyReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReplicaStatuses != nil {
+		in, out := &in.ReplicaStatuses, &out.ReplicaStatuses
+		*out = make([]ReplicaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceStatus.
@@ -559,6 +838,32 @@ func (in *ServiceStatus) DeepCopy() *ServiceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackHealth) DeepCopyInto(out *StackHealth) {
+	*out = *in
+	if in.Incidents != nil {
+		in, out := &in.Incidents, &out.Incidents
+		*out = make([]HealthIncident, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CheckedAt != nil {
+		in, out := &in.CheckedAt, &out.CheckedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackHealth.
+func (in *StackHealth) DeepCopy() *StackHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(StackHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeStatus) DeepCopyInto(out *VolumeStatus) {
 	*out = *in
@@ -88,6 +88,16 @@ func (cr *ComposeStack) SetManagementPolicies(p xpv1.ManagementPolicies) {
 	cr.Spec.ManagementPolicies = p
 }
 
+// GetProviderConfigReference returns the ProviderConfigReference field.
+func (cr *ComposeStack) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return cr.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference sets the ProviderConfigReference field.
+func (cr *ComposeStack) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	cr.Spec.ProviderConfigReference = p
+}
+
 // +kubebuilder:object:root=true
 
 // ComposeStackList contains a list of ComposeStack.
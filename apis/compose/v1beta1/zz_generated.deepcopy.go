@@ -28,6 +28,10 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComposeStack) DeepCopyInto(out *ComposeStack) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStack.
@@ -51,6 +55,15 @@ func (in *ComposeStack) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComposeStackList) DeepCopyInto(out *ComposeStackList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComposeStack, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStackList.
@@ -95,6 +108,18 @@ func (in *ComposeStackObservation) DeepCopyInto(out *ComposeStackObservation) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceLogs != nil {
+		in, out := &in.ServiceLogs, &out.ServiceLogs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStackObservation.
@@ -151,6 +176,28 @@ func (in *ComposeStackParameters) DeepCopyInto(out *ComposeStackParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(v1alpha1.LogsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(v1alpha1.MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExportEnvironment != nil {
+		in, out := &in.ExportEnvironment, &out.ExportEnvironment
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStackParameters.
@@ -166,6 +213,8 @@ func (in *ComposeStackParameters) DeepCopy() *ComposeStackParameters {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComposeStackSpec) DeepCopyInto(out *ComposeStackSpec) {
 	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStackSpec.
@@ -181,6 +230,8 @@ func (in *ComposeStackSpec) DeepCopy() *ComposeStackSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComposeStackStatus) DeepCopyInto(out *ComposeStackStatus) {
 	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStackStatus.
@@ -0,0 +1,280 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TypePostConditionFailed indicates whether a post-condition of the
+// container failed when it was last checked.
+const TypePostConditionFailed xpv1.ConditionType = "PostConditionFailed"
+
+// Reasons a container's post-conditions did or did not fail.
+const (
+	ReasonPostConditionFailed xpv1.ConditionReason = "CheckFailed"
+	ReasonPostConditionsMet   xpv1.ConditionReason = "ChecksPassed"
+)
+
+// PostConditionFailed returns a condition indicating that a post-condition
+// of the container failed.
+func PostConditionFailed(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePostConditionFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPostConditionFailed,
+		Message:            message,
+	}
+}
+
+// PostConditionsMet returns a condition indicating that all post-conditions
+// of the container passed.
+func PostConditionsMet() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePostConditionFailed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPostConditionsMet,
+	}
+}
+
+// TypePostStartFailed indicates whether a post-start command of the container
+// failed when it was last run.
+const TypePostStartFailed xpv1.ConditionType = "PostStartFailed"
+
+// Reasons a container's post-start commands did or did not fail.
+const (
+	ReasonPostStartCommandFailed  xpv1.ConditionReason = "CommandFailed"
+	ReasonPostStartCommandsPassed xpv1.ConditionReason = "CommandsSucceeded"
+)
+
+// PostStartFailed returns a condition indicating that a post-start command of
+// the container failed.
+func PostStartFailed(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePostStartFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPostStartCommandFailed,
+		Message:            message,
+	}
+}
+
+// PostStartSucceeded returns a condition indicating that all post-start
+// commands of the container succeeded.
+func PostStartSucceeded() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePostStartFailed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPostStartCommandsPassed,
+	}
+}
+
+// ReasonSessionExpired indicates that the helper container of a DebugSession
+// was removed once its TTL passed.
+const ReasonSessionExpired xpv1.ConditionReason = "Expired"
+
+// SessionExpired returns a condition indicating that a DebugSession has
+// expired, and is no longer available.
+func SessionExpired() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSessionExpired,
+	}
+}
+
+// Reasons a ContainerOperation is or is not ready once it has completed.
+const (
+	ReasonOperationCompleted xpv1.ConditionReason = "Completed"
+	ReasonOperationFailed    xpv1.ConditionReason = "Failed"
+)
+
+// OperationCompleted returns a condition indicating that a ContainerOperation
+// succeeded on every container it selected.
+func OperationCompleted(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOperationCompleted,
+		Message:            message,
+	}
+}
+
+// OperationFailed returns a condition indicating that a ContainerOperation
+// failed on some of the containers it selected.
+func OperationFailed(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOperationFailed,
+		Message:            message,
+	}
+}
+
+// TypeDeferred indicates whether acting on drift of the container has been
+// deferred because its maintenance window is closed.
+const TypeDeferred xpv1.ConditionType = "Deferred"
+
+// Reasons acting on drift of the container is or is not deferred.
+const (
+	ReasonOutsideMaintenanceWindow xpv1.ConditionReason = "OutsideMaintenanceWindow"
+	ReasonNothingDeferred          xpv1.ConditionReason = "NothingDeferred"
+)
+
+// Deferred returns a condition indicating that the container has drifted, and
+// that acting on it waits for its maintenance window to open.
+func Deferred(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeferred,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOutsideMaintenanceWindow,
+		Message:            message,
+	}
+}
+
+// NotDeferred returns a condition indicating that nothing about the container
+// waits for its maintenance window.
+func NotDeferred() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeferred,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNothingDeferred,
+	}
+}
+
+// TypeClockDrifted indicates whether the clock of the container has drifted
+// from the provider's by more than its clock check allows.
+const TypeClockDrifted xpv1.ConditionType = "ClockDrifted"
+
+// Reasons the clock of the container has or has not drifted.
+const (
+	ReasonClockDrifted     xpv1.ConditionReason = "DriftExceeded"
+	ReasonClockInSync      xpv1.ConditionReason = "InSync"
+	ReasonClockCheckFailed xpv1.ConditionReason = "CheckFailed"
+)
+
+// ClockDrifted returns a condition indicating that the clock of the
+// container is further from the provider's than its clock check allows.
+func ClockDrifted(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeClockDrifted,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonClockDrifted,
+		Message:            message,
+	}
+}
+
+// ClockInSync returns a condition indicating that the clock of the container
+// is as close to the provider's as its clock check requires.
+func ClockInSync() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeClockDrifted,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonClockInSync,
+	}
+}
+
+// ClockCheckFailed returns a condition indicating that the clock of the
+// container could not be read.
+func ClockCheckFailed(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeClockDrifted,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonClockCheckFailed,
+		Message:            message,
+	}
+}
+
+// TypeImagePulled indicates whether the image of the container could be
+// pulled when it was last created.
+const TypeImagePulled xpv1.ConditionType = "ImagePulled"
+
+// Reasons the image of the container was or was not pulled.
+const (
+	ReasonImagePulled     xpv1.ConditionReason = "Pulled"
+	ReasonImagePullFailed xpv1.ConditionReason = "PullFailed"
+)
+
+// ImagePulled returns a condition indicating that the image of the
+// container was pulled.
+func ImagePulled(image string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeImagePulled,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImagePulled,
+		Message:            "pulled " + image,
+	}
+}
+
+// ImagePullFailed returns a condition indicating that the image of the
+// container could not be pulled.
+func ImagePullFailed(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeImagePulled,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImagePullFailed,
+		Message:            message,
+	}
+}
+
+// TypeUnsupportedOnHost indicates whether the container requests features
+// its Docker host does not support.
+const TypeUnsupportedOnHost xpv1.ConditionType = "UnsupportedOnHost"
+
+// Reasons the container is or is not supported on its Docker host.
+const (
+	ReasonUnsupportedOnHost xpv1.ConditionReason = "UnsupportedFeatures"
+	ReasonSupportedOnHost   xpv1.ConditionReason = "FeaturesSupported"
+)
+
+// UnsupportedOnHost returns a condition indicating that the container
+// requests features its Docker host does not support.
+func UnsupportedOnHost(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUnsupportedOnHost,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnsupportedOnHost,
+		Message:            message,
+	}
+}
+
+// SupportedOnHost returns a condition indicating that the Docker host of the
+// container supports the features it requests.
+func SupportedOnHost() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUnsupportedOnHost,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSupportedOnHost,
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A ContainerAction is an action performed on containers in bulk.
+// +kubebuilder:validation:Enum=Restart;Stop;Pause
+type ContainerAction string
+
+// Container actions.
+const (
+	ContainerActionRestart ContainerAction = "Restart"
+	ContainerActionStop    ContainerAction = "Stop"
+	ContainerActionPause   ContainerAction = "Pause"
+)
+
+// A ContainerOperationSpec defines the desired state of a ContainerOperation.
+type ContainerOperationSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+
+	// ForProvider contains the provider-specific configuration.
+	ForProvider ContainerOperationParameters `json:"forProvider"`
+}
+
+// ContainerOperationParameters are the configurable fields of a
+// ContainerOperation.
+type ContainerOperationParameters struct {
+	// Action performed on each container selected.
+	Action ContainerAction `json:"action"`
+
+	// Selector selects the containers the action is performed on by their
+	// Docker labels. Only containers the provider manages, on the Docker
+	// host of the operation's ProviderConfig, are selected.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// StopTimeout is how long a container is given to stop, when it is
+	// restarted or stopped, before it is killed. Defaults to the stop
+	// timeout the container was created with.
+	// +optional
+	StopTimeout *metav1.Duration `json:"stopTimeout,omitempty"`
+}
+
+// A ContainerOperationStatus represents the observed state of a
+// ContainerOperation.
+type ContainerOperationStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+
+	// AtProvider contains the observed state of the ContainerOperation.
+	AtProvider ContainerOperationObservation `json:"atProvider,omitempty"`
+}
+
+// ContainerOperationObservation are the observable fields of a
+// ContainerOperation.
+type ContainerOperationObservation struct {
+	// Targets are the results of the action on each container selected.
+	// +optional
+	Targets []OperationTarget `json:"targets,omitempty"`
+
+	// Succeeded is how many containers the action succeeded on.
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// Failed is how many containers the action failed on.
+	Failed int32 `json:"failed,omitempty"`
+
+	// CompletedAt is when the action was performed. An operation that has
+	// completed is not performed again.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// An OperationTarget is the result of an action on a container.
+type OperationTarget struct {
+	// Name of the container.
+	Name string `json:"name"`
+
+	// ID of the container.
+	ID string `json:"id"`
+
+	// Succeeded is true if the action succeeded.
+	Succeeded bool `json:"succeeded"`
+
+	// Error is why the action failed.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ContainerOperation is a managed resource that represents a one-shot
+// action, such as a restart, performed on every managed container of a
+// Docker host that matches a label selector.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ACTION",type="string",JSONPath=".spec.forProvider.action"
+// +kubebuilder:printcolumn:name="SUCCEEDED",type="integer",JSONPath=".status.atProvider.succeeded"
+// +kubebuilder:printcolumn:name="FAILED",type="integer",JSONPath=".status.atProvider.failed"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="COMPLETED",type="date",JSONPath=".status.atProvider.completedAt",priority=1
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,docker}
+type ContainerOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ContainerOperationSpec   `json:"spec"`
+	Status ContainerOperationStatus `json:"status,omitempty"`
+}
+
+// GetCondition returns the condition for the given ConditionType.
+func (cr *ContainerOperation) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return cr.Status.GetCondition(ct)
+}
+
+// SetConditions sets the conditions on the resource.
+func (cr *ContainerOperation) SetConditions(c ...xpv1.Condition) {
+	cr.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies of the resource.
+func (cr *ContainerOperation) GetManagementPolicies() xpv1.ManagementPolicies {
+	return cr.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies of the resource.
+func (cr *ContainerOperation) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	cr.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference returns the ProviderConfigReference field.
+func (cr *ContainerOperation) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return cr.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference sets the ProviderConfigReference field.
+func (cr *ContainerOperation) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	cr.Spec.ProviderConfigReference = p
+}
+
+// +kubebuilder:object:root=true
+
+// ContainerOperationList contains a list of ContainerOperation.
+type ContainerOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ContainerOperation `json:"items"`
+}
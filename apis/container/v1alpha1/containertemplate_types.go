@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A ContainerTemplateSpec defines the configuration shared by the Containers
+// created from a ContainerTemplate.
+type ContainerTemplateSpec struct {
+	// Template is the configuration of the Containers created from the
+	// template. Its name and fromTemplateRef are ignored: each Container is
+	// named on its own, and templates do not nest.
+	Template ContainerParameters `json:"template"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ContainerTemplate is the configuration shared by a fleet of similar
+// Containers, which refer to it with spec.forProvider.fromTemplateRef and
+// override it where they differ.
+// +kubebuilder:printcolumn:name="IMAGE",type="string",JSONPath=".spec.template.image"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,docker}
+type ContainerTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ContainerTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ContainerTemplateList contains a list of ContainerTemplate.
+type ContainerTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ContainerTemplate `json:"items"`
+}
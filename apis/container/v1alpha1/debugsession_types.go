@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A DebugSessionSpec defines the desired state of a DebugSession.
+type DebugSessionSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+
+	// ForProvider contains the provider-specific configuration.
+	ForProvider DebugSessionParameters `json:"forProvider"`
+}
+
+// DebugSessionParameters are the configurable fields of a DebugSession.
+type DebugSessionParameters struct {
+	// Target is the name or ID of the Docker container to debug. One of
+	// Target or TargetRef must be set.
+	// +optional
+	Target *string `json:"target,omitempty"`
+
+	// TargetRef references the Container, in the same namespace, to debug.
+	// +optional
+	TargetRef *xpv1.Reference `json:"targetRef,omitempty"`
+
+	// Image of the helper container, holding the tools to debug with.
+	Image string `json:"image"`
+
+	// Command run by the helper container. By default it sleeps for the
+	// TTL of the session, so that it can be attached to with docker exec.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// ShareNetwork runs the helper container in the network namespace of
+	// the target.
+	// +kubebuilder:default=true
+	// +optional
+	ShareNetwork *bool `json:"shareNetwork,omitempty"`
+
+	// SharePID runs the helper container in the PID namespace of the
+	// target, so that its processes can be inspected.
+	// +kubebuilder:default=true
+	// +optional
+	SharePID *bool `json:"sharePID,omitempty"`
+
+	// TTL is how long the helper container runs before it is removed.
+	// +kubebuilder:default="1h"
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// A DebugSessionStatus represents the observed state of a DebugSession.
+type DebugSessionStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+
+	// AtProvider contains the observed state of the DebugSession.
+	AtProvider DebugSessionObservation `json:"atProvider,omitempty"`
+}
+
+// DebugSessionObservation are the observable fields of a DebugSession.
+type DebugSessionObservation struct {
+	// ID is the ID of the helper container.
+	ID string `json:"id,omitempty"`
+
+	// TargetID is the ID of the container being debugged.
+	TargetID string `json:"targetID,omitempty"`
+
+	// ExpiresAt is when the helper container is removed.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Expired is true once the helper container has been removed after its
+	// TTL. An expired session is not started again.
+	Expired bool `json:"expired,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DebugSession is a managed resource that represents a short-lived helper
+// container sharing the namespaces of a container, with tools to debug it.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane.io/external-name"
+// +kubebuilder:printcolumn:name="EXPIRES",type="date",JSONPath=".status.atProvider.expiresAt"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="IMAGE",type="string",JSONPath=".spec.forProvider.image",priority=1
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,docker}
+type DebugSession struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DebugSessionSpec   `json:"spec"`
+	Status DebugSessionStatus `json:"status,omitempty"`
+}
+
+// GetCondition returns the condition for the given ConditionType.
+func (cr *DebugSession) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return cr.Status.GetCondition(ct)
+}
+
+// SetConditions sets the conditions on the resource.
+func (cr *DebugSession) SetConditions(c ...xpv1.Condition) {
+	cr.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies of the resource.
+func (cr *DebugSession) GetManagementPolicies() xpv1.ManagementPolicies {
+	return cr.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies of the resource.
+func (cr *DebugSession) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	cr.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference returns the ProviderConfigReference field.
+func (cr *DebugSession) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return cr.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference sets the ProviderConfigReference field.
+func (cr *DebugSession) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	cr.Spec.ProviderConfigReference = p
+}
+
+// +kubebuilder:object:root=true
+
+// DebugSessionList contains a list of DebugSession.
+type DebugSessionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DebugSession `json:"items"`
+}
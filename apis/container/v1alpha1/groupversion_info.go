@@ -40,6 +40,16 @@ func addKnownTypes(s *runtime.Scheme) error {
 	s.AddKnownTypes(SchemeGroupVersion,
 		&Container{},
 		&ContainerList{},
+		&ContainerOperation{},
+		&ContainerOperationList{},
+		&ContainerTemplate{},
+		&ContainerTemplateList{},
+		&DebugSession{},
+		&DebugSessionList{},
+		&Image{},
+		&ImageList{},
+		&ImagePrefetch{},
+		&ImagePrefetchList{},
 	)
 	return nil
 }
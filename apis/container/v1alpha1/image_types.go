@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// An ImagePullPolicy says when an image is pulled.
+// +kubebuilder:validation:Enum=Always;IfNotPresent
+type ImagePullPolicy string
+
+// Image pull policies.
+const (
+	// PullAlways pulls the image again every refresh interval, so that a
+	// tag that has moved is updated.
+	PullAlways ImagePullPolicy = "Always"
+
+	// PullIfNotPresent pulls the image only if it is not on the host.
+	PullIfNotPresent ImagePullPolicy = "IfNotPresent"
+)
+
+// An ImageSpec defines the desired state of an Image.
+type ImageSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+
+	// ForProvider contains the provider-specific configuration.
+	ForProvider ImageParameters `json:"forProvider"`
+}
+
+// ImageParameters are the configurable fields of an Image.
+type ImageParameters struct {
+	// Image is the reference of the image, such as nginx:1.27.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// Digest pins the image to the content with this digest, such as
+	// sha256:0123.... The image is pulled by digest rather than by tag.
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	// +optional
+	Digest *string `json:"digest,omitempty"`
+
+	// PullPolicy says when the image is pulled: IfNotPresent pulls it only
+	// if it is not on the host, and Always pulls it again every
+	// refreshInterval as well.
+	// +kubebuilder:default=IfNotPresent
+	// +optional
+	PullPolicy *ImagePullPolicy `json:"pullPolicy,omitempty"`
+
+	// RefreshInterval is how often an image with the Always pull policy is
+	// pulled again.
+	// +kubebuilder:default="24h"
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// RemoveOnDelete removes the image from the host when the Image is
+	// deleted. An image in use by a container is kept.
+	// +optional
+	RemoveOnDelete *bool `json:"removeOnDelete,omitempty"`
+}
+
+// An ImageStatus represents the observed state of an Image.
+type ImageStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+
+	// AtProvider contains the observed state of the Image.
+	AtProvider ImageObservation `json:"atProvider,omitempty"`
+}
+
+// ImageObservation are the observable fields of an Image.
+type ImageObservation struct {
+	// ID is the ID of the image on the host.
+	ID string `json:"id,omitempty"`
+
+	// Digest is the digest of the image in its repository.
+	Digest string `json:"digest,omitempty"`
+
+	// Size is the size of the image on the host, in bytes.
+	Size int64 `json:"size,omitempty"`
+
+	// Layers is the number of layers of the image.
+	Layers int `json:"layers,omitempty"`
+
+	// PulledAt is when the image was last pulled.
+	PulledAt *metav1.Time `json:"pulledAt,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An Image is a managed resource that keeps an image pulled on a Docker
+// host.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="IMAGE",type="string",JSONPath=".spec.forProvider.image"
+// +kubebuilder:printcolumn:name="DIGEST",type="string",JSONPath=".status.atProvider.digest",priority=1
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".status.atProvider.size",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,docker}
+type Image struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ImageSpec   `json:"spec"`
+	Status ImageStatus `json:"status,omitempty"`
+}
+
+// GetCondition returns the condition for the given ConditionType.
+func (cr *Image) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return cr.Status.GetCondition(ct)
+}
+
+// SetConditions sets the conditions on the resource.
+func (cr *Image) SetConditions(c ...xpv1.Condition) {
+	cr.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies of the resource.
+func (cr *Image) GetManagementPolicies() xpv1.ManagementPolicies {
+	return cr.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies of the resource.
+func (cr *Image) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	cr.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference returns the ProviderConfigReference field.
+func (cr *Image) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return cr.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference sets the ProviderConfigReference field.
+func (cr *Image) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	cr.Spec.ProviderConfigReference = p
+}
+
+// +kubebuilder:object:root=true
+
+// ImageList contains a list of Image.
+type ImageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Image `json:"items"`
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// An ImagePrefetchSpec defines the desired state of an ImagePrefetch.
+type ImagePrefetchSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+
+	// ForProvider contains the provider-specific configuration.
+	ForProvider ImagePrefetchParameters `json:"forProvider"`
+}
+
+// ImagePrefetchParameters are the configurable fields of an ImagePrefetch.
+type ImagePrefetchParameters struct {
+	// Images are the references of the images to keep pulled on the host.
+	// +kubebuilder:validation:MinItems=1
+	Images []string `json:"images"`
+
+	// RefreshInterval is how often the images are pulled again, so that
+	// tags that have moved are updated.
+	// +kubebuilder:default="24h"
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// RemoveOnDelete removes the images from the host when the
+	// ImagePrefetch is deleted. Images in use by a container are kept.
+	// +optional
+	RemoveOnDelete *bool `json:"removeOnDelete,omitempty"`
+}
+
+// An ImagePrefetchStatus represents the observed state of an ImagePrefetch.
+type ImagePrefetchStatus struct {
+	xpv1.ManagedResourceStatus `json:",inline"`
+
+	// AtProvider contains the observed state of the ImagePrefetch.
+	AtProvider ImagePrefetchObservation `json:"atProvider,omitempty"`
+}
+
+// ImagePrefetchObservation are the observable fields of an ImagePrefetch.
+type ImagePrefetchObservation struct {
+	// Images are the images observed on the host, in the order of the spec.
+	Images []PrefetchedImage `json:"images,omitempty"`
+
+	// Present is how many of the images are present on the host.
+	Present int `json:"present,omitempty"`
+
+	// RefreshedAt is when the images were last pulled.
+	RefreshedAt *metav1.Time `json:"refreshedAt,omitempty"`
+}
+
+// A PrefetchedImage is an image observed on the host.
+type PrefetchedImage struct {
+	// Image is the reference of the image.
+	Image string `json:"image"`
+
+	// ID is the ID of the image on the host, if it is present.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Present is true if the image is on the host.
+	Present bool `json:"present"`
+}
+
+// +kubebuilder:object:root=true
+
+// An ImagePrefetch is a managed resource that keeps a set of images pulled
+// on a Docker host, so that they are present before the workloads that use
+// them are scheduled.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PRESENT",type="integer",JSONPath=".status.atProvider.present"
+// +kubebuilder:printcolumn:name="REFRESHED",type="date",JSONPath=".status.atProvider.refreshedAt"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,docker}
+type ImagePrefetch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ImagePrefetchSpec   `json:"spec"`
+	Status ImagePrefetchStatus `json:"status,omitempty"`
+}
+
+// GetCondition returns the condition for the given ConditionType.
+func (cr *ImagePrefetch) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return cr.Status.GetCondition(ct)
+}
+
+// SetConditions sets the conditions on the resource.
+func (cr *ImagePrefetch) SetConditions(c ...xpv1.Condition) {
+	cr.Status.SetConditions(c...)
+}
+
+// GetManagementPolicies returns the management policies of the resource.
+func (cr *ImagePrefetch) GetManagementPolicies() xpv1.ManagementPolicies {
+	return cr.Spec.ManagementPolicies
+}
+
+// SetManagementPolicies sets the management policies of the resource.
+func (cr *ImagePrefetch) SetManagementPolicies(p xpv1.ManagementPolicies) {
+	cr.Spec.ManagementPolicies = p
+}
+
+// GetProviderConfigReference returns the ProviderConfigReference field.
+func (cr *ImagePrefetch) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return cr.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference sets the ProviderConfigReference field.
+func (cr *ImagePrefetch) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	cr.Spec.ProviderConfigReference = p
+}
+
+// +kubebuilder:object:root=true
+
+// ImagePrefetchList contains a list of ImagePrefetch.
+type ImagePrefetchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ImagePrefetch `json:"items"`
+}
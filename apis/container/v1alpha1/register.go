@@ -30,6 +30,46 @@ var (
 	ContainerGroupVersionKind = SchemeGroupVersion.WithKind(ContainerKind)
 )
 
+// ContainerOperation type metadata.
+var (
+	ContainerOperationKind             = reflect.TypeOf(ContainerOperation{}).Name()
+	ContainerOperationGroupKind        = schema.GroupKind{Group: Group, Kind: ContainerOperationKind}
+	ContainerOperationKindAPIVersion   = ContainerOperationKind + "." + SchemeGroupVersion.String()
+	ContainerOperationGroupVersionKind = SchemeGroupVersion.WithKind(ContainerOperationKind)
+)
+
+// ContainerTemplate type metadata.
+var (
+	ContainerTemplateKind             = reflect.TypeOf(ContainerTemplate{}).Name()
+	ContainerTemplateGroupKind        = schema.GroupKind{Group: Group, Kind: ContainerTemplateKind}
+	ContainerTemplateKindAPIVersion   = ContainerTemplateKind + "." + SchemeGroupVersion.String()
+	ContainerTemplateGroupVersionKind = SchemeGroupVersion.WithKind(ContainerTemplateKind)
+)
+
+// DebugSession type metadata.
+var (
+	DebugSessionKind             = reflect.TypeOf(DebugSession{}).Name()
+	DebugSessionGroupKind        = schema.GroupKind{Group: Group, Kind: DebugSessionKind}
+	DebugSessionKindAPIVersion   = DebugSessionKind + "." + SchemeGroupVersion.String()
+	DebugSessionGroupVersionKind = SchemeGroupVersion.WithKind(DebugSessionKind)
+)
+
+// Image type metadata.
+var (
+	ImageKind             = reflect.TypeOf(Image{}).Name()
+	ImageGroupKind        = schema.GroupKind{Group: Group, Kind: ImageKind}
+	ImageKindAPIVersion   = ImageKind + "." + SchemeGroupVersion.String()
+	ImageGroupVersionKind = SchemeGroupVersion.WithKind(ImageKind)
+)
+
+// ImagePrefetch type metadata.
+var (
+	ImagePrefetchKind             = reflect.TypeOf(ImagePrefetch{}).Name()
+	ImagePrefetchGroupKind        = schema.GroupKind{Group: Group, Kind: ImagePrefetchKind}
+	ImagePrefetchKindAPIVersion   = ImagePrefetchKind + "." + SchemeGroupVersion.String()
+	ImagePrefetchGroupVersionKind = SchemeGroupVersion.WithKind(ImagePrefetchKind)
+)
+
 // EnvVar type metadata.
 var (
 	EnvVarKind             = reflect.TypeOf(EnvVar{}).Name()
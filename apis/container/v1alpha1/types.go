@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-docker/pkg/labels"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -32,9 +33,39 @@ type ContainerSpec struct {
 
 // ContainerParameters are the configurable fields of a Container.
 type ContainerParameters struct {
-	// Image is the Docker image to run.
+	// FromTemplateRef references a ContainerTemplate, in the same namespace,
+	// that the container is created from. Fields set on the container
+	// override those of the template; its environment variables, ports,
+	// volumes, networks, post-start commands, post-conditions and
+	// dependencies are merged with the template's.
+	// +optional
+	FromTemplateRef *xpv1.Reference `json:"fromTemplateRef,omitempty"`
+
+	// DependsOn lists managed resources the container waits for. It is
+	// not created, and is Pending, until each of them is Ready.
+	// +optional
+	DependsOn []Dependency `json:"dependsOn,omitempty"`
+
+	// Image is the Docker image to run. It must be set here or by the
+	// container's template.
 	// Examples: nginx:1.21, alpine:latest, ubuntu:20.04
-	Image string `json:"image"`
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy says when the image is pulled with the registry
+	// credentials of the ProviderConfig: IfNotPresent, the default, pulls
+	// it only if it is not on the host, and Always pulls it every time the
+	// container is created, so that a tag that has moved is picked up.
+	// +optional
+	ImagePullPolicy *ImagePullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Platform is the platform of the image to run, such as linux/arm64 or
+	// linux/arm/v7, for a host that runs images of other architectures
+	// with emulation. Defaults to the platform of the Docker host. It only
+	// takes effect when the container is created.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+/[a-z0-9_]+(/[a-z0-9]+)?$`
+	// +optional
+	Platform *string `json:"platform,omitempty"`
 
 	// Name is the container name. If not specified, a name will be generated.
 	// +optional
@@ -95,6 +126,14 @@ type ContainerParameters struct {
 	// +optional
 	ExtraHosts []string `json:"extraHosts,omitempty"`
 
+	// BridgePeers are other containers on the same Docker host that this
+	// container resolves by name. Docker provides no name resolution
+	// between containers on its default bridge network, so an /etc/hosts
+	// entry is added for the address of each peer that is running. The
+	// container is out of date whenever a peer's address changes.
+	// +optional
+	BridgePeers []BridgePeer `json:"bridgePeers,omitempty"`
+
 	// DNS configuration for the container.
 	// +optional
 	DNS []string `json:"dns,omitempty"`
@@ -111,26 +150,112 @@ type ContainerParameters struct {
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
 
+	// LogConfig configures the log driver of the container, such as
+	// json-file with rotation, syslog, fluentd or journald.
+	// +optional
+	LogConfig *LogConfig `json:"logConfig,omitempty"`
+
 	// Resources specify compute resource requirements.
 	// +optional
 	Resources *ResourceRequirements `json:"resources,omitempty"`
 
+	// StorageOpt are options of the storage driver for the container, such
+	// as size=20G to cap the size of its writable layer. Only some storage
+	// drivers support them, such as overlay2 on XFS mounted with pquota,
+	// btrfs and zfs; creating the container fails on other hosts.
+	// +optional
+	StorageOpt map[string]string `json:"storageOpt,omitempty"`
+
+	// Runtime is the OCI runtime the container runs with, such as runsc
+	// or nvidia, which must be configured on the Docker host. Defaults to
+	// the host's default runtime.
+	// +optional
+	Runtime *string `json:"runtime,omitempty"`
+
+	// UsernsMode is the user namespace of the container. host runs it in
+	// the host's user namespace, opting it out of the Docker daemon's user
+	// namespace remapping; remap requires the daemon to remap it, so that
+	// root in the container is unprivileged on the host. Defaults to
+	// whatever the daemon does.
+	// +kubebuilder:validation:Enum=host;remap
+	// +optional
+	UsernsMode *string `json:"usernsMode,omitempty"`
+
+	// Bandwidth limits the container's network throughput. Docker has no
+	// native network rate limiting, so the limits are applied with tc by a
+	// short-lived helper container that joins the container's network
+	// namespace once it has started.
+	// +optional
+	Bandwidth *BandwidthLimits `json:"bandwidth,omitempty"`
+
 	// SecurityContext defines security attributes.
 	// +optional
 	SecurityContext *SecurityContext `json:"securityContext,omitempty"`
 
+	// SecurityProfile applies a preset of security settings underneath
+	// SecurityContext; fields set explicitly in SecurityContext win.
+	// restricted drops all capabilities, disallows privilege escalation,
+	// mounts the root filesystem read-only and applies the runtime default
+	// seccomp profile. baseline disallows
+	// privilege escalation and applies the runtime default seccomp profile.
+	// privileged applies no restrictions.
+	// +kubebuilder:validation:Enum=restricted;baseline;privileged
+	// +optional
+	SecurityProfile *string `json:"securityProfile,omitempty"`
+
 	// HealthCheck defines health checking configuration.
 	// +optional
 	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
 
-	// Init specifies if this is an init container.
+	// Readiness defines when a running container is considered ready, for
+	// images whose readiness cannot be detected by a health check.
+	// +optional
+	Readiness *Readiness `json:"readiness,omitempty"`
+
+	// ReadinessProbe is checked each time the running container is
+	// observed, once any readiness log line has been logged. The container
+	// is not available while the probe fails.
+	// +optional
+	ReadinessProbe *ReadinessProbe `json:"readinessProbe,omitempty"`
+
+	// Init runs an init process as PID 1 inside the container, which
+	// forwards signals and reaps zombie processes.
 	// +optional
 	Init *bool `json:"init,omitempty"`
 
+	// StopSignal is the signal sent to the container to stop it. Defaults
+	// to the image's stop signal, usually SIGTERM.
+	// +optional
+	StopSignal *string `json:"stopSignal,omitempty"`
+
+	// StopGracePeriod is how long the container has to exit after
+	// StopSignal before it is killed. Defaults to 10s.
+	// +optional
+	StopGracePeriod *metav1.Duration `json:"stopGracePeriod,omitempty"`
+
 	// Privileged runs the container in privileged mode.
 	// +optional
 	Privileged *bool `json:"privileged,omitempty"`
 
+	// DeviceCgroupRules are rules added to the container's device cgroup,
+	// allowing it access to host devices without running it privileged,
+	// such as "c 189:* rmw" for USB devices. Each rule is a device type
+	// (a, b or c), a major:minor number, either of which may be *, and
+	// the access allowed (r, w and m).
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^[abc] ([0-9]+|\*):([0-9]+|\*) [rwm]{1,3}$`
+	DeviceCgroupRules []string `json:"deviceCgroupRules,omitempty"`
+
+	// Devices are host devices added to the container, such as serial
+	// ports or video capture devices.
+	// +optional
+	Devices []DeviceMapping `json:"devices,omitempty"`
+
+	// DeviceRequests request devices, such as GPUs, from a device driver of
+	// the Docker host, as docker run --gpus does.
+	// +optional
+	DeviceRequests []DeviceRequest `json:"deviceRequests,omitempty"`
+
 	// Remove automatically removes the container when it exits.
 	// +optional
 	Remove *bool `json:"remove,omitempty"`
@@ -139,6 +264,362 @@ type ContainerParameters struct {
 	// Defaults to true.
 	// +optional
 	StartOnCreate *bool `json:"startOnCreate,omitempty"`
+
+	// Failover recreates the container on a standby Docker host when its
+	// own host has been unreachable for a while.
+	// +optional
+	Failover *Failover `json:"failover,omitempty"`
+
+	// Remediation repairs the container when its health check keeps
+	// reporting it unhealthy.
+	// +optional
+	Remediation *Remediation `json:"remediation,omitempty"`
+
+	// UpdateStrategy is how the container is brought back in line with its
+	// spec when it has drifted. InPlace changes its restart policy and resource
+	// limits while it runs, and fails when anything else has changed. Recreate
+	// does the same, but replaces the container when anything else has changed.
+	// Never leaves a drifted container as it is.
+	// +kubebuilder:default="Recreate"
+	// +optional
+	UpdateStrategy *UpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// MaintenanceWindow constrains when the container may be disruptively
+	// recreated. Outside the window drift is still reported, but acting on
+	// it is deferred until the window opens.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// TerminationMessage captures why the container exited in its status.
+	// +optional
+	TerminationMessage *TerminationMessage `json:"terminationMessage,omitempty"`
+
+	// CaptureLogs captures the last lines of the container's logs in its
+	// status, and in an event, when it exits non-zero or becomes unhealthy.
+	// +optional
+	CaptureLogs *CaptureLogs `json:"captureLogs,omitempty"`
+
+	// PostStartCommands are run in order inside the container each time it
+	// starts, once it has logged its readiness line if it waits for one,
+	// for tasks such as schema migrations and bootstrapping. A command that
+	// fails is retried with backoff. The container is not ready, and its
+	// post-conditions are not checked, until they have all succeeded.
+	// +optional
+	PostStartCommands []PostStartCommand `json:"postStartCommands,omitempty"`
+
+	// PostConditions are checked inside the container each time it starts,
+	// until they all pass. The container is not ready while any fails.
+	// +optional
+	PostConditions []PostCondition `json:"postConditions,omitempty"`
+
+	// ClockCheck periodically compares the clock of the running container
+	// with the provider's, for workloads sensitive to time such as license
+	// servers. A container whose clock has drifted too far has a true
+	// ClockDrifted condition.
+	// +optional
+	ClockCheck *ClockCheck `json:"clockCheck,omitempty"`
+}
+
+// A Dependency is a managed resource a container waits for.
+type Dependency struct {
+	// Kind of the managed resource.
+	// +kubebuilder:validation:Enum=Container;Volume;Network
+	Kind string `json:"kind"`
+
+	// Name of the managed resource. Containers are looked up in the
+	// namespace of the container; Volumes and Networks are cluster scoped.
+	Name string `json:"name"`
+}
+
+// A BridgePeer is a container resolved by name through /etc/hosts.
+type BridgePeer struct {
+	// Container is the name or ID of the peer container.
+	Container string `json:"container"`
+
+	// Hostname the peer is resolved as. Defaults to Container.
+	// +optional
+	Hostname *string `json:"hostname,omitempty"`
+}
+
+// A ClockCheck compares the clock of a container with the provider's by
+// running date +%s in the container, which its image must provide.
+type ClockCheck struct {
+	// MaxDrift is how far the clock of the container may be from the
+	// provider's. Clocks are compared to the second. Defaults to 5s.
+	// +optional
+	MaxDrift *metav1.Duration `json:"maxDrift,omitempty"`
+
+	// Interval is how often the clocks are compared. Defaults to 5m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// A PostCondition is a check run inside a started container. Exactly one of
+// FileExists, Command and PortListening must be set.
+type PostCondition struct {
+	// Name identifies the check when it fails.
+	Name string `json:"name"`
+
+	// FileExists is the path of a file or directory that must exist in the
+	// container.
+	// +optional
+	FileExists *string `json:"fileExists,omitempty"`
+
+	// Command must exit zero when run in the container.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// PortListening is a TCP port that must be listening in the container.
+	// It is checked with sh and grep, which the image must provide.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	PortListening *int32 `json:"portListening,omitempty"`
+
+	// Timeout is how long the check may run. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// A PostStartCommand is a command run inside a started container.
+type PostStartCommand struct {
+	// Name identifies the command in the status of the container.
+	Name string `json:"name"`
+
+	// Command to run, with its arguments.
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// Timeout is how long the command may run. Defaults to 1m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retries is how many times the command is run again after failing,
+	// before it is given up on until the container next starts. Defaults
+	// to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+
+	// Backoff is how long the first retry waits. Each retry waits twice as
+	// long as the one before, up to 5m. Defaults to 10s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// TerminationMessage defines how the reason a container exited is captured,
+// like the termination message of a Kubernetes container.
+type TerminationMessage struct {
+	// Path is a file inside the container the message is read from, like
+	// terminationMessagePath. The tail of the container's logs is used
+	// when it is unset, or the file is missing or empty.
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// MaxBytes is the most of the message that is kept. Defaults to 4096.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16384
+	// +optional
+	MaxBytes *int32 `json:"maxBytes,omitempty"`
+}
+
+// CaptureLogs defines how the logs of a failed container are captured.
+type CaptureLogs struct {
+	// TailLines is how many of the last lines of the logs are captured.
+	// Defaults to 50.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=500
+	// +optional
+	TailLines *int32 `json:"tailLines,omitempty"`
+
+	// MaxBytes is the most of those lines that is kept. Defaults to 4096.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16384
+	// +optional
+	MaxBytes *int32 `json:"maxBytes,omitempty"`
+}
+
+// UpdateStrategy is how a drifted container is updated.
+// +kubebuilder:validation:Enum=Recreate;InPlace;Never
+type UpdateStrategy string
+
+// Update strategies.
+const (
+	// UpdateRecreate updates a container in place where it can, and
+	// otherwise removes it and creates it afresh.
+	UpdateRecreate UpdateStrategy = "Recreate"
+
+	// UpdateInPlace only updates a container in place.
+	UpdateInPlace UpdateStrategy = "InPlace"
+
+	// UpdateNever never updates a container.
+	UpdateNever UpdateStrategy = "Never"
+)
+
+// RemediationAction is what is done to repair an unhealthy container.
+// +kubebuilder:validation:Enum=None;Restart;Recreate
+type RemediationAction string
+
+// Remediation actions.
+const (
+	// RemediationNone leaves an unhealthy container as it is.
+	RemediationNone RemediationAction = "None"
+
+	// RemediationRestart restarts an unhealthy container.
+	RemediationRestart RemediationAction = "Restart"
+
+	// RemediationRecreate removes an unhealthy container and creates it
+	// afresh.
+	RemediationRecreate RemediationAction = "Recreate"
+)
+
+// A MaintenanceWindow is a recurring period in which disruptive operations
+// on a resource may run.
+type MaintenanceWindow struct {
+	// Schedule is a standard five-field cron expression for when each
+	// window opens, such as "0 2 * * 6" for 02:00 every Saturday.
+	Schedule string `json:"schedule"`
+
+	// Duration is how long each window stays open.
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone of the schedule. Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
+// Remediation defines how an unhealthy container is repaired.
+type Remediation struct {
+	// OnUnhealthy is done once the container's health check has reported
+	// it unhealthy for UnhealthyThreshold consecutive observations.
+	// +kubebuilder:default=None
+	// +optional
+	OnUnhealthy RemediationAction `json:"onUnhealthy,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive observations the
+	// container must be unhealthy for before it is repaired. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	UnhealthyThreshold *int32 `json:"unhealthyThreshold,omitempty"`
+}
+
+// Readiness defines when a running container is considered ready.
+type Readiness struct {
+	// WaitForLogLine considers the container ready once it has logged a
+	// line matching a pattern since it started.
+	// +optional
+	WaitForLogLine *WaitForLogLine `json:"waitForLogLine,omitempty"`
+}
+
+// WaitForLogLine waits for a container to log a line matching a pattern.
+type WaitForLogLine struct {
+	// Pattern is a regular expression matched against each line the
+	// container writes to stdout or stderr.
+	Pattern string `json:"pattern"`
+
+	// Timeout is how long after starting the container must log a matching
+	// line before it is reported as degraded. Defaults to 5m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// A ProbeResult is the result of checking a readiness probe.
+type ProbeResult struct {
+	// Ready is whether the probe passed.
+	Ready bool `json:"ready"`
+
+	// Message explains why the probe failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastProbeTime is when the probe was checked.
+	LastProbeTime metav1.Time `json:"lastProbeTime"`
+}
+
+// A ReadinessProbe checks whether a running container is ready. Exactly one
+// of Exec, HTTPGet and TCPSocket must be set.
+type ReadinessProbe struct {
+	// Exec runs a command in the container, which is ready when it exits
+	// zero.
+	// +optional
+	Exec *ExecProbe `json:"exec,omitempty"`
+
+	// HTTPGet sends an HTTP GET request to the container, which is ready
+	// when it responds with a status from 200 to 399.
+	// +optional
+	HTTPGet *HTTPGetProbe `json:"httpGet,omitempty"`
+
+	// TCPSocket opens a TCP connection to the container, which is ready
+	// when the connection is accepted.
+	// +optional
+	TCPSocket *TCPSocketProbe `json:"tcpSocket,omitempty"`
+
+	// InitialDelay is how long after the container starts it is first
+	// probed. Until then it is not ready.
+	// +optional
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+
+	// Timeout is how long the probe may take. Defaults to 1s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// An ExecProbe runs a command in a container.
+type ExecProbe struct {
+	// Command to run. It is not run in a shell.
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+}
+
+// An HTTPGetProbe sends an HTTP GET request to a container.
+type HTTPGetProbe struct {
+	// Path requested. Defaults to /.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Port of the container the request is sent to.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Scheme of the request. The certificate of an HTTPS container is not
+	// verified.
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	// +kubebuilder:default="HTTP"
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+
+	// Host the request is sent to. Defaults to the IP address of the
+	// container, which the provider must be able to reach.
+	// +optional
+	Host *string `json:"host,omitempty"`
+}
+
+// A TCPSocketProbe opens a TCP connection to a container.
+type TCPSocketProbe struct {
+	// Port of the container the connection is opened to.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Host the connection is opened to. Defaults to the IP address of the
+	// container, which the provider must be able to reach.
+	// +optional
+	Host *string `json:"host,omitempty"`
+}
+
+// Failover configures failing a container over to a standby Docker host.
+// Failing over is one way: the container stays on the standby host until
+// the failover block is removed.
+type Failover struct {
+	// StandbyProviderConfigRef refers to the ProviderConfig of the Docker
+	// host to recreate the container on.
+	StandbyProviderConfigRef xpv1.ProviderConfigReference `json:"standbyProviderConfigRef"`
+
+	// UnreachableFor is how long the container's own host must have been
+	// unreachable before the container fails over. Defaults to 5m.
+	// +optional
+	UnreachableFor *metav1.Duration `json:"unreachableFor,omitempty"`
 }
 
 // EnvVar represents an environment variable.
@@ -164,6 +645,33 @@ type EnvVarSource struct {
 	// ConfigMapKeyRef selects a key of a ConfigMap in the same namespace.
 	// +optional
 	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// StoreKeyRef selects a key from a value store registered with the
+	// provider, such as "secret" or "env".
+	// +optional
+	StoreKeyRef *StoreKeySelector `json:"storeKeyRef,omitempty"`
+}
+
+// StoreKeySelector selects a key from a value store registered with the
+// provider.
+type StoreKeySelector struct {
+	// Store is the name of the value store, for example "secret" for a
+	// Kubernetes Secret in the same namespace or "env" for the provider's
+	// DOCKER_ENV_ prefixed environment variables.
+	Store string `json:"store"`
+
+	// Name of the object within the store holding the key, such as the
+	// name of the Secret. Stores that are not organised into objects
+	// ignore it.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Key to select from the store.
+	Key string `json:"key"`
+
+	// Optional specifies whether the key must exist.
+	// +optional
+	Optional *bool `json:"optional,omitempty"`
 }
 
 // SecretKeySelector selects a key from a Secret.
@@ -240,11 +748,14 @@ type VolumeSource struct {
 	// +optional
 	EmptyDir *EmptyDirVolumeSource `json:"emptyDir,omitempty"`
 
-	// Secret represents a secret that should be mounted.
+	// Secret represents a secret that should be mounted. Its keys are
+	// written as files into a Docker volume of the container's own, which
+	// is mounted read-only, and written again when the Secret changes.
 	// +optional
 	Secret *SecretVolumeSource `json:"secret,omitempty"`
 
-	// ConfigMap represents a configMap that should be mounted.
+	// ConfigMap represents a configMap that should be mounted, as Secret
+	// is.
 	// +optional
 	ConfigMap *ConfigMapVolumeSource `json:"configMap,omitempty"`
 
@@ -352,8 +863,25 @@ type KeyToPath struct {
 
 // VolumeVolumeSource represents a Docker volume.
 type VolumeVolumeSource struct {
-	// VolumeName is the name of the Docker volume.
-	VolumeName string `json:"volumeName"`
+	// VolumeName is the name of the Docker volume. It is set from the
+	// Volume referenced by VolumeRef or selected by VolumeSelector, if
+	// either is used.
+	// +crossplane:generate:reference:type=github.com/rossigee/provider-docker/apis/volume/v1alpha1.Volume
+	// +crossplane:generate:reference:extractor=github.com/rossigee/provider-docker/apis/volume/v1alpha1.VolumeName()
+	// +crossplane:generate:reference:refFieldName=VolumeRef
+	// +crossplane:generate:reference:selectorFieldName=VolumeSelector
+	// +optional
+	VolumeName string `json:"volumeName,omitempty"`
+
+	// VolumeRef references the Volume managed resource whose Docker volume
+	// is mounted. The container waits for the Volume to be created.
+	// +optional
+	VolumeRef *xpv1.Reference `json:"volumeRef,omitempty"`
+
+	// VolumeSelector selects the Volume managed resource whose Docker
+	// volume is mounted, by its labels.
+	// +optional
+	VolumeSelector *xpv1.Selector `json:"volumeSelector,omitempty"`
 }
 
 // BindVolumeSource represents a bind mount.
@@ -369,8 +897,24 @@ type BindVolumeSource struct {
 
 // NetworkAttachment describes how to attach the container to a network.
 type NetworkAttachment struct {
-	// Name of the network.
-	Name string `json:"name"`
+	// Name of the network. It is set from the Network referenced by
+	// NetworkRef or selected by NetworkSelector, if either is used.
+	// +crossplane:generate:reference:type=github.com/rossigee/provider-docker/apis/network/v1alpha1.Network
+	// +crossplane:generate:reference:extractor=github.com/rossigee/provider-docker/apis/network/v1alpha1.NetworkName()
+	// +crossplane:generate:reference:refFieldName=NetworkRef
+	// +crossplane:generate:reference:selectorFieldName=NetworkSelector
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// NetworkRef references the Network managed resource the container is
+	// attached to. The container waits for the Network to be created.
+	// +optional
+	NetworkRef *xpv1.Reference `json:"networkRef,omitempty"`
+
+	// NetworkSelector selects the Network managed resource the container is
+	// attached to, by its labels.
+	// +optional
+	NetworkSelector *xpv1.Selector `json:"networkSelector,omitempty"`
 
 	// Aliases for the container on this network.
 	// +optional
@@ -387,22 +931,176 @@ type NetworkAttachment struct {
 	// Links to other containers (legacy).
 	// +optional
 	Links []string `json:"links,omitempty"`
+
+	// CreateIfMissing creates the network before the container is created
+	// when no network with this name exists. Networks created this way carry
+	// the docker.crossplane.io/managed-by label.
+	// +optional
+	CreateIfMissing *bool `json:"createIfMissing,omitempty"`
+
+	// Driver is the driver of a network created by CreateIfMissing.
+	// +kubebuilder:default="bridge"
+	// +optional
+	Driver *string `json:"driver,omitempty"`
+
+	// Subnet is the subnet in CIDR format of a network created by
+	// CreateIfMissing. Docker allocates one when it is not set.
+	// +optional
+	Subnet *string `json:"subnet,omitempty"`
+
+	// RemoveWhenUnused removes a network created by CreateIfMissing when the
+	// container is deleted and no other containers are attached to it.
+	// +optional
+	RemoveWhenUnused *bool `json:"removeWhenUnused,omitempty"`
 }
 
 // ResourceRequirements describes compute resource requirements.
 type ResourceRequirements struct {
 	// Limits describes the maximum amount of compute resources allowed.
+	// A memory limit caps the memory of the container, and a cpu limit the
+	// number of CPUs it may use.
 	// +optional
 	Limits ResourceList `json:"limits,omitempty"`
 
 	// Requests describes the minimum amount of compute resources required.
+	// A memory request becomes the memory reservation of the container, and
+	// a cpu request its CPU shares, as the kubelet converts them.
 	// +optional
 	Requests ResourceList `json:"requests,omitempty"`
+
+	// MemorySwap is the memory and swap the container may use together,
+	// such as 2Gi, or -1 for unlimited swap. It must be at least the memory
+	// limit. Docker defaults it to twice the memory limit.
+	// +optional
+	MemorySwap *intstr.IntOrString `json:"memorySwap,omitempty"`
+
+	// PidsLimit is the most processes the container may run at once, or -1
+	// for no limit.
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	PidsLimit *int64 `json:"pidsLimit,omitempty"`
+
+	// BlkioWeight is the weight of the container's block IO relative to
+	// other containers, from 10 to 1000.
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	BlkioWeight *int32 `json:"blkioWeight,omitempty"`
+
+	// ProtectWorkingSet reserves the memory a container requests, and
+	// adjusts the OOM score of one that requests memory without a memory
+	// limit as the kubelet does for burstable pods, so that it is killed
+	// later under host memory pressure the more memory it requests.
+	// Defaults to true.
+	// +optional
+	ProtectWorkingSet *bool `json:"protectWorkingSet,omitempty"`
 }
 
 // ResourceList is a set of (resource name, quantity) pairs.
 type ResourceList map[string]intstr.IntOrString
 
+// A DeviceMapping adds a host device to a container.
+type DeviceMapping struct {
+	// HostPath is the path of the device on the Docker host, such as
+	// /dev/ttyUSB0.
+	// +kubebuilder:validation:Pattern=`^/`
+	HostPath string `json:"hostPath"`
+
+	// ContainerPath is the path of the device in the container. Defaults
+	// to its host path.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	ContainerPath string `json:"containerPath,omitempty"`
+
+	// CgroupPermissions are the access the container has to the device:
+	// any of r (read), w (write) and m (mknod).
+	// +kubebuilder:validation:Pattern=`^[rwm]{1,3}$`
+	// +kubebuilder:default="rwm"
+	// +optional
+	CgroupPermissions string `json:"cgroupPermissions,omitempty"`
+}
+
+// A DeviceRequest requests devices from a device driver of the Docker host.
+type DeviceRequest struct {
+	// Driver is the device driver, such as nvidia. Defaults to any driver
+	// that provides the capabilities.
+	// +optional
+	Driver string `json:"driver,omitempty"`
+
+	// Count is the number of devices, or -1 for all of them. Defaults to
+	// all of them, unless DeviceIDs is set.
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	Count *int32 `json:"count,omitempty"`
+
+	// DeviceIDs are the devices requested, such as GPU indexes or UUIDs,
+	// instead of a count of them.
+	// +optional
+	DeviceIDs []string `json:"deviceIDs,omitempty"`
+
+	// Capabilities the devices must all have, such as gpu, compute or
+	// utility. Defaults to gpu.
+	// +optional
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// Options are passed to the device driver.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// A LogConfig configures the log driver of a container.
+type LogConfig struct {
+	// Driver is the log driver, such as json-file, local, syslog, fluentd
+	// or journald. Defaults to the log driver of the Docker host.
+	// +optional
+	Driver string `json:"driver,omitempty"`
+
+	// Options are passed to the log driver, such as max-size and max-file
+	// to rotate json-file logs, or syslog-address.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// BandwidthLimits constrains a container's network throughput.
+type BandwidthLimits struct {
+	// Egress is the maximum outbound rate in tc rate units, e.g. 10mbit.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(bit|kbit|mbit|gbit|bps|kbps|mbps|gbps)$`
+	// +optional
+	Egress *string `json:"egress,omitempty"`
+
+	// Ingress is the maximum inbound rate in tc rate units, e.g. 10mbit.
+	// Inbound traffic over the limit is dropped rather than queued.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(bit|kbit|mbit|gbit|bps|kbps|mbps|gbps)$`
+	// +optional
+	Ingress *string `json:"ingress,omitempty"`
+
+	// Interface is the network interface inside the container to shape.
+	// +kubebuilder:default="eth0"
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.-]{1,15}$`
+	// +optional
+	Interface *string `json:"interface,omitempty"`
+
+	// HelperImage is the image used to run tc. It must provide sh and tc.
+	// +kubebuilder:default="nicolaka/netshoot:latest"
+	// +optional
+	HelperImage *string `json:"helperImage,omitempty"`
+}
+
+// Security profiles, from least to most restrictive.
+const (
+	SecurityProfilePrivileged = "privileged"
+	SecurityProfileBaseline   = "baseline"
+	SecurityProfileRestricted = "restricted"
+)
+
+// AnnotationSkipInjection opts a container out of the ProviderConfig
+// injection when set to "true".
+const AnnotationSkipInjection = labels.AnnotationSkipInjection
+
+// AnnotationIgnoreFields lists the spec fields whose out-of-band changes are
+// not treated as drift, e.g. "spec.forProvider.labels,spec.forProvider.env[DEBUG]".
+const AnnotationIgnoreFields = labels.AnnotationIgnoreFields
+
 // SecurityContext holds security configuration.
 type SecurityContext struct {
 	// RunAsUser is the UID to run the container as.
@@ -535,6 +1233,28 @@ type ContainerObservation struct {
 	// Name is the actual container name.
 	Name string `json:"name,omitempty"`
 
+	// Phase summarises where the container is in its lifecycle.
+	// +optional
+	Phase ContainerPhase `json:"phase,omitempty"`
+
+	// LogLineSeenAt is when the container was found to have logged the line
+	// its readiness waits for, since it last started.
+	// +optional
+	LogLineSeenAt *metav1.Time `json:"logLineSeenAt,omitempty"`
+
+	// ReadinessProbe is the result of the latest check of the container's
+	// readiness probe.
+	// +optional
+	ReadinessProbe *ProbeResult `json:"readinessProbe,omitempty"`
+
+	// ActiveHost is the Docker host the container is managed on.
+	// +optional
+	ActiveHost string `json:"activeHost,omitempty"`
+
+	// Failover reports the container's failover to its standby host.
+	// +optional
+	Failover *FailoverStatus `json:"failover,omitempty"`
+
 	// State is the container state.
 	State ContainerState `json:"state,omitempty"`
 
@@ -552,8 +1272,283 @@ type ContainerObservation struct {
 
 	// Networks shows the networks the container is attached to.
 	Networks map[string]NetworkInfo `json:"networks,omitempty"`
+
+	// Mounts shows what Docker actually mounted into the container, to be
+	// compared with the volumes that were asked for.
+	// +optional
+	Mounts []MountInfo `json:"mounts,omitempty"`
+
+	// MountDrift lists how the mounts of the container differ from the
+	// volumes of its spec, such as a volume mounted read-write that the
+	// spec mounts read-only, or one no longer in the spec.
+	// +optional
+	MountDrift []string `json:"mountDrift,omitempty"`
+
+	// SecurityOpts are the security options in effect for the container,
+	// such as no-new-privileges and seccomp or AppArmor profiles.
+	// +optional
+	SecurityOpts []string `json:"securityOpts,omitempty"`
+
+	// Environment lists the variables the spec of the container sets, as
+	// they were found in the container, to confirm which were injected.
+	// The values of those taken from Secrets or value stores are redacted.
+	// +optional
+	Environment []ObservedEnvVar `json:"environment,omitempty"`
+
+	// AuditLog lists the most recent mutating Docker API calls made for the
+	// container, oldest first, when its ProviderConfig enables auditing.
+	// +optional
+	AuditLog []AuditEntry `json:"auditLog,omitempty"`
+
+	// Remediation reports the repairs made to the container while it was
+	// unhealthy.
+	// +optional
+	Remediation *RemediationStatus `json:"remediation,omitempty"`
+
+	// TerminationMessage is why the container last exited, when it
+	// captures a termination message.
+	// +optional
+	TerminationMessage string `json:"terminationMessage,omitempty"`
+
+	// LastLogTail is the tail of the container's logs when it last failed,
+	// when it captures its logs.
+	// +optional
+	LastLogTail *LogTail `json:"lastLogTail,omitempty"`
+
+	// PostStartCommands are the results of the post-start commands run
+	// since the container last started.
+	// +optional
+	PostStartCommands []CommandResult `json:"postStartCommands,omitempty"`
+
+	// PostConditionsPassedAt is when the container's post-conditions were
+	// found to pass, since it last started.
+	// +optional
+	PostConditionsPassedAt *metav1.Time `json:"postConditionsPassedAt,omitempty"`
+
+	// ClockCheckedAt is when the clock of the container was last compared
+	// with the provider's.
+	// +optional
+	ClockCheckedAt *metav1.Time `json:"clockCheckedAt,omitempty"`
+
+	// ClockOffset is how far the clock of the container was ahead of the
+	// provider's when they were last compared. It is negative when the
+	// container's clock is behind.
+	// +optional
+	ClockOffset *metav1.Duration `json:"clockOffset,omitempty"`
+
+	// ProjectedVolumes are the Secret and ConfigMap volumes of the
+	// container, as they were last written into their Docker volumes.
+	// +optional
+	ProjectedVolumes []ProjectedVolume `json:"projectedVolumes,omitempty"`
+
+	// Bandwidth is the shaping last applied to the network traffic of the
+	// container.
+	// +optional
+	Bandwidth *AppliedBandwidth `json:"bandwidth,omitempty"`
+}
+
+// LogTail is the tail of the logs of a container, captured when it failed.
+type LogTail struct {
+	// Reason the logs were captured: Exited, when the container exited
+	// non-zero, or Unhealthy.
+	Reason string `json:"reason"`
+
+	// CapturedAt is when the logs were captured.
+	CapturedAt metav1.Time `json:"capturedAt"`
+
+	// Lines are the last lines the container wrote to stdout and stderr.
+	// +optional
+	Lines string `json:"lines,omitempty"`
+}
+
+// CommandResult is the result of a command run inside a container.
+type CommandResult struct {
+	// Name of the command.
+	Name string `json:"name"`
+
+	// Succeeded is true once the command has exited zero.
+	Succeeded bool `json:"succeeded"`
+
+	// Attempts is how many times the command has been run.
+	Attempts int32 `json:"attempts"`
+
+	// ExitCode of the last attempt. It is unset if the attempt did not
+	// finish within the command's timeout.
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Output is the tail of what the last attempt wrote to stdout and
+	// stderr.
+	// +optional
+	Output string `json:"output,omitempty"`
+
+	// LastAttemptTime is when the command was last run.
+	LastAttemptTime metav1.Time `json:"lastAttemptTime"`
+
+	// NextAttemptTime is when a failed command is run again. It is unset
+	// once the command has succeeded or has no retries left.
+	// +optional
+	NextAttemptTime *metav1.Time `json:"nextAttemptTime,omitempty"`
 }
 
+// EnvVarOrigin is where the value of an environment variable was taken from.
+// +kubebuilder:validation:Enum=Value;ConfigMap;Secret;Store
+type EnvVarOrigin string
+
+// Origins of the values of environment variables.
+const (
+	EnvVarOriginValue     EnvVarOrigin = "Value"
+	EnvVarOriginConfigMap EnvVarOrigin = "ConfigMap"
+	EnvVarOriginSecret    EnvVarOrigin = "Secret"
+	EnvVarOriginStore     EnvVarOrigin = "Store"
+)
+
+// ObservedEnvVar is an environment variable found in a container.
+type ObservedEnvVar struct {
+	// Name of the variable.
+	Name string `json:"name"`
+
+	// Value of the variable, unless it is redacted.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Origin is where the value was taken from.
+	Origin EnvVarOrigin `json:"origin"`
+
+	// Redacted is true when the value is left out because it was taken
+	// from a Secret or a value store.
+	// +optional
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// ProjectedVolume is a Secret or ConfigMap volume of a container, as it was
+// last written into the Docker volume it is projected into.
+type ProjectedVolume struct {
+	// Name of the volume.
+	Name string `json:"name"`
+
+	// VolumeName is the name of the Docker volume the files of the Secret
+	// or ConfigMap are written into.
+	VolumeName string `json:"volumeName"`
+
+	// Hash of the files last written.
+	Hash string `json:"hash"`
+
+	// Revision counts the times the files have been written.
+	Revision int64 `json:"revision"`
+
+	// MountedAt is when the holder of the Docker volume started, as the
+	// Docker host reported it when the files were last written. The files
+	// are kept in memory, and are written again once the holder starts again.
+	// +optional
+	MountedAt string `json:"mountedAt,omitempty"`
+}
+
+// AppliedBandwidth is the shaping last applied to the network traffic of a
+// container. Shaping is lost when the container starts again, so is applied
+// again once it runs.
+type AppliedBandwidth struct {
+	// Hash of the tc commands applied.
+	Hash string `json:"hash"`
+
+	// StartedAt is when the container started, as the Docker host reported
+	// it when the shaping was applied.
+	StartedAt string `json:"startedAt"`
+}
+
+// RemediationStatus reports the repairs made to an unhealthy container.
+type RemediationStatus struct {
+	// UnhealthyObservations is the number of consecutive observations the
+	// container has been unhealthy for since it was last repaired.
+	// +optional
+	UnhealthyObservations int32 `json:"unhealthyObservations,omitempty"`
+
+	// Restarts is the number of times the container has been restarted.
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+
+	// Recreates is the number of times the container has been recreated.
+	// +optional
+	Recreates int32 `json:"recreates,omitempty"`
+
+	// LastRemediatedAt is when the container was last repaired.
+	// +optional
+	LastRemediatedAt *metav1.Time `json:"lastRemediatedAt,omitempty"`
+}
+
+// An AuditEntry records a mutating Docker API call made for a container.
+type AuditEntry struct {
+	// Time the call was made.
+	Time metav1.Time `json:"time"`
+
+	// Operation is the Docker API operation, such as ContainerStop.
+	Operation string `json:"operation"`
+
+	// Target is the container, image or network operated on.
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// Args summarises the arguments of the call.
+	// +optional
+	Args string `json:"args,omitempty"`
+
+	// Result is Success, or the error the call failed with.
+	Result string `json:"result"`
+}
+
+// FailoverStatus reports a container's failover to its standby host.
+type FailoverStatus struct {
+	// PrimaryUnreachableSince is when the container's own host was first
+	// found unreachable, while it remains so.
+	// +optional
+	PrimaryUnreachableSince *metav1.Time `json:"primaryUnreachableSince,omitempty"`
+
+	// FailedOverAt is when the container failed over to its standby host.
+	// The container is managed on the standby host while this is set.
+	// +optional
+	FailedOverAt *metav1.Time `json:"failedOverAt,omitempty"`
+
+	// StandbyProviderConfigRef refers to the ProviderConfig of the standby
+	// host the container failed over to, from which it is removed once its
+	// failover is removed.
+	// +optional
+	StandbyProviderConfigRef *xpv1.ProviderConfigReference `json:"standbyProviderConfigRef,omitempty"`
+}
+
+// ContainerPhase summarises where a container is in its lifecycle.
+// +kubebuilder:validation:Enum=Pending;Pulling;Creating;Starting;Running;Degraded;Exited;Removing
+type ContainerPhase string
+
+// Container phases.
+const (
+	// PhasePending means the container exists but has not been started.
+	PhasePending ContainerPhase = "Pending"
+
+	// PhasePulling means the container's image is being pulled.
+	PhasePulling ContainerPhase = "Pulling"
+
+	// PhaseCreating means the container is being created.
+	PhaseCreating ContainerPhase = "Creating"
+
+	// PhaseStarting means the container is starting or restarting, or is
+	// running but its health check has not yet passed.
+	PhaseStarting ContainerPhase = "Starting"
+
+	// PhaseRunning means the container is running, and healthy if it has a
+	// health check.
+	PhaseRunning ContainerPhase = "Running"
+
+	// PhaseDegraded means the container is running but unhealthy, is
+	// paused, or is dead.
+	PhaseDegraded ContainerPhase = "Degraded"
+
+	// PhaseExited means the container's main process has exited.
+	PhaseExited ContainerPhase = "Exited"
+
+	// PhaseRemoving means the container is being removed.
+	PhaseRemoving ContainerPhase = "Removing"
+)
+
 // ContainerState represents the state of a container.
 type ContainerState struct {
 	// Status is the container status (created, running, paused, restarting, removing, exited, dead).
@@ -650,6 +1645,31 @@ type NetworkInfo struct {
 	MacAddress string `json:"macAddress,omitempty"`
 }
 
+// MountInfo represents a mount of a container, as Docker reports it.
+type MountInfo struct {
+	// Type is the type of the mount: bind, volume, tmpfs, npipe or
+	// cluster.
+	Type string `json:"type"`
+
+	// Name is the name of the volume, for a volume mount.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Source is the path on the host that is mounted.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Destination is the path in the container it is mounted at.
+	Destination string `json:"destination"`
+
+	// Driver is the volume driver, for a volume mount.
+	// +optional
+	Driver string `json:"driver,omitempty"`
+
+	// RW is true if the mount is writable.
+	RW bool `json:"rw"`
+}
+
 // ContainerHealth represents health check status.
 type ContainerHealth struct {
 	// Status is the health status (starting, healthy, unhealthy).
@@ -684,6 +1704,7 @@ type HealthCheckResult struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.atProvider.phase"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="IMAGE",type="string",JSONPath=".spec.forProvider.image",priority=1
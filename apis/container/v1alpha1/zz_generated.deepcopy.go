@@ -21,7 +21,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -44,6 +47,72 @@ func (in *AppArmorProfile) DeepCopy() *AppArmorProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedBandwidth) DeepCopyInto(out *AppliedBandwidth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedBandwidth.
+func (in *AppliedBandwidth) DeepCopy() *AppliedBandwidth {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedBandwidth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditEntry) DeepCopyInto(out *AuditEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditEntry.
+func (in *AuditEntry) DeepCopy() *AuditEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandwidthLimits) DeepCopyInto(out *BandwidthLimits) {
+	*out = *in
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(string)
+		**out = **in
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interface != nil {
+		in, out := &in.Interface, &out.Interface
+		*out = new(string)
+		**out = **in
+	}
+	if in.HelperImage != nil {
+		in, out := &in.HelperImage, &out.HelperImage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthLimits.
+func (in *BandwidthLimits) DeepCopy() *BandwidthLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(BandwidthLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BindVolumeSource) DeepCopyInto(out *BindVolumeSource) {
 	*out = *in
@@ -64,6 +133,26 @@ func (in *BindVolumeSource) DeepCopy() *BindVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BridgePeer) DeepCopyInto(out *BridgePeer) {
+	*out = *in
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BridgePeer.
+func (in *BridgePeer) DeepCopy() *BridgePeer {
+	if in == nil {
+		return nil
+	}
+	out := new(BridgePeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Capabilities) DeepCopyInto(out *Capabilities) {
 	*out = *in
@@ -89,6 +178,81 @@ func (in *Capabilities) DeepCopy() *Capabilities {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CaptureLogs) DeepCopyInto(out *CaptureLogs) {
+	*out = *in
+	if in.TailLines != nil {
+		in, out := &in.TailLines, &out.TailLines
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaptureLogs.
+func (in *CaptureLogs) DeepCopy() *CaptureLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(CaptureLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClockCheck) DeepCopyInto(out *ClockCheck) {
+	*out = *in
+	if in.MaxDrift != nil {
+		in, out := &in.MaxDrift, &out.MaxDrift
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClockCheck.
+func (in *ClockCheck) DeepCopy() *ClockCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ClockCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandResult) DeepCopyInto(out *CommandResult) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	in.LastAttemptTime.DeepCopyInto(&out.LastAttemptTime)
+	if in.NextAttemptTime != nil {
+		in, out := &in.NextAttemptTime, &out.NextAttemptTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandResult.
+func (in *CommandResult) DeepCopy() *CommandResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
 	*out = *in
@@ -229,6 +393,87 @@ func (in *ContainerObservation) DeepCopyInto(out *ContainerObservation) {
 	*out = *in
 	in.State.DeepCopyInto(&out.State)
 	out.Image = in.Image
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]MountInfo, len(*in))
+		copy(*out, *in)
+	}
+	if in.MountDrift != nil {
+		in, out := &in.MountDrift, &out.MountDrift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityOpts != nil {
+		in, out := &in.SecurityOpts, &out.SecurityOpts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = make([]ObservedEnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogLineSeenAt != nil {
+		in, out := &in.LogLineSeenAt, &out.LogLineSeenAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(FailoverStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = make([]AuditEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastLogTail != nil {
+		in, out := &in.LastLogTail, &out.LastLogTail
+		*out = new(LogTail)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostStartCommands != nil {
+		in, out := &in.PostStartCommands, &out.PostStartCommands
+		*out = make([]CommandResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostConditionsPassedAt != nil {
+		in, out := &in.PostConditionsPassedAt, &out.PostConditionsPassedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ClockCheckedAt != nil {
+		in, out := &in.ClockCheckedAt, &out.ClockCheckedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ClockOffset != nil {
+		in, out := &in.ClockOffset, &out.ClockOffset
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ProjectedVolumes != nil {
+		in, out := &in.ProjectedVolumes, &out.ProjectedVolumes
+		*out = make([]ProjectedVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(AppliedBandwidth)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerObservation.
@@ -241,9 +486,167 @@ func (in *ContainerObservation) DeepCopy() *ContainerObservation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerOperation) DeepCopyInto(out *ContainerOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerOperation.
+func (in *ContainerOperation) DeepCopy() *ContainerOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContainerOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerOperationList) DeepCopyInto(out *ContainerOperationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ContainerOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerOperationList.
+func (in *ContainerOperationList) DeepCopy() *ContainerOperationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerOperationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContainerOperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerOperationObservation) DeepCopyInto(out *ContainerOperationObservation) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]OperationTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerOperationObservation.
+func (in *ContainerOperationObservation) DeepCopy() *ContainerOperationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerOperationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerOperationParameters) DeepCopyInto(out *ContainerOperationParameters) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.StopTimeout != nil {
+		in, out := &in.StopTimeout, &out.StopTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerOperationParameters.
+func (in *ContainerOperationParameters) DeepCopy() *ContainerOperationParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerOperationParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerOperationSpec) DeepCopyInto(out *ContainerOperationSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerOperationSpec.
+func (in *ContainerOperationSpec) DeepCopy() *ContainerOperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerOperationStatus) DeepCopyInto(out *ContainerOperationStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerOperationStatus.
+func (in *ContainerOperationStatus) DeepCopy() *ContainerOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerParameters) DeepCopyInto(out *ContainerParameters) {
 	*out = *in
+	if in.FromTemplateRef != nil {
+		in, out := &in.FromTemplateRef, &out.FromTemplateRef
+		*out = new(v2.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]Dependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagePullPolicy != nil {
+		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
+		*out = new(ImagePullPolicy)
+		**out = **in
+	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(string)
+		**out = **in
+	}
 	if in.Name != nil {
 		in, out := &in.Name, &out.Name
 		*out = new(string)
@@ -322,6 +725,13 @@ func (in *ContainerParameters) DeepCopyInto(out *ContainerParameters) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BridgePeers != nil {
+		in, out := &in.BridgePeers, &out.BridgePeers
+		*out = make([]BridgePeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DNS != nil {
 		in, out := &in.DNS, &out.DNS
 		*out = make([]string, len(*in))
@@ -344,16 +754,48 @@ func (in *ContainerParameters) DeepCopyInto(out *ContainerParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.LogConfig != nil {
+		in, out := &in.LogConfig, &out.LogConfig
+		*out = new(LogConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(SecurityContext)
-		(*in).DeepCopyInto(*out)
-	}
+	if in.StorageOpt != nil {
+		in, out := &in.StorageOpt, &out.StorageOpt
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Runtime != nil {
+		in, out := &in.Runtime, &out.Runtime
+		*out = new(string)
+		**out = **in
+	}
+	if in.UsernsMode != nil {
+		in, out := &in.UsernsMode, &out.UsernsMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(BandwidthLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(string)
+		**out = **in
+	}
 	if in.HealthCheck != nil {
 		in, out := &in.HealthCheck, &out.HealthCheck
 		*out = new(HealthCheck)
@@ -364,11 +806,38 @@ func (in *ContainerParameters) DeepCopyInto(out *ContainerParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.StopSignal != nil {
+		in, out := &in.StopSignal, &out.StopSignal
+		*out = new(string)
+		**out = **in
+	}
+	if in.StopGracePeriod != nil {
+		in, out := &in.StopGracePeriod, &out.StopGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Privileged != nil {
 		in, out := &in.Privileged, &out.Privileged
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DeviceCgroupRules != nil {
+		in, out := &in.DeviceCgroupRules, &out.DeviceCgroupRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]DeviceMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeviceRequests != nil {
+		in, out := &in.DeviceRequests, &out.DeviceRequests
+		*out = make([]DeviceRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Remove != nil {
 		in, out := &in.Remove, &out.Remove
 		*out = new(bool)
@@ -379,6 +848,65 @@ func (in *ContainerParameters) DeepCopyInto(out *ContainerParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(Readiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ReadinessProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(Failover)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(Remediation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(UpdateStrategy)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationMessage != nil {
+		in, out := &in.TerminationMessage, &out.TerminationMessage
+		*out = new(TerminationMessage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CaptureLogs != nil {
+		in, out := &in.CaptureLogs, &out.CaptureLogs
+		*out = new(CaptureLogs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostStartCommands != nil {
+		in, out := &in.PostStartCommands, &out.PostStartCommands
+		*out = make([]PostStartCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostConditions != nil {
+		in, out := &in.PostConditions, &out.PostConditions
+		*out = make([]PostCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClockCheck != nil {
+		in, out := &in.ClockCheck, &out.ClockCheck
+		*out = new(ClockCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerParameters.
@@ -437,264 +965,1299 @@ func (in *ContainerState) DeepCopy() *ContainerState {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContainerStatus) DeepCopyInto(out *ContainerStatus) {
+func (in *ContainerStatus) DeepCopyInto(out *ContainerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerStatus.
+func (in *ContainerStatus) DeepCopy() *ContainerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerTemplate) DeepCopyInto(out *ContainerTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerTemplate.
+func (in *ContainerTemplate) DeepCopy() *ContainerTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContainerTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerTemplateList) DeepCopyInto(out *ContainerTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ContainerTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerTemplateList.
+func (in *ContainerTemplateList) DeepCopy() *ContainerTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContainerTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerTemplateSpec) DeepCopyInto(out *ContainerTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerTemplateSpec.
+func (in *ContainerTemplateSpec) DeepCopy() *ContainerTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugSession) DeepCopyInto(out *DebugSession) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugSession.
+func (in *DebugSession) DeepCopy() *DebugSession {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugSession)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DebugSession) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugSessionList) DeepCopyInto(out *DebugSessionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DebugSession, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugSessionList.
+func (in *DebugSessionList) DeepCopy() *DebugSessionList {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugSessionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DebugSessionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugSessionObservation) DeepCopyInto(out *DebugSessionObservation) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugSessionObservation.
+func (in *DebugSessionObservation) DeepCopy() *DebugSessionObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugSessionObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugSessionParameters) DeepCopyInto(out *DebugSessionParameters) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(string)
+		**out = **in
+	}
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(v2.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ShareNetwork != nil {
+		in, out := &in.ShareNetwork, &out.ShareNetwork
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SharePID != nil {
+		in, out := &in.SharePID, &out.SharePID
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugSessionParameters.
+func (in *DebugSessionParameters) DeepCopy() *DebugSessionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugSessionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugSessionSpec) DeepCopyInto(out *DebugSessionSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugSessionSpec.
+func (in *DebugSessionSpec) DeepCopy() *DebugSessionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugSessionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugSessionStatus) DeepCopyInto(out *DebugSessionStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugSessionStatus.
+func (in *DebugSessionStatus) DeepCopy() *DebugSessionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugSessionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Dependency) DeepCopyInto(out *Dependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Dependency.
+func (in *Dependency) DeepCopy() *Dependency {
+	if in == nil {
+		return nil
+	}
+	out := new(Dependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceMapping) DeepCopyInto(out *DeviceMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceMapping.
+func (in *DeviceMapping) DeepCopy() *DeviceMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceRequest) DeepCopyInto(out *DeviceRequest) {
+	*out = *in
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeviceIDs != nil {
+		in, out := &in.DeviceIDs, &out.DeviceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceRequest.
+func (in *DeviceRequest) DeepCopy() *DeviceRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmptyDirVolumeSource) DeepCopyInto(out *EmptyDirVolumeSource) {
+	*out = *in
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmptyDirVolumeSource.
+func (in *EmptyDirVolumeSource) DeepCopy() *EmptyDirVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EmptyDirVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvVar) DeepCopyInto(out *EnvVar) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(EnvVarSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVar.
+func (in *EnvVar) DeepCopy() *EnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvVarSource) DeepCopyInto(out *EnvVarSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StoreKeyRef != nil {
+		in, out := &in.StoreKeyRef, &out.StoreKeyRef
+		*out = new(StoreKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVarSource.
+func (in *EnvVarSource) DeepCopy() *EnvVarSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvVarSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecProbe) DeepCopyInto(out *ExecProbe) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecProbe.
+func (in *ExecProbe) DeepCopy() *ExecProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Failover) DeepCopyInto(out *Failover) {
+	*out = *in
+	out.StandbyProviderConfigRef = in.StandbyProviderConfigRef
+	if in.UnreachableFor != nil {
+		in, out := &in.UnreachableFor, &out.UnreachableFor
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Failover.
+func (in *Failover) DeepCopy() *Failover {
+	if in == nil {
+		return nil
+	}
+	out := new(Failover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverStatus) DeepCopyInto(out *FailoverStatus) {
+	*out = *in
+	if in.PrimaryUnreachableSince != nil {
+		in, out := &in.PrimaryUnreachableSince, &out.PrimaryUnreachableSince
+		*out = (*in).DeepCopy()
+	}
+	if in.FailedOverAt != nil {
+		in, out := &in.FailedOverAt, &out.FailedOverAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StandbyProviderConfigRef != nil {
+		in, out := &in.StandbyProviderConfigRef, &out.StandbyProviderConfigRef
+		*out = new(v2.ProviderConfigReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverStatus.
+func (in *FailoverStatus) DeepCopy() *FailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPGetProbe) DeepCopyInto(out *HTTPGetProbe) {
+	*out = *in
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPGetProbe.
+func (in *HTTPGetProbe) DeepCopy() *HTTPGetProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPGetProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+	if in.Test != nil {
+		in, out := &in.Test, &out.Test
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckResult) DeepCopyInto(out *HealthCheckResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckResult.
+func (in *HealthCheckResult) DeepCopy() *HealthCheckResult {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPathVolumeSource) DeepCopyInto(out *HostPathVolumeSource) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(HostPathType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPathVolumeSource.
+func (in *HostPathVolumeSource) DeepCopy() *HostPathVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPathVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Image) DeepCopyInto(out *Image) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Image.
+func (in *Image) DeepCopy() *Image {
+	if in == nil {
+		return nil
+	}
+	out := new(Image)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Image) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageList) DeepCopyInto(out *ImageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Image, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageList.
+func (in *ImageList) DeepCopy() *ImageList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageObservation) DeepCopyInto(out *ImageObservation) {
+	*out = *in
+	if in.PulledAt != nil {
+		in, out := &in.PulledAt, &out.PulledAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageObservation.
+func (in *ImageObservation) DeepCopy() *ImageObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageParameters) DeepCopyInto(out *ImageParameters) {
+	*out = *in
+	if in.Digest != nil {
+		in, out := &in.Digest, &out.Digest
+		*out = new(string)
+		**out = **in
+	}
+	if in.PullPolicy != nil {
+		in, out := &in.PullPolicy, &out.PullPolicy
+		*out = new(ImagePullPolicy)
+		**out = **in
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RemoveOnDelete != nil {
+		in, out := &in.RemoveOnDelete, &out.RemoveOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageParameters.
+func (in *ImageParameters) DeepCopy() *ImageParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePrefetch) DeepCopyInto(out *ImagePrefetch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrefetch.
+func (in *ImagePrefetch) DeepCopy() *ImagePrefetch {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePrefetch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImagePrefetch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePrefetchList) DeepCopyInto(out *ImagePrefetchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImagePrefetch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrefetchList.
+func (in *ImagePrefetchList) DeepCopy() *ImagePrefetchList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePrefetchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImagePrefetchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePrefetchObservation) DeepCopyInto(out *ImagePrefetchObservation) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]PrefetchedImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.RefreshedAt != nil {
+		in, out := &in.RefreshedAt, &out.RefreshedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrefetchObservation.
+func (in *ImagePrefetchObservation) DeepCopy() *ImagePrefetchObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePrefetchObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePrefetchParameters) DeepCopyInto(out *ImagePrefetchParameters) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RemoveOnDelete != nil {
+		in, out := &in.RemoveOnDelete, &out.RemoveOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrefetchParameters.
+func (in *ImagePrefetchParameters) DeepCopy() *ImagePrefetchParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePrefetchParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePrefetchSpec) DeepCopyInto(out *ImagePrefetchSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrefetchSpec.
+func (in *ImagePrefetchSpec) DeepCopy() *ImagePrefetchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePrefetchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePrefetchStatus) DeepCopyInto(out *ImagePrefetchStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrefetchStatus.
+func (in *ImagePrefetchStatus) DeepCopy() *ImagePrefetchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePrefetchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSpec.
+func (in *ImageSpec) DeepCopy() *ImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageStatus) DeepCopyInto(out *ImageStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageStatus.
+func (in *ImageStatus) DeepCopy() *ImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyToPath) DeepCopyInto(out *KeyToPath) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyToPath.
+func (in *KeyToPath) DeepCopy() *KeyToPath {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyToPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogConfig) DeepCopyInto(out *LogConfig) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogConfig.
+func (in *LogConfig) DeepCopy() *LogConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LogConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogTail) DeepCopyInto(out *LogTail) {
+	*out = *in
+	in.CapturedAt.DeepCopyInto(&out.CapturedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogTail.
+func (in *LogTail) DeepCopy() *LogTail {
+	if in == nil {
+		return nil
+	}
+	out := new(LogTail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MountInfo) DeepCopyInto(out *MountInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MountInfo.
+func (in *MountInfo) DeepCopy() *MountInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(MountInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkAttachment) DeepCopyInto(out *NetworkAttachment) {
+	*out = *in
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPAddress != nil {
+		in, out := &in.IPAddress, &out.IPAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPv6Address != nil {
+		in, out := &in.IPv6Address, &out.IPv6Address
+		*out = new(string)
+		**out = **in
+	}
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreateIfMissing != nil {
+		in, out := &in.CreateIfMissing, &out.CreateIfMissing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Driver != nil {
+		in, out := &in.Driver, &out.Driver
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.RemoveWhenUnused != nil {
+		in, out := &in.RemoveWhenUnused, &out.RemoveWhenUnused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NetworkRef != nil {
+		in, out := &in.NetworkRef, &out.NetworkRef
+		*out = new(v2.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkSelector != nil {
+		in, out := &in.NetworkSelector, &out.NetworkSelector
+		*out = new(v2.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAttachment.
+func (in *NetworkAttachment) DeepCopy() *NetworkAttachment {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAttachment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInfo) DeepCopyInto(out *NetworkInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInfo.
+func (in *NetworkInfo) DeepCopy() *NetworkInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservedEnvVar) DeepCopyInto(out *ObservedEnvVar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedEnvVar.
+func (in *ObservedEnvVar) DeepCopy() *ObservedEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservedEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationTarget) DeepCopyInto(out *OperationTarget) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerStatus.
-func (in *ContainerStatus) DeepCopy() *ContainerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationTarget.
+func (in *OperationTarget) DeepCopy() *OperationTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(ContainerStatus)
+	out := new(OperationTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmptyDirVolumeSource) DeepCopyInto(out *EmptyDirVolumeSource) {
+func (in *PortSpec) DeepCopyInto(out *PortSpec) {
 	*out = *in
-	if in.SizeLimit != nil {
-		in, out := &in.SizeLimit, &out.SizeLimit
+	if in.HostPort != nil {
+		in, out := &in.HostPort, &out.HostPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HostIP != nil {
+		in, out := &in.HostIP, &out.HostIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmptyDirVolumeSource.
-func (in *EmptyDirVolumeSource) DeepCopy() *EmptyDirVolumeSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortSpec.
+func (in *PortSpec) DeepCopy() *PortSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EmptyDirVolumeSource)
+	out := new(PortSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvVar) DeepCopyInto(out *EnvVar) {
-	*out = *in
-	if in.Value != nil {
-		in, out := &in.Value, &out.Value
-		*out = new(string)
-		**out = **in
-	}
-	if in.ValueFrom != nil {
-		in, out := &in.ValueFrom, &out.ValueFrom
-		*out = new(EnvVarSource)
-		(*in).DeepCopyInto(*out)
+func (in ResourceList) DeepCopyInto(out *ResourceList) {
+	{
+		in := &in
+		*out = make(ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVar.
-func (in *EnvVar) DeepCopy() *EnvVar {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceList.
+func (in ResourceList) DeepCopy() ResourceList {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvVar)
+	out := new(ResourceList)
 	in.DeepCopyInto(out)
-	return out
+	return *out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvVarSource) DeepCopyInto(out *EnvVarSource) {
+func (in *PostCondition) DeepCopyInto(out *PostCondition) {
 	*out = *in
-	if in.SecretKeyRef != nil {
-		in, out := &in.SecretKeyRef, &out.SecretKeyRef
-		*out = new(SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.FileExists != nil {
+		in, out := &in.FileExists, &out.FileExists
+		*out = new(string)
+		**out = **in
 	}
-	if in.ConfigMapKeyRef != nil {
-		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
-		*out = new(ConfigMapKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PortListening != nil {
+		in, out := &in.PortListening, &out.PortListening
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVarSource.
-func (in *EnvVarSource) DeepCopy() *EnvVarSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostCondition.
+func (in *PostCondition) DeepCopy() *PostCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvVarSource)
+	out := new(PostCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+func (in *PostStartCommand) DeepCopyInto(out *PostStartCommand) {
 	*out = *in
-	if in.Test != nil {
-		in, out := &in.Test, &out.Test
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
-func (in *HealthCheck) DeepCopy() *HealthCheck {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostStartCommand.
+func (in *PostStartCommand) DeepCopy() *PostStartCommand {
 	if in == nil {
 		return nil
 	}
-	out := new(HealthCheck)
+	out := new(PostStartCommand)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HealthCheckResult) DeepCopyInto(out *HealthCheckResult) {
+func (in *PrefetchedImage) DeepCopyInto(out *PrefetchedImage) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckResult.
-func (in *HealthCheckResult) DeepCopy() *HealthCheckResult {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefetchedImage.
+func (in *PrefetchedImage) DeepCopy() *PrefetchedImage {
 	if in == nil {
 		return nil
 	}
-	out := new(HealthCheckResult)
+	out := new(PrefetchedImage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostPathVolumeSource) DeepCopyInto(out *HostPathVolumeSource) {
+func (in *ProbeResult) DeepCopyInto(out *ProbeResult) {
 	*out = *in
-	if in.Type != nil {
-		in, out := &in.Type, &out.Type
-		*out = new(HostPathType)
-		**out = **in
-	}
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPathVolumeSource.
-func (in *HostPathVolumeSource) DeepCopy() *HostPathVolumeSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeResult.
+func (in *ProbeResult) DeepCopy() *ProbeResult {
 	if in == nil {
 		return nil
 	}
-	out := new(HostPathVolumeSource)
+	out := new(ProbeResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyToPath) DeepCopyInto(out *KeyToPath) {
+func (in *ProjectedVolume) DeepCopyInto(out *ProjectedVolume) {
 	*out = *in
-	if in.Mode != nil {
-		in, out := &in.Mode, &out.Mode
-		*out = new(int32)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyToPath.
-func (in *KeyToPath) DeepCopy() *KeyToPath {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectedVolume.
+func (in *ProjectedVolume) DeepCopy() *ProjectedVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyToPath)
+	out := new(ProjectedVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkAttachment) DeepCopyInto(out *NetworkAttachment) {
+func (in *Readiness) DeepCopyInto(out *Readiness) {
 	*out = *in
-	if in.Aliases != nil {
-		in, out := &in.Aliases, &out.Aliases
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.IPAddress != nil {
-		in, out := &in.IPAddress, &out.IPAddress
-		*out = new(string)
-		**out = **in
-	}
-	if in.IPv6Address != nil {
-		in, out := &in.IPv6Address, &out.IPv6Address
-		*out = new(string)
-		**out = **in
-	}
-	if in.Links != nil {
-		in, out := &in.Links, &out.Links
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.WaitForLogLine != nil {
+		in, out := &in.WaitForLogLine, &out.WaitForLogLine
+		*out = new(WaitForLogLine)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAttachment.
-func (in *NetworkAttachment) DeepCopy() *NetworkAttachment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Readiness.
+func (in *Readiness) DeepCopy() *Readiness {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkAttachment)
+	out := new(Readiness)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkInfo) DeepCopyInto(out *NetworkInfo) {
+func (in *ReadinessProbe) DeepCopyInto(out *ReadinessProbe) {
 	*out = *in
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(ExecProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(HTTPGetProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCPSocket != nil {
+		in, out := &in.TCPSocket, &out.TCPSocket
+		*out = new(TCPSocketProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitialDelay != nil {
+		in, out := &in.InitialDelay, &out.InitialDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInfo.
-func (in *NetworkInfo) DeepCopy() *NetworkInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessProbe.
+func (in *ReadinessProbe) DeepCopy() *ReadinessProbe {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkInfo)
+	out := new(ReadinessProbe)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortSpec) DeepCopyInto(out *PortSpec) {
+func (in *Remediation) DeepCopyInto(out *Remediation) {
 	*out = *in
-	if in.HostPort != nil {
-		in, out := &in.HostPort, &out.HostPort
+	if in.UnhealthyThreshold != nil {
+		in, out := &in.UnhealthyThreshold, &out.UnhealthyThreshold
 		*out = new(int32)
 		**out = **in
 	}
-	if in.HostIP != nil {
-		in, out := &in.HostIP, &out.HostIP
-		*out = new(string)
-		**out = **in
-	}
-	if in.Protocol != nil {
-		in, out := &in.Protocol, &out.Protocol
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortSpec.
-func (in *PortSpec) DeepCopy() *PortSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Remediation.
+func (in *Remediation) DeepCopy() *Remediation {
 	if in == nil {
 		return nil
 	}
-	out := new(PortSpec)
+	out := new(Remediation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ResourceList) DeepCopyInto(out *ResourceList) {
-	{
-		in := &in
-		*out = make(ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+func (in *RemediationStatus) DeepCopyInto(out *RemediationStatus) {
+	*out = *in
+	if in.LastRemediatedAt != nil {
+		in, out := &in.LastRemediatedAt, &out.LastRemediatedAt
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceList.
-func (in ResourceList) DeepCopy() ResourceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStatus.
+func (in *RemediationStatus) DeepCopy() *RemediationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceList)
+	out := new(RemediationStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -714,6 +2277,26 @@ func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
 			(*out)[key] = val
 		}
 	}
+	if in.MemorySwap != nil {
+		in, out := &in.MemorySwap, &out.MemorySwap
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.PidsLimit != nil {
+		in, out := &in.PidsLimit, &out.PidsLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BlkioWeight != nil {
+		in, out := &in.BlkioWeight, &out.BlkioWeight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProtectWorkingSet != nil {
+		in, out := &in.ProtectWorkingSet, &out.ProtectWorkingSet
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRequirements.
@@ -893,6 +2476,71 @@ func (in *SecurityContext) DeepCopy() *SecurityContext {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreKeySelector) DeepCopyInto(out *StoreKeySelector) {
+	*out = *in
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreKeySelector.
+func (in *StoreKeySelector) DeepCopy() *StoreKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPSocketProbe) DeepCopyInto(out *TCPSocketProbe) {
+	*out = *in
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPSocketProbe.
+func (in *TCPSocketProbe) DeepCopy() *TCPSocketProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPSocketProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerminationMessage) DeepCopyInto(out *TerminationMessage) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerminationMessage.
+func (in *TerminationMessage) DeepCopy() *TerminationMessage {
+	if in == nil {
+		return nil
+	}
+	out := new(TerminationMessage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeMount) DeepCopyInto(out *VolumeMount) {
 	*out = *in
@@ -940,7 +2588,7 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 	if in.Volume != nil {
 		in, out := &in.Volume, &out.Volume
 		*out = new(VolumeVolumeSource)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Bind != nil {
 		in, out := &in.Bind, &out.Bind
@@ -962,6 +2610,16 @@ func (in *VolumeSource) DeepCopy() *VolumeSource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeVolumeSource) DeepCopyInto(out *VolumeVolumeSource) {
 	*out = *in
+	if in.VolumeRef != nil {
+		in, out := &in.VolumeRef, &out.VolumeRef
+		*out = new(v2.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeSelector != nil {
+		in, out := &in.VolumeSelector, &out.VolumeSelector
+		*out = new(v2.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeVolumeSource.
@@ -973,3 +2631,23 @@ func (in *VolumeVolumeSource) DeepCopy() *VolumeVolumeSource {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitForLogLine) DeepCopyInto(out *WaitForLogLine) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitForLogLine.
+func (in *WaitForLogLine) DeepCopy() *WaitForLogLine {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitForLogLine)
+	in.DeepCopyInto(out)
+	return out
+}
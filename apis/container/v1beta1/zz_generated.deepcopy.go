@@ -21,7 +21,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	"github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -76,6 +78,77 @@ func (in *ContainerObservation) DeepCopyInto(out *ContainerObservation) {
 	*out = *in
 	in.State.DeepCopyInto(&out.State)
 	out.Image = in.Image
+	if in.LogLineSeenAt != nil {
+		in, out := &in.LogLineSeenAt, &out.LogLineSeenAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1alpha1.ProbeResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(v1alpha1.FailoverStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]v1alpha1.MountInfo, len(*in))
+		copy(*out, *in)
+	}
+	if in.MountDrift != nil {
+		in, out := &in.MountDrift, &out.MountDrift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = make([]v1alpha1.ObservedEnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = make([]v1alpha1.AuditEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(v1alpha1.RemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastLogTail != nil {
+		in, out := &in.LastLogTail, &out.LastLogTail
+		*out = new(v1alpha1.LogTail)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostStartCommands != nil {
+		in, out := &in.PostStartCommands, &out.PostStartCommands
+		*out = make([]v1alpha1.CommandResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostConditionsPassedAt != nil {
+		in, out := &in.PostConditionsPassedAt, &out.PostConditionsPassedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ClockCheckedAt != nil {
+		in, out := &in.ClockCheckedAt, &out.ClockCheckedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ClockOffset != nil {
+		in, out := &in.ClockOffset, &out.ClockOffset
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ProjectedVolumes != nil {
+		in, out := &in.ProjectedVolumes, &out.ProjectedVolumes
+		*out = make([]v1alpha1.ProjectedVolume, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerObservation.
@@ -91,6 +164,26 @@ func (in *ContainerObservation) DeepCopy() *ContainerObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerParameters) DeepCopyInto(out *ContainerParameters) {
 	*out = *in
+	if in.FromTemplateRef != nil {
+		in, out := &in.FromTemplateRef, &out.FromTemplateRef
+		*out = new(v2.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]v1alpha1.Dependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagePullPolicy != nil {
+		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
+		*out = new(v1alpha1.ImagePullPolicy)
+		**out = **in
+	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(string)
+		**out = **in
+	}
 	if in.Name != nil {
 		in, out := &in.Name, &out.Name
 		*out = new(string)
@@ -169,6 +262,13 @@ func (in *ContainerParameters) DeepCopyInto(out *ContainerParameters) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BridgePeers != nil {
+		in, out := &in.BridgePeers, &out.BridgePeers
+		*out = make([]v1alpha1.BridgePeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DNS != nil {
 		in, out := &in.DNS, &out.DNS
 		*out = make([]string, len(*in))
@@ -191,11 +291,33 @@ func (in *ContainerParameters) DeepCopyInto(out *ContainerParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.LogConfig != nil {
+		in, out := &in.LogConfig, &out.LogConfig
+		*out = new(v1alpha1.LogConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1alpha1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.StorageOpt != nil {
+		in, out := &in.StorageOpt, &out.StorageOpt
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Runtime != nil {
+		in, out := &in.Runtime, &out.Runtime
+		*out = new(string)
+		**out = **in
+	}
+	if in.UsernsMode != nil {
+		in, out := &in.UsernsMode, &out.UsernsMode
+		*out = new(string)
+		**out = **in
+	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
 		*out = new(v1alpha1.SecurityContext)
@@ -211,11 +333,38 @@ func (in *ContainerParameters) DeepCopyInto(out *ContainerParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.StopSignal != nil {
+		in, out := &in.StopSignal, &out.StopSignal
+		*out = new(string)
+		**out = **in
+	}
+	if in.StopGracePeriod != nil {
+		in, out := &in.StopGracePeriod, &out.StopGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Privileged != nil {
 		in, out := &in.Privileged, &out.Privileged
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DeviceCgroupRules != nil {
+		in, out := &in.DeviceCgroupRules, &out.DeviceCgroupRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]v1alpha1.DeviceMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeviceRequests != nil {
+		in, out := &in.DeviceRequests, &out.DeviceRequests
+		*out = make([]v1alpha1.DeviceRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Remove != nil {
 		in, out := &in.Remove, &out.Remove
 		*out = new(bool)
@@ -226,6 +375,65 @@ func (in *ContainerParameters) DeepCopyInto(out *ContainerParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(v1alpha1.Readiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1alpha1.ReadinessProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(v1alpha1.Failover)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(v1alpha1.Remediation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(v1alpha1.UpdateStrategy)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(v1alpha1.MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationMessage != nil {
+		in, out := &in.TerminationMessage, &out.TerminationMessage
+		*out = new(v1alpha1.TerminationMessage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CaptureLogs != nil {
+		in, out := &in.CaptureLogs, &out.CaptureLogs
+		*out = new(v1alpha1.CaptureLogs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostStartCommands != nil {
+		in, out := &in.PostStartCommands, &out.PostStartCommands
+		*out = make([]v1alpha1.PostStartCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostConditions != nil {
+		in, out := &in.PostConditions, &out.PostConditions
+		*out = make([]v1alpha1.PostCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClockCheck != nil {
+		in, out := &in.ClockCheck, &out.ClockCheck
+		*out = new(v1alpha1.ClockCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerParameters.
@@ -23,4 +23,7 @@ limitations under the License.
 // Generate managed resource interface methods
 //go:generate go run -tags generate github.com/crossplane/crossplane-tools/cmd/angryjet generate-methodsets --header-file=../hack/boilerplate.go.txt ./...
 
+// Generate reference resolvers
+//go:generate go run -tags generate github.com/crossplane/crossplane-tools/cmd/angryjet generate-resolvers --header-file=../hack/boilerplate.go.txt ./...
+
 package apis
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+)
+
+// NetworkName extracts the name of the Docker network of a Network. It is
+// empty until the Docker network has been created or observed, so that
+// references to the Network are not resolved before it exists.
+func NetworkName() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		n, ok := mg.(*Network)
+		if !ok {
+			return ""
+		}
+		return n.Status.AtProvider.Name
+	}
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-docker/pkg/labels"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -68,8 +69,17 @@ type NetworkParameters struct {
 	// Labels is a map of labels to apply to the network.
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// PreventDeleteWhileInUse blocks deletion of the network while containers
+	// are attached to it. Set the docker.crossplane.io/force-delete annotation
+	// to "true" to delete it regardless.
+	// +optional
+	PreventDeleteWhileInUse *bool `json:"preventDeleteWhileInUse,omitempty"`
 }
 
+// AnnotationForceDelete overrides PreventDeleteWhileInUse when set to "true".
+const AnnotationForceDelete = labels.AnnotationForceDelete
+
 // IPAMConfig contains IP Address Management configuration.
 type IPAMConfig struct {
 	// Driver specifies the IPAM driver to use.
@@ -153,6 +163,34 @@ type NetworkObservation struct {
 
 	// Containers is a map of containers attached to the network.
 	Containers map[string]*NetworkContainer `json:"containers,omitempty"`
+
+	// ConnectedContainers is the number of containers attached to the network.
+	ConnectedContainers int `json:"connectedContainers,omitempty"`
+
+	// ConnectedContainerIDs are the IDs of the containers attached to the
+	// network, sorted.
+	ConnectedContainerIDs []string `json:"connectedContainerIDs,omitempty"`
+
+	// SubnetUtilization reports how many addresses are allocated in each
+	// IPAM subnet of the network.
+	SubnetUtilization []SubnetUtilization `json:"subnetUtilization,omitempty"`
+}
+
+// SubnetUtilization is the address allocation of a single subnet.
+type SubnetUtilization struct {
+	// Subnet is the subnet in CIDR format.
+	Subnet string `json:"subnet"`
+
+	// Allocated is the number of addresses in the subnet assigned to
+	// attached containers.
+	Allocated int64 `json:"allocated"`
+
+	// Capacity is the number of addresses in the subnet that can be assigned
+	// to containers, excluding the network, broadcast and gateway addresses.
+	Capacity int64 `json:"capacity"`
+
+	// UtilizationPercent is Allocated as a whole percentage of Capacity.
+	UtilizationPercent int32 `json:"utilizationPercent"`
 }
 
 // NetworkContainer represents a container attached to the network.
@@ -184,6 +222,7 @@ type NetworkContainer struct {
 // +kubebuilder:printcolumn:name="DRIVER",type="string",JSONPath=".status.atProvider.driver",priority=1
 // +kubebuilder:printcolumn:name="SCOPE",type="string",JSONPath=".status.atProvider.scope",priority=1
 // +kubebuilder:printcolumn:name="INTERNAL",type="boolean",JSONPath=".status.atProvider.internal",priority=1
+// +kubebuilder:printcolumn:name="CONTAINERS",type="integer",JSONPath=".status.atProvider.connectedContainers",priority=1
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,docker}
 type Network struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -213,6 +252,16 @@ func (cr *Network) SetManagementPolicies(p xpv1.ManagementPolicies) {
 	cr.Spec.ManagementPolicies = p
 }
 
+// GetProviderConfigReference returns the ProviderConfigReference field.
+func (cr *Network) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return cr.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference sets the ProviderConfigReference field.
+func (cr *Network) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	cr.Spec.ProviderConfigReference = p
+}
+
 // +kubebuilder:object:root=true
 
 // NetworkList contains a list of Network.
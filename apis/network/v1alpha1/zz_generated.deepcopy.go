@@ -159,6 +159,16 @@ func (in *NetworkList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkObservation) DeepCopyInto(out *NetworkObservation) {
 	*out = *in
+	if in.ConnectedContainerIDs != nil {
+		in, out := &in.ConnectedContainerIDs, &out.ConnectedContainerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubnetUtilization != nil {
+		in, out := &in.SubnetUtilization, &out.SubnetUtilization
+		*out = make([]SubnetUtilization, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkObservation.
@@ -223,6 +233,11 @@ func (in *NetworkParameters) DeepCopyInto(out *NetworkParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.PreventDeleteWhileInUse != nil {
+		in, out := &in.PreventDeleteWhileInUse, &out.PreventDeleteWhileInUse
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkParameters.
@@ -264,3 +279,18 @@ func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetUtilization) DeepCopyInto(out *SubnetUtilization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetUtilization.
+func (in *SubnetUtilization) DeepCopy() *SubnetUtilization {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetUtilization)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -60,6 +60,7 @@ type NetworkObservation v1alpha1.NetworkObservation
 // +kubebuilder:printcolumn:name="DRIVER",type="string",JSONPath=".status.atProvider.driver",priority=1
 // +kubebuilder:printcolumn:name="SCOPE",type="string",JSONPath=".status.atProvider.scope",priority=1
 // +kubebuilder:printcolumn:name="INTERNAL",type="boolean",JSONPath=".status.atProvider.internal",priority=1
+// +kubebuilder:printcolumn:name="CONTAINERS",type="integer",JSONPath=".status.atProvider.connectedContainers",priority=1
 // +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,docker,v2}
 type Network struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -89,6 +90,16 @@ func (cr *Network) SetManagementPolicies(p xpv1.ManagementPolicies) {
 	cr.Spec.ManagementPolicies = p
 }
 
+// GetProviderConfigReference returns the ProviderConfigReference field.
+func (cr *Network) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return cr.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference sets the ProviderConfigReference field.
+func (cr *Network) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	cr.Spec.ProviderConfigReference = p
+}
+
 // +kubebuilder:object:root=true
 
 // NetworkList contains a list of Network.
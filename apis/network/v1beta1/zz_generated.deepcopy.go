@@ -74,6 +74,16 @@ func (in *NetworkList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkObservation) DeepCopyInto(out *NetworkObservation) {
 	*out = *in
+	if in.ConnectedContainerIDs != nil {
+		in, out := &in.ConnectedContainerIDs, &out.ConnectedContainerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubnetUtilization != nil {
+		in, out := &in.SubnetUtilization, &out.SubnetUtilization
+		*out = make([]v1alpha1.SubnetUtilization, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkObservation.
@@ -138,6 +148,11 @@ func (in *NetworkParameters) DeepCopyInto(out *NetworkParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.PreventDeleteWhileInUse != nil {
+		in, out := &in.PreventDeleteWhileInUse, &out.PreventDeleteWhileInUse
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkParameters.
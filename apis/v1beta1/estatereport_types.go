@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A DockerEstateReportSpec defines how a DockerEstateReport is refreshed.
+type DockerEstateReportSpec struct {
+	// RefreshInterval is how often the report is refreshed.
+	// +kubebuilder:default="5m"
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// ListUnmanagedContainers lists, for each ProviderConfig in the report,
+	// the containers on its Docker host that the provider does not manage,
+	// such as those started by hand or by other tools on a shared host.
+	// +optional
+	ListUnmanagedContainers bool `json:"listUnmanagedContainers,omitempty"`
+}
+
+// A DockerEstateReportStatus summarizes the resources managed through each
+// ProviderConfig.
+type DockerEstateReportStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+
+	// RefreshedAt is when the report was last refreshed.
+	// +optional
+	RefreshedAt *metav1.Time `json:"refreshedAt,omitempty"`
+
+	// ProviderConfigs summarizes the resources of each ProviderConfig,
+	// sorted by kind, namespace and name.
+	// +optional
+	ProviderConfigs []ProviderConfigEstate `json:"providerConfigs,omitempty"`
+
+	// Total is the number of managed resources across all ProviderConfigs.
+	Total int32 `json:"total"`
+
+	// Drifted is the number of managed resources, across all
+	// ProviderConfigs, whose drift waits for a maintenance window.
+	Drifted int32 `json:"drifted"`
+
+	// Failing is the number of managed resources, across all
+	// ProviderConfigs, that are failing to sync or are unavailable.
+	Failing int32 `json:"failing"`
+
+	// Unmanaged is the number of containers, across the Docker hosts of all
+	// ProviderConfigs, that the provider does not manage. It is only
+	// counted when the report lists unmanaged containers.
+	// +optional
+	Unmanaged int32 `json:"unmanaged,omitempty"`
+}
+
+// A ProviderConfigEstate summarizes the resources managed through a single
+// ProviderConfig.
+type ProviderConfigEstate struct {
+	// Kind of the ProviderConfig, either ProviderConfig or
+	// ClusterProviderConfig.
+	Kind string `json:"kind"`
+
+	// Name of the ProviderConfig.
+	Name string `json:"name"`
+
+	// Namespace of the ProviderConfig, if it is namespaced.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Containers counts the Containers managed through the ProviderConfig.
+	Containers EstateCounts `json:"containers"`
+
+	// ComposeStacks counts the ComposeStacks managed through the
+	// ProviderConfig.
+	ComposeStacks EstateCounts `json:"composeStacks"`
+
+	// Volumes counts the Volumes managed through the ProviderConfig.
+	Volumes EstateCounts `json:"volumes"`
+
+	// Networks counts the Networks managed through the ProviderConfig.
+	Networks EstateCounts `json:"networks"`
+
+	// UnmanagedContainers are the containers on the Docker host of the
+	// ProviderConfig that the provider does not manage, sorted by name, if
+	// the report lists them. At most 100 are listed.
+	// +optional
+	UnmanagedContainers []UnmanagedContainer `json:"unmanagedContainers,omitempty"`
+
+	// InventoryError is why the containers on the Docker host of the
+	// ProviderConfig could not be listed.
+	// +optional
+	InventoryError string `json:"inventoryError,omitempty"`
+}
+
+// An UnmanagedContainer is a container on a Docker host that the provider
+// does not manage.
+type UnmanagedContainer struct {
+	// Name of the container.
+	Name string `json:"name"`
+
+	// ID of the container.
+	ID string `json:"id"`
+
+	// Image the container was created from.
+	Image string `json:"image"`
+
+	// State of the container, such as running or exited.
+	State string `json:"state"`
+
+	// StartedAt is when the container last started, if it has.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+}
+
+// EstateCounts are the numbers of managed resources of a kind.
+type EstateCounts struct {
+	// Total is the number of resources.
+	Total int32 `json:"total"`
+
+	// Drifted is the number of resources whose drift waits for a
+	// maintenance window.
+	Drifted int32 `json:"drifted"`
+
+	// Failing is the number of resources that are failing to sync or are
+	// unavailable.
+	Failing int32 `json:"failing"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DockerEstateReport summarizes the Docker resources managed by the
+// provider, per ProviderConfig, refreshing them periodically.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="TOTAL",type="integer",JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="DRIFTED",type="integer",JSONPath=".status.drifted"
+// +kubebuilder:printcolumn:name="FAILING",type="integer",JSONPath=".status.failing"
+// +kubebuilder:printcolumn:name="UNMANAGED",type="integer",JSONPath=".status.unmanaged",priority=1
+// +kubebuilder:printcolumn:name="REFRESHED",type="date",JSONPath=".status.refreshedAt"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,docker}
+type DockerEstateReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DockerEstateReportSpec   `json:"spec,omitempty"`
+	Status DockerEstateReportStatus `json:"status,omitempty"`
+}
+
+// GetCondition returns the condition for the given ConditionType.
+func (r *DockerEstateReport) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
+// SetConditions sets the conditions on the report.
+func (r *DockerEstateReport) SetConditions(c ...xpv1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// +kubebuilder:object:root=true
+
+// DockerEstateReportList contains a list of DockerEstateReport.
+type DockerEstateReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DockerEstateReport `json:"items"`
+}
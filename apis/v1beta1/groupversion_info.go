@@ -42,6 +42,8 @@ func addKnownTypes(s *runtime.Scheme) error {
 		&ProviderConfigList{},
 		&ProviderConfigUsage{},
 		&ProviderConfigUsageList{},
+		&DockerEstateReport{},
+		&DockerEstateReportList{},
 	)
 	return nil
 }
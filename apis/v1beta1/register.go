@@ -29,3 +29,11 @@ var (
 	ProviderConfigKindAPIVersion   = ProviderConfigKind + "." + SchemeGroupVersion.String()
 	ProviderConfigGroupVersionKind = SchemeGroupVersion.WithKind(ProviderConfigKind)
 )
+
+// DockerEstateReport type metadata.
+var (
+	DockerEstateReportKind             = reflect.TypeOf(DockerEstateReport{}).Name()
+	DockerEstateReportGroupKind        = schema.GroupKind{Group: Group, Kind: DockerEstateReportKind}
+	DockerEstateReportKindAPIVersion   = DockerEstateReportKind + "." + SchemeGroupVersion.String()
+	DockerEstateReportGroupVersionKind = SchemeGroupVersion.WithKind(DockerEstateReportKind)
+)
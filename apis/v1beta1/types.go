@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,6 +32,10 @@ type ProviderConfigSpec struct {
 	// - unix:///var/run/docker.sock (Unix socket)
 	// - tcp://host:port (TCP without TLS)
 	// - tcp://host:port (TCP with TLS when TLSConfig is provided)
+	// - npipe:////./pipe/docker_engine (Windows named pipe)
+	// SSH (ssh://) hosts are not supported.
+	// +kubebuilder:validation:XValidation:rule="self.matches('^(unix|tcp|npipe)://.+')",message="host must be a unix://, tcp:// or npipe:// URL"
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('ssh://')",message="ssh:// Docker hosts are not supported; expose the Docker API over tcp:// with TLS instead"
 	// +optional
 	Host *string `json:"host,omitempty"`
 
@@ -53,6 +58,250 @@ type ProviderConfigSpec struct {
 	// This can be overridden per-container.
 	// +optional
 	RegistryAuth *RegistryAuth `json:"registryAuth,omitempty"`
+
+	// Policy constrains the containers created through this ProviderConfig.
+	// +optional
+	Policy *Policy `json:"policy,omitempty"`
+
+	// Injection is merged into every container created through this
+	// ProviderConfig. Containers opt out with the
+	// docker.crossplane.io/skip-injection annotation.
+	// +optional
+	Injection *ContainerInjection `json:"injection,omitempty"`
+
+	// Webhooks are notified when containers created through this
+	// ProviderConfig move between lifecycle phases.
+	// +optional
+	Webhooks []Webhook `json:"webhooks,omitempty"`
+
+	// Audit records the mutating Docker API calls made for each container
+	// created through this ProviderConfig in the container's status.
+	// +optional
+	Audit *Audit `json:"audit,omitempty"`
+
+	// Guardrails limit the containers that can be created through this
+	// ProviderConfig, counting those already on its Docker host.
+	// +optional
+	Guardrails *Guardrails `json:"guardrails,omitempty"`
+
+	// Naming names the containers created through this ProviderConfig that
+	// do not set a name of their own, so that the containers of several
+	// clusters sharing one Docker host do not collide.
+	// +optional
+	Naming *ContainerNaming `json:"naming,omitempty"`
+
+	// UsageExport periodically exports the CPU and memory used by the
+	// containers and stacks created through this ProviderConfig, so that a
+	// shared Docker host can be charged back to the teams using it.
+	// +optional
+	UsageExport *UsageExport `json:"usageExport,omitempty"`
+
+	// UserAgent is sent with each Docker API call made through this
+	// ProviderConfig, so that the audit logs of the Docker daemon attribute
+	// calls to the provider. Defaults to provider-docker/<version>.
+	// +optional
+	UserAgent *string `json:"userAgent,omitempty"`
+
+	// RequestHeaders are sent with each Docker API call made through this
+	// ProviderConfig, in addition to the headers identifying the managed
+	// resource the call is made for.
+	// +optional
+	RequestHeaders map[string]string `json:"requestHeaders,omitempty"`
+
+	// ClusterDNS registers the ports published by the containers created
+	// through this ProviderConfig as Kubernetes Services, so that workloads
+	// in the cluster reach them by their cluster DNS names.
+	// +optional
+	ClusterDNS *ClusterDNS `json:"clusterDNS,omitempty"`
+}
+
+// ClusterDNS registers each container that publishes ports as a Service
+// named after it, whose endpoint is its Docker host.
+type ClusterDNS struct {
+	// Address the cluster reaches the Docker host at. An IP address is
+	// registered as the endpoint of a Service without a selector, which
+	// forwards the ports of each container to those it publishes; a
+	// hostname is registered as an ExternalName Service, reached at the
+	// published ports. Defaults to the host of the Docker endpoint, so must
+	// be set for a Docker host reached over a socket. A namespaced
+	// ProviderConfig may only set the host of its Docker endpoint.
+	// +optional
+	Address *string `json:"address,omitempty"`
+
+	// Namespace of the Services of cluster scoped containers. Namespaced
+	// containers are registered in their own namespace. Defaults to
+	// default.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Guardrails are limits enforced when a container is created, so that a
+// misconfigured composition cannot exhaust a Docker host.
+type Guardrails struct {
+	// MaxContainersPerProviderConfig is the most containers that may exist
+	// on the host having been created through this ProviderConfig.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxContainersPerProviderConfig *int32 `json:"maxContainersPerProviderConfig,omitempty"`
+
+	// MaxTotalMemory is the most memory that the containers created through
+	// this ProviderConfig may be limited to in total. A container without a
+	// memory limit counts its memory reservation, if any.
+	// +optional
+	MaxTotalMemory *resource.Quantity `json:"maxTotalMemory,omitempty"`
+}
+
+// ContainerNaming is how containers that do not set forProvider.name are
+// named. Such a container is otherwise named after its managed resource.
+type ContainerNaming struct {
+	// Prefix is added before the name of the managed resource, such as the
+	// name of the cluster.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Suffix is added after the name of the managed resource.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.-]*$`
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
+
+	// MaxLength is the longest a name may be. Longer names are truncated
+	// and end with a hash of the whole name, so that they stay unique.
+	// +kubebuilder:validation:Minimum=16
+	// +optional
+	MaxLength *int32 `json:"maxLength,omitempty"`
+}
+
+// UsageExport configures where the usage of containers is exported to. At
+// least one of configMapRef and pushgateway should be set.
+type UsageExport struct {
+	// Interval is how often the usage of each container is sampled and
+	// exported.
+	// +kubebuilder:default="5m"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ConfigMapRef is a ConfigMap that the usage of each resource is
+	// written to, as JSON under a key naming the resource. The ConfigMap is
+	// created if it does not exist.
+	// +optional
+	ConfigMapRef *UsageConfigMapReference `json:"configMapRef,omitempty"`
+
+	// Pushgateway is a Prometheus Pushgateway that the usage of each
+	// resource is pushed to.
+	// +optional
+	Pushgateway *Pushgateway `json:"pushgateway,omitempty"`
+}
+
+// UsageConfigMapReference names the ConfigMap usage is written to.
+type UsageConfigMapReference struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+}
+
+// Pushgateway is a Prometheus Pushgateway.
+type Pushgateway struct {
+	// URL of the Pushgateway, such as http://pushgateway.monitoring:9091.
+	URL string `json:"url"`
+
+	// Job the usage is pushed as.
+	// +kubebuilder:default="provider-docker"
+	// +optional
+	Job string `json:"job,omitempty"`
+}
+
+// Audit configures the audit trail kept in container status.
+type Audit struct {
+	// MaxEntries is the number of most recent calls kept. Older calls are
+	// dropped.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=20
+	// +optional
+	MaxEntries *int32 `json:"maxEntries,omitempty"`
+}
+
+// A Webhook is sent an HTTP POST with a JSON description of each container
+// lifecycle transition it is interested in. Delivery is best effort: failed
+// deliveries are logged and not retried.
+type Webhook struct {
+	// URL the transitions are posted to.
+	URL string `json:"url"`
+
+	// Phases restricts the webhook to transitions into these phases. All
+	// transitions are posted when unset.
+	// +kubebuilder:validation:items:Enum=Pending;Pulling;Creating;Starting;Running;Degraded;Exited;Removing
+	// +optional
+	Phases []string `json:"phases,omitempty"`
+}
+
+// ContainerInjection holds settings added to every container created through
+// a ProviderConfig. Settings the container specifies itself take precedence.
+type ContainerInjection struct {
+	// Env variables added to containers that do not set them.
+	// +optional
+	Env []InjectedEnvVar `json:"env,omitempty"`
+
+	// Mounts added to containers that do not already mount something at
+	// the same target path.
+	// +optional
+	Mounts []InjectedMount `json:"mounts,omitempty"`
+
+	// Labels added to containers that do not set them.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// InjectedEnvVar is an environment variable added to containers.
+type InjectedEnvVar struct {
+	// Name of the environment variable.
+	Name string `json:"name"`
+
+	// Value of the environment variable.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// InjectedMount is a mount added to containers, such as a log shipper socket
+// or a CA bundle.
+type InjectedMount struct {
+	// Type of the mount.
+	// +kubebuilder:validation:Enum=bind;volume;tmpfs
+	// +kubebuilder:default="bind"
+	// +optional
+	Type *string `json:"type,omitempty"`
+
+	// Source is the host path for bind mounts or the volume name for volume
+	// mounts. It is ignored for tmpfs mounts.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Target is the path the mount appears at inside the container.
+	Target string `json:"target"`
+
+	// ReadOnly mounts the source read-only.
+	// +optional
+	ReadOnly *bool `json:"readOnly,omitempty"`
+}
+
+// Policy constrains containers created through a ProviderConfig.
+type Policy struct {
+	// DefaultSecurityProfile is applied to containers that do not set a
+	// securityProfile of their own.
+	// +kubebuilder:validation:Enum=restricted;baseline;privileged
+	// +optional
+	DefaultSecurityProfile *string `json:"defaultSecurityProfile,omitempty"`
+
+	// MinimumSecurityProfile is the least restrictive securityProfile a
+	// container may use. Containers without a securityProfile have it
+	// applied, and containers requesting privileged mode are rejected
+	// unless it is privileged.
+	// +kubebuilder:validation:Enum=restricted;baseline;privileged
+	// +optional
+	MinimumSecurityProfile *string `json:"minimumSecurityProfile,omitempty"`
 }
 
 // TLSConfig configures TLS for Docker daemon connections.
@@ -113,8 +362,10 @@ type RegistryAuth struct {
 
 // ProviderCredentials required to authenticate.
 type ProviderCredentials struct {
-	// Source of the provider credentials.
-	// +kubebuilder:validation:Enum=Secret
+	// Source of the provider credentials: a Secret, an environment variable
+	// of the provider, or a path on its filesystem, which namespaced
+	// ProviderConfigs cannot use. None connects without credentials.
+	// +kubebuilder:validation:Enum=Secret;Environment;Filesystem;None
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
@@ -21,9 +21,315 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Audit) DeepCopyInto(out *Audit) {
+	*out = *in
+	if in.MaxEntries != nil {
+		in, out := &in.MaxEntries, &out.MaxEntries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Audit.
+func (in *Audit) DeepCopy() *Audit {
+	if in == nil {
+		return nil
+	}
+	out := new(Audit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDNS) DeepCopyInto(out *ClusterDNS) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDNS.
+func (in *ClusterDNS) DeepCopy() *ClusterDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerInjection) DeepCopyInto(out *ContainerInjection) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]InjectedEnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]InjectedMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerInjection.
+func (in *ContainerInjection) DeepCopy() *ContainerInjection {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerInjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerNaming) DeepCopyInto(out *ContainerNaming) {
+	*out = *in
+	if in.MaxLength != nil {
+		in, out := &in.MaxLength, &out.MaxLength
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerNaming.
+func (in *ContainerNaming) DeepCopy() *ContainerNaming {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerNaming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerEstateReport) DeepCopyInto(out *DockerEstateReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerEstateReport.
+func (in *DockerEstateReport) DeepCopy() *DockerEstateReport {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerEstateReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DockerEstateReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerEstateReportList) DeepCopyInto(out *DockerEstateReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DockerEstateReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerEstateReportList.
+func (in *DockerEstateReportList) DeepCopy() *DockerEstateReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerEstateReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DockerEstateReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerEstateReportSpec) DeepCopyInto(out *DockerEstateReportSpec) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerEstateReportSpec.
+func (in *DockerEstateReportSpec) DeepCopy() *DockerEstateReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerEstateReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerEstateReportStatus) DeepCopyInto(out *DockerEstateReportStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.RefreshedAt != nil {
+		in, out := &in.RefreshedAt, &out.RefreshedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ProviderConfigs != nil {
+		in, out := &in.ProviderConfigs, &out.ProviderConfigs
+		*out = make([]ProviderConfigEstate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerEstateReportStatus.
+func (in *DockerEstateReportStatus) DeepCopy() *DockerEstateReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerEstateReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EstateCounts) DeepCopyInto(out *EstateCounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EstateCounts.
+func (in *EstateCounts) DeepCopy() *EstateCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(EstateCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Guardrails) DeepCopyInto(out *Guardrails) {
+	*out = *in
+	if in.MaxContainersPerProviderConfig != nil {
+		in, out := &in.MaxContainersPerProviderConfig, &out.MaxContainersPerProviderConfig
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTotalMemory != nil {
+		in, out := &in.MaxTotalMemory, &out.MaxTotalMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Guardrails.
+func (in *Guardrails) DeepCopy() *Guardrails {
+	if in == nil {
+		return nil
+	}
+	out := new(Guardrails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectedEnvVar) DeepCopyInto(out *InjectedEnvVar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectedEnvVar.
+func (in *InjectedEnvVar) DeepCopy() *InjectedEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectedEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectedMount) DeepCopyInto(out *InjectedMount) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectedMount.
+func (in *InjectedMount) DeepCopy() *InjectedMount {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectedMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Policy) DeepCopyInto(out *Policy) {
+	*out = *in
+	if in.DefaultSecurityProfile != nil {
+		in, out := &in.DefaultSecurityProfile, &out.DefaultSecurityProfile
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinimumSecurityProfile != nil {
+		in, out := &in.MinimumSecurityProfile, &out.MinimumSecurityProfile
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Policy.
+func (in *Policy) DeepCopy() *Policy {
+	if in == nil {
+		return nil
+	}
+	out := new(Policy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	*out = *in
@@ -47,6 +353,32 @@ func (in *ProviderConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigEstate) DeepCopyInto(out *ProviderConfigEstate) {
+	*out = *in
+	out.Containers = in.Containers
+	out.ComposeStacks = in.ComposeStacks
+	out.Volumes = in.Volumes
+	out.Networks = in.Networks
+	if in.UnmanagedContainers != nil {
+		in, out := &in.UnmanagedContainers, &out.UnmanagedContainers
+		*out = make([]UnmanagedContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigEstate.
+func (in *ProviderConfigEstate) DeepCopy() *ProviderConfigEstate {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigEstate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderConfigList) DeepCopyInto(out *ProviderConfigList) {
 	*out = *in
@@ -89,6 +421,60 @@ func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(Policy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Injection != nil {
+		in, out := &in.Injection, &out.Injection
+		*out = new(ContainerInjection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]Webhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(Audit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Guardrails != nil {
+		in, out := &in.Guardrails, &out.Guardrails
+		*out = new(Guardrails)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(ContainerNaming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageExport != nil {
+		in, out := &in.UsageExport, &out.UsageExport
+		*out = new(UsageExport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserAgent != nil {
+		in, out := &in.UserAgent, &out.UserAgent
+		*out = new(string)
+		**out = **in
+	}
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ClusterDNS != nil {
+		in, out := &in.ClusterDNS, &out.ClusterDNS
+		*out = new(ClusterDNS)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
@@ -177,6 +563,21 @@ func (in *ProviderCredentials) DeepCopy() *ProviderCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pushgateway) DeepCopyInto(out *Pushgateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pushgateway.
+func (in *Pushgateway) DeepCopy() *Pushgateway {
+	if in == nil {
+		return nil
+	}
+	out := new(Pushgateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryAuth) DeepCopyInto(out *RegistryAuth) {
 	*out = *in
@@ -256,3 +657,87 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnmanagedContainer) DeepCopyInto(out *UnmanagedContainer) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnmanagedContainer.
+func (in *UnmanagedContainer) DeepCopy() *UnmanagedContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(UnmanagedContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageConfigMapReference) DeepCopyInto(out *UsageConfigMapReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageConfigMapReference.
+func (in *UsageConfigMapReference) DeepCopy() *UsageConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageExport) DeepCopyInto(out *UsageExport) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(UsageConfigMapReference)
+		**out = **in
+	}
+	if in.Pushgateway != nil {
+		in, out := &in.Pushgateway, &out.Pushgateway
+		*out = new(Pushgateway)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageExport.
+func (in *UsageExport) DeepCopy() *UsageExport {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Webhook) DeepCopyInto(out *Webhook) {
+	*out = *in
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Webhook.
+func (in *Webhook) DeepCopy() *Webhook {
+	if in == nil {
+		return nil
+	}
+	out := new(Webhook)
+	in.DeepCopyInto(out)
+	return out
+}
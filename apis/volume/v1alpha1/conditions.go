@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TypeCapacityExceeded indicates whether the data stored in a volume is
+// above its CapacityAlertThreshold.
+const TypeCapacityExceeded xpv1.ConditionType = "CapacityExceeded"
+
+// Reasons a Volume is or is not above its capacity alert threshold.
+const (
+	ReasonAboveThreshold xpv1.ConditionReason = "AboveThreshold"
+	ReasonBelowThreshold xpv1.ConditionReason = "BelowThreshold"
+)
+
+// CapacityExceeded returns a condition indicating that the volume holds
+// more data than its alert threshold.
+func CapacityExceeded(used, threshold int64) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCapacityExceeded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAboveThreshold,
+		Message:            fmt.Sprintf("volume uses %d bytes, above the alert threshold of %d bytes", used, threshold),
+	}
+}
+
+// CapacityWithinThreshold returns a condition indicating that the volume
+// holds no more data than its alert threshold.
+func CapacityWithinThreshold() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCapacityExceeded,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBelowThreshold,
+	}
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -50,6 +51,98 @@ type VolumeParameters struct {
 	// Labels is a map of labels to apply to the volume.
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// CapacityAlertThreshold is the amount of data, e.g. 10Gi, above which
+	// the volume reports a CapacityExceeded condition. Usage is only
+	// available for drivers that report it, such as local.
+	// +optional
+	CapacityAlertThreshold *resource.Quantity `json:"capacityAlertThreshold,omitempty"`
+
+	// Migration migrates the data of the volume to a new Docker volume when
+	// its driver, driver options or ProviderConfig change. Without it such
+	// changes leave the existing volume as it is. The old volume is kept
+	// once its data has been migrated.
+	// +optional
+	Migration *VolumeMigration `json:"migration,omitempty"`
+}
+
+// VolumeMigration configures how the data of a volume is migrated.
+type VolumeMigration struct {
+	// HelperImage is the image of the helper containers that stream the
+	// data from the old volume to the new one. It must provide sh, tar,
+	// sha256sum, and an nc that can run a program with -e.
+	// +kubebuilder:default="busybox:1.36"
+	// +optional
+	HelperImage *string `json:"helperImage,omitempty"`
+
+	// Port is the port the helper container on the old volume's Docker host
+	// serves the data on. When the volume moves to another Docker host, the
+	// new host must be able to reach the old one on this port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=7070
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// A VolumeMigrationPhase is a step of a volume migration.
+type VolumeMigrationPhase string
+
+// Volume migration phases.
+const (
+	// MigrationCopying is streaming the data to the new volume.
+	MigrationCopying VolumeMigrationPhase = "Copying"
+
+	// MigrationCompleted has switched the volume's consumers to the new
+	// volume.
+	MigrationCompleted VolumeMigrationPhase = "Completed"
+
+	// MigrationFailed could not copy or verify the data. The migration is
+	// retried from the start.
+	MigrationFailed VolumeMigrationPhase = "Failed"
+)
+
+// VolumeMigrationStatus is the observed state of the last volume migration.
+type VolumeMigrationStatus struct {
+	// Phase is the step the migration is at.
+	Phase VolumeMigrationPhase `json:"phase"`
+
+	// Source is the Docker volume the data is migrated from.
+	Source string `json:"source"`
+
+	// SourceProviderConfigName is the ProviderConfig of the Docker host of
+	// the source volume.
+	SourceProviderConfigName string `json:"sourceProviderConfigName"`
+
+	// Target is the Docker volume the data is migrated to.
+	Target string `json:"target"`
+
+	// Checksum is the checksum of the files of the volume, which was the
+	// same for the source and target volumes.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// Consumers are the containers that were switched to the target volume,
+	// as kind/name or kind/namespace/name.
+	// +optional
+	Consumers []string `json:"consumers,omitempty"`
+
+	// PausedContainers are the IDs of the containers on the source Docker
+	// host that were paused while the data was copied.
+	// +optional
+	PausedContainers []string `json:"pausedContainers,omitempty"`
+
+	// Message describes why the migration failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartedAt is when the migration started.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// CompletedAt is when the migration completed.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
 }
 
 // A VolumeStatus represents the observed state of a Volume.
@@ -85,6 +178,20 @@ type VolumeObservation struct {
 
 	// UsageData contains information about volume usage.
 	UsageData *VolumeUsageData `json:"usageData,omitempty"`
+
+	// UsedBytes is the amount of data stored in the volume, as last
+	// computed by the Docker engine.
+	UsedBytes *int64 `json:"usedBytes,omitempty"`
+
+	// UsageCheckedAt is when UsedBytes was last computed.
+	UsageCheckedAt *metav1.Time `json:"usageCheckedAt,omitempty"`
+
+	// ProviderConfigName is the ProviderConfig of the Docker host the volume
+	// is on.
+	ProviderConfigName string `json:"providerConfigName,omitempty"`
+
+	// Migration is the state of the last migration of the volume's data.
+	Migration *VolumeMigrationStatus `json:"migration,omitempty"`
 }
 
 // VolumeUsageData contains information about volume usage.
@@ -106,6 +213,7 @@ type VolumeUsageData struct {
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="DRIVER",type="string",JSONPath=".status.atProvider.driver",priority=1
 // +kubebuilder:printcolumn:name="MOUNTPOINT",type="string",JSONPath=".status.atProvider.mountpoint",priority=1
+// +kubebuilder:printcolumn:name="USED",type="integer",JSONPath=".status.atProvider.usedBytes",priority=1
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,docker}
 type Volume struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -135,6 +243,16 @@ func (cr *Volume) SetManagementPolicies(p xpv1.ManagementPolicies) {
 	cr.Spec.ManagementPolicies = p
 }
 
+// GetProviderConfigReference returns the ProviderConfigReference field.
+func (cr *Volume) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return cr.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference sets the ProviderConfigReference field.
+func (cr *Volume) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	cr.Spec.ProviderConfigReference = p
+}
+
 // +kubebuilder:object:root=true
 
 // VolumeList contains a list of Volume.
@@ -70,9 +70,81 @@ func (in *VolumeList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeMigration) DeepCopyInto(out *VolumeMigration) {
+	*out = *in
+	if in.HelperImage != nil {
+		in, out := &in.HelperImage, &out.HelperImage
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeMigration.
+func (in *VolumeMigration) DeepCopy() *VolumeMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeMigrationStatus) DeepCopyInto(out *VolumeMigrationStatus) {
+	*out = *in
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PausedContainers != nil {
+		in, out := &in.PausedContainers, &out.PausedContainers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeMigrationStatus.
+func (in *VolumeMigrationStatus) DeepCopy() *VolumeMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeObservation) DeepCopyInto(out *VolumeObservation) {
 	*out = *in
+	if in.UsedBytes != nil {
+		in, out := &in.UsedBytes, &out.UsedBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UsageCheckedAt != nil {
+		in, out := &in.UsageCheckedAt, &out.UsageCheckedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(VolumeMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeObservation.
@@ -112,6 +184,16 @@ func (in *VolumeParameters) DeepCopyInto(out *VolumeParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.CapacityAlertThreshold != nil {
+		in, out := &in.CapacityAlertThreshold, &out.CapacityAlertThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(VolumeMigration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeParameters.
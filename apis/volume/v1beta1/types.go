@@ -59,6 +59,7 @@ type VolumeObservation v1alpha1.VolumeObservation
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="DRIVER",type="string",JSONPath=".status.atProvider.driver",priority=1
 // +kubebuilder:printcolumn:name="MOUNTPOINT",type="string",JSONPath=".status.atProvider.mountpoint",priority=1
+// +kubebuilder:printcolumn:name="USED",type="integer",JSONPath=".status.atProvider.usedBytes",priority=1
 // +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,docker,v2}
 type Volume struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -88,6 +89,16 @@ func (cr *Volume) SetManagementPolicies(p xpv1.ManagementPolicies) {
 	cr.Spec.ManagementPolicies = p
 }
 
+// GetProviderConfigReference returns the ProviderConfigReference field.
+func (cr *Volume) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return cr.Spec.ProviderConfigReference
+}
+
+// SetProviderConfigReference sets the ProviderConfigReference field.
+func (cr *Volume) SetProviderConfigReference(p *xpv1.ProviderConfigReference) {
+	cr.Spec.ProviderConfigReference = p
+}
+
 // +kubebuilder:object:root=true
 
 // VolumeList contains a list of Volume.
@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"github.com/rossigee/provider-docker/apis/volume/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -73,6 +74,20 @@ func (in *VolumeList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeObservation) DeepCopyInto(out *VolumeObservation) {
 	*out = *in
+	if in.UsedBytes != nil {
+		in, out := &in.UsedBytes, &out.UsedBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UsageCheckedAt != nil {
+		in, out := &in.UsageCheckedAt, &out.UsageCheckedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(v1alpha1.VolumeMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeObservation.
@@ -112,6 +127,16 @@ func (in *VolumeParameters) DeepCopyInto(out *VolumeParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.CapacityAlertThreshold != nil {
+		in, out := &in.CapacityAlertThreshold, &out.CapacityAlertThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(v1alpha1.VolumeMigration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeParameters.
@@ -24,7 +24,11 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
 	"github.com/rossigee/provider-docker/apis"
 	"github.com/rossigee/provider-docker/internal/controller"
+	"github.com/rossigee/provider-docker/internal/controller/container"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+	"github.com/rossigee/provider-docker/internal/envsource"
 	"github.com/rossigee/provider-docker/internal/features"
+	"github.com/rossigee/provider-docker/internal/shutdown"
 	"github.com/rossigee/provider-docker/internal/tracing"
 	"github.com/rossigee/provider-docker/internal/version"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -35,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"strings"
 	"time"
 )
 
@@ -48,14 +53,36 @@ func main() {
 		maxReconcileRate         = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
 		syncPeriod               = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
 		enableManagementPolicies = app.Flag("enable-management-policies", "Enable support for management policies.").Default("true").OverrideDefaultFromEnvar("ENABLE_MANAGEMENT_POLICIES").Bool()
+		tracingEnabled           = app.Flag("tracing", "Export OpenTelemetry traces of reconciles and Docker API calls over OTLP.").Default("false").OverrideDefaultFromEnvar("OTEL_TRACING_ENABLED").Bool()
+		tracingEndpoint          = app.Flag("tracing-endpoint", "OTLP gRPC endpoint to export traces to.").Default("localhost:4317").OverrideDefaultFromEnvar("OTEL_EXPORTER_OTLP_ENDPOINT").String()
+		tracingInsecure          = app.Flag("tracing-insecure", "Connect to the OTLP endpoint without TLS.").Default("true").OverrideDefaultFromEnvar("OTEL_EXPORTER_OTLP_INSECURE").Bool()
+		tracingSamplingRatio     = app.Flag("tracing-sampling-ratio", "Fraction of reconciles to trace, between 0 and 1.").Default("0.1").OverrideDefaultFromEnvar("OTEL_SAMPLING_RATIO").Float64()
+		tracingServiceName       = app.Flag("tracing-service-name", "Service name reported with exported traces.").Default("provider-docker").OverrideDefaultFromEnvar("OTEL_SERVICE_NAME").String()
+		pollTransitioning        = app.Flag("poll-transitioning", "How often containers that are starting or restarting are checked. Zero uses --poll.").Default(container.DefaultPollIntervals.Transitioning.String()).Duration()
+		pollStable               = app.Flag("poll-stable", "How often containers that have been running stably are checked. Zero uses --poll.").Default(container.DefaultPollIntervals.Stable.String()).Duration()
+		pollSettle               = app.Flag("poll-settle", "How long a container must run before it is checked at the --poll-stable interval.").Default(container.DefaultPollIntervals.Settle.String()).Duration()
+		snapshotTTL              = app.Flag("container-snapshot-ttl", "How often the containers on each Docker host are listed to decide which need inspecting. Zero inspects every container on every reconcile.").Default(container.DefaultSnapshotTTL.String()).Duration()
+		drainTimeout             = app.Flag("shutdown-drain-timeout", "How long to wait on shutdown for in-flight Docker operations to finish before cancelling them.").Default("30s").Duration()
+		dryRun                   = app.Flag("dry-run", "Observe resources and report the changes that would be made to Docker hosts, without making them.").Default("false").OverrideDefaultFromEnvar("DRY_RUN").Bool()
+		featureGates             = app.Flag("feature-gates", "Comma-separated Name=true|false pairs enabling or disabling feature gates, of "+strings.Join(features.GateNames(), ", ")+".").Default("").OverrideDefaultFromEnvar("FEATURE_GATES").String()
+		enableControllers        = app.Flag("enable-controllers", "Comma-separated controllers to run, of "+strings.Join(controller.Names(), ", ")+". Empty runs them all.").Default("").String()
 	)
 
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
+	gates, err := features.ParseGates(*featureGates)
+	kingpin.FatalIfError(err, "Cannot parse feature gates")
+
 	zl := zap.New(zap.UseDevMode(*debug))
 	log := logging.NewLogrLogger(zl.WithName("provider-docker"))
 
-	shutdownTracing := tracing.Init("provider-docker")
+	shutdownTracing := tracing.Init(tracing.Config{
+		Enabled:       *tracingEnabled,
+		Endpoint:      *tracingEndpoint,
+		Insecure:      *tracingInsecure,
+		SamplingRatio: *tracingSamplingRatio,
+		ServiceName:   *tracingServiceName,
+	})
 	defer shutdownTracing(context.Background())
 
 	if *debug {
@@ -72,10 +99,16 @@ func main() {
 		"platform", runtime.GOOS+"/"+runtime.GOARCH,
 		"sync-period", syncPeriod.String(),
 		"poll-interval", pollInterval.String(),
+		"poll-transitioning", pollTransitioning.String(),
+		"poll-stable", pollStable.String(),
+		"container-snapshot-ttl", snapshotTTL.String(),
+		"shutdown-drain-timeout", drainTimeout.String(),
 		"max-reconcile-rate", *maxReconcileRate,
 		"leader-election", *leaderElection,
 		"leader-election-namespace", *leaderElectionNS,
 		"management-policies", *enableManagementPolicies,
+		"tracing", *tracingEnabled,
+		"dry-run", *dryRun,
 		"debug-mode", *debug)
 
 	cfg, err := ctrl.GetConfig()
@@ -110,17 +143,67 @@ func main() {
 		o.Features.Enable(features.EnableAlphaManagementPolicies)
 		log.Info("Alpha feature enabled", "flag", features.EnableAlphaManagementPolicies)
 	}
+	gates.Apply(o.Features)
+	for _, flag := range gates.Enabled() {
+		log.Info("Feature gate enabled", "gate", flag)
+	}
 
 	if err := apis.AddToScheme(mgr.GetScheme()); err != nil {
 		kingpin.FatalIfError(err, "Cannot add Docker APIs to scheme")
 	}
 
-	if err := controller.Setup(mgr, o); err != nil {
+	container.SetPollIntervals(container.PollIntervals{
+		Transitioning: *pollTransitioning,
+		Stable:        *pollStable,
+		Settle:        *pollSettle,
+	})
+
+	container.SetSnapshotTTL(*snapshotTTL)
+
+	if *dryRun {
+		dryrun.Enable()
+		log.Info("Running in dry-run mode; Docker hosts will not be changed")
+	}
+
+	// Stores that container env vars can take their values from. Secrets
+	// are read directly rather than through the cache, so that the provider
+	// does not watch every Secret in the cluster.
+	envsource.Register(envsource.StoreSecret, envsource.NewSecretSource(mgr.GetAPIReader()))
+	envsource.Register(envsource.StoreEnv, envsource.NewEnvSource(envsource.DefaultEnvPrefix))
+
+	var enabled []string
+	for _, name := range strings.Split(*enableControllers, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			enabled = append(enabled, name)
+		}
+	}
+	if len(enabled) > 0 {
+		log.Info("Running a subset of controllers", "controllers", enabled)
+	}
+	if err := controller.Setup(mgr, o, enabled...); err != nil {
 		kingpin.FatalIfError(err, "Cannot setup Docker controllers")
 	}
 
 	kingpin.FatalIfError(mgr.AddHealthzCheck("healthz", healthz.Ping), "Cannot add health check")
 	kingpin.FatalIfError(mgr.AddReadyzCheck("readyz", healthz.Ping), "Cannot add ready check")
-
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+	kingpin.FatalIfError(mgr.AddMetricsServerExtraHandler("/features", gates), "Cannot serve feature gates")
+
+	// Keep the manager running after a termination signal until in-flight
+	// Docker operations have finished, or the drain timeout has passed, so
+	// they are not cancelled part way through. Reconciles started meanwhile
+	// fail with shutdown.ErrDraining and are retried after the restart.
+	signalCtx := ctrl.SetupSignalHandler()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-signalCtx.Done()
+		log.Info("Draining in-flight Docker operations", "timeout", drainTimeout.String())
+		drainCtx, drainCancel := context.WithTimeout(context.Background(), *drainTimeout)
+		if n := shutdown.Drain(drainCtx); n > 0 {
+			log.Info("Cancelling Docker operations still in flight", "operations", n)
+		}
+		drainCancel()
+		cancel()
+	}()
+
+	kingpin.FatalIfError(mgr.Start(ctx), "Cannot start controller manager")
 }
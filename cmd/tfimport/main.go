@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command tfimport converts the Docker resources in a Terraform state to
+// managed resources of this provider, so that the containers, networks and
+// volumes managed by the kreuzwerker/docker Terraform provider can be adopted
+// by Crossplane.
+package main
+
+import (
+	"fmt"
+	"github.com/rossigee/provider-docker/internal/tfimport"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+)
+
+func main() {
+	var (
+		app            = kingpin.New(filepath.Base(os.Args[0]), "Convert Terraform Docker resources to provider-docker managed resources.").DefaultEnvars()
+		stateFile      = app.Arg("state", "Terraform state file to convert, as written by terraform state pull. Reads standard input when -.").Default("terraform.tfstate").String()
+		providerConfig = app.Flag("provider-config", "Name of the ProviderConfig the managed resources refer to.").Default("default").String()
+	)
+
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	var (
+		data []byte
+		err  error
+	)
+	if *stateFile == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(*stateFile)
+	}
+	kingpin.FatalIfError(err, "Cannot read Terraform state")
+
+	result, err := tfimport.Convert(data, tfimport.Options{ProviderConfig: *providerConfig})
+	kingpin.FatalIfError(err, "Cannot convert Terraform state")
+
+	for _, w := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", w)
+	}
+	for _, mg := range result.Resources {
+		out, err := yaml.Marshal(mg)
+		kingpin.FatalIfError(err, "Cannot render %s", mg.GetName())
+		fmt.Printf("---\n%s", out)
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// An AuditRecord describes a mutating Docker API call once it has returned.
+type AuditRecord struct {
+	// Time the call was made.
+	Time time.Time
+
+	// Operation is the DockerClient method called, such as ContainerStop.
+	Operation string
+
+	// Target is the container, image, volume or network operated on.
+	Target string
+
+	// Args summarises the arguments of the call that matter for an audit,
+	// leaving out anything that may be sensitive, such as environment.
+	Args string
+
+	// Err is the error the call returned, if any.
+	Err error
+}
+
+// NewAuditingClient returns a DockerClient that passes a record of each
+// mutating call made through dc to record. Calls that only read state are
+// not recorded.
+func NewAuditingClient(dc DockerClient, record func(AuditRecord)) DockerClient {
+	return &auditingClient{DockerClient: dc, record: record, now: time.Now}
+}
+
+type auditingClient struct {
+	DockerClient
+	record func(AuditRecord)
+	now    func() time.Time
+}
+
+var _ DockerClient = (*auditingClient)(nil)
+
+// audit returns a function that records the call it was started for when it
+// is passed the call's error.
+func (c *auditingClient) audit(operation, target, args string) func(error) {
+	t := c.now()
+	return func(err error) {
+		c.record(AuditRecord{Time: t, Operation: operation, Target: target, Args: args, Err: err})
+	}
+}
+
+func (c *auditingClient) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig,
+	networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.CreateResponse, error) {
+	var args string
+	if config != nil {
+		args = "image=" + config.Image
+	}
+	done := c.audit("ContainerCreate", containerName, args)
+	resp, err := c.DockerClient.ContainerCreate(ctx, config, hostConfig, networkingConfig, platform, containerName)
+	done(err)
+	return resp, err
+}
+
+func (c *auditingClient) ContainerStart(ctx context.Context, containerID string, options container.StartOptions) error {
+	done := c.audit("ContainerStart", containerID, "")
+	err := c.DockerClient.ContainerStart(ctx, containerID, options)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) ContainerStop(ctx context.Context, containerID string, options container.StopOptions) error {
+	done := c.audit("ContainerStop", containerID, stopArgs(options))
+	err := c.DockerClient.ContainerStop(ctx, containerID, options)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) ContainerRestart(ctx context.Context, containerID string, options container.StopOptions) error {
+	done := c.audit("ContainerRestart", containerID, stopArgs(options))
+	err := c.DockerClient.ContainerRestart(ctx, containerID, options)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) ContainerRemove(ctx context.Context, containerID string, options container.RemoveOptions) error {
+	done := c.audit("ContainerRemove", containerID, fmt.Sprintf("force=%t removeVolumes=%t", options.Force, options.RemoveVolumes))
+	err := c.DockerClient.ContainerRemove(ctx, containerID, options)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) ContainerUpdate(ctx context.Context, containerID string, updateConfig container.UpdateConfig) (container.UpdateResponse, error) {
+	var args string
+	if updateConfig.RestartPolicy.Name != "" {
+		args = "restartPolicy=" + string(updateConfig.RestartPolicy.Name)
+	}
+	done := c.audit("ContainerUpdate", containerID, args)
+	resp, err := c.DockerClient.ContainerUpdate(ctx, containerID, updateConfig)
+	done(err)
+	return resp, err
+}
+
+func (c *auditingClient) ContainerRename(ctx context.Context, containerID, newContainerName string) error {
+	done := c.audit("ContainerRename", containerID, "name="+newContainerName)
+	err := c.DockerClient.ContainerRename(ctx, containerID, newContainerName)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) ContainerPause(ctx context.Context, containerID string) error {
+	done := c.audit("ContainerPause", containerID, "")
+	err := c.DockerClient.ContainerPause(ctx, containerID)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) ContainerUnpause(ctx context.Context, containerID string) error {
+	done := c.audit("ContainerUnpause", containerID, "")
+	err := c.DockerClient.ContainerUnpause(ctx, containerID)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) CopyToContainer(ctx context.Context, containerID, dstPath string, content io.Reader, options container.CopyToContainerOptions) error {
+	done := c.audit("CopyToContainer", containerID, "path="+dstPath)
+	err := c.DockerClient.CopyToContainer(ctx, containerID, dstPath, content, options)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) ImagePull(ctx context.Context, refStr string, options image.PullOptions) (io.ReadCloser, error) {
+	var args string
+	if options.Platform != "" {
+		args = "platform=" + options.Platform
+	}
+	done := c.audit("ImagePull", refStr, args)
+	rc, err := c.DockerClient.ImagePull(ctx, refStr, options)
+	done(err)
+	return rc, err
+}
+
+func (c *auditingClient) ImageRemove(ctx context.Context, imageID string, options image.RemoveOptions) ([]image.DeleteResponse, error) {
+	done := c.audit("ImageRemove", imageID, fmt.Sprintf("force=%t", options.Force))
+	resp, err := c.DockerClient.ImageRemove(ctx, imageID, options)
+	done(err)
+	return resp, err
+}
+
+func (c *auditingClient) VolumeCreate(ctx context.Context, options volume.CreateOptions) (volume.Volume, error) {
+	done := c.audit("VolumeCreate", options.Name, "driver="+options.Driver)
+	v, err := c.DockerClient.VolumeCreate(ctx, options)
+	done(err)
+	return v, err
+}
+
+func (c *auditingClient) VolumeRemove(ctx context.Context, volumeID string, force bool) error {
+	done := c.audit("VolumeRemove", volumeID, fmt.Sprintf("force=%t", force))
+	err := c.DockerClient.VolumeRemove(ctx, volumeID, force)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) NetworkCreate(ctx context.Context, name string, options network.CreateOptions) (network.CreateResponse, error) {
+	done := c.audit("NetworkCreate", name, "driver="+options.Driver)
+	resp, err := c.DockerClient.NetworkCreate(ctx, name, options)
+	done(err)
+	return resp, err
+}
+
+func (c *auditingClient) NetworkRemove(ctx context.Context, networkID string) error {
+	done := c.audit("NetworkRemove", networkID, "")
+	err := c.DockerClient.NetworkRemove(ctx, networkID)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+	done := c.audit("NetworkConnect", networkID, "container="+containerID)
+	err := c.DockerClient.NetworkConnect(ctx, networkID, containerID, config)
+	done(err)
+	return err
+}
+
+func (c *auditingClient) NetworkDisconnect(ctx context.Context, networkID, containerID string, force bool) error {
+	done := c.audit("NetworkDisconnect", networkID, fmt.Sprintf("container=%s force=%t", containerID, force))
+	err := c.DockerClient.NetworkDisconnect(ctx, networkID, containerID, force)
+	done(err)
+	return err
+}
+
+// stopArgs summarises the options of a stop or restart.
+func stopArgs(options container.StopOptions) string {
+	var args string
+	if options.Signal != "" {
+		args = "signal=" + options.Signal
+	}
+	if options.Timeout != nil {
+		if args != "" {
+			args += " "
+		}
+		args += fmt.Sprintf("timeout=%ds", *options.Timeout)
+	}
+	return args
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+// fakeMutator fails to stop containers and reads containers successfully.
+type fakeMutator struct {
+	DockerClient
+}
+
+func (fakeMutator) ContainerStop(_ context.Context, _ string, _ container.StopOptions) error {
+	return errors.New("boom")
+}
+
+func (fakeMutator) ContainerRemove(_ context.Context, _ string, _ container.RemoveOptions) error {
+	return nil
+}
+
+func (fakeMutator) ContainerInspect(_ context.Context, id string) (container.InspectResponse, error) {
+	return container.InspectResponse{}, nil
+}
+
+func TestAuditingClient(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var got []AuditRecord
+	c := NewAuditingClient(fakeMutator{}, func(r AuditRecord) { got = append(got, r) })
+	c.(*auditingClient).now = func() time.Time { return now }
+
+	timeout := 5
+	_ = c.ContainerStop(context.Background(), "web", container.StopOptions{Signal: "SIGTERM", Timeout: &timeout})
+	_ = c.ContainerRemove(context.Background(), "web", container.RemoveOptions{Force: true})
+	_, _ = c.ContainerInspect(context.Background(), "web")
+
+	want := []AuditRecord{
+		{Time: now, Operation: "ContainerStop", Target: "web", Args: "signal=SIGTERM timeout=5s", Err: errors.New("boom")},
+		{Time: now, Operation: "ContainerRemove", Target: "web", Args: "force=true removeVolumes=false"},
+	}
+	errText := cmp.Comparer(func(a, b error) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.Error() == b.Error()
+	})
+	if diff := cmp.Diff(want, got, errText); diff != "" {
+		t.Errorf("records: -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// A TerminalBackoff slows down retries of managed resources whose last
+// reconcile failed with a terminal error.
+//
+// The managed reconciler requeues a resource with the same exponential
+// backoff whatever error it failed with, so a resource the Docker daemon
+// rejects as invalid is retried as eagerly as one whose daemon is briefly
+// unreachable. A TerminalBackoff classifies the errors of the external
+// clients it wraps, and requeues a resource that failed with a terminal one
+// after a fixed delay instead. Changing the resource still reconciles it
+// immediately.
+type TerminalBackoff struct {
+	after time.Duration
+
+	mu     sync.Mutex
+	failed map[ktypes.NamespacedName]bool
+}
+
+// NewTerminalBackoff returns a TerminalBackoff that requeues resources that
+// failed with a terminal error after the supplied delay.
+func NewTerminalBackoff(after time.Duration) *TerminalBackoff {
+	return &TerminalBackoff{after: after, failed: map[ktypes.NamespacedName]bool{}}
+}
+
+// Connector wraps c so that the errors of the external clients it connects,
+// and of connecting them, are classified as retryable or terminal.
+func (b *TerminalBackoff) Connector(c managed.ExternalConnector) managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		if err != nil {
+			return nil, b.classify(mg, err)
+		}
+		return &classifyingClient{client: ec, backoff: b}, nil
+	})
+}
+
+// Reconciler wraps r so that a resource whose reconcile failed with a
+// terminal error is requeued after the TerminalBackoff's delay.
+func (b *TerminalBackoff) Reconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		result, err := r.Reconcile(ctx, req)
+
+		b.mu.Lock()
+		terminal := b.failed[req.NamespacedName]
+		delete(b.failed, req.NamespacedName)
+		b.mu.Unlock()
+
+		if terminal && err == nil && result.RequeueAfter == 0 {
+			return reconcile.Result{RequeueAfter: b.after}, nil
+		}
+		return result, err
+	})
+}
+
+// classify classifies err, remembering mg as failed if err is terminal.
+func (b *TerminalBackoff) classify(mg resource.Managed, err error) error {
+	err = ClassifyError(err)
+	if IsTerminal(err) {
+		b.mu.Lock()
+		b.failed[ktypes.NamespacedName{Namespace: mg.GetNamespace(), Name: mg.GetName()}] = true
+		b.mu.Unlock()
+	}
+	return err
+}
+
+type classifyingClient struct {
+	client  managed.ExternalClient
+	backoff *TerminalBackoff
+}
+
+func (c *classifyingClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.client.Observe(ctx, mg)
+	if err != nil {
+		return o, c.backoff.classify(mg, err)
+	}
+	return o, nil
+}
+
+func (c *classifyingClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, err := c.client.Create(ctx, mg)
+	if err != nil {
+		return cr, c.backoff.classify(mg, err)
+	}
+	return cr, nil
+}
+
+func (c *classifyingClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := c.client.Update(ctx, mg)
+	if err != nil {
+		return u, c.backoff.classify(mg, err)
+	}
+	return u, nil
+}
+
+func (c *classifyingClient) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	d, err := c.client.Delete(ctx, mg)
+	if err != nil {
+		return d, c.backoff.classify(mg, err)
+	}
+	return d, nil
+}
+
+func (c *classifyingClient) Disconnect(ctx context.Context) error {
+	return c.client.Disconnect(ctx)
+}
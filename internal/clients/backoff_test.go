@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestTerminalBackoff(t *testing.T) {
+	tests := map[string]struct {
+		createErr error
+		want      reconcile.Result
+	}{
+		"Terminal":  {createErr: cerrdefs.ErrInvalidArgument, want: reconcile.Result{RequeueAfter: time.Minute}},
+		"Retryable": {createErr: cerrdefs.ErrUnavailable, want: reconcile.Result{Requeue: true}},
+		"Succeeded": {want: reconcile.Result{Requeue: true}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := NewTerminalBackoff(time.Minute)
+			connector := b.Connector(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+						return managed.ExternalCreation{}, tc.createErr
+					},
+				}, nil
+			}))
+
+			// Stand in for the managed reconciler, which records errors in
+			// the resource's conditions and requeues it.
+			var createErr error
+			r := b.Reconciler(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+				cr := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: req.Name}}
+				ec, err := connector.Connect(ctx, cr)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+				_, createErr = ec.Create(ctx, cr)
+				return reconcile.Result{Requeue: true}, nil
+			}))
+
+			req := reconcile.Request{NamespacedName: ktypes.NamespacedName{Name: "web"}}
+			got, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Reconcile(...): -want, +got:\n%s", diff)
+			}
+			if tc.createErr != nil && !errors.Is(createErr, tc.createErr) {
+				t.Errorf("Create(...) = %v, want %v", createErr, tc.createErr)
+			}
+
+			// A failure is only remembered for the reconcile it happened in.
+			if len(b.failed) != 0 {
+				t.Errorf("TerminalBackoff still remembers %d failed resources", len(b.failed))
+			}
+		})
+	}
+}
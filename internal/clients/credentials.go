@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+)
+
+const (
+	errCredentialsSource = "credentials source %s is not currently supported"
+	errNoEnvSelector     = "credentials source Environment needs env.name"
+	errEnvNotSet         = "environment variable %s of the credentials is not set"
+	errNoFsSelector      = "credentials source Filesystem needs fs.path"
+	errReadCredentials   = "cannot read credentials from %s"
+)
+
+// credentialsFromData returns the credentials stored under the keys of a
+// credentials Secret: the TLS material in ca, cert and key, or in ca.pem,
+// cert.pem and key.pem as Docker names the files of a certificate
+// directory, and the registry credentials read by secretRegistryAuths.
+func credentialsFromData(data map[string][]byte) (*DockerCredentials, error) {
+	pick := func(keys ...string) []byte {
+		for _, k := range keys {
+			if v, ok := data[k]; ok {
+				return v
+			}
+		}
+		return nil
+	}
+	creds := &DockerCredentials{
+		CAData:   pick("ca", "ca.pem"),
+		CertData: pick("cert", "cert.pem"),
+		KeyData:  pick("key", "key.pem"),
+	}
+
+	auths, err := secretRegistryAuths(data)
+	if err != nil {
+		return nil, err
+	}
+	creds.RegistryAuths = auths
+	return creds, nil
+}
+
+// environmentCredentials returns the credentials in an environment variable
+// of the provider, as a credentials document.
+func environmentCredentials(sel *xpv1.EnvSelector) (*DockerCredentials, error) {
+	if sel == nil || sel.Name == "" {
+		return nil, errors.New(errNoEnvSelector)
+	}
+	v, ok := os.LookupEnv(sel.Name)
+	if !ok {
+		return nil, errors.Errorf(errEnvNotSet, sel.Name)
+	}
+	data, err := credentialsDocument([]byte(v))
+	if err != nil {
+		return nil, err
+	}
+	return credentialsFromData(data)
+}
+
+// filesystemCredentials returns the credentials at a path on the provider's
+// filesystem. A directory holds a file for each key of a credentials
+// Secret, as a Secret mounted as a volume or a Docker certificate directory
+// does; a file holds a credentials document.
+func filesystemCredentials(sel *xpv1.FsSelector) (*DockerCredentials, error) {
+	if sel == nil || sel.Path == "" {
+		return nil, errors.New(errNoFsSelector)
+	}
+	fi, err := os.Stat(sel.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, errReadCredentials, sel.Path)
+	}
+
+	var data map[string][]byte
+	if fi.IsDir() {
+		data, err = credentialsDirectory(sel.Path)
+	} else {
+		var b []byte
+		if b, err = os.ReadFile(sel.Path); err == nil {
+			data, err = credentialsDocument(b)
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, errReadCredentials, sel.Path)
+	}
+	return credentialsFromData(data)
+}
+
+// credentialsDocument parses a JSON object whose keys are those of a
+// credentials Secret. A value may be a string, such as a PEM encoded
+// certificate, or a JSON value, such as the auths of registries.
+func credentialsDocument(b []byte) (map[string][]byte, error) {
+	doc := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalCredentials)
+	}
+	data := make(map[string][]byte, len(doc))
+	for k, raw := range doc {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			data[k] = []byte(s)
+			continue
+		}
+		data[k] = raw
+	}
+	return data, nil
+}
+
+// credentialsDirectory reads each file of a directory as the key of a
+// credentials Secret named after it. The entries Kubernetes keeps in a
+// mounted Secret volume, whose names begin with "..", are skipped.
+func credentialsDirectory(dir string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string][]byte{}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "..") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if fi.IsDir() {
+			continue
+		}
+		if data[e.Name()], err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
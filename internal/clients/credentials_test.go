@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+)
+
+func TestExtractCredentialsSources(t *testing.T) {
+	const doc = `{"ca": "ca-pem", "cert": "cert-pem", "key": "key-pem", "auths": {"ghcr.io": {"username": "bot", "password": "s3cret"}}}`
+	want := &DockerCredentials{
+		CAData:        []byte("ca-pem"),
+		CertData:      []byte("cert-pem"),
+		KeyData:       []byte("key-pem"),
+		RegistryAuths: map[string]RegistryAuth{"ghcr.io": {Username: "bot", Password: "s3cret"}},
+	}
+
+	// A Docker certificate directory, as mounted from a Secret volume
+	certs := t.TempDir()
+	for name, content := range map[string]string{"ca.pem": "ca-pem", "cert.pem": "cert-pem", "key.pem": "key-pem", "auths": `{"ghcr.io": {"username": "bot", "password": "s3cret"}}`} {
+		if err := os.WriteFile(filepath.Join(certs, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(certs, "..data"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(file, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DOCKER_CREDENTIALS", doc)
+	t.Setenv("DOCKER_CREDENTIALS_INVALID", "ca-pem")
+
+	tests := map[string]struct {
+		creds   v1beta1.ProviderCredentials
+		want    *DockerCredentials
+		wantErr string
+	}{
+		"Environment": {
+			creds: v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceEnvironment, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: "DOCKER_CREDENTIALS"}}},
+			want:  want,
+		},
+		"EnvironmentNotSet": {
+			creds:   v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceEnvironment, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: "DOCKER_CREDENTIALS_UNSET"}}},
+			wantErr: "environment variable DOCKER_CREDENTIALS_UNSET of the credentials is not set",
+		},
+		"EnvironmentNotJSON": {
+			creds:   v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceEnvironment, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: "DOCKER_CREDENTIALS_INVALID"}}},
+			wantErr: errUnmarshalCredentials,
+		},
+		"EnvironmentWithoutSelector": {
+			creds:   v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceEnvironment},
+			wantErr: errNoEnvSelector,
+		},
+		"FilesystemDirectory": {
+			creds: v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceFilesystem, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: certs}}},
+			want:  want,
+		},
+		"FilesystemFile": {
+			creds: v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceFilesystem, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: file}}},
+			want:  want,
+		},
+		"FilesystemMissing": {
+			creds:   v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceFilesystem, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: filepath.Join(certs, "missing")}}},
+			wantErr: "cannot read credentials from",
+		},
+		"FilesystemWithoutSelector": {
+			creds:   v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceFilesystem},
+			wantErr: errNoFsSelector,
+		},
+		"None": {
+			creds: v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+			want:  &DockerCredentials{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &v1beta1.ProviderConfig{Spec: v1beta1.ProviderConfigSpec{Credentials: tt.creds}}
+			got, err := ExtractCredentials(context.Background(), fake.NewClientBuilder().Build(), pc)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExtractCredentials() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExtractCredentials() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ExtractCredentials(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
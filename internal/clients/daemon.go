@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/system"
+)
+
+// daemonReader is the part of DockerClient a DaemonWatch needs.
+type daemonReader interface {
+	Info(ctx context.Context) (system.Info, error)
+}
+
+// A DaemonRestart is a restart of a Docker daemon: the daemon was last seen
+// running before it restarted at Since, and first seen running again at At.
+type DaemonRestart struct {
+	Since time.Time
+	At    time.Time
+}
+
+// DaemonWatch notices the Docker daemon on each host restarting, sharing
+// the check between the reconciles of the resources on that host. The
+// Docker API reports neither when a daemon started nor the boot of its
+// host, so a daemon is taken to have restarted when it answers again after
+// failing to, or answers with another ID or version, as it does when it is
+// upgraded or its host is rebuilt.
+type DaemonWatch struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*daemonState
+}
+
+type daemonState struct {
+	mu      sync.Mutex
+	checked time.Time
+	up      time.Time
+	down    bool
+	id      string
+	version string
+	restart *DaemonRestart
+}
+
+// NewDaemonWatch returns a DaemonWatch that checks the daemon on each host
+// at most once per ttl.
+func NewDaemonWatch(ttl time.Duration) *DaemonWatch {
+	return &DaemonWatch{
+		ttl:   ttl,
+		now:   time.Now,
+		hosts: map[string]*daemonState{},
+	}
+}
+
+// Check checks the daemon on the supplied host, unless it was checked within
+// the ttl, and returns the last restart seen, if any. It reports whether
+// this check saw that restart, which only one caller is told. A daemon is
+// not known to have restarted before it has been seen running, and a nil
+// DaemonWatch sees no restarts.
+func (w *DaemonWatch) Check(ctx context.Context, c daemonReader, host string) (*DaemonRestart, bool) {
+	if w == nil {
+		return nil, false
+	}
+
+	d := w.host(host)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := w.now()
+	if now.Sub(d.checked) < w.ttl {
+		return d.restart, false
+	}
+	d.checked = now
+
+	info, err := c.Info(ctx)
+	if err != nil {
+		d.down = true
+		return d.restart, false
+	}
+
+	seen := !d.up.IsZero()
+	changed := d.down || info.ID != d.id || info.ServerVersion != d.version
+	fresh := seen && changed
+	if fresh {
+		d.restart = &DaemonRestart{Since: d.up, At: now}
+	}
+	d.up, d.down, d.id, d.version = now, false, info.ID, info.ServerVersion
+	return d.restart, fresh
+}
+
+func (w *DaemonWatch) host(host string) *daemonState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	d, ok := w.hosts[host]
+	if !ok {
+		d = &daemonState{}
+		w.hosts[host] = d
+	}
+	return d
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/system"
+	"github.com/pkg/errors"
+)
+
+type fakeDaemon struct {
+	info  system.Info
+	err   error
+	calls int
+}
+
+func (f *fakeDaemon) Info(_ context.Context) (system.Info, error) {
+	f.calls++
+	return f.info, f.err
+}
+
+func TestDaemonWatchCheck(t *testing.T) {
+	type step struct {
+		advance   time.Duration
+		down      bool
+		version   string
+		wantCalls int
+		wantFresh bool
+		// wantSince is how long after the start the daemon was last seen
+		// running before the restart seen, if any.
+		wantSince *time.Duration
+	}
+	at := func(d time.Duration) *time.Duration { return &d }
+
+	cases := map[string][]step{
+		"Steady": {
+			{version: "28.5.2", wantCalls: 1},
+			{advance: 10 * time.Second, version: "28.5.2", wantCalls: 2},
+		},
+		"ChecksOncePerTTL": {
+			{version: "28.5.2", wantCalls: 1},
+			{advance: 5 * time.Second, down: true, version: "28.5.2", wantCalls: 1},
+		},
+		"Unreachable": {
+			{version: "28.5.2", wantCalls: 1},
+			{advance: 10 * time.Second, down: true, version: "28.5.2", wantCalls: 2},
+			{advance: 10 * time.Second, version: "28.5.2", wantCalls: 3, wantFresh: true, wantSince: at(0)},
+			{advance: 10 * time.Second, version: "28.5.2", wantCalls: 4, wantSince: at(0)},
+		},
+		"Upgraded": {
+			{version: "28.5.2", wantCalls: 1},
+			{advance: 10 * time.Second, version: "29.0.0", wantCalls: 2, wantFresh: true, wantSince: at(0)},
+		},
+		"UnreachableAtFirst": {
+			{down: true, version: "28.5.2", wantCalls: 1},
+			{advance: 10 * time.Second, version: "28.5.2", wantCalls: 2},
+		},
+	}
+
+	for name, steps := range cases {
+		t.Run(name, func(t *testing.T) {
+			start := time.Unix(0, 0)
+			now := start
+			w := NewDaemonWatch(10 * time.Second)
+			w.now = func() time.Time { return now }
+			f := &fakeDaemon{}
+
+			for i, st := range steps {
+				now = now.Add(st.advance)
+				f.info = system.Info{ID: "daemon", ServerVersion: st.version}
+				f.err = nil
+				if st.down {
+					f.err = errors.New("connection refused")
+				}
+
+				restart, fresh := w.Check(context.Background(), f, "tcp://docker:2376")
+				if f.calls != st.wantCalls {
+					t.Errorf("step %d: Info calls = %d, want %d", i, f.calls, st.wantCalls)
+				}
+				if fresh != st.wantFresh {
+					t.Errorf("step %d: Check() fresh = %v, want %v", i, fresh, st.wantFresh)
+				}
+				switch {
+				case st.wantSince == nil && restart != nil:
+					t.Errorf("step %d: Check() = %+v, want no restart", i, restart)
+				case st.wantSince != nil && restart == nil:
+					t.Errorf("step %d: Check() saw no restart", i)
+				case st.wantSince != nil && !restart.Since.Equal(start.Add(*st.wantSince)):
+					t.Errorf("step %d: Check() Since = %v, want %v", i, restart.Since, start.Add(*st.wantSince))
+				}
+			}
+		})
+	}
+}
+
+func TestDaemonWatchNil(t *testing.T) {
+	var w *DaemonWatch
+	f := &fakeDaemon{}
+	if restart, fresh := w.Check(context.Background(), f, ""); restart != nil || fresh || f.calls != 0 {
+		t.Errorf("Check() = %v, %v after %d calls, want no restart and no calls", restart, fresh, f.calls)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+)
+
+// diskUsageReader is the part of DockerClient a VolumeUsageCache needs.
+type diskUsageReader interface {
+	DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error)
+}
+
+// VolumeUsageCache shares the disk usage of the volumes on each Docker host
+// between the reconciles of the volumes on that host. The daemon sizes every
+// volume to answer, so it is asked again at most once per ttl.
+type VolumeUsageCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*cachedVolumeUsage
+}
+
+type cachedVolumeUsage struct {
+	mu      sync.Mutex
+	fetched time.Time
+	volumes []*volume.Volume
+}
+
+// NewVolumeUsageCache returns a VolumeUsageCache that asks the daemon on
+// each host at most once per ttl.
+func NewVolumeUsageCache(ttl time.Duration) *VolumeUsageCache {
+	return &VolumeUsageCache{
+		ttl:   ttl,
+		now:   time.Now,
+		hosts: map[string]*cachedVolumeUsage{},
+	}
+}
+
+// Get returns the volumes on the supplied host with their disk usage, unless
+// it was asked within the ttl. An error is not cached, and a nil
+// VolumeUsageCache always asks.
+func (c *VolumeUsageCache) Get(ctx context.Context, r diskUsageReader, host string) ([]*volume.Volume, error) {
+	if c == nil {
+		return volumeUsage(ctx, r)
+	}
+
+	h := c.host(host)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := c.now()
+	if !h.fetched.IsZero() && now.Sub(h.fetched) < c.ttl {
+		return h.volumes, nil
+	}
+	volumes, err := volumeUsage(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	h.fetched, h.volumes = now, volumes
+	return volumes, nil
+}
+
+func (c *VolumeUsageCache) host(host string) *cachedVolumeUsage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	h, ok := c.hosts[host]
+	if !ok {
+		h = &cachedVolumeUsage{}
+		c.hosts[host] = h
+	}
+	return h
+}
+
+// volumeUsage asks a daemon for the disk usage of its volumes only.
+func volumeUsage(ctx context.Context, r diskUsageReader) ([]*volume.Volume, error) {
+	du, err := r.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		return nil, err
+	}
+	return du.Volumes, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/pkg/errors"
+)
+
+type fakeDiskUsage struct {
+	volumes []*volume.Volume
+	err     error
+	calls   int
+}
+
+func (f *fakeDiskUsage) DiskUsage(_ context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+	f.calls++
+	if len(options.Types) != 1 || options.Types[0] != types.VolumeObject {
+		return types.DiskUsage{}, errors.Errorf("DiskUsage() asked for %v, want volumes only", options.Types)
+	}
+	return types.DiskUsage{Volumes: f.volumes}, f.err
+}
+
+func TestVolumeUsageCacheGet(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	c := NewVolumeUsageCache(time.Minute)
+	c.now = func() time.Time { return now }
+	sized := func(size int64) []*volume.Volume {
+		return []*volume.Volume{{Name: "data", UsageData: &volume.UsageData{Size: size, RefCount: 1}}}
+	}
+	d := &fakeDiskUsage{volumes: sized(100)}
+
+	get := func(host string, wantCalls int) int64 {
+		t.Helper()
+		volumes, err := c.Get(context.Background(), d, host)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if d.calls != wantCalls {
+			t.Errorf("Get() asked the daemon %d times, want %d", d.calls, wantCalls)
+		}
+		return volumes[0].UsageData.Size
+	}
+
+	get("edge", 1)
+	d.volumes = sized(200)
+	if size := get("edge", 1); size != 100 {
+		t.Errorf("Get() within the ttl = %d, want the cached size", size)
+	}
+	if size := get("core", 2); size != 200 {
+		t.Errorf("Get() of another host = %d, want its own size", size)
+	}
+	now = now.Add(time.Minute)
+	if size := get("edge", 3); size != 200 {
+		t.Errorf("Get() after the ttl = %d, want the daemon's size", size)
+	}
+
+	// Errors are not cached
+	now = now.Add(time.Minute)
+	d.err = errors.New("connection refused")
+	if _, err := c.Get(context.Background(), d, "edge"); err == nil {
+		t.Error("Get() of a daemon that cannot be reached did not fail")
+	}
+	d.err = nil
+	get("edge", 5)
+
+	var none *VolumeUsageCache
+	if _, err := none.Get(context.Background(), d, "edge"); err != nil || d.calls != 6 {
+		t.Errorf("Get() on nil = %v after %d calls, want the daemon asked", err, d.calls)
+	}
+}
@@ -20,17 +20,23 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
 	"time"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/system"
@@ -39,11 +45,18 @@ import (
 	"github.com/docker/go-connections/tlsconfig"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
+	namespacedv1beta1 "github.com/rossigee/provider-docker/apis/namespaced/v1beta1"
 	"github.com/rossigee/provider-docker/apis/v1beta1"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+	"github.com/rossigee/provider-docker/internal/tracing"
+	"github.com/rossigee/provider-docker/internal/version"
+	"go.opentelemetry.io/otel"
 
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -68,10 +81,31 @@ func NewNotFoundError(resourceType, resourceID string) error {
 const (
 	errNoProviderConfig     = "no providerConfig specified"
 	errGetProviderConfig    = "cannot get providerConfig"
+	errCrossNamespaceSecret = "credentials secret in namespace %q cannot be used by a ProviderConfig in namespace %q"
+	errNamespacedCertPath   = "tlsConfig.certPath cannot be used by a namespaced ProviderConfig"
+	errNamespacedSource     = "credentials source %s cannot be used by a namespaced ProviderConfig"
+	errNamespacedHost       = "a namespaced ProviderConfig must set host, rather than use the Docker host of the provider"
+	errNamespacedSocket     = "a namespaced ProviderConfig cannot use the local socket %s of the provider"
+	errNamespacedDNSAddress = "clusterDNS.address %q of a namespaced ProviderConfig must be the address of its host"
 	errTrackUsage           = "cannot track ProviderConfig usage"
+	errApplyUsage           = "cannot apply ProviderConfigUsage"
+	errReleaseUsage         = "cannot delete ProviderConfigUsage"
 	errExtractCredentials   = "cannot extract credentials"
 	errUnmarshalCredentials = "cannot unmarshal credentials"
 	errCreateDockerClient   = "cannot create Docker client"
+	errSSHHost              = "ssh:// Docker hosts are not supported; expose the Docker API over tcp:// with TLS instead, for example through a tunnel from a bastion"
+	errHostScheme           = "host must be a unix://, tcp:// or npipe:// URL"
+	errNamedPipeHost        = "npipe:// Docker hosts can only be used when the provider runs on Windows"
+)
+
+// Headers sent with each Docker API call, identifying the managed resource
+// the call is made for, so that the audit logs of the Docker daemon can
+// attribute calls to resources.
+const (
+	HeaderResourceKind      = "X-Crossplane-Resource-Kind"
+	HeaderResourceName      = "X-Crossplane-Resource-Name"
+	HeaderResourceNamespace = "X-Crossplane-Resource-Namespace"
+	HeaderResourceUID       = "X-Crossplane-Resource-Uid"
 )
 
 // DockerClient is an interface for Docker operations.
@@ -86,12 +120,17 @@ type DockerClient interface {
 	ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error)
 	ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error)
 	ContainerLogs(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error)
-	// ContainerStats temporarily disabled due to complex interface mocking
-	// ContainerStats(ctx context.Context, containerID string, stream bool) (container.StatsResponseReader, error)
+	ContainerStatsOneShot(ctx context.Context, containerID string) (container.StatsResponseReader, error)
 	ContainerUpdate(ctx context.Context, containerID string, updateConfig container.UpdateConfig) (container.UpdateResponse, error)
 	ContainerRename(ctx context.Context, containerID, newContainerName string) error
 	ContainerPause(ctx context.Context, containerID string) error
 	ContainerUnpause(ctx context.Context, containerID string) error
+	CopyFromContainer(ctx context.Context, containerID, srcPath string) (io.ReadCloser, container.PathStat, error)
+	CopyToContainer(ctx context.Context, containerID, dstPath string, content io.Reader, options container.CopyToContainerOptions) error
+	ContainerExecCreate(ctx context.Context, containerID string, options container.ExecOptions) (container.ExecCreateResponse, error)
+	ContainerExecStart(ctx context.Context, execID string, config container.ExecStartOptions) error
+	ContainerExecInspect(ctx context.Context, execID string) (container.ExecInspect, error)
+	ContainerExecAttach(ctx context.Context, execID string, config container.ExecAttachOptions) (types.HijackedResponse, error)
 
 	// Image operations
 	ImagePull(ctx context.Context, refStr string, options image.PullOptions) (io.ReadCloser, error)
@@ -117,6 +156,8 @@ type DockerClient interface {
 	Ping(ctx context.Context) (types.Ping, error)
 	Info(ctx context.Context) (system.Info, error)
 	ServerVersion(ctx context.Context) (types.Version, error)
+	DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error)
+	Events(ctx context.Context, options events.ListOptions) (<-chan events.Message, <-chan error)
 
 	// Close the client
 	Close() error
@@ -131,37 +172,97 @@ type dockerClient struct {
 var _ DockerClient = (*dockerClient)(nil)
 
 // NewDockerClient creates a new Docker client from a ProviderConfig.
+// The returned client traces each Docker API call as a child of the span
+// carried by the context it is called with.
 func NewDockerClient(ctx context.Context, k8s k8sclient.Client, mg resource.Managed) (DockerClient, error) {
+	ctx, span := tracing.StartSpan(ctx, "docker.connect",
+		tracing.SpanAttrs(mg.GetObjectKind().GroupVersionKind().Kind, mg.GetName(), "connect")...)
+	defer span.End()
+
 	pc, err := GetProviderConfig(ctx, k8s, mg)
 	if err != nil {
-		return nil, errors.Wrap(err, errGetProviderConfig)
+		return nil, tracing.RecordError(span, errors.Wrap(err, errGetProviderConfig))
 	}
 
 	if err := TrackProviderConfigUsage(ctx, k8s, mg); err != nil {
-		return nil, errors.Wrap(err, errTrackUsage)
+		return nil, tracing.RecordError(span, errors.Wrap(err, errTrackUsage))
+	}
+
+	creds, err := ExtractCredentials(ctx, k8s, pc)
+	if err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, errExtractCredentials))
 	}
 
+	c, err := connect(pc, creds, mg)
+	return c, tracing.RecordError(span, err)
+}
+
+// NewDockerClientFor returns a client for the Docker host of another
+// ProviderConfig than the managed resource's own, such as the host a volume
+// is being migrated from. The usage of that ProviderConfig is not tracked.
+func NewDockerClientFor(ctx context.Context, k8s k8sclient.Client, mg resource.Managed, ref *xpv1.ProviderConfigReference) (DockerClient, error) {
+	pc, err := GetProviderConfig(ctx, k8s, WithProviderConfig(mg, ref))
+	if err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
 	creds, err := ExtractCredentials(ctx, k8s, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errExtractCredentials)
 	}
+	return connect(pc, creds, mg)
+}
+
+// WithProviderConfig returns the managed resource as if it referred to
+// another ProviderConfig.
+func WithProviderConfig(mg resource.Managed, ref *xpv1.ProviderConfigReference) resource.Managed {
+	return &referencing{Managed: mg, ref: ref}
+}
+
+// referencing is a managed resource that refers to another ProviderConfig.
+type referencing struct {
+	resource.Managed
+	ref *xpv1.ProviderConfigReference
+}
+
+func (r *referencing) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return r.ref
+}
 
-	dockerCli, err := createDockerClient(pc, creds)
+// connect returns a client for the Docker host of a ProviderConfig, which
+// cannot change the host in dry-run mode. Changes to the networks of the
+// host are serialised with those of every other client of the host.
+func connect(pc *v1beta1.ProviderConfig, creds *DockerCredentials, mg resource.Managed) (DockerClient, error) {
+	dockerCli, err := createDockerClient(pc, creds, identify(pc, mg)...)
 	if err != nil {
 		return nil, errors.Wrap(err, errCreateDockerClient)
 	}
 
-	return &dockerClient{Client: dockerCli}, nil
+	if dryrun.Enabled() {
+		return ReadOnly(&dockerClient{Client: dockerCli}), nil
+	}
+	return SerializeNetworks(&dockerClient{Client: dockerCli}, dockerCli.DaemonHost()), nil
 }
 
 // createDockerClient creates a new Docker client with the given configuration.
-func createDockerClient(pc *v1beta1.ProviderConfig, creds *DockerCredentials) (*dockerclient.Client, error) {
+// The extra options are applied last.
+func createDockerClient(pc *v1beta1.ProviderConfig, creds *DockerCredentials, extra ...dockerclient.Opt) (*dockerclient.Client, error) {
 	opts := []dockerclient.Opt{
-		dockerclient.FromEnv,
+		dockerclient.WithTraceProvider(otel.GetTracerProvider()),
 	}
 
-	// Set host if specified
+	// The DOCKER_HOST, DOCKER_CERT_PATH and other variables of the provider's
+	// environment only apply to cluster scoped configs, so that namespaced
+	// ProviderConfigs cannot use the provider's host or TLS material.
+	if pc.GetNamespace() == "" {
+		opts = append(opts, dockerclient.FromEnv)
+	}
+
+	// Set host if specified. The CRD rejects hosts with other schemes, but
+	// ProviderConfigs admitted before it did are checked here as well.
 	if pc.Spec.Host != nil {
+		if err := validateHost(field.NewPath("spec", "host"), *pc.Spec.Host); err != nil {
+			return nil, err
+		}
 		opts = append(opts, dockerclient.WithHost(*pc.Spec.Host))
 	}
 
@@ -188,10 +289,62 @@ func createDockerClient(pc *v1beta1.ProviderConfig, creds *DockerCredentials) (*
 		timeout = pc.Spec.Timeout.Duration
 	}
 	opts = append(opts, dockerclient.WithTimeout(timeout))
+	opts = append(opts, extra...)
 
 	return dockerclient.NewClientWithOpts(opts...)
 }
 
+// identify returns the options that identify the provider and the managed
+// resource to the Docker daemon: the User-Agent of the ProviderConfig, and
+// its request headers together with those naming the resource.
+func identify(pc *v1beta1.ProviderConfig, mg resource.Managed) []dockerclient.Opt {
+	userAgent := "provider-docker/" + version.Version
+	if pc.Spec.UserAgent != nil && *pc.Spec.UserAgent != "" {
+		userAgent = *pc.Spec.UserAgent
+	}
+
+	headers := make(map[string]string, len(pc.Spec.RequestHeaders)+4)
+	maps.Copy(headers, pc.Spec.RequestHeaders)
+	for header, value := range map[string]string{
+		HeaderResourceKind:      mg.GetObjectKind().GroupVersionKind().Kind,
+		HeaderResourceName:      mg.GetName(),
+		HeaderResourceNamespace: mg.GetNamespace(),
+		HeaderResourceUID:       string(mg.GetUID()),
+	} {
+		if value != "" {
+			headers[header] = value
+		}
+	}
+
+	return []dockerclient.Opt{
+		dockerclient.WithUserAgent(userAgent),
+		dockerclient.WithHTTPHeaders(headers),
+	}
+}
+
+// validateHost checks that host uses a scheme the Docker client can connect
+// over. There is no SSH transport; the Docker client would otherwise accept
+// an ssh:// host and fail on every call. Named pipes only exist on Windows.
+func validateHost(path *field.Path, host string) *field.Error {
+	scheme, addr, ok := strings.Cut(host, "://")
+	if !ok || addr == "" {
+		return field.Invalid(path, host, errHostScheme)
+	}
+	switch scheme {
+	case "unix", "tcp":
+		return nil
+	case "npipe":
+		if runtime.GOOS != "windows" {
+			return field.Invalid(path, host, errNamedPipeHost)
+		}
+		return nil
+	case "ssh":
+		return field.Forbidden(path, errSSHHost)
+	default:
+		return field.NotSupported(path, scheme, []string{"unix", "tcp", "npipe"})
+	}
+}
+
 // createHTTPClientWithTLS creates an HTTP client with TLS configuration.
 func createHTTPClientWithTLS(tlsConfig *v1beta1.TLSConfig, creds *DockerCredentials) (*http.Client, error) {
 	tlsConf := &tls.Config{}
@@ -278,22 +431,49 @@ func createHTTPClientWithTLS(tlsConfig *v1beta1.TLSConfig, creds *DockerCredenti
 }
 
 // GetProviderConfig returns the ProviderConfig for the given managed resource.
+// A namespaced resource referencing a ProviderConfig uses the one in its own
+// namespace, which may only read credentials from that namespace. Any other
+// reference resolves to the ClusterProviderConfig of that name, falling back
+// to the cluster scoped docker.crossplane.io ProviderConfig.
 func GetProviderConfig(ctx context.Context, k8s k8sclient.Client, mg resource.Managed) (*v1beta1.ProviderConfig, error) {
-	// Get provider config reference from the managed resource's ResourceSpec
-	var pcRef *xpv1.ProviderConfigReference
-
-	// Type assert to extract the ProviderConfigReference from the managed resource
-	switch mr := mg.(type) {
-	case interface {
-		GetProviderConfigReference() *xpv1.ProviderConfigReference
-	}:
-		pcRef = mr.GetProviderConfigReference()
-	default:
-		return nil, errors.New(errNoProviderConfig)
+	pcRef, err := providerConfigReference(mg)
+	if err != nil {
+		return nil, err
 	}
 
-	if pcRef == nil {
-		return nil, errors.New(errNoProviderConfig)
+	switch {
+	case pcRef.Kind == namespacedv1beta1.ProviderConfigKind && mg.GetNamespace() != "":
+		npc := &namespacedv1beta1.ProviderConfig{}
+		if err := k8s.Get(ctx, ktypes.NamespacedName{Namespace: mg.GetNamespace(), Name: pcRef.Name}, npc); err != nil {
+			return nil, errors.Wrap(err, errGetProviderConfig)
+		}
+		if err := checkCredentialIsolation(npc); err != nil {
+			return nil, err
+		}
+		pc := &v1beta1.ProviderConfig{
+			ObjectMeta: npc.ObjectMeta,
+			Spec:       v1beta1.ProviderConfigSpec(npc.Spec),
+			Status:     v1beta1.ProviderConfigStatus(npc.Status),
+		}
+		if ref := pc.Spec.Credentials.SecretRef; ref != nil {
+			ref.Namespace = npc.GetNamespace()
+		}
+		return pc, nil
+	case pcRef.Kind == namespacedv1beta1.ProviderConfigKind:
+		// Cluster scoped resources can only use cluster scoped configs
+	default:
+		cpc := &namespacedv1beta1.ClusterProviderConfig{}
+		err := k8s.Get(ctx, ktypes.NamespacedName{Name: pcRef.Name}, cpc)
+		if err == nil {
+			return &v1beta1.ProviderConfig{
+				ObjectMeta: cpc.ObjectMeta,
+				Spec:       v1beta1.ProviderConfigSpec(cpc.Spec),
+				Status:     v1beta1.ProviderConfigStatus(cpc.Status),
+			}, nil
+		}
+		if !kerrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, errGetProviderConfig)
+		}
 	}
 
 	pc := &v1beta1.ProviderConfig{}
@@ -304,41 +484,254 @@ func GetProviderConfig(ctx context.Context, k8s k8sclient.Client, mg resource.Ma
 	return pc, nil
 }
 
-// TrackProviderConfigUsage tracks the usage of a ProviderConfig.
-func TrackProviderConfigUsage(ctx context.Context, k8s k8sclient.Client, mg resource.Managed) error {
-	// Get provider config reference from the managed resource's ResourceSpec
-	var pcRef *xpv1.ProviderConfigReference
+// checkCredentialIsolation rejects a namespaced ProviderConfig that refers to
+// credentials outside its namespace, so that one tenant cannot use another
+// tenant's secrets, or the TLS material and environment of the provider. It
+// must set its host to a remote endpoint, as the default, or a unix:// or
+// npipe:// host, would be the Docker socket of the provider, and may only register the address of that host for cluster DNS,
+// so that it cannot point a Service at an arbitrary endpoint in the cluster.
+func checkCredentialIsolation(npc *namespacedv1beta1.ProviderConfig) error {
+	if ref := npc.Spec.Credentials.SecretRef; ref != nil && ref.Namespace != "" && ref.Namespace != npc.GetNamespace() {
+		return errors.Errorf(errCrossNamespaceSecret, ref.Namespace, npc.GetNamespace())
+	}
+	if npc.Spec.Host == nil || *npc.Spec.Host == "" {
+		return errors.New(errNamespacedHost)
+	}
+	if h := *npc.Spec.Host; strings.HasPrefix(h, "unix://") || strings.HasPrefix(h, "npipe://") {
+		return errors.Errorf(errNamespacedSocket, h)
+	}
+	if npc.Spec.TLSConfig != nil && npc.Spec.TLSConfig.CertPath != nil {
+		return errors.New(errNamespacedCertPath)
+	}
+	if dns := npc.Spec.ClusterDNS; dns != nil && dns.Address != nil && *dns.Address != "" {
+		u, err := url.Parse(*npc.Spec.Host)
+		if err != nil || u.Hostname() == "" || u.Hostname() != *dns.Address {
+			return errors.Errorf(errNamespacedDNSAddress, *dns.Address)
+		}
+	}
+	switch src := npc.Spec.Credentials.Source; src {
+	case xpv1.CredentialsSourceEnvironment, xpv1.CredentialsSourceFilesystem:
+		return errors.Errorf(errNamespacedSource, src)
+	}
+	return nil
+}
 
-	// Type assert to extract the ProviderConfigReference from the managed resource
-	switch mr := mg.(type) {
-	case interface {
-		GetProviderConfigReference() *xpv1.ProviderConfigReference
-	}:
-		pcRef = mr.GetProviderConfigReference()
-	default:
-		return errors.New(errNoProviderConfig)
+// TrackProviderConfigUsage records that the managed resource uses its
+// ProviderConfig. The usage is keyed by the resource's UID, so tracking is
+// idempotent and follows the resource if it switches to another
+// ProviderConfig. Resources that are being deleted are not tracked, so that a
+// released usage is not recreated while the resource is finalized.
+func TrackProviderConfigUsage(ctx context.Context, k8s k8sclient.Client, mg resource.Managed) error {
+	pcRef, err := providerConfigReference(mg)
+	if err != nil {
+		return err
 	}
+	return trackUsage(ctx, k8s, mg, pcRef, string(mg.GetUID()))
+}
 
-	if pcRef == nil {
-		return errors.New(errNoProviderConfig)
+// TrackStandbyProviderConfigUsage records that the managed resource also
+// uses the ProviderConfig of a standby Docker host it can fail over to. The
+// usage is kept apart from that of its own ProviderConfig, so that neither
+// can be deleted while the resource may be managed through it.
+func TrackStandbyProviderConfigUsage(ctx context.Context, k8s k8sclient.Client, mg resource.Managed, ref *xpv1.ProviderConfigReference) error {
+	return trackUsage(ctx, k8s, mg, ref, standbyUsageName(mg))
+}
+
+// trackUsage records that the managed resource uses a ProviderConfig, in the
+// usage of the given name.
+func trackUsage(ctx context.Context, k8s k8sclient.Client, mg resource.Managed, pcRef *xpv1.ProviderConfigReference, name string) error {
+	// Objects that were never persisted have no UID to key a usage by
+	if meta.WasDeleted(mg) || mg.GetUID() == "" {
+		return nil
+	}
+	if mg.GetNamespace() != "" {
+		return trackNamespacedUsage(ctx, k8s, mg, pcRef, name)
 	}
 
-	usage := &v1beta1.ProviderConfigUsage{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: fmt.Sprintf("%s-%s", pcRef.Name, mg.GetUID()),
+	gvk := mg.GetObjectKind().GroupVersionKind()
+	desired := xpv1.ProviderConfigUsage{
+		ProviderConfigReference: xpv1.Reference{Name: pcRef.Name},
+		ResourceReference: xpv1.TypedReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       mg.GetName(),
+			UID:        mg.GetUID(),
 		},
-		ProviderConfigUsage: xpv1.ProviderConfigUsage{
-			ProviderConfigReference: xpv1.Reference{Name: pcRef.Name},
-			ResourceReference: xpv1.TypedReference{
-				APIVersion: mg.GetObjectKind().GroupVersionKind().GroupVersion().String(),
-				Kind:       mg.GetObjectKind().GroupVersionKind().Kind,
-				Name:       mg.GetName(),
-				UID:        mg.GetUID(),
+	}
+
+	usage := &v1beta1.ProviderConfigUsage{}
+	err := k8s.Get(ctx, ktypes.NamespacedName{Name: name}, usage)
+	if kerrors.IsNotFound(err) {
+		usage = &v1beta1.ProviderConfigUsage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{xpv1.LabelKeyProviderName: pcRef.Name},
 			},
+			ProviderConfigUsage: desired,
+		}
+		// Let the garbage collector remove the usage of a cluster scoped
+		// resource even if it is deleted without being finalized. Cluster
+		// scoped objects cannot be owned by namespaced ones.
+		if mg.GetNamespace() == "" && !gvk.Empty() {
+			usage.SetOwnerReferences([]metav1.OwnerReference{meta.AsOwner(meta.TypedReferenceTo(mg, gvk))})
+		}
+		// A concurrent reconcile may have created it in the meantime
+		return errors.Wrap(resource.Ignore(kerrors.IsAlreadyExists, k8s.Create(ctx, usage)), errApplyUsage)
+	}
+	if err != nil {
+		return errors.Wrap(err, errApplyUsage)
+	}
+
+	if usage.ProviderConfigReference == desired.ProviderConfigReference &&
+		usage.ResourceReference == desired.ResourceReference &&
+		usage.GetLabels()[xpv1.LabelKeyProviderName] == pcRef.Name {
+		return nil
+	}
+
+	usage.ProviderConfigUsage = desired
+	meta.AddLabels(usage, map[string]string{xpv1.LabelKeyProviderName: pcRef.Name})
+	return errors.Wrap(k8s.Update(ctx, usage), errApplyUsage)
+}
+
+// trackNamespacedUsage records the usage of a namespaced resource as a
+// namespaced ProviderConfigUsage alongside it, which the resource owns.
+func trackNamespacedUsage(ctx context.Context, k8s k8sclient.Client, mg resource.Managed, pcRef *xpv1.ProviderConfigReference, name string) error {
+	gvk := mg.GetObjectKind().GroupVersionKind()
+	desired := xpv1.TypedProviderConfigUsage{
+		ProviderConfigReference: *pcRef,
+		ResourceReference: xpv1.TypedReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       mg.GetName(),
+			UID:        mg.GetUID(),
 		},
 	}
 
-	return k8s.Create(ctx, usage)
+	usage := &namespacedv1beta1.ProviderConfigUsage{}
+	err := k8s.Get(ctx, ktypes.NamespacedName{Namespace: mg.GetNamespace(), Name: name}, usage)
+	if kerrors.IsNotFound(err) {
+		usage = &namespacedv1beta1.ProviderConfigUsage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: mg.GetNamespace(),
+				Name:      name,
+				Labels:    map[string]string{xpv1.LabelKeyProviderName: pcRef.Name},
+			},
+			TypedProviderConfigUsage: desired,
+		}
+		if !gvk.Empty() {
+			usage.SetOwnerReferences([]metav1.OwnerReference{meta.AsOwner(meta.TypedReferenceTo(mg, gvk))})
+		}
+		return errors.Wrap(resource.Ignore(kerrors.IsAlreadyExists, k8s.Create(ctx, usage)), errApplyUsage)
+	}
+	if err != nil {
+		return errors.Wrap(err, errApplyUsage)
+	}
+
+	if usage.ProviderConfigReference == desired.ProviderConfigReference &&
+		usage.ResourceReference == desired.ResourceReference &&
+		usage.GetLabels()[xpv1.LabelKeyProviderName] == pcRef.Name {
+		return nil
+	}
+
+	usage.TypedProviderConfigUsage = desired
+	meta.AddLabels(usage, map[string]string{xpv1.LabelKeyProviderName: pcRef.Name})
+	return errors.Wrap(k8s.Update(ctx, usage), errApplyUsage)
+}
+
+// ReleaseProviderConfigUsage deletes the usages recorded for the managed
+// resource, including one named after the ProviderConfig and UID as earlier
+// releases did, and that of its standby ProviderConfig. Namespaced resources
+// used cluster scoped usages before namespaced ones existed, so both are
+// released for them.
+func ReleaseProviderConfigUsage(ctx context.Context, k8s k8sclient.Client, mg resource.Managed) error {
+	if err := ReleaseStandbyProviderConfigUsage(ctx, k8s, mg); err != nil {
+		return err
+	}
+	if mg.GetNamespace() != "" {
+		usage := &namespacedv1beta1.ProviderConfigUsage{ObjectMeta: metav1.ObjectMeta{
+			Namespace: mg.GetNamespace(),
+			Name:      string(mg.GetUID()),
+		}}
+		if err := k8s.Delete(ctx, usage); resource.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, errReleaseUsage)
+		}
+	}
+
+	names := []string{string(mg.GetUID())}
+	if pcRef, err := providerConfigReference(mg); err == nil {
+		names = append(names, fmt.Sprintf("%s-%s", pcRef.Name, mg.GetUID()))
+	}
+
+	for _, name := range names {
+		usage := &v1beta1.ProviderConfigUsage{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if err := k8s.Delete(ctx, usage); resource.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, errReleaseUsage)
+		}
+	}
+	return nil
+}
+
+// ReleaseStandbyProviderConfigUsage deletes the usage of a standby
+// ProviderConfig recorded for the managed resource, if there is one.
+func ReleaseStandbyProviderConfigUsage(ctx context.Context, k8s k8sclient.Client, mg resource.Managed) error {
+	var usage k8sclient.Object = &v1beta1.ProviderConfigUsage{}
+	if mg.GetNamespace() != "" {
+		usage = &namespacedv1beta1.ProviderConfigUsage{}
+	}
+	err := k8s.Get(ctx, ktypes.NamespacedName{Namespace: mg.GetNamespace(), Name: standbyUsageName(mg)}, usage)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errReleaseUsage)
+	}
+	return errors.Wrap(resource.IgnoreNotFound(k8s.Delete(ctx, usage)), errReleaseUsage)
+}
+
+// standbyUsageName returns the name of the usage of the standby
+// ProviderConfig of a managed resource.
+func standbyUsageName(mg resource.Managed) string {
+	return string(mg.GetUID()) + "-standby"
+}
+
+// A UsageFinalizer releases a managed resource's ProviderConfigUsage before
+// removing its finalizer, which the managed reconciler does once the external
+// resource is gone.
+type UsageFinalizer struct {
+	resource.Finalizer
+	kube k8sclient.Client
+}
+
+// NewUsageFinalizer returns a UsageFinalizer that manages the standard
+// managed resource finalizer.
+func NewUsageFinalizer(kube k8sclient.Client) *UsageFinalizer {
+	return &UsageFinalizer{
+		Finalizer: resource.NewAPIFinalizer(kube, managed.FinalizerName),
+		kube:      kube,
+	}
+}
+
+// RemoveFinalizer releases the resource's ProviderConfigUsage, then removes
+// its finalizer.
+func (f *UsageFinalizer) RemoveFinalizer(ctx context.Context, obj resource.Object) error {
+	if mg, ok := obj.(resource.Managed); ok {
+		if err := ReleaseProviderConfigUsage(ctx, f.kube, mg); err != nil {
+			return err
+		}
+	}
+	return f.Finalizer.RemoveFinalizer(ctx, obj)
+}
+
+// providerConfigReference returns the ProviderConfig the managed resource
+// refers to.
+func providerConfigReference(mg resource.Managed) (*xpv1.ProviderConfigReference, error) {
+	mr, ok := mg.(interface {
+		GetProviderConfigReference() *xpv1.ProviderConfigReference
+	})
+	if !ok || mr.GetProviderConfigReference() == nil {
+		return nil, errors.New(errNoProviderConfig)
+	}
+	return mr.GetProviderConfigReference(), nil
 }
 
 // DockerCredentials represents credentials for Docker daemon connection.
@@ -359,50 +752,37 @@ type RegistryAuth struct {
 	Email         string `json:"email,omitempty"`
 	IdentityToken string `json:"identitytoken,omitempty"`
 	RegistryToken string `json:"registrytoken,omitempty"`
+
+	// Auth is the username and password joined by a colon and base64
+	// encoded, as a Docker config.json stores them.
+	Auth string `json:"auth,omitempty"`
 }
 
-// ExtractCredentials extracts credentials from the ProviderConfig.
+// ExtractCredentials extracts credentials from the ProviderConfig, from its
+// credentials Secret, an environment variable of the provider or a path on
+// its filesystem, as its credentials source says.
 func ExtractCredentials(ctx context.Context, k8s k8sclient.Client, pc *v1beta1.ProviderConfig) (*DockerCredentials, error) {
-	if pc.Spec.Credentials.SecretRef == nil {
-		return &DockerCredentials{}, nil
-	}
-
-	// Validate credentials source (only when SecretRef is provided)
-	if pc.Spec.Credentials.Source != xpv1.CredentialsSourceSecret && pc.Spec.Credentials.Source != "" {
-		return nil, errors.Errorf("credentials source %s is not currently supported", pc.Spec.Credentials.Source)
-	}
-
-	secret := &corev1.Secret{}
-	secretKey := ktypes.NamespacedName{
-		Namespace: pc.Spec.Credentials.SecretRef.Namespace,
-		Name:      pc.Spec.Credentials.SecretRef.Name,
-	}
-
-	if err := k8s.Get(ctx, secretKey, secret); err != nil {
-		return nil, errors.Wrap(err, "cannot get secret")
-	}
-
-	creds := &DockerCredentials{}
-
-	// Extract TLS certificates if present
-	if caData, ok := secret.Data["ca"]; ok {
-		creds.CAData = caData
-	}
-	if certData, ok := secret.Data["cert"]; ok {
-		creds.CertData = certData
-	}
-	if keyData, ok := secret.Data["key"]; ok {
-		creds.KeyData = keyData
-	}
-
-	// Extract registry authentication if present
-	if authsData, ok := secret.Data["auths"]; ok {
-		auths := make(map[string]RegistryAuth)
-		if err := json.Unmarshal(authsData, &auths); err != nil {
-			return nil, errors.Wrap(err, errUnmarshalCredentials)
+	sel := pc.Spec.Credentials.CommonCredentialSelectors
+	switch pc.Spec.Credentials.Source {
+	case xpv1.CredentialsSourceSecret, "":
+		if sel.SecretRef == nil {
+			return &DockerCredentials{}, nil
 		}
-		creds.RegistryAuths = auths
+		secret := &corev1.Secret{}
+		secretKey := ktypes.NamespacedName{
+			Namespace: sel.SecretRef.Namespace,
+			Name:      sel.SecretRef.Name,
+		}
+		if err := k8s.Get(ctx, secretKey, secret); err != nil {
+			return nil, errors.Wrap(err, "cannot get secret")
+		}
+		return credentialsFromData(secret.Data)
+	case xpv1.CredentialsSourceEnvironment:
+		return environmentCredentials(sel.Env)
+	case xpv1.CredentialsSourceFilesystem:
+		return filesystemCredentials(sel.Fs)
+	case xpv1.CredentialsSourceNone:
+		return &DockerCredentials{}, nil
 	}
-
-	return creds, nil
+	return nil, errors.Errorf(errCredentialsSource, pc.Spec.Credentials.Source)
 }
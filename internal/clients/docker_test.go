@@ -18,15 +18,25 @@ package clients
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	goruntime "runtime"
+	"strings"
 	"testing"
 
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	dockerclient "github.com/docker/docker/client"
 	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	containerv1beta1 "github.com/rossigee/provider-docker/apis/container/v1beta1"
+	namespacedv1beta1 "github.com/rossigee/provider-docker/apis/namespaced/v1beta1"
 	v1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
 
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -38,6 +48,7 @@ func TestDockerClientInterface(t *testing.T) {
 func TestGetProviderConfig(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = v1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = namespacedv1beta1.SchemeBuilder.AddToScheme(scheme)
 	_ = v1alpha1.SchemeBuilder.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 
@@ -154,6 +165,7 @@ func TestGetProviderConfig(t *testing.T) {
 func TestExtractCredentials(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = v1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = namespacedv1beta1.SchemeBuilder.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 
 	tests := []struct {
@@ -255,6 +267,7 @@ func TestNewDockerClient(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = v1alpha1.SchemeBuilder.AddToScheme(scheme)
 	_ = v1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = namespacedv1beta1.SchemeBuilder.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 
 	tests := []struct {
@@ -356,9 +369,258 @@ func containsSubstring(s, substr string) bool {
 	return false
 }
 
+func TestGetProviderConfigResolution(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = namespacedv1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = containerv1beta1.SchemeBuilder.AddToScheme(scheme)
+
+	host := func(h string) *string { return &h }
+	secretRef := func(namespace string) *xpv1.SecretKeySelector {
+		return &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "docker-creds", Namespace: namespace},
+			Key:             "config",
+		}
+	}
+	objects := []runtime.Object{
+		&v1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy"},
+			Spec:       v1beta1.ProviderConfigSpec{Host: host("tcp://legacy:2376")},
+		},
+		&v1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+			Spec:       v1beta1.ProviderConfigSpec{Host: host("tcp://legacy-shared:2376")},
+		},
+		&namespacedv1beta1.ClusterProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+			Spec:       namespacedv1beta1.ProviderConfigSpec{Host: host("tcp://cluster-shared:2376")},
+		},
+		&namespacedv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "team-a"},
+			Spec: namespacedv1beta1.ProviderConfigSpec{
+				Host:        host("tcp://team-a:2376"),
+				Credentials: v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef("team-a")}},
+			},
+		},
+		&namespacedv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "borrowed", Namespace: "team-a"},
+			Spec: namespacedv1beta1.ProviderConfigSpec{
+				Credentials: v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef("team-b")}},
+			},
+		},
+		&namespacedv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "unset", Namespace: "team-a"},
+			Spec: namespacedv1beta1.ProviderConfigSpec{
+				Host:        host("tcp://team-a:2376"),
+				Credentials: v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef("")}},
+			},
+		},
+		&namespacedv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "certpath", Namespace: "team-a"},
+			Spec: namespacedv1beta1.ProviderConfigSpec{
+				Host:      host("tcp://team-a:2376"),
+				TLSConfig: &v1beta1.TLSConfig{CertPath: host("/etc/docker/certs")},
+			},
+		},
+		&namespacedv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "dnsaddress", Namespace: "team-a"},
+			Spec: namespacedv1beta1.ProviderConfigSpec{
+				Host:       host("tcp://team-a:2376"),
+				ClusterDNS: &v1beta1.ClusterDNS{Address: host("10.96.0.10")},
+			},
+		},
+		&namespacedv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "dnshost", Namespace: "team-a"},
+			Spec: namespacedv1beta1.ProviderConfigSpec{
+				Host:       host("tcp://team-a:2376"),
+				ClusterDNS: &v1beta1.ClusterDNS{Address: host("team-a")},
+			},
+		},
+		&namespacedv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "socket", Namespace: "team-a"},
+			Spec: namespacedv1beta1.ProviderConfigSpec{
+				Host: host("unix:///var/run/docker.sock"),
+			},
+		},
+		&namespacedv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "nohost", Namespace: "team-a"},
+			Spec: namespacedv1beta1.ProviderConfigSpec{
+				Credentials: v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef("team-a")}},
+			},
+		},
+		&namespacedv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "environment", Namespace: "team-a"},
+			Spec: namespacedv1beta1.ProviderConfigSpec{
+				Host:        host("tcp://team-a:2376"),
+				Credentials: v1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceEnvironment, CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: "DOCKER_CREDENTIALS"}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		namespace       string
+		ref             xpv1.ProviderConfigReference
+		wantHost        string
+		wantSecretNS    string
+		wantErrContains string
+	}{
+		{
+			name:         "NamespacedProviderConfigInOwnNamespace",
+			namespace:    "team-a",
+			ref:          xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "tenant"},
+			wantHost:     "tcp://team-a:2376",
+			wantSecretNS: "team-a",
+		},
+		{
+			name:            "NamespacedProviderConfigCrossNamespaceSecret",
+			namespace:       "team-a",
+			ref:             xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "borrowed"},
+			wantErrContains: `credentials secret in namespace "team-b"`,
+		},
+		{
+			name:         "NamespacedProviderConfigSecretDefaultsToOwnNamespace",
+			namespace:    "team-a",
+			ref:          xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "unset"},
+			wantSecretNS: "team-a",
+		},
+		{
+			name:            "NamespacedProviderConfigCertPath",
+			namespace:       "team-a",
+			ref:             xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "certpath"},
+			wantErrContains: errNamespacedCertPath,
+		},
+		{
+			name:            "NamespacedProviderConfigClusterDNSAddress",
+			namespace:       "team-a",
+			ref:             xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "dnsaddress"},
+			wantErrContains: `clusterDNS.address "10.96.0.10"`,
+		},
+		{
+			name:      "NamespacedProviderConfigClusterDNSHostAddress",
+			namespace: "team-a",
+			ref:       xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "dnshost"},
+			wantHost:  "tcp://team-a:2376",
+		},
+		{
+			name:            "NamespacedProviderConfigWithoutHost",
+			namespace:       "team-a",
+			ref:             xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "nohost"},
+			wantErrContains: errNamespacedHost,
+		},
+		{
+			name:            "NamespacedProviderConfigLocalSocket",
+			namespace:       "team-a",
+			ref:             xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "socket"},
+			wantErrContains: "cannot use the local socket unix:///var/run/docker.sock",
+		},
+		{
+			name:            "NamespacedProviderConfigEnvironment",
+			namespace:       "team-a",
+			ref:             xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "environment"},
+			wantErrContains: "credentials source Environment cannot be used by a namespaced ProviderConfig",
+		},
+		{
+			name:            "NamespacedProviderConfigInOtherNamespace",
+			namespace:       "team-b",
+			ref:             xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "tenant"},
+			wantErrContains: "not found",
+		},
+		{
+			name:      "ClusterProviderConfigPreferred",
+			namespace: "team-a",
+			ref:       xpv1.ProviderConfigReference{Kind: "ClusterProviderConfig", Name: "shared"},
+			wantHost:  "tcp://cluster-shared:2376",
+		},
+		{
+			name:      "ClusterProviderConfigFallsBackToLegacy",
+			namespace: "team-a",
+			ref:       xpv1.ProviderConfigReference{Kind: "ClusterProviderConfig", Name: "legacy"},
+			wantHost:  "tcp://legacy:2376",
+		},
+		{
+			name:     "ClusterScopedProviderConfigKind",
+			ref:      xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "shared"},
+			wantHost: "tcp://legacy-shared:2376",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+			ref := tt.ref
+			mg := &containerv1beta1.Container{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-container", Namespace: tt.namespace},
+				Spec: containerv1beta1.ContainerSpec{
+					ManagedResourceSpec: xpv1.ManagedResourceSpec{ProviderConfigReference: &ref},
+				},
+			}
+
+			pc, err := GetProviderConfig(context.Background(), kubeClient, mg)
+			if tt.wantErrContains != "" {
+				if err == nil || !contains(err.Error(), tt.wantErrContains) {
+					t.Fatalf("GetProviderConfig() error = %v, want error containing %q", err, tt.wantErrContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetProviderConfig() unexpected error: %v", err)
+			}
+			if tt.wantHost != "" && (pc.Spec.Host == nil || *pc.Spec.Host != tt.wantHost) {
+				t.Errorf("GetProviderConfig() host = %v, want %s", pc.Spec.Host, tt.wantHost)
+			}
+			if tt.wantSecretNS != "" && pc.Spec.Credentials.SecretRef.Namespace != tt.wantSecretNS {
+				t.Errorf("GetProviderConfig() secret namespace = %s, want %s", pc.Spec.Credentials.SecretRef.Namespace, tt.wantSecretNS)
+			}
+		})
+	}
+}
+
+func TestNamespacedProviderConfigUsage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = namespacedv1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = containerv1beta1.SchemeBuilder.AddToScheme(scheme)
+
+	ctx := context.Background()
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	mg := &containerv1beta1.Container{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-container", Namespace: "team-a", UID: "test-uid"},
+		Spec: containerv1beta1.ContainerSpec{
+			ManagedResourceSpec: xpv1.ManagedResourceSpec{
+				ProviderConfigReference: &xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "tenant"},
+			},
+		},
+	}
+	mg.SetGroupVersionKind(containerv1beta1.ContainerGroupVersionKind)
+
+	if err := TrackProviderConfigUsage(ctx, kubeClient, mg); err != nil {
+		t.Fatalf("TrackProviderConfigUsage() error = %v", err)
+	}
+	usage := &namespacedv1beta1.ProviderConfigUsage{}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "test-uid"}, usage); err != nil {
+		t.Fatalf("cannot get namespaced ProviderConfigUsage: %v", err)
+	}
+	if usage.ProviderConfigReference != *mg.Spec.ProviderConfigReference {
+		t.Errorf("ProviderConfigUsage refers to %+v, want %+v", usage.ProviderConfigReference, *mg.Spec.ProviderConfigReference)
+	}
+	if len(usage.GetOwnerReferences()) != 1 || usage.GetOwnerReferences()[0].UID != mg.GetUID() {
+		t.Errorf("ProviderConfigUsage owner references = %v, want the managed resource", usage.GetOwnerReferences())
+	}
+
+	if err := ReleaseProviderConfigUsage(ctx, kubeClient, mg); err != nil {
+		t.Fatalf("ReleaseProviderConfigUsage() error = %v", err)
+	}
+	err := kubeClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "test-uid"}, usage)
+	if !kerrors.IsNotFound(err) {
+		t.Errorf("namespaced ProviderConfigUsage still exists after release: %v", err)
+	}
+}
+
 func TestTrackProviderConfigUsage(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = v1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = namespacedv1beta1.SchemeBuilder.AddToScheme(scheme)
 	_ = v1alpha1.SchemeBuilder.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 
@@ -385,6 +647,7 @@ func TestTrackProviderConfigUsage(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "test-container",
 						Namespace: "default",
+						UID:       "test-uid",
 					},
 					Spec: v1alpha1.ContainerSpec{
 						ManagedResourceSpec: xpv1.ManagedResourceSpec{
@@ -431,6 +694,83 @@ func TestTrackProviderConfigUsage(t *testing.T) {
 	}
 }
 
+func TestProviderConfigUsageLifecycle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = namespacedv1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = v1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	ctx := context.Background()
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	mg := &v1alpha1.Container{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-container", UID: "test-uid"},
+		Spec: v1alpha1.ContainerSpec{
+			ManagedResourceSpec: xpv1.ManagedResourceSpec{
+				ProviderConfigReference: &xpv1.ProviderConfigReference{Name: "config-a"},
+			},
+		},
+	}
+
+	getUsage := func() (*v1beta1.ProviderConfigUsage, error) {
+		usage := &v1beta1.ProviderConfigUsage{}
+		err := kubeClient.Get(ctx, types.NamespacedName{Name: "test-uid"}, usage)
+		return usage, err
+	}
+
+	// Tracking is repeated on every reconcile and must not conflict
+	for i := 0; i < 2; i++ {
+		if err := TrackProviderConfigUsage(ctx, kubeClient, mg); err != nil {
+			t.Fatalf("TrackProviderConfigUsage() call %d error = %v", i+1, err)
+		}
+	}
+
+	// Switching ProviderConfig updates the existing usage
+	mg.Spec.ProviderConfigReference.Name = "config-b"
+	if err := TrackProviderConfigUsage(ctx, kubeClient, mg); err != nil {
+		t.Fatalf("TrackProviderConfigUsage() error = %v", err)
+	}
+	usage, err := getUsage()
+	if err != nil {
+		t.Fatalf("cannot get ProviderConfigUsage: %v", err)
+	}
+	if usage.ProviderConfigReference.Name != "config-b" || usage.GetLabels()[xpv1.LabelKeyProviderName] != "config-b" {
+		t.Errorf("ProviderConfigUsage refers to %q (label %q), want config-b",
+			usage.ProviderConfigReference.Name, usage.GetLabels()[xpv1.LabelKeyProviderName])
+	}
+
+	// A standby ProviderConfig is tracked in a usage of its own
+	if err := TrackStandbyProviderConfigUsage(ctx, kubeClient, mg, &xpv1.ProviderConfigReference{Name: "config-standby"}); err != nil {
+		t.Fatalf("TrackStandbyProviderConfigUsage() error = %v", err)
+	}
+	standby := &v1beta1.ProviderConfigUsage{}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Name: "test-uid-standby"}, standby); err != nil {
+		t.Fatalf("cannot get standby ProviderConfigUsage: %v", err)
+	}
+	if standby.ProviderConfigReference.Name != "config-standby" {
+		t.Errorf("standby ProviderConfigUsage refers to %q, want config-standby", standby.ProviderConfigReference.Name)
+	}
+	if usage, err := getUsage(); err != nil || usage.ProviderConfigReference.Name != "config-b" {
+		t.Errorf("tracking the standby ProviderConfig replaced the usage of config-b: %v", err)
+	}
+
+	// Releasing deletes both usages, and a resource being deleted is not tracked again
+	if err := ReleaseProviderConfigUsage(ctx, kubeClient, mg); err != nil {
+		t.Fatalf("ReleaseProviderConfigUsage() error = %v", err)
+	}
+	err = kubeClient.Get(ctx, types.NamespacedName{Name: "test-uid-standby"}, standby)
+	if !kerrors.IsNotFound(err) {
+		t.Errorf("standby ProviderConfigUsage still exists after release: %v", err)
+	}
+	now := metav1.Now()
+	mg.SetDeletionTimestamp(&now)
+	if err := TrackProviderConfigUsage(ctx, kubeClient, mg); err != nil {
+		t.Fatalf("TrackProviderConfigUsage() error = %v", err)
+	}
+	if _, err := getUsage(); !kerrors.IsNotFound(err) {
+		t.Errorf("ProviderConfigUsage still exists after release: %v", err)
+	}
+}
+
 func TestNotFoundError(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -465,6 +805,7 @@ func TestNotFoundError(t *testing.T) {
 func TestExtractCredentialsErrorCases(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = v1beta1.SchemeBuilder.AddToScheme(scheme)
+	_ = namespacedv1beta1.SchemeBuilder.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 
 	tests := []struct {
@@ -590,6 +931,17 @@ func TestCreateDockerClientEdgeCases(t *testing.T) {
 			wantError: true,
 			errorMsg:  "failed to parse CA certificate from credentials",
 		},
+		{
+			name: "SSH host",
+			providerConfig: &v1beta1.ProviderConfig{
+				Spec: v1beta1.ProviderConfigSpec{
+					Host: stringPtr("ssh://docker@edge-01"),
+				},
+			},
+			credentials: &DockerCredentials{},
+			wantError:   true,
+			errorMsg:    "ssh:// Docker hosts are not supported",
+		},
 	}
 
 	for _, tt := range tests {
@@ -613,6 +965,178 @@ func TestCreateDockerClientEdgeCases(t *testing.T) {
 	}
 }
 
+func TestCreateDockerClientEnvironment(t *testing.T) {
+	const envHost = "tcp://provider-env.example.com:2376"
+	t.Setenv("DOCKER_HOST", envHost)
+
+	tests := map[string]struct {
+		namespace string
+		host      *string
+		certPath  bool
+		wantHost  string
+		wantErr   bool
+	}{
+		"ClusterScopedUsesEnvironment": {
+			wantHost: envHost,
+		},
+		"ClusterScopedHostOverridesEnvironment": {
+			host:     stringPtr("tcp://docker-01:2376"),
+			wantHost: "tcp://docker-01:2376",
+		},
+		"NamespacedIgnoresEnvironment": {
+			namespace: "team-a",
+			host:      stringPtr("tcp://team-a:2376"),
+			wantHost:  "tcp://team-a:2376",
+		},
+		"NamespacedWithoutHostIgnoresEnvironment": {
+			namespace: "team-a",
+			wantHost:  dockerclient.DefaultDockerHost,
+		},
+		// The certificates are missing from DOCKER_CERT_PATH, so only a
+		// client that reads it fails.
+		"ClusterScopedReadsCertPath": {
+			certPath: true,
+			wantErr:  true,
+		},
+		"NamespacedIgnoresCertPath": {
+			namespace: "team-a",
+			host:      stringPtr("tcp://team-a:2376"),
+			certPath:  true,
+			wantHost:  "tcp://team-a:2376",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &v1beta1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "docker", Namespace: tt.namespace},
+				Spec:       v1beta1.ProviderConfigSpec{Host: tt.host},
+			}
+			if tt.certPath {
+				t.Setenv("DOCKER_CERT_PATH", t.TempDir())
+			}
+			cli, err := createDockerClient(pc, &DockerCredentials{})
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("createDockerClient(...): expected an error reading DOCKER_CERT_PATH")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createDockerClient(...): %v", err)
+			}
+			defer func() { _ = cli.Close() }()
+			if got := cli.DaemonHost(); got != tt.wantHost {
+				t.Errorf("DaemonHost() = %q, want %q", got, tt.wantHost)
+			}
+		})
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	cr := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps", UID: "1234"}}
+	cr.SetGroupVersionKind(v1alpha1.ContainerGroupVersionKind)
+
+	tests := map[string]struct {
+		spec          v1beta1.ProviderConfigSpec
+		wantUserAgent string
+		wantHeaders   map[string]string
+	}{
+		"Defaults": {
+			wantUserAgent: "provider-docker/",
+			wantHeaders: map[string]string{
+				HeaderResourceKind:      "Container",
+				HeaderResourceName:      "web",
+				HeaderResourceNamespace: "apps",
+				HeaderResourceUID:       "1234",
+			},
+		},
+		"Configured": {
+			spec: v1beta1.ProviderConfigSpec{
+				UserAgent:      stringPtr("platform-team/1.0"),
+				RequestHeaders: map[string]string{"X-Team": "platform", HeaderResourceName: "spoofed"},
+			},
+			wantUserAgent: "platform-team/1.0",
+			wantHeaders: map[string]string{
+				"X-Team":           "platform",
+				HeaderResourceName: "web",
+				HeaderResourceUID:  "1234",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Clone()
+				w.Header().Set("Api-Version", "1.47")
+			}))
+			defer srv.Close()
+
+			pc := &v1beta1.ProviderConfig{Spec: tt.spec}
+			pc.Spec.Host = stringPtr("tcp://" + strings.TrimPrefix(srv.URL, "http://"))
+			cli, err := createDockerClient(pc, &DockerCredentials{}, identify(pc, cr)...)
+			if err != nil {
+				t.Fatalf("createDockerClient(...): %v", err)
+			}
+			defer func() { _ = cli.Close() }()
+			if _, err := cli.Ping(context.Background()); err != nil {
+				t.Fatalf("Ping(...): %v", err)
+			}
+
+			if ua := got.Get("User-Agent"); !strings.HasPrefix(ua, tt.wantUserAgent) {
+				t.Errorf("User-Agent = %q, want prefix %q", ua, tt.wantUserAgent)
+			}
+			for header, want := range tt.wantHeaders {
+				if v := got.Get(header); v != want {
+					t.Errorf("%s = %q, want %q", header, v, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateHost(t *testing.T) {
+	path := field.NewPath("spec", "host")
+
+	// Named pipes only exist on Windows.
+	namedPipe := field.ErrorTypeInvalid
+	if goruntime.GOOS == "windows" {
+		namedPipe = ""
+	}
+
+	tests := []struct {
+		name     string
+		host     string
+		wantType field.ErrorType
+	}{
+		{name: "UnixSocket", host: "unix:///var/run/docker.sock"},
+		{name: "TCP", host: "tcp://docker.example.com:2376"},
+		{name: "SSH", host: "ssh://docker@edge-01", wantType: field.ErrorTypeForbidden},
+		{name: "HTTP", host: "http://docker.example.com:2375", wantType: field.ErrorTypeNotSupported},
+		{name: "NoScheme", host: "docker.example.com:2376", wantType: field.ErrorTypeInvalid},
+		{name: "EmptyAddress", host: "tcp://", wantType: field.ErrorTypeInvalid},
+		{name: "NamedPipe", host: "npipe:////./pipe/docker_engine", wantType: namedPipe},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHost(path, tt.host)
+			if tt.wantType == "" {
+				if err != nil {
+					t.Errorf("validateHost(%q) = %v, want nil", tt.host, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("validateHost(%q) = nil, want %s error", tt.host, tt.wantType)
+			}
+			if err.Type != tt.wantType || err.Field != "spec.host" {
+				t.Errorf("validateHost(%q) = %s on %s, want %s on spec.host", tt.host, err.Type, err.Field, tt.wantType)
+			}
+		})
+	}
+}
+
 // Helper functions for tests
 func stringPtr(s string) *string {
 	return &s
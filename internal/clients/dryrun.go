@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"io"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+)
+
+// A readOnlyClient passes the calls that read the state of a Docker host
+// through to the client it wraps, and fails those that would change it with
+// dryrun.ErrDryRun. Commands are still executed in containers, since the
+// clock and post-condition checks observe containers that way.
+type readOnlyClient struct {
+	DockerClient
+}
+
+// ReadOnly wraps c so that it cannot change the state of its Docker host.
+func ReadOnly(c DockerClient) DockerClient {
+	return &readOnlyClient{DockerClient: c}
+}
+
+func refuse(call string) error {
+	return errors.Wrapf(dryrun.ErrDryRun, "would call %s", call)
+}
+
+func (c *readOnlyClient) ContainerCreate(context.Context, *container.Config, *container.HostConfig, *network.NetworkingConfig, *specs.Platform, string) (container.CreateResponse, error) {
+	return container.CreateResponse{}, refuse("ContainerCreate")
+}
+
+func (c *readOnlyClient) ContainerStart(context.Context, string, container.StartOptions) error {
+	return refuse("ContainerStart")
+}
+
+func (c *readOnlyClient) ContainerStop(context.Context, string, container.StopOptions) error {
+	return refuse("ContainerStop")
+}
+
+func (c *readOnlyClient) ContainerRestart(context.Context, string, container.StopOptions) error {
+	return refuse("ContainerRestart")
+}
+
+func (c *readOnlyClient) ContainerRemove(context.Context, string, container.RemoveOptions) error {
+	return refuse("ContainerRemove")
+}
+
+func (c *readOnlyClient) ContainerUpdate(context.Context, string, container.UpdateConfig) (container.UpdateResponse, error) {
+	return container.UpdateResponse{}, refuse("ContainerUpdate")
+}
+
+func (c *readOnlyClient) ContainerRename(context.Context, string, string) error {
+	return refuse("ContainerRename")
+}
+
+func (c *readOnlyClient) ContainerPause(context.Context, string) error {
+	return refuse("ContainerPause")
+}
+
+func (c *readOnlyClient) ContainerUnpause(context.Context, string) error {
+	return refuse("ContainerUnpause")
+}
+
+func (c *readOnlyClient) CopyToContainer(context.Context, string, string, io.Reader, container.CopyToContainerOptions) error {
+	return refuse("CopyToContainer")
+}
+
+func (c *readOnlyClient) ImagePull(context.Context, string, image.PullOptions) (io.ReadCloser, error) {
+	return nil, refuse("ImagePull")
+}
+
+func (c *readOnlyClient) ImageRemove(context.Context, string, image.RemoveOptions) ([]image.DeleteResponse, error) {
+	return nil, refuse("ImageRemove")
+}
+
+func (c *readOnlyClient) VolumeCreate(context.Context, volume.CreateOptions) (volume.Volume, error) {
+	return volume.Volume{}, refuse("VolumeCreate")
+}
+
+func (c *readOnlyClient) VolumeRemove(context.Context, string, bool) error {
+	return refuse("VolumeRemove")
+}
+
+func (c *readOnlyClient) NetworkCreate(context.Context, string, network.CreateOptions) (network.CreateResponse, error) {
+	return network.CreateResponse{}, refuse("NetworkCreate")
+}
+
+func (c *readOnlyClient) NetworkRemove(context.Context, string) error {
+	return refuse("NetworkRemove")
+}
+
+func (c *readOnlyClient) NetworkConnect(context.Context, string, string, *network.EndpointSettings) error {
+	return refuse("NetworkConnect")
+}
+
+func (c *readOnlyClient) NetworkDisconnect(context.Context, string, string, bool) error {
+	return refuse("NetworkDisconnect")
+}
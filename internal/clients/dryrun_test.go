@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+)
+
+// pingClient answers Ping, and panics on any other call.
+type pingClient struct {
+	DockerClient
+}
+
+func (c *pingClient) Ping(context.Context) (types.Ping, error) {
+	return types.Ping{APIVersion: "1.47"}, nil
+}
+
+func TestReadOnly(t *testing.T) {
+	c := ReadOnly(&pingClient{})
+	ctx := context.Background()
+
+	if p, err := c.Ping(ctx); err != nil || p.APIVersion != "1.47" {
+		t.Errorf("Ping() = %v, %v, want the wrapped client's answer", p, err)
+	}
+
+	mutations := map[string]error{
+		"ContainerStop":   c.ContainerStop(ctx, "web", container.StopOptions{}),
+		"ContainerRemove": c.ContainerRemove(ctx, "web", container.RemoveOptions{}),
+		"VolumeRemove":    c.VolumeRemove(ctx, "data", true),
+		"NetworkRemove":   c.NetworkRemove(ctx, "backend"),
+		"CopyToContainer": c.CopyToContainer(ctx, "web", "/data", nil, container.CopyToContainerOptions{}),
+	}
+	_, mutations["ContainerCreate"] = c.ContainerCreate(ctx, &container.Config{}, nil, nil, nil, "web")
+	_, mutations["VolumeCreate"] = c.VolumeCreate(ctx, volume.CreateOptions{Name: "data"})
+	for call, err := range mutations {
+		if !errors.Is(err, dryrun.ErrDryRun) {
+			t.Errorf("%s() error = %v, want %v", call, err, dryrun.ErrDryRun)
+		}
+	}
+}
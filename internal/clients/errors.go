@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net"
+	"syscall"
+
+	cerrdefs "github.com/containerd/errdefs"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// A retryableError failed for a reason that may go away by itself, such as
+// the Docker daemon being unreachable or overloaded.
+type retryableError struct{ error }
+
+func (e retryableError) Unwrap() error { return e.error }
+
+// A terminalError failed because of the request itself, such as invalid
+// configuration the Docker daemon rejected. Retrying it unchanged will fail
+// again.
+type terminalError struct{ error }
+
+func (e terminalError) Unwrap() error { return e.error }
+
+// ClassifyError marks err as retryable or terminal, keeping its message. An
+// error that has already been classified is returned as it is. Errors that
+// cannot be told apart are retryable, which is how every error was treated
+// before they were classified.
+func ClassifyError(err error) error {
+	if err == nil || IsTerminal(err) || errors.As(err, new(retryableError)) {
+		return err
+	}
+	if isTerminalCause(err) {
+		return terminalError{err}
+	}
+	return retryableError{err}
+}
+
+// IsTerminal reports whether err was classified as terminal.
+func IsTerminal(err error) bool {
+	return errors.As(err, new(terminalError))
+}
+
+// IsRetryable reports whether retrying the request that returned err may
+// succeed without anything having changed.
+func IsRetryable(err error) bool {
+	return err != nil && !IsTerminal(err)
+}
+
+// isTerminalCause reports whether err is one the Docker daemon or this
+// provider returns for a request that cannot succeed as it is: the 4xx
+// responses other than conflicts and missing objects, invalid configuration
+// found before the request was made, and changes refused in dry-run mode.
+// Timeouts, refused connections and 5xx responses are never terminal.
+func isTerminalCause(err error) bool {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET):
+		return false
+	case dockerclient.IsErrConnectionFailed(err), isTimeout(err):
+		return false
+	case cerrdefs.IsUnavailable(err), cerrdefs.IsInternal(err), cerrdefs.IsDeadlineExceeded(err), cerrdefs.IsUnknown(err):
+		return false
+	}
+
+	var fieldErr *field.Error
+	return errors.As(err, &fieldErr) ||
+		errors.Is(err, dryrun.ErrDryRun) ||
+		cerrdefs.IsInvalidArgument(err) ||
+		cerrdefs.IsPermissionDenied(err) ||
+		cerrdefs.IsUnauthorized(err) ||
+		cerrdefs.IsNotImplemented(err)
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := map[string]struct {
+		err          error
+		wantTerminal bool
+	}{
+		"InvalidArgument":   {err: errors.Wrap(cerrdefs.ErrInvalidArgument, "cannot create container"), wantTerminal: true},
+		"PermissionDenied":  {err: cerrdefs.ErrPermissionDenied, wantTerminal: true},
+		"Unauthorized":      {err: cerrdefs.ErrUnauthenticated, wantTerminal: true},
+		"InvalidHost":       {err: field.Forbidden(field.NewPath("spec", "host"), errSSHHost), wantTerminal: true},
+		"Unavailable":       {err: cerrdefs.ErrUnavailable},
+		"ServerError":       {err: cerrdefs.ErrInternal},
+		"Conflict":          {err: cerrdefs.ErrConflict},
+		"ConnectionRefused": {err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}},
+		"Timeout":           {err: errors.Wrap(context.DeadlineExceeded, "cannot inspect container")},
+		"Unknown":           {err: errors.New("boom")},
+		"DryRun":            {err: errors.Wrap(dryrun.ErrDryRun, "would call ContainerCreate"), wantTerminal: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := ClassifyError(tc.err)
+			if got.Error() != tc.err.Error() {
+				t.Errorf("ClassifyError(...).Error() = %q, want %q", got.Error(), tc.err.Error())
+			}
+			if IsTerminal(got) != tc.wantTerminal {
+				t.Errorf("IsTerminal(ClassifyError(%v)) = %t, want %t", tc.err, IsTerminal(got), tc.wantTerminal)
+			}
+			if IsRetryable(got) == tc.wantTerminal {
+				t.Errorf("IsRetryable(ClassifyError(%v)) = %t, want %t", tc.err, IsRetryable(got), !tc.wantTerminal)
+			}
+			if IsTerminal(errors.Wrap(got, "observe failed")) != tc.wantTerminal {
+				t.Errorf("wrapping ClassifyError(%v) changed its class", tc.err)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorClassifiedOnce(t *testing.T) {
+	retryable := ClassifyError(cerrdefs.ErrUnavailable)
+	if got := ClassifyError(errors.Wrap(retryable, "cannot reach Docker")); IsTerminal(got) {
+		t.Errorf("reclassifying a retryable error made it terminal")
+	}
+	if ClassifyError(nil) != nil {
+		t.Errorf("ClassifyError(nil) != nil")
+	}
+	if IsRetryable(nil) {
+		t.Errorf("IsRetryable(nil) = true, want false")
+	}
+}
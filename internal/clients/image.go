@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/pkg/errors"
+)
+
+const errListImageContainers = "cannot list the containers of image %s"
+
+// ImageInUse reports whether any container, running or not, was created from
+// an image, so that Docker would decline to remove it.
+func ImageInUse(ctx context.Context, client DockerClient, ref string) (bool, error) {
+	containers, err := client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Limit:   1,
+		Filters: filters.NewArgs(filters.Arg("ancestor", ref)),
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, errListImageContainers, ref)
+	}
+	return len(containers) > 0, nil
+}
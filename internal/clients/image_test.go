@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// fakeImageContainers serves ContainerList from the images of its containers.
+type fakeImageContainers struct {
+	DockerClient
+	images []string
+}
+
+func (f *fakeImageContainers) ContainerList(_ context.Context, o container.ListOptions) ([]container.Summary, error) {
+	var list []container.Summary
+	for _, image := range f.images {
+		if o.All && o.Filters.ExactMatch("ancestor", image) {
+			list = append(list, container.Summary{Image: image})
+		}
+	}
+	return list, nil
+}
+
+func TestImageInUse(t *testing.T) {
+	f := &fakeImageContainers{images: []string{"nginx:1.27"}}
+	tests := map[string]struct {
+		ref  string
+		want bool
+	}{
+		"InUse":  {ref: "nginx:1.27", want: true},
+		"Unused": {ref: "redis:7"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ImageInUse(context.Background(), f, tt.ref)
+			if err != nil {
+				t.Fatalf("ImageInUse() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ImageInUse(%s) = %v, want %v", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/system"
+)
+
+// InfoCache shares what the Docker daemon on each host reports about itself,
+// such as its runtimes and storage driver, between the reconciles of the
+// resources on that host, asking the daemon again at most once per ttl.
+type InfoCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*cachedInfo
+}
+
+type cachedInfo struct {
+	mu      sync.Mutex
+	fetched time.Time
+	info    system.Info
+}
+
+// NewInfoCache returns an InfoCache that asks the daemon on each host at
+// most once per ttl.
+func NewInfoCache(ttl time.Duration) *InfoCache {
+	return &InfoCache{
+		ttl:   ttl,
+		now:   time.Now,
+		hosts: map[string]*cachedInfo{},
+	}
+}
+
+// Get returns what the daemon on the supplied host reports about itself,
+// unless it was asked within the ttl. An error is not cached, and a nil
+// InfoCache always asks.
+func (c *InfoCache) Get(ctx context.Context, r daemonReader, host string) (system.Info, error) {
+	if c == nil {
+		return r.Info(ctx)
+	}
+
+	h := c.host(host)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := c.now()
+	if !h.fetched.IsZero() && now.Sub(h.fetched) < c.ttl {
+		return h.info, nil
+	}
+	info, err := r.Info(ctx)
+	if err != nil {
+		return system.Info{}, err
+	}
+	h.fetched, h.info = now, info
+	return info, nil
+}
+
+// Forget drops what is known about the daemon on the supplied host, so that
+// it is asked afresh, as it must be after the daemon restarts. Forget does
+// nothing on a nil InfoCache.
+func (c *InfoCache) Forget(host string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.hosts, host)
+}
+
+func (c *InfoCache) host(host string) *cachedInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	h, ok := c.hosts[host]
+	if !ok {
+		h = &cachedInfo{}
+		c.hosts[host] = h
+	}
+	return h
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/system"
+	"github.com/pkg/errors"
+)
+
+func TestInfoCacheGet(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	c := NewInfoCache(time.Minute)
+	c.now = func() time.Time { return now }
+	d := &fakeDaemon{info: system.Info{Driver: "overlay2"}}
+
+	get := func(wantCalls int) system.Info {
+		t.Helper()
+		info, err := c.Get(context.Background(), d, "edge")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if d.calls != wantCalls {
+			t.Errorf("Get() asked the daemon %d times, want %d", d.calls, wantCalls)
+		}
+		return info
+	}
+
+	get(1)
+	d.info.Driver = "zfs"
+	if info := get(1); info.Driver != "overlay2" {
+		t.Errorf("Get() within the ttl = %q, want the cached driver", info.Driver)
+	}
+	now = now.Add(time.Minute)
+	if info := get(2); info.Driver != "zfs" {
+		t.Errorf("Get() after the ttl = %q, want the daemon's driver", info.Driver)
+	}
+
+	d.info.Driver = "btrfs"
+	c.Forget("edge")
+	if info := get(3); info.Driver != "btrfs" {
+		t.Errorf("Get() after Forget() = %q, want the daemon's driver", info.Driver)
+	}
+
+	// Errors are not cached
+	now = now.Add(time.Minute)
+	d.err = errors.New("connection refused")
+	if _, err := c.Get(context.Background(), d, "edge"); err == nil {
+		t.Error("Get() of a daemon that cannot be reached did not fail")
+	}
+	d.err = nil
+	get(5)
+
+	var none *InfoCache
+	if _, err := none.Get(context.Background(), d, "edge"); err != nil || d.calls != 6 {
+		t.Errorf("Get() on nil = %v after %d calls, want the daemon asked", err, d.calls)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+// networkLocks holds a lock for the networks of each Docker host, keyed by
+// the host's address. Each lock is a buffered channel of one, so that
+// waiting for it can be cancelled.
+var networkLocks sync.Map
+
+// A networkLockingClient serialises the calls that change the networks of
+// its Docker host with those made by every other client of the same host,
+// so that controllers reconciling concurrently cannot race to create,
+// remove, connect to or disconnect from the same network.
+type networkLockingClient struct {
+	DockerClient
+	lock chan struct{}
+}
+
+// SerializeNetworks wraps c so that the calls it makes to change networks
+// are made one at a time with those of every other client wrapped for the
+// same host. Connecting a container to a network it is already connected
+// to, and disconnecting it from one it is not connected to, succeed.
+func SerializeNetworks(c DockerClient, host string) DockerClient {
+	lock, _ := networkLocks.LoadOrStore(host, make(chan struct{}, 1))
+	return &networkLockingClient{DockerClient: c, lock: lock.(chan struct{})}
+}
+
+var _ DockerClient = (*networkLockingClient)(nil)
+
+// acquire waits for the network lock of the host, returning a function that
+// releases it, or the error of ctx if it is done first.
+func (c *networkLockingClient) acquire(ctx context.Context) (func(), error) {
+	select {
+	case c.lock <- struct{}{}:
+		return func() { <-c.lock }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *networkLockingClient) NetworkCreate(ctx context.Context, name string, options network.CreateOptions) (network.CreateResponse, error) {
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return network.CreateResponse{}, err
+	}
+	defer release()
+	return c.DockerClient.NetworkCreate(ctx, name, options)
+}
+
+func (c *networkLockingClient) NetworkRemove(ctx context.Context, networkID string) error {
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return c.DockerClient.NetworkRemove(ctx, networkID)
+}
+
+func (c *networkLockingClient) NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if err := c.DockerClient.NetworkConnect(ctx, networkID, containerID, config); err != nil && !isAlreadyConnected(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *networkLockingClient) NetworkDisconnect(ctx context.Context, networkID, containerID string, force bool) error {
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if err := c.DockerClient.NetworkDisconnect(ctx, networkID, containerID, force); err != nil && !isNotConnected(err) {
+		return err
+	}
+	return nil
+}
+
+// isAlreadyConnected reports whether err is the Docker daemon refusing to
+// connect a container to a network it is already connected to.
+func isAlreadyConnected(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already exists in network") || strings.Contains(msg, "already attached")
+}
+
+// isNotConnected reports whether err is the Docker daemon refusing to
+// disconnect a container from a network it is not connected to.
+func isNotConnected(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "is not connected to")
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/pkg/errors"
+)
+
+// fakeNetworks tracks how many network calls are in flight at once, and
+// fails with err.
+type fakeNetworks struct {
+	DockerClient
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+	err      error
+}
+
+func (f *fakeNetworks) NetworkConnect(context.Context, string, string, *network.EndpointSettings) error {
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		seen := f.maxSeen.Load()
+		if n <= seen || f.maxSeen.CompareAndSwap(seen, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	return f.err
+}
+
+func (f *fakeNetworks) NetworkDisconnect(context.Context, string, string, bool) error {
+	return f.err
+}
+
+func TestSerializeNetworks(t *testing.T) {
+	fake := &fakeNetworks{}
+	var wg sync.WaitGroup
+	for range 10 {
+		// Clients of the same host share its lock
+		c := SerializeNetworks(fake, "tcp://serialized:2376")
+		wg.Go(func() {
+			if err := c.NetworkConnect(context.Background(), "backend", "web", nil); err != nil {
+				t.Errorf("NetworkConnect(...): %v", err)
+			}
+		})
+	}
+	wg.Wait()
+	if got := fake.maxSeen.Load(); got != 1 {
+		t.Errorf("NetworkConnect(...) made %d calls at once, want 1", got)
+	}
+}
+
+func TestSerializeNetworksWaitIsCancelled(t *testing.T) {
+	c := SerializeNetworks(&fakeNetworks{}, "tcp://cancelled:2376").(*networkLockingClient)
+	release, err := c.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.NetworkConnect(ctx, "backend", "web", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("NetworkConnect(...) = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSerializeNetworksIsIdempotent(t *testing.T) {
+	tests := map[string]struct {
+		err     error
+		call    func(DockerClient) error
+		wantErr bool
+	}{
+		"AlreadyConnected": {
+			err: errors.New("Error response from daemon: endpoint with name web already exists in network backend"),
+			call: func(c DockerClient) error {
+				return c.NetworkConnect(context.Background(), "backend", "web", nil)
+			},
+		},
+		"NotConnected": {
+			err: errors.New("Error response from daemon: container 0123 is not connected to network backend"),
+			call: func(c DockerClient) error {
+				return c.NetworkDisconnect(context.Background(), "backend", "web", false)
+			},
+		},
+		"OtherError": {
+			err: errors.New("Error response from daemon: network backend not found"),
+			call: func(c DockerClient) error {
+				return c.NetworkConnect(context.Background(), "backend", "web", nil)
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := SerializeNetworks(&fakeNetworks{err: tc.err}, "tcp://idempotent:2376")
+			if err := tc.call(c); (err != nil) != tc.wantErr {
+				t.Errorf("call error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
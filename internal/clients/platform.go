@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const errParsePlatform = "cannot parse platform %q: want os/architecture or os/architecture/variant"
+
+// ParsePlatform parses a platform of the form os/architecture[/variant], such
+// as linux/arm64 or linux/arm/v7. An empty platform is nil, which Docker
+// takes to be the platform of its host.
+func ParsePlatform(s string) (*specs.Platform, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(strings.ToLower(s), "/")
+	if len(parts) < 2 || len(parts) > 3 || slices.Contains(parts, "") {
+		return nil, errors.Errorf(errParsePlatform, s)
+	}
+	p := &specs.Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		p.Variant = parts[2]
+	}
+	return p, nil
+}
+
+// FormatPlatform formats a platform as ParsePlatform parses it. A nil
+// platform is empty.
+func FormatPlatform(p *specs.Platform) string {
+	if p == nil {
+		return ""
+	}
+	return path.Join(p.OS, p.Architecture, p.Variant)
+}
+
+// IsOtherPlatform reports whether err is Docker finding an image on its host
+// only for platforms other than the one asked for, which must be pulled
+// before a container of that platform is created from it.
+func IsOtherPlatform(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "was found but")
+}
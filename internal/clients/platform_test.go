@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+func TestParsePlatform(t *testing.T) {
+	tests := map[string]struct {
+		platform string
+		want     *specs.Platform
+		wantErr  bool
+	}{
+		"Empty":       {},
+		"Arch":        {platform: "linux/arm64", want: &specs.Platform{OS: "linux", Architecture: "arm64"}},
+		"Variant":     {platform: "linux/arm/v7", want: &specs.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+		"MixedCase":   {platform: "Linux/AMD64", want: &specs.Platform{OS: "linux", Architecture: "amd64"}},
+		"NoArch":      {platform: "linux", wantErr: true},
+		"EmptyArch":   {platform: "linux/", wantErr: true},
+		"TooManyBits": {platform: "linux/arm/v7/extra", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParsePlatform(tt.platform)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePlatform(%q) error = %v, wantErr %v", tt.platform, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParsePlatform(%q) mismatch (-want +got):\n%s", tt.platform, diff)
+			}
+			if got != nil && FormatPlatform(got) != strings.ToLower(tt.platform) {
+				t.Errorf("FormatPlatform(ParsePlatform(%q)) = %q", tt.platform, FormatPlatform(got))
+			}
+		})
+	}
+}
+
+func TestIsOtherPlatform(t *testing.T) {
+	err := errors.New("Error response from daemon: image with reference nginx:1.27 was found but its platform (linux/amd64) does not match the specified platform (linux/arm64)")
+	if !IsOtherPlatform(err) {
+		t.Error("IsOtherPlatform() = false for an image pulled only for another platform")
+	}
+	if IsOtherPlatform(errors.New("No such image: nginx:1.27")) {
+		t.Error("IsOtherPlatform() = true for a missing image")
+	}
+}
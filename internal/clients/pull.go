@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/pkg/errors"
+)
+
+const (
+	errReadPullProgress = "cannot read the progress of the image pull"
+	errPullImage        = "cannot pull image %s"
+	errRegistryAuth     = "cannot get registry credentials"
+)
+
+// RegistryAuthsFunc returns the registry credentials to pull images with,
+// which are only read when an image is pulled.
+type RegistryAuthsFunc func(ctx context.Context) (map[string]RegistryAuth, error)
+
+// PullImage pulls an image for a platform, or that of the Docker host if it
+// is empty, with the credentials auths returns for its registry, if any. Each
+// message of the pull's progress is passed to progress, if set. PullImage
+// waits for the pull to complete, and fails with an error in its progress.
+func PullImage(ctx context.Context, client DockerClient, ref, platform string, auths RegistryAuthsFunc, progress func(jsonmessage.JSONMessage)) error {
+	opts := image.PullOptions{Platform: platform}
+	if auths != nil {
+		a, err := auths(ctx)
+		if err != nil {
+			return errors.Wrap(err, errRegistryAuth)
+		}
+		if opts.RegistryAuth, err = PullAuth(a, ref); err != nil {
+			return err
+		}
+	}
+
+	rc, err := client.ImagePull(ctx, ref, opts)
+	if err != nil {
+		return errors.Wrapf(err, errPullImage, ref)
+	}
+	defer func() { _ = rc.Close() }()
+
+	return errors.Wrapf(ReadPullProgress(rc, progress), errPullImage, ref)
+}
+
+// IsNoSuchImage reports whether err is Docker declining to create a container
+// because it does not have its image, or not for the platform asked for.
+func IsNoSuchImage(err error) bool {
+	return err != nil && (strings.Contains(strings.ToLower(err.Error()), "no such image") || IsOtherPlatform(err))
+}
+
+// ReadPullProgress reads the progress of an image pull to the end, passing
+// each message to progress, if set. A pull only completes once its progress
+// has been read to the end. Docker reports a pull that fails after it has
+// started in its progress, having already answered that it succeeded, so
+// the error of the first message that has one is returned.
+func ReadPullProgress(r io.Reader, progress func(jsonmessage.JSONMessage)) error {
+	dec := json.NewDecoder(r)
+	for {
+		var m jsonmessage.JSONMessage
+		if err := dec.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.Wrap(err, errReadPullProgress)
+		}
+		if m.Error != nil {
+			return m.Error
+		}
+		if progress != nil {
+			progress(m)
+		}
+	}
+}
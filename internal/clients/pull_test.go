@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/pkg/errors"
+)
+
+// fakePuller records the options of the images it pulls.
+type fakePuller struct {
+	DockerClient
+	pulled []image.PullOptions
+}
+
+func (f *fakePuller) ImagePull(_ context.Context, _ string, o image.PullOptions) (io.ReadCloser, error) {
+	f.pulled = append(f.pulled, o)
+	return io.NopCloser(strings.NewReader(`{"status":"Pulling"}`)), nil
+}
+
+func TestReadPullProgress(t *testing.T) {
+	tests := map[string]struct {
+		stream       string
+		wantErr      string
+		wantMessages int
+	}{
+		"Empty": {},
+		"Completed": {
+			stream:       `{"status":"Pulling from library/nginx","id":"1.27"}` + "\n" + `{"status":"Download complete","id":"abc"}` + "\n" + `{"status":"Status: Downloaded newer image for nginx:1.27"}`,
+			wantMessages: 3,
+		},
+		"FailedAfterStarting": {
+			stream:       `{"status":"Pulling from library/nginx","id":"1.27"}` + "\n" + `{"errorDetail":{"message":"unauthorized: authentication required"},"error":"unauthorized: authentication required"}` + "\n" + `{"status":"unreachable"}`,
+			wantErr:      "unauthorized: authentication required",
+			wantMessages: 1,
+		},
+		"Malformed": {
+			stream:  `{"status":`,
+			wantErr: errReadPullProgress,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var messages int
+			err := ReadPullProgress(strings.NewReader(tt.stream), func(jsonmessage.JSONMessage) { messages++ })
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("ReadPullProgress() error = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("ReadPullProgress() error = %v, want %q", err, tt.wantErr)
+			}
+			if messages != tt.wantMessages {
+				t.Errorf("ReadPullProgress() passed on %d messages, want %d", messages, tt.wantMessages)
+			}
+		})
+	}
+
+	if err := ReadPullProgress(strings.NewReader(`{"status":"Pulling"}`), nil); err != nil {
+		t.Errorf("ReadPullProgress() without progress error = %v", err)
+	}
+}
+
+func TestPullImage(t *testing.T) {
+	private := func(context.Context) (map[string]RegistryAuth, error) {
+		return map[string]RegistryAuth{"registry.example.com": {Username: "ci", Password: "secret"}}, nil
+	}
+	tests := map[string]struct {
+		ref      string
+		platform string
+		auths    RegistryAuthsFunc
+		wantAuth bool
+		wantErr  string
+	}{
+		"NoCredentials":   {ref: "nginx:1.27"},
+		"PrivateRegistry": {ref: "registry.example.com/tools/netshoot:1.0", platform: "linux/arm64", auths: private, wantAuth: true},
+		"OtherRegistry":   {ref: "nicolaka/netshoot", auths: private},
+		"CredentialsUnavailable": {
+			ref:     "nginx:1.27",
+			auths:   func(context.Context) (map[string]RegistryAuth, error) { return nil, errors.New("secret not found") },
+			wantErr: errRegistryAuth,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &fakePuller{}
+			err := PullImage(context.Background(), f, tt.ref, tt.platform, tt.auths, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("PullImage() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PullImage() error = %v", err)
+			}
+			if len(f.pulled) != 1 || (f.pulled[0].RegistryAuth != "") != tt.wantAuth || f.pulled[0].Platform != tt.platform {
+				t.Errorf("PullImage() pulled with %+v, want credentials %v for platform %q", f.pulled, tt.wantAuth, tt.platform)
+			}
+		})
+	}
+}
+
+func TestIsNoSuchImage(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"Nil":         {},
+		"NoSuchImage": {err: errors.New("Error response from daemon: No such image: nginx:1.27"), want: true},
+		"Other":       {err: errors.New("Conflict. The container name is already in use")},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsNoSuchImage(tt.err); got != tt.want {
+				t.Errorf("IsNoSuchImage(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
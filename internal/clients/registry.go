@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errParseImageReference = "cannot parse image reference %q"
+	errEncodeRegistryAuth  = "cannot encode credentials for registry %s"
+	errDecodeRegistryAuth  = "cannot decode the auth of registry %s"
+	errNotUserPassword     = "auth is not username:password"
+
+	// defaultRegistry is the registry of image references that don't name
+	// one.
+	defaultRegistry = "docker.io"
+)
+
+// RegistryAuths returns the registry credentials of the managed resource's
+// ProviderConfig, keyed by registry host. The auths of its credentials
+// secret take precedence over its registryAuth.
+func RegistryAuths(ctx context.Context, k8s k8sclient.Client, mg resource.Managed) (map[string]RegistryAuth, error) {
+	pc, err := GetProviderConfig(ctx, k8s, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+	return ProviderConfigRegistryAuths(ctx, k8s, pc)
+}
+
+// ProviderConfigRegistryAuths returns the registry credentials of a
+// ProviderConfig, keyed by registry host.
+func ProviderConfigRegistryAuths(ctx context.Context, k8s k8sclient.Client, pc *v1beta1.ProviderConfig) (map[string]RegistryAuth, error) {
+	creds, err := ExtractCredentials(ctx, k8s, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errExtractCredentials)
+	}
+	return registryAuths(pc, creds), nil
+}
+
+// registryAuths merges the registry credentials of a ProviderConfig and its
+// credentials secret, keyed by registry host.
+func registryAuths(pc *v1beta1.ProviderConfig, creds *DockerCredentials) map[string]RegistryAuth {
+	auths := make(map[string]RegistryAuth, len(creds.RegistryAuths)+1)
+	if ra := pc.Spec.RegistryAuth; ra != nil {
+		auths[registryHost(ra.Registry)] = RegistryAuth{
+			Username:      deref(ra.Username),
+			Password:      deref(ra.Password),
+			Email:         deref(ra.Email),
+			IdentityToken: deref(ra.IdentityToken),
+			RegistryToken: deref(ra.RegistryToken),
+		}
+	}
+	for host, auth := range creds.RegistryAuths {
+		auths[registryHost(host)] = auth
+	}
+	return auths
+}
+
+// A dockerConfig holds the registry credentials of a Docker config.json.
+type dockerConfig struct {
+	Auths map[string]RegistryAuth `json:"auths"`
+}
+
+// secretRegistryAuths returns the registry credentials of a credentials
+// secret, keyed by registry host. They are read from its auths, and from a
+// Docker config.json in its .dockerconfigjson key, as a Secret of type
+// kubernetes.io/dockerconfigjson has, whose credentials the auths take
+// precedence over. Either may store a username and password as a Docker
+// config.json does, in an auth.
+func secretRegistryAuths(data map[string][]byte) (map[string]RegistryAuth, error) {
+	var sources []map[string]RegistryAuth
+	if b, ok := data[corev1.DockerConfigJsonKey]; ok {
+		cfg := dockerConfig{}
+		if err := json.Unmarshal(b, &cfg); err != nil {
+			return nil, errors.Wrap(err, errUnmarshalCredentials)
+		}
+		sources = append(sources, cfg.Auths)
+	}
+	if b, ok := data["auths"]; ok {
+		auths := map[string]RegistryAuth{}
+		if err := json.Unmarshal(b, &auths); err != nil {
+			return nil, errors.Wrap(err, errUnmarshalCredentials)
+		}
+		sources = append(sources, auths)
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	auths := map[string]RegistryAuth{}
+	for _, source := range sources {
+		for host, auth := range source {
+			auth, err := decodeAuth(host, auth)
+			if err != nil {
+				return nil, err
+			}
+			auths[registryHost(host)] = auth
+		}
+	}
+	return auths, nil
+}
+
+// decodeAuth sets the username and password of registry credentials from
+// their auth, unless they are set already.
+func decodeAuth(host string, auth RegistryAuth) (RegistryAuth, error) {
+	if auth.Auth == "" || auth.Username != "" || auth.Password != "" {
+		return auth, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(auth.Auth)
+	if err != nil {
+		return auth, errors.Wrapf(err, errDecodeRegistryAuth, host)
+	}
+	username, password, ok := strings.Cut(string(b), ":")
+	if !ok {
+		return auth, errors.Wrapf(errors.New(errNotUserPassword), errDecodeRegistryAuth, host)
+	}
+	auth.Username, auth.Password = username, password
+	return auth, nil
+}
+
+// PullAuth returns the encoded credentials to pull the image ref with, or an
+// empty string if there are none for its registry.
+func PullAuth(auths map[string]RegistryAuth, ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", errors.Wrapf(err, errParseImageReference, ref)
+	}
+	host := reference.Domain(named)
+	auth, ok := auths[host]
+	if !ok {
+		return "", nil
+	}
+	encoded, err := registry.EncodeAuthConfig(registry.AuthConfig{
+		Username:      auth.Username,
+		Password:      auth.Password,
+		Email:         auth.Email,
+		ServerAddress: host,
+		IdentityToken: auth.IdentityToken,
+		RegistryToken: auth.RegistryToken,
+	})
+	return encoded, errors.Wrapf(err, errEncodeRegistryAuth, host)
+}
+
+// registryHost returns the host of a registry as it appears in image
+// references, so that "https://index.docker.io/v1/" and "docker.io" are the
+// same registry.
+func registryHost(registry string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return defaultRegistry
+	}
+	return host
+}
+
+// deref returns the value of s, or an empty string if it is nil.
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
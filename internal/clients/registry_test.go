@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+)
+
+func TestPullAuth(t *testing.T) {
+	user := func(s string) *string { return &s }
+	pc := &v1beta1.ProviderConfig{Spec: v1beta1.ProviderConfigSpec{RegistryAuth: &v1beta1.RegistryAuth{
+		Registry: "https://index.docker.io/v1/",
+		Username: user("hub-user"),
+		Password: user("hub-password"),
+	}}}
+	creds := &DockerCredentials{RegistryAuths: map[string]RegistryAuth{
+		"ghcr.io":              {Username: "gh-user", Password: "gh-token"},
+		"https://docker.io/v2": {Username: "secret-hub-user", Password: "secret-hub-password"},
+	}}
+	auths := registryAuths(pc, creds)
+
+	tests := map[string]struct {
+		ref        string
+		wantUser   string
+		wantServer string
+	}{
+		"DockerHub":          {ref: "nginx:1.27", wantUser: "secret-hub-user", wantServer: "docker.io"},
+		"OtherRegistry":      {ref: "ghcr.io/org/app@sha256:" + zeros, wantUser: "gh-user", wantServer: "ghcr.io"},
+		"UnknownRegistry":    {ref: "quay.io/org/app:1"},
+		"RegistryWithPort":   {ref: "localhost:5000/app"},
+		"ExplicitDockerHub":  {ref: "docker.io/library/redis:7", wantUser: "secret-hub-user", wantServer: "docker.io"},
+		"DockerHubNamespace": {ref: "bitnami/redis", wantUser: "secret-hub-user", wantServer: "docker.io"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := PullAuth(auths, tt.ref)
+			if err != nil {
+				t.Fatalf("PullAuth(%q): %v", tt.ref, err)
+			}
+			if tt.wantUser == "" {
+				if encoded != "" {
+					t.Errorf("PullAuth(%q) = %q, want no credentials", tt.ref, encoded)
+				}
+				return
+			}
+			raw, err := base64.URLEncoding.DecodeString(encoded)
+			if err != nil {
+				t.Fatalf("cannot decode credentials: %v", err)
+			}
+			var auth registry.AuthConfig
+			if err := json.Unmarshal(raw, &auth); err != nil {
+				t.Fatalf("cannot unmarshal credentials: %v", err)
+			}
+			if auth.Username != tt.wantUser || auth.ServerAddress != tt.wantServer {
+				t.Errorf("PullAuth(%q) = %s at %s, want %s at %s", tt.ref, auth.Username, auth.ServerAddress, tt.wantUser, tt.wantServer)
+			}
+		})
+	}
+
+	if _, err := PullAuth(auths, "Not A Reference"); err == nil {
+		t.Error("PullAuth() succeeded with an invalid reference")
+	}
+}
+
+func TestSecretRegistryAuths(t *testing.T) {
+	basic := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	tests := map[string]struct {
+		data    map[string][]byte
+		want    map[string]RegistryAuth
+		wantErr bool
+	}{
+		"None": {
+			data: map[string][]byte{"ca": []byte("ca-cert-data")},
+		},
+		"Auths": {
+			data: map[string][]byte{"auths": []byte(`{"ghcr.io":{"username":"gh-user","password":"gh-token"}}`)},
+			want: map[string]RegistryAuth{"ghcr.io": {Username: "gh-user", Password: "gh-token"}},
+		},
+		"DockerConfigJSON": {
+			data: map[string][]byte{".dockerconfigjson": []byte(`{"auths":{
+				"https://index.docker.io/v1/":{"auth":"` + basic("hub-user:hub:password") + `"},
+				"registry.example.com":{"identitytoken":"refresh-token"}}}`)},
+			want: map[string]RegistryAuth{
+				"docker.io":            {Username: "hub-user", Password: "hub:password", Auth: basic("hub-user:hub:password")},
+				"registry.example.com": {IdentityToken: "refresh-token"},
+			},
+		},
+		"AuthsTakePrecedence": {
+			data: map[string][]byte{
+				".dockerconfigjson": []byte(`{"auths":{"ghcr.io":{"auth":"` + basic("old:secret") + `"},"quay.io":{"auth":"` + basic("quay:secret") + `"}}}`),
+				"auths":             []byte(`{"ghcr.io":{"username":"gh-user","password":"gh-token"}}`),
+			},
+			want: map[string]RegistryAuth{
+				"ghcr.io": {Username: "gh-user", Password: "gh-token"},
+				"quay.io": {Username: "quay", Password: "secret", Auth: basic("quay:secret")},
+			},
+		},
+		"AuthNotBase64": {
+			data:    map[string][]byte{"auths": []byte(`{"ghcr.io":{"auth":"not base64!"}}`)},
+			wantErr: true,
+		},
+		"AuthNotUserPassword": {
+			data:    map[string][]byte{"auths": []byte(`{"ghcr.io":{"auth":"` + basic("token") + `"}}`)},
+			wantErr: true,
+		},
+		"InvalidDockerConfigJSON": {
+			data:    map[string][]byte{".dockerconfigjson": []byte(`{`)},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := secretRegistryAuths(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("secretRegistryAuths() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("secretRegistryAuths() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+const zeros = "0000000000000000000000000000000000000000000000000000000000000000"
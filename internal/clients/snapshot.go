@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/pkg/labels"
+)
+
+// maxSnapshotReuses bounds how many snapshot periods an inspect result is
+// reused for. A container that restarts between two snapshots looks
+// unchanged in both, so its inspect result must eventually be refreshed.
+const maxSnapshotReuses = 10
+
+// containerReader is the part of DockerClient a ContainerSnapshots needs.
+type containerReader interface {
+	ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error)
+	ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error)
+}
+
+// ContainerSnapshots shares a periodic ContainerList of the labelled
+// containers on each Docker host between the reconciles of the resources
+// on that host. A container's inspect result is reused until the snapshot
+// shows it has changed, so that observing many containers that are not
+// changing costs one list per period rather than an inspect each.
+type ContainerSnapshots struct {
+	ttl   time.Duration
+	label string
+	now   func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*hostSnapshot
+}
+
+type hostSnapshot struct {
+	mu        sync.Mutex
+	taken     time.Time
+	summaries map[string]container.Summary
+	inspected map[string]inspectResult
+}
+
+type inspectResult struct {
+	fingerprint string
+	taken       time.Time
+	info        container.InspectResponse
+}
+
+// NewContainerSnapshots returns a ContainerSnapshots that lists containers
+// carrying the supplied label, in key=value form, at most once per ttl.
+func NewContainerSnapshots(ttl time.Duration, label string) *ContainerSnapshots {
+	return &ContainerSnapshots{
+		ttl:   ttl,
+		label: label,
+		now:   time.Now,
+		hosts: map[string]*hostSnapshot{},
+	}
+}
+
+// Inspect inspects the named container on the supplied host, returning an
+// earlier inspect result if the host's snapshot shows the container has not
+// changed since. A nil ContainerSnapshots always inspects.
+func (s *ContainerSnapshots) Inspect(ctx context.Context, c containerReader, host, name string) (container.InspectResponse, error) {
+	if s == nil {
+		return c.ContainerInspect(ctx, name)
+	}
+
+	h := s.host(host)
+	now := s.now()
+
+	h.mu.Lock()
+	if now.Sub(h.taken) >= s.ttl {
+		s.refresh(ctx, c, h, now)
+	}
+	summary, listed := h.summaries[name]
+	if listed {
+		r, ok := h.inspected[summary.ID]
+		if ok && r.fingerprint == fingerprint(summary) && now.Sub(r.taken) < maxSnapshotReuses*s.ttl && settled(r.info) {
+			h.mu.Unlock()
+			return r.info, nil
+		}
+	}
+	h.mu.Unlock()
+
+	info, err := c.ContainerInspect(ctx, name)
+	if err != nil || !listed {
+		return info, err
+	}
+
+	h.mu.Lock()
+	h.inspected[summary.ID] = inspectResult{fingerprint: fingerprint(summary), taken: now, info: info}
+	h.mu.Unlock()
+	return info, nil
+}
+
+// Forget drops what is known about the named container on the supplied
+// host, so that it is inspected afresh. Callers must Forget a container
+// after changing it. Forget does nothing on a nil ContainerSnapshots.
+func (s *ContainerSnapshots) Forget(host, name string) {
+	if s == nil {
+		return
+	}
+
+	h := s.host(host)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if summary, ok := h.summaries[name]; ok {
+		delete(h.inspected, summary.ID)
+		delete(h.summaries, summary.ID)
+		for _, n := range summary.Names {
+			delete(h.summaries, strings.TrimPrefix(n, "/"))
+		}
+	}
+}
+
+// ForgetHost drops what is known about every container on the supplied
+// host, so that each is inspected afresh, as after its Docker daemon
+// restarted. ForgetHost does nothing on a nil ContainerSnapshots.
+func (s *ContainerSnapshots) ForgetHost(host string) {
+	if s == nil {
+		return
+	}
+
+	h := s.host(host)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.taken = time.Time{}
+	h.summaries = map[string]container.Summary{}
+	h.inspected = map[string]inspectResult{}
+}
+
+func (s *ContainerSnapshots) host(host string) *hostSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.hosts[host]
+	if !ok {
+		h = &hostSnapshot{summaries: map[string]container.Summary{}, inspected: map[string]inspectResult{}}
+		s.hosts[host] = h
+	}
+	return h
+}
+
+// refresh replaces the host's snapshot. When the list fails the snapshot
+// is left empty, so that every container is inspected until it succeeds.
+func (s *ContainerSnapshots) refresh(ctx context.Context, c containerReader, h *hostSnapshot, now time.Time) {
+	h.taken = now
+	h.summaries = map[string]container.Summary{}
+
+	list, err := c.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: labels.Filter(s.label),
+	})
+	if err != nil {
+		h.inspected = map[string]inspectResult{}
+		return
+	}
+
+	inspected := make(map[string]inspectResult, len(list))
+	for _, summary := range list {
+		h.summaries[summary.ID] = summary
+		for _, n := range summary.Names {
+			h.summaries[strings.TrimPrefix(n, "/")] = summary
+		}
+		if r, ok := h.inspected[summary.ID]; ok {
+			inspected[summary.ID] = r
+		}
+	}
+	h.inspected = inspected
+}
+
+// fingerprint summarises the parts of a listed container that change when
+// its inspect result would. The listed status reports uptime, so only its
+// health is taken from it.
+func fingerprint(summary container.Summary) string {
+	health := ""
+	for _, h := range []string{"(healthy)", "(unhealthy)", "(health: starting)"} {
+		if strings.Contains(summary.Status, h) {
+			health = h
+		}
+	}
+	return strings.Join([]string{summary.ID, summary.ImageID, string(summary.State), health}, "|")
+}
+
+// settled reports whether a container is in a state it stays in until
+// something changes it. Containers that are starting or restarting are
+// always inspected.
+func settled(info container.InspectResponse) bool {
+	if info.ContainerJSONBase == nil || info.State == nil {
+		return false
+	}
+	switch info.State.Status {
+	case container.StateRunning:
+		return info.State.Health == nil || info.State.Health.Status != container.Starting
+	case container.StateExited, container.StatePaused, container.StateDead:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+)
+
+type fakeContainerReader struct {
+	list     []container.Summary
+	listErr  error
+	state    string
+	lists    int
+	inspects int
+}
+
+func (f *fakeContainerReader) ContainerList(_ context.Context, _ container.ListOptions) ([]container.Summary, error) {
+	f.lists++
+	return f.list, f.listErr
+}
+
+func (f *fakeContainerReader) ContainerInspect(_ context.Context, name string) (container.InspectResponse, error) {
+	f.inspects++
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			ID:    "abc123",
+			Name:  "/" + name,
+			State: &container.State{Status: container.ContainerState(f.state)},
+		},
+	}, nil
+}
+
+func TestContainerSnapshotsInspect(t *testing.T) {
+	type step struct {
+		advance      time.Duration
+		state        container.ContainerState
+		forget       bool
+		forgetHost   bool
+		wantInspects int
+		wantLists    int
+	}
+
+	cases := map[string]struct {
+		listErr error
+		steps   []step
+	}{
+		"ReusesUnchangedContainer": {
+			steps: []step{
+				{state: container.StateRunning, wantInspects: 1, wantLists: 1},
+				{advance: 5 * time.Second, state: container.StateRunning, wantInspects: 1, wantLists: 1},
+				{advance: 10 * time.Second, state: container.StateRunning, wantInspects: 1, wantLists: 2},
+			},
+		},
+		"InspectsChangedContainer": {
+			steps: []step{
+				{state: container.StateRunning, wantInspects: 1, wantLists: 1},
+				{advance: 10 * time.Second, state: container.StateExited, wantInspects: 2, wantLists: 2},
+			},
+		},
+		"InspectsTransitioningContainer": {
+			steps: []step{
+				{state: container.StateRestarting, wantInspects: 1, wantLists: 1},
+				{state: container.StateRestarting, wantInspects: 2, wantLists: 1},
+			},
+		},
+		"InspectsForgottenContainer": {
+			steps: []step{
+				{state: container.StateRunning, wantInspects: 1, wantLists: 1},
+				{state: container.StateRunning, forget: true, wantInspects: 2, wantLists: 1},
+			},
+		},
+		"RelistsForgottenHost": {
+			steps: []step{
+				{state: container.StateRunning, wantInspects: 1, wantLists: 1},
+				{state: container.StateRunning, forgetHost: true, wantInspects: 2, wantLists: 2},
+			},
+		},
+		"RefreshesOldInspectResult": {
+			steps: []step{
+				{state: container.StateRunning, wantInspects: 1, wantLists: 1},
+				{advance: maxSnapshotReuses * 10 * time.Second, state: container.StateRunning, wantInspects: 2, wantLists: 2},
+			},
+		},
+		"InspectsWhenListFails": {
+			listErr: errors.New("boom"),
+			steps: []step{
+				{state: container.StateRunning, wantInspects: 1, wantLists: 1},
+				{state: container.StateRunning, wantInspects: 2, wantLists: 1},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Unix(0, 0)
+			s := NewContainerSnapshots(10*time.Second, "docker.crossplane.io/managed-by=provider-docker")
+			s.now = func() time.Time { return now }
+			f := &fakeContainerReader{listErr: tc.listErr}
+
+			for i, st := range tc.steps {
+				now = now.Add(st.advance)
+				f.state = string(st.state)
+				f.list = []container.Summary{{ID: "abc123", Names: []string{"/web"}, State: st.state}}
+				if st.forget {
+					s.Forget("tcp://docker:2376", "web")
+				}
+				if st.forgetHost {
+					s.ForgetHost("tcp://docker:2376")
+				}
+
+				info, err := s.Inspect(context.Background(), f, "tcp://docker:2376", "web")
+				if err != nil {
+					t.Fatalf("step %d: Inspect() error = %v", i, err)
+				}
+				if info.ID != "abc123" {
+					t.Errorf("step %d: Inspect() ID = %q, want %q", i, info.ID, "abc123")
+				}
+				if f.inspects != st.wantInspects {
+					t.Errorf("step %d: ContainerInspect calls = %d, want %d", i, f.inspects, st.wantInspects)
+				}
+				if f.lists != st.wantLists {
+					t.Errorf("step %d: ContainerList calls = %d, want %d", i, f.lists, st.wantLists)
+				}
+			}
+		})
+	}
+}
+
+func TestContainerSnapshotsNil(t *testing.T) {
+	var s *ContainerSnapshots
+	f := &fakeContainerReader{state: string(container.StateRunning)}
+
+	s.Forget("", "web")
+	s.ForgetHost("")
+	if _, err := s.Inspect(context.Background(), f, "", "web"); err != nil {
+		t.Fatalf("Inspect() error = %v", err)
+	}
+	if f.inspects != 1 || f.lists != 0 {
+		t.Errorf("Inspect() made %d inspects and %d lists, want 1 and 0", f.inspects, f.lists)
+	}
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusterdns registers the ports containers publish on their Docker
+// hosts as Kubernetes Services, so that workloads in the cluster find them
+// with cluster DNS.
+package clusterdns
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+)
+
+const (
+	errNoAddress      = "clusterDNS.address must be set for a Docker host that is not reached over TCP or SSH"
+	errServiceName    = "cannot name a Service after %q: %s"
+	errNotOwned       = "Service %s/%s exists and is not registered for this container"
+	errGetService     = "cannot get Service"
+	errApplyService   = "cannot apply Service"
+	errApplySlice     = "cannot apply EndpointSlice"
+	errDeleteService  = "cannot delete Service"
+	errDeleteSlice    = "cannot delete EndpointSlice"
+	errGetSlice       = "cannot get EndpointSlice"
+	defaultNamespace  = "default"
+	endpointManagedBy = "provider-docker.crossplane.io"
+)
+
+// A Port is a port a container publishes on its Docker host.
+type Port struct {
+	// Port is the port within the container, which the Service exposes.
+	Port int32
+
+	// HostPort is the port published on the Docker host.
+	HostPort int32
+
+	Protocol corev1.Protocol
+}
+
+// name names a port of a Service, which must be unique within it.
+func (p Port) name() string {
+	return fmt.Sprintf("%s-%d", strings.ToLower(string(p.Protocol)), p.Port)
+}
+
+// A Registration is the Service of a container.
+type Registration struct {
+	// Namespace and Name of the Service.
+	Namespace string
+	Name      string
+
+	// Address the cluster reaches the Docker host at.
+	Address string
+
+	// Ports the container publishes.
+	Ports []Port
+
+	// Ready is whether the container is ready to serve its ports.
+	Ready bool
+}
+
+// Ports returns the ports a container publishes on its Docker host, by the
+// port within the container. Ports published only on a loopback address of
+// the host are skipped, since the cluster cannot reach them.
+func Ports(info *container.InspectResponse) []Port {
+	if info.NetworkSettings == nil {
+		return nil
+	}
+	var ports []Port
+	for port, bindings := range info.NetworkSettings.Ports {
+		for _, b := range bindings {
+			if ip := net.ParseIP(b.HostIP); ip != nil && ip.IsLoopback() {
+				continue
+			}
+			var host int
+			if _, err := fmt.Sscan(b.HostPort, &host); err != nil || host == 0 {
+				continue
+			}
+			ports = append(ports, Port{
+				Port:     int32(port.Int()),
+				HostPort: int32(host),
+				Protocol: corev1.Protocol(strings.ToUpper(port.Proto())),
+			})
+			break
+		}
+	}
+	slices.SortFunc(ports, func(a, b Port) int {
+		return cmp.Or(cmp.Compare(a.Port, b.Port), strings.Compare(string(a.Protocol), string(b.Protocol)))
+	})
+	return ports
+}
+
+// Address returns the address the cluster reaches a Docker host at: that
+// the ProviderConfig sets, or else the host of its Docker endpoint.
+func Address(cfg *v1beta1.ClusterDNS, host string) (string, error) {
+	if cfg.Address != nil && *cfg.Address != "" {
+		return *cfg.Address, nil
+	}
+	u, err := url.Parse(host)
+	if err != nil || (u.Scheme != "tcp" && u.Scheme != "ssh" && u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return "", errors.New(errNoAddress)
+	}
+	return u.Hostname(), nil
+}
+
+// Namespace returns the namespace of the Service of a container: its own,
+// or for a cluster scoped container that the ProviderConfig sets.
+func Namespace(cfg *v1beta1.ClusterDNS, namespace string) string {
+	switch {
+	case namespace != "":
+		return namespace
+	case cfg.Namespace != "":
+		return cfg.Namespace
+	}
+	return defaultNamespace
+}
+
+// ServiceName returns the name of the Service of a container, which is its
+// own with any dots replaced, since a Service is named by a DNS label.
+func ServiceName(name string) (string, error) {
+	svc := strings.ReplaceAll(name, ".", "-")
+	if errs := validation.IsDNS1035Label(svc); len(errs) > 0 {
+		return "", errors.Errorf(errServiceName, name, strings.Join(errs, ", "))
+	}
+	return svc, nil
+}
+
+// Register creates or updates the Service of a container, controlled by its
+// owner so that it is deleted along with it. A Docker host reached at an IP
+// address is registered as the endpoints of a Service without a selector,
+// which forwards the ports of the container to those published on the host;
+// one reached at a hostname is registered as an ExternalName Service, which
+// is reached at the ports published on the host.
+func Register(ctx context.Context, kube client.Client, owner metav1.Object, gvk schema.GroupVersionKind, r Registration) error {
+	ref := *metav1.NewControllerRef(owner, gvk)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: r.Namespace, Name: r.Name}}
+	err := kube.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: r.Name}, svc)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, errGetService)
+	}
+	exists := err == nil
+	if exists && !metav1.IsControlledBy(svc, owner) {
+		return errors.Errorf(errNotOwned, r.Namespace, r.Name)
+	}
+
+	orig := svc.DeepCopy()
+	ip := net.ParseIP(r.Address)
+	svc.OwnerReferences = []metav1.OwnerReference{ref}
+	svc.Spec.Ports = make([]corev1.ServicePort, 0, len(r.Ports))
+	for _, p := range r.Ports {
+		sp := corev1.ServicePort{Name: p.name(), Protocol: p.Protocol, Port: p.Port, TargetPort: intstr.FromInt32(p.HostPort)}
+		if ip == nil {
+			sp.Port = p.HostPort
+		}
+		svc.Spec.Ports = append(svc.Spec.Ports, sp)
+	}
+	svc.Spec.Selector = nil
+	if ip == nil {
+		svc.Spec.Type, svc.Spec.ExternalName = corev1.ServiceTypeExternalName, r.Address
+		svc.Spec.ClusterIP, svc.Spec.ClusterIPs, svc.Spec.IPFamilies, svc.Spec.IPFamilyPolicy = "", nil, nil, nil
+	} else if svc.Spec.Type != corev1.ServiceTypeClusterIP {
+		svc.Spec.Type, svc.Spec.ExternalName = corev1.ServiceTypeClusterIP, ""
+	}
+	if err := apply(ctx, kube, exists, orig, svc); err != nil {
+		return errors.Wrap(err, errApplyService)
+	}
+
+	if ip == nil {
+		return deleteSlice(ctx, kube, r.Namespace, r.Name)
+	}
+	return applySlice(ctx, kube, ref, ip, r)
+}
+
+// applySlice creates or updates the EndpointSlice of the Service of a
+// container, whose one endpoint is its Docker host.
+func applySlice(ctx context.Context, kube client.Client, ref metav1.OwnerReference, ip net.IP, r Registration) error {
+	slice := &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Namespace: r.Namespace, Name: r.Name}}
+	err := kube.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: r.Name}, slice)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, errGetSlice)
+	}
+	exists := err == nil
+	orig := slice.DeepCopy()
+
+	slice.OwnerReferences = []metav1.OwnerReference{ref}
+	slice.Labels = map[string]string{
+		discoveryv1.LabelServiceName: r.Name,
+		discoveryv1.LabelManagedBy:   endpointManagedBy,
+	}
+	slice.AddressType = discoveryv1.AddressTypeIPv4
+	if ip.To4() == nil {
+		slice.AddressType = discoveryv1.AddressTypeIPv6
+	}
+	ready := r.Ready
+	slice.Endpoints = []discoveryv1.Endpoint{{
+		Addresses:  []string{ip.String()},
+		Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+	}}
+	slice.Ports = make([]discoveryv1.EndpointPort, 0, len(r.Ports))
+	for _, p := range r.Ports {
+		name, protocol, port := p.name(), p.Protocol, p.HostPort
+		slice.Ports = append(slice.Ports, discoveryv1.EndpointPort{Name: &name, Protocol: &protocol, Port: &port})
+	}
+	return errors.Wrap(apply(ctx, kube, exists, orig, slice), errApplySlice)
+}
+
+// apply creates an object that does not exist, or updates one that does if
+// it has changed from orig.
+func apply(ctx context.Context, kube client.Client, exists bool, orig, obj client.Object) error {
+	switch {
+	case !exists:
+		return kube.Create(ctx, obj)
+	case equality.Semantic.DeepEqual(orig, obj):
+		return nil
+	}
+	return kube.Update(ctx, obj)
+}
+
+// Unregister deletes the Service of a container, and its EndpointSlice, if
+// the container controls them.
+func Unregister(ctx context.Context, kube client.Client, owner metav1.Object, namespace, name string) error {
+	svc := &corev1.Service{}
+	err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, svc)
+	if kerrors.IsNotFound(err) || (err == nil && !metav1.IsControlledBy(svc, owner)) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetService)
+	}
+	if err := deleteSlice(ctx, kube, namespace, name); err != nil {
+		return err
+	}
+	return errors.Wrap(client.IgnoreNotFound(kube.Delete(ctx, svc)), errDeleteService)
+}
+
+// deleteSlice deletes the EndpointSlice of the Service of a container, if
+// it has one the provider manages.
+func deleteSlice(ctx context.Context, kube client.Client, namespace, name string) error {
+	slice := &discoveryv1.EndpointSlice{}
+	err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, slice)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetSlice)
+	}
+	if slice.Labels[discoveryv1.LabelManagedBy] != endpointManagedBy {
+		return nil
+	}
+	return errors.Wrap(client.IgnoreNotFound(kube.Delete(ctx, slice)), errDeleteSlice)
+}
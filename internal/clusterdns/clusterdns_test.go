@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+)
+
+var ownerKind = schema.GroupVersionKind{Group: "container.docker.crossplane.io", Version: "v1alpha1", Kind: "Container"}
+
+func TestPorts(t *testing.T) {
+	info := &container.InspectResponse{NetworkSettings: &container.NetworkSettings{NetworkSettingsBase: container.NetworkSettingsBase{Ports: nat.PortMap{
+		"8080/tcp": {{HostIP: "0.0.0.0", HostPort: "32768"}, {HostIP: "::", HostPort: "32768"}},
+		"53/udp":   {{HostIP: "10.0.0.5", HostPort: "5353"}},
+		"9090/tcp": {{HostIP: "127.0.0.1", HostPort: "9090"}},
+		"5432/tcp": nil,
+	}}}}
+	want := []Port{
+		{Port: 53, HostPort: 5353, Protocol: corev1.ProtocolUDP},
+		{Port: 8080, HostPort: 32768, Protocol: corev1.ProtocolTCP},
+	}
+	if diff := cmp.Diff(want, Ports(info)); diff != "" {
+		t.Errorf("Ports(): -want, +got:\n%s", diff)
+	}
+}
+
+func TestAddress(t *testing.T) {
+	cases := map[string]struct {
+		cfg     v1beta1.ClusterDNS
+		host    string
+		want    string
+		wantErr bool
+	}{
+		"Configured":  {cfg: v1beta1.ClusterDNS{Address: ptr.To("docker.lan")}, host: "unix:///var/run/docker.sock", want: "docker.lan"},
+		"TCP":         {host: "tcp://10.0.0.5:2376", want: "10.0.0.5"},
+		"SSH":         {host: "ssh://deploy@docker.lan", want: "docker.lan"},
+		"Socket":      {host: "unix:///var/run/docker.sock", wantErr: true},
+		"DefaultHost": {wantErr: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Address(&tc.cfg, tc.host)
+			if (err != nil) != tc.wantErr || got != tc.want {
+				t.Errorf("Address() = %q, %v, want %q, error %v", got, err, tc.want, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceName(t *testing.T) {
+	if got, err := ServiceName("web.frontend"); err != nil || got != "web-frontend" {
+		t.Errorf("ServiceName() = %q, %v, want web-frontend", got, err)
+	}
+	if _, err := ServiceName("1web"); err == nil {
+		t.Error("ServiceName() of a name that is not a DNS label: want error")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	ctx := context.Background()
+	owner := &metav1.ObjectMeta{Name: "web", UID: "uid"}
+	kube := fake.NewClientBuilder().Build()
+	key := types.NamespacedName{Namespace: "apps", Name: "web"}
+	r := Registration{
+		Namespace: "apps",
+		Name:      "web",
+		Address:   "10.0.0.5",
+		Ports:     []Port{{Port: 80, HostPort: 32768, Protocol: corev1.ProtocolTCP}},
+		Ready:     true,
+	}
+
+	// A host reached at an IP address is the endpoint of the Service
+	if err := Register(ctx, kube, owner, ownerKind, r); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	svc := &corev1.Service{}
+	if err := kube.Get(ctx, key, svc); err != nil {
+		t.Fatalf("Register() did not create the Service: %v", err)
+	}
+	wantPorts := []corev1.ServicePort{{Name: "tcp-80", Protocol: corev1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt32(32768)}}
+	if diff := cmp.Diff(wantPorts, svc.Spec.Ports); diff != "" || svc.Spec.Selector != nil || !metav1.IsControlledBy(svc, owner) {
+		t.Errorf("Register() Service = %+v, want a selectorless Service controlled by the container: -want, +got:\n%s", svc.Spec, diff)
+	}
+	slice := &discoveryv1.EndpointSlice{}
+	if err := kube.Get(ctx, key, slice); err != nil {
+		t.Fatalf("Register() did not create the EndpointSlice: %v", err)
+	}
+	if slice.Labels[discoveryv1.LabelServiceName] != "web" || slice.Endpoints[0].Addresses[0] != "10.0.0.5" ||
+		!*slice.Endpoints[0].Conditions.Ready || *slice.Ports[0].Port != 32768 || *slice.Ports[0].Name != "tcp-80" {
+		t.Errorf("Register() EndpointSlice = %+v, want the published port on the Docker host", slice)
+	}
+
+	// Registering again changes nothing
+	version := svc.ResourceVersion
+	if err := Register(ctx, kube, owner, ownerKind, r); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := kube.Get(ctx, key, svc); err != nil || svc.ResourceVersion != version {
+		t.Errorf("Register() of an unchanged container updated its Service to version %s, want %s", svc.ResourceVersion, version)
+	}
+
+	// A host reached at a hostname is an ExternalName
+	r.Address = "docker.lan"
+	if err := Register(ctx, kube, owner, ownerKind, r); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := kube.Get(ctx, key, svc); err != nil || svc.Spec.Type != corev1.ServiceTypeExternalName || svc.Spec.ExternalName != "docker.lan" || svc.Spec.Ports[0].Port != 32768 {
+		t.Errorf("Register() Service = %+v, want an ExternalName reached at the published port", svc.Spec)
+	}
+	if err := kube.Get(ctx, key, slice); !kerrors.IsNotFound(err) {
+		t.Errorf("Register() of an ExternalName kept the EndpointSlice: %v", err)
+	}
+
+	// Another container's Service is left alone
+	other := &metav1.ObjectMeta{Name: "web", UID: "other"}
+	if err := Register(ctx, kube, other, ownerKind, r); err == nil {
+		t.Error("Register() over the Service of another container: want error")
+	}
+	if err := Unregister(ctx, kube, other, "apps", "web"); err != nil {
+		t.Fatalf("Unregister() error = %v", err)
+	}
+	if err := kube.Get(ctx, key, svc); err != nil {
+		t.Errorf("Unregister() deleted the Service of another container: %v", err)
+	}
+
+	if err := Unregister(ctx, kube, owner, "apps", "web"); err != nil {
+		t.Fatalf("Unregister() error = %v", err)
+	}
+	if err := kube.Get(ctx, key, svc); !kerrors.IsNotFound(err) {
+		t.Errorf("Unregister() kept the Service: %v", err)
+	}
+}
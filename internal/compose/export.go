@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/pkg/labels"
+)
+
+// Export renders the observed containers of a stack, keyed by service name,
+// as a Docker Compose file. It is meant for audit and for recreating a stack
+// on another host, so it describes the containers as they run rather than as
+// they were specified.
+func Export(projectName string, containers map[string]container.InspectResponse) ([]byte, error) {
+	project := &types.Project{
+		Name:     projectName,
+		Services: types.Services{},
+		Networks: types.Networks{},
+		Volumes:  types.Volumes{},
+	}
+
+	for name, info := range containers {
+		svc := types.ServiceConfig{Name: name}
+		if info.Name != "" {
+			svc.ContainerName = strings.TrimPrefix(info.Name, "/")
+		}
+
+		if cfg := info.Config; cfg != nil {
+			svc.Image = cfg.Image
+			svc.Command = types.ShellCommand(cfg.Cmd)
+			svc.Entrypoint = types.ShellCommand(cfg.Entrypoint)
+			svc.User = cfg.User
+			svc.WorkingDir = cfg.WorkingDir
+			svc.Hostname = cfg.Hostname
+			svc.StopSignal = cfg.StopSignal
+			if cfg.StopTimeout != nil {
+				period := types.Duration(time.Duration(*cfg.StopTimeout) * time.Second)
+				svc.StopGracePeriod = &period
+			}
+			if len(cfg.Env) > 0 {
+				svc.Environment = types.NewMappingWithEquals(cfg.Env)
+			}
+			for k, v := range cfg.Labels {
+				if labels.IsStackLabel(k) {
+					continue
+				}
+				if svc.Labels == nil {
+					svc.Labels = types.Labels{}
+				}
+				svc.Labels[k] = v
+			}
+		}
+
+		if hc := info.HostConfig; hc != nil {
+			if policy := string(hc.RestartPolicy.Name); policy != "" && policy != "no" {
+				svc.Restart = policy
+			}
+			svc.Privileged = hc.Privileged
+			svc.DeviceCgroupRules = hc.DeviceCgroupRules
+			svc.Init = hc.Init
+			svc.ReadOnly = hc.ReadonlyRootfs
+			svc.CapAdd = hc.CapAdd
+			svc.CapDrop = hc.CapDrop
+			svc.Ports = exportPorts(hc)
+			if mode := string(hc.NetworkMode); mode == "host" || mode == "none" {
+				svc.NetworkMode = mode
+			} else if shared := sharedNetworkService(containers, hc.NetworkMode); shared != "" {
+				svc.NetworkMode = types.ServicePrefix + shared
+			}
+		}
+
+		for _, m := range info.Mounts {
+			v := types.ServiceVolumeConfig{
+				Type:     string(m.Type),
+				Source:   m.Source,
+				Target:   m.Destination,
+				ReadOnly: !m.RW,
+			}
+			if m.Type == mount.TypeVolume {
+				v.Source = m.Name
+				project.Volumes[m.Name] = types.VolumeConfig{Name: m.Name, Driver: m.Driver}
+			}
+			svc.Volumes = append(svc.Volumes, v)
+		}
+		sort.Slice(svc.Volumes, func(i, j int) bool { return svc.Volumes[i].Target < svc.Volumes[j].Target })
+
+		if info.NetworkSettings != nil && svc.NetworkMode == "" {
+			for net, ep := range info.NetworkSettings.Networks {
+				if net == "bridge" || net == "host" || net == "none" {
+					continue
+				}
+				if svc.Networks == nil {
+					svc.Networks = map[string]*types.ServiceNetworkConfig{}
+				}
+				cfg := &types.ServiceNetworkConfig{}
+				if ep != nil {
+					cfg.Aliases = ep.Aliases
+				}
+				svc.Networks[net] = cfg
+				project.Networks[net] = types.NetworkConfig{Name: net}
+			}
+		}
+
+		project.Services[name] = svc
+	}
+
+	out, err := project.MarshalYAML()
+	return out, errors.Wrap(err, "cannot render compose file")
+}
+
+// sharedNetworkService returns the service whose container a container shares
+// its network stack with, or "" if it does not share one within the stack.
+func sharedNetworkService(containers map[string]container.InspectResponse, mode container.NetworkMode) string {
+	if !mode.IsContainer() {
+		return ""
+	}
+	id := mode.ConnectedContainer()
+	for name, info := range containers {
+		if info.ID == id || strings.TrimPrefix(info.Name, "/") == id {
+			return name
+		}
+	}
+	return ""
+}
+
+// exportPorts returns the published ports of a container, sorted by target.
+func exportPorts(hc *container.HostConfig) []types.ServicePortConfig {
+	var ports []types.ServicePortConfig
+	for port, bindings := range hc.PortBindings {
+		for _, b := range bindings {
+			ports = append(ports, types.ServicePortConfig{
+				Mode:      "ingress",
+				HostIP:    b.HostIP,
+				Target:    uint32(port.Int()), //nolint:gosec // Port numbers fit in 16 bits.
+				Published: b.HostPort,
+				Protocol:  port.Proto(),
+			})
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Target != ports[j].Target {
+			return ports[i].Target < ports[j].Target
+		}
+		return ports[i].Protocol < ports[j].Protocol
+	})
+	return ports
+}
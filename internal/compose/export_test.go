@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+)
+
+func TestExport(t *testing.T) {
+	observed := map[string]container.InspectResponse{
+		"web": {
+			ContainerJSONBase: &container.ContainerJSONBase{
+				Name: "/shop_web",
+				HostConfig: &container.HostConfig{
+					RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+					PortBindings: nat.PortMap{
+						"80/tcp": []nat.PortBinding{{HostPort: "8080"}},
+					},
+				},
+			},
+			Config: &container.Config{
+				Image: "nginx:1.27",
+				Env:   []string{"MODE=production"},
+				Labels: map[string]string{
+					"com.docker.compose.project": "shop",
+					"com.docker.compose.service": "web",
+					"tier":                       "frontend",
+				},
+			},
+			Mounts: []container.MountPoint{
+				{Type: mount.TypeVolume, Name: "shop_assets", Destination: "/usr/share/nginx/html", RW: false},
+			},
+		},
+	}
+
+	out, err := Export("shop", observed)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	content := string(out)
+	for _, want := range []string{"image: nginx:1.27", "restart: unless-stopped", "MODE: production", "tier: frontend", `published: "8080"`, "shop_assets"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Export() output does not contain %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "com.docker.compose.") {
+		t.Errorf("Export() output contains compose labels:\n%s", content)
+	}
+
+	// The export must be usable to recreate the stack
+	result, err := NewParser("shop", "", nil).ParseCompose(context.Background(), content)
+	if err != nil {
+		t.Fatalf("ParseCompose() of exported stack error = %v\n%s", err, content)
+	}
+	if len(result.Containers) != 1 || result.Containers[0].Spec.ForProvider.Image != "nginx:1.27" {
+		t.Errorf("ParseCompose() of exported stack returned %+v", result.Containers)
+	}
+}
+
+func TestExport_SharedNetwork(t *testing.T) {
+	observed := map[string]container.InspectResponse{
+		"vpn": {
+			ContainerJSONBase: &container.ContainerJSONBase{
+				ID:         "abc123",
+				Name:       "/media_vpn",
+				HostConfig: &container.HostConfig{NetworkMode: "bridge"},
+			},
+			Config: &container.Config{Image: "gluetun:latest"},
+		},
+		"app": {
+			ContainerJSONBase: &container.ContainerJSONBase{
+				Name:       "/media_app",
+				HostConfig: &container.HostConfig{NetworkMode: "container:abc123"},
+			},
+			Config: &container.Config{Image: "qbittorrent:latest"},
+		},
+	}
+
+	out, err := Export("media", observed)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if !strings.Contains(string(out), "network_mode: service:vpn") {
+		t.Errorf("Export() output does not share vpn's network:\n%s", out)
+	}
+
+	result, err := NewParser("media", "", nil).ParseCompose(context.Background(), string(out))
+	if err != nil {
+		t.Fatalf("ParseCompose() of exported stack error = %v\n%s", err, out)
+	}
+	if got := *result.Containers[0].Spec.ForProvider.Name; got != "vpn" {
+		t.Errorf("ParseCompose() of exported stack starts with %s, want vpn", got)
+	}
+}
@@ -21,12 +21,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/compose-spec/compose-go/v2/cli"
 	"github.com/compose-spec/compose-go/v2/types"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
 )
@@ -36,6 +40,7 @@ type Parser struct {
 	projectName string
 	workingDir  string
 	environment map[string]string
+	profiles    []string
 }
 
 // NewParser creates a new compose parser with the given configuration.
@@ -47,12 +52,24 @@ func NewParser(projectName, workingDir string, environment map[string]string) *P
 	}
 }
 
+// WithProfiles activates compose profiles, so that the services that list
+// them are parsed. Services that list profiles none of which is active are
+// left out of the project's services, in its disabled services.
+func (p *Parser) WithProfiles(profiles []string) *Parser {
+	p.profiles = profiles
+	return p
+}
+
 // ParseResult contains the results of parsing a compose file.
 type ParseResult struct {
 	Project    *types.Project
 	Containers []containerv1alpha1.Container
 	Networks   []NetworkDefinition
 	Volumes    []VolumeDefinition
+
+	// Warnings are deprecation warnings about legacy keys in the compose
+	// file that were translated rather than used as is.
+	Warnings []string
 }
 
 // NetworkDefinition represents a Docker network to be created.
@@ -97,6 +114,7 @@ func (p *Parser) ParseCompose(ctx context.Context, composeContent string) (*Pars
 		[]string{composeFile},
 		cli.WithName(p.projectName),
 		cli.WithWorkingDirectory(workingDir),
+		cli.WithProfiles(p.profiles),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create project options")
@@ -124,6 +142,7 @@ func (p *Parser) ParseCompose(ctx context.Context, composeContent string) (*Pars
 		return nil, errors.Wrap(err, "failed to convert services")
 	}
 	result.Containers = containers
+	result.Warnings = p.convertLinks(project.Services, result.Containers)
 
 	// Convert networks
 	networks := p.convertNetworks(project.Networks)
@@ -137,10 +156,17 @@ func (p *Parser) ParseCompose(ctx context.Context, composeContent string) (*Pars
 }
 
 // convertServices converts Docker Compose services to Container resources.
+// Services are returned in dependency order, so that every service follows the
+// services it depends on.
 func (p *Parser) convertServices(services types.Services) ([]containerv1alpha1.Container, error) {
 	var containers []containerv1alpha1.Container
 
-	for _, service := range services {
+	ordered, err := servicesInDependencyOrder(services)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, service := range ordered {
 		container, err := p.convertService(service)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to convert service %s", service.Name)
@@ -187,6 +213,12 @@ func (p *Parser) convertService(service types.ServiceConfig) (*containerv1alpha1
 		params.Networks = p.convertServiceNetworks(service.Networks)
 	}
 
+	// Convert network mode. A service:<name> mode is resolved to that
+	// service's container when the stack is created.
+	if service.NetworkMode != "" {
+		params.NetworkMode = &service.NetworkMode
+	}
+
 	// Convert restart policy
 	if service.Restart != "" {
 		params.RestartPolicy = &service.Restart
@@ -212,6 +244,36 @@ func (p *Parser) convertService(service types.ServiceConfig) (*containerv1alpha1
 		params.Labels = service.Labels
 	}
 
+	// Convert stop signal, grace period and init, so that the service
+	// shuts down the way it did under docker compose
+	if service.StopSignal != "" {
+		params.StopSignal = &service.StopSignal
+	}
+	if service.StopGracePeriod != nil {
+		params.StopGracePeriod = &metav1.Duration{Duration: time.Duration(*service.StopGracePeriod)}
+	}
+	if service.Init != nil {
+		params.Init = service.Init
+	}
+
+	// Convert device cgroup rules
+	if len(service.DeviceCgroupRules) > 0 {
+		params.DeviceCgroupRules = service.DeviceCgroupRules
+	}
+
+	// Convert logging
+	if service.Logging != nil {
+		params.LogConfig = &containerv1alpha1.LogConfig{
+			Driver:  service.Logging.Driver,
+			Options: service.Logging.Options,
+		}
+	}
+
+	// Convert platform
+	if service.Platform != "" {
+		params.Platform = &service.Platform
+	}
+
 	container.Spec.ForProvider = params
 
 	return container, nil
@@ -338,6 +400,78 @@ func (p *Parser) convertServiceNetworks(networks map[string]*types.ServiceNetwor
 	return networkAttachments
 }
 
+// convertLinks translates the legacy links and external_links keys of each
+// service. A link to another service becomes an alias of that service on the
+// networks the two share. An external link, to a container outside the stack,
+// becomes an endpoint link on each of the service's networks. Every legacy
+// link yields a deprecation warning.
+func (p *Parser) convertLinks(services types.Services, containers []containerv1alpha1.Container) []string {
+	byService := make(map[string]*containerv1alpha1.ContainerParameters, len(containers))
+	for i := range containers {
+		if name := containers[i].Spec.ForProvider.Name; name != nil {
+			byService[*name] = &containers[i].Spec.ForProvider
+		}
+	}
+
+	var warnings []string
+	for _, cont := range containers {
+		name := *cont.Spec.ForProvider.Name
+		service := services[name]
+		params := byService[name]
+
+		for _, link := range service.Links {
+			target, alias := splitLink(link)
+			linked := byService[target]
+			if linked == nil || !addSharedAlias(params.Networks, linked.Networks, alias) {
+				warnings = append(warnings, fmt.Sprintf("service %s: links is deprecated and %s shares no network with it, so it cannot be reached as %s", name, target, alias))
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("service %s: links is deprecated, %s is reachable as %s on their shared networks instead", name, target, alias))
+		}
+
+		for _, link := range service.ExternalLinks {
+			target, alias := splitLink(link)
+			if len(params.Networks) == 0 {
+				warnings = append(warnings, fmt.Sprintf("service %s: external_links is deprecated and the service has no networks, so %s cannot be reached as %s", name, target, alias))
+				continue
+			}
+			for i := range params.Networks {
+				params.Networks[i].Links = append(params.Networks[i].Links, target+":"+alias)
+			}
+			warnings = append(warnings, fmt.Sprintf("service %s: external_links is deprecated, %s is linked as %s on the service's networks instead", name, target, alias))
+		}
+	}
+
+	return warnings
+}
+
+// splitLink splits a SERVICE[:ALIAS] link. The alias defaults to the service.
+func splitLink(link string) (string, string) {
+	target, alias, found := strings.Cut(link, ":")
+	if !found {
+		alias = target
+	}
+	return target, alias
+}
+
+// addSharedAlias adds alias to each of the linked attachments on a network the
+// linking attachments are also on. It reports whether there was such a network.
+func addSharedAlias(linking, linked []containerv1alpha1.NetworkAttachment, alias string) bool {
+	shared := false
+	for i := range linked {
+		for _, attachment := range linking {
+			if attachment.Name != linked[i].Name {
+				continue
+			}
+			shared = true
+			if !slices.Contains(linked[i].Aliases, alias) {
+				linked[i].Aliases = append(linked[i].Aliases, alias)
+			}
+		}
+	}
+	return shared
+}
+
 // convertNetworks converts Docker Compose networks to NetworkDefinitions.
 func (p *Parser) convertNetworks(networks types.Networks) []NetworkDefinition {
 	var networkDefs []NetworkDefinition
@@ -406,3 +540,82 @@ func (p *Parser) GetServiceDependencies(project *types.Project) map[string][]str
 
 	return dependencies
 }
+
+// GetCompletionDependencies returns, for each service, the dependencies that
+// must run to completion and exit successfully before the service is started
+// (depends_on condition service_completed_successfully).
+func (p *Parser) GetCompletionDependencies(project *types.Project) map[string][]string {
+	dependencies := make(map[string][]string)
+
+	for _, service := range project.Services {
+		var deps []string
+
+		for dep, config := range service.DependsOn {
+			if config.Condition == types.ServiceConditionCompletedSuccessfully {
+				deps = append(deps, dep)
+			}
+		}
+
+		if len(deps) > 0 {
+			sort.Strings(deps)
+			dependencies[service.Name] = deps
+		}
+	}
+
+	return dependencies
+}
+
+// servicesInDependencyOrder sorts services so that each one follows its
+// depends_on dependencies. Services that are otherwise unordered are sorted by
+// name to keep creation order stable across reconciles.
+func servicesInDependencyOrder(services types.Services) ([]types.ServiceConfig, error) {
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ordered := make([]types.ServiceConfig, 0, len(services))
+	visited := make(map[string]bool, len(services))
+	visiting := make(map[string]bool)
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+		if visiting[name] {
+			return errors.Errorf("dependency cycle detected at service %s", name)
+		}
+		service := services[name]
+
+		visiting[name] = true
+		deps := make([]string, 0, len(service.DependsOn))
+		for dep := range service.DependsOn {
+			deps = append(deps, dep)
+		}
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if _, ok := services[dep]; !ok {
+				// Optional dependencies may have been disabled by profiles
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		delete(visiting, name)
+
+		visited[name] = true
+		ordered = append(ordered, service)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
@@ -18,7 +18,10 @@ package compose
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParser_ParseCompose(t *testing.T) {
@@ -121,6 +124,81 @@ services:
 				}
 			},
 		},
+		{
+			name:        "service with stop settings",
+			projectName: "test-stop",
+			workingDir:  "",
+			environment: nil,
+			composeContent: `
+services:
+  worker:
+    image: busybox:latest
+    stop_signal: SIGINT
+    stop_grace_period: 1m30s
+    init: true
+`,
+			wantErr:        false,
+			wantContainers: 1,
+			validateResult: func(t *testing.T, result *ParseResult) {
+				params := result.Containers[0].Spec.ForProvider
+				if params.StopSignal == nil || *params.StopSignal != "SIGINT" {
+					t.Errorf("Expected stop signal SIGINT, got %v", params.StopSignal)
+				}
+				if params.StopGracePeriod == nil || params.StopGracePeriod.Duration != 90*time.Second {
+					t.Errorf("Expected stop grace period 1m30s, got %v", params.StopGracePeriod)
+				}
+				if params.Init == nil || !*params.Init {
+					t.Errorf("Expected init to be enabled, got %v", params.Init)
+				}
+			},
+		},
+		{
+			name:        "service with logging",
+			projectName: "test-logging",
+			workingDir:  "",
+			environment: nil,
+			composeContent: `
+services:
+  web:
+    image: nginx:latest
+    logging:
+      driver: json-file
+      options:
+        max-size: 10m
+        max-file: "3"
+`,
+			wantErr:        false,
+			wantContainers: 1,
+			validateResult: func(t *testing.T, result *ParseResult) {
+				lc := result.Containers[0].Spec.ForProvider.LogConfig
+				if lc == nil || lc.Driver != "json-file" {
+					t.Fatalf("Expected json-file log driver, got %v", lc)
+				}
+				if lc.Options["max-size"] != "10m" || lc.Options["max-file"] != "3" {
+					t.Errorf("Expected rotation options, got %v", lc.Options)
+				}
+			},
+		},
+		{
+			name:        "service with platform",
+			projectName: "test-platform",
+			workingDir:  "",
+			environment: nil,
+			composeContent: `
+services:
+  web:
+    image: nginx:latest
+    platform: linux/arm64
+`,
+			wantErr:        false,
+			wantContainers: 1,
+			validateResult: func(t *testing.T, result *ParseResult) {
+				p := result.Containers[0].Spec.ForProvider.Platform
+				if p == nil || *p != "linux/arm64" {
+					t.Errorf("Expected platform linux/arm64, got %v", p)
+				}
+			},
+		},
 		{
 			name:        "service with volumes",
 			projectName: "test-volumes",
@@ -434,3 +512,187 @@ services:
 		})
 	}
 }
+
+func TestParser_DependencyOrder(t *testing.T) {
+	composeContent := `
+services:
+  web:
+    image: nginx:latest
+    depends_on:
+      - api
+  api:
+    image: node:18-alpine
+    depends_on:
+      migrate:
+        condition: service_completed_successfully
+      redis:
+        condition: service_started
+  migrate:
+    image: node:18-alpine
+  redis:
+    image: redis:7-alpine
+`
+
+	parser := NewParser("test", "", nil)
+	result, err := parser.ParseCompose(context.Background(), composeContent)
+	if err != nil {
+		t.Fatalf("ParseCompose() error = %v", err)
+	}
+
+	var got []string
+	for _, c := range result.Containers {
+		got = append(got, *c.Spec.ForProvider.Name)
+	}
+	want := []string{"migrate", "redis", "api", "web"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ParseCompose() container order = %v, want %v", got, want)
+	}
+
+	completion := parser.GetCompletionDependencies(result.Project)
+	if len(completion) != 1 || strings.Join(completion["api"], ",") != "migrate" {
+		t.Errorf("GetCompletionDependencies() = %v, want map[api:[migrate]]", completion)
+	}
+}
+
+func TestParser_LegacyLinks(t *testing.T) {
+	composeContent := `
+services:
+  web:
+    image: nginx:latest
+    links:
+      - api:backend
+    external_links:
+      - legacy-db:db
+  api:
+    image: node:18-alpine
+    networks:
+      - default
+      - internal
+networks:
+  internal: {}
+`
+
+	parser := NewParser("test", "", nil)
+	result, err := parser.ParseCompose(context.Background(), composeContent)
+	if err != nil {
+		t.Fatalf("ParseCompose() error = %v", err)
+	}
+
+	type endpoint struct{ aliases, links []string }
+	byName := make(map[string]map[string]endpoint)
+	for _, c := range result.Containers {
+		networks := make(map[string]endpoint)
+		for _, n := range c.Spec.ForProvider.Networks {
+			networks[n.Name] = endpoint{n.Aliases, n.Links}
+		}
+		byName[*c.Spec.ForProvider.Name] = networks
+	}
+
+	if got := byName["api"]["default"].aliases; strings.Join(got, ",") != "backend" {
+		t.Errorf("api aliases on default = %v, want [backend]", got)
+	}
+	if got := byName["api"]["internal"].aliases; len(got) != 0 {
+		t.Errorf("api aliases on internal = %v, want none", got)
+	}
+	if got := byName["web"]["default"].links; strings.Join(got, ",") != "legacy-db:db" {
+		t.Errorf("web links on default = %v, want [legacy-db:db]", got)
+	}
+
+	if len(result.Warnings) != 2 {
+		t.Fatalf("ParseCompose() warnings = %v, want 2", result.Warnings)
+	}
+	for _, w := range result.Warnings {
+		if !strings.HasPrefix(w, "service web: ") || !strings.Contains(w, "deprecated") {
+			t.Errorf("ParseCompose() warning = %q, want a deprecation warning for web", w)
+		}
+	}
+}
+
+func TestParser_NetworkMode(t *testing.T) {
+	composeContent := `
+services:
+  app:
+    image: qbittorrent:latest
+    network_mode: service:vpn
+  vpn:
+    image: gluetun:latest
+  monitor:
+    image: node-exporter:latest
+    network_mode: host
+`
+
+	parser := NewParser("test", "", nil)
+	result, err := parser.ParseCompose(context.Background(), composeContent)
+	if err != nil {
+		t.Fatalf("ParseCompose() error = %v", err)
+	}
+
+	var order []string
+	modes := make(map[string]string)
+	for _, c := range result.Containers {
+		name := *c.Spec.ForProvider.Name
+		order = append(order, name)
+		if c.Spec.ForProvider.NetworkMode != nil {
+			modes[name] = *c.Spec.ForProvider.NetworkMode
+		}
+		if name != "vpn" && len(c.Spec.ForProvider.Networks) != 0 {
+			t.Errorf("%s networks = %v, want none", name, c.Spec.ForProvider.Networks)
+		}
+	}
+
+	if modes["app"] != "service:vpn" {
+		t.Errorf("app network mode = %q, want service:vpn", modes["app"])
+	}
+	if modes["monitor"] != "host" {
+		t.Errorf("monitor network mode = %q, want host", modes["monitor"])
+	}
+	if _, ok := modes["vpn"]; ok {
+		t.Errorf("vpn network mode = %q, want unset", modes["vpn"])
+	}
+	if slices.Index(order, "vpn") > slices.Index(order, "app") {
+		t.Errorf("ParseCompose() container order = %v, want vpn before app", order)
+	}
+}
+
+func TestParser_Profiles(t *testing.T) {
+	composeContent := `
+services:
+  web:
+    image: nginx:latest
+  debug:
+    image: busybox:latest
+    profiles: [debug]
+  metrics:
+    image: node-exporter:latest
+    profiles: [monitoring]
+`
+
+	tests := map[string]struct {
+		profiles     []string
+		wantServices []string
+	}{
+		"None":     {wantServices: []string{"web"}},
+		"One":      {profiles: []string{"debug"}, wantServices: []string{"debug", "web"}},
+		"Unknown":  {profiles: []string{"tracing"}, wantServices: []string{"web"}},
+		"Wildcard": {profiles: []string{"*"}, wantServices: []string{"debug", "metrics", "web"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := NewParser("test", "", nil).WithProfiles(tt.profiles).ParseCompose(context.Background(), composeContent)
+			if err != nil {
+				t.Fatalf("ParseCompose() error = %v", err)
+			}
+			var got []string
+			for _, c := range result.Containers {
+				got = append(got, *c.Spec.ForProvider.Name)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.wantServices) {
+				t.Errorf("ParseCompose() services = %v, want %v", got, tt.wantServices)
+			}
+			if len(result.Project.DisabledServices)+len(got) != 3 {
+				t.Errorf("ParseCompose() disabled services = %v, want the others", result.Project.DisabledServiceNames())
+			}
+		})
+	}
+}
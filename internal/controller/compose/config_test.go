@@ -18,13 +18,18 @@ package compose
 
 import (
 	"context"
+	"github.com/docker/docker/api/types/container"
 	"github.com/google/go-cmp/cmp"
 	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -367,7 +372,10 @@ func TestExternal_BuildEnvironment(t *testing.T) {
 		},
 	}
 
-	env := ext.buildEnvironment(context.Background(), cr)
+	env, err := ext.buildEnvironment(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("buildEnvironment() error = %v", err)
+	}
 
 	expectedEnv := map[string]string{
 		"NODE_ENV":          "production",
@@ -380,6 +388,64 @@ func TestExternal_BuildEnvironment(t *testing.T) {
 	}
 }
 
+func TestExternal_BuildEnvironmentStrict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+	ext := &external{kube: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()}
+
+	strict := true
+	cr := &composev1alpha1.ComposeStack{ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default"}}
+	cr.Spec.ForProvider.StrictEnvResolution = &strict
+	cr.Spec.ForProvider.Environment = []composev1alpha1.ComposeEnvVar{
+		{Name: "DB_PASSWORD", ValueFrom: &composev1alpha1.EnvVarSource{SecretKeyRef: &composev1alpha1.SecretKeySelector{Name: "db", Key: "password"}}},
+		{Name: "DB_USER", ValueFrom: &composev1alpha1.EnvVarSource{SecretKeyRef: &composev1alpha1.SecretKeySelector{Name: "db", Key: "user"}}},
+		{Name: "API_URL", ValueFrom: &composev1alpha1.EnvVarSource{ConfigMapKeyRef: &composev1alpha1.ConfigMapKeySelector{Name: "api", Key: "url"}}},
+	}
+
+	if _, err := ext.buildEnvironment(context.Background(), cr); err == nil {
+		t.Fatal("buildEnvironment() error = nil, want unresolved variables")
+	}
+	cond := cr.GetCondition(composev1alpha1.TypeMissingReference)
+	if cond.Status != corev1.ConditionTrue {
+		t.Fatalf("buildEnvironment() MissingReference = %q, want True", cond.Status)
+	}
+	for _, name := range []string{"DB_USER", "API_URL"} {
+		if !strings.Contains(cond.Message, name) {
+			t.Errorf("buildEnvironment() MissingReference message %q does not list %s", cond.Message, name)
+		}
+	}
+	if strings.Contains(cond.Message, "DB_PASSWORD") {
+		t.Errorf("buildEnvironment() MissingReference message %q lists DB_PASSWORD, which resolved", cond.Message)
+	}
+
+	// Once every reference resolves, the condition is cleared
+	cr.Spec.ForProvider.Environment = cr.Spec.ForProvider.Environment[:1]
+	env, err := ext.buildEnvironment(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("buildEnvironment() error = %v", err)
+	}
+	if env["DB_PASSWORD"] != "hunter2" {
+		t.Errorf("buildEnvironment() DB_PASSWORD = %q, want hunter2", env["DB_PASSWORD"])
+	}
+	if got := cr.GetCondition(composev1alpha1.TypeMissingReference).Status; got != corev1.ConditionFalse {
+		t.Errorf("buildEnvironment() MissingReference = %q, want False", got)
+	}
+
+	// Without strict resolution, unresolved variables are left empty
+	cr.Spec.ForProvider.StrictEnvResolution = nil
+	cr.Spec.ForProvider.Environment = append(cr.Spec.ForProvider.Environment, composev1alpha1.ComposeEnvVar{
+		Name: "API_URL", ValueFrom: &composev1alpha1.EnvVarSource{ConfigMapKeyRef: &composev1alpha1.ConfigMapKeySelector{Name: "api", Key: "url"}},
+	})
+	env, err = ext.buildEnvironment(context.Background(), cr)
+	if err != nil || env["API_URL"] != "" {
+		t.Errorf("buildEnvironment() = %v, %v, want API_URL empty", env, err)
+	}
+}
+
 func TestExternal_GetComposeContent(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -542,7 +608,114 @@ services:
 	}
 }
 
-// Helper function
+// Helper functions
 func stringPtr(s string) *string {
 	return &s
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestParseMemory(t *testing.T) {
+	cases := map[string]struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		"Mebibytes":     {in: "512Mi", want: 512 * 1024 * 1024},
+		"Gibibytes":     {in: "2Gi", want: 2 * 1024 * 1024 * 1024},
+		"Bytes":         {in: "1048576", want: 1048576},
+		"Megabytes":     {in: "100M", want: 100 * 1000 * 1000},
+		"UnknownSuffix": {in: "100MB", wantErr: true},
+		"Negative":      {in: "-1Gi", wantErr: true},
+		"Empty":         {in: "", wantErr: true},
+		"TooLarge":      {in: "9Ei", wantErr: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseMemory(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseMemory(%q) error = %v, wantErr %v", tc.in, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("parseMemory(%q) = %d, want %d", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseCPU(t *testing.T) {
+	cases := map[string]struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		"Whole":      {in: "2", want: 2_000_000_000},
+		"Fraction":   {in: "1.5", want: 1_500_000_000},
+		"Millicores": {in: "500m", want: 500_000_000},
+		"NotANumber": {in: "two", wantErr: true},
+		"Negative":   {in: "-1", wantErr: true},
+		"TooLarge":   {in: "1e12", wantErr: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseCPU(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseCPU(%q) error = %v, wantErr %v", tc.in, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("parseCPU(%q) = %d, want %d", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func FuzzParseMemory(f *testing.F) {
+	for _, seed := range []string{"512Mi", "2Gi", "1048576", "100M", "100MB", "-1", "", "9Ei", "1e3"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		bytes, err := parseMemory(s)
+		if err != nil {
+			if !strings.Contains(err.Error(), strconv.Quote(s)) {
+				t.Errorf("parseMemory(%q) error %q does not name the value", s, err)
+			}
+			return
+		}
+		if bytes < 0 {
+			t.Errorf("parseMemory(%q) = %d, want at least 0", s, bytes)
+		}
+	})
+}
+
+func FuzzParseCPU(f *testing.F) {
+	for _, seed := range []string{"2", "1.5", "500m", "0.001", "two", "-1", "", "1e12", "1n"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		nanos, err := parseCPU(s)
+		if err != nil {
+			if !strings.Contains(err.Error(), strconv.Quote(s)) {
+				t.Errorf("parseCPU(%q) error %q does not name the value", s, err)
+			}
+			return
+		}
+		if nanos < 0 {
+			t.Errorf("parseCPU(%q) = %d, want at least 0", s, nanos)
+		}
+	})
+}
+
+func TestSetResourceLimitsInvalid(t *testing.T) {
+	resources := &containerv1alpha1.ResourceRequirements{
+		Limits: containerv1alpha1.ResourceList{"cpu": intstr.FromString("lots")},
+	}
+	err := (&external{}).setResourceLimits(&container.HostConfig{}, resources)
+	if err == nil {
+		t.Fatal("setResourceLimits() succeeded with an invalid CPU limit")
+	}
+	if want := "resources.limits.cpu"; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("setResourceLimits() error %q does not start with the field path %s", err, want)
+	}
+}
@@ -18,46 +18,66 @@ package compose
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/go-connections/nat"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
 	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
 	dockerclients "github.com/rossigee/provider-docker/internal/clients"
 	"github.com/rossigee/provider-docker/internal/compose"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+	"github.com/rossigee/provider-docker/internal/shutdown"
 	"github.com/rossigee/provider-docker/internal/tracing"
+	"github.com/rossigee/provider-docker/internal/usage"
+	"github.com/rossigee/provider-docker/pkg/labels"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"maps"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	errNotComposeStack  = "managed resource is not a ComposeStack custom resource"
-	errTrackPCUsage     = "cannot track ProviderConfig usage"
-	errGetPC            = "cannot get ProviderConfig"
-	errGetCreds         = "cannot get credentials"
-	errNewClient        = "cannot create new Docker client"
-	errParseCompose     = "cannot parse Docker Compose content"
-	errGetConfigMap     = "cannot get ConfigMap"
-	errGetSecret        = "cannot get Secret"
-	errCreateContainer  = "cannot create container"
-	errObserveContainer = "cannot observe container"
-	errUpdateContainer  = "cannot update container"
-	errDeleteContainer  = "cannot delete container"
+	errNotComposeStack   = "managed resource is not a ComposeStack custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errGetCreds          = "cannot get credentials"
+	errNewClient         = "cannot create new Docker client"
+	errParseCompose      = "cannot parse Docker Compose content"
+	errGetConfigMap      = "cannot get ConfigMap"
+	errGetSecret         = "cannot get Secret"
+	errCreateContainer   = "cannot create container"
+	errObserveContainer  = "cannot observe container"
+	errUpdateContainer   = "cannot update container"
+	errDeleteContainer   = "cannot delete container"
+	errExportStack       = "cannot export stack"
+	errExportEnvironment = "cannot export stack environment"
+	errCaptureInspect    = "cannot capture service inspect output"
+	errPublishSecrets    = "cannot publish service connection secrets"
+	errSecretNotOwned    = "Secret %s/%s exists and is not controlled by this stack"
+	errConfigMapNotOwned = "ConfigMap %s/%s exists and is not controlled by this stack"
+	errListContainers    = "cannot list containers"
 
 	// Reconcile intervals
 	reconcileTimeout = 2 * time.Minute
@@ -67,15 +87,20 @@ const (
 // Setup adds a controller that reconciles ComposeStack managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(composev1alpha1.ComposeStackGroupKind.String())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
 
+	backoff := dockerclients.NewTerminalBackoff(pollInterval)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(composev1alpha1.ComposeStackGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(backoff.Connector(dryrun.Connector(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
 			newServiceFn: dockerclients.NewDockerClient,
-		}),
+			exporter:     usage.NewExporter(mgr.GetClient(), o.Logger),
+			recorder:     recorder,
+		}, recorder))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithFinalizer(dockerclients.NewUsageFinalizer(mgr.GetClient())),
 		managed.WithPollInterval(pollInterval),
 		managed.WithRecorder(nil))
 
@@ -84,7 +109,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&composev1alpha1.ComposeStack{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, backoff.Reconciler(r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -93,6 +118,8 @@ type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
 	newServiceFn func(context.Context, client.Client, resource.Managed) (dockerclients.DockerClient, error)
+	exporter     *usage.Exporter
+	recorder     event.Recorder
 }
 
 // Connect typically produces an ExternalClient by:
@@ -115,11 +142,21 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
+	pc, err := dockerclients.GetProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
 
 	return &external{
-		kube:    c.kube,
-		service: svc,
-		parser:  compose.NewParser("", "", nil),
+		kube:        c.kube,
+		service:     svc,
+		parser:      compose.NewParser("", "", nil),
+		recorder:    c.recorder,
+		usageExport: pc.Spec.UsageExport,
+		exporter:    c.exporter,
+		registryAuths: func(ctx context.Context) (map[string]dockerclients.RegistryAuth, error) {
+			return dockerclients.ProviderConfigRegistryAuths(ctx, c.kube, pc)
+		},
 	}, nil
 }
 
@@ -129,6 +166,19 @@ type external struct {
 	kube    client.Client
 	service dockerclients.DockerClient
 	parser  *compose.Parser
+
+	// recorder is sent an event summarising the services of a stack that
+	// failed, when the stack aggregates their logs.
+	recorder event.Recorder
+
+	// The usage of the running containers of a stack is sampled and
+	// exported as the ProviderConfig says, if it does.
+	usageExport *apisv1beta1.UsageExport
+	exporter    *usage.Exporter
+
+	// Images are pulled with the registry credentials of the ProviderConfig,
+	// which are only read when an image is pulled.
+	registryAuths dockerclients.RegistryAuthsFunc
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
@@ -136,7 +186,7 @@ func (c *external) Disconnect(ctx context.Context) error {
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	_, span := tracing.StartSpan(ctx, "composestack.observe",
+	ctx, span := tracing.StartSpan(ctx, "composestack.observe",
 		tracing.SpanAttrs("composestack", mg.GetName(), "observe")...)
 	defer span.End()
 
@@ -153,8 +203,11 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	// Create parser with project configuration
 	projectName := c.getProjectName(cr)
-	environment := c.buildEnvironment(ctx, cr)
-	parser := compose.NewParser(projectName, "", environment)
+	environment, err := c.buildEnvironment(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	parser := compose.NewParser(projectName, "", environment).WithProfiles(cr.Spec.ForProvider.Profiles)
 
 	// Parse the compose file
 	parseResult, err := parser.ParseCompose(ctx, composeContent)
@@ -162,6 +215,15 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, errParseCompose)
 	}
 
+	model, err := modelHash(parseResult.Project, cr.Spec.ForProvider.ServiceOverrides)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	hashes, err := serviceHashes(parseResult.Project, cr.Spec.ForProvider.ServiceOverrides)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	// Check if containers exist and get their status
 	observation := managed.ExternalObservation{
 		ResourceExists:   true,
@@ -169,26 +231,61 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	services := make(map[string]composev1alpha1.ServiceStatus)
+	observed := make(map[string]container.InspectResponse)
+	failed := make(map[string]string)
 	allRunning := true
+	drifted := false
+	var checks stackHealth
+
+	// Services that others wait on to complete are expected to exit
+	oneShot := make(map[string]bool)
+	for _, deps := range parser.GetCompletionDependencies(parseResult.Project) {
+		for _, dep := range deps {
+			oneShot[dep] = true
+		}
+	}
+
+	owned, err := c.stackContainers(ctx, cr, projectName)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveContainer)
+	}
+	replicas := replicaContainers(owned)
 
 	for _, container := range parseResult.Containers {
-		containerName := c.getContainerName(projectName, container.Name)
+		// Containers are found by the stack's labels, falling back to the
+		// name for a container created before the stack was labelled
+		ref, ok := replicas[serviceName(&container)][1]
+		if !ok {
+			ref = c.getContainerName(projectName, container.Name)
+		}
 
 		// Try to inspect the container
-		containerInfo, err := c.service.ContainerInspect(ctx, containerName)
+		containerInfo, err := c.service.ContainerInspect(ctx, ref)
 		if err != nil {
 			// Container doesn't exist
 			observation.ResourceExists = false
 			observation.ResourceUpToDate = false
 			allRunning = false
-			services[container.Name] = composev1alpha1.ServiceStatus{
+			status := composev1alpha1.ServiceStatus{
 				Name:  container.Name,
 				State: "pending",
 			}
+			observePhase(&status, cr.Status.AtProvider.Services[container.Name], nil, "")
+			services[container.Name] = status
 			continue
 		}
 
 		// Container exists, check its state
+		observed[container.Name] = containerInfo
+		if containerInfo.State != nil && containerInfo.State.Running {
+			c.exporter.Export(ctx, c.usageExport, c.service, containerInfo.ID, usage.Resource{
+				Kind:      composev1alpha1.ComposeStackKind,
+				Name:      cr.GetName(),
+				Namespace: cr.GetNamespace(),
+				Service:   serviceName(&container),
+				Labels:    usageLabels(cr),
+			})
+		}
 		status := composev1alpha1.ServiceStatus{
 			Name: container.Name,
 		}
@@ -214,6 +311,30 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 				status.StartedAt = &metav1.Time{Time: startedAt}
 			}
 		}
+		status.Health = serviceHealth(containerInfo.State)
+		checks.observe(serviceName(&container), healthOf(containerInfo.State))
+
+		// A service whose definition has changed since its container was
+		// created, or whose configuration no longer matches the hash its
+		// container was created with, has drifted
+		serviceDrifted, err := c.serviceDrifted(ctx, cr, projectName, model, hashes[serviceName(&container)], &container, containerInfo)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errObserveContainer)
+		}
+		if serviceDrifted {
+			drifted = true
+		}
+
+		reason := serviceFailure(containerInfo.State, oneShot[serviceName(&container)])
+		if reason != "" {
+			failed[container.Name] = reason
+		}
+		observePhase(&status, cr.Status.AtProvider.Services[container.Name], containerInfo.State, reason)
+
+		if oneShot[serviceName(&container)] && completedSuccessfully(containerInfo.State) {
+			services[container.Name] = status
+			continue
+		}
 
 		if containerInfo.State == nil || containerInfo.State.Status != "running" {
 			allRunning = false
@@ -223,16 +344,116 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		services[container.Name] = status
 	}
 
+	// The replicas of scaled services beyond their first are observed
+	// like it, and those they were scaled down from left for Update to
+	// remove
+	for _, cont := range parseResult.Containers {
+		name := serviceName(&cont)
+		var first *container.State
+		if info, ok := observed[cont.Name]; ok {
+			first = info.State
+		}
+		status := services[cont.Name]
+		scale, err := c.observeReplicas(ctx, cr, projectName, model, hashes[name], &cont, replicas[name], first, oneShot[name], &status)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errObserveContainer)
+		}
+		services[cont.Name] = status
+		if scale.missing {
+			observation.ResourceExists = false
+			observation.ResourceUpToDate = false
+		}
+		if scale.notReady || scale.missing {
+			allRunning = false
+			observation.ResourceUpToDate = false
+		}
+		drifted = drifted || scale.drifted
+		for _, h := range scale.health {
+			checks.observe(name, h)
+		}
+	}
+	if len(surplusReplicas(cr, parseResult.Project, owned)) > 0 {
+		observation.ResourceUpToDate = false
+	}
+
+	// The containers of services whose profiles are no longer active are
+	// left for Update to remove
+	if len(disabledContainers(parseResult.Project, owned)) > 0 {
+		observation.ResourceUpToDate = false
+	}
+
+	// Outside its maintenance window, drift is reported but not acted on
+	upToDate, err := deferDrift(cr, !drifted, time.Now())
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !upToDate {
+		observation.ResourceUpToDate = false
+	}
+
+	// A stack that was interrupted by a pause is being reconciled again
+	if cr.GetCondition(composev1alpha1.TypePaused).Status == v1.ConditionTrue {
+		cr.SetConditions(composev1alpha1.Resumed())
+	}
+
+	// Surface the logs of a stack with failed services, with an event when
+	// it first fails
+	serviceLogs := c.aggregateLogs(ctx, cr, observed, failed)
+	if len(serviceLogs) > 0 && len(cr.Status.AtProvider.ServiceLogs) == 0 && c.recorder != nil {
+		c.recorder.Event(cr, failureEvent(failed, serviceLogs))
+	}
+
+	// Score the stack on the events of its services. A stack whose events
+	// cannot be read keeps its last score until they can.
+	health, err := c.observeHealth(ctx, cr, projectName, time.Now())
+	if err != nil {
+		health = cr.Status.AtProvider.Health
+	}
+
 	// Update status
-	cr.Status.AtProvider.ProjectName = projectName
-	cr.Status.AtProvider.Services = services
-	cr.Status.AtProvider.ParsedAt = &metav1.Time{Time: time.Now()}
+	parsedAt := &metav1.Time{Time: time.Now()}
+	if err := c.updateObservation(ctx, cr, func(obs *composev1alpha1.ComposeStackObservation) {
+		obs.ProjectName = projectName
+		obs.Services = services
+		obs.ParsedAt = parsedAt
+		obs.Warnings = parseResult.Warnings
+		obs.ServiceLogs = serviceLogs
+		obs.ModelHash = model
+		obs.Health = health
+	}); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	reportHealth(cr, health)
+
+	if name := cr.GetAnnotations()[labels.AnnotationExportConfigMap]; name != "" && observation.ResourceExists {
+		if err := c.exportStack(ctx, cr, name, projectName, observed); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errExportStack)
+		}
+	}
+
+	if service := cr.GetAnnotations()[labels.AnnotationInspectService]; service != "" {
+		if err := c.captureInspect(ctx, cr, service, observed); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errCaptureInspect)
+		}
+	}
+
+	if cr.Spec.ForProvider.ExportEnvironment != nil && *cr.Spec.ForProvider.ExportEnvironment {
+		if err := c.exportEnvironment(ctx, cr, environment); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errExportEnvironment)
+		}
+	}
+
+	if err := c.publishConnectionSecrets(ctx, cr, parseResult.Containers, observed); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errPublishSecrets)
+	}
 
 	// Set conditions
 	if !observation.ResourceExists {
 		cr.SetConditions(xpv1.Unavailable())
 	} else if allRunning {
-		cr.SetConditions(xpv1.Available())
+		// A running stack is only Available once its services pass their
+		// health checks
+		cr.SetConditions(checks.condition())
 	} else {
 		cr.SetConditions(xpv1.Creating())
 	}
@@ -241,7 +462,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	_, span := tracing.StartSpan(ctx, "composestack.create",
+	ctx, span := tracing.StartSpan(ctx, "composestack.create",
 		tracing.SpanAttrs("composestack", mg.GetName(), "create")...)
 	defer span.End()
 
@@ -250,6 +471,12 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotComposeStack)
 	}
 
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	defer done()
+
 	cr.SetConditions(xpv1.Creating())
 
 	// Parse the compose content
@@ -260,22 +487,71 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	// Create parser with project configuration
 	projectName := c.getProjectName(cr)
-	environment := c.buildEnvironment(ctx, cr)
-	parser := compose.NewParser(projectName, "", environment)
+	environment, err := c.buildEnvironment(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	parser := compose.NewParser(projectName, "", environment).WithProfiles(cr.Spec.ForProvider.Profiles)
 
 	// Parse the compose file
 	parseResult, err := parser.ParseCompose(ctx, composeContent)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errParseCompose)
 	}
+	model, err := modelHash(parseResult.Project, cr.Spec.ForProvider.ServiceOverrides)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	hashes, err := serviceHashes(parseResult.Project, cr.Spec.ForProvider.ServiceOverrides)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	// Create the networks the services attach to before the services
+	if err := c.createNetworks(ctx, cr, projectName, parseResult.Project, parseResult.Containers); err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
+	}
 
-	// Create containers in dependency order
-	// For now, create them sequentially. In a full implementation,
-	// we would implement proper dependency ordering based on depends_on
+	// Create containers in dependency order. Services that depend on another
+	// service completing successfully are only created once it has exited,
+	// and those that depend on a service with a readiness probe once it
+	// passes; until then they are left for a later reconcile.
+	completion := parser.GetCompletionDependencies(parseResult.Project)
+	containerNames := make(map[string]string, len(parseResult.Containers))
 	for _, cont := range parseResult.Containers {
-		err := c.createContainer(ctx, cr, projectName, &cont)
+		containerNames[serviceName(&cont)] = c.getContainerName(projectName, cont.Name)
+	}
+	for _, cont := range parseResult.Containers {
+		if c.pauseRequested(ctx, cr) {
+			// Leave the remaining services for when the stack is unpaused
+			cr.SetConditions(composev1alpha1.Paused())
+			return managed.ExternalCreation{}, nil
+		}
+
+		completed, err := c.dependenciesCompleted(ctx, containerNames, completion[serviceName(&cont)])
 		if err != nil {
-			return managed.ExternalCreation{}, errors.Wrapf(err, errCreateContainer)
+			return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, errCreateContainer))
+		}
+		if !completed {
+			return managed.ExternalCreation{}, nil
+		}
+		ready, waiting, err := c.dependenciesReady(ctx, cr, containerNames, parseResult.Project.Services[serviceName(&cont)].DependsOn)
+		if err != nil {
+			return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, errCreateContainer))
+		}
+		if !ready {
+			if _, err := c.service.ContainerInspect(ctx, containerNames[serviceName(&cont)]); err != nil {
+				c.recordPhase(ctx, cr, cont.Name, composev1alpha1.ServicePhasePending, waiting)
+			}
+			return managed.ExternalCreation{}, nil
+		}
+
+		// Every replica of a scaled service is created with its first
+		for n := int32(1); n <= replicaCount(cr, serviceName(&cont)); n++ {
+			err = c.createContainer(ctx, cr, projectName, model, hashes[serviceName(&cont)], &cont, n)
+			if err != nil {
+				return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrapf(err, errCreateContainer))
+			}
 		}
 	}
 
@@ -286,21 +562,158 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	_, span := tracing.StartSpan(ctx, "composestack.update",
+	ctx, span := tracing.StartSpan(ctx, "composestack.update",
 		tracing.SpanAttrs("composestack", mg.GetName(), "update")...)
 	defer span.End()
 
-	_, ok := mg.(*composev1alpha1.ComposeStack)
+	cr, ok := mg.(*composev1alpha1.ComposeStack)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotComposeStack)
 	}
 
-	// Update is not yet fully implemented - for now return an error
-	return managed.ExternalUpdate{}, errors.New("update is not yet implemented")
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	defer done()
+
+	// Parse the compose content
+	composeContent, err := c.getComposeContent(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errParseCompose)
+	}
+
+	// Create parser with project configuration
+	projectName := c.getProjectName(cr)
+	environment, err := c.buildEnvironment(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	parser := compose.NewParser(projectName, "", environment).WithProfiles(cr.Spec.ForProvider.Profiles)
+
+	// Parse the compose file
+	parseResult, err := parser.ParseCompose(ctx, composeContent)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errParseCompose)
+	}
+	model, err := modelHash(parseResult.Project, cr.Spec.ForProvider.ServiceOverrides)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	hashes, err := serviceHashes(parseResult.Project, cr.Spec.ForProvider.ServiceOverrides)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Networks added to the compose file are created before the services
+	// that attach to them are recreated
+	if err := c.createNetworks(ctx, cr, projectName, parseResult.Project, parseResult.Containers); err != nil {
+		return managed.ExternalUpdate{}, tracing.RecordError(span, err)
+	}
+
+	owned, err := c.stackContainers(ctx, cr, projectName)
+	if err != nil {
+		return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateContainer))
+	}
+	replicas := replicaContainers(owned)
+
+	// Services whose profiles are no longer active are removed first, and
+	// those whose profiles have become active are left for Create. So are
+	// the replicas services were scaled down from, while those they were
+	// scaled up to are left for Create too.
+	if err := c.removeDisabledServices(ctx, parseResult.Project, owned); err != nil {
+		return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateContainer))
+	}
+	if err := c.removeSurplusReplicas(ctx, cr, parseResult.Project, owned); err != nil {
+		return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateContainer))
+	}
+
+	// Outside its maintenance window, drifted services are left as they
+	// are, but stopped ones are still started
+	deferred := cr.GetCondition(composev1alpha1.TypeDeferred).Status == v1.ConditionTrue
+
+	// Converge each service in dependency order, recreating only those that
+	// have drifted and starting those that have stopped. Services that are
+	// missing are left for Create, and the others are left untouched. The
+	// dependents of a service that was recreated are restarted, so that they
+	// reconnect to it at its new address.
+	completion := parser.GetCompletionDependencies(parseResult.Project)
+	oneShot := make(map[string]bool)
+	for _, deps := range completion {
+		for _, dep := range deps {
+			oneShot[dep] = true
+		}
+	}
+	containerNames := make(map[string]string, len(parseResult.Containers))
+	for _, cont := range parseResult.Containers {
+		containerNames[serviceName(&cont)] = c.getContainerName(projectName, cont.Name)
+	}
+	recreated := make(map[string]bool)
+	for _, cont := range parseResult.Containers {
+		if c.pauseRequested(ctx, cr) {
+			// Leave the remaining services for when the stack is unpaused
+			cr.SetConditions(composev1alpha1.Paused())
+			return managed.ExternalUpdate{}, nil
+		}
+
+		name := serviceName(&cont)
+		ref, ok := replicas[name][1]
+		if !ok {
+			ref = c.getContainerName(projectName, cont.Name)
+		}
+		info, err := c.service.ContainerInspect(ctx, ref)
+		if err != nil {
+			continue
+		}
+
+		drifted, err := c.serviceDrifted(ctx, cr, projectName, model, hashes[name], &cont, info)
+		if err != nil {
+			return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateContainer))
+		}
+		// A service that shares the network of one that was recreated
+		// still refers to the container that was removed, so it cannot
+		// be restarted and is recreated as well
+		if shared := sharedNetworkService(&cont); shared != "" && recreated[shared] {
+			drifted = true
+		}
+		switch {
+		case drifted && !deferred:
+			// A service that waits on another to complete, or to pass
+			// its readiness probe, is only recreated once it has
+			completed, err := c.dependenciesCompleted(ctx, containerNames, completion[name])
+			if err != nil {
+				return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateContainer))
+			}
+			if !completed {
+				return managed.ExternalUpdate{}, nil
+			}
+			ready, _, err := c.dependenciesReady(ctx, cr, containerNames, parseResult.Project.Services[name].DependsOn)
+			if err != nil {
+				return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateContainer))
+			}
+			if !ready {
+				return managed.ExternalUpdate{}, nil
+			}
+			err = c.recreateContainer(ctx, cr, projectName, model, hashes[name], &cont, 1, info)
+			recreated[name] = true
+		case dependsOnAny(parseResult.Project.Services[name].DependsOn, recreated) && !isStopped(info.State):
+			err = c.restartContainer(ctx, cr, &cont, info.ID)
+		case !oneShot[name] && isStopped(info.State):
+			err = c.startContainer(ctx, cr, &cont, info.ID)
+		}
+		if err != nil {
+			return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateContainer))
+		}
+		if err := c.convergeReplicas(ctx, cr, projectName, model, hashes[name], &cont, replicas[name], deferred, oneShot[name]); err != nil {
+			return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateContainer))
+		}
+	}
+
+	return managed.ExternalUpdate{}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
-	_, span := tracing.StartSpan(ctx, "composestack.delete",
+	ctx, span := tracing.StartSpan(ctx, "composestack.delete",
 		tracing.SpanAttrs("composestack", mg.GetName(), "delete")...)
 	defer span.End()
 
@@ -309,29 +722,28 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalDelete{}, errors.New(errNotComposeStack)
 	}
 
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	defer done()
+
 	cr.SetConditions(xpv1.Deleting())
+	healthScore.DeleteLabelValues(cr.GetNamespace(), cr.GetName())
 
 	// Get project name
 	projectName := c.getProjectName(cr)
 
-	// List all containers with this project name and remove them
-	// This is a simplified approach - a full implementation would track
-	// exactly which containers belong to this stack
-	containers, err := c.service.ContainerList(ctx, container.ListOptions{
-		All: true,
-		Filters: filters.NewArgs(
-			filters.Arg("label", fmt.Sprintf("com.docker.compose.project=%s", projectName)),
-		),
-	})
+	// Remove all the containers that belong to the stack
+	containers, err := c.stackContainers(ctx, cr, projectName)
 	if err != nil {
-		return managed.ExternalDelete{}, errors.Wrap(err, "cannot list containers")
+		return managed.ExternalDelete{}, err
 	}
 
 	for _, cont := range containers {
-		// Stop and remove the container
-		timeout := 10 // 10 second timeout
-		stopOpts := container.StopOptions{Timeout: &timeout}
-		err := c.service.ContainerStop(ctx, cont.ID, stopOpts)
+		// Stop and remove the container, giving it the stop timeout it was
+		// created with, which defaults to 10 seconds
+		err := c.service.ContainerStop(ctx, cont.ID, container.StopOptions{})
 		if err != nil {
 			return managed.ExternalDelete{}, errors.Wrapf(err, "cannot stop container %s", cont.ID)
 		}
@@ -342,6 +754,7 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		if err != nil {
 			return managed.ExternalDelete{}, errors.Wrapf(err, "cannot remove container %s", cont.ID)
 		}
+		c.exporter.Forget(cont.ID)
 	}
 
 	return managed.ExternalDelete{}, nil
@@ -417,8 +830,13 @@ func (c *external) getProjectName(cr *composev1alpha1.ComposeStack) string {
 	return cr.GetName()
 }
 
-func (c *external) buildEnvironment(ctx context.Context, cr *composev1alpha1.ComposeStack) map[string]string {
+// buildEnvironment resolves the environment variables of a stack. A variable
+// whose Secret or ConfigMap reference cannot be resolved is interpolated as
+// empty, unless the stack resolves its environment strictly: then every such
+// variable is listed in a MissingReference condition, and an error returned.
+func (c *external) buildEnvironment(ctx context.Context, cr *composev1alpha1.ComposeStack) (map[string]string, error) {
 	environment := make(map[string]string)
+	var unresolved []string
 
 	for _, env := range cr.Spec.ForProvider.Environment {
 		if env.Value != nil {
@@ -427,16 +845,22 @@ func (c *external) buildEnvironment(ctx context.Context, cr *composev1alpha1.Com
 			// Resolve environment variable from ConfigMap or Secret
 			value, err := c.resolveEnvValueFrom(ctx, cr, env.ValueFrom)
 			if err != nil {
-				// Log error but continue - could use empty string or skip
-				// In production, might want to fail fast or use status conditions
-				environment[env.Name] = ""
-			} else {
-				environment[env.Name] = value
+				unresolved = append(unresolved, fmt.Sprintf("%s (%v)", env.Name, err))
 			}
+			environment[env.Name] = value
 		}
 	}
 
-	return environment
+	strict := cr.Spec.ForProvider.StrictEnvResolution != nil && *cr.Spec.ForProvider.StrictEnvResolution
+	if strict && len(unresolved) > 0 {
+		msg := "cannot resolve environment variables: " + strings.Join(unresolved, "; ")
+		cr.SetConditions(composev1alpha1.MissingReference(msg))
+		return nil, errors.New(msg)
+	}
+	if cr.GetCondition(composev1alpha1.TypeMissingReference).Status == v1.ConditionTrue {
+		cr.SetConditions(composev1alpha1.ReferencesResolved())
+	}
+	return environment, nil
 }
 
 func (c *external) resolveEnvValueFrom(ctx context.Context, cr *composev1alpha1.ComposeStack, valueFrom *composev1alpha1.EnvVarSource) (string, error) {
@@ -501,13 +925,231 @@ func (c *external) getValueFromConfigMap(ctx context.Context, cr *composev1alpha
 	return data, nil
 }
 
+// pauseRequested reports whether the stack has been paused since this
+// reconcile started. It is only consulted when InterruptOnPause is set, since
+// it costs an API server read per service.
+func (c *external) pauseRequested(ctx context.Context, cr *composev1alpha1.ComposeStack) bool {
+	if cr.Spec.ForProvider.InterruptOnPause == nil || !*cr.Spec.ForProvider.InterruptOnPause {
+		return false
+	}
+
+	latest := &composev1alpha1.ComposeStack{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}, latest); err != nil {
+		return false
+	}
+	return meta.IsPaused(latest)
+}
+
+// exportStack writes the observed containers of the stack to the named
+// ConfigMap as a Docker Compose file, creating the ConfigMap if needed. The
+// values of environment variables taken from Secrets are redacted.
+func (c *external) exportStack(ctx context.Context, cr *composev1alpha1.ComposeStack, name, projectName string, observed map[string]container.InspectResponse) error {
+	redacted := make(map[string]container.InspectResponse, len(observed))
+	for service, info := range observed {
+		redacted[service] = redactInspect(info, cr.Spec.ForProvider.Environment)
+	}
+	content, err := compose.Export(projectName, redacted)
+	if err != nil {
+		return err
+	}
+
+	return c.applyConfigMap(ctx, cr, name, func(data map[string]string) bool {
+		if data[composev1alpha1.ExportConfigMapKey] == string(content) {
+			return false
+		}
+		data[composev1alpha1.ExportConfigMapKey] = string(content)
+		return true
+	})
+}
+
+// applyConfigMap creates the named ConfigMap in the namespace of the stack,
+// controlled by the stack, or updates it if the stack controls it. A
+// ConfigMap the stack does not control is left alone. The mutate function
+// edits its data, and reports whether it changed anything.
+func (c *external) applyConfigMap(ctx context.Context, cr *composev1alpha1.ComposeStack, name string, mutate func(data map[string]string) bool) error {
+	cm := &v1.ConfigMap{}
+	err := c.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: name}, cm)
+	if kerrors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cr.GetNamespace(),
+				Name:      name,
+			},
+			Data: map[string]string{},
+		}
+		mutate(cm.Data)
+		meta.AddOwnerReference(cm, meta.AsController(meta.TypedReferenceTo(cr, composev1alpha1.ComposeStackGroupVersionKind)))
+		return c.kube.Create(ctx, cm)
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(cm, cr) {
+		return errors.Errorf(errConfigMapNotOwned, cm.Namespace, cm.Name)
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	if !mutate(cm.Data) {
+		return nil
+	}
+	return c.kube.Update(ctx, cm)
+}
+
+// stackContainers lists the containers that belong to a stack: those labelled
+// with its UID, and those labelled with its project that predate the UID label.
+func (c *external) stackContainers(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName string) ([]container.Summary, error) {
+	var owned []container.Summary
+	seen := make(map[string]bool)
+
+	if uid := string(cr.GetUID()); uid != "" {
+		containers, err := c.service.ContainerList(ctx, container.ListOptions{
+			All:     true,
+			Filters: labels.Filter(labels.Selector(labels.StackUID, uid)),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, errListContainers)
+		}
+		for _, cont := range containers {
+			seen[cont.ID] = true
+			owned = append(owned, cont)
+		}
+	}
+
+	containers, err := c.service.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: labels.Filter(labels.Selector(labels.ComposeProject, projectName)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errListContainers)
+	}
+	for _, cont := range containers {
+		if seen[cont.ID] || cont.Labels[labels.StackUID] != "" {
+			continue
+		}
+		seen[cont.ID] = true
+		owned = append(owned, cont)
+	}
+
+	return owned, nil
+}
+
+// stackLabels returns the labels of one of a stack's Docker objects: the
+// labels set on the stack, overridden by the object's own, and the labels
+// identifying the stack, which override both.
+func stackLabels(cr *composev1alpha1.ComposeStack, projectName string, own map[string]string) map[string]string {
+	l := make(map[string]string, len(cr.Spec.ForProvider.Labels)+len(own)+2)
+	maps.Copy(l, cr.Spec.ForProvider.Labels)
+	maps.Copy(l, own)
+	l[labels.ComposeProject] = projectName
+	if uid := string(cr.GetUID()); uid != "" {
+		l[labels.StackUID] = uid
+	}
+	return l
+}
+
+// usageLabels returns the labels the usage of a stack's containers is
+// attributed by: the labels of the ComposeStack, overridden by the labels set
+// on its Docker objects.
+func usageLabels(cr *composev1alpha1.ComposeStack) map[string]string {
+	l := make(map[string]string, len(cr.GetLabels())+len(cr.Spec.ForProvider.Labels))
+	maps.Copy(l, cr.GetLabels())
+	maps.Copy(l, cr.Spec.ForProvider.Labels)
+	return l
+}
+
+// buildContainer converts a service's container to Docker configuration,
+// with the service's override, and labels it with a hash of that
+// configuration. The platform is nil unless the service sets one.
+func (c *external) buildContainer(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName string, cont *containerv1alpha1.Container) (*container.Config, *container.HostConfig, *network.NetworkingConfig, *specs.Platform, error) {
+	spec := cont.Spec.ForProvider
+	if override, ok := cr.Spec.ForProvider.ServiceOverrides[serviceName(cont)]; ok {
+		if override.LogConfig != nil {
+			spec.LogConfig = override.LogConfig
+		}
+		if override.Platform != nil {
+			spec.Platform = override.Platform
+		}
+	}
+	config, hostConfig, networkConfig, err := c.convertContainerSpec(ctx, cr, &spec, projectName)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "failed to convert container spec")
+	}
+	var platform *specs.Platform
+	if spec.Platform != nil {
+		if platform, err = dockerclients.ParsePlatform(*spec.Platform); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	hash, err := configHash(config, hostConfig, networkConfig, platform)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	config.Labels[labels.ConfigHash] = hash
+
+	return config, hostConfig, networkConfig, platform, nil
+}
+
+// configHash hashes a container's configuration. A container of the host's
+// platform hashes as it did before services could set their platform.
+func configHash(config *container.Config, hostConfig *container.HostConfig, networkConfig *network.NetworkingConfig, platform *specs.Platform) (string, error) {
+	b, err := json.Marshal(struct {
+		Config        *container.Config
+		HostConfig    *container.HostConfig
+		NetworkConfig *network.NetworkingConfig
+		Platform      *specs.Platform `json:",omitempty"`
+	}{config, hostConfig, networkConfig, platform})
+	if err != nil {
+		return "", errors.Wrap(err, "cannot hash container configuration")
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// getContainerName returns the name of the first replica of a service.
 func (c *external) getContainerName(projectName, serviceName string) string {
-	return fmt.Sprintf("%s_%s_1", projectName, serviceName)
+	return replicaName(projectName, serviceName, 1)
+}
+
+// serviceName returns the compose service a parsed container was created from.
+func serviceName(cont *containerv1alpha1.Container) string {
+	if cont.Spec.ForProvider.Name != nil {
+		return *cont.Spec.ForProvider.Name
+	}
+	return cont.Name
+}
+
+// dependenciesCompleted reports whether every given service has run to
+// completion. A dependency that exited with a non-zero code is an error, since
+// its dependents would otherwise never be started.
+func (c *external) dependenciesCompleted(ctx context.Context, containerNames map[string]string, deps []string) (bool, error) {
+	for _, dep := range deps {
+		info, err := c.service.ContainerInspect(ctx, containerNames[dep])
+		if err != nil {
+			return false, nil
+		}
+		if info.State == nil || info.State.Status != "exited" {
+			return false, nil
+		}
+		if !completedSuccessfully(info.State) {
+			return false, errors.Errorf("service %s did not complete successfully: exit code %d", dep, info.State.ExitCode)
+		}
+	}
+	return true, nil
+}
+
+// completedSuccessfully reports whether a container has exited with code 0.
+func completedSuccessfully(state *container.State) bool {
+	return state != nil && state.Status == "exited" && state.ExitCode == 0
 }
 
-func (c *external) createContainer(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName string, cont *containerv1alpha1.Container) error {
+// createContainer creates and starts a replica of a service, numbered from
+// 1, unless it exists.
+func (c *external) createContainer(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName, model, service string, cont *containerv1alpha1.Container, replica int32) error {
 	// Convert Container spec to Docker API calls
-	containerName := c.getContainerName(projectName, cont.Name)
+	containerName := replicaName(projectName, cont.Name, replica)
 
 	// Check if container already exists
 	_, err := c.service.ContainerInspect(ctx, containerName)
@@ -517,15 +1159,30 @@ func (c *external) createContainer(ctx context.Context, cr *composev1alpha1.Comp
 	}
 
 	// Convert Container spec to Docker container configuration
-	config, hostConfig, networkConfig, err := c.convertContainerSpec(ctx, cr, &cont.Spec.ForProvider, projectName)
+	c.recordPhase(ctx, cr, cont.Name, composev1alpha1.ServicePhaseCreating, "")
+	config, hostConfig, networkConfig, platform, err := c.buildContainer(ctx, cr, projectName, cont)
 	if err != nil {
-		return errors.Wrap(err, "failed to convert container spec")
+		return c.serviceFailed(ctx, cr, cont.Name, err)
+	}
+	if err := c.resolveNetworkMode(ctx, cr, projectName, hostConfig); err != nil {
+		return c.serviceFailed(ctx, cr, cont.Name, err)
 	}
+	config.Labels[labels.ModelHash] = model
+	config.Labels[labels.ServiceHash] = service
+	config.Labels[labels.ComposeContainerNumber] = strconv.Itoa(int(replica))
 
-	// Create the container
-	resp, err := c.service.ContainerCreate(ctx, config, hostConfig, networkConfig, nil, containerName)
+	// Create the container, pulling its image first if it is missing
+	resp, err := c.service.ContainerCreate(ctx, config, hostConfig, networkConfig, platform, containerName)
+	if dockerclients.IsNoSuchImage(err) {
+		c.recordPhase(ctx, cr, cont.Name, composev1alpha1.ServicePhasePulling, "")
+		if err := dockerclients.PullImage(ctx, c.service, config.Image, dockerclients.FormatPlatform(platform), c.registryAuths, nil); err != nil {
+			return c.serviceFailed(ctx, cr, cont.Name, err)
+		}
+		c.recordPhase(ctx, cr, cont.Name, composev1alpha1.ServicePhaseCreating, "")
+		resp, err = c.service.ContainerCreate(ctx, config, hostConfig, networkConfig, platform, containerName)
+	}
 	if err != nil {
-		return errors.Wrapf(err, "failed to create container %s", containerName)
+		return c.serviceFailed(ctx, cr, cont.Name, errors.Wrapf(err, "failed to create container %s", containerName))
 	}
 
 	// Start the container if StartOnCreate is true (default)
@@ -535,9 +1192,10 @@ func (c *external) createContainer(ctx context.Context, cr *composev1alpha1.Comp
 	}
 
 	if startOnCreate {
+		c.recordPhase(ctx, cr, cont.Name, composev1alpha1.ServicePhaseStarting, "")
 		err = c.service.ContainerStart(ctx, resp.ID, container.StartOptions{})
 		if err != nil {
-			return errors.Wrapf(err, "failed to start container %s", containerName)
+			return c.serviceFailed(ctx, cr, cont.Name, errors.Wrapf(err, "failed to start container %s", containerName))
 		}
 	}
 
@@ -564,9 +1222,11 @@ func (c *external) convertContainerSpec(ctx context.Context, cr *composev1alpha1
 		config.Cmd = append(config.Cmd, spec.Args...)
 	}
 
-	// Set environment variables
+	// Set environment variables, sorted since they are converted from an
+	// unordered map
 	if len(spec.Environment) > 0 {
 		config.Env = c.convertEnvironmentVars(ctx, cr, spec.Environment)
+		slices.Sort(config.Env)
 	}
 
 	// Set working directory
@@ -584,18 +1244,19 @@ func (c *external) convertContainerSpec(ctx context.Context, cr *composev1alpha1
 		config.Hostname = *spec.Hostname
 	}
 
-	// Set labels
-	if len(spec.Labels) > 0 {
-		config.Labels = spec.Labels
+	// Set stop signal and grace period
+	if spec.StopSignal != nil {
+		config.StopSignal = *spec.StopSignal
 	}
-
-	// Add compose project label
-	if config.Labels == nil {
-		config.Labels = make(map[string]string)
+	if spec.StopGracePeriod != nil {
+		timeout := int(spec.StopGracePeriod.Seconds())
+		config.StopTimeout = &timeout
 	}
-	config.Labels["com.docker.compose.project"] = projectName
+
+	// Set labels, over those of the stack
+	config.Labels = stackLabels(cr, projectName, spec.Labels)
 	if spec.Name != nil {
-		config.Labels["com.docker.compose.service"] = *spec.Name
+		config.Labels[labels.ComposeService] = *spec.Name
 	}
 
 	// Set exposed ports
@@ -634,6 +1295,14 @@ func (c *external) convertContainerSpec(ctx context.Context, cr *composev1alpha1
 		}
 		hostConfig.Binds = binds
 		hostConfig.Mounts = mounts
+
+		// Named volumes that do not exist yet are created with the
+		// container, and carry the stack's labels too
+		for i := range hostConfig.Mounts {
+			if hostConfig.Mounts[i].Type == mount.TypeVolume {
+				hostConfig.Mounts[i].VolumeOptions = &mount.VolumeOptions{Labels: stackLabels(cr, projectName, nil)}
+			}
+		}
 	}
 
 	// Set DNS configuration
@@ -656,10 +1325,21 @@ func (c *external) convertContainerSpec(ctx context.Context, cr *composev1alpha1
 	if spec.Privileged != nil {
 		hostConfig.Privileged = *spec.Privileged
 	}
+	hostConfig.DeviceCgroupRules = spec.DeviceCgroupRules
+
+	// Set log driver
+	if spec.LogConfig != nil {
+		hostConfig.LogConfig = container.LogConfig{Type: spec.LogConfig.Driver, Config: spec.LogConfig.Options}
+	}
+
+	// Set init process
+	hostConfig.Init = spec.Init
 
 	// Set resource limits
 	if spec.Resources != nil {
-		c.setResourceLimits(hostConfig, spec.Resources)
+		if err := c.setResourceLimits(hostConfig, spec.Resources); err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	// Set security context
@@ -672,6 +1352,7 @@ func (c *external) convertContainerSpec(ctx context.Context, cr *composev1alpha1
 	if len(spec.Networks) > 0 {
 		networkConfig.EndpointsConfig = c.convertNetworkAttachments(spec.Networks)
 	}
+	applyNetworkMode(config, hostConfig, networkConfig)
 
 	return config, hostConfig, networkConfig, nil
 }
@@ -875,35 +1556,48 @@ func (c *external) convertNetworkAttachments(networks []containerv1alpha1.Networ
 			endpoint.Aliases = net.Aliases
 		}
 
+		if len(net.Links) > 0 {
+			endpoint.Links = net.Links
+		}
+
 		endpoints[net.Name] = endpoint
 	}
 	return endpoints
 }
 
-func (c *external) setResourceLimits(hostConfig *container.HostConfig, resources *containerv1alpha1.ResourceRequirements) {
+// setResourceLimits sets the resource limits and reservations of a service.
+// A value that cannot be parsed is an error naming the field it was set in.
+func (c *external) setResourceLimits(hostConfig *container.HostConfig, resources *containerv1alpha1.ResourceRequirements) error {
 	// Set memory limits
 	if resources.Limits != nil {
 		if memLimit, exists := resources.Limits["memory"]; exists {
-			if bytes, err := parseMemory(memLimit.String()); err == nil {
-				hostConfig.Memory = bytes
+			bytes, err := parseMemory(memLimit.String())
+			if err != nil {
+				return errors.Wrap(err, "resources.limits.memory")
 			}
+			hostConfig.Memory = bytes
 		}
 		if cpuLimit, exists := resources.Limits["cpu"]; exists {
-			if nanos, err := parseCPU(cpuLimit.String()); err == nil {
-				hostConfig.CPUQuota = nanos
-				hostConfig.CPUPeriod = 100000 // 100ms period
+			nanos, err := parseCPU(cpuLimit.String())
+			if err != nil {
+				return errors.Wrap(err, "resources.limits.cpu")
 			}
+			hostConfig.CPUQuota = nanos
+			hostConfig.CPUPeriod = 100000 // 100ms period
 		}
 	}
 
 	// Set memory reservations
 	if resources.Requests != nil {
 		if memRequest, exists := resources.Requests["memory"]; exists {
-			if bytes, err := parseMemory(memRequest.String()); err == nil {
-				hostConfig.MemoryReservation = bytes
+			bytes, err := parseMemory(memRequest.String())
+			if err != nil {
+				return errors.Wrap(err, "resources.requests.memory")
 			}
+			hostConfig.MemoryReservation = bytes
 		}
 	}
+	return nil
 }
 
 func (c *external) setSecurityContext(hostConfig *container.HostConfig, config *container.Config, secCtx *containerv1alpha1.SecurityContext) {
@@ -927,27 +1621,3 @@ func (c *external) setSecurityContext(hostConfig *container.HostConfig, config *
 		}
 	}
 }
-
-// Helper functions for parsing resource values
-
-func parseMemory(memStr string) (int64, error) {
-	// Simple memory parsing - in production would use k8s resource parsing
-	if strings.HasSuffix(memStr, "Mi") {
-		if val, err := strconv.ParseInt(strings.TrimSuffix(memStr, "Mi"), 10, 64); err == nil {
-			return val * 1024 * 1024, nil
-		}
-	} else if strings.HasSuffix(memStr, "Gi") {
-		if val, err := strconv.ParseInt(strings.TrimSuffix(memStr, "Gi"), 10, 64); err == nil {
-			return val * 1024 * 1024 * 1024, nil
-		}
-	}
-	return 0, errors.New("unsupported memory format")
-}
-
-func parseCPU(cpuStr string) (int64, error) {
-	// Simple CPU parsing - convert to nanoseconds
-	if val, err := strconv.ParseFloat(cpuStr, 64); err == nil {
-		return int64(val * 1000000000), nil // Convert to nanoseconds
-	}
-	return 0, errors.New("unsupported CPU format")
-}
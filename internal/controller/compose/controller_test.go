@@ -20,19 +20,24 @@ import (
 	"context"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/api/types/volume"
+	"github.com/google/go-cmp/cmp"
 	specsv1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
 	"github.com/rossigee/provider-docker/internal/compose"
+	"github.com/rossigee/provider-docker/pkg/labels"
 	"io"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
 	"testing"
 )
 
@@ -40,22 +45,49 @@ import (
 type mockDockerClient struct {
 	containers           []container.Summary
 	containerInspectResp *container.InspectResponse
+	containerInspectFunc func(containerID string) (container.InspectResponse, error)
+	createdContainers    []string
+	createdConfigs       []*container.Config
+	removedContainers    []string
+	containerLogs        map[string]string
+	containerStats       map[string]string
 	containerCreateResp  container.CreateResponse
 	inspectError         error
 	createError          error
 	startError           error
 	removeError          error
 	listError            error
+	events               []events.Message
 }
 
 func (m *mockDockerClient) ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error) {
 	if m.listError != nil {
 		return nil, m.listError
 	}
-	return m.containers, nil
+	var matched []container.Summary
+	for _, c := range m.containers {
+		if labelsMatch(c.Labels, options.Filters.Get("label")) {
+			matched = append(matched, c)
+		}
+	}
+	return matched, nil
+}
+
+// labelsMatch reports whether labels satisfy every key=value label filter.
+func labelsMatch(labels map[string]string, filters []string) bool {
+	for _, f := range filters {
+		k, v, _ := strings.Cut(f, "=")
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
 }
 
 func (m *mockDockerClient) ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error) {
+	if m.containerInspectFunc != nil {
+		return m.containerInspectFunc(containerID)
+	}
 	if m.inspectError != nil {
 		return container.InspectResponse{}, m.inspectError
 	}
@@ -69,6 +101,8 @@ func (m *mockDockerClient) ContainerCreate(ctx context.Context, config *containe
 	if m.createError != nil {
 		return container.CreateResponse{}, m.createError
 	}
+	m.createdContainers = append(m.createdContainers, containerName)
+	m.createdConfigs = append(m.createdConfigs, config)
 	return m.containerCreateResp, nil
 }
 
@@ -77,11 +111,24 @@ func (m *mockDockerClient) ContainerStart(ctx context.Context, containerID strin
 }
 
 func (m *mockDockerClient) ContainerRemove(ctx context.Context, containerID string, options container.RemoveOptions) error {
+	if m.removeError == nil {
+		m.removedContainers = append(m.removedContainers, containerID)
+	}
 	return m.removeError
 }
 
 // Additional required methods for DockerClient interface
+func (m *mockDockerClient) ContainerStatsOneShot(ctx context.Context, containerID string) (container.StatsResponseReader, error) {
+	if stats, ok := m.containerStats[containerID]; ok {
+		return container.StatsResponseReader{Body: io.NopCloser(strings.NewReader(stats))}, nil
+	}
+	return container.StatsResponseReader{}, errors.New("No such container: " + containerID)
+}
+
 func (m *mockDockerClient) ContainerLogs(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error) {
+	if logs, ok := m.containerLogs[containerID]; ok {
+		return io.NopCloser(strings.NewReader(logs)), nil
+	}
 	return nil, nil
 }
 
@@ -109,6 +156,30 @@ func (m *mockDockerClient) ContainerUnpause(ctx context.Context, containerID str
 	return nil
 }
 
+func (m *mockDockerClient) CopyFromContainer(ctx context.Context, containerID, srcPath string) (io.ReadCloser, container.PathStat, error) {
+	return nil, container.PathStat{}, nil
+}
+
+func (m *mockDockerClient) CopyToContainer(ctx context.Context, containerID, dstPath string, content io.Reader, options container.CopyToContainerOptions) error {
+	return nil
+}
+
+func (m *mockDockerClient) ContainerExecCreate(ctx context.Context, containerID string, options container.ExecOptions) (container.ExecCreateResponse, error) {
+	return container.ExecCreateResponse{}, nil
+}
+
+func (m *mockDockerClient) ContainerExecStart(ctx context.Context, execID string, config container.ExecStartOptions) error {
+	return nil
+}
+
+func (m *mockDockerClient) ContainerExecInspect(ctx context.Context, execID string) (container.ExecInspect, error) {
+	return container.ExecInspect{}, nil
+}
+
+func (m *mockDockerClient) ContainerExecAttach(ctx context.Context, execID string, config container.ExecAttachOptions) (types.HijackedResponse, error) {
+	return types.HijackedResponse{}, nil
+}
+
 // Image operations
 func (m *mockDockerClient) ImagePull(ctx context.Context, refStr string, options image.PullOptions) (io.ReadCloser, error) {
 	return nil, nil
@@ -181,6 +252,22 @@ func (m *mockDockerClient) ServerVersion(ctx context.Context) (types.Version, er
 	return types.Version{}, nil
 }
 
+func (m *mockDockerClient) DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+	return types.DiskUsage{}, nil
+}
+
+func (m *mockDockerClient) Events(ctx context.Context, options events.ListOptions) (<-chan events.Message, <-chan error) {
+	messages := make(chan events.Message)
+	errs := make(chan error, 1)
+	go func() {
+		for _, e := range m.events {
+			messages <- e
+		}
+		errs <- io.EOF
+	}()
+	return messages, errs
+}
+
 func (m *mockDockerClient) Close() error {
 	return nil
 }
@@ -353,6 +440,57 @@ services:
 	}
 }
 
+func TestExternal_ObserveExportsStack(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	cr := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-stack",
+			Namespace:   "default",
+			Annotations: map[string]string{labels.AnnotationExportConfigMap: "test-stack-export"},
+		},
+		Spec: composev1alpha1.ComposeStackSpec{
+			ForProvider: composev1alpha1.ComposeStackParameters{
+				Compose: stringPtr(`
+services:
+  web:
+    image: nginx:latest
+`),
+			},
+		},
+	}
+	dockerClient := &mockDockerClient{
+		containerInspectResp: &container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{
+				ID:    "container123",
+				Name:  "/test-stack_web_1",
+				State: &container.State{Status: "running"},
+			},
+			Config: &container.Config{Image: "nginx:1.27"},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ext := &external{kube: fakeClient, service: dockerClient, parser: &compose.Parser{}}
+
+	// Observing again must leave the existing export in place
+	for i := 0; i < 2; i++ {
+		if _, err := ext.Observe(context.Background(), cr); err != nil {
+			t.Fatalf("Observe() call %d error = %v", i+1, err)
+		}
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := fakeClient.Get(context.Background(), ktypes.NamespacedName{Namespace: "default", Name: "test-stack-export"}, cm); err != nil {
+		t.Fatalf("cannot get export ConfigMap: %v", err)
+	}
+	// The observed image is exported, not the specified one
+	if !strings.Contains(cm.Data[composev1alpha1.ExportConfigMapKey], "image: nginx:1.27") {
+		t.Errorf("exported compose file does not contain the observed image:\n%s", cm.Data[composev1alpha1.ExportConfigMapKey])
+	}
+}
+
 func TestExternal_Create(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -450,12 +588,157 @@ services:
 	}
 }
 
+func TestExternal_CreateInterruptedByPause(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	cr := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-stack",
+			Namespace: "default",
+		},
+		Spec: composev1alpha1.ComposeStackSpec{
+			ForProvider: composev1alpha1.ComposeStackParameters{
+				Compose: stringPtr(`
+services:
+  web:
+    image: nginx:latest
+`),
+				InterruptOnPause: boolPtr(true),
+			},
+		},
+	}
+
+	// The stored object has been paused since the reconcile started
+	stored := cr.DeepCopy()
+	stored.SetAnnotations(map[string]string{"crossplane.io/paused": "true"})
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(stored).
+		Build()
+
+	ext := &external{
+		kube: fakeClient,
+		service: &mockDockerClient{
+			inspectError: errors.New("container not found"),
+			createError:  errors.New("container should not be created while paused"),
+		},
+		parser: &compose.Parser{},
+	}
+
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+
+	if got := cr.GetCondition(composev1alpha1.TypePaused); got.Status != corev1.ConditionTrue {
+		t.Errorf("Create() Paused condition = %v, want %v", got.Status, corev1.ConditionTrue)
+	}
+}
+
+func TestExternal_CreateWaitsForCompletedDependency(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	newStack := func() *composev1alpha1.ComposeStack {
+		return &composev1alpha1.ComposeStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-stack",
+				Namespace: "default",
+			},
+			Spec: composev1alpha1.ComposeStackSpec{
+				ForProvider: composev1alpha1.ComposeStackParameters{
+					Compose: stringPtr(`
+services:
+  app:
+    image: my-app:latest
+    depends_on:
+      migrate:
+        condition: service_completed_successfully
+  migrate:
+    image: my-app:latest
+    command: ["migrate"]
+`),
+				},
+			},
+		}
+	}
+
+	const migrateName = "test-stack_test-stack-migrate_1"
+
+	tests := []struct {
+		name        string
+		migrate     *container.State
+		wantCreated []string
+		wantErr     bool
+	}{
+		{
+			name:        "dependency created first and dependents held back",
+			wantCreated: []string{migrateName},
+		},
+		{
+			name:        "dependency still running",
+			migrate:     &container.State{Status: "running"},
+			wantCreated: nil,
+		},
+		{
+			name:        "dependency completed successfully",
+			migrate:     &container.State{Status: "exited", ExitCode: 0},
+			wantCreated: []string{"test-stack_test-stack-app_1"},
+		},
+		{
+			name:    "dependency failed",
+			migrate: &container.State{Status: "exited", ExitCode: 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dockerClient := &mockDockerClient{
+				containerCreateResp: container.CreateResponse{ID: "container123"},
+			}
+			dockerClient.containerInspectFunc = func(containerID string) (container.InspectResponse, error) {
+				for _, created := range dockerClient.createdContainers {
+					if created == containerID {
+						// Freshly created one-shot services are still running
+						return container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{State: &container.State{Status: "running"}}}, nil
+					}
+				}
+				if containerID == migrateName && tt.migrate != nil {
+					return container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{State: tt.migrate}}, nil
+				}
+				return container.InspectResponse{}, errors.New("container not found")
+			}
+
+			ext := &external{
+				kube:    fake.NewClientBuilder().WithScheme(scheme).Build(),
+				service: dockerClient,
+				parser:  &compose.Parser{},
+			}
+
+			_, err := ext.Create(context.Background(), newStack())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.wantCreated, dockerClient.createdContainers); diff != "" {
+				t.Errorf("Create() created containers -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestExternal_Update(t *testing.T) {
 	ext := &external{}
 
 	_, err := ext.Update(context.Background(), &composev1alpha1.ComposeStack{})
 
-	// Update should return ErrNotImplemented
+	// A stack without compose content cannot be updated
 	if err == nil {
 		t.Errorf("Update() error = nil, want error")
 	}
@@ -585,6 +868,103 @@ services:
 	}
 }
 
+func TestExternal_StackOwnershipLabels(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	cr := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-stack",
+			Namespace: "default",
+			UID:       "stack-uid-1",
+		},
+		Spec: composev1alpha1.ComposeStackSpec{
+			ForProvider: composev1alpha1.ComposeStackParameters{
+				Compose: stringPtr(`
+services:
+  web:
+    image: nginx:latest
+    volumes:
+      - data:/data
+volumes:
+  data: {}
+`),
+			},
+		},
+	}
+
+	dockerClient := &mockDockerClient{
+		inspectError:        errors.New("container not found"),
+		containerCreateResp: container.CreateResponse{ID: "container123"},
+	}
+	ext := &external{
+		kube:    fake.NewClientBuilder().WithScheme(scheme).Build(),
+		service: dockerClient,
+		parser:  &compose.Parser{},
+	}
+
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if len(dockerClient.createdConfigs) != 1 {
+		t.Fatalf("Create() created %d containers, want 1", len(dockerClient.createdConfigs))
+	}
+	created := dockerClient.createdConfigs[0].Labels
+	for _, key := range []string{labels.ComposeProject, labels.ComposeService, labels.StackUID, labels.ConfigHash} {
+		if created[key] == "" {
+			t.Errorf("Create() container label %s is not set", key)
+		}
+	}
+
+	// The container is found by its labels, however it is named
+	dockerClient.inspectError = nil
+	dockerClient.containers = []container.Summary{
+		{ID: "container123", Names: []string{"/renamed"}, Labels: created},
+		{ID: "container456", Names: []string{"/other"}, Labels: map[string]string{
+			labels.ComposeProject: "test-stack",
+			labels.ComposeService: "web",
+			labels.StackUID:       "stack-uid-2",
+		}},
+	}
+	dockerClient.containerInspectFunc = func(id string) (container.InspectResponse, error) {
+		if id != "container123" {
+			return container.InspectResponse{}, errors.New("container not found")
+		}
+		return container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{ID: id, State: &container.State{Status: "running"}},
+			Config:            &container.Config{Image: "nginx:latest", Labels: created},
+		}, nil
+	}
+
+	obs, err := ext.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	if !obs.ResourceExists || !obs.ResourceUpToDate {
+		t.Errorf("Observe() = %+v, want existing and up to date", obs)
+	}
+
+	// A changed service no longer matches the container's config hash
+	cr.Spec.ForProvider.Compose = stringPtr(strings.Replace(*cr.Spec.ForProvider.Compose, "nginx:latest", "nginx:1.27", 1))
+	obs, err = ext.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	if obs.ResourceUpToDate {
+		t.Error("Observe() ResourceUpToDate = true for a changed service, want false")
+	}
+
+	// Only the stack's own container is removed, not another stack's
+	// container with the same project name
+	if _, err := ext.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if strings.Join(dockerClient.removedContainers, ",") != "container123" {
+		t.Errorf("Delete() removed %v, want [container123]", dockerClient.removedContainers)
+	}
+}
+
 func TestExternal_GetProjectName(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	"maps"
+	"reflect"
+)
+
+const errModelHash = "cannot hash compose model"
+
+// modelHash hashes the compose model of a stack as rendered from its spec.
+// The model is hashed after interpolation, so that changes to the compose
+// content, to the ConfigMap or Secret it is read from, and to the values
+// interpolated into it all change the hash, and together with the overrides
+// of its services. The replicas, connection secrets and readiness probes of
+// services are not part of the model; their containers are not recreated
+// when they change.
+func modelHash(project *types.Project, overrides map[string]composev1alpha1.ServiceOverride) (string, error) {
+	model, err := project.MarshalJSON()
+	if err != nil {
+		return "", errors.Wrap(err, errModelHash)
+	}
+	overrides = withoutUnhashed(overrides)
+	b, err := json.Marshal(struct {
+		Model     json.RawMessage
+		Overrides map[string]composev1alpha1.ServiceOverride
+	}{model, overrides})
+	if err != nil {
+		return "", errors.Wrap(err, errModelHash)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// serviceHashes hashes the compose definition of each service of a stack as
+// rendered from its spec, together with its override, keyed by service. Unlike
+// the model hash, the hash of a service only changes with the service itself,
+// so that a change to one service leaves the others untouched, as with
+// docker compose up.
+func serviceHashes(project *types.Project, overrides map[string]composev1alpha1.ServiceOverride) (map[string]string, error) {
+	overrides = withoutUnhashed(overrides)
+	hashes := make(map[string]string, len(project.Services))
+	for name, service := range project.Services {
+		b, err := json.Marshal(struct {
+			Service  types.ServiceConfig
+			Override *composev1alpha1.ServiceOverride `json:",omitempty"`
+		}{service, overrideOf(overrides, name)})
+		if err != nil {
+			return nil, errors.Wrap(err, errModelHash)
+		}
+		sum := sha256.Sum256(b)
+		hashes[name] = hex.EncodeToString(sum[:])
+	}
+	return hashes, nil
+}
+
+// overrideOf returns the override of a service, if it has one.
+func overrideOf(overrides map[string]composev1alpha1.ServiceOverride, name string) *composev1alpha1.ServiceOverride {
+	override, ok := overrides[name]
+	if !ok {
+		return nil
+	}
+	return &override
+}
+
+// serviceDrifted reports whether the container of a service no longer
+// matches the service: its definition has changed since the container was
+// created, or the configuration it converts to no longer matches the hash
+// the container was created with. Containers created before their service
+// was hashed have drifted when the whole compose model has.
+func (c *external) serviceDrifted(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName, model, service string, cont *containerv1alpha1.Container, info container.InspectResponse) (bool, error) {
+	if info.Config == nil {
+		return false, nil
+	}
+	if created, ok := info.Config.Labels[labels.ServiceHash]; ok {
+		if created != service {
+			return true, nil
+		}
+	} else if modelDrifted(info, model) {
+		return true, nil
+	}
+
+	hash := info.Config.Labels[labels.ConfigHash]
+	if hash == "" {
+		return false, nil
+	}
+	config, _, _, _, err := c.buildContainer(ctx, cr, projectName, cont)
+	if err != nil {
+		return false, err
+	}
+	return config.Labels[labels.ConfigHash] != hash, nil
+}
+
+// modelDrifted reports whether a container was created from a compose model
+// other than the one hashed. Containers created before their model was
+// hashed have not drifted.
+func modelDrifted(info container.InspectResponse, hash string) bool {
+	if info.Config == nil {
+		return false
+	}
+	created, ok := info.Config.Labels[labels.ModelHash]
+	return ok && created != hash
+}
+
+// withoutUnhashed returns the overrides without the replicas, connection
+// secrets and readiness probes of their services, which the provider acts on
+// itself rather than configuring containers with. Overrides that only configured
+// those are dropped, so that adding one hashes as before.
+func withoutUnhashed(overrides map[string]composev1alpha1.ServiceOverride) map[string]composev1alpha1.ServiceOverride {
+	var stripped map[string]composev1alpha1.ServiceOverride
+	for name, override := range overrides {
+		if override.Replicas == nil && override.WriteConnectionSecretTo == nil && override.ReadinessProbe == nil {
+			continue
+		}
+		if stripped == nil {
+			stripped = maps.Clone(overrides)
+		}
+		override.Replicas = nil
+		override.WriteConnectionSecretTo = nil
+		override.ReadinessProbe = nil
+		if reflect.DeepEqual(override, composev1alpha1.ServiceOverride{}) {
+			delete(stripped, name)
+			continue
+		}
+		stripped[name] = override
+	}
+	if stripped == nil {
+		return overrides
+	}
+	if len(stripped) == 0 {
+		return nil
+	}
+	return stripped
+}
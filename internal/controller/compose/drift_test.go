@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/compose"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	"k8s.io/utils/ptr"
+	"testing"
+)
+
+func TestModelHash(t *testing.T) {
+	const content = `
+services:
+  web:
+    image: nginx:${TAG}
+    environment:
+      LEVEL: info
+`
+	hash := func(t *testing.T, content string, env map[string]string, overrides map[string]composev1alpha1.ServiceOverride) string {
+		t.Helper()
+		result, err := compose.NewParser("stack", "", env).ParseCompose(context.Background(), content)
+		if err != nil {
+			t.Fatalf("ParseCompose(): %v", err)
+		}
+		h, err := modelHash(result.Project, overrides)
+		if err != nil {
+			t.Fatalf("modelHash(): %v", err)
+		}
+		return h
+	}
+
+	base := hash(t, content, map[string]string{"TAG": "1.27"}, nil)
+	restart := "always"
+	tests := map[string]struct {
+		content   string
+		env       map[string]string
+		overrides map[string]composev1alpha1.ServiceOverride
+		wantSame  bool
+	}{
+		"Unchanged":         {content: content, env: map[string]string{"TAG": "1.27"}, wantSame: true},
+		"InterpolatedValue": {content: content, env: map[string]string{"TAG": "1.28"}},
+		"Content":           {content: content + "      DEBUG: \"true\"\n", env: map[string]string{"TAG": "1.27"}},
+		"Overrides":         {content: content, env: map[string]string{"TAG": "1.27"}, overrides: map[string]composev1alpha1.ServiceOverride{"web": {RestartPolicy: &restart}}},
+		"UnusedEnvironment": {content: content, env: map[string]string{"TAG": "1.27", "UNUSED": "x"}, wantSame: true},
+		"ConnectionSecret":  {content: content, env: map[string]string{"TAG": "1.27"}, overrides: map[string]composev1alpha1.ServiceOverride{"web": {WriteConnectionSecretTo: &composev1alpha1.ServiceConnectionSecret{Name: "web"}}}, wantSame: true},
+		"ReadinessProbe":    {content: content, env: map[string]string{"TAG": "1.27"}, overrides: map[string]composev1alpha1.ServiceOverride{"web": {ReadinessProbe: &containerv1alpha1.ReadinessProbe{TCPSocket: &containerv1alpha1.TCPSocketProbe{Port: 80}}}}, wantSame: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := hash(t, tt.content, tt.env, tt.overrides); (got == base) != tt.wantSame {
+				t.Errorf("modelHash() same as before = %v, want %v", got == base, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestModelDrifted(t *testing.T) {
+	tests := map[string]struct {
+		labels map[string]string
+		want   bool
+	}{
+		"SameModel":    {labels: map[string]string{labels.ModelHash: "abc"}},
+		"OtherModel":   {labels: map[string]string{labels.ModelHash: "def"}, want: true},
+		"NotYetHashed": {labels: map[string]string{labels.ConfigHash: "123"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			info := container.InspectResponse{Config: &container.Config{Labels: tt.labels}}
+			if got := modelDrifted(info, "abc"); got != tt.want {
+				t.Errorf("modelDrifted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceHashes(t *testing.T) {
+	hashes := func(t *testing.T, content string) map[string]string {
+		t.Helper()
+		result, err := compose.NewParser("stack", "", nil).ParseCompose(context.Background(), content)
+		if err != nil {
+			t.Fatalf("ParseCompose(): %v", err)
+		}
+		h, err := serviceHashes(result.Project, nil)
+		if err != nil {
+			t.Fatalf("serviceHashes(): %v", err)
+		}
+		return h
+	}
+
+	before := hashes(t, "services:\n  web:\n    image: nginx:1.27\n  db:\n    image: postgres:16\n")
+	after := hashes(t, "services:\n  web:\n    image: nginx:1.28\n  db:\n    image: postgres:16\n")
+	if before["web"] == after["web"] {
+		t.Error("serviceHashes() did not change for a changed service")
+	}
+	if before["db"] != after["db"] {
+		t.Error("serviceHashes() changed for an unchanged service")
+	}
+}
+
+func TestBuildContainerLogConfig(t *testing.T) {
+	result, err := compose.NewParser("stack", "", nil).ParseCompose(context.Background(),
+		"services:\n  web:\n    image: nginx:1.27\n    logging:\n      driver: json-file\n      options:\n        max-size: 10m\n")
+	if err != nil {
+		t.Fatalf("ParseCompose(): %v", err)
+	}
+	cont := &result.Containers[0]
+	cr := &composev1alpha1.ComposeStack{}
+	ext := &external{}
+
+	config, hostConfig, _, _, err := ext.buildContainer(context.Background(), cr, "stack", cont)
+	if err != nil {
+		t.Fatalf("buildContainer(): %v", err)
+	}
+	if hostConfig.LogConfig.Type != "json-file" || hostConfig.LogConfig.Config["max-size"] != "10m" {
+		t.Errorf("buildContainer() LogConfig = %v, want the logging of the compose file", hostConfig.LogConfig)
+	}
+
+	cr.Spec.ForProvider.ServiceOverrides = map[string]composev1alpha1.ServiceOverride{
+		"web": {LogConfig: &containerv1alpha1.LogConfig{Driver: "syslog", Options: map[string]string{"syslog-address": "udp://logs:514"}}},
+	}
+	overridden, hostConfig, _, _, err := ext.buildContainer(context.Background(), cr, "stack", cont)
+	if err != nil {
+		t.Fatalf("buildContainer(): %v", err)
+	}
+	if hostConfig.LogConfig.Type != "syslog" || hostConfig.LogConfig.Config["max-size"] != "" {
+		t.Errorf("buildContainer() LogConfig = %v, want the override", hostConfig.LogConfig)
+	}
+	if overridden.Labels[labels.ConfigHash] == config.Labels[labels.ConfigHash] {
+		t.Error("buildContainer() did not change the config hash of an overridden log config")
+	}
+	if cont.Spec.ForProvider.LogConfig.Driver != "json-file" {
+		t.Error("buildContainer() changed the parsed container")
+	}
+}
+
+func TestBuildContainerPlatform(t *testing.T) {
+	parse := func(t *testing.T, content string) *containerv1alpha1.Container {
+		t.Helper()
+		result, err := compose.NewParser("stack", "", nil).ParseCompose(context.Background(), content)
+		if err != nil {
+			t.Fatalf("ParseCompose(): %v", err)
+		}
+		return &result.Containers[0]
+	}
+	cr := &composev1alpha1.ComposeStack{}
+	ext := &external{}
+
+	native := parse(t, "services:\n  web:\n    image: nginx:1.27\n")
+	config, hostConfig, networkConfig, platform, err := ext.buildContainer(context.Background(), cr, "stack", native)
+	if err != nil {
+		t.Fatalf("buildContainer(): %v", err)
+	}
+	if platform != nil {
+		t.Errorf("buildContainer() platform = %v, want the host's", platform)
+	}
+	// Containers of the host's platform hash as they did before platforms
+	delete(config.Labels, labels.ConfigHash)
+	b, _ := json.Marshal(struct {
+		Config        *container.Config
+		HostConfig    *container.HostConfig
+		NetworkConfig *network.NetworkingConfig
+	}{config, hostConfig, networkConfig})
+	sum := sha256.Sum256(b)
+	if got, _ := configHash(config, hostConfig, networkConfig, nil); got != hex.EncodeToString(sum[:]) {
+		t.Error("configHash() changed for a container of the host's platform")
+	}
+
+	emulated := parse(t, "services:\n  web:\n    image: nginx:1.27\n    platform: linux/arm64\n")
+	arm64, _, _, platform, err := ext.buildContainer(context.Background(), cr, "stack", emulated)
+	if err != nil {
+		t.Fatalf("buildContainer(): %v", err)
+	}
+	if platform == nil || platform.OS != "linux" || platform.Architecture != "arm64" {
+		t.Errorf("buildContainer() platform = %v, want that of the compose file", platform)
+	}
+
+	cr.Spec.ForProvider.ServiceOverrides = map[string]composev1alpha1.ServiceOverride{"web": {Platform: ptr.To("linux/arm/v7")}}
+	armv7, _, _, platform, err := ext.buildContainer(context.Background(), cr, "stack", emulated)
+	if err != nil {
+		t.Fatalf("buildContainer(): %v", err)
+	}
+	if platform == nil || platform.Architecture != "arm" || platform.Variant != "v7" {
+		t.Errorf("buildContainer() platform = %v, want the override", platform)
+	}
+	if arm64.Labels[labels.ConfigHash] == armv7.Labels[labels.ConfigHash] {
+		t.Error("buildContainer() did not change the config hash of an overridden platform")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"maps"
+)
+
+// exportEnvironment writes the variables of environment that were not taken
+// from Secrets to the ConfigMap the environment of the stack is exported to.
+func (c *external) exportEnvironment(ctx context.Context, cr *composev1alpha1.ComposeStack, environment map[string]string) error {
+	exported := nonSecretEnvironment(cr.Spec.ForProvider.Environment, environment)
+	return c.applyConfigMap(ctx, cr, cr.GetName()+composev1alpha1.EnvironmentConfigMapSuffix, func(data map[string]string) bool {
+		if maps.Equal(data, exported) {
+			return false
+		}
+		maps.DeleteFunc(data, func(string, string) bool { return true })
+		maps.Copy(data, exported)
+		return true
+	})
+}
+
+// nonSecretEnvironment returns the variables of environment, as resolved from
+// vars, other than those whose value was taken from a Secret. As when the
+// environment is resolved, a variable listed more than once takes its last
+// value.
+func nonSecretEnvironment(vars []composev1alpha1.ComposeEnvVar, environment map[string]string) map[string]string {
+	secret := make(map[string]bool)
+	for _, v := range vars {
+		if v.Value == nil && v.ValueFrom == nil {
+			continue
+		}
+		secret[v.Name] = v.Value == nil && v.ValueFrom != nil && v.ValueFrom.SecretKeyRef != nil
+	}
+
+	exported := make(map[string]string, len(environment))
+	for name, value := range environment {
+		if !secret[name] {
+			exported[name] = value
+		}
+	}
+	return exported
+}
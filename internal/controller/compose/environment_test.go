@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/google/go-cmp/cmp"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
+	"testing"
+)
+
+func TestNonSecretEnvironment(t *testing.T) {
+	fromSecret := &composev1alpha1.EnvVarSource{SecretKeyRef: &composev1alpha1.SecretKeySelector{Name: "db", Key: "password"}}
+	fromConfigMap := &composev1alpha1.EnvVarSource{ConfigMapKeyRef: &composev1alpha1.ConfigMapKeySelector{Name: "db", Key: "host"}}
+
+	vars := []composev1alpha1.ComposeEnvVar{
+		{Name: "NODE_ENV", Value: stringPtr("production")},
+		{Name: "DATABASE_HOST", ValueFrom: fromConfigMap},
+		{Name: "DATABASE_PASSWORD", ValueFrom: fromSecret},
+		{Name: "API_TOKEN", ValueFrom: fromSecret},
+		{Name: "API_TOKEN", Value: stringPtr("public")},
+		{Name: "NODE_ENV"},
+	}
+	environment := map[string]string{
+		"NODE_ENV":          "production",
+		"DATABASE_HOST":     "postgres.example.com",
+		"DATABASE_PASSWORD": "hunter2",
+		"API_TOKEN":         "public",
+	}
+
+	want := map[string]string{
+		"NODE_ENV":      "production",
+		"DATABASE_HOST": "postgres.example.com",
+		"API_TOKEN":     "public",
+	}
+	if diff := cmp.Diff(want, nonSecretEnvironment(vars, environment)); diff != "" {
+		t.Errorf("nonSecretEnvironment(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestExportEnvironment(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	cr := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "1234"},
+		Spec: composev1alpha1.ComposeStackSpec{
+			ForProvider: composev1alpha1.ComposeStackParameters{
+				Environment: []composev1alpha1.ComposeEnvVar{
+					{Name: "TAG", Value: stringPtr("1.2.3")},
+					{Name: "PASSWORD", ValueFrom: &composev1alpha1.EnvVarSource{SecretKeyRef: &composev1alpha1.SecretKeySelector{Name: "db", Key: "password"}}},
+				},
+			},
+		},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ext := &external{kube: kube}
+	key := types.NamespacedName{Namespace: "default", Name: "web-environment"}
+
+	if err := ext.exportEnvironment(context.Background(), cr, map[string]string{"TAG": "1.2.3", "PASSWORD": "hunter2"}); err != nil {
+		t.Fatalf("exportEnvironment(...): %v", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(context.Background(), key, cm); err != nil {
+		t.Fatalf("cannot get exported ConfigMap: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"TAG": "1.2.3"}, cm.Data); diff != "" {
+		t.Errorf("exported environment: -want, +got:\n%s", diff)
+	}
+	if !metav1.IsControlledBy(cm, cr) {
+		t.Errorf("exported ConfigMap owners = %+v, want the stack to control it", cm.GetOwnerReferences())
+	}
+
+	// Variables removed from the stack are removed from the ConfigMap.
+	cr.Spec.ForProvider.Environment = []composev1alpha1.ComposeEnvVar{{Name: "REPLICAS", Value: stringPtr("2")}}
+	if err := ext.exportEnvironment(context.Background(), cr, map[string]string{"REPLICAS": "2"}); err != nil {
+		t.Fatalf("exportEnvironment(...): %v", err)
+	}
+	if err := kube.Get(context.Background(), key, cm); err != nil {
+		t.Fatalf("cannot get exported ConfigMap: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"REPLICAS": "2"}, cm.Data); diff != "" {
+		t.Errorf("re-exported environment: -want, +got:\n%s", diff)
+	}
+}
+
+func TestExportEnvironmentNotOwned(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	cr := &composev1alpha1.ComposeStack{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "1234"}}
+	// A ConfigMap the stack does not control is not overwritten
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-environment", Namespace: "default"},
+		Data:       map[string]string{"LOG_LEVEL": "debug"},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+	ext := &external{kube: kube}
+
+	err := ext.exportEnvironment(context.Background(), cr, map[string]string{"TAG": "1.2.3"})
+	if err == nil || !strings.Contains(err.Error(), "not controlled by this stack") {
+		t.Fatalf("exportEnvironment(...): %v, want the ConfigMap not to be controlled by the stack", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "web-environment"}, cm); err != nil {
+		t.Fatalf("cannot get ConfigMap: %v", err)
+	}
+	if diff := cmp.Diff(existing.Data, cm.Data); diff != "" {
+		t.Errorf("ConfigMap data: -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"github.com/docker/docker/api/types/events"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"slices"
+	"time"
+)
+
+const (
+	errReadEvents = "cannot read the events of the stack"
+
+	// defaultHealthWindow is how far back incidents count against the
+	// score of a stack that does not say.
+	defaultHealthWindow = time.Hour
+
+	// defaultDegradedBelow is the score under which a stack that does not
+	// say is Degraded.
+	defaultDegradedBelow = 70
+
+	// How much each incident takes off the score of a stack.
+	restartPenalty   = 10
+	unhealthyPenalty = 5
+	oomKillPenalty   = 20
+
+	// maxIncidents bounds the incidents kept in the status of a stack. A
+	// stack with this many incidents in its window scores 0 regardless.
+	maxIncidents = 100
+)
+
+// healthScore exports the health score of each scored stack.
+var healthScore = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "provider_docker_compose_stack_health_score",
+	Help: "Health score of a ComposeStack from 0 to 100, less for each restart, failed health check and out-of-memory kill of its services in its window.",
+}, []string{"namespace", "name"})
+
+func init() {
+	metrics.Registry.MustRegister(healthScore)
+}
+
+// observeHealth reads the Docker events of the stack's services since they
+// were last read, and scores the stack on the incidents in its window. It
+// returns nothing for a stack that is not scored.
+func (c *external) observeHealth(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName string, now time.Time) (*composev1alpha1.StackHealth, error) {
+	cfg := cr.Spec.ForProvider.Health
+	if cfg == nil {
+		return nil, nil
+	}
+	window := defaultHealthWindow
+	if cfg.Window != nil && cfg.Window.Duration > 0 {
+		window = cfg.Window.Duration
+	}
+	cutoff := now.Add(-window)
+
+	// Only the events since the stack was last checked are read again
+	since := cutoff
+	var incidents []composev1alpha1.HealthIncident
+	if prev := cr.Status.AtProvider.Health; prev != nil {
+		incidents = slices.Clone(prev.Incidents)
+		if prev.CheckedAt != nil && prev.CheckedAt.After(since) {
+			since = prev.CheckedAt.Time
+		}
+	}
+
+	read, err := c.readIncidents(ctx, projectName, since, now)
+	if err != nil {
+		return nil, errors.Wrap(err, errReadEvents)
+	}
+	h := scoreHealth(append(incidents, read...), cutoff)
+	h.CheckedAt = &metav1.Time{Time: now}
+	return h, nil
+}
+
+// readIncidents returns the incidents of the services of a stack between
+// since and until, oldest first.
+func (c *external) readIncidents(ctx context.Context, projectName string, since, until time.Time) ([]composev1alpha1.HealthIncident, error) {
+	f := labels.Filter(labels.Selector(labels.ComposeProject, projectName))
+	f.Add("type", string(events.ContainerEventType))
+	for _, a := range []events.Action{events.ActionDie, events.ActionOOM, events.ActionHealthStatus} {
+		f.Add("event", string(a))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	messages, errs := c.service.Events(ctx, events.ListOptions{
+		Since:   timestamp(since),
+		Until:   timestamp(until),
+		Filters: f,
+	})
+
+	var incidents []composev1alpha1.HealthIncident
+	for {
+		select {
+		case m := <-messages:
+			// Events at the boundary were read last time
+			if inc, ok := incident(m); ok && inc.At.After(since) {
+				incidents = append(incidents, inc)
+			}
+		case err := <-errs:
+			// A stream of events up to a time ends with EOF
+			if errors.Is(err, io.EOF) {
+				return incidents, nil
+			}
+			return nil, err
+		}
+	}
+}
+
+// incident returns the incident a Docker event records, if any. A container
+// that dies with the exit code of a stop, SIGTERM or SIGKILL, was stopped
+// rather than crashed, and an out-of-memory kill is its own incident.
+func incident(m events.Message) (composev1alpha1.HealthIncident, bool) {
+	var t composev1alpha1.HealthIncidentType
+	switch m.Action {
+	case events.ActionDie:
+		switch m.Actor.Attributes["exitCode"] {
+		case "", "0", "137", "143":
+			return composev1alpha1.HealthIncident{}, false
+		}
+		t = composev1alpha1.HealthIncidentRestart
+	case events.ActionOOM:
+		t = composev1alpha1.HealthIncidentOOMKill
+	case events.ActionHealthStatusUnhealthy:
+		t = composev1alpha1.HealthIncidentUnhealthy
+	default:
+		return composev1alpha1.HealthIncident{}, false
+	}
+
+	service := m.Actor.Attributes[labels.ComposeService]
+	if service == "" {
+		service = m.Actor.Attributes["name"]
+	}
+	return composev1alpha1.HealthIncident{
+		Service: service,
+		Type:    t,
+		At:      metav1.Time{Time: time.Unix(0, m.TimeNano)},
+	}, true
+}
+
+// scoreHealth scores a stack on its incidents after cutoff.
+func scoreHealth(incidents []composev1alpha1.HealthIncident, cutoff time.Time) *composev1alpha1.StackHealth {
+	incidents = slices.DeleteFunc(incidents, func(i composev1alpha1.HealthIncident) bool {
+		return !i.At.After(cutoff)
+	})
+	slices.SortStableFunc(incidents, func(a, b composev1alpha1.HealthIncident) int {
+		return a.At.Compare(b.At.Time)
+	})
+	if len(incidents) > maxIncidents {
+		incidents = incidents[len(incidents)-maxIncidents:]
+	}
+
+	h := &composev1alpha1.StackHealth{Score: 100, Incidents: incidents}
+	for _, i := range incidents {
+		switch i.Type {
+		case composev1alpha1.HealthIncidentRestart:
+			h.Restarts++
+			h.Score -= restartPenalty
+		case composev1alpha1.HealthIncidentUnhealthy:
+			h.UnhealthyIntervals++
+			h.Score -= unhealthyPenalty
+		case composev1alpha1.HealthIncidentOOMKill:
+			h.OOMKills++
+			h.Score -= oomKillPenalty
+		}
+	}
+	h.Score = max(h.Score, 0)
+	return h
+}
+
+// reportHealth exports the health score of a stack, and marks it Degraded
+// while its score is below its threshold.
+func reportHealth(cr *composev1alpha1.ComposeStack, h *composev1alpha1.StackHealth) {
+	if h == nil {
+		healthScore.DeleteLabelValues(cr.GetNamespace(), cr.GetName())
+		return
+	}
+	healthScore.WithLabelValues(cr.GetNamespace(), cr.GetName()).Set(float64(h.Score))
+
+	threshold := int32(defaultDegradedBelow)
+	if t := cr.Spec.ForProvider.Health.DegradedBelow; t != nil {
+		threshold = *t
+	}
+	if h.Score < threshold {
+		cr.SetConditions(composev1alpha1.Degraded(h.Score, threshold))
+	} else {
+		cr.SetConditions(composev1alpha1.NotDegraded())
+	}
+}
+
+// timestamp formats t as the events API expects.
+func timestamp(t time.Time) string {
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
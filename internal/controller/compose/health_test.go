@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/events"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestIncident(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	msg := func(action events.Action, attrs map[string]string) events.Message {
+		return events.Message{Action: action, Actor: events.Actor{Attributes: attrs}, TimeNano: at.UnixNano()}
+	}
+
+	tests := map[string]struct {
+		m    events.Message
+		want composev1alpha1.HealthIncidentType
+	}{
+		"Crashed":   {m: msg(events.ActionDie, map[string]string{"exitCode": "1", labels.ComposeService: "web"}), want: composev1alpha1.HealthIncidentRestart},
+		"Exited":    {m: msg(events.ActionDie, map[string]string{"exitCode": "0", labels.ComposeService: "web"})},
+		"Stopped":   {m: msg(events.ActionDie, map[string]string{"exitCode": "143", labels.ComposeService: "web"})},
+		"Killed":    {m: msg(events.ActionDie, map[string]string{"exitCode": "137", labels.ComposeService: "web"})},
+		"OOMKilled": {m: msg(events.ActionOOM, map[string]string{labels.ComposeService: "web"}), want: composev1alpha1.HealthIncidentOOMKill},
+		"Unhealthy": {m: msg(events.ActionHealthStatusUnhealthy, map[string]string{labels.ComposeService: "web"}), want: composev1alpha1.HealthIncidentUnhealthy},
+		"Healthy":   {m: msg(events.ActionHealthStatusHealthy, map[string]string{labels.ComposeService: "web"})},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := incident(tc.m)
+			if ok != (tc.want != "") {
+				t.Fatalf("incident(...): ok = %v, want %v", ok, tc.want != "")
+			}
+			if ok && (got.Type != tc.want || got.Service != "web" || !got.At.Equal(&metav1.Time{Time: at})) {
+				t.Errorf("incident(...) = %+v, want a %s of web at %s", got, tc.want, at)
+			}
+		})
+	}
+}
+
+func TestScoreHealth(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	inc := func(typ composev1alpha1.HealthIncidentType, ago time.Duration) composev1alpha1.HealthIncident {
+		return composev1alpha1.HealthIncident{Service: "web", Type: typ, At: metav1.Time{Time: now.Add(-ago)}}
+	}
+
+	h := scoreHealth([]composev1alpha1.HealthIncident{
+		inc(composev1alpha1.HealthIncidentUnhealthy, 10*time.Minute),
+		inc(composev1alpha1.HealthIncidentRestart, 2*time.Hour),
+		inc(composev1alpha1.HealthIncidentRestart, 30*time.Minute),
+		inc(composev1alpha1.HealthIncidentOOMKill, 20*time.Minute),
+	}, now.Add(-time.Hour))
+	if h.Score != 65 || h.Restarts != 1 || h.UnhealthyIntervals != 1 || h.OOMKills != 1 {
+		t.Errorf("scoreHealth(...) = %+v, want score 65 from 1 restart, 1 unhealthy and 1 OOM kill", h)
+	}
+	if len(h.Incidents) != 3 || h.Incidents[0].Type != composev1alpha1.HealthIncidentRestart {
+		t.Errorf("scoreHealth(...) incidents = %+v, want the 3 in the window, oldest first", h.Incidents)
+	}
+
+	var many []composev1alpha1.HealthIncident
+	for i := range 12 {
+		many = append(many, inc(composev1alpha1.HealthIncidentOOMKill, time.Duration(i)*time.Minute))
+	}
+	if h := scoreHealth(many, now.Add(-time.Hour)); h.Score != 0 {
+		t.Errorf("scoreHealth(...) score = %d, want 0", h.Score)
+	}
+}
+
+func TestObserveHealth(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	crash := events.Message{
+		Action:   events.ActionDie,
+		Actor:    events.Actor{Attributes: map[string]string{"exitCode": "1", labels.ComposeService: "web"}},
+		TimeNano: now.Add(-time.Minute).UnixNano(),
+	}
+	ext := &external{service: &mockDockerClient{events: []events.Message{crash}}}
+
+	cr := &composev1alpha1.ComposeStack{ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default"}}
+	if h, err := ext.observeHealth(context.Background(), cr, "shop", now); h != nil || err != nil {
+		t.Errorf("observeHealth(...) without health = %v, %v, want nil", h, err)
+	}
+
+	// The stack had two restarts already, one of which has left the window
+	threshold := int32(90)
+	cr.Spec.ForProvider.Health = &composev1alpha1.HealthScoreConfig{DegradedBelow: &threshold}
+	cr.Status.AtProvider.Health = &composev1alpha1.StackHealth{
+		Incidents: []composev1alpha1.HealthIncident{
+			{Service: "web", Type: composev1alpha1.HealthIncidentRestart, At: metav1.Time{Time: now.Add(-2 * time.Hour)}},
+			{Service: "db", Type: composev1alpha1.HealthIncidentRestart, At: metav1.Time{Time: now.Add(-10 * time.Minute)}},
+		},
+		CheckedAt: &metav1.Time{Time: now.Add(-5 * time.Minute)},
+	}
+	h, err := ext.observeHealth(context.Background(), cr, "shop", now)
+	if err != nil {
+		t.Fatalf("observeHealth(...): %v", err)
+	}
+	if h.Score != 80 || h.Restarts != 2 || !h.CheckedAt.Equal(&metav1.Time{Time: now}) {
+		t.Errorf("observeHealth(...) = %+v, want score 80 from 2 restarts, checked now", h)
+	}
+
+	reportHealth(cr, h)
+	if c := cr.GetCondition(composev1alpha1.TypeDegraded); c.Status != v1.ConditionTrue {
+		t.Errorf("Degraded condition = %+v, want True", c)
+	}
+	if got := testutil.ToFloat64(healthScore.WithLabelValues("default", "shop")); got != 80 {
+		t.Errorf("health score metric = %v, want 80", got)
+	}
+	reportHealth(cr, nil)
+	if n := testutil.CollectAndCount(healthScore); n != 0 {
+		t.Errorf("health score metric has %d series after the stack stopped being scored, want 0", n)
+	}
+}
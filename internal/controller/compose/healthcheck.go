@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"slices"
+	"strings"
+)
+
+// serviceHealth returns the health check status of a service's container,
+// or nil if it has no health check.
+func serviceHealth(state *container.State) *composev1alpha1.HealthStatus {
+	if state == nil || state.Health == nil {
+		return nil
+	}
+	streak := int32(state.Health.FailingStreak)
+	h := &composev1alpha1.HealthStatus{
+		Status:        state.Health.Status,
+		FailingStreak: &streak,
+	}
+	for _, r := range state.Health.Log {
+		result := composev1alpha1.HealthCheckResult{ExitCode: int32(r.ExitCode)}
+		if r.Output != "" {
+			output := r.Output
+			result.Output = &output
+		}
+		if !r.Start.IsZero() {
+			result.Start = &metav1.Time{Time: r.Start}
+		}
+		if !r.End.IsZero() {
+			result.End = &metav1.Time{Time: r.End}
+		}
+		h.Log = append(h.Log, result)
+	}
+	return h
+}
+
+// healthOf returns the health check status of a running container, or
+// container.NoHealthcheck if it has no health check or is not running. The
+// health of a container that is not running does not count, since its
+// state already does.
+func healthOf(state *container.State) container.HealthStatus {
+	if state == nil || state.Status != "running" || state.Health == nil || state.Health.Status == "" {
+		return container.NoHealthcheck
+	}
+	return state.Health.Status
+}
+
+// stackHealth collects the services of a stack whose health checks do not
+// yet report them healthy.
+type stackHealth struct {
+	starting  []string
+	unhealthy []string
+}
+
+// observe records the health of a container of a service.
+func (h *stackHealth) observe(service string, status container.HealthStatus) {
+	switch status {
+	case container.Starting:
+		if !slices.Contains(h.starting, service) {
+			h.starting = append(h.starting, service)
+		}
+	case container.Unhealthy:
+		if !slices.Contains(h.unhealthy, service) {
+			h.unhealthy = append(h.unhealthy, service)
+		}
+	}
+}
+
+// condition returns the Ready condition of a running stack: Available once
+// every service with a health check reports healthy, Creating while any
+// has yet to pass its first, and Unavailable while any fails its health
+// check.
+func (h *stackHealth) condition() xpv1.Condition {
+	switch {
+	case len(h.unhealthy) > 0:
+		slices.Sort(h.unhealthy)
+		return xpv1.Unavailable().WithMessage("Unhealthy services: " + strings.Join(h.unhealthy, ", "))
+	case len(h.starting) > 0:
+		slices.Sort(h.starting)
+		return xpv1.Creating().WithMessage("Waiting for the health checks of services: " + strings.Join(h.starting, ", "))
+	}
+	return xpv1.Available()
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+	"time"
+)
+
+func TestExternal_ObserveHealthChecks(t *testing.T) {
+	const content = `
+services:
+  web:
+    image: nginx:1.27
+  db:
+    image: postgres:16
+`
+
+	scheme := runtime.NewScheme()
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+	stored := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "uid"},
+	}
+	stored.Spec.ForProvider.Compose = stringPtr(content)
+	stored.Spec.ForProvider.ServiceOverrides = map[string]composev1alpha1.ServiceOverride{"web": {Replicas: ptr.To[int32](2)}}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithStatusSubresource(stored).Build()
+
+	dc := &hostClient{mockDockerClient: &mockDockerClient{}, host: map[string]container.InspectResponse{}}
+	ext := &external{kube: kube, service: dc}
+
+	cr := stored.DeepCopy()
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	checked := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	setHealth := func(name string, status container.HealthStatus) {
+		dc.host[name].State.Health = &container.Health{
+			Status:        status,
+			FailingStreak: 2,
+			Log:           []*container.HealthcheckResult{{Start: checked, End: checked.Add(time.Second), ExitCode: 1, Output: "connection refused"}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		db      container.HealthStatus
+		web2    container.HealthStatus
+		reason  xpv1.ConditionReason
+		message string
+	}{
+		{
+			name:   "NoHealthChecks",
+			reason: xpv1.ReasonAvailable,
+		},
+		{
+			name:   "Healthy",
+			db:     container.Healthy,
+			web2:   container.Healthy,
+			reason: xpv1.ReasonAvailable,
+		},
+		{
+			name:    "Starting",
+			db:      container.Starting,
+			web2:    container.Healthy,
+			reason:  xpv1.ReasonCreating,
+			message: "Waiting for the health checks of services: db",
+		},
+		{
+			name:    "Unhealthy",
+			db:      container.Starting,
+			web2:    container.Unhealthy,
+			reason:  xpv1.ReasonUnavailable,
+			message: "Unhealthy services: web",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dc.host["stack_stack-db_1"].State.Health = nil
+			dc.host["stack_stack-web_2"].State.Health = nil
+			if tt.db != "" {
+				setHealth("stack_stack-db_1", tt.db)
+			}
+			if tt.web2 != "" {
+				setHealth("stack_stack-web_2", tt.web2)
+			}
+
+			obs, err := ext.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe() error = %v", err)
+			}
+			if !obs.ResourceExists || !obs.ResourceUpToDate {
+				t.Errorf("Observe() = %+v, want the stack to exist and be up to date whatever its health", obs)
+			}
+			ready := cr.GetCondition(xpv1.TypeReady)
+			if ready.Reason != tt.reason || ready.Message != tt.message {
+				t.Errorf("Observe() Ready = %s %q, want %s %q", ready.Reason, ready.Message, tt.reason, tt.message)
+			}
+
+			db := cr.Status.AtProvider.Services["stack-db"]
+			if tt.db == "" {
+				if db.Health != nil {
+					t.Errorf("Observe() health of a service without a health check = %+v, want none", db.Health)
+				}
+				return
+			}
+			if db.Health == nil || db.Health.Status != tt.db || *db.Health.FailingStreak != 2 || len(db.Health.Log) != 1 ||
+				*db.Health.Log[0].Output != "connection refused" || !db.Health.Log[0].Start.Time.Equal(checked) {
+				t.Errorf("Observe() health of the db service = %+v, want %s with its log", db.Health, tt.db)
+			}
+		})
+	}
+}
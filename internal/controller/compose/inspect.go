@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"maps"
+	"strings"
+)
+
+const (
+	// maxInspectBytes bounds a captured inspect output, well within the
+	// 1MiB a ConfigMap may hold.
+	maxInspectBytes = 512 * 1024
+
+	// redactedValue replaces the value of an environment variable of a
+	// captured container that was taken from a Secret.
+	redactedValue = "<redacted>"
+
+	reasonInspectNotCaptured event.Reason = "InspectNotCaptured"
+)
+
+// captureInspect writes the inspect output of the named service's container
+// to the stack's inspect ConfigMap, replacing any earlier capture. A service
+// without a container, or whose output is too large for a ConfigMap, is not
+// captured, with a warning event saying why.
+func (c *external) captureInspect(ctx context.Context, cr *composev1alpha1.ComposeStack, service string, observed map[string]container.InspectResponse) error {
+	info, ok := observed[service]
+	if !ok {
+		c.warnInspect(cr, errors.Errorf("service %q has no container to inspect", service))
+		return nil
+	}
+
+	content, err := inspectJSON(redactInspect(info, cr.Spec.ForProvider.Environment))
+	if err != nil {
+		return err
+	}
+	if len(content) > maxInspectBytes {
+		c.warnInspect(cr, errors.Errorf("inspect output of service %q is %d bytes, more than the %d a capture may hold", service, len(content), maxInspectBytes))
+		return nil
+	}
+
+	captured := map[string]string{service + ".json": string(content)}
+	return c.applyConfigMap(ctx, cr, cr.GetName()+composev1alpha1.InspectConfigMapSuffix, func(data map[string]string) bool {
+		if maps.Equal(data, captured) {
+			return false
+		}
+		maps.DeleteFunc(data, func(string, string) bool { return true })
+		maps.Copy(data, captured)
+		return true
+	})
+}
+
+func (c *external) warnInspect(cr *composev1alpha1.ComposeStack, err error) {
+	if c.recorder != nil {
+		c.recorder.Event(cr, event.Warning(reasonInspectNotCaptured, err))
+	}
+}
+
+// inspectJSON returns inspect output as docker inspect prints it, indented,
+// or compact if indenting would make it too large to capture.
+func inspectJSON(info container.InspectResponse) ([]byte, error) {
+	content, err := json.MarshalIndent(info, "", "    ")
+	if err != nil || len(content) <= maxInspectBytes {
+		return content, err
+	}
+	return json.Marshal(info)
+}
+
+// redactInspect returns a copy of inspect output without the values of the
+// environment variables of the stack that were taken from Secrets, since a
+// ConfigMap can be read more widely than they can.
+func redactInspect(info container.InspectResponse, vars []composev1alpha1.ComposeEnvVar) container.InspectResponse {
+	if info.Config == nil || len(info.Config.Env) == 0 {
+		return info
+	}
+
+	secret := make(map[string]bool)
+	for _, v := range vars {
+		if v.ValueFrom != nil && v.ValueFrom.SecretKeyRef != nil {
+			secret[v.Name] = true
+		}
+	}
+	if len(secret) == 0 {
+		return info
+	}
+
+	cfg := *info.Config
+	cfg.Env = make([]string, len(info.Config.Env))
+	for i, kv := range info.Config.Env {
+		name, _, _ := strings.Cut(kv, "=")
+		if secret[name] {
+			kv = name + "=" + redactedValue
+		}
+		cfg.Env[i] = kv
+	}
+	info.Config = &cfg
+	return info
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/docker/docker/api/types/container"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"maps"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCaptureInspect(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	cr := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default", UID: "1234"},
+		Spec: composev1alpha1.ComposeStackSpec{
+			ForProvider: composev1alpha1.ComposeStackParameters{
+				Environment: []composev1alpha1.ComposeEnvVar{
+					{Name: "DB_PASSWORD", ValueFrom: &composev1alpha1.EnvVarSource{SecretKeyRef: &composev1alpha1.SecretKeySelector{Name: "db", Key: "password"}}},
+				},
+			},
+		},
+	}
+	inspect := func(id string, env ...string) container.InspectResponse {
+		return container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{ID: id, State: &container.State{Status: "running"}},
+			Config:            &container.Config{Image: "shop:1.0", Env: env},
+		}
+	}
+	observed := map[string]container.InspectResponse{
+		"web": inspect("web123", "MODE=production", "DB_PASSWORD=hunter2"),
+		"db":  inspect("db123"),
+	}
+
+	kube := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ext := &external{kube: kube}
+	key := types.NamespacedName{Namespace: "default", Name: "shop-inspect"}
+
+	if err := ext.captureInspect(context.Background(), cr, "web", observed); err != nil {
+		t.Fatalf("captureInspect(...): %v", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(context.Background(), key, cm); err != nil {
+		t.Fatalf("cannot get inspect ConfigMap: %v", err)
+	}
+	got := container.InspectResponse{}
+	if err := json.Unmarshal([]byte(cm.Data["web.json"]), &got); err != nil {
+		t.Fatalf("captured inspect output is not JSON: %v", err)
+	}
+	if got.ID != "web123" {
+		t.Errorf("captured container %q, want web123", got.ID)
+	}
+	if want := []string{"MODE=production", "DB_PASSWORD=" + redactedValue}; !slices.Equal(got.Config.Env, want) {
+		t.Errorf("captured environment %q, want %q", got.Config.Env, want)
+	}
+	if observed["web"].Config.Env[1] != "DB_PASSWORD=hunter2" {
+		t.Error("captureInspect(...) redacted the observed container rather than a copy")
+	}
+	if refs := cm.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != cr.GetUID() {
+		t.Errorf("inspect ConfigMap owners = %+v, want the stack", refs)
+	}
+
+	// Capturing another service replaces the earlier capture.
+	if err := ext.captureInspect(context.Background(), cr, "db", observed); err != nil {
+		t.Fatalf("captureInspect(...): %v", err)
+	}
+	if err := kube.Get(context.Background(), key, cm); err != nil {
+		t.Fatalf("cannot get inspect ConfigMap: %v", err)
+	}
+	if _, ok := cm.Data["web.json"]; ok || !strings.Contains(cm.Data["db.json"], `"db123"`) {
+		t.Errorf("inspect ConfigMap keys after capturing db = %v, want only db.json", slices.Sorted(maps.Keys(cm.Data)))
+	}
+
+	// A service without a container, or too large an output, is not captured.
+	huge := inspect("big123", "BLOB="+strings.Repeat("x", maxInspectBytes))
+	for name, o := range map[string]map[string]container.InspectResponse{"cache": observed, "big": {"big": huge}} {
+		if err := ext.captureInspect(context.Background(), cr, name, o); err != nil {
+			t.Fatalf("captureInspect(%q): %v", name, err)
+		}
+	}
+	if err := kube.Get(context.Background(), key, cm); err != nil {
+		t.Fatalf("cannot get inspect ConfigMap: %v", err)
+	}
+	if _, ok := cm.Data["db.json"]; !ok || len(cm.Data) != 1 {
+		t.Errorf("inspect ConfigMap keys changed by services that cannot be captured: %d keys", len(cm.Data))
+	}
+}
+
+func TestExportStackRedactsSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	cr := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default", UID: "1234"},
+		Spec: composev1alpha1.ComposeStackSpec{
+			ForProvider: composev1alpha1.ComposeStackParameters{
+				Environment: []composev1alpha1.ComposeEnvVar{
+					{Name: "DB_PASSWORD", ValueFrom: &composev1alpha1.EnvVarSource{SecretKeyRef: &composev1alpha1.SecretKeySelector{Name: "db", Key: "password"}}},
+				},
+			},
+		},
+	}
+	observed := map[string]container.InspectResponse{
+		"web": {
+			ContainerJSONBase: &container.ContainerJSONBase{ID: "web123", Name: "/shop-web-1"},
+			Config:            &container.Config{Image: "shop:1.0", Env: []string{"MODE=production", "DB_PASSWORD=hunter2"}},
+		},
+	}
+
+	kube := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ext := &external{kube: kube}
+	if err := ext.exportStack(context.Background(), cr, "shop-export", "shop", observed); err != nil {
+		t.Fatalf("exportStack(...): %v", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "shop-export"}, cm); err != nil {
+		t.Fatalf("cannot get export ConfigMap: %v", err)
+	}
+	exported := cm.Data[composev1alpha1.ExportConfigMapKey]
+	if strings.Contains(exported, "hunter2") || !strings.Contains(exported, redactedValue) {
+		t.Errorf("exported compose file does not redact DB_PASSWORD:\n%s", exported)
+	}
+	if !strings.Contains(exported, "production") {
+		t.Errorf("exported compose file lost MODE:\n%s", exported)
+	}
+	if observed["web"].Config.Env[1] != "DB_PASSWORD=hunter2" {
+		t.Error("exportStack(...) redacted the observed container rather than a copy")
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultTailLines is how many lines of each service are collected when
+	// the stack does not say.
+	defaultTailLines = 20
+
+	// maxEventMessage bounds the aggregated event, which the events API
+	// truncates at 1kB.
+	maxEventMessage = 1024
+
+	reasonServicesFailed event.Reason = "ServicesFailed"
+)
+
+// serviceFailure returns why a service's container has failed, or nothing if
+// it has not. A one-shot service that others wait on may exit, as long as
+// it exits successfully.
+func serviceFailure(state *container.State, oneShot bool) string {
+	switch {
+	case state == nil:
+		return ""
+	case state.Dead:
+		return "dead"
+	case state.Restarting:
+		return "restarting"
+	case state.Status == "exited" && (state.ExitCode != 0 || !oneShot):
+		return fmt.Sprintf("exited with code %d", state.ExitCode)
+	}
+	return ""
+}
+
+// aggregateLogs collects the last lines each observed service logged, when
+// the stack aggregates logs and any of its services has failed.
+func (c *external) aggregateLogs(ctx context.Context, cr *composev1alpha1.ComposeStack, observed map[string]container.InspectResponse, failed map[string]string) map[string]string {
+	l := cr.Spec.ForProvider.Logs
+	if l == nil || !l.Aggregate || len(failed) == 0 {
+		return nil
+	}
+	tail := defaultTailLines
+	if l.TailLines != nil && *l.TailLines > 0 {
+		tail = int(*l.TailLines)
+	}
+
+	logs := make(map[string]string, len(observed))
+	for name, info := range observed {
+		out, err := c.tailLogs(ctx, &info, tail)
+		if err != nil {
+			out = fmt.Sprintf("cannot read logs: %v", err)
+		}
+		logs[name] = out
+	}
+	return logs
+}
+
+// tailLogs returns the last lines a container wrote to stdout and stderr.
+func (c *external) tailLogs(ctx context.Context, info *container.InspectResponse, lines int) (string, error) {
+	rc, err := c.service.ContainerLogs(ctx, info.ID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.Itoa(lines),
+	})
+	if err != nil {
+		return "", err
+	}
+	if rc == nil {
+		return "", nil
+	}
+	defer func() { _ = rc.Close() }()
+
+	// Containers without a TTY interleave stdout and stderr in one stream
+	var buf bytes.Buffer
+	if info.Config == nil || !info.Config.Tty {
+		_, err = stdcopy.StdCopy(&buf, &buf, rc)
+	} else {
+		_, err = io.Copy(&buf, rc)
+	}
+	return buf.String(), errors.Wrap(err, "cannot read container logs")
+}
+
+// failureEvent summarises the failed services of a stack, with the last line
+// each logged, in a single event.
+func failureEvent(failed map[string]string, logs map[string]string) event.Event {
+	names := make([]string, 0, len(failed))
+	for name := range failed {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s %s", name, failed[name])
+		if last := lastLine(logs[name]); last != "" {
+			fmt.Fprintf(&b, ": %s", last)
+		}
+		b.WriteString("\n")
+	}
+	msg := strings.TrimSuffix(b.String(), "\n")
+	if len(msg) > maxEventMessage {
+		msg = msg[:maxEventMessage]
+	}
+	return event.Warning(reasonServicesFailed, errors.New(msg))
+}
+
+// lastLine returns the last non-empty line of s.
+func lastLine(s string) string {
+	s = strings.TrimRight(s, "\n")
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		s = s[i+1:]
+	}
+	return s
+}
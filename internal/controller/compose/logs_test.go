@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"testing"
+)
+
+func TestServiceFailure(t *testing.T) {
+	tests := map[string]struct {
+		state   *container.State
+		oneShot bool
+		want    string
+	}{
+		"Running":          {state: &container.State{Status: "running", Running: true}},
+		"Dead":             {state: &container.State{Status: "dead", Dead: true}, want: "dead"},
+		"Restarting":       {state: &container.State{Status: "restarting", Restarting: true}, want: "restarting"},
+		"ExitedWithError":  {state: &container.State{Status: "exited", ExitCode: 2}, want: "exited with code 2"},
+		"ExitedCleanly":    {state: &container.State{Status: "exited"}, want: "exited with code 0"},
+		"OneShotCompleted": {state: &container.State{Status: "exited"}, oneShot: true},
+		"OneShotWithError": {state: &container.State{Status: "exited", ExitCode: 1}, oneShot: true, want: "exited with code 1"},
+		"NoStateToObserve": {},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := serviceFailure(tc.state, tc.oneShot); got != tc.want {
+				t.Errorf("serviceFailure(...) = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAggregateLogs(t *testing.T) {
+	tty := &container.Config{Tty: true}
+	observed := map[string]container.InspectResponse{
+		"web": {ContainerJSONBase: &container.ContainerJSONBase{ID: "web-id"}, Config: tty},
+		"db":  {ContainerJSONBase: &container.ContainerJSONBase{ID: "db-id"}, Config: tty},
+	}
+	ext := &external{service: &mockDockerClient{containerLogs: map[string]string{
+		"web-id": "listening on :80\n",
+		"db-id":  "starting\nFATAL: password authentication failed\n",
+	}}}
+
+	cr := &composev1alpha1.ComposeStack{}
+	failed := map[string]string{"db": "exited with code 1"}
+	if got := ext.aggregateLogs(context.Background(), cr, observed, failed); got != nil {
+		t.Errorf("aggregateLogs(...) without aggregation = %v, want nil", got)
+	}
+
+	cr.Spec.ForProvider.Logs = &composev1alpha1.LogsConfig{Aggregate: true}
+	if got := ext.aggregateLogs(context.Background(), cr, observed, nil); got != nil {
+		t.Errorf("aggregateLogs(...) without failures = %v, want nil", got)
+	}
+
+	got := ext.aggregateLogs(context.Background(), cr, observed, failed)
+	want := map[string]string{
+		"web": "listening on :80\n",
+		"db":  "starting\nFATAL: password authentication failed\n",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("aggregateLogs(...): -want, +got:\n%s", diff)
+	}
+
+	e := failureEvent(failed, got)
+	if e.Type != event.TypeWarning || e.Message != "db exited with code 1: FATAL: password authentication failed" {
+		t.Errorf("failureEvent(...) = %+v", e)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	"strings"
+)
+
+// servicePrefix marks a compose network mode that shares the network stack of
+// another service of the stack.
+const servicePrefix = "service:"
+
+// applyNetworkMode drops the settings Docker rejects for a container that
+// does not get a network stack of its own. A container on the host network
+// cannot publish ports or join other networks, and one sharing another
+// container's network inherits its hostname, DNS and ports as well.
+func applyNetworkMode(config *container.Config, hostConfig *container.HostConfig, networkConfig *network.NetworkingConfig) {
+	mode := hostConfig.NetworkMode
+	shared := mode.IsContainer() || strings.HasPrefix(string(mode), servicePrefix)
+	if !mode.IsHost() && !shared {
+		return
+	}
+
+	hostConfig.PortBindings = nil
+	hostConfig.PublishAllPorts = false
+	networkConfig.EndpointsConfig = nil
+	if !shared {
+		return
+	}
+
+	config.Hostname = ""
+	config.Domainname = ""
+	config.ExposedPorts = nil
+	hostConfig.DNS = nil
+	hostConfig.DNSSearch = nil
+	hostConfig.DNSOptions = nil
+	hostConfig.ExtraHosts = nil
+}
+
+// resolveNetworkMode translates a service:<name> network mode to the
+// container of that service, which has already been created since compose
+// makes the service a dependency. It is resolved only when the container is
+// created so that the configuration hash does not change when the service's
+// container is replaced.
+func (c *external) resolveNetworkMode(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName string, hostConfig *container.HostConfig) error {
+	service, ok := strings.CutPrefix(string(hostConfig.NetworkMode), servicePrefix)
+	if !ok {
+		return nil
+	}
+
+	containers, err := c.stackContainers(ctx, cr, projectName)
+	if err != nil {
+		return err
+	}
+	for _, cont := range containers {
+		if cont.Labels[labels.ComposeService] == service {
+			hostConfig.NetworkMode = container.NetworkMode("container:" + cont.ID)
+			return nil
+		}
+	}
+	return errors.Errorf("cannot share the network of service %s: it has no container", service)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestConvertContainerSpecNetworkMode(t *testing.T) {
+	tests := []struct {
+		mode         string
+		wantPorts    bool
+		wantNetworks bool
+		wantHostname bool
+	}{
+		{mode: "bridge", wantPorts: true, wantNetworks: true, wantHostname: true},
+		{mode: "host", wantHostname: true},
+		{mode: "service:vpn"},
+		{mode: "container:abc123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			name := "app"
+			mode := tt.mode
+			hostPort := int32(8080)
+			spec := &containerv1alpha1.ContainerParameters{
+				Image:       "qbittorrent:latest",
+				Name:        &name,
+				NetworkMode: &mode,
+				Ports:       []containerv1alpha1.PortSpec{{ContainerPort: 8080, HostPort: &hostPort}},
+				Networks:    []containerv1alpha1.NetworkAttachment{{Name: "default"}},
+				DNS:         []string{"1.1.1.1"},
+			}
+			ext := &external{}
+			config, hostConfig, networkConfig, err := ext.convertContainerSpec(context.Background(), &composev1alpha1.ComposeStack{}, spec, "media")
+			if err != nil {
+				t.Fatalf("convertContainerSpec() error = %v", err)
+			}
+
+			if string(hostConfig.NetworkMode) != tt.mode {
+				t.Errorf("NetworkMode = %q, want %q", hostConfig.NetworkMode, tt.mode)
+			}
+			if got := len(hostConfig.PortBindings) > 0; got != tt.wantPorts {
+				t.Errorf("PortBindings = %v, want ports %v", hostConfig.PortBindings, tt.wantPorts)
+			}
+			if got := len(networkConfig.EndpointsConfig) > 0; got != tt.wantNetworks {
+				t.Errorf("EndpointsConfig = %v, want networks %v", networkConfig.EndpointsConfig, tt.wantNetworks)
+			}
+			if got := config.Hostname != ""; got != tt.wantHostname {
+				t.Errorf("Hostname = %q, want hostname %v", config.Hostname, tt.wantHostname)
+			}
+			if got := len(hostConfig.DNS) > 0; got != tt.wantHostname {
+				t.Errorf("DNS = %v, want DNS %v", hostConfig.DNS, tt.wantHostname)
+			}
+		})
+	}
+}
+
+func TestResolveNetworkMode(t *testing.T) {
+	cr := &composev1alpha1.ComposeStack{ObjectMeta: metav1.ObjectMeta{Name: "media", UID: "uid"}}
+	dc := &mockDockerClient{containers: []container.Summary{
+		{ID: "vpn123", Labels: map[string]string{labels.StackUID: "uid", labels.ComposeProject: "media", labels.ComposeService: "vpn"}},
+	}}
+	ext := &external{service: dc}
+
+	tests := []struct {
+		mode    string
+		want    string
+		wantErr bool
+	}{
+		{mode: "service:vpn", want: "container:vpn123"},
+		{mode: "service:proxy", want: "service:proxy", wantErr: true},
+		{mode: "host", want: "host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			hostConfig := &container.HostConfig{NetworkMode: container.NetworkMode(tt.mode)}
+			err := ext.resolveNetworkMode(context.Background(), cr, "media", hostConfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveNetworkMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(hostConfig.NetworkMode) != tt.want {
+				t.Errorf("NetworkMode = %q, want %q", hostConfig.NetworkMode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/compose-spec/compose-go/v2/types"
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/network"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+)
+
+const (
+	errInspectNetwork = "cannot inspect network %s"
+	errCreateNetwork  = "cannot create network %s"
+)
+
+// predefinedNetworks are the networks Docker provides itself, which cannot be
+// created.
+var predefinedNetworks = map[string]bool{"default": true, "bridge": true, "host": true, "none": true}
+
+// createNetworks creates the networks the services of a stack attach to that
+// do not exist yet, as the compose file defines them and labelled as the
+// stack's other Docker objects are. Networks the compose file marks as
+// external are never created.
+func (c *external) createNetworks(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName string, project *types.Project, containers []containerv1alpha1.Container) error {
+	created := map[string]bool{}
+	for _, cont := range containers {
+		for _, att := range cont.Spec.ForProvider.Networks {
+			name := att.Name
+			def, ok := project.Networks[name]
+			if !ok || bool(def.External) || predefinedNetworks[name] || created[name] {
+				continue
+			}
+			created[name] = true
+
+			_, err := c.service.NetworkInspect(ctx, name, network.InspectOptions{})
+			if err == nil {
+				continue
+			}
+			if !cerrdefs.IsNotFound(err) {
+				return errors.Wrapf(err, errInspectNetwork, name)
+			}
+			if _, err := c.service.NetworkCreate(ctx, name, networkOptions(cr, projectName, def)); err != nil {
+				return errors.Wrapf(err, errCreateNetwork, name)
+			}
+		}
+	}
+	return nil
+}
+
+// networkOptions converts a compose network definition to the options the
+// network is created with.
+func networkOptions(cr *composev1alpha1.ComposeStack, projectName string, def types.NetworkConfig) network.CreateOptions {
+	opts := network.CreateOptions{
+		Driver:     def.Driver,
+		Options:    def.DriverOpts,
+		Internal:   def.Internal,
+		Attachable: def.Attachable,
+		EnableIPv4: def.EnableIPv4,
+		EnableIPv6: def.EnableIPv6,
+		Labels:     stackLabels(cr, projectName, def.Labels),
+	}
+	if def.Ipam.Driver != "" || len(def.Ipam.Config) > 0 {
+		opts.IPAM = &network.IPAM{Driver: def.Ipam.Driver}
+		for _, pool := range def.Ipam.Config {
+			opts.IPAM.Config = append(opts.IPAM.Config, network.IPAMConfig{
+				Subnet:     pool.Subnet,
+				Gateway:    pool.Gateway,
+				IPRange:    pool.IPRange,
+				AuxAddress: pool.AuxiliaryAddresses,
+			})
+		}
+	}
+	return opts
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/network"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/compose"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"slices"
+	"testing"
+)
+
+// networkClient serves the networks that exist, and records those created.
+type networkClient struct {
+	mockDockerClient
+	existing map[string]bool
+	created  map[string]network.CreateOptions
+}
+
+func (c *networkClient) NetworkInspect(_ context.Context, name string, _ network.InspectOptions) (network.Inspect, error) {
+	if !c.existing[name] {
+		return network.Inspect{}, cerrdefs.ErrNotFound
+	}
+	return network.Inspect{Name: name}, nil
+}
+
+func (c *networkClient) NetworkCreate(_ context.Context, name string, options network.CreateOptions) (network.CreateResponse, error) {
+	c.created[name] = options
+	return network.CreateResponse{ID: name}, nil
+}
+
+func TestCreateNetworks(t *testing.T) {
+	content := `
+services:
+  web:
+    image: nginx
+    networks: [frontend, backend, shared]
+  db:
+    image: postgres
+    networks: [backend]
+  worker:
+    image: worker
+networks:
+  frontend:
+    labels:
+      team: web
+  backend:
+    internal: true
+  shared:
+    external: true
+`
+	result, err := compose.NewParser("shop", "", nil).ParseCompose(context.Background(), content)
+	if err != nil {
+		t.Fatalf("ParseCompose(...): %v", err)
+	}
+
+	client := &networkClient{existing: map[string]bool{"frontend": false}, created: map[string]network.CreateOptions{}}
+	c := &external{service: client}
+	cr := &composev1alpha1.ComposeStack{ObjectMeta: metav1.ObjectMeta{Name: "shop", UID: "abc"}}
+	cr.Spec.ForProvider.Labels = map[string]string{"team": "platform", "cost-center": "42"}
+
+	if err := c.createNetworks(context.Background(), cr, "shop", result.Project, result.Containers); err != nil {
+		t.Fatalf("createNetworks(...): %v", err)
+	}
+
+	var names []string
+	for name := range client.created {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if want := []string{"backend", "frontend"}; !slices.Equal(names, want) {
+		t.Fatalf("createNetworks(...) created %v, want %v", names, want)
+	}
+	if !client.created["backend"].Internal {
+		t.Error("createNetworks(...) did not create backend as an internal network")
+	}
+	got := client.created["frontend"].Labels
+	if got["team"] != "web" || got["cost-center"] != "42" || got[labels.StackUID] != "abc" || got[labels.ComposeProject] != "shop" {
+		t.Errorf("createNetworks(...) labelled frontend %v, want the network's labels over the stack's", got)
+	}
+
+	// Networks that exist are left alone
+	client.existing = map[string]bool{"frontend": true, "backend": true}
+	client.created = map[string]network.CreateOptions{}
+	if err := c.createNetworks(context.Background(), cr, "shop", result.Project, result.Containers); err != nil {
+		t.Fatalf("createNetworks(...): %v", err)
+	}
+	if len(client.created) != 0 {
+		t.Errorf("createNetworks(...) created %v, want existing networks reused", client.created)
+	}
+}
+
+func TestStackLabels(t *testing.T) {
+	cr := &composev1alpha1.ComposeStack{ObjectMeta: metav1.ObjectMeta{Name: "shop", UID: "abc"}}
+	cr.Spec.ForProvider.Labels = map[string]string{
+		"team":                "platform",
+		"env":                 "prod",
+		labels.ComposeProject: "other",
+	}
+
+	got := stackLabels(cr, "shop", map[string]string{"team": "payments", labels.StackUID: "forged"})
+	want := map[string]string{
+		"team":                "payments",
+		"env":                 "prod",
+		labels.ComposeProject: "shop",
+		labels.StackUID:       "abc",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("stackLabels(...) = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("stackLabels(...)[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/pkg/labels"
+)
+
+// disabledContainers returns the containers of a stack whose services are
+// defined, but not enabled by its active profiles.
+func disabledContainers(project *types.Project, owned []container.Summary) []container.Summary {
+	var disabled []container.Summary
+	for _, cont := range owned {
+		if _, ok := project.DisabledServices[cont.Labels[labels.ComposeService]]; ok {
+			disabled = append(disabled, cont)
+		}
+	}
+	return disabled
+}
+
+// removeDisabledServices stops and removes the containers of the services of
+// a stack that its active profiles no longer enable, giving each the stop
+// timeout it was created with.
+func (c *external) removeDisabledServices(ctx context.Context, project *types.Project, owned []container.Summary) error {
+	for _, cont := range disabledContainers(project, owned) {
+		if err := c.service.ContainerStop(ctx, cont.ID, container.StopOptions{}); err != nil {
+			return errors.Wrapf(err, "cannot stop container %s", cont.ID)
+		}
+		if err := c.service.ContainerRemove(ctx, cont.ID, container.RemoveOptions{Force: true}); err != nil {
+			return errors.Wrapf(err, "cannot remove container %s", cont.ID)
+		}
+		c.exporter.Forget(cont.ID)
+	}
+	return nil
+}
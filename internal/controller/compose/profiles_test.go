@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"maps"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"slices"
+	"testing"
+)
+
+func TestExternal_Profiles(t *testing.T) {
+	const content = `
+services:
+  web:
+    image: nginx:1.27
+  debug:
+    image: busybox:1.37
+    profiles: [debug]
+  metrics:
+    image: prom/node-exporter:1.8
+    profiles: [monitoring, debug]
+`
+
+	scheme := runtime.NewScheme()
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+	stored := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "uid"},
+	}
+	stored.Spec.ForProvider.Compose = stringPtr(content)
+	stored.Spec.ForProvider.Profiles = []string{"debug"}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithStatusSubresource(stored).Build()
+
+	dc := &hostClient{mockDockerClient: &mockDockerClient{}, host: map[string]container.InspectResponse{}}
+	ext := &external{kube: kube, service: dc}
+
+	cr := stored.DeepCopy()
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	want := []string{"stack_stack-debug_1", "stack_stack-metrics_1", "stack_stack-web_1"}
+	if got := slices.Sorted(maps.Keys(dc.host)); !slices.Equal(got, want) {
+		t.Fatalf("Create() with the debug profile created %v, want %v", got, want)
+	}
+
+	// Switching to another profile removes the services only the old one
+	// enabled, and leaves the others alone
+	cr.Spec.ForProvider.Profiles = []string{"monitoring"}
+	if _, err := ext.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if want := []string{"stack_stack-debug_1"}; !slices.Equal(dc.removed, want) {
+		t.Errorf("Update() removed %v, want %v", dc.removed, want)
+	}
+
+	// Without profiles only the services that list none remain
+	cr.Spec.ForProvider.Profiles = nil
+	if _, err := ext.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got, want := slices.Sorted(maps.Keys(dc.host)), []string{"stack_stack-web_1"}; !slices.Equal(got, want) {
+		t.Errorf("Update() without profiles left %v, want %v", got, want)
+	}
+
+	// Activating every profile creates their services again
+	dc.created = nil
+	cr.Spec.ForProvider.Profiles = []string{"*"}
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if want := []string{"stack_stack-debug_1", "stack_stack-metrics_1"}; !slices.Equal(slices.Sorted(slices.Values(dc.created)), want) {
+		t.Errorf("Create() with every profile created %v, want %v", dc.created, want)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recordPhase records that a service of a stack has entered a phase of
+// being brought up, and persists it straight away so that a slow bring-up
+// can be followed as it happens. Progress is best effort: a failure to
+// record it does not fail the bring-up.
+func (c *external) recordPhase(ctx context.Context, cr *composev1alpha1.ComposeStack, service string, phase composev1alpha1.ServicePhase, message string) {
+	now := metav1.Now()
+	_ = c.updateObservation(ctx, cr, func(obs *composev1alpha1.ComposeStackObservation) {
+		if obs.Services == nil {
+			obs.Services = map[string]composev1alpha1.ServiceStatus{}
+		}
+		status := obs.Services[service]
+		status.Name = service
+		if status.State == "" {
+			status.State = "creating"
+		}
+		setPhase(&status, phase, message, now)
+		obs.Services[service] = status
+	})
+}
+
+// setPhase moves a service to a phase at the given time. Moving to Pulling
+// or Creating from any phase but those starts a new bring-up, forgetting
+// the transitions of the last one.
+func setPhase(status *composev1alpha1.ServiceStatus, phase composev1alpha1.ServicePhase, message string, now metav1.Time) {
+	status.Message = message
+	if status.Phase == phase {
+		return
+	}
+	if bringingUp(phase) && !bringingUp(status.Phase) {
+		status.PhaseTransitions = nil
+	}
+	status.Phase = phase
+	status.PhaseTransitions = append(status.PhaseTransitions, composev1alpha1.ServicePhaseTransition{Phase: phase, Time: now})
+}
+
+// bringingUp reports whether a phase is the start of a bring-up.
+func bringingUp(phase composev1alpha1.ServicePhase) bool {
+	return phase == composev1alpha1.ServicePhasePulling || phase == composev1alpha1.ServicePhaseCreating
+}
+
+// observePhase sets the phase of an observed service, carrying on from its
+// previous status. state is nil if the service has no container, and
+// failure is why it has failed, if it has.
+func observePhase(status *composev1alpha1.ServiceStatus, previous composev1alpha1.ServiceStatus, state *container.State, failure string) {
+	status.Phase = previous.Phase
+	status.PhaseTransitions = previous.PhaseTransitions
+	status.Message = previous.Message
+
+	now := metav1.Now()
+	switch {
+	case failure != "":
+		setPhase(status, composev1alpha1.ServicePhaseFailed, failure, now)
+	case state != nil && state.Running:
+		setPhase(status, composev1alpha1.ServicePhaseRunning, "", now)
+	case state == nil && status.Phase != composev1alpha1.ServicePhaseFailed && !bringingUp(status.Phase):
+		// A service is pending until it is brought up, unless its last
+		// bring-up failed or is still going
+		setPhase(status, composev1alpha1.ServicePhasePending, "", now)
+	}
+}
+
+// serviceFailed records that bringing up a service failed with err, and
+// returns err.
+func (c *external) serviceFailed(ctx context.Context, cr *composev1alpha1.ComposeStack, service string, err error) error {
+	c.recordPhase(ctx, cr, service, composev1alpha1.ServicePhaseFailed, err.Error())
+	return err
+}
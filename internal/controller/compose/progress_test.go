@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"errors"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	specsv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pullingClient reports images as missing until they are pulled.
+type pullingClient struct {
+	*mockDockerClient
+	pulled []string
+}
+
+func (m *pullingClient) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specsv1.Platform, containerName string) (container.CreateResponse, error) {
+	if len(m.pulled) == 0 {
+		return container.CreateResponse{}, errors.New("No such image: " + config.Image)
+	}
+	return m.mockDockerClient.ContainerCreate(ctx, config, hostConfig, networkingConfig, platform, containerName)
+}
+
+func (m *pullingClient) ImagePull(ctx context.Context, refStr string, options image.PullOptions) (io.ReadCloser, error) {
+	m.pulled = append(m.pulled, refStr)
+	return io.NopCloser(strings.NewReader(`{"status":"Downloaded"}`)), nil
+}
+
+func TestCreateContainerRecordsPhases(t *testing.T) {
+	tests := []struct {
+		name       string
+		startError error
+		want       []composev1alpha1.ServicePhase
+		wantErr    bool
+	}{
+		{
+			name: "PulledAndStarted",
+			want: []composev1alpha1.ServicePhase{
+				composev1alpha1.ServicePhaseCreating,
+				composev1alpha1.ServicePhasePulling,
+				composev1alpha1.ServicePhaseCreating,
+				composev1alpha1.ServicePhaseStarting,
+			},
+		},
+		{
+			name:       "StartFailed",
+			startError: errors.New("port is already allocated"),
+			want: []composev1alpha1.ServicePhase{
+				composev1alpha1.ServicePhaseCreating,
+				composev1alpha1.ServicePhasePulling,
+				composev1alpha1.ServicePhaseCreating,
+				composev1alpha1.ServicePhaseStarting,
+				composev1alpha1.ServicePhaseFailed,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+			stored := &composev1alpha1.ComposeStack{
+				ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "uid"},
+			}
+			kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithStatusSubresource(stored).Build()
+
+			dc := &pullingClient{mockDockerClient: &mockDockerClient{
+				inspectError:        errors.New("No such container"),
+				containerCreateResp: container.CreateResponse{ID: "web123"},
+				startError:          tt.startError,
+			}}
+			ext := &external{kube: kube, service: dc}
+			cr := stored.DeepCopy()
+			cont := &containerv1alpha1.Container{
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec: containerv1alpha1.ContainerSpec{ForProvider: containerv1alpha1.ContainerParameters{
+					Image: "nginx:latest",
+				}},
+			}
+
+			err := ext.createContainer(context.Background(), cr, "stack", "", "", cont, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("createContainer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(dc.pulled) != 1 || dc.pulled[0] != "nginx:latest" {
+				t.Errorf("createContainer() pulled %v, want [nginx:latest]", dc.pulled)
+			}
+
+			got := &composev1alpha1.ComposeStack{}
+			if err := kube.Get(context.Background(), ktypes.NamespacedName{Namespace: "default", Name: "stack"}, got); err != nil {
+				t.Fatalf("Get(...): %v", err)
+			}
+			svc := got.Status.AtProvider.Services["web"]
+			var phases []composev1alpha1.ServicePhase
+			for _, tr := range svc.PhaseTransitions {
+				phases = append(phases, tr.Phase)
+			}
+			if !reflect.DeepEqual(phases, tt.want) {
+				t.Errorf("stored phase transitions = %v, want %v", phases, tt.want)
+			}
+			if svc.Phase != tt.want[len(tt.want)-1] {
+				t.Errorf("stored phase = %q, want %q", svc.Phase, tt.want[len(tt.want)-1])
+			}
+			if tt.wantErr && !strings.Contains(svc.Message, tt.startError.Error()) {
+				t.Errorf("stored message = %q, want it to contain %q", svc.Message, tt.startError)
+			}
+		})
+	}
+}
+
+func TestObservePhase(t *testing.T) {
+	started := metav1.NewTime(time.Now().Add(-time.Minute))
+	bringingUp := composev1alpha1.ServiceStatus{
+		Phase: composev1alpha1.ServicePhaseStarting,
+		PhaseTransitions: []composev1alpha1.ServicePhaseTransition{
+			{Phase: composev1alpha1.ServicePhaseCreating, Time: started},
+			{Phase: composev1alpha1.ServicePhaseStarting, Time: started},
+		},
+	}
+	failed := composev1alpha1.ServiceStatus{Phase: composev1alpha1.ServicePhaseFailed, Message: "no space left on device"}
+
+	tests := []struct {
+		name            string
+		previous        composev1alpha1.ServiceStatus
+		state           *container.State
+		failure         string
+		wantPhase       composev1alpha1.ServicePhase
+		wantTransitions int
+		wantMessage     string
+	}{
+		{
+			name:            "NotCreated",
+			wantPhase:       composev1alpha1.ServicePhasePending,
+			wantTransitions: 1,
+		},
+		{
+			name:            "Running",
+			previous:        bringingUp,
+			state:           &container.State{Status: "running", Running: true},
+			wantPhase:       composev1alpha1.ServicePhaseRunning,
+			wantTransitions: 3,
+		},
+		{
+			name:            "NotYetRunning",
+			previous:        bringingUp,
+			state:           &container.State{Status: "created"},
+			wantPhase:       composev1alpha1.ServicePhaseStarting,
+			wantTransitions: 2,
+		},
+		{
+			name:            "Died",
+			previous:        bringingUp,
+			state:           &container.State{Status: "exited", ExitCode: 1},
+			failure:         "exited with code 1",
+			wantPhase:       composev1alpha1.ServicePhaseFailed,
+			wantTransitions: 3,
+			wantMessage:     "exited with code 1",
+		},
+		{
+			name:        "CreateFailed",
+			previous:    failed,
+			wantPhase:   composev1alpha1.ServicePhaseFailed,
+			wantMessage: "no space left on device",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := composev1alpha1.ServiceStatus{Name: "web"}
+			observePhase(&status, tt.previous, tt.state, tt.failure)
+			if status.Phase != tt.wantPhase {
+				t.Errorf("observePhase() phase = %q, want %q", status.Phase, tt.wantPhase)
+			}
+			if len(status.PhaseTransitions) != tt.wantTransitions {
+				t.Errorf("observePhase() transitions = %v, want %d", status.PhaseTransitions, tt.wantTransitions)
+			}
+			if status.Message != tt.wantMessage {
+				t.Errorf("observePhase() message = %q, want %q", status.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"math"
+	"strings"
+)
+
+var (
+	// maxBytes bounds the amounts of memory Docker can be given, in bytes.
+	// Quantities at or above it are rejected when they are parsed.
+	maxBytes = resource.NewQuantity(math.MaxInt64, resource.BinarySI)
+
+	// maxCPUs is the largest number of CPUs whose billionths fit an int64.
+	maxCPUs = resource.NewQuantity(math.MaxInt64/1_000_000_000, resource.DecimalSI)
+)
+
+// parseMemory parses an amount of memory, either a number of bytes or a
+// quantity such as "512Mi" or "1G", into bytes.
+func parseMemory(memStr string) (int64, error) {
+	q, err := resource.ParseQuantity(strings.TrimSpace(memStr))
+	if err != nil {
+		return 0, errors.Errorf("invalid memory %q: must be a number of bytes or a quantity such as 512Mi", memStr)
+	}
+	if q.Sign() < 0 {
+		return 0, errors.Errorf("invalid memory %q: must not be negative", memStr)
+	}
+	if q.Cmp(*maxBytes) >= 0 {
+		return 0, errors.Errorf("invalid memory %q: must be less than 8Ei", memStr)
+	}
+	return q.Value(), nil
+}
+
+// parseCPU parses a number of CPUs, such as "1.5" or "500m", into billionths
+// of a CPU.
+func parseCPU(cpuStr string) (int64, error) {
+	q, err := resource.ParseQuantity(strings.TrimSpace(cpuStr))
+	if err != nil {
+		return 0, errors.Errorf("invalid CPU %q: must be a number of CPUs such as 1.5 or 500m", cpuStr)
+	}
+	if q.Sign() < 0 {
+		return 0, errors.Errorf("invalid CPU %q: must not be negative", cpuStr)
+	}
+	if q.Cmp(*maxCPUs) > 0 {
+		return 0, errors.Errorf("invalid CPU %q: must be at most %s", cpuStr, maxCPUs)
+	}
+	return q.ScaledValue(resource.Nano), nil
+}
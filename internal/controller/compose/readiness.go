@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/probe"
+	"maps"
+	"slices"
+)
+
+// dependenciesReady reports whether every service a service depends on
+// that has a readiness probe override passes it, and if not, why. Services
+// without a probe are not waited for here, nor are those it waits on to
+// complete.
+func (c *external) dependenciesReady(ctx context.Context, cr *composev1alpha1.ComposeStack, containerNames map[string]string, deps types.DependsOnConfig) (bool, string, error) {
+	for _, dep := range slices.Sorted(maps.Keys(deps)) {
+		override, ok := cr.Spec.ForProvider.ServiceOverrides[dep]
+		if !ok || override.ReadinessProbe == nil || deps[dep].Condition == types.ServiceConditionCompletedSuccessfully {
+			continue
+		}
+		info, err := c.service.ContainerInspect(ctx, containerNames[dep])
+		if err != nil || info.State == nil || info.State.Status != "running" {
+			return false, fmt.Sprintf("Waiting for service %s to be running", dep), nil
+		}
+		ready, msg, err := probe.Check(ctx, c.service, &info, override.ReadinessProbe)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "cannot probe readiness of service %s", dep)
+		}
+		if !ready {
+			return false, fmt.Sprintf("Waiting for service %s to be ready: %s", dep, msg), nil
+		}
+	}
+	return true, "", nil
+}
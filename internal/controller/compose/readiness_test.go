@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// probedClient is a Docker host whose readiness commands exit with a code.
+type probedClient struct {
+	*hostClient
+	exitCode int
+}
+
+func (m *probedClient) ContainerExecInspect(context.Context, string) (container.ExecInspect, error) {
+	return container.ExecInspect{ExitCode: m.exitCode}, nil
+}
+
+func TestExternal_CreateWaitsForReadyDependencies(t *testing.T) {
+	const content = `
+services:
+  db:
+    image: postgres:16
+  web:
+    image: nginx:1.27
+    depends_on: [db]
+`
+	scheme := runtime.NewScheme()
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+	stored := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "uid"},
+	}
+	stored.Spec.ForProvider.Compose = stringPtr(content)
+	stored.Spec.ForProvider.ServiceOverrides = map[string]composev1alpha1.ServiceOverride{
+		"db": {ReadinessProbe: &containerv1alpha1.ReadinessProbe{Exec: &containerv1alpha1.ExecProbe{Command: []string{"pg_isready"}}}},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithStatusSubresource(stored).Build()
+
+	dc := &probedClient{hostClient: &hostClient{mockDockerClient: &mockDockerClient{}, host: map[string]container.InspectResponse{}}, exitCode: 1}
+	ext := &external{kube: kube, service: dc}
+
+	cr := stored.DeepCopy()
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if want := []string{"stack_stack-db_1"}; !slices.Equal(dc.created, want) {
+		t.Fatalf("Create() created %v while db was not ready, want %v", dc.created, want)
+	}
+	if msg := cr.Status.AtProvider.Services["stack-web"].Message; !strings.Contains(msg, "db to be ready") {
+		t.Errorf("Create() recorded web as %q, want it waiting for db", msg)
+	}
+
+	dc.exitCode = 0
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if want := []string{"stack_stack-db_1", "stack_stack-web_1"}; !slices.Equal(dc.created, want) {
+		t.Errorf("Create() created %v once db was ready, want %v", dc.created, want)
+	}
+}
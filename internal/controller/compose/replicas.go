@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// replicaCount returns how many containers run a service of a stack: the
+// replicas its override sets, or one.
+func replicaCount(cr *composev1alpha1.ComposeStack, service string) int32 {
+	if override, ok := cr.Spec.ForProvider.ServiceOverrides[service]; ok && override.Replicas != nil && *override.Replicas > 1 {
+		return *override.Replicas
+	}
+	return 1
+}
+
+// scaled reports whether the override of a service sets its replicas.
+func scaled(cr *composev1alpha1.ComposeStack, service string) bool {
+	override, ok := cr.Spec.ForProvider.ServiceOverrides[service]
+	return ok && override.Replicas != nil
+}
+
+// replicaNumber returns which replica of its service a container is. A
+// container created before services could be scaled is the first.
+func replicaNumber(l map[string]string) int32 {
+	n, err := strconv.ParseInt(l[labels.ComposeContainerNumber], 10, 32)
+	if err != nil || n < 1 {
+		return 1
+	}
+	return int32(n)
+}
+
+// replicaName returns the name of a replica of a service, as docker compose
+// names it.
+func replicaName(projectName, service string, n int32) string {
+	return fmt.Sprintf("%s_%s_%d", projectName, service, n)
+}
+
+// replicaContainers returns the IDs of the containers of a stack by service
+// and replica number.
+func replicaContainers(owned []container.Summary) map[string]map[int32]string {
+	replicas := make(map[string]map[int32]string)
+	for _, cont := range owned {
+		svc := cont.Labels[labels.ComposeService]
+		if svc == "" {
+			continue
+		}
+		if replicas[svc] == nil {
+			replicas[svc] = make(map[int32]string)
+		}
+		replicas[svc][replicaNumber(cont.Labels)] = cont.ID
+	}
+	return replicas
+}
+
+// surplusReplicas returns the containers of a stack's services numbered
+// beyond the replicas they run, highest numbered first.
+func surplusReplicas(cr *composev1alpha1.ComposeStack, project *types.Project, owned []container.Summary) []container.Summary {
+	var surplus []container.Summary
+	for _, cont := range owned {
+		svc := cont.Labels[labels.ComposeService]
+		if _, ok := project.Services[svc]; !ok {
+			continue
+		}
+		if replicaNumber(cont.Labels) > replicaCount(cr, svc) {
+			surplus = append(surplus, cont)
+		}
+	}
+	slices.SortFunc(surplus, func(a, b container.Summary) int {
+		return cmp.Compare(replicaNumber(b.Labels), replicaNumber(a.Labels))
+	})
+	return surplus
+}
+
+// removeSurplusReplicas stops and removes the replicas of the services of a
+// stack that it has been scaled down from, highest numbered first, giving
+// each the stop timeout it was created with.
+func (c *external) removeSurplusReplicas(ctx context.Context, cr *composev1alpha1.ComposeStack, project *types.Project, owned []container.Summary) error {
+	for _, cont := range surplusReplicas(cr, project, owned) {
+		if err := c.service.ContainerStop(ctx, cont.ID, container.StopOptions{}); err != nil {
+			return errors.Wrapf(err, "cannot stop container %s", cont.ID)
+		}
+		if err := c.service.ContainerRemove(ctx, cont.ID, container.RemoveOptions{Force: true}); err != nil {
+			return errors.Wrapf(err, "cannot remove container %s", cont.ID)
+		}
+		c.exporter.Forget(cont.ID)
+	}
+	return nil
+}
+
+// A replicaObservation is what observing the replicas of a service beyond
+// its first found.
+type replicaObservation struct {
+	// missing is whether any replica has yet to be created.
+	missing bool
+
+	// drifted is whether any replica no longer matches its service.
+	drifted bool
+
+	// notReady is whether any replica is neither running nor, for a
+	// service others wait on to complete, completed.
+	notReady bool
+
+	// health is the health check status of each replica observed.
+	health []container.HealthStatus
+}
+
+// observeReplicas observes the replicas of a service beyond its first, whose
+// state is supplied if its container exists. A service whose override sets
+// its replicas has the status of each, and how many are ready, recorded
+// alongside that of its first.
+func (c *external) observeReplicas(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName, model, hash string, cont *containerv1alpha1.Container, ids map[int32]string, first *container.State, oneShot bool, status *composev1alpha1.ServiceStatus) (replicaObservation, error) {
+	name := serviceName(cont)
+	count := replicaCount(cr, name)
+	ready := func(state *container.State) bool {
+		return state != nil && (state.Status == "running" || oneShot && completedSuccessfully(state))
+	}
+
+	var obs replicaObservation
+	statuses := []composev1alpha1.ReplicaStatus{{Number: 1, ContainerID: status.ContainerID, State: status.State, StartedAt: status.StartedAt}}
+	readyReplicas := int32(0)
+	if ready(first) {
+		readyReplicas++
+	}
+	for n := int32(2); n <= count; n++ {
+		replica := composev1alpha1.ReplicaStatus{Number: n, State: "pending"}
+		id, ok := ids[n]
+		if !ok {
+			obs.missing = true
+			statuses = append(statuses, replica)
+			continue
+		}
+		info, err := c.service.ContainerInspect(ctx, id)
+		if err != nil {
+			obs.missing = true
+			statuses = append(statuses, replica)
+			continue
+		}
+
+		replica.ContainerID = &info.ID
+		replica.State = "unknown"
+		if info.State != nil {
+			replica.State = info.State.Status
+			if startedAt, err := time.Parse(time.RFC3339Nano, info.State.StartedAt); err == nil && info.State.StartedAt != "" {
+				replica.StartedAt = &metav1.Time{Time: startedAt}
+			}
+		}
+		statuses = append(statuses, replica)
+
+		drifted, err := c.serviceDrifted(ctx, cr, projectName, model, hash, cont, info)
+		if err != nil {
+			return replicaObservation{}, err
+		}
+		obs.drifted = obs.drifted || drifted
+		obs.health = append(obs.health, healthOf(info.State))
+		if ready(info.State) {
+			readyReplicas++
+		} else {
+			obs.notReady = true
+		}
+	}
+
+	if scaled(cr, name) {
+		status.Replicas = &count
+		status.ReadyReplicas = &readyReplicas
+		status.ReplicaStatuses = statuses
+	}
+	return obs, nil
+}
+
+// convergeReplicas converges the replicas of a service beyond its first, as
+// Update converges its first: recreating those that have drifted, unless
+// their drift is deferred, and starting those that have stopped. Replicas
+// that are missing are left for Create.
+func (c *external) convergeReplicas(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName, model, hash string, cont *containerv1alpha1.Container, ids map[int32]string, deferred, oneShot bool) error {
+	for n := int32(2); n <= replicaCount(cr, serviceName(cont)); n++ {
+		id, ok := ids[n]
+		if !ok {
+			continue
+		}
+		info, err := c.service.ContainerInspect(ctx, id)
+		if err != nil {
+			continue
+		}
+		drifted, err := c.serviceDrifted(ctx, cr, projectName, model, hash, cont, info)
+		if err != nil {
+			return err
+		}
+		switch {
+		case drifted && !deferred:
+			err = c.recreateContainer(ctx, cr, projectName, model, hash, cont, n, info)
+		case !oneShot && isStopped(info.State):
+			err = c.startContainer(ctx, cr, cont, info.ID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"maps"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"slices"
+	"testing"
+)
+
+func TestExternal_Replicas(t *testing.T) {
+	const content = `
+services:
+  web:
+    image: nginx:1.27
+  db:
+    image: postgres:16
+`
+
+	scheme := runtime.NewScheme()
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+	stored := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "uid"},
+	}
+	stored.Spec.ForProvider.Compose = stringPtr(content)
+	stored.Spec.ForProvider.ServiceOverrides = map[string]composev1alpha1.ServiceOverride{"web": {Replicas: ptr.To[int32](3)}}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithStatusSubresource(stored).Build()
+
+	dc := &hostClient{mockDockerClient: &mockDockerClient{}, host: map[string]container.InspectResponse{}}
+	ext := &external{kube: kube, service: dc}
+
+	cr := stored.DeepCopy()
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	want := []string{"stack_stack-db_1", "stack_stack-web_1", "stack_stack-web_2", "stack_stack-web_3"}
+	if got := slices.Sorted(maps.Keys(dc.host)); !slices.Equal(got, want) {
+		t.Fatalf("Create() with 3 replicas created %v, want %v", got, want)
+	}
+	if got := dc.host["stack_stack-web_2"].Config.Labels[labels.ComposeContainerNumber]; got != "2" {
+		t.Errorf("Create() labelled the second replica %q, want 2", got)
+	}
+
+	obs, err := ext.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	if !obs.ResourceExists || !obs.ResourceUpToDate {
+		t.Errorf("Observe() of a scaled stack = %+v, want it to exist and be up to date", obs)
+	}
+	web := cr.Status.AtProvider.Services["stack-web"]
+	if web.ReadyReplicas == nil || *web.ReadyReplicas != 3 || len(web.ReplicaStatuses) != 3 || web.ReplicaStatuses[2].State != "running" {
+		t.Errorf("Observe() status of the scaled service = %+v, want 3 running replicas", web)
+	}
+	if db := cr.Status.AtProvider.Services["stack-db"]; db.Replicas != nil || db.ReplicaStatuses != nil {
+		t.Errorf("Observe() status of an unscaled service = %+v, want no replicas", db)
+	}
+
+	// Scaling down removes the highest numbered replicas, and recreates
+	// none of the others
+	dc.created = nil
+	cr.Spec.ForProvider.ServiceOverrides["web"] = composev1alpha1.ServiceOverride{Replicas: ptr.To[int32](1)}
+	if obs, err := ext.Observe(context.Background(), cr); err != nil || obs.ResourceUpToDate {
+		t.Fatalf("Observe() after scaling down = %+v, %v, want it out of date", obs, err)
+	}
+	if _, err := ext.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if want := []string{"stack_stack-web_3", "stack_stack-web_2"}; !slices.Equal(dc.removed, want) {
+		t.Errorf("Update() removed %v, want %v", dc.removed, want)
+	}
+	if len(dc.created) > 0 {
+		t.Errorf("Update() after scaling down recreated %v, want none", dc.created)
+	}
+
+	// Scaling up creates the missing replicas
+	cr.Spec.ForProvider.ServiceOverrides["web"] = composev1alpha1.ServiceOverride{Replicas: ptr.To[int32](2)}
+	if obs, err := ext.Observe(context.Background(), cr); err != nil || obs.ResourceExists {
+		t.Fatalf("Observe() after scaling up = %+v, %v, want it missing", obs, err)
+	}
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if want := []string{"stack_stack-web_2"}; !slices.Equal(dc.created, want) {
+		t.Errorf("Create() after scaling up created %v, want %v", dc.created, want)
+	}
+}
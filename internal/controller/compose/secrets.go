@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	dockerclients "github.com/rossigee/provider-docker/internal/clients"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"maps"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Keys of the connection details a service publishes.
+const (
+	connectionEndpointKey = "endpoint"
+	connectionPortKey     = "port"
+)
+
+// publishConnectionSecrets writes the connection details of each observed
+// service that asks for them to its own Secret.
+func (c *external) publishConnectionSecrets(ctx context.Context, cr *composev1alpha1.ComposeStack, containers []containerv1alpha1.Container, observed map[string]container.InspectResponse) error {
+	var dockerHost *string
+	for i := range containers {
+		override, ok := cr.Spec.ForProvider.ServiceOverrides[serviceName(&containers[i])]
+		if !ok || override.WriteConnectionSecretTo == nil {
+			continue
+		}
+		info, ok := observed[containers[i].Name]
+		if !ok {
+			continue
+		}
+
+		// The Docker host is only looked up for a service published on all
+		// of its interfaces
+		details := connectionDetails(info, override.WriteConnectionSecretTo)
+		if details[connectionEndpointKey] == "" {
+			if dockerHost == nil {
+				host, err := c.dockerHost(ctx, cr)
+				if err != nil {
+					return err
+				}
+				dockerHost = &host
+			}
+			details[connectionEndpointKey] = *dockerHost
+		}
+
+		if err := c.applySecret(ctx, cr, override.WriteConnectionSecretTo.Name, details); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// connectionDetails returns the connection details of a service from the
+// inspected state of its container. A service whose port is published on all
+// interfaces of the Docker host has an empty endpoint, for the caller to fill
+// in. A service whose port is not published is reached by the name of its
+// container, on the networks it shares with it.
+func connectionDetails(info container.InspectResponse, secret *composev1alpha1.ServiceConnectionSecret) map[string]string {
+	var env []string
+	if info.Config != nil {
+		env = info.Config.Env
+	}
+	lookup := make(map[string]string, len(env))
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		lookup[name] = value
+	}
+
+	details := make(map[string]string, len(secret.Values)+2)
+	for key, value := range secret.Values {
+		details[key] = os.Expand(value, func(name string) string { return lookup[name] })
+	}
+
+	var ports nat.PortMap
+	if info.NetworkSettings != nil {
+		ports = info.NetworkSettings.Ports
+	}
+	if binding, ok := publishedBinding(ports, secret.Port); ok {
+		details[connectionPortKey] = binding.HostPort
+		details[connectionEndpointKey] = ""
+		if ip := net.ParseIP(binding.HostIP); ip != nil && !ip.IsUnspecified() {
+			details[connectionEndpointKey] = binding.HostIP
+		}
+		return details
+	}
+
+	details[connectionEndpointKey] = strings.TrimPrefix(info.Name, "/")
+	if secret.Port != nil {
+		details[connectionPortKey] = strconv.Itoa(int(*secret.Port))
+	}
+	return details
+}
+
+// publishedBinding returns the host binding of the container port, or of the
+// lowest published container port if port is nil.
+func publishedBinding(ports nat.PortMap, port *int32) (nat.PortBinding, bool) {
+	for _, p := range slices.Sorted(maps.Keys(ports)) {
+		if port != nil && p.Int() != int(*port) {
+			continue
+		}
+		for _, binding := range ports[p] {
+			if binding.HostPort != "" {
+				return binding, true
+			}
+		}
+	}
+	return nat.PortBinding{}, false
+}
+
+// dockerHost returns the host name of the Docker host of the stack, for
+// services published on all of its interfaces. A Docker host reached over a
+// socket is the host the provider runs on.
+func (c *external) dockerHost(ctx context.Context, cr *composev1alpha1.ComposeStack) (string, error) {
+	pc, err := dockerclients.GetProviderConfig(ctx, c.kube, cr)
+	if err != nil {
+		return "", err
+	}
+	if pc.Spec.Host != nil && strings.HasPrefix(*pc.Spec.Host, "tcp://") {
+		if u, err := url.Parse(*pc.Spec.Host); err == nil && u.Hostname() != "" {
+			return u.Hostname(), nil
+		}
+	}
+	return "localhost", nil
+}
+
+// applySecret creates the named connection Secret in the namespace of the
+// stack, controlled by the stack, or replaces its data if the stack controls
+// it. A Secret the stack does not control is left alone.
+func (c *external) applySecret(ctx context.Context, cr *composev1alpha1.ComposeStack, name string, details map[string]string) error {
+	data := make(map[string][]byte, len(details))
+	for key, value := range details {
+		data[key] = []byte(value)
+	}
+
+	s := &v1.Secret{}
+	err := c.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: name}, s)
+	if kerrors.IsNotFound(err) {
+		s = &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cr.GetNamespace(),
+				Name:      name,
+			},
+			Type: resource.SecretTypeConnection,
+			Data: data,
+		}
+		meta.AddOwnerReference(s, meta.AsController(meta.TypedReferenceTo(cr, composev1alpha1.ComposeStackGroupVersionKind)))
+		return c.kube.Create(ctx, s)
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(s, cr) {
+		return errors.Errorf(errSecretNotOwned, s.Namespace, s.Name)
+	}
+
+	if maps.EqualFunc(s.Data, data, func(a, b []byte) bool { return string(a) == string(b) }) {
+		return nil
+	}
+	s.Data = data
+	return c.kube.Update(ctx, s)
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/google/go-cmp/cmp"
+	"github.com/rossigee/provider-docker/apis"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
+	"testing"
+)
+
+func TestConnectionDetails(t *testing.T) {
+	inspect := func(ports nat.PortMap) container.InspectResponse {
+		return container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{Name: "/stack-db"},
+			Config:            &container.Config{Env: []string{"POSTGRES_USER=app", "POSTGRES_PASSWORD=hunter2"}},
+			NetworkSettings:   &container.NetworkSettings{NetworkSettingsBase: container.NetworkSettingsBase{Ports: ports}},
+		}
+	}
+	values := map[string]string{"username": "${POSTGRES_USER}", "password": "$POSTGRES_PASSWORD", "url": "postgres://${POSTGRES_USER}@db/${MISSING}"}
+
+	tests := map[string]struct {
+		info   container.InspectResponse
+		secret composev1alpha1.ServiceConnectionSecret
+		want   map[string]string
+	}{
+		"PublishedOnAddress": {
+			info:   inspect(nat.PortMap{"5432/tcp": {{HostIP: "192.0.2.10", HostPort: "15432"}}}),
+			secret: composev1alpha1.ServiceConnectionSecret{Values: values},
+			want:   map[string]string{"endpoint": "192.0.2.10", "port": "15432", "username": "app", "password": "hunter2", "url": "postgres://app@db/"},
+		},
+		"PublishedOnAllInterfaces": {
+			info:   inspect(nat.PortMap{"5432/tcp": {{HostIP: "0.0.0.0", HostPort: "15432"}}}),
+			secret: composev1alpha1.ServiceConnectionSecret{},
+			want:   map[string]string{"endpoint": "", "port": "15432"},
+		},
+		"SelectedPort": {
+			info: inspect(nat.PortMap{
+				"8080/tcp": {{HostIP: "192.0.2.10", HostPort: "18080"}},
+				"9090/tcp": {{HostIP: "192.0.2.10", HostPort: "19090"}},
+			}),
+			secret: composev1alpha1.ServiceConnectionSecret{Port: int32Ptr(9090)},
+			want:   map[string]string{"endpoint": "192.0.2.10", "port": "19090"},
+		},
+		"LowestPort": {
+			info: inspect(nat.PortMap{
+				"9090/tcp": {{HostIP: "192.0.2.10", HostPort: "19090"}},
+				"8080/tcp": {{HostIP: "192.0.2.10", HostPort: "18080"}},
+			}),
+			secret: composev1alpha1.ServiceConnectionSecret{},
+			want:   map[string]string{"endpoint": "192.0.2.10", "port": "18080"},
+		},
+		"NotPublished": {
+			info:   inspect(nat.PortMap{"5432/tcp": nil}),
+			secret: composev1alpha1.ServiceConnectionSecret{Port: int32Ptr(5432)},
+			want:   map[string]string{"endpoint": "stack-db", "port": "5432"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, connectionDetails(tt.info, &tt.secret)); diff != "" {
+				t.Errorf("connectionDetails(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPublishConnectionSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = apis.AddToScheme(scheme)
+
+	cr := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "1234"},
+		Spec: composev1alpha1.ComposeStackSpec{
+			ManagedResourceSpec: xpv1.ManagedResourceSpec{ProviderConfigReference: &xpv1.ProviderConfigReference{Name: "remote"}},
+			ForProvider: composev1alpha1.ComposeStackParameters{
+				ServiceOverrides: map[string]composev1alpha1.ServiceOverride{
+					"db":    {WriteConnectionSecretTo: &composev1alpha1.ServiceConnectionSecret{Name: "db-conn", Values: map[string]string{"password": "${PASSWORD}"}}},
+					"cache": {WriteConnectionSecretTo: &composev1alpha1.ServiceConnectionSecret{Name: "cache-conn"}},
+				},
+			},
+		},
+	}
+	pc := &v1beta1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote"},
+		Spec:       v1beta1.ProviderConfigSpec{Host: stringPtr("tcp://docker.example.com:2376")},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pc).Build()
+	ext := &external{kube: kube}
+
+	containers := []containerv1alpha1.Container{
+		{ObjectMeta: metav1.ObjectMeta{Name: "db"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cache"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web"}},
+	}
+	observed := map[string]container.InspectResponse{
+		"db": {
+			ContainerJSONBase: &container.ContainerJSONBase{Name: "/stack-db"},
+			Config:            &container.Config{Env: []string{"PASSWORD=hunter2"}},
+			NetworkSettings:   &container.NetworkSettings{NetworkSettingsBase: container.NetworkSettingsBase{Ports: nat.PortMap{"5432/tcp": {{HostIP: "0.0.0.0", HostPort: "15432"}}}}},
+		},
+		"web": {ContainerJSONBase: &container.ContainerJSONBase{Name: "/stack-web"}},
+	}
+
+	if err := ext.publishConnectionSecrets(context.Background(), cr, containers, observed); err != nil {
+		t.Fatalf("publishConnectionSecrets(...): %v", err)
+	}
+
+	s := &corev1.Secret{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "db-conn"}, s); err != nil {
+		t.Fatalf("cannot get connection Secret: %v", err)
+	}
+	want := map[string][]byte{"endpoint": []byte("docker.example.com"), "port": []byte("15432"), "password": []byte("hunter2")}
+	if diff := cmp.Diff(want, s.Data); diff != "" {
+		t.Errorf("connection details: -want, +got:\n%s", diff)
+	}
+	if s.Type != resource.SecretTypeConnection {
+		t.Errorf("Secret type = %q, want %q", s.Type, resource.SecretTypeConnection)
+	}
+	if !metav1.IsControlledBy(s, cr) {
+		t.Errorf("connection Secret owners = %+v, want the stack to control it", s.GetOwnerReferences())
+	}
+
+	// A service whose container is not observed publishes nothing yet.
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "cache-conn"}, &corev1.Secret{}); err == nil {
+		t.Errorf("connection Secret of an unobserved service was published")
+	}
+
+	// Changed details replace those published before.
+	observed["db"].Config.Env = []string{"PASSWORD=correct-horse"}
+	if err := ext.publishConnectionSecrets(context.Background(), cr, containers, observed); err != nil {
+		t.Fatalf("publishConnectionSecrets(...): %v", err)
+	}
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "db-conn"}, s); err != nil {
+		t.Fatalf("cannot get connection Secret: %v", err)
+	}
+	if got := string(s.Data["password"]); got != "correct-horse" {
+		t.Errorf("republished password = %q, want %q", got, "correct-horse")
+	}
+}
+
+func TestPublishConnectionSecretsNotOwned(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = apis.AddToScheme(scheme)
+
+	cr := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "1234"},
+		Spec: composev1alpha1.ComposeStackSpec{
+			ForProvider: composev1alpha1.ComposeStackParameters{
+				ServiceOverrides: map[string]composev1alpha1.ServiceOverride{
+					"db": {WriteConnectionSecretTo: &composev1alpha1.ServiceConnectionSecret{Name: "db-conn"}},
+				},
+			},
+		},
+	}
+	// A Secret the stack does not control is not overwritten
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-conn", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+	ext := &external{kube: kube}
+
+	containers := []containerv1alpha1.Container{{ObjectMeta: metav1.ObjectMeta{Name: "db"}}}
+	observed := map[string]container.InspectResponse{
+		"db": {
+			ContainerJSONBase: &container.ContainerJSONBase{Name: "/stack-db"},
+			NetworkSettings:   &container.NetworkSettings{NetworkSettingsBase: container.NetworkSettingsBase{Ports: nat.PortMap{"5432/tcp": {{HostIP: "10.0.0.1", HostPort: "15432"}}}}},
+		},
+	}
+
+	err := ext.publishConnectionSecrets(context.Background(), cr, containers, observed)
+	if err == nil || !strings.Contains(err.Error(), "not controlled by this stack") {
+		t.Fatalf("publishConnectionSecrets(...): %v, want the Secret not to be controlled by the stack", err)
+	}
+	s := &corev1.Secret{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "db-conn"}, s); err != nil {
+		t.Fatalf("cannot get Secret: %v", err)
+	}
+	if diff := cmp.Diff(existing.Data, s.Data); diff != "" {
+		t.Errorf("Secret data: -want, +got:\n%s", diff)
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const errPatchStatus = "cannot patch ComposeStack status"
+
+// updateObservation applies observe to the status of a stack, and persists
+// the change straight away as a merge patch of the latest stored stack. A
+// patch only touches the service entries that changed, and a conflicting
+// write is retried against the newer stack, so entries written concurrently
+// are not lost when the reconciler later updates the stack's conditions.
+// A stack that is not stored is left for the reconciler to persist.
+func (c *external) updateObservation(ctx context.Context, cr *composev1alpha1.ComposeStack, observe func(*composev1alpha1.ComposeStackObservation)) error {
+	observe(&cr.Status.AtProvider)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &composev1alpha1.ComposeStack{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}, latest); err != nil {
+			return err
+		}
+		if latest.GetUID() != cr.GetUID() {
+			return nil
+		}
+
+		orig := latest.DeepCopy()
+		observe(&latest.Status.AtProvider)
+		if err := c.kube.Status().Patch(ctx, latest, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})); err != nil {
+			return err
+		}
+
+		// Carry on from the stored stack, so that the reconciler's own
+		// update does not conflict with this one. A stack whose spec or
+		// metadata changed since it was read keeps its resource version, so
+		// that its update conflicts rather than reverts them.
+		if sameSpecAndMetadata(cr, orig) {
+			cr.SetResourceVersion(latest.GetResourceVersion())
+		}
+		cr.Status.AtProvider = latest.Status.AtProvider
+		return nil
+	})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errPatchStatus)
+}
+
+// sameSpecAndMetadata reports whether two copies of a stack have the same
+// spec, and the same metadata other than their resource version.
+func sameSpecAndMetadata(a, b *composev1alpha1.ComposeStack) bool {
+	return equality.Semantic.DeepEqual(a.Spec, b.Spec) &&
+		equality.Semantic.DeepEqual(a.GetLabels(), b.GetLabels()) &&
+		equality.Semantic.DeepEqual(a.GetAnnotations(), b.GetAnnotations()) &&
+		equality.Semantic.DeepEqual(a.GetFinalizers(), b.GetFinalizers()) &&
+		equality.Semantic.DeepEqual(a.GetOwnerReferences(), b.GetOwnerReferences()) &&
+		equality.Semantic.DeepEqual(a.GetDeletionTimestamp(), b.GetDeletionTimestamp())
+}
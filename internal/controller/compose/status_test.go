@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"testing"
+)
+
+func TestUpdateObservation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	stored := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "uid"},
+	}
+	stored.Status.AtProvider.Services = map[string]composev1alpha1.ServiceStatus{
+		"web": {Name: "web", State: "pending"},
+		"old": {Name: "old", State: "exited"},
+	}
+
+	// The first status patch conflicts with a concurrent write
+	conflicts := 0
+	kube := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(stored).
+		WithStatusSubresource(stored).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				if conflicts == 0 {
+					conflicts++
+					return kerrors.NewConflict(schema.GroupResource{Resource: "composestacks"}, obj.GetName(), nil)
+				}
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	// The stack being reconciled was read before the concurrent write
+	cr := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "uid", ResourceVersion: "1"},
+	}
+	ext := &external{kube: kube}
+
+	err := ext.updateObservation(context.Background(), cr, func(obs *composev1alpha1.ComposeStackObservation) {
+		obs.ProjectName = "stack"
+		obs.Services = map[string]composev1alpha1.ServiceStatus{
+			"web": {Name: "web", State: "running"},
+		}
+	})
+	if err != nil {
+		t.Fatalf("updateObservation(...): %v", err)
+	}
+	if conflicts != 1 {
+		t.Errorf("conflicts = %d, want 1", conflicts)
+	}
+
+	got := &composev1alpha1.ComposeStack{}
+	if err := kube.Get(context.Background(), ktypes.NamespacedName{Namespace: "default", Name: "stack"}, got); err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+	if s := got.Status.AtProvider.Services; len(s) != 1 || s["web"].State != "running" {
+		t.Errorf("stored Services = %+v, want only web running", s)
+	}
+	if got.Status.AtProvider.ProjectName != "stack" {
+		t.Errorf("stored ProjectName = %q, want %q", got.Status.AtProvider.ProjectName, "stack")
+	}
+	if cr.GetResourceVersion() != got.GetResourceVersion() {
+		t.Errorf("ResourceVersion = %q, want stored %q", cr.GetResourceVersion(), got.GetResourceVersion())
+	}
+}
+
+func TestUpdateObservationSpecChanged(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	// The spec of the stack changed after it was read
+	stored := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "uid"},
+	}
+	stored.Spec.ForProvider.Compose = stringPtr("services: {web: {image: nginx:1.27}}")
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithStatusSubresource(stored).Build()
+
+	cr := stored.DeepCopy()
+	cr.SetResourceVersion("1")
+	cr.Spec.ForProvider.Compose = stringPtr("services: {web: {image: nginx:1.25}}")
+	ext := &external{kube: kube}
+
+	err := ext.updateObservation(context.Background(), cr, func(obs *composev1alpha1.ComposeStackObservation) {
+		obs.ProjectName = "stack"
+	})
+	if err != nil {
+		t.Fatalf("updateObservation(...): %v", err)
+	}
+
+	got := &composev1alpha1.ComposeStack{}
+	if err := kube.Get(context.Background(), ktypes.NamespacedName{Namespace: "default", Name: "stack"}, got); err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+	if got.Status.AtProvider.ProjectName != "stack" {
+		t.Errorf("stored ProjectName = %q, want %q", got.Status.AtProvider.ProjectName, "stack")
+	}
+	// Updating the stack must conflict, rather than revert the spec
+	if cr.GetResourceVersion() != "1" {
+		t.Errorf("ResourceVersion = %q, want 1 as read, since the stored spec differs", cr.GetResourceVersion())
+	}
+	if err := kube.Update(context.Background(), cr); !kerrors.IsConflict(err) {
+		t.Errorf("Update(...): %v, want a conflict", err)
+	}
+}
+
+func TestUpdateObservationNotStored(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	cr := &composev1alpha1.ComposeStack{ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default"}}
+	ext := &external{kube: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	err := ext.updateObservation(context.Background(), cr, func(obs *composev1alpha1.ComposeStackObservation) {
+		obs.ProjectName = "stack"
+	})
+	if err != nil {
+		t.Fatalf("updateObservation(...): %v", err)
+	}
+	if cr.Status.AtProvider.ProjectName != "stack" {
+		t.Errorf("ProjectName = %q, want %q", cr.Status.AtProvider.ProjectName, "stack")
+	}
+}
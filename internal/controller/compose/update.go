@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	dockerclients "github.com/rossigee/provider-docker/internal/clients"
+	"strings"
+)
+
+// recreateContainer replaces a replica of a service that has drifted with
+// one created from the service as it is now. A missing image, or one
+// only on the host for another platform than the service's, is pulled
+// before the old container is stopped, so that the service is only down
+// while its container is replaced.
+func (c *external) recreateContainer(ctx context.Context, cr *composev1alpha1.ComposeStack, projectName, model, service string, cont *containerv1alpha1.Container, replica int32, old container.InspectResponse) error {
+	_, _, _, platform, err := c.buildContainer(ctx, cr, projectName, cont)
+	if err != nil {
+		return c.serviceFailed(ctx, cr, cont.Name, err)
+	}
+	image := cont.Spec.ForProvider.Image
+	if img, _, err := c.service.ImageInspectWithRaw(ctx, image); dockerclients.IsNoSuchImage(err) || (err == nil && !ofPlatform(img, platform)) {
+		c.recordPhase(ctx, cr, cont.Name, composev1alpha1.ServicePhasePulling, "")
+		if err := dockerclients.PullImage(ctx, c.service, image, dockerclients.FormatPlatform(platform), c.registryAuths, nil); err != nil {
+			return c.serviceFailed(ctx, cr, cont.Name, err)
+		}
+	}
+
+	// The old container is given the stop timeout it was created with
+	if err := c.service.ContainerStop(ctx, old.ID, container.StopOptions{}); err != nil {
+		return errors.Wrapf(err, "cannot stop container %s", old.ID)
+	}
+	if err := c.service.ContainerRemove(ctx, old.ID, container.RemoveOptions{Force: true}); err != nil {
+		return errors.Wrapf(err, "cannot remove container %s", old.ID)
+	}
+	return c.createContainer(ctx, cr, projectName, model, service, cont, replica)
+}
+
+// ofPlatform reports whether an image is of a platform. Every image is of the
+// platform of the Docker host, which is nil.
+func ofPlatform(img image.InspectResponse, platform *specs.Platform) bool {
+	if platform == nil {
+		return true
+	}
+	return img.Os == platform.OS && img.Architecture == platform.Architecture &&
+		(platform.Variant == "" || img.Variant == platform.Variant)
+}
+
+// startContainer starts the stopped container of a service that has not
+// drifted.
+func (c *external) startContainer(ctx context.Context, cr *composev1alpha1.ComposeStack, cont *containerv1alpha1.Container, id string) error {
+	c.recordPhase(ctx, cr, cont.Name, composev1alpha1.ServicePhaseStarting, "")
+	if err := c.service.ContainerStart(ctx, id, container.StartOptions{}); err != nil {
+		return c.serviceFailed(ctx, cr, cont.Name, errors.Wrapf(err, "failed to start container %s", id))
+	}
+	return nil
+}
+
+// restartContainer restarts the running container of a service that depends
+// on one that was recreated.
+func (c *external) restartContainer(ctx context.Context, cr *composev1alpha1.ComposeStack, cont *containerv1alpha1.Container, id string) error {
+	c.recordPhase(ctx, cr, cont.Name, composev1alpha1.ServicePhaseStarting, "")
+	if err := c.service.ContainerRestart(ctx, id, container.StopOptions{}); err != nil {
+		return c.serviceFailed(ctx, cr, cont.Name, errors.Wrapf(err, "failed to restart container %s", id))
+	}
+	return nil
+}
+
+// dependsOnAny reports whether any of the dependencies of a service is in
+// services.
+func dependsOnAny(dependsOn types.DependsOnConfig, services map[string]bool) bool {
+	for dep := range dependsOn {
+		if services[dep] {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedNetworkService returns the service whose network a container shares
+// through a service:<name> network mode, if any.
+func sharedNetworkService(cont *containerv1alpha1.Container) string {
+	if cont.Spec.ForProvider.NetworkMode == nil {
+		return ""
+	}
+	if service, ok := strings.CutPrefix(*cont.Spec.ForProvider.NetworkMode, servicePrefix); ok {
+		return service
+	}
+	return ""
+}
+
+// isStopped reports whether a container has been created or has exited
+// without being restarted.
+func isStopped(state *container.State) bool {
+	return state != nil && (state.Status == "created" || state.Status == "exited")
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"errors"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	specsv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"maps"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"slices"
+	"testing"
+)
+
+// hostClient keeps the containers created on a Docker host, by name.
+type hostClient struct {
+	*mockDockerClient
+	host      map[string]container.InspectResponse
+	created   []string
+	removed   []string
+	started   []string
+	restarted []string
+}
+
+func (m *hostClient) ContainerList(_ context.Context, options container.ListOptions) ([]container.Summary, error) {
+	var matched []container.Summary
+	for _, name := range slices.Sorted(maps.Keys(m.host)) {
+		info := m.host[name]
+		if labelsMatch(info.Config.Labels, options.Filters.Get("label")) {
+			matched = append(matched, container.Summary{ID: info.ID, Labels: info.Config.Labels})
+		}
+	}
+	return matched, nil
+}
+
+func (m *hostClient) ContainerInspect(_ context.Context, name string) (container.InspectResponse, error) {
+	info, ok := m.host[name]
+	if !ok {
+		return container.InspectResponse{}, errors.New("No such container: " + name)
+	}
+	return info, nil
+}
+
+func (m *hostClient) ContainerCreate(_ context.Context, config *container.Config, hostConfig *container.HostConfig, _ *network.NetworkingConfig, _ *specsv1.Platform, name string) (container.CreateResponse, error) {
+	m.host[name] = container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{ID: name, State: &container.State{Status: "created"}, HostConfig: hostConfig},
+		Config:            config,
+	}
+	m.created = append(m.created, name)
+	return container.CreateResponse{ID: name}, nil
+}
+
+func (m *hostClient) ContainerStart(_ context.Context, id string, _ container.StartOptions) error {
+	m.host[id].State.Status = "running"
+	m.started = append(m.started, id)
+	return nil
+}
+
+func (m *hostClient) ContainerRestart(_ context.Context, id string, _ container.StopOptions) error {
+	m.restarted = append(m.restarted, id)
+	return nil
+}
+
+func (m *hostClient) ContainerRemove(_ context.Context, id string, _ container.RemoveOptions) error {
+	delete(m.host, id)
+	m.removed = append(m.removed, id)
+	return nil
+}
+
+func TestExternal_UpdateConvergesServices(t *testing.T) {
+	const (
+		before = `
+services:
+  web:
+    image: nginx:1.27
+  db:
+    image: postgres:16
+`
+		after = `
+services:
+  web:
+    image: nginx:1.28
+  db:
+    image: postgres:16
+`
+	)
+
+	tests := map[string]struct {
+		content     string
+		stopped     string
+		deferred    bool
+		wantRemoved []string
+		wantCreated []string
+		wantStarted []string
+		wantImage   string
+	}{
+		"Unchanged": {
+			content:   before,
+			wantImage: "nginx:1.27",
+		},
+		"ChangedService": {
+			content:     after,
+			wantRemoved: []string{"stack_stack-web_1"},
+			wantCreated: []string{"stack_stack-web_1"},
+			wantStarted: []string{"stack_stack-web_1"},
+			wantImage:   "nginx:1.28",
+		},
+		"StoppedService": {
+			content:     before,
+			stopped:     "stack_stack-db_1",
+			wantStarted: []string{"stack_stack-db_1"},
+			wantImage:   "nginx:1.27",
+		},
+		"DeferredChange": {
+			content:     after,
+			stopped:     "stack_stack-db_1",
+			deferred:    true,
+			wantStarted: []string{"stack_stack-db_1"},
+			wantImage:   "nginx:1.27",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+			stored := &composev1alpha1.ComposeStack{
+				ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "uid"},
+			}
+			stored.Spec.ForProvider.Compose = stringPtr(before)
+			kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithStatusSubresource(stored).Build()
+
+			dc := &hostClient{mockDockerClient: &mockDockerClient{}, host: map[string]container.InspectResponse{}}
+			ext := &external{kube: kube, service: dc}
+
+			cr := stored.DeepCopy()
+			if _, err := ext.Create(context.Background(), cr); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if tt.stopped != "" {
+				dc.host[tt.stopped].State.Status = "exited"
+			}
+			dc.created, dc.started = nil, nil
+
+			cr.Spec.ForProvider.Compose = stringPtr(tt.content)
+			if tt.deferred {
+				cr.SetConditions(xpv1.Condition{Type: composev1alpha1.TypeDeferred, Status: corev1.ConditionTrue})
+			}
+			if _, err := ext.Update(context.Background(), cr); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			for what, got := range map[string][][]string{
+				"removed": {dc.removed, tt.wantRemoved},
+				"created": {dc.created, tt.wantCreated},
+				"started": {dc.started, tt.wantStarted},
+			} {
+				if !slices.Equal(got[0], got[1]) {
+					t.Errorf("Update() %s %v, want %v", what, got[0], got[1])
+				}
+			}
+			if img := dc.host["stack_stack-web_1"].Config.Image; img != tt.wantImage {
+				t.Errorf("Update() left web on %s, want %s", img, tt.wantImage)
+			}
+		})
+	}
+}
+
+func TestExternal_UpdateRestartsDependents(t *testing.T) {
+	const (
+		before = `
+services:
+  db:
+    image: postgres:16
+  web:
+    image: nginx:1.27
+    depends_on: [db]
+  sidecar:
+    image: busybox:1.37
+    network_mode: service:db
+  cache:
+    image: redis:7
+`
+		after = `
+services:
+  db:
+    image: postgres:17
+  web:
+    image: nginx:1.27
+    depends_on: [db]
+  sidecar:
+    image: busybox:1.37
+    network_mode: service:db
+  cache:
+    image: redis:7
+`
+	)
+
+	scheme := runtime.NewScheme()
+	_ = composev1alpha1.SchemeBuilder.AddToScheme(scheme)
+	stored := &composev1alpha1.ComposeStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack", Namespace: "default", UID: "uid"},
+	}
+	stored.Spec.ForProvider.Compose = stringPtr(before)
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithStatusSubresource(stored).Build()
+
+	dc := &hostClient{mockDockerClient: &mockDockerClient{}, host: map[string]container.InspectResponse{}}
+	ext := &external{kube: kube, service: dc}
+
+	cr := stored.DeepCopy()
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	dc.created, dc.started = nil, nil
+
+	cr.Spec.ForProvider.Compose = stringPtr(after)
+	if _, err := ext.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// The sidecar shares the network of db, so it is recreated with it,
+	// while web is only restarted and cache is left alone
+	recreated := []string{"stack_stack-db_1", "stack_stack-sidecar_1"}
+	for what, got := range map[string][][]string{
+		"removed":   {dc.removed, recreated},
+		"created":   {dc.created, recreated},
+		"restarted": {dc.restarted, {"stack_stack-web_1"}},
+	} {
+		if !slices.Equal(got[0], got[1]) {
+			t.Errorf("Update() %s %v, want %v", what, got[0], got[1])
+		}
+	}
+	if mode := dc.host["stack_stack-sidecar_1"].HostConfig.NetworkMode; mode != "container:stack_stack-db_1" {
+		t.Errorf("Update() left sidecar on network mode %s, want container:stack_stack-db_1", mode)
+	}
+}
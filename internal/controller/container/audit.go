@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultAuditEntries is how many calls are kept when the ProviderConfig
+// does not say.
+const defaultAuditEntries = 20
+
+// auditResultSuccess is the result of a call that did not fail.
+const auditResultSuccess = "Success"
+
+// withAudit returns dc unchanged unless the ProviderConfig enables auditing,
+// in which case the mutating calls made through it are appended to the audit
+// log of obs.
+func withAudit(dc clients.DockerClient, a *apisv1beta1.Audit, obs *v1alpha1.ContainerObservation) clients.DockerClient {
+	if a == nil {
+		return dc
+	}
+	limit := defaultAuditEntries
+	if a.MaxEntries != nil && *a.MaxEntries > 0 {
+		limit = int(*a.MaxEntries)
+	}
+	return clients.NewAuditingClient(dc, func(r clients.AuditRecord) {
+		obs.AuditLog = appendAudit(obs.AuditLog, r, limit)
+	})
+}
+
+// appendAudit appends a record of a call to log, dropping the oldest entries
+// so that no more than limit remain.
+func appendAudit(log []v1alpha1.AuditEntry, r clients.AuditRecord, limit int) []v1alpha1.AuditEntry {
+	e := v1alpha1.AuditEntry{
+		Time:      metav1.NewTime(r.Time),
+		Operation: r.Operation,
+		Target:    r.Target,
+		Args:      r.Args,
+		Result:    auditResultSuccess,
+	}
+	if r.Err != nil {
+		e.Result = r.Err.Error()
+	}
+	log = append(log, e)
+	if len(log) > limit {
+		log = append([]v1alpha1.AuditEntry(nil), log[len(log)-limit:]...)
+	}
+	return log
+}
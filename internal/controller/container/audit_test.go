@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"errors"
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	"testing"
+)
+
+func TestWithAudit(t *testing.T) {
+	mock := &mockDockerClient{
+		containerStopFunc: func(_ context.Context, _ string, _ container.StopOptions) error {
+			return errors.New("cannot stop")
+		},
+	}
+
+	obs := &v1alpha1.ContainerObservation{}
+	if dc := withAudit(mock, nil, obs); dc != mock {
+		t.Fatalf("withAudit(nil): want the client unchanged")
+	}
+
+	dc := withAudit(mock, &apisv1beta1.Audit{MaxEntries: int32Ptr(2)}, obs)
+	_ = dc.ContainerStart(context.Background(), "web", container.StartOptions{})
+	_ = dc.ContainerStop(context.Background(), "web", container.StopOptions{})
+	_ = dc.ContainerRemove(context.Background(), "web", container.RemoveOptions{})
+	_, _ = dc.ContainerInspect(context.Background(), "web")
+
+	if len(obs.AuditLog) != 2 {
+		t.Fatalf("len(AuditLog) = %d, want 2", len(obs.AuditLog))
+	}
+	if e := obs.AuditLog[0]; e.Operation != "ContainerStop" || e.Result != "cannot stop" {
+		t.Errorf("AuditLog[0] = %+v, want failed ContainerStop", e)
+	}
+	if e := obs.AuditLog[1]; e.Operation != "ContainerRemove" || e.Result != auditResultSuccess || e.Target != "web" {
+		t.Errorf("AuditLog[1] = %+v, want successful ContainerRemove of web", e)
+	}
+}
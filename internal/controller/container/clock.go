@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	errAttachExec = "cannot attach to exec instance"
+	errReadClock  = "cannot read the clock of the container"
+
+	// defaultMaxClockDrift is how far the clock of a container may be from
+	// the provider's, when its clock check does not say.
+	defaultMaxClockDrift = 5 * time.Second
+
+	// defaultClockCheckInterval is how often clocks are compared, when a
+	// clock check does not say.
+	defaultClockCheckInterval = 5 * time.Minute
+
+	// clockCheckTimeout bounds reading the clock of a container.
+	clockCheckTimeout = 10 * time.Second
+)
+
+// checkClock compares the clock of a running container with the provider's,
+// once each interval of its clock check, and records whether it has drifted
+// too far in its ClockDrifted condition. A clock that cannot be read leaves
+// the condition unknown rather than failing the observation.
+func (c *external) checkClock(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse) {
+	cc := cr.Spec.ForProvider.ClockCheck
+	obs := &cr.Status.AtProvider
+	if cc == nil {
+		obs.ClockCheckedAt, obs.ClockOffset = nil, nil
+		return
+	}
+	if !info.State.Running {
+		return
+	}
+	interval := defaultClockCheckInterval
+	if cc.Interval != nil && cc.Interval.Duration > 0 {
+		interval = cc.Interval.Duration
+	}
+	if obs.ClockCheckedAt != nil && time.Now().Before(obs.ClockCheckedAt.Add(interval)) {
+		return
+	}
+
+	offset, err := c.clockOffset(ctx, info.ID)
+	now := metav1.Now()
+	obs.ClockCheckedAt = &now
+	if err != nil {
+		obs.ClockOffset = nil
+		cr.SetConditions(v1alpha1.ClockCheckFailed(err.Error()))
+		return
+	}
+	obs.ClockOffset = &metav1.Duration{Duration: offset}
+
+	maxDrift := defaultMaxClockDrift
+	if cc.MaxDrift != nil {
+		maxDrift = cc.MaxDrift.Duration
+	}
+	if offset > maxDrift || offset < -maxDrift {
+		cr.SetConditions(v1alpha1.ClockDrifted(fmt.Sprintf("Container clock is %s off the provider's, more than the %s allowed", offset, maxDrift)))
+		return
+	}
+	cr.SetConditions(v1alpha1.ClockInSync())
+}
+
+// clockOffset returns how far the clock of a container is ahead of the
+// provider's, to the second. The container's clock is read with date +%s,
+// and compared with the provider's clock halfway through reading it.
+func (c *external) clockOffset(ctx context.Context, containerID string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, clockCheckTimeout)
+	defer cancel()
+
+	before := time.Now()
+	exec, err := c.client.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          []string{"date", "+%s"},
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, errCreateExec)
+	}
+	resp, err := c.client.ContainerExecAttach(ctx, exec.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return 0, errors.Wrap(err, errAttachExec)
+	}
+	defer resp.Close()
+
+	// Reading the hijacked connection does not heed the context
+	deadline, _ := ctx.Deadline()
+	_ = resp.Conn.SetReadDeadline(deadline)
+
+	var stdout bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, io.Discard, resp.Reader); err != nil {
+		return 0, errors.Wrap(err, errReadClock)
+	}
+	after := time.Now()
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(stdout.String()), 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, errReadClock)
+	}
+
+	// The container's clock is somewhere within the second it printed
+	containerTime := time.Unix(seconds, 0).Add(500 * time.Millisecond)
+	providerTime := before.Add(after.Sub(before) / 2)
+	return containerTime.Sub(providerTime).Round(time.Second), nil
+}
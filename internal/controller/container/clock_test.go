@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// execOutput returns an exec attach that streams stdout.
+func execOutput(stdout string) func(context.Context, string, container.ExecAttachOptions) (types.HijackedResponse, error) {
+	return func(context.Context, string, container.ExecAttachOptions) (types.HijackedResponse, error) {
+		conn, peer := net.Pipe()
+		_ = peer.Close()
+		return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(bytes.NewReader(multiplexed(stdout, "")))}, nil
+	}
+}
+
+func TestCheckClock(t *testing.T) {
+	running := &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+		ID:    "abc",
+		State: &container.State{Running: true},
+	}}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	ahead := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	recently := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name        string
+		check       *v1alpha1.ClockCheck
+		checkedAt   *metav1.Time
+		attach      func(context.Context, string, container.ExecAttachOptions) (types.HijackedResponse, error)
+		wantStatus  corev1.ConditionStatus
+		wantChecked bool
+	}{
+		{
+			name:       "NoCheck",
+			attach:     execOutput(ahead),
+			wantStatus: corev1.ConditionUnknown,
+		},
+		{
+			name:        "InSync",
+			check:       &v1alpha1.ClockCheck{},
+			attach:      execOutput(now + "\n"),
+			wantStatus:  corev1.ConditionFalse,
+			wantChecked: true,
+		},
+		{
+			name:        "Drifted",
+			check:       &v1alpha1.ClockCheck{MaxDrift: &metav1.Duration{Duration: time.Minute}},
+			attach:      execOutput(ahead + "\n"),
+			wantStatus:  corev1.ConditionTrue,
+			wantChecked: true,
+		},
+		{
+			name:        "NotDue",
+			check:       &v1alpha1.ClockCheck{},
+			checkedAt:   &recently,
+			attach:      execOutput(ahead),
+			wantStatus:  corev1.ConditionUnknown,
+			wantChecked: true,
+		},
+		{
+			name:        "Unreadable",
+			check:       &v1alpha1.ClockCheck{},
+			attach:      execOutput("date: not found\n"),
+			wantStatus:  corev1.ConditionUnknown,
+			wantChecked: true,
+		},
+		{
+			name:  "AttachFails",
+			check: &v1alpha1.ClockCheck{},
+			attach: func(context.Context, string, container.ExecAttachOptions) (types.HijackedResponse, error) {
+				return types.HijackedResponse{}, errors.New("boom")
+			},
+			wantStatus:  corev1.ConditionUnknown,
+			wantChecked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &external{logger: logging.NewNopLogger(), client: &mockDockerClient{execAttachFunc: tt.attach}}
+			cr := &v1alpha1.Container{}
+			cr.Spec.ForProvider.ClockCheck = tt.check
+			cr.Status.AtProvider.ClockCheckedAt = tt.checkedAt
+
+			c.checkClock(context.Background(), cr, running)
+
+			if got := cr.GetCondition(v1alpha1.TypeClockDrifted).Status; got != tt.wantStatus {
+				t.Errorf("ClockDrifted = %s, want %s", got, tt.wantStatus)
+			}
+			if got := cr.Status.AtProvider.ClockCheckedAt != nil; got != tt.wantChecked {
+				t.Errorf("ClockCheckedAt set = %t, want %t", got, tt.wantChecked)
+			}
+		})
+	}
+}
+
+func TestClockOffset(t *testing.T) {
+	ahead := strconv.FormatInt(time.Now().Add(90*time.Second).Unix(), 10)
+	c := &external{client: &mockDockerClient{execAttachFunc: execOutput(ahead + "\n")}}
+
+	offset, err := c.clockOffset(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("clockOffset(...): %v", err)
+	}
+	if offset < 89*time.Second || offset > 91*time.Second {
+		t.Errorf("clockOffset(...) = %s, want about 1m30s", offset)
+	}
+}
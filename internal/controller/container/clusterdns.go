@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clusterdns"
+)
+
+const reasonClusterDNSFailed event.Reason = "ClusterDNSRegistrationFailed"
+
+// registerClusterDNS registers the ports a container publishes as a Service
+// named after it, when its ProviderConfig says to, so that workloads in the
+// cluster find it by its cluster DNS name. The Service of a container that
+// publishes no ports is removed. Registration does not hold up the
+// container: one whose Service cannot be registered is reported with an
+// event.
+func (c *external) registerClusterDNS(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse) {
+	if c.clusterDNS == nil || c.kube == nil {
+		return
+	}
+	if err := c.applyClusterDNS(ctx, cr, info); err != nil {
+		c.record(cr, event.Warning(reasonClusterDNSFailed, err))
+	}
+}
+
+func (c *external) applyClusterDNS(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse) error {
+	name, err := clusterdns.ServiceName(cr.GetName())
+	if err != nil {
+		return err
+	}
+	namespace := clusterdns.Namespace(c.clusterDNS, cr.GetNamespace())
+	ports := clusterdns.Ports(info)
+	if len(ports) == 0 {
+		return clusterdns.Unregister(ctx, c.kube, cr, namespace, name)
+	}
+	address, err := clusterdns.Address(c.clusterDNS, c.host)
+	if err != nil {
+		return err
+	}
+	return clusterdns.Register(ctx, c.kube, cr, c.kind, clusterdns.Registration{
+		Namespace: namespace,
+		Name:      name,
+		Address:   address,
+		Ports:     ports,
+		Ready:     serving(info.State),
+	})
+}
+
+// serving reports whether a container is running and, if it has a health
+// check, healthy.
+func serving(state *container.State) bool {
+	if state == nil || !state.Running {
+		return false
+	}
+	return state.Health == nil || state.Health.Status == container.Healthy || state.Health.Status == container.NoHealthcheck
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestRegisterClusterDNS(t *testing.T) {
+	ctx := context.Background()
+	cr := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "web", UID: "uid"}}
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{State: &container.State{Running: true}},
+		NetworkSettings: &container.NetworkSettings{NetworkSettingsBase: container.NetworkSettingsBase{Ports: nat.PortMap{
+			"80/tcp": {{HostIP: "0.0.0.0", HostPort: "8080"}},
+		}}},
+	}
+	kube := fake.NewClientBuilder().Build()
+	rec := &eventRecorder{}
+	c := &external{
+		kube:       kube,
+		host:       "tcp://10.0.0.5:2376",
+		kind:       v1alpha1.ContainerGroupVersionKind,
+		clusterDNS: &apisv1beta1.ClusterDNS{Namespace: "docker"},
+		recorder:   rec,
+	}
+	key := types.NamespacedName{Namespace: "docker", Name: "web"}
+
+	c.registerClusterDNS(ctx, cr, info)
+	if len(rec.events) > 0 {
+		t.Fatalf("registerClusterDNS() recorded %+v, want no events", rec.events)
+	}
+	svc := &corev1.Service{}
+	if err := kube.Get(ctx, key, svc); err != nil || svc.OwnerReferences[0].Kind != v1alpha1.ContainerKind {
+		t.Fatalf("registerClusterDNS() Service = %+v, %v, want one owned by the container", svc, err)
+	}
+	slice := &discoveryv1.EndpointSlice{}
+	if err := kube.Get(ctx, key, slice); err != nil || slice.Endpoints[0].Addresses[0] != "10.0.0.5" {
+		t.Errorf("registerClusterDNS() EndpointSlice = %+v, %v, want the Docker host as its endpoint", slice, err)
+	}
+
+	// A container that no longer publishes ports is unregistered
+	info.NetworkSettings.Ports = nil
+	c.registerClusterDNS(ctx, cr, info)
+	if err := kube.Get(ctx, key, svc); !kerrors.IsNotFound(err) {
+		t.Errorf("registerClusterDNS() kept the Service of a container publishing no ports: %v", err)
+	}
+
+	// A host the cluster cannot be told how to reach is reported
+	info.NetworkSettings.Ports = nat.PortMap{"80/tcp": {{HostPort: "8080"}}}
+	c.host = "unix:///var/run/docker.sock"
+	c.registerClusterDNS(ctx, cr, info)
+	if len(rec.events) != 1 || rec.events[0].Reason != reasonClusterDNSFailed {
+		t.Errorf("registerClusterDNS() recorded %+v, want a %s event", rec.events, reasonClusterDNSFailed)
+	}
+
+	// Nothing is registered unless the ProviderConfig says to
+	c.clusterDNS, c.host = nil, "tcp://10.0.0.5:2376"
+	c.registerClusterDNS(ctx, cr, info)
+	if err := kube.Get(ctx, key, svc); !kerrors.IsNotFound(err) {
+		t.Errorf("registerClusterDNS() without clusterDNS registered a Service: %v", err)
+	}
+}
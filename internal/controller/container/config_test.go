@@ -17,6 +17,7 @@ limitations under the License.
 package container
 
 import (
+	"context"
 	"errors"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/docker/docker/api/types/container"
@@ -24,7 +25,12 @@ import (
 	"github.com/docker/go-connections/nat"
 	"github.com/google/go-cmp/cmp"
 	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	"github.com/rossigee/provider-docker/internal/envsource"
+	"github.com/rossigee/provider-docker/pkg/labels"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -352,7 +358,7 @@ func TestBuildVolumeConfiguration(t *testing.T) {
 				err:    nil,
 			},
 		},
-		"SecretVolumeSkipped": {
+		"SecretVolume": {
 			args: args{
 				volumes: []v1alpha1.VolumeMount{
 					{
@@ -368,11 +374,11 @@ func TestBuildVolumeConfiguration(t *testing.T) {
 			},
 			want: want{
 				binds:  []string{},
-				mounts: 0, // Secret volumes are skipped
+				mounts: 1, // Secret volumes are projected into a Docker volume
 				err:    nil,
 			},
 		},
-		"ConfigMapVolumeSkipped": {
+		"ConfigMapVolume": {
 			args: args{
 				volumes: []v1alpha1.VolumeMount{
 					{
@@ -388,7 +394,7 @@ func TestBuildVolumeConfiguration(t *testing.T) {
 			},
 			want: want{
 				binds:  []string{},
-				mounts: 0, // ConfigMap volumes are skipped
+				mounts: 1, // ConfigMap volumes are projected into a Docker volume
 				err:    nil,
 			},
 		},
@@ -433,7 +439,7 @@ func TestBuildVolumeConfiguration(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			builder := &defaultContainerConfigBuilder{}
-			gotBinds, gotMounts, gotErr := builder.buildVolumeConfiguration(tc.args.volumes)
+			gotBinds, gotMounts, gotErr := builder.buildVolumeConfiguration(client.ObjectKey{Namespace: "default", Name: "web"}, tc.args.volumes)
 
 			if diff := cmp.Diff(tc.want.err, gotErr); diff != "" {
 				t.Errorf("buildVolumeConfiguration() error mismatch (-want +got):\n%s", diff)
@@ -474,6 +480,19 @@ func TestBuildVolumeConfiguration(t *testing.T) {
 				}
 			}
 
+			if name == "SecretVolume" && len(gotMounts) > 0 {
+				mount := gotMounts[0]
+				if mount.Type != "volume" || !mount.ReadOnly {
+					t.Errorf("Expected a read-only volume mount, got %+v", mount)
+				}
+				if want := projectedVolumeName(client.ObjectKey{Namespace: "default", Name: "web"}, "secret-vol"); mount.Source != want {
+					t.Errorf("Expected mount source '%s', got '%s'", want, mount.Source)
+				}
+				if mount.VolumeOptions == nil || !mount.VolumeOptions.NoCopy {
+					t.Errorf("Expected the volume not to be populated from the image, got %v", mount.VolumeOptions)
+				}
+			}
+
 			if name == "EmptyDirWithSize" && len(gotMounts) > 0 {
 				mount := gotMounts[0]
 				if mount.Type != "tmpfs" {
@@ -519,6 +538,26 @@ func TestParseByteSize(t *testing.T) {
 			args: args{sizeStr: "0Mi"},
 			want: want{bytes: 0, err: nil},
 		},
+		"DecimalSuffix": {
+			args: args{sizeStr: "1.5G"},
+			want: want{bytes: 1500 * 1000 * 1000, err: nil},
+		},
+		"Empty": {
+			args: args{sizeStr: ""},
+			want: want{err: errors.New("invalid size")},
+		},
+		"UnknownSuffix": {
+			args: args{sizeStr: "100MB"},
+			want: want{err: errors.New("invalid size")},
+		},
+		"Negative": {
+			args: args{sizeStr: "-1Mi"},
+			want: want{err: errors.New("invalid size")},
+		},
+		"TooLarge": {
+			args: args{sizeStr: "9Ei"},
+			want: want{err: errors.New("invalid size")},
+		},
 	}
 
 	for name, tc := range cases {
@@ -544,6 +583,55 @@ func TestParseByteSize(t *testing.T) {
 	}
 }
 
+func FuzzParseByteSize(f *testing.F) {
+	for _, seed := range []string{"100Mi", "2Gi", "1024", "1.5G", "0", "", "-1", "1e3", "9Ei", "100MB", " 64Ki "} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		bytes, err := parseByteSize(s)
+		if err != nil {
+			if !strings.Contains(err.Error(), strconv.Quote(s)) {
+				t.Errorf("parseByteSize(%q) error %q does not name the value", s, err)
+			}
+			return
+		}
+		if bytes < 0 {
+			t.Errorf("parseByteSize(%q) = %d, want a size of at least 0", s, bytes)
+		}
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil && n >= 0 && bytes != n {
+			t.Errorf("parseByteSize(%q) = %d, want %d", s, bytes, n)
+		}
+	})
+}
+
+func TestBuildVolumeConfigurationInvalidSize(t *testing.T) {
+	size := "100MB"
+	volumes := []v1alpha1.VolumeMount{
+		{Name: "data", MountPath: "/data", VolumeSource: v1alpha1.VolumeSource{EmptyDir: &v1alpha1.EmptyDirVolumeSource{}}},
+		{Name: "tmp", MountPath: "/tmp", VolumeSource: v1alpha1.VolumeSource{EmptyDir: &v1alpha1.EmptyDirVolumeSource{SizeLimit: &size}}},
+	}
+	_, _, err := (&defaultContainerConfigBuilder{}).buildVolumeConfiguration(client.ObjectKey{Namespace: "default", Name: "web"}, volumes)
+	if err == nil {
+		t.Fatal("buildVolumeConfiguration() succeeded with an invalid size limit")
+	}
+	if want := "spec.forProvider.volumes[1].source.emptyDir.sizeLimit"; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("buildVolumeConfiguration() error %q does not start with the field path %s", err, want)
+	}
+}
+
+func TestBuildVolumeConfigurationUnresolvedVolume(t *testing.T) {
+	volumes := []v1alpha1.VolumeMount{
+		{Name: "data", MountPath: "/data", VolumeSource: v1alpha1.VolumeSource{Volume: &v1alpha1.VolumeVolumeSource{VolumeRef: &xpv1.Reference{Name: "data"}}}},
+	}
+	_, _, err := (&defaultContainerConfigBuilder{}).buildVolumeConfiguration(client.ObjectKey{Namespace: "default", Name: "web"}, volumes)
+	if err == nil {
+		t.Fatal("buildVolumeConfiguration() succeeded with an unresolved volume reference")
+	}
+	if want := "spec.forProvider.volumes[0].source.volume"; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("buildVolumeConfiguration() error %q does not start with the field path %s", err, want)
+	}
+}
+
 func TestBuildNetworkConfiguration(t *testing.T) {
 	type args struct {
 		networks []v1alpha1.NetworkAttachment
@@ -806,6 +894,20 @@ func TestBuildNetworkConfiguration(t *testing.T) {
 	}
 }
 
+func TestBuildNetworkConfigurationUnresolvedNetwork(t *testing.T) {
+	networks := []v1alpha1.NetworkAttachment{
+		{Name: "frontend"},
+		{NetworkSelector: &xpv1.Selector{MatchLabels: map[string]string{"tier": "backend"}}},
+	}
+	_, err := (&defaultContainerConfigBuilder{}).buildNetworkConfiguration(networks)
+	if err == nil {
+		t.Fatal("buildNetworkConfiguration() succeeded with an unresolved network selector")
+	}
+	if want := "spec.forProvider.networks[1]"; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("buildNetworkConfiguration() error %q does not start with the field path %s", err, want)
+	}
+}
+
 func TestBuildSecurityConfiguration(t *testing.T) {
 	type args struct {
 		securityContext *v1alpha1.SecurityContext
@@ -918,7 +1020,7 @@ func TestBuildSecurityConfiguration(t *testing.T) {
 				privileged:       func() *bool { b := false; return &b }(),
 				capAdd:           nil,
 				capDrop:          nil,
-				securityOptCount: 0,
+				securityOptCount: 1, // no-new-privileges
 				err:              nil,
 			},
 		},
@@ -1030,7 +1132,7 @@ func TestBuildSecurityConfiguration(t *testing.T) {
 				privileged:       func() *bool { b := false; return &b }(),
 				capAdd:           strslice.StrSlice{"NET_BIND_SERVICE"},
 				capDrop:          strslice.StrSlice{"ALL"},
-				securityOptCount: 3, // SELinux, Seccomp, AppArmor
+				securityOptCount: 4, // SELinux, Seccomp, AppArmor, no-new-privileges
 				hasSelinux:       true,
 				hasSeccomp:       true,
 				hasAppArmor:      true,
@@ -1526,12 +1628,62 @@ func TestBuildContainerConfig(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ContainerWithStopSettings": {
+			args: args{
+				container: &v1alpha1.Container{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-container",
+					},
+					Spec: v1alpha1.ContainerSpec{
+						ManagedResourceSpec: xpv1.ManagedResourceSpec{},
+						ForProvider: v1alpha1.ContainerParameters{
+							Image:           "nginx:latest",
+							StopSignal:      func() *string { s := "SIGQUIT"; return &s }(),
+							StopGracePeriod: &metav1.Duration{Duration: 90 * time.Second},
+							Init:            func() *bool { b := true; return &b }(),
+						},
+					},
+				},
+			},
+			want: want{
+				configFields: map[string]interface{}{
+					"StopSignal":  "SIGQUIT",
+					"StopTimeout": 90,
+				},
+				hostFields: map[string]interface{}{
+					"Init": true,
+				},
+				err: nil,
+			},
+		},
+		"ContainerWithDeviceCgroupRules": {
+			args: args{
+				container: &v1alpha1.Container{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-container",
+					},
+					Spec: v1alpha1.ContainerSpec{
+						ManagedResourceSpec: xpv1.ManagedResourceSpec{},
+						ForProvider: v1alpha1.ContainerParameters{
+							Image:             "nginx:latest",
+							DeviceCgroupRules: []string{"c 189:* rmw", "c 188:* rmw"},
+						},
+					},
+				},
+			},
+			want: want{
+				hostFields: map[string]interface{}{
+					"DeviceCgroupRules": []string{"c 189:* rmw", "c 188:* rmw"},
+				},
+				err: nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			builder := NewContainerConfigBuilder()
-			gotConfig, gotHostConfig, _, _, gotErr := builder.BuildContainerConfig(tc.args.container)
+			gotConfig, gotHostConfig, _, _, gotErr := builder.BuildContainerConfig(context.Background(), tc.args.container)
 
 			if diff := cmp.Diff(tc.want.err, gotErr); diff != "" {
 				t.Errorf("BuildContainerConfig() error mismatch (-want +got):\n%s", diff)
@@ -1549,6 +1701,12 @@ func TestBuildContainerConfig(t *testing.T) {
 						gotValue = []string(gotConfig.Cmd) // Convert StrSlice to []string
 					case "Env":
 						gotValue = gotConfig.Env
+					case "StopSignal":
+						gotValue = gotConfig.StopSignal
+					case "StopTimeout":
+						if gotConfig.StopTimeout != nil {
+							gotValue = *gotConfig.StopTimeout
+						}
 					}
 
 					if diff := cmp.Diff(expectedValue, gotValue); diff != "" {
@@ -1562,6 +1720,12 @@ func TestBuildContainerConfig(t *testing.T) {
 					switch field {
 					case "RestartPolicy":
 						gotValue = gotHostConfig.RestartPolicy
+					case "Init":
+						if gotHostConfig.Init != nil {
+							gotValue = *gotHostConfig.Init
+						}
+					case "DeviceCgroupRules":
+						gotValue = gotHostConfig.DeviceCgroupRules
 					}
 
 					if diff := cmp.Diff(expectedValue, gotValue); diff != "" {
@@ -1573,8 +1737,210 @@ func TestBuildContainerConfig(t *testing.T) {
 	}
 }
 
+func TestSecurityProfile(t *testing.T) {
+	type args struct {
+		policy *apisv1beta1.Policy
+		params v1alpha1.ContainerParameters
+	}
+	type want struct {
+		securityOpt    []string
+		capDrop        []string
+		readonlyRootfs bool
+		errContains    string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoProfile": {
+			args: args{
+				params: v1alpha1.ContainerParameters{Image: "nginx:latest"},
+			},
+			want: want{},
+		},
+		"Restricted": {
+			args: args{
+				params: v1alpha1.ContainerParameters{
+					Image:           "nginx:latest",
+					SecurityProfile: stringPtr(v1alpha1.SecurityProfileRestricted),
+				},
+			},
+			want: want{
+				securityOpt:    []string{"seccomp:runtime/default", "no-new-privileges:true"},
+				capDrop:        []string{"ALL"},
+				readonlyRootfs: true,
+			},
+		},
+		"BaselineKeepsExplicitSettings": {
+			args: args{
+				params: v1alpha1.ContainerParameters{
+					Image:           "nginx:latest",
+					SecurityProfile: stringPtr(v1alpha1.SecurityProfileBaseline),
+					SecurityContext: &v1alpha1.SecurityContext{
+						SeccompProfile: &v1alpha1.SeccompProfile{Type: "Unconfined"},
+					},
+				},
+			},
+			want: want{
+				securityOpt: []string{"seccomp:unconfined", "no-new-privileges:true"},
+			},
+		},
+		"PolicyDefaultApplied": {
+			args: args{
+				policy: &apisv1beta1.Policy{DefaultSecurityProfile: stringPtr(v1alpha1.SecurityProfileBaseline)},
+				params: v1alpha1.ContainerParameters{Image: "nginx:latest"},
+			},
+			want: want{
+				securityOpt: []string{"seccomp:runtime/default", "no-new-privileges:true"},
+			},
+		},
+		"PolicyMinimumApplied": {
+			args: args{
+				policy: &apisv1beta1.Policy{MinimumSecurityProfile: stringPtr(v1alpha1.SecurityProfileRestricted)},
+				params: v1alpha1.ContainerParameters{Image: "nginx:latest"},
+			},
+			want: want{
+				securityOpt:    []string{"seccomp:runtime/default", "no-new-privileges:true"},
+				capDrop:        []string{"ALL"},
+				readonlyRootfs: true,
+			},
+		},
+		"PolicyMinimumViolated": {
+			args: args{
+				policy: &apisv1beta1.Policy{MinimumSecurityProfile: stringPtr(v1alpha1.SecurityProfileRestricted)},
+				params: v1alpha1.ContainerParameters{
+					Image:           "nginx:latest",
+					SecurityProfile: stringPtr(v1alpha1.SecurityProfileBaseline),
+				},
+			},
+			want: want{errContains: "less restrictive than the minimum"},
+		},
+		"PolicyRejectsPrivileged": {
+			args: args{
+				policy: &apisv1beta1.Policy{MinimumSecurityProfile: stringPtr(v1alpha1.SecurityProfilePrivileged)},
+				params: v1alpha1.ContainerParameters{
+					Image:           "nginx:latest",
+					SecurityProfile: stringPtr(v1alpha1.SecurityProfileBaseline),
+					Privileged:      boolPtr(true),
+				},
+			},
+			want: want{errContains: "does not allow privileged mode"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			builder := NewContainerConfigBuilderWithPolicy(tc.args.policy)
+			cr := &v1alpha1.Container{
+				Spec: v1alpha1.ContainerSpec{ForProvider: tc.args.params},
+			}
+			_, hostConfig, _, _, err := builder.BuildContainerConfig(context.Background(), cr)
+
+			if tc.want.errContains != "" {
+				if err == nil || !contains(err.Error(), tc.want.errContains) {
+					t.Errorf("BuildContainerConfig() error = %v, want error containing %q", err, tc.want.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildContainerConfig() unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want.securityOpt, hostConfig.SecurityOpt); diff != "" {
+				t.Errorf("BuildContainerConfig() hostConfig.SecurityOpt mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.capDrop, []string(hostConfig.CapDrop)); diff != "" {
+				t.Errorf("BuildContainerConfig() hostConfig.CapDrop mismatch (-want +got):\n%s", diff)
+			}
+			if hostConfig.ReadonlyRootfs != tc.want.readonlyRootfs {
+				t.Errorf("BuildContainerConfig() hostConfig.ReadonlyRootfs = %v, want %v", hostConfig.ReadonlyRootfs, tc.want.readonlyRootfs)
+			}
+		})
+	}
+}
+
+func TestProviderConfigInjection(t *testing.T) {
+	pc := &apisv1beta1.ProviderConfig{
+		Spec: apisv1beta1.ProviderConfigSpec{
+			Injection: &apisv1beta1.ContainerInjection{
+				Env: []apisv1beta1.InjectedEnvVar{
+					{Name: "LOG_SOCKET", Value: "/var/run/fluent.sock"},
+					{Name: "TZ", Value: "UTC"},
+				},
+				Labels: map[string]string{"team": "platform", "logging": "enabled"},
+				Mounts: []apisv1beta1.InjectedMount{
+					{Source: "/var/run/fluent.sock", Target: "/var/run/fluent.sock"},
+					{Source: "/etc/ssl/ca.pem", Target: "/etc/ssl/certs/ca.pem", ReadOnly: boolPtr(true)},
+				},
+			},
+		},
+	}
+	params := v1alpha1.ContainerParameters{
+		Image:       "nginx:latest",
+		Environment: []v1alpha1.EnvVar{{Name: "TZ", Value: stringPtr("Europe/London")}},
+		Labels:      map[string]string{"team": "web"},
+		Volumes: []v1alpha1.VolumeMount{{
+			MountPath:    "/etc/ssl/certs/ca.pem",
+			VolumeSource: v1alpha1.VolumeSource{HostPath: &v1alpha1.HostPathVolumeSource{Path: "/opt/ca.pem"}},
+		}},
+	}
+
+	type want struct {
+		env    []string
+		labels map[string]string
+		mounts int
+	}
+	cases := map[string]struct {
+		annotations map[string]string
+		want        want
+	}{
+		"Injected": {
+			want: want{
+				env:    []string{"LOG_SOCKET=/var/run/fluent.sock", "TZ=Europe/London"},
+				labels: map[string]string{"team": "web", "logging": "enabled"},
+				mounts: 1,
+			},
+		},
+		"OptedOut": {
+			annotations: map[string]string{labels.AnnotationSkipInjection: "true"},
+			want: want{
+				env:    []string{"TZ=Europe/London"},
+				labels: map[string]string{"team": "web"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Container{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       v1alpha1.ContainerSpec{ForProvider: *params.DeepCopy()},
+			}
+			config, hostConfig, _, _, err := NewContainerConfigBuilderForProviderConfig(pc).BuildContainerConfig(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("BuildContainerConfig() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want.env, config.Env); diff != "" {
+				t.Errorf("BuildContainerConfig() config.Env mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.labels, config.Labels); diff != "" {
+				t.Errorf("BuildContainerConfig() config.Labels mismatch (-want +got):\n%s", diff)
+			}
+			// The CA bundle target is already bound by the container itself
+			if len(hostConfig.Mounts) != tc.want.mounts {
+				t.Errorf("BuildContainerConfig() got %d mounts, want %d: %v", len(hostConfig.Mounts), tc.want.mounts, hostConfig.Mounts)
+			}
+			if diff := cmp.Diff(map[string]string{"team": "web"}, cr.Spec.ForProvider.Labels); diff != "" {
+				t.Errorf("BuildContainerConfig() modified the resource labels (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestBuildEnvironmentConfiguration(t *testing.T) {
 	builder := NewContainerConfigBuilder().(*defaultContainerConfigBuilder)
+	builder.sources = envsource.NewRegistry()
 
 	tests := []struct {
 		name     string
@@ -1658,7 +2024,7 @@ func TestBuildEnvironmentConfiguration(t *testing.T) {
 			},
 			expected: nil,
 			wantErr:  true,
-			errMsg:   "Secret valueFrom not yet implemented",
+			errMsg:   `no value store named "secret" is registered`,
 		},
 		{
 			name: "EnvironmentVariableWithoutValueOrValueFrom",
@@ -1676,7 +2042,7 @@ func TestBuildEnvironmentConfiguration(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := builder.buildEnvironmentConfiguration(tt.envVars)
+			result, err := builder.buildEnvironmentConfiguration(context.Background(), "default", tt.envVars)
 
 			if tt.wantErr {
 				if err == nil {
@@ -1708,6 +2074,86 @@ func TestBuildEnvironmentConfiguration(t *testing.T) {
 	}
 }
 
+func TestBuildEnvironmentConfigurationValueStores(t *testing.T) {
+	sources := envsource.NewRegistry()
+	sources.Register(envsource.StoreSecret, envsource.SourceFn(func(_ context.Context, ref envsource.Ref) (string, error) {
+		if ref.Namespace == "team-a" && ref.Name == "db" && ref.Key == "password" {
+			return "s3cret", nil
+		}
+		return "", envsource.ErrNotFound
+	}))
+	sources.Register("vault", envsource.SourceFn(func(_ context.Context, ref envsource.Ref) (string, error) {
+		return ref.Name + "/" + ref.Key, nil
+	}))
+	builder := &defaultContainerConfigBuilder{sources: sources}
+
+	tests := []struct {
+		name     string
+		envVars  []v1alpha1.EnvVar
+		expected []string
+		errMsg   string
+	}{
+		{
+			name: "SecretKeyRef",
+			envVars: []v1alpha1.EnvVar{{
+				Name:      "DB_PASSWORD",
+				ValueFrom: &v1alpha1.EnvVarSource{SecretKeyRef: &v1alpha1.SecretKeySelector{Name: "db", Key: "password"}},
+			}},
+			expected: []string{"DB_PASSWORD=s3cret"},
+		},
+		{
+			name: "StoreKeyRef",
+			envVars: []v1alpha1.EnvVar{{
+				Name:      "TOKEN",
+				ValueFrom: &v1alpha1.EnvVarSource{StoreKeyRef: &v1alpha1.StoreKeySelector{Store: "vault", Name: "app", Key: "token"}},
+			}},
+			expected: []string{"TOKEN=app/token"},
+		},
+		{
+			name: "OptionalMissingSkipped",
+			envVars: []v1alpha1.EnvVar{{
+				Name:      "MISSING",
+				ValueFrom: &v1alpha1.EnvVarSource{SecretKeyRef: &v1alpha1.SecretKeySelector{Name: "db", Key: "user", Optional: boolPtr(true)}},
+			}},
+			expected: []string{},
+		},
+		{
+			name: "RequiredMissing",
+			envVars: []v1alpha1.EnvVar{{
+				Name:      "MISSING",
+				ValueFrom: &v1alpha1.EnvVarSource{SecretKeyRef: &v1alpha1.SecretKeySelector{Name: "db", Key: "user"}},
+			}},
+			errMsg: "cannot resolve value for environment variable MISSING",
+		},
+		{
+			name: "UnknownStore",
+			envVars: []v1alpha1.EnvVar{{
+				Name:      "SSM",
+				ValueFrom: &v1alpha1.EnvVarSource{StoreKeyRef: &v1alpha1.StoreKeySelector{Store: "ssm", Key: "param"}},
+			}},
+			errMsg: `no value store named "ssm" is registered`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := builder.buildEnvironmentConfiguration(context.Background(), "team-a", tt.envVars)
+			if tt.errMsg != "" {
+				if err == nil || !contains(err.Error(), tt.errMsg) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, result); diff != "" {
+				t.Errorf("buildEnvironmentConfiguration(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestIsEnvVarOptional(t *testing.T) {
 	builder := NewContainerConfigBuilder().(*defaultContainerConfigBuilder)
 
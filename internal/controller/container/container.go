@@ -17,10 +17,15 @@ limitations under the License.
 package container
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	xpcontroller "github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
@@ -28,74 +33,153 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
 	"github.com/rossigee/provider-docker/apis/container/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
 	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+	"github.com/rossigee/provider-docker/internal/envsource"
+	"github.com/rossigee/provider-docker/internal/features"
+	"github.com/rossigee/provider-docker/internal/shutdown"
 	"github.com/rossigee/provider-docker/internal/tracing"
+	"github.com/rossigee/provider-docker/internal/usage"
+	"github.com/rossigee/provider-docker/internal/webhook"
+	"github.com/rossigee/provider-docker/pkg/labels"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"maps"
+	"regexp"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	errNotContainer = "managed resource is not a Container custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errNewClient    = "cannot create new Docker client"
-	errCreateFailed = "cannot create container"
-	errDeleteFailed = "cannot delete container"
-	errUpdateFailed = "cannot update container"
+	errNotContainer  = "managed resource is not a Container custom resource"
+	errTrackPCUsage  = "cannot track ProviderConfig usage"
+	errGetPC         = "cannot get ProviderConfig"
+	errNewClient     = "cannot create new Docker client"
+	errCreateFailed  = "cannot create container"
+	errDeleteFailed  = "cannot delete container"
+	errUpdateFailed  = "cannot update container"
+	errRemoving      = "cannot create a container that is being removed"
+	errNoVolumeName  = "volumeName must be set, or resolved from volumeRef or volumeSelector"
+	errNoNetworkName = "name must be set, or resolved from networkRef or networkSelector"
+
+	errCreateNotAllowed = "management policies do not permit creating the container"
 
 	// AnnotationKeyExternalName is the annotation key for external names
 	AnnotationKeyExternalName = "crossplane.io/external-name"
+
+	// rollbackTimeout bounds the removal of a container whose creation was
+	// interrupted by shutdown.
+	rollbackTimeout = 30 * time.Second
+
+	// defaultStopTimeout is the seconds a container is given to exit when
+	// stopped, unless it sets a StopGracePeriod.
+	defaultStopTimeout = 10
+
+	defaultBandwidthInterface   = "eth0"
+	defaultBandwidthHelperImage = "nicolaka/netshoot:latest"
+
+	// bandwidthHelperTimeout bounds how long tc is given to shape a
+	// container's traffic.
+	bandwidthHelperTimeout = 30 * time.Second
+)
+
+var (
+	// bandwidthRate matches tc rate values such as 512kbit or 10mbit.
+	bandwidthRate = regexp.MustCompile(`^[0-9]+(bit|kbit|mbit|gbit|bps|kbps|mbps|gbps)$`)
+
+	// interfaceName matches Linux network interface names.
+	interfaceName = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,15}$`)
 )
 
 // ContainerConfigBuilder builds Docker container configuration from Crossplane resources.
 type ContainerConfigBuilder interface {
-	BuildContainerConfig(cr *v1alpha1.Container) (*container.Config, *container.HostConfig, *network.NetworkingConfig, *specs.Platform, error)
+	BuildContainerConfig(ctx context.Context, cr *v1alpha1.Container) (*container.Config, *container.HostConfig, *network.NetworkingConfig, *specs.Platform, error)
 }
 
 // defaultContainerConfigBuilder implements ContainerConfigBuilder.
-type defaultContainerConfigBuilder struct{}
+type defaultContainerConfigBuilder struct {
+	policy    *apisv1beta1.Policy
+	injection *apisv1beta1.ContainerInjection
+
+	// sources resolves valueFrom env vars. The default registry is used
+	// when it is nil.
+	sources *envsource.Registry
+}
 
 // NewContainerConfigBuilder creates a new ContainerConfigBuilder.
 func NewContainerConfigBuilder() ContainerConfigBuilder {
 	return &defaultContainerConfigBuilder{}
 }
 
+// NewContainerConfigBuilderWithPolicy creates a ContainerConfigBuilder that
+// applies and enforces the given ProviderConfig policy.
+func NewContainerConfigBuilderWithPolicy(policy *apisv1beta1.Policy) ContainerConfigBuilder {
+	return &defaultContainerConfigBuilder{policy: policy}
+}
+
+// NewContainerConfigBuilderForProviderConfig creates a ContainerConfigBuilder
+// that applies the policy and injection of the given ProviderConfig.
+func NewContainerConfigBuilderForProviderConfig(pc *apisv1beta1.ProviderConfig) ContainerConfigBuilder {
+	return &defaultContainerConfigBuilder{policy: pc.Spec.Policy, injection: pc.Spec.Injection}
+}
+
 // Setup adds a controller that reconciles Container managed resources.
 func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
 	name := managed.ControllerName(v1alpha1.ContainerGroupKind.Kind)
-
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.ContainerGroupVersionKind),
-		managed.WithExternalConnector(&connector{
-			kube:   mgr.GetClient(),
-			usage:  resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
-			logger: o.Logger,
-		}),
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
+
+	backoff := clients.NewTerminalBackoff(o.PollInterval)
+	requeuer := newRestartRequeuer(mgr.GetClient(), listContainers)
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(backoff.Connector(dryrun.Connector(&connector{
+			kube:     mgr.GetClient(),
+			usage:    resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			logger:   o.Logger,
+			notifier: webhook.NewNotifier(o.Logger),
+			exporter: usage.NewExporter(mgr.GetClient(), o.Logger),
+			recorder: recorder,
+			requeuer: requeuer,
+		}, recorder))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
-		managed.WithRecorder(nil))
+		managed.WithFinalizer(clients.NewUsageFinalizer(mgr.GetClient())),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(newPollIntervalHook(pollIntervals).Interval),
+		managed.WithRecorder(nil),
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.ContainerGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Container{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		WatchesRawSource(requeuer.source()).
+		Complete(ratelimiter.NewReconciler(name, backoff.Reconciler(r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube   client.Client
-	usage  resource.Tracker
-	logger logging.Logger
+	kube     client.Client
+	usage    resource.Tracker
+	logger   logging.Logger
+	notifier *webhook.Notifier
+	exporter *usage.Exporter
+	recorder event.Recorder
+	requeuer *restartRequeuer
 }
 
 // Connect typically produces an ExternalClient by:
@@ -104,7 +188,8 @@ type connector struct {
 // 3. Getting the credentials specified by the ProviderConfig.
 // 4. Using the credentials to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	if _, ok := mg.(*v1alpha1.Container); !ok {
+	cr, ok := mg.(*v1alpha1.Container)
+	if !ok {
 		return nil, errors.New(errNotContainer)
 	}
 
@@ -112,15 +197,43 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
-	dockerClient, err := clients.NewDockerClient(ctx, c.kube, mg)
+	template, err := getTemplate(ctx, c.kube, cr.GetNamespace(), &cr.Spec.ForProvider)
+	if err != nil {
+		return nil, err
+	}
+	params, err := templatedParameters(template, &cr.Spec.ForProvider)
 	if err != nil {
-		return nil, errors.Wrap(err, errNewClient)
+		return nil, err
+	}
+
+	dockerClient, pc, err := connectDocker(ctx, c.kube, mg, params.Failover, &cr.Status.AtProvider)
+	if err != nil {
+		return nil, err
 	}
 
 	return &external{
-		client:        dockerClient,
-		configBuilder: NewContainerConfigBuilder(),
-		logger:        c.logger,
+		client:         withAudit(dockerClient, pc.Spec.Audit, &cr.Status.AtProvider),
+		configBuilder:  NewContainerConfigBuilderForProviderConfig(pc),
+		logger:         c.logger,
+		snapshots:      snapshots,
+		daemons:        daemons,
+		infos:          hostInfo,
+		requeuer:       c.requeuer,
+		host:           providerConfigHost(pc),
+		notifier:       c.notifier,
+		webhooks:       pc.Spec.Webhooks,
+		providerConfig: providerConfigLabel(pc),
+		guardrails:     pc.Spec.Guardrails,
+		naming:         pc.Spec.Naming,
+		usageExport:    pc.Spec.UsageExport,
+		clusterDNS:     pc.Spec.ClusterDNS,
+		exporter:       c.exporter,
+		template:       template,
+		kind:           v1alpha1.ContainerGroupVersionKind,
+		registryAuths:  registryAuths(c.kube, pc),
+		recorder:       c.recorder,
+		kube:           c.kube,
+		readOnly:       dryrun.Enabled(),
 	}, nil
 }
 
@@ -130,6 +243,66 @@ type external struct {
 	client        clients.DockerClient
 	configBuilder ContainerConfigBuilder
 	logger        logging.Logger
+
+	// snapshots shares container listings between the containers on host.
+	snapshots *clients.ContainerSnapshots
+	host      string
+
+	// daemons notices the Docker daemon on host restarting, after which
+	// requeuer requeues the containers that share its ProviderConfig.
+	daemons  *clients.DaemonWatch
+	requeuer *restartRequeuer
+
+	// infos shares what the Docker daemon on host reports about itself.
+	infos *clients.InfoCache
+
+	// Lifecycle transitions of the container, a kind of resource, are
+	// posted to webhooks.
+	notifier *webhook.Notifier
+	webhooks []apisv1beta1.Webhook
+	kind     schema.GroupVersionKind
+
+	// Containers are labelled with the ProviderConfig they were created
+	// through, whose guardrails limit them.
+	providerConfig string
+	guardrails     *apisv1beta1.Guardrails
+
+	// Containers that do not set a name are named by the naming policy of
+	// the ProviderConfig, if it has one.
+	naming *apisv1beta1.ContainerNaming
+
+	// The usage of running containers is sampled and exported as the
+	// ProviderConfig says, if it does.
+	usageExport *apisv1beta1.UsageExport
+	exporter    *usage.Exporter
+
+	// The ports a container publishes are registered as a Service in the
+	// cluster, if the ProviderConfig says to.
+	clusterDNS *apisv1beta1.ClusterDNS
+
+	// template is the configuration of the ContainerTemplate the container
+	// is created from, if any.
+	template *v1alpha1.ContainerParameters
+
+	// Images are pulled with the registry credentials of the ProviderConfig,
+	// and the progress of pulling a container's image is recorded as events.
+	registryAuths clients.RegistryAuthsFunc
+	recorder      event.Recorder
+
+	// kube reads the Secrets and ConfigMaps the container mounts.
+	kube client.Client
+
+	// readOnly is true in dry-run mode, when the provider only observes the
+	// Docker host, and changes nothing while doing so.
+	readOnly bool
+}
+
+// registryAuths returns a function that reads the registry credentials of a
+// ProviderConfig, so that they are only read when an image is pulled.
+func registryAuths(kube client.Client, pc *apisv1beta1.ProviderConfig) clients.RegistryAuthsFunc {
+	return func(ctx context.Context) (map[string]clients.RegistryAuth, error) {
+		return clients.ProviderConfigRegistryAuths(ctx, kube, pc)
+	}
 }
 
 // Disconnect closes any connection to the external resource.
@@ -138,7 +311,7 @@ func (c *external) Disconnect(ctx context.Context) error {
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	_, span := tracing.StartSpan(ctx, "container.observe",
+	ctx, span := tracing.StartSpan(ctx, "container.observe",
 		tracing.SpanAttrs("container", mg.GetName(), "observe")...)
 	defer span.End()
 
@@ -147,36 +320,135 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotContainer)
 	}
 
-	// Get the container ID from external name or status
-	containerID := cr.GetAnnotations()[AnnotationKeyExternalName]
-	if containerID == "" {
-		return managed.ExternalObservation{ResourceExists: false}, nil
+	restore, err := c.withTemplate(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, tracing.RecordError(span, err)
+	}
+	defer restore()
+
+	previous := cr.Status.AtProvider.Phase
+
+	// The external-name holds the container name; legacy resources may still
+	// carry a container ID, which Docker resolves just as well.
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" {
+		obs, err := c.observeMissing(ctx, cr, previous)
+		return obs, tracing.RecordError(span, err)
 	}
 
-	// Inspect the container
-	containerInfo, err := c.client.ContainerInspect(ctx, containerID)
+	// Inspect the container, unless the host's snapshot shows it has not
+	// changed since it was last inspected; the snapshot is dropped when the
+	// host's Docker daemon restarts. A container named by the naming policy
+	// of its ProviderConfig is looked up by that name until its
+	// external-name records it.
+	lookup := externalName
+	if named := policyName(c.naming, cr); named != "" {
+		lookup = named
+	}
+	restart := c.checkDaemon(ctx, cr)
+	containerInfo, err := c.snapshots.Inspect(ctx, c.client, c.host, lookup)
 	if err != nil {
 		// If container not found, it doesn't exist
 		if isNotFound(err) {
-			return managed.ExternalObservation{ResourceExists: false}, nil
+			obs, err := c.observeMissing(ctx, cr, previous)
+			return obs, tracing.RecordError(span, err)
+		}
+		return managed.ExternalObservation{}, tracing.RecordError(span, errors.Wrap(err, "cannot inspect container"))
+	}
+
+	// A container stopped by its Docker daemon restarting is started again
+	recovered, err := c.recoverFromDaemonRestart(ctx, cr, restart, &containerInfo)
+	if err != nil {
+		return managed.ExternalObservation{}, tracing.RecordError(span, err)
+	}
+	if recovered {
+		if containerInfo, err = c.client.ContainerInspect(ctx, containerInfo.ID); err != nil {
+			return managed.ExternalObservation{}, tracing.RecordError(span, errors.Wrap(err, "cannot inspect container"))
 		}
-		return managed.ExternalObservation{}, errors.Wrap(err, "cannot inspect container")
 	}
 
 	// Update the status with observed state
+	previousState := cr.Status.AtProvider.State
 	c.updateStatus(cr, &containerInfo)
+	if err := c.checkLogReadiness(ctx, cr, &containerInfo); err != nil {
+		return managed.ExternalObservation{}, tracing.RecordError(span, err)
+	}
+	if err := c.runPostStartCommands(ctx, cr, &containerInfo); err != nil {
+		return managed.ExternalObservation{}, tracing.RecordError(span, err)
+	}
+	if err := c.checkPostConditions(ctx, cr, &containerInfo); err != nil {
+		return managed.ExternalObservation{}, tracing.RecordError(span, err)
+	}
+	if err := c.checkReadinessProbe(ctx, cr, &containerInfo); err != nil {
+		return managed.ExternalObservation{}, tracing.RecordError(span, err)
+	}
+	c.checkClock(ctx, cr, &containerInfo)
+	if containerInfo.State != nil && containerInfo.State.Running {
+		c.exporter.Export(ctx, c.usageExport, c.client, containerInfo.ID, usage.Resource{
+			Kind:      c.kind.Kind,
+			Name:      cr.GetName(),
+			Namespace: cr.GetNamespace(),
+			Labels:    cr.GetLabels(),
+		})
+	}
+	c.registerClusterDNS(ctx, cr, &containerInfo)
+	c.captureTerminationMessage(ctx, cr, &containerInfo, previousState.FinishedAt)
+	c.captureFailureLogs(ctx, cr, &containerInfo, previousState)
+
+	// Repair the container if it has been unhealthy for too long. A
+	// recreated container is reported missing so that it is created again.
+	recreate, err := c.remediate(ctx, cr, &containerInfo)
+	if err != nil {
+		return managed.ExternalObservation{}, tracing.RecordError(span, err)
+	}
+	if recreate {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// Canonicalize the external-name to the stable container name so that
+	// it survives the container being recreated under a new ID.
+	lateInitialized := false
+	if name := observedContainerName(&containerInfo); name != "" && name != externalName {
+		meta.SetExternalName(cr, name)
+		lateInitialized = true
+	}
 
 	// Check if container is up to date
 	upToDate := c.isUpToDate(cr, &containerInfo)
+	if upToDate {
+		// A bridge peer may have been given a new address
+		if upToDate, err = c.arePeersUpToDate(ctx, cr, &containerInfo); err != nil {
+			return managed.ExternalObservation{}, tracing.RecordError(span, err)
+		}
+	}
+	if upToDate {
+		// A Secret or ConfigMap the container mounts may have changed
+		if upToDate, err = c.areProjectedVolumesUpToDate(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, tracing.RecordError(span, err)
+		}
+	}
+	if upToDate {
+		// Shaping is lost each time the container starts
+		upToDate = isBandwidthUpToDate(cr, &containerInfo)
+	}
+
+	// Outside its maintenance window, drift is reported but not acted on
+	upToDate, err = deferDrift(cr, upToDate, time.Now())
+	if err != nil {
+		return managed.ExternalObservation{}, tracing.RecordError(span, err)
+	}
+
+	c.notifyTransition(ctx, cr, previous)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: upToDate,
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: lateInitialized,
 	}, nil
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	_, span := tracing.StartSpan(ctx, "container.create",
+	ctx, span := tracing.StartSpan(ctx, "container.create",
 		tracing.SpanAttrs("container", mg.GetName(), "create")...)
 	defer span.End()
 
@@ -184,61 +456,284 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotContainer)
 	}
+	if !allows(cr, xpv1.ManagementActionCreate) {
+		return managed.ExternalCreation{}, errors.New(errCreateNotAllowed)
+	}
+
+	restore, err := c.withTemplate(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
+	}
+	defer restore()
+
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	defer done()
+
+	if !c.moveTo(ctx, cr, v1alpha1.PhaseCreating) {
+		return managed.ExternalCreation{}, errors.New(errRemoving)
+	}
 
 	c.logger.Debug("Creating container", "container", cr.Name)
 
+	if err := c.ensureNetworks(ctx, cr.Spec.ForProvider.Networks); err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
+	}
+
+	// Volumes are created implicitly on the container's own host, but must
+	// be recreated on a standby host
+	if fs := cr.Status.AtProvider.Failover; fs != nil && fs.FailedOverAt != nil {
+		if err := c.ensureVolumes(ctx, cr); err != nil {
+			return managed.ExternalCreation{}, tracing.RecordError(span, err)
+		}
+	}
+
+	// Secrets and ConfigMaps are projected into volumes of the container's
+	// own
+	if err := c.createProjectedVolumes(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
+	}
+
 	// Convert Container spec to Docker API types
-	containerConfig, hostConfig, networkingConfig, platform, err := c.configBuilder.BuildContainerConfig(cr)
+	containerConfig, hostConfig, networkingConfig, platform, err := c.configBuilder.BuildContainerConfig(ctx, cr)
 	if err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, "cannot build container configuration")
+		return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, "cannot build container configuration"))
+	}
+	if err := c.protectWorkingSet(ctx, cr.Spec.ForProvider.Resources, hostConfig); err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, "cannot build container configuration"))
+	}
+	if err := c.checkHostSupport(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, "cannot build container configuration"))
+	}
+
+	// The default bridge network has no name resolution, so bridge peers
+	// are resolved through /etc/hosts
+	peerHosts, err := c.peerHosts(ctx, cr.Spec.ForProvider.BridgePeers)
+	if err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
+	}
+	hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, peerHosts...)
+
+	// Label the container so that it is included in the host's snapshots,
+	// and counted against its ProviderConfig's guardrails
+	containerConfig.Labels = withLabel(containerConfig.Labels, labels.ManagedBy, labels.ManagedByProvider)
+	if c.providerConfig != "" {
+		containerConfig.Labels = withLabel(containerConfig.Labels, labels.ProviderConfig, c.providerConfig)
+	}
+
+	// Refuse to create more than the ProviderConfig allows on the host
+	if err := c.checkGuardrails(ctx, hostConfig); err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
+	}
+
+	// An image that is always pulled is pulled before the container is
+	// created
+	if pullPolicy(&cr.Spec.ForProvider) == v1alpha1.PullAlways {
+		c.moveTo(ctx, cr, v1alpha1.PhasePulling)
+		if err := c.pullContainerImage(ctx, cr, containerConfig.Image); err != nil {
+			return managed.ExternalCreation{}, tracing.RecordError(span, err)
+		}
+		c.moveTo(ctx, cr, v1alpha1.PhaseCreating)
 	}
 
 	// Create the container
-	containerName := ""
-	if cr.Spec.ForProvider.Name != nil {
-		containerName = *cr.Spec.ForProvider.Name
+	containerName := desiredContainerName(cr)
+	if named := policyName(c.naming, cr); named != "" {
+		containerName = named
 	}
+	c.snapshots.Forget(c.host, containerName)
 	response, err := c.client.ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, platform, containerName)
+	if clients.IsNoSuchImage(err) {
+		// Pull the missing image, then try again
+		c.moveTo(ctx, cr, v1alpha1.PhasePulling)
+		if err := c.pullContainerImage(ctx, cr, containerConfig.Image); err != nil {
+			return managed.ExternalCreation{}, tracing.RecordError(span, err)
+		}
+		c.moveTo(ctx, cr, v1alpha1.PhaseCreating)
+		response, err = c.client.ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, platform, containerName)
+	}
 	if err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+		return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, errCreateFailed))
+	}
+	cr.Status.AtProvider.ID = response.ID
+
+	// The files of the Secrets and ConfigMaps the container mounts are
+	// written before it starts. A container whose files cannot be written is
+	// removed, to be created again.
+	if err := c.projectVolumes(ctx, cr, true); err != nil {
+		if rerr := c.client.ContainerRemove(ctx, response.ID, container.RemoveOptions{Force: true}); rerr != nil && !isNotFound(rerr) {
+			c.logger.Info("Cannot remove container whose volumes cannot be written", "id", response.ID, "error", rerr)
+		}
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
 	}
 
 	// Start the container if requested
-	if cr.Spec.ForProvider.StartOnCreate == nil || *cr.Spec.ForProvider.StartOnCreate {
+	if cr.Spec.ForProvider.StartOnCreate != nil && !*cr.Spec.ForProvider.StartOnCreate {
+		c.moveTo(ctx, cr, v1alpha1.PhasePending)
+	} else {
+		c.moveTo(ctx, cr, v1alpha1.PhaseStarting)
 		if err := c.client.ContainerStart(ctx, response.ID, container.StartOptions{}); err != nil {
-			return managed.ExternalCreation{}, errors.Wrap(err, "cannot start container")
+			c.rollbackCreate(ctx, response.ID)
+			return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, "cannot start container"))
+		}
+
+		// Bandwidth limits live in the container's network namespace, so
+		// they can only be applied once it is running
+		if cr.Spec.ForProvider.Bandwidth != nil {
+			if err := c.applyBandwidthLimits(ctx, cr, response.ID); err != nil {
+				c.rollbackCreate(ctx, response.ID)
+				return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, "cannot apply bandwidth limits"))
+			}
 		}
 	}
 
-	// Set the external name annotation
-	annotations := cr.GetAnnotations()
-	if annotations == nil {
-		annotations = make(map[string]string)
+	// Docker generates a name when none was requested; look it up so the
+	// external-name is always a name rather than an ID.
+	if containerName == "" {
+		containerName = response.ID
+		if info, err := c.client.ContainerInspect(ctx, response.ID); err == nil {
+			if name := observedContainerName(&info); name != "" {
+				containerName = name
+			}
+		}
 	}
-	annotations[AnnotationKeyExternalName] = response.ID
-	cr.SetAnnotations(annotations)
+
+	// Record the stable name as the external name
+	meta.SetExternalName(cr, containerName)
 
 	return managed.ExternalCreation{}, nil
 }
 
+// DefaultSnapshotTTL is how often the containers on a Docker host are listed
+// by default, to decide whether they need inspecting.
+const DefaultSnapshotTTL = 15 * time.Second
+
+var snapshots = clients.NewContainerSnapshots(DefaultSnapshotTTL, labels.Selector(labels.ManagedBy, labels.ManagedByProvider))
+
+// SetSnapshotTTL sets how often the containers on a Docker host are listed,
+// and so how long a container's inspect result may be reused while the
+// listing shows it unchanged. Zero inspects every container on every
+// reconcile. It must be called before the provider starts reconciling.
+func SetSnapshotTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		snapshots = nil
+		return
+	}
+	snapshots = clients.NewContainerSnapshots(ttl, labels.Selector(labels.ManagedBy, labels.ManagedByProvider))
+}
+
+// providerConfigHost returns the Docker host a ProviderConfig connects to.
+func providerConfigHost(pc *apisv1beta1.ProviderConfig) string {
+	if pc.Spec.Host == nil {
+		return ""
+	}
+	return *pc.Spec.Host
+}
+
+// moveTo moves a container to a phase the provider is driving it to, as
+// setPhase does, and notifies webhooks of the transition.
+func (c *external) moveTo(ctx context.Context, cr *v1alpha1.Container, to v1alpha1.ContainerPhase) bool {
+	previous := cr.Status.AtProvider.Phase
+	if !setPhase(cr, to) {
+		return false
+	}
+	c.notifyTransition(ctx, cr, previous)
+	return true
+}
+
+// notifyTransition notifies webhooks if a container has moved from the
+// previous phase.
+func (c *external) notifyTransition(ctx context.Context, cr *v1alpha1.Container, previous v1alpha1.ContainerPhase) {
+	phase := cr.Status.AtProvider.Phase
+	if phase == previous || phase == "" {
+		return
+	}
+	c.notifier.Notify(ctx, c.webhooks, webhook.Transition{
+		Resource: webhook.Resource{
+			APIVersion: c.kind.GroupVersion().String(),
+			Kind:       c.kind.Kind,
+			Name:       cr.GetName(),
+			Namespace:  cr.GetNamespace(),
+			UID:        string(cr.GetUID()),
+		},
+		Phase:         string(phase),
+		PreviousPhase: string(previous),
+		ContainerID:   cr.Status.AtProvider.ID,
+		Host:          c.host,
+		Time:          time.Now(),
+	})
+}
+
+// withLabel returns a copy of labels with key set to value.
+func withLabel(l map[string]string, key, value string) map[string]string {
+	out := make(map[string]string, len(l)+1)
+	for k, v := range l {
+		out[k] = v
+	}
+	out[key] = value
+	return out
+}
+
+// rollbackCreate removes a container whose creation was interrupted by the
+// provider shutting down. Nothing records its ID, so it would otherwise be
+// left behind holding the container's name.
+func (c *external) rollbackCreate(ctx context.Context, id string) {
+	if ctx.Err() == nil {
+		return
+	}
+
+	rctx, cancel := shutdown.Rollback(ctx, rollbackTimeout)
+	defer cancel()
+	if err := c.client.ContainerRemove(rctx, id, container.RemoveOptions{Force: true}); err != nil && !isNotFound(err) {
+		c.logger.Info("Cannot remove interrupted container", "id", id, "error", err)
+	}
+}
+
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	_, span := tracing.StartSpan(ctx, "container.update",
+	ctx, span := tracing.StartSpan(ctx, "container.update",
 		tracing.SpanAttrs("container", mg.GetName(), "update")...)
 	defer span.End()
 
-	_, ok := mg.(*v1alpha1.Container)
+	cr, ok := mg.(*v1alpha1.Container)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotContainer)
 	}
 
-	// Container updates are not implemented as they require recreation
-	// due to Docker API limitations. Most container config changes
-	// require stopping and recreating the container.
-	return managed.ExternalUpdate{}, errors.New("container update is not implemented")
+	// Drift of a container whose management policies do not permit updates
+	// is never repaired, least of all by recreating the container.
+	if !allows(cr, xpv1.ManagementActionUpdate) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	// Secrets and ConfigMaps that have changed are written into the volumes
+	// of the container as it runs
+	if err := c.refreshProjectedVolumes(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateFailed))
+	}
+
+	recreate, err := c.update(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateFailed))
+	}
+	if !recreate {
+		// Bandwidth limits are applied again once the container runs
+		if err := c.reapplyBandwidthLimits(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateFailed))
+		}
+		return managed.ExternalUpdate{}, nil
+	}
+
+	// The container was removed so that it can be created afresh
+	if _, err := c.Create(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, tracing.RecordError(span, errors.Wrap(err, errUpdateFailed))
+	}
+	return managed.ExternalUpdate{}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
-	_, span := tracing.StartSpan(ctx, "container.delete",
+	ctx, span := tracing.StartSpan(ctx, "container.delete",
 		tracing.SpanAttrs("container", mg.GetName(), "delete")...)
 	defer span.End()
 
@@ -246,36 +741,61 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotContainer)
 	}
+	if !allows(cr, xpv1.ManagementActionDelete) {
+		return managed.ExternalDelete{}, nil
+	}
 
-	containerID := cr.GetAnnotations()[AnnotationKeyExternalName]
+	restore, err := c.withTemplate(cr)
+	if err != nil {
+		return managed.ExternalDelete{}, tracing.RecordError(span, err)
+	}
+	defer restore()
+
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	defer done()
+
+	containerID := meta.GetExternalName(cr)
 	if containerID == "" {
 		return managed.ExternalDelete{}, nil // Nothing to delete
 	}
 
 	c.logger.Debug("Deleting container", "container", cr.Name, "id", containerID)
+	c.moveTo(ctx, cr, v1alpha1.PhaseRemoving)
+	defer c.snapshots.Forget(c.host, containerID)
+	defer c.exporter.Forget(cr.Status.AtProvider.ID)
 
 	// Stop the container first
-	timeout := 10
+	timeout := stopTimeout(&cr.Spec.ForProvider)
 	if err := c.client.ContainerStop(ctx, containerID, container.StopOptions{Timeout: &timeout}); err != nil {
 		if !isNotFound(err) {
-			return managed.ExternalDelete{}, errors.Wrap(err, "cannot stop container")
+			return managed.ExternalDelete{}, tracing.RecordError(span, errors.Wrap(err, "cannot stop container"))
 		}
 	}
 
 	// Remove the container
 	if err := c.client.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true}); err != nil {
 		if !isNotFound(err) {
-			return managed.ExternalDelete{}, errors.Wrap(err, errDeleteFailed)
+			return managed.ExternalDelete{}, tracing.RecordError(span, errors.Wrap(err, errDeleteFailed))
 		}
 	}
 
+	if err := c.removeUnusedNetworks(ctx, cr.Spec.ForProvider.Networks); err != nil {
+		return managed.ExternalDelete{}, tracing.RecordError(span, err)
+	}
+	if err := c.removeProjectedVolumes(ctx, cr); err != nil {
+		return managed.ExternalDelete{}, tracing.RecordError(span, err)
+	}
+
 	return managed.ExternalDelete{}, nil
 }
 
 // Helper functions
 
 // BuildContainerConfig implements ContainerConfigBuilder interface.
-func (b *defaultContainerConfigBuilder) BuildContainerConfig(cr *v1alpha1.Container) (*container.Config, *container.HostConfig, *network.NetworkingConfig, *specs.Platform, error) {
+func (b *defaultContainerConfigBuilder) BuildContainerConfig(ctx context.Context, cr *v1alpha1.Container) (*container.Config, *container.HostConfig, *network.NetworkingConfig, *specs.Platform, error) {
 	config := &container.Config{
 		Image: cr.Spec.ForProvider.Image,
 	}
@@ -295,7 +815,7 @@ func (b *defaultContainerConfigBuilder) BuildContainerConfig(cr *v1alpha1.Contai
 
 	// Environment variables
 	if len(cr.Spec.ForProvider.Environment) > 0 {
-		env, err := b.buildEnvironmentConfiguration(cr.Spec.ForProvider.Environment)
+		env, err := b.buildEnvironmentConfiguration(ctx, cr.GetNamespace(), cr.Spec.ForProvider.Environment)
 		if err != nil {
 			return nil, nil, nil, nil, errors.Wrap(err, "cannot build environment configuration")
 		}
@@ -322,6 +842,15 @@ func (b *defaultContainerConfigBuilder) BuildContainerConfig(cr *v1alpha1.Contai
 		config.Hostname = *cr.Spec.ForProvider.Hostname
 	}
 
+	// Stop signal and grace period
+	if cr.Spec.ForProvider.StopSignal != nil {
+		config.StopSignal = *cr.Spec.ForProvider.StopSignal
+	}
+	if cr.Spec.ForProvider.StopGracePeriod != nil {
+		timeout := stopTimeout(&cr.Spec.ForProvider)
+		config.StopTimeout = &timeout
+	}
+
 	// Exposed ports
 	exposedPorts, portBindings, err := b.buildPortConfiguration(cr.Spec.ForProvider.Ports)
 	if err != nil {
@@ -348,8 +877,13 @@ func (b *defaultContainerConfigBuilder) BuildContainerConfig(cr *v1alpha1.Contai
 		hostConfig.NetworkMode = container.NetworkMode(*cr.Spec.ForProvider.NetworkMode)
 	}
 
+	// Additional /etc/hosts entries
+	if len(cr.Spec.ForProvider.ExtraHosts) > 0 {
+		hostConfig.ExtraHosts = append([]string(nil), cr.Spec.ForProvider.ExtraHosts...)
+	}
+
 	// Volume mounts
-	binds, mounts, err := b.buildVolumeConfiguration(cr.Spec.ForProvider.Volumes)
+	binds, mounts, err := b.buildVolumeConfiguration(client.ObjectKeyFromObject(cr), cr.Spec.ForProvider.Volumes)
 	if err != nil {
 		return nil, nil, nil, nil, errors.Wrap(err, "cannot build volume configuration")
 	}
@@ -362,8 +896,42 @@ func (b *defaultContainerConfigBuilder) BuildContainerConfig(cr *v1alpha1.Contai
 		return nil, nil, nil, nil, errors.Wrap(err, "cannot build network configuration")
 	}
 
+	// Resource limits
+	limits, err := resourceLimits(cr.Spec.ForProvider.Resources)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "cannot build resource limits")
+	}
+	setLimits(&hostConfig.Resources, limits)
+
+	// Privileged mode
+	if cr.Spec.ForProvider.Privileged != nil {
+		hostConfig.Privileged = *cr.Spec.ForProvider.Privileged
+	}
+	hostConfig.DeviceCgroupRules = cr.Spec.ForProvider.DeviceCgroupRules
+	hostConfig.Devices = deviceMappings(cr.Spec.ForProvider.Devices)
+	hostConfig.DeviceRequests = deviceRequests(cr.Spec.ForProvider.DeviceRequests)
+	hostConfig.StorageOpt = maps.Clone(cr.Spec.ForProvider.StorageOpt)
+	if cr.Spec.ForProvider.Runtime != nil {
+		hostConfig.Runtime = *cr.Spec.ForProvider.Runtime
+	}
+	if u := cr.Spec.ForProvider.UsernsMode; u != nil && *u == "host" {
+		hostConfig.UsernsMode = container.UsernsMode(*u)
+	}
+
+	// Log driver
+	hostConfig.LogConfig = logConfig(cr.Spec.ForProvider.LogConfig)
+
+	// Init process
+	hostConfig.Init = cr.Spec.ForProvider.Init
+
+	// Security profile, subject to the ProviderConfig policy
+	profile, err := b.resolveSecurityProfile(&cr.Spec.ForProvider)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "cannot apply security policy")
+	}
+
 	// Security context
-	err = b.buildSecurityConfiguration(cr.Spec.ForProvider.SecurityContext, config, hostConfig)
+	err = b.buildSecurityConfiguration(applySecurityProfile(profile, cr.Spec.ForProvider.SecurityContext), config, hostConfig)
 	if err != nil {
 		return nil, nil, nil, nil, errors.Wrap(err, "cannot build security configuration")
 	}
@@ -374,7 +942,75 @@ func (b *defaultContainerConfigBuilder) BuildContainerConfig(cr *v1alpha1.Contai
 		return nil, nil, nil, nil, errors.Wrap(err, "cannot build health check configuration")
 	}
 
-	return config, hostConfig, networkingConfig, nil, nil
+	// ProviderConfig injection, unless the container opts out
+	if b.injection != nil && cr.GetAnnotations()[labels.AnnotationSkipInjection] != "true" {
+		applyInjection(b.injection, config, hostConfig)
+	}
+	sortConfig(config, hostConfig)
+
+	// Platform of the image
+	var platform *specs.Platform
+	if cr.Spec.ForProvider.Platform != nil {
+		if platform, err = clients.ParsePlatform(*cr.Spec.ForProvider.Platform); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	return config, hostConfig, networkingConfig, platform, nil
+}
+
+// applyInjection adds the ProviderConfig's injected env variables, labels and
+// mounts to a container, without overriding what the container sets itself.
+func applyInjection(inj *apisv1beta1.ContainerInjection, config *container.Config, hostConfig *container.HostConfig) {
+	envSet := make(map[string]bool, len(config.Env))
+	for _, env := range config.Env {
+		envSet[strings.SplitN(env, "=", 2)[0]] = true
+	}
+	for _, env := range inj.Env {
+		if !envSet[env.Name] {
+			config.Env = append(config.Env, env.Name+"="+env.Value)
+		}
+	}
+
+	if len(inj.Labels) > 0 {
+		// The labels map may be shared with the resource spec
+		labels := make(map[string]string, len(config.Labels)+len(inj.Labels))
+		for k, v := range inj.Labels {
+			labels[k] = v
+		}
+		for k, v := range config.Labels {
+			labels[k] = v
+		}
+		config.Labels = labels
+	}
+
+	targets := make(map[string]bool)
+	for _, bind := range hostConfig.Binds {
+		if parts := strings.Split(bind, ":"); len(parts) > 1 {
+			targets[parts[1]] = true
+		}
+	}
+	for _, m := range hostConfig.Mounts {
+		targets[m.Target] = true
+	}
+	for _, im := range inj.Mounts {
+		if targets[im.Target] {
+			continue
+		}
+		m := mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   im.Source,
+			Target:   im.Target,
+			ReadOnly: im.ReadOnly != nil && *im.ReadOnly,
+		}
+		if im.Type != nil {
+			m.Type = mount.Type(*im.Type)
+		}
+		if m.Type == mount.TypeTmpfs {
+			m.Source = ""
+		}
+		hostConfig.Mounts = append(hostConfig.Mounts, m)
+	}
 }
 
 // buildPortConfiguration builds Docker port configuration from Crossplane port specs.
@@ -407,12 +1043,13 @@ func (b *defaultContainerConfigBuilder) buildPortConfiguration(ports []v1alpha1.
 	return exposedPorts, portBindings, nil
 }
 
-// buildVolumeConfiguration builds Docker volume configuration from Crossplane volume specs.
-func (b *defaultContainerConfigBuilder) buildVolumeConfiguration(volumes []v1alpha1.VolumeMount) ([]string, []mount.Mount, error) {
+// buildVolumeConfiguration builds Docker volume configuration from Crossplane volume specs
+// of the container owner.
+func (b *defaultContainerConfigBuilder) buildVolumeConfiguration(owner client.ObjectKey, volumes []v1alpha1.VolumeMount) ([]string, []mount.Mount, error) {
 	binds := make([]string, 0)
 	mounts := make([]mount.Mount, 0)
 
-	for _, volumeSpec := range volumes {
+	for i, volumeSpec := range volumes {
 		readOnly := false
 		if volumeSpec.ReadOnly != nil {
 			readOnly = *volumeSpec.ReadOnly
@@ -429,7 +1066,11 @@ func (b *defaultContainerConfigBuilder) buildVolumeConfiguration(volumes []v1alp
 			binds = append(binds, bind)
 
 		case volumeSpec.VolumeSource.Volume != nil:
-			// Named Docker volume using mounts
+			// Named Docker volume using mounts. Docker would create an
+			// anonymous volume for a reference that is not yet resolved.
+			if volumeSpec.VolumeSource.Volume.VolumeName == "" {
+				return nil, nil, errors.Errorf("spec.forProvider.volumes[%d].source.volume: %s", i, errNoVolumeName)
+			}
 			mountSpec := mount.Mount{
 				Type:     mount.TypeVolume,
 				Source:   volumeSpec.VolumeSource.Volume.VolumeName,
@@ -479,15 +1120,26 @@ func (b *defaultContainerConfigBuilder) buildVolumeConfiguration(volumes []v1alp
 				if mountSpec.TmpfsOptions == nil {
 					mountSpec.TmpfsOptions = &mount.TmpfsOptions{}
 				}
-				mountSpec.TmpfsOptions.SizeBytes, _ = parseByteSize(*volumeSpec.VolumeSource.EmptyDir.SizeLimit)
+				size, err := parseByteSize(*volumeSpec.VolumeSource.EmptyDir.SizeLimit)
+				if err != nil {
+					return nil, nil, errors.Wrapf(err, "spec.forProvider.volumes[%d].source.emptyDir.sizeLimit", i)
+				}
+				mountSpec.TmpfsOptions.SizeBytes = size
 			}
 			mounts = append(mounts, mountSpec)
 
-		case volumeSpec.VolumeSource.Secret != nil || volumeSpec.VolumeSource.ConfigMap != nil:
-			// Secret and ConfigMap mounts will need special handling via init containers
-			// or pre-populated volumes. For now, we'll skip these as they require
-			// Kubernetes integration.
-			continue
+		case isProjected(volumeSpec.VolumeSource):
+			// Secrets and ConfigMaps are written into a Docker volume of the
+			// container's own, kept in memory, which it can only read. The
+			// volume is not populated from the image, so that only their
+			// files are in it.
+			mounts = append(mounts, mount.Mount{
+				Type:          mount.TypeVolume,
+				Source:        projectedVolumeName(owner, volumeSpec.Name),
+				Target:        volumeSpec.MountPath,
+				ReadOnly:      true,
+				VolumeOptions: &mount.VolumeOptions{NoCopy: true},
+			})
 
 		default:
 			return nil, nil, errors.Errorf("unsupported volume source type for volume %s", volumeSpec.Name)
@@ -507,7 +1159,11 @@ func (b *defaultContainerConfigBuilder) buildNetworkConfiguration(networks []v1a
 		EndpointsConfig: make(map[string]*network.EndpointSettings),
 	}
 
-	for _, networkSpec := range networks {
+	for i, networkSpec := range networks {
+		if networkSpec.Name == "" {
+			return nil, errors.Errorf("spec.forProvider.networks[%d]: %s", i, errNoNetworkName)
+		}
+
 		endpointSettings := &network.EndpointSettings{}
 
 		// Set IP address if specified
@@ -566,14 +1222,11 @@ func (b *defaultContainerConfigBuilder) buildSecurityConfiguration(securityConte
 		hostConfig.ReadonlyRootfs = true
 	}
 
-	// Privileged mode
-	if securityContext.AllowPrivilegeEscalation != nil {
-		// Docker doesn't have a direct equivalent, but we can use privileged mode
-		// This is a conservative mapping - if privilege escalation is explicitly disabled,
-		// we ensure the container is not privileged
-		if !*securityContext.AllowPrivilegeEscalation {
-			hostConfig.Privileged = false
-		}
+	// Privilege escalation - a privileged container can always escalate, so
+	// disallowing escalation also rules out privileged mode
+	noNewPrivileges := securityContext.AllowPrivilegeEscalation != nil && !*securityContext.AllowPrivilegeEscalation
+	if noNewPrivileges {
+		hostConfig.Privileged = false
 	}
 
 	// Capabilities
@@ -637,6 +1290,12 @@ func (b *defaultContainerConfigBuilder) buildSecurityConfiguration(securityConte
 		}
 	}
 
+	// no-new-privileges stops setuid binaries and file capabilities from
+	// granting more privileges than the process started with
+	if noNewPrivileges {
+		securityOpts = append(securityOpts, "no-new-privileges:true")
+	}
+
 	// Apply security options
 	if len(securityOpts) > 0 {
 		hostConfig.SecurityOpt = securityOpts
@@ -650,6 +1309,87 @@ func (b *defaultContainerConfigBuilder) buildSecurityConfiguration(securityConte
 	return nil
 }
 
+// resolveSecurityProfile determines the security profile to apply to a
+// container, falling back to the policy default and rejecting containers that
+// are less restrictive than the policy minimum.
+func (b *defaultContainerConfigBuilder) resolveSecurityProfile(params *v1alpha1.ContainerParameters) (string, error) {
+	profile := ""
+	if params.SecurityProfile != nil {
+		profile = *params.SecurityProfile
+	}
+	if profile == "" && b.policy != nil && b.policy.DefaultSecurityProfile != nil {
+		profile = *b.policy.DefaultSecurityProfile
+	}
+
+	privileged := params.Privileged != nil && *params.Privileged
+	if privileged && securityProfileRank(profile) > securityProfileRank(v1alpha1.SecurityProfilePrivileged) {
+		return "", errors.Errorf("security profile %q does not allow privileged mode", profile)
+	}
+
+	if b.policy == nil || b.policy.MinimumSecurityProfile == nil {
+		return profile, nil
+	}
+
+	minimum := *b.policy.MinimumSecurityProfile
+	if profile == "" {
+		profile = minimum
+	}
+	if securityProfileRank(profile) < securityProfileRank(minimum) {
+		return "", errors.Errorf("security profile %q is less restrictive than the minimum %q allowed by the ProviderConfig", profile, minimum)
+	}
+	if privileged && minimum != v1alpha1.SecurityProfilePrivileged {
+		return "", errors.Errorf("privileged mode is not allowed by the ProviderConfig minimum security profile %q", minimum)
+	}
+
+	return profile, nil
+}
+
+// securityProfileRank orders security profiles from least to most
+// restrictive. No profile ranks with privileged.
+func securityProfileRank(profile string) int {
+	switch profile {
+	case v1alpha1.SecurityProfileBaseline:
+		return 1
+	case v1alpha1.SecurityProfileRestricted:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// applySecurityProfile returns the security context with the settings of the
+// given profile filled in wherever the context leaves them unset.
+func applySecurityProfile(profile string, securityContext *v1alpha1.SecurityContext) *v1alpha1.SecurityContext {
+	if profile != v1alpha1.SecurityProfileBaseline && profile != v1alpha1.SecurityProfileRestricted {
+		return securityContext
+	}
+
+	effective := &v1alpha1.SecurityContext{}
+	if securityContext != nil {
+		*effective = *securityContext
+	}
+
+	if effective.AllowPrivilegeEscalation == nil {
+		allowPrivilegeEscalation := false
+		effective.AllowPrivilegeEscalation = &allowPrivilegeEscalation
+	}
+	if effective.SeccompProfile == nil {
+		effective.SeccompProfile = &v1alpha1.SeccompProfile{Type: "RuntimeDefault"}
+	}
+
+	if profile == v1alpha1.SecurityProfileRestricted {
+		if effective.Capabilities == nil {
+			effective.Capabilities = &v1alpha1.Capabilities{Drop: []string{"ALL"}}
+		}
+		if effective.ReadOnlyRootFilesystem == nil {
+			readOnlyRootFilesystem := true
+			effective.ReadOnlyRootFilesystem = &readOnlyRootFilesystem
+		}
+	}
+
+	return effective
+}
+
 // buildHealthCheckConfiguration builds Docker health check configuration from Crossplane health check spec.
 func (b *defaultContainerConfigBuilder) buildHealthCheckConfiguration(healthCheck *v1alpha1.HealthCheck, config *container.Config) error {
 	if healthCheck == nil {
@@ -711,26 +1451,6 @@ func parseDuration(durationStr string) (time.Duration, error) {
 	return duration, nil
 }
 
-// parseByteSize parses a size string like "100Mi", "1Gi" into bytes.
-func parseByteSize(sizeStr string) (int64, error) {
-	// Simple implementation - in production, use a proper parsing library
-	if strings.HasSuffix(sizeStr, "Mi") {
-		val, err := strconv.ParseInt(strings.TrimSuffix(sizeStr, "Mi"), 10, 64)
-		if err != nil {
-			return 0, err
-		}
-		return val * 1024 * 1024, nil
-	}
-	if strings.HasSuffix(sizeStr, "Gi") {
-		val, err := strconv.ParseInt(strings.TrimSuffix(sizeStr, "Gi"), 10, 64)
-		if err != nil {
-			return 0, err
-		}
-		return val * 1024 * 1024 * 1024, nil
-	}
-	return strconv.ParseInt(sizeStr, 10, 64)
-}
-
 func (c *external) updateStatus(cr *v1alpha1.Container, containerInfo *container.InspectResponse) {
 	// Initialize the observation
 	observation := v1alpha1.ContainerObservation{}
@@ -787,11 +1507,78 @@ func (c *external) updateStatus(cr *v1alpha1.Container, containerInfo *container
 	// Network information
 	observation.Networks = c.buildObservedNetworks(containerInfo)
 
+	// Mounts, as Docker made them
+	observation.Mounts = c.buildObservedMounts(containerInfo)
+	if !ignoredFieldsOf(cr).ignores("volumes") {
+		observation.MountDrift = mountDrift(client.ObjectKeyFromObject(cr), cr.Spec.ForProvider.Volumes, containerInfo)
+	}
+
+	// Effective security options, as applied by the Docker daemon
+	if containerInfo.HostConfig != nil && len(containerInfo.HostConfig.SecurityOpt) > 0 {
+		observation.SecurityOpts = append([]string(nil), containerInfo.HostConfig.SecurityOpt...)
+	}
+
+	// Environment the spec sets, as it was injected
+	observation.Environment = c.buildObservedEnvironment(cr.Spec.ForProvider.Environment, containerInfo)
+
 	// Health check information
 	if containerInfo.State.Health != nil {
 		observation.State.Health = c.buildObservedHealth(containerInfo.State.Health)
 	}
 
+	// Phase, which may depend on the phase the provider last set
+	observation.Phase = nextPhase(cr, containerInfo)
+
+	// A readiness log line, post-start commands and post-conditions are
+	// only waited for once each time it starts
+	if seen := cr.Status.AtProvider.LogLineSeenAt; seen != nil &&
+		observation.State.StartedAt != nil && !seen.Before(observation.State.StartedAt) {
+		observation.LogLineSeenAt = seen
+	}
+	// Times in the status are kept to the second
+	if observation.State.StartedAt != nil {
+		started := metav1.NewTime(observation.State.StartedAt.Truncate(time.Second))
+		for _, r := range cr.Status.AtProvider.PostStartCommands {
+			if !r.LastAttemptTime.Before(&started) {
+				observation.PostStartCommands = append(observation.PostStartCommands, r)
+			}
+		}
+	}
+	if passed := cr.Status.AtProvider.PostConditionsPassedAt; passed != nil &&
+		observation.State.StartedAt != nil && !passed.Before(observation.State.StartedAt) {
+		observation.PostConditionsPassedAt = passed
+	}
+	// The result of the readiness probe is kept while the container has
+	// not restarted, so that a probe failing after passing is told apart
+	if result := cr.Status.AtProvider.ReadinessProbe; result != nil && cr.Spec.ForProvider.ReadinessProbe != nil &&
+		observation.State.StartedAt != nil && !result.LastProbeTime.Before(observation.State.StartedAt) {
+		observation.ReadinessProbe = result
+	}
+
+	// Where the container is managed, which is decided on connecting
+	observation.ActiveHost = c.host
+	observation.Failover = cr.Status.AtProvider.Failover
+
+	// The audit log and remediations are kept as they are made, not observed
+	observation.AuditLog = cr.Status.AtProvider.AuditLog
+	observation.Remediation = cr.Status.AtProvider.Remediation
+
+	// The termination message and logs are captured once each time the
+	// container fails
+	observation.TerminationMessage = cr.Status.AtProvider.TerminationMessage
+	observation.LastLogTail = cr.Status.AtProvider.LastLogTail
+
+	// Projected volumes are recorded as they are written
+	observation.ProjectedVolumes = cr.Status.AtProvider.ProjectedVolumes
+
+	// Bandwidth limits are recorded as they are applied
+	observation.Bandwidth = cr.Status.AtProvider.Bandwidth
+
+	// The clock of the container is only compared once each interval of
+	// its clock check
+	observation.ClockCheckedAt = cr.Status.AtProvider.ClockCheckedAt
+	observation.ClockOffset = cr.Status.AtProvider.ClockOffset
+
 	// Update the status
 	cr.Status.AtProvider = observation
 
@@ -810,8 +1597,11 @@ func (c *external) updateStatus(cr *v1alpha1.Container, containerInfo *container
 }
 
 func (c *external) isUpToDate(cr *v1alpha1.Container, containerInfo *container.InspectResponse) bool {
+	// Fields an operator has deliberately changed out of band are not drift
+	ignored := ignoredFieldsOf(cr)
+
 	// Check if the container is based on the desired image
-	if containerInfo.Config.Image != cr.Spec.ForProvider.Image {
+	if containerInfo.Config.Image != cr.Spec.ForProvider.Image && !ignored.ignores("image") {
 		if c.logger != nil {
 			c.logger.Debug("Container image mismatch", "expected", cr.Spec.ForProvider.Image, "actual", containerInfo.Config.Image)
 		}
@@ -819,7 +1609,7 @@ func (c *external) isUpToDate(cr *v1alpha1.Container, containerInfo *container.I
 	}
 
 	// Check restart policy
-	if cr.Spec.ForProvider.RestartPolicy != nil {
+	if cr.Spec.ForProvider.RestartPolicy != nil && !ignored.ignores("restartPolicy") {
 		if containerInfo.HostConfig == nil {
 			if c.logger != nil {
 				c.logger.Debug("Container HostConfig is nil, cannot check restart policy")
@@ -836,7 +1626,7 @@ func (c *external) isUpToDate(cr *v1alpha1.Container, containerInfo *container.I
 		}
 
 		// Check retry count for on-failure policy
-		if expectedPolicy == "on-failure" && cr.Spec.ForProvider.MaximumRetryCount != nil {
+		if expectedPolicy == "on-failure" && cr.Spec.ForProvider.MaximumRetryCount != nil && !ignored.ignores("maximumRetryCount") {
 			if containerInfo.HostConfig.RestartPolicy.MaximumRetryCount != *cr.Spec.ForProvider.MaximumRetryCount {
 				if c.logger != nil {
 					c.logger.Debug("Container retry count mismatch",
@@ -848,8 +1638,32 @@ func (c *external) isUpToDate(cr *v1alpha1.Container, containerInfo *container.I
 		}
 	}
 
+	// Check resource limits
+	if cr.Spec.ForProvider.Resources != nil && !ignored.ignores("resources") {
+		if containerInfo.HostConfig == nil {
+			if c.logger != nil {
+				c.logger.Debug("Container HostConfig is nil, cannot check resource limits")
+			}
+			return false
+		}
+		// Limits that cannot be parsed fail Create rather than Observe
+		if limits, err := resourceLimits(cr.Spec.ForProvider.Resources); err == nil && !limitsMatch(limits, containerInfo.HostConfig.Resources) {
+			if c.logger != nil {
+				c.logger.Debug("Container resource limits mismatch",
+					"expectedMemory", limits.Memory, "actualMemory", containerInfo.HostConfig.Memory,
+					"expectedNanoCPUs", limits.NanoCPUs, "actualNanoCPUs", containerInfo.HostConfig.NanoCPUs,
+					"expectedMemoryReservation", limits.MemoryReservation, "actualMemoryReservation", containerInfo.HostConfig.MemoryReservation,
+					"expectedCPUShares", limits.CPUShares, "actualCPUShares", containerInfo.HostConfig.CPUShares,
+					"expectedPidsLimit", pidsLimit(limits.PidsLimit), "actualPidsLimit", pidsLimit(containerInfo.HostConfig.PidsLimit),
+					"expectedBlkioWeight", limits.BlkioWeight, "actualBlkioWeight", containerInfo.HostConfig.BlkioWeight,
+					"expectedMemorySwap", limits.MemorySwap, "actualMemorySwap", containerInfo.HostConfig.MemorySwap)
+			}
+			return false
+		}
+	}
+
 	// Check environment variables
-	if !c.isEnvironmentUpToDate(cr.Spec.ForProvider.Environment, containerInfo.Config.Env) {
+	if !c.isEnvironmentUpToDate(ignored.environment(cr.Spec.ForProvider.Environment), containerInfo.Config.Env) {
 		if c.logger != nil {
 			c.logger.Debug("Container environment variables mismatch")
 		}
@@ -857,7 +1671,7 @@ func (c *external) isUpToDate(cr *v1alpha1.Container, containerInfo *container.I
 	}
 
 	// Check labels
-	if !c.isLabelsUpToDate(cr.Spec.ForProvider.Labels, containerInfo.Config.Labels) {
+	if !c.isLabelsUpToDate(ignored.labels(cr.Spec.ForProvider.Labels), containerInfo.Config.Labels) {
 		if c.logger != nil {
 			c.logger.Debug("Container labels mismatch")
 		}
@@ -865,7 +1679,7 @@ func (c *external) isUpToDate(cr *v1alpha1.Container, containerInfo *container.I
 	}
 
 	// Check privileged mode
-	if cr.Spec.ForProvider.Privileged != nil {
+	if cr.Spec.ForProvider.Privileged != nil && !ignored.ignores("privileged") {
 		if containerInfo.HostConfig == nil {
 			if c.logger != nil {
 				c.logger.Debug("Container HostConfig is nil, cannot check privileged mode")
@@ -882,6 +1696,98 @@ func (c *external) isUpToDate(cr *v1alpha1.Container, containerInfo *container.I
 		}
 	}
 
+	// Check device cgroup rules
+	if len(cr.Spec.ForProvider.DeviceCgroupRules) > 0 && !ignored.ignores("deviceCgroupRules") {
+		if containerInfo.HostConfig == nil || !sameElements(containerInfo.HostConfig.DeviceCgroupRules, cr.Spec.ForProvider.DeviceCgroupRules) {
+			if c.logger != nil {
+				c.logger.Debug("Container device cgroup rules mismatch",
+					"expected", cr.Spec.ForProvider.DeviceCgroupRules)
+			}
+			return false
+		}
+	}
+
+	// Check log config
+	if lc := cr.Spec.ForProvider.LogConfig; lc != nil && !ignored.ignores("logConfig") {
+		if containerInfo.HostConfig == nil || !logConfigMatch(lc, containerInfo.HostConfig.LogConfig) {
+			if c.logger != nil {
+				c.logger.Debug("Container log config mismatch",
+					"expected", lc.Driver)
+			}
+			return false
+		}
+	}
+
+	// Check devices and device requests
+	if len(cr.Spec.ForProvider.Devices) > 0 && !ignored.ignores("devices") {
+		if containerInfo.HostConfig == nil || !sameDevices(containerInfo.HostConfig.Devices, deviceMappings(cr.Spec.ForProvider.Devices)) {
+			if c.logger != nil {
+				c.logger.Debug("Container devices mismatch",
+					"expected", cr.Spec.ForProvider.Devices)
+			}
+			return false
+		}
+	}
+	if len(cr.Spec.ForProvider.DeviceRequests) > 0 && !ignored.ignores("deviceRequests") {
+		if containerInfo.HostConfig == nil || !deviceRequestsMatch(deviceRequests(cr.Spec.ForProvider.DeviceRequests), containerInfo.HostConfig.DeviceRequests) {
+			if c.logger != nil {
+				c.logger.Debug("Container device requests mismatch",
+					"expected", cr.Spec.ForProvider.DeviceRequests)
+			}
+			return false
+		}
+	}
+
+	// Check volumes
+	if !ignored.ignores("volumes") {
+		if drift := mountDrift(client.ObjectKeyFromObject(cr), cr.Spec.ForProvider.Volumes, containerInfo); len(drift) > 0 {
+			if c.logger != nil {
+				c.logger.Debug("Container mounts mismatch", "drift", drift)
+			}
+			return false
+		}
+	}
+
+	// Check published ports
+	if !ignored.ignores("ports") && !portsMatch(cr.Spec.ForProvider.Ports, containerInfo) {
+		if c.logger != nil {
+			c.logger.Debug("Container ports mismatch",
+				"expected", cr.Spec.ForProvider.Ports)
+		}
+		return false
+	}
+
+	// Check storage options, which only take effect when the container is
+	// created
+	if len(cr.Spec.ForProvider.StorageOpt) > 0 && !ignored.ignores("storageOpt") {
+		if containerInfo.HostConfig == nil || !storageOptMatch(cr.Spec.ForProvider.StorageOpt, containerInfo.HostConfig.StorageOpt) {
+			if c.logger != nil {
+				c.logger.Debug("Container storage options mismatch",
+					"expected", cr.Spec.ForProvider.StorageOpt)
+			}
+			return false
+		}
+	}
+
+	// Check the runtime and user namespace, which also only take effect
+	// when the container is created
+	if r := cr.Spec.ForProvider.Runtime; r != nil && !ignored.ignores("runtime") {
+		if containerInfo.HostConfig == nil || containerInfo.HostConfig.Runtime != *r {
+			if c.logger != nil {
+				c.logger.Debug("Container runtime mismatch", "expected", *r)
+			}
+			return false
+		}
+	}
+	if u := cr.Spec.ForProvider.UsernsMode; u != nil && !ignored.ignores("usernsMode") {
+		if containerInfo.HostConfig == nil || !usernsModeMatch(*u, string(containerInfo.HostConfig.UsernsMode)) {
+			if c.logger != nil {
+				c.logger.Debug("Container user namespace mismatch", "expected", *u)
+			}
+			return false
+		}
+	}
+
 	// Check if container is healthy (if health checks are configured)
 	if containerInfo.State != nil && containerInfo.State.Health != nil {
 		if containerInfo.State.Health.Status == "unhealthy" {
@@ -953,7 +1859,7 @@ func (c *external) isLabelsUpToDate(expectedLabels map[string]string, actualLabe
 }
 
 // buildEnvironmentConfiguration builds environment variables from Crossplane env var specs.
-func (b *defaultContainerConfigBuilder) buildEnvironmentConfiguration(envVars []v1alpha1.EnvVar) ([]string, error) {
+func (b *defaultContainerConfigBuilder) buildEnvironmentConfiguration(ctx context.Context, namespace string, envVars []v1alpha1.EnvVar) ([]string, error) {
 	env := make([]string, 0, len(envVars))
 
 	for _, envVar := range envVars {
@@ -964,11 +1870,11 @@ func (b *defaultContainerConfigBuilder) buildEnvironmentConfiguration(envVars []
 		if envVar.Value != nil {
 			value = *envVar.Value
 		} else if envVar.ValueFrom != nil {
-			// Handle valueFrom - ConfigMap or Secret references
-			value, err = b.resolveEnvVarValue(envVar.Name, envVar.ValueFrom)
+			// Handle valueFrom - ConfigMap, Secret or value store references
+			value, err = b.resolveEnvVarValue(ctx, namespace, envVar.ValueFrom)
 			if err != nil {
 				// If optional and not found, skip this env var
-				if b.isEnvVarOptional(envVar.ValueFrom) && isNotFound(err) {
+				if b.isEnvVarOptional(envVar.ValueFrom) && envsource.IsNotFound(err) {
 					continue
 				}
 				return nil, errors.Wrapf(err, "cannot resolve value for environment variable %s", envVar.Name)
@@ -984,29 +1890,34 @@ func (b *defaultContainerConfigBuilder) buildEnvironmentConfiguration(envVars []
 	return env, nil
 }
 
-// resolveEnvVarValue resolves environment variable value from ConfigMap or Secret.
-func (b *defaultContainerConfigBuilder) resolveEnvVarValue(envVarName string, valueFrom *v1alpha1.EnvVarSource) (string, error) {
-	// NOTE: This is a placeholder implementation for MVP
-	// In a real implementation, we would need:
-	// 1. Access to Kubernetes client
-	// 2. Namespace context (same as Container resource)
-	// 3. Proper error handling for not found vs other errors
-
+// resolveEnvVarValue resolves an environment variable value from a
+// ConfigMap, Secret or registered value store.
+func (b *defaultContainerConfigBuilder) resolveEnvVarValue(ctx context.Context, namespace string, valueFrom *v1alpha1.EnvVarSource) (string, error) {
 	if valueFrom.ConfigMapKeyRef != nil {
 		// NOTE: ConfigMap value resolution needs Kubernetes client
 		// Implementation: mgr.GetClient(), same namespace, optional handling
 		return "", errors.New("ConfigMap valueFrom not yet implemented - requires Kubernetes client integration")
 	}
 
-	if valueFrom.SecretKeyRef != nil {
-		// NOTE: Secret value resolution needs Kubernetes client
-		// Implementation: mgr.GetClient(), same namespace, optional handling
-		return "", errors.New("Secret valueFrom not yet implemented - requires Kubernetes client integration")
+	if ref := valueFrom.SecretKeyRef; ref != nil {
+		return b.envSources().Resolve(ctx, envsource.StoreSecret, envsource.Ref{Namespace: namespace, Name: ref.Name, Key: ref.Key})
+	}
+
+	if ref := valueFrom.StoreKeyRef; ref != nil {
+		return b.envSources().Resolve(ctx, ref.Store, envsource.Ref{Namespace: namespace, Name: ref.Name, Key: ref.Key})
 	}
 
 	return "", errors.New("unknown valueFrom source")
 }
 
+// envSources returns the value stores env vars are resolved from.
+func (b *defaultContainerConfigBuilder) envSources() *envsource.Registry {
+	if b.sources != nil {
+		return b.sources
+	}
+	return envsource.Default()
+}
+
 // isEnvVarOptional checks if an environment variable source is optional.
 func (b *defaultContainerConfigBuilder) isEnvVarOptional(valueFrom *v1alpha1.EnvVarSource) bool {
 	if valueFrom.ConfigMapKeyRef != nil && valueFrom.ConfigMapKeyRef.Optional != nil {
@@ -1015,6 +1926,9 @@ func (b *defaultContainerConfigBuilder) isEnvVarOptional(valueFrom *v1alpha1.Env
 	if valueFrom.SecretKeyRef != nil && valueFrom.SecretKeyRef.Optional != nil {
 		return *valueFrom.SecretKeyRef.Optional
 	}
+	if valueFrom.StoreKeyRef != nil && valueFrom.StoreKeyRef.Optional != nil {
+		return *valueFrom.StoreKeyRef.Optional
+	}
 	return false
 }
 
@@ -1088,6 +2002,73 @@ func (c *external) buildObservedNetworks(containerInfo *container.InspectRespons
 	return networks
 }
 
+// buildObservedMounts builds the observed mounts from Docker container info.
+func (c *external) buildObservedMounts(containerInfo *container.InspectResponse) []v1alpha1.MountInfo {
+	if len(containerInfo.Mounts) == 0 {
+		return nil
+	}
+	mounts := make([]v1alpha1.MountInfo, 0, len(containerInfo.Mounts))
+	for _, m := range containerInfo.Mounts {
+		mounts = append(mounts, v1alpha1.MountInfo{
+			Type:        string(m.Type),
+			Name:        m.Name,
+			Source:      m.Source,
+			Destination: m.Destination,
+			Driver:      m.Driver,
+			RW:          m.RW,
+		})
+	}
+	return mounts
+}
+
+// buildObservedEnvironment builds the observed environment from Docker
+// container info: the variables the spec sets that were found in the
+// container, in the order of the spec. Values taken from Secrets and value
+// stores are redacted, since the status can be read more widely than they
+// can.
+func (c *external) buildObservedEnvironment(spec []v1alpha1.EnvVar, containerInfo *container.InspectResponse) []v1alpha1.ObservedEnvVar {
+	if len(spec) == 0 || containerInfo.Config == nil {
+		return nil
+	}
+	values := make(map[string]string, len(containerInfo.Config.Env))
+	for _, env := range containerInfo.Config.Env {
+		name, value, _ := strings.Cut(env, "=")
+		values[name] = value
+	}
+
+	var observed []v1alpha1.ObservedEnvVar
+	seen := make(map[string]bool, len(spec))
+	for _, env := range slices.Backward(spec) {
+		value, ok := values[env.Name]
+		if !ok || seen[env.Name] {
+			continue
+		}
+		seen[env.Name] = true
+		o := v1alpha1.ObservedEnvVar{Name: env.Name, Value: value, Origin: envVarOrigin(env)}
+		if o.Origin == v1alpha1.EnvVarOriginSecret || o.Origin == v1alpha1.EnvVarOriginStore {
+			o.Value, o.Redacted = "", true
+		}
+		observed = append(observed, o)
+	}
+	slices.Reverse(observed)
+	return observed
+}
+
+// envVarOrigin returns where the value of an environment variable is taken
+// from.
+func envVarOrigin(env v1alpha1.EnvVar) v1alpha1.EnvVarOrigin {
+	switch {
+	case env.Value != nil || env.ValueFrom == nil:
+		return v1alpha1.EnvVarOriginValue
+	case env.ValueFrom.SecretKeyRef != nil:
+		return v1alpha1.EnvVarOriginSecret
+	case env.ValueFrom.ConfigMapKeyRef != nil:
+		return v1alpha1.EnvVarOriginConfigMap
+	default:
+		return v1alpha1.EnvVarOriginStore
+	}
+}
+
 // buildObservedHealth builds the observed health status from Docker health info.
 func (c *external) buildObservedHealth(health *container.Health) *v1alpha1.ContainerHealth {
 	if health == nil {
@@ -1125,6 +2106,296 @@ func (c *external) buildObservedHealth(health *container.Health) *v1alpha1.Conta
 	return containerHealth
 }
 
+// pullImage pulls an image, waiting for the pull to complete.
+func (c *external) pullImage(ctx context.Context, ref string) error {
+	return c.pull(ctx, ref, "", nil)
+}
+
+// applyBandwidthLimits shapes the network traffic of a running container by
+// starting a helper container in its network namespace that configures tc,
+// waiting for it to exit, and recording the shaping applied. The helper is
+// removed once done.
+func (c *external) applyBandwidthLimits(ctx context.Context, cr *v1alpha1.Container, containerID string) error {
+	limits := cr.Spec.ForProvider.Bandwidth
+	script, err := bandwidthScript(limits)
+	if err != nil || script == "" {
+		return err
+	}
+
+	helperImage := defaultBandwidthHelperImage
+	if limits.HelperImage != nil && *limits.HelperImage != "" {
+		helperImage = *limits.HelperImage
+	}
+
+	if err := c.pullImage(ctx, helperImage); err != nil {
+		return errors.Wrap(err, "cannot pull bandwidth helper image")
+	}
+
+	helper, err := c.client.ContainerCreate(ctx,
+		&container.Config{
+			Image:      helperImage,
+			Entrypoint: []string{"sh", "-c"},
+			Cmd:        []string{script},
+			Labels:     map[string]string{labels.HelperFor: containerID},
+		},
+		&container.HostConfig{
+			NetworkMode: container.NetworkMode("container:" + containerID),
+			CapAdd:      []string{"NET_ADMIN"},
+		},
+		nil, nil, "")
+	if err != nil {
+		return errors.Wrap(err, "cannot create bandwidth helper container")
+	}
+	defer func() {
+		if err := c.client.ContainerRemove(ctx, helper.ID, container.RemoveOptions{Force: true}); err != nil && !isNotFound(err) {
+			c.logger.Debug("Cannot remove bandwidth helper container", "id", helper.ID, "error", err)
+		}
+	}()
+
+	if err := c.client.ContainerStart(ctx, helper.ID, container.StartOptions{}); err != nil {
+		return errors.Wrap(err, "cannot start bandwidth helper container")
+	}
+	if err := c.waitForBandwidthHelper(ctx, helper.ID); err != nil {
+		return err
+	}
+
+	info, err := c.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return errors.Wrap(err, "cannot inspect shaped container")
+	}
+	applied := &v1alpha1.AppliedBandwidth{Hash: bandwidthHash(script)}
+	if info.ContainerJSONBase != nil && info.State != nil {
+		applied.StartedAt = info.State.StartedAt
+	}
+	cr.Status.AtProvider.Bandwidth = applied
+	return nil
+}
+
+// waitForBandwidthHelper waits for a bandwidth helper container to exit, and
+// fails with the tail of its logs if tc did not succeed.
+func (c *external) waitForBandwidthHelper(ctx context.Context, helperID string) error {
+	ctx, cancel := context.WithTimeout(ctx, bandwidthHelperTimeout)
+	defer cancel()
+
+	for {
+		info, err := c.client.ContainerInspect(ctx, helperID)
+		if err != nil {
+			return errors.Wrap(err, "cannot inspect bandwidth helper container")
+		}
+		if info.ContainerJSONBase == nil || info.State == nil {
+			return nil
+		}
+		if !info.State.Running {
+			if info.State.ExitCode == 0 {
+				return nil
+			}
+			return errors.Errorf("bandwidth helper exited with code %d: %s", info.State.ExitCode, c.helperLogs(ctx, helperID))
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "bandwidth helper container did not exit")
+		case <-time.After(execPollInterval):
+		}
+	}
+}
+
+// helperLogs returns the tail of the output of a helper container, or
+// nothing if it cannot be read.
+func (c *external) helperLogs(ctx context.Context, helperID string) string {
+	logs, err := c.client.ContainerLogs(ctx, helperID, container.LogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = logs.Close() }()
+	var out bytes.Buffer
+	if _, err := stdcopy.StdCopy(&out, &out, logs); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(tail(out.Bytes(), maxCommandOutput))
+}
+
+// isBandwidthUpToDate reports whether the shaping a running container's spec
+// asks for has been applied since it last started.
+func isBandwidthUpToDate(cr *v1alpha1.Container, info *container.InspectResponse) bool {
+	limits := cr.Spec.ForProvider.Bandwidth
+	if limits == nil || info.ContainerJSONBase == nil || info.State == nil || !info.State.Running {
+		return true
+	}
+	// Invalid limits fail the container's creation
+	script, err := bandwidthScript(limits)
+	if err != nil || script == "" {
+		return true
+	}
+	applied := cr.Status.AtProvider.Bandwidth
+	return applied != nil && applied.Hash == bandwidthHash(script) && applied.StartedAt == info.State.StartedAt
+}
+
+// reapplyBandwidthLimits shapes the network traffic of a running container
+// again if its shaping was lost as it started again, or has changed.
+func (c *external) reapplyBandwidthLimits(ctx context.Context, cr *v1alpha1.Container) error {
+	restore, err := c.withTemplate(cr)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	if cr.Spec.ForProvider.Bandwidth == nil {
+		return nil
+	}
+	info, err := c.client.ContainerInspect(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return errors.Wrap(err, "cannot inspect shaped container")
+	}
+	if isBandwidthUpToDate(cr, &info) {
+		return nil
+	}
+	return errors.Wrap(c.applyBandwidthLimits(ctx, cr, info.ID), "cannot apply bandwidth limits")
+}
+
+// bandwidthHash returns the hash of the tc commands that shape a container.
+func bandwidthHash(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:8])
+}
+
+// bandwidthScript returns the shell script that applies the given bandwidth
+// limits with tc. Egress is shaped with a token bucket filter; ingress can
+// only be policed, so excess inbound traffic is dropped.
+func bandwidthScript(limits *v1alpha1.BandwidthLimits) (string, error) {
+	iface := defaultBandwidthInterface
+	if limits.Interface != nil && *limits.Interface != "" {
+		iface = *limits.Interface
+	}
+	if !interfaceName.MatchString(iface) {
+		return "", errors.Errorf("invalid network interface %q", iface)
+	}
+
+	var cmds []string
+	if limits.Egress != nil {
+		if !bandwidthRate.MatchString(*limits.Egress) {
+			return "", errors.Errorf("invalid egress rate %q", *limits.Egress)
+		}
+		cmds = append(cmds, fmt.Sprintf("tc qdisc replace dev %s root tbf rate %s burst 32kbit latency 400ms", iface, *limits.Egress))
+	}
+	if limits.Ingress != nil {
+		if !bandwidthRate.MatchString(*limits.Ingress) {
+			return "", errors.Errorf("invalid ingress rate %q", *limits.Ingress)
+		}
+		cmds = append(cmds,
+			fmt.Sprintf("(tc qdisc del dev %s ingress 2>/dev/null || true)", iface),
+			fmt.Sprintf("tc qdisc add dev %s handle ffff: ingress", iface),
+			fmt.Sprintf("tc filter add dev %s parent ffff: protocol all u32 match u32 0 0 police rate %s burst 32k drop flowid :1", iface, *limits.Ingress),
+		)
+	}
+
+	return strings.Join(cmds, " && "), nil
+}
+
+// ensureNetworks creates the networks marked CreateIfMissing that don't exist
+// yet, so the container can be attached to them when it is created.
+func (c *external) ensureNetworks(ctx context.Context, networks []v1alpha1.NetworkAttachment) error {
+	for _, n := range networks {
+		// An unresolved network reference is reported when the container
+		// configuration is built.
+		if n.Name == "" || n.CreateIfMissing == nil || !*n.CreateIfMissing {
+			continue
+		}
+
+		_, err := c.client.NetworkInspect(ctx, n.Name, network.InspectOptions{})
+		if err == nil {
+			continue
+		}
+		if !isNotFound(err) {
+			return errors.Wrapf(err, "cannot inspect network %s", n.Name)
+		}
+
+		opts := network.CreateOptions{
+			Driver: "bridge",
+			Labels: map[string]string{labels.ManagedBy: labels.ManagedByProvider},
+		}
+		if n.Driver != nil {
+			opts.Driver = *n.Driver
+		}
+		if n.Subnet != nil {
+			opts.IPAM = &network.IPAM{Config: []network.IPAMConfig{{Subnet: *n.Subnet}}}
+		}
+
+		c.logger.Debug("Creating missing network", "network", n.Name)
+		if _, err := c.client.NetworkCreate(ctx, n.Name, opts); err != nil {
+			// Another container may have created it in the meantime
+			if strings.Contains(strings.ToLower(err.Error()), "already exists") {
+				continue
+			}
+			return errors.Wrapf(err, "cannot create network %s", n.Name)
+		}
+	}
+	return nil
+}
+
+// removeUnusedNetworks removes the networks marked RemoveWhenUnused that the
+// provider created and that no longer have containers attached. Networks
+// without the managed-by label were not created by the provider and are
+// always left alone.
+func (c *external) removeUnusedNetworks(ctx context.Context, networks []v1alpha1.NetworkAttachment) error {
+	for _, n := range networks {
+		if n.Name == "" || n.RemoveWhenUnused == nil || !*n.RemoveWhenUnused {
+			continue
+		}
+
+		info, err := c.client.NetworkInspect(ctx, n.Name, network.InspectOptions{})
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "cannot inspect network %s", n.Name)
+		}
+		if info.Labels[labels.ManagedBy] != labels.ManagedByProvider || len(info.Containers) > 0 {
+			continue
+		}
+
+		c.logger.Debug("Removing unused network", "network", n.Name)
+		if err := c.client.NetworkRemove(ctx, info.ID); err != nil && !isNotFound(err) {
+			return errors.Wrapf(err, "cannot remove network %s", n.Name)
+		}
+	}
+	return nil
+}
+
+// desiredContainerName returns the name a Container should be created with.
+// An explicit forProvider.name wins, followed by the external-name. A legacy
+// external-name that merely records the last observed container ID is
+// replaced by the last observed container name so that recreation is
+// deterministic.
+func desiredContainerName(cr *v1alpha1.Container) string {
+	if cr.Spec.ForProvider.Name != nil && *cr.Spec.ForProvider.Name != "" {
+		return *cr.Spec.ForProvider.Name
+	}
+
+	externalName := meta.GetExternalName(cr)
+	if externalName != "" && externalName == cr.Status.AtProvider.ID {
+		return strings.TrimPrefix(cr.Status.AtProvider.Name, "/")
+	}
+	return externalName
+}
+
+// observedContainerName returns the container name reported by Docker,
+// without the leading slash.
+func observedContainerName(containerInfo *container.InspectResponse) string {
+	if containerInfo.ContainerJSONBase == nil {
+		return ""
+	}
+	return strings.TrimPrefix(containerInfo.Name, "/")
+}
+
+// stopTimeout returns the seconds a container is given to exit after its
+// stop signal before it is killed.
+func stopTimeout(params *v1alpha1.ContainerParameters) int {
+	if params.StopGracePeriod == nil {
+		return defaultStopTimeout
+	}
+	return int(params.StopGracePeriod.Seconds())
+}
+
 func isNotFound(err error) bool {
 	if err == nil {
 		return false
@@ -1133,37 +2404,55 @@ func isNotFound(err error) bool {
 	// Check for Docker API not found errors
 	errorMessage := strings.ToLower(err.Error())
 	return strings.Contains(errorMessage, "not found") ||
-		strings.Contains(errorMessage, "no such container")
+		strings.Contains(errorMessage, "no such container") ||
+		strings.Contains(errorMessage, "no such volume")
 }
 
 // SetupV1Beta1 creates a controller for the v1beta1 (namespaced) Container resource.
 func SetupV1Beta1(mgr ctrl.Manager, o xpcontroller.Options) error {
 	name := managed.ControllerName(v1beta1.ContainerGroupKind.Kind + "-v1beta1")
-
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1beta1.ContainerGroupVersionKind),
-		managed.WithExternalConnector(&v1beta1Connector{
-			kube:   mgr.GetClient(),
-			usage:  resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
-			logger: o.Logger,
-		}),
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
+
+	backoff := clients.NewTerminalBackoff(o.PollInterval)
+	requeuer := newRestartRequeuer(mgr.GetClient(), listV1Beta1Containers)
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(backoff.Connector(dryrun.Connector(&v1beta1Connector{
+			kube:     mgr.GetClient(),
+			usage:    resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			logger:   o.Logger,
+			notifier: webhook.NewNotifier(o.Logger),
+			exporter: usage.NewExporter(mgr.GetClient(), o.Logger),
+			recorder: recorder,
+			requeuer: requeuer,
+		}, recorder))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithFinalizer(clients.NewUsageFinalizer(mgr.GetClient())),
 		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(newPollIntervalHook(pollIntervals).Interval),
 		managed.WithRecorder(nil),
-	)
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1beta1.ContainerGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		For(&v1beta1.Container{}).
-		Complete(r)
+		WatchesRawSource(requeuer.source()).
+		Complete(backoff.Reconciler(r))
 }
 
 // v1beta1Connector creates external connectors for v1beta1 Container resources.
 type v1beta1Connector struct {
-	kube   client.Client
-	usage  resource.Tracker
-	logger logging.Logger
+	kube     client.Client
+	usage    resource.Tracker
+	logger   logging.Logger
+	notifier *webhook.Notifier
+	exporter *usage.Exporter
+	recorder event.Recorder
+	requeuer *restartRequeuer
 }
 
 // Connect returns an ExternalClient capable of interacting with Docker API.
@@ -1173,20 +2462,51 @@ func (c *v1beta1Connector) Connect(ctx context.Context, mg resource.Managed) (ma
 		return nil, errors.New(errNotContainer)
 	}
 
-	// Convert v1beta1 to v1alpha1 for business logic compatibility
-	v1alpha1Container := convertV1Beta1ToV1Alpha1(cr)
+	template, err := getTemplate(ctx, c.kube, cr.GetNamespace(), (*v1alpha1.ContainerParameters)(&cr.Spec.ForProvider))
+	if err != nil {
+		return nil, err
+	}
+	params, err := templatedParameters(template, (*v1alpha1.ContainerParameters)(&cr.Spec.ForProvider))
+	if err != nil {
+		return nil, err
+	}
 
-	// Create Docker client (using the managed resource interface)
-	dockerClient, err := clients.NewDockerClient(ctx, c.kube, v1alpha1Container)
+	// Resolve the ProviderConfig against the namespaced resource itself, so
+	// that its namespace and kind are honoured
+	dockerClient, pc, err := connectDocker(ctx, c.kube, cr,
+		params.Failover, (*v1alpha1.ContainerObservation)(&cr.Status.AtProvider))
 	if err != nil {
-		return nil, errors.Wrap(err, errNewClient)
+		return nil, err
 	}
 
+	// Convert v1beta1 to v1alpha1 for business logic compatibility
+	v1alpha1Container := convertV1Beta1ToV1Alpha1(cr)
+
+	// Audited calls are recorded against the converted resource, whose
+	// status is copied back to the v1beta1 resource.
 	return &v1beta1External{
 		external: external{
-			client:        dockerClient,
-			configBuilder: &defaultContainerConfigBuilder{},
-			logger:        c.logger,
+			client:         withAudit(dockerClient, pc.Spec.Audit, &v1alpha1Container.Status.AtProvider),
+			configBuilder:  NewContainerConfigBuilderForProviderConfig(pc),
+			logger:         c.logger,
+			snapshots:      snapshots,
+			daemons:        daemons,
+			infos:          hostInfo,
+			requeuer:       c.requeuer,
+			host:           providerConfigHost(pc),
+			notifier:       c.notifier,
+			webhooks:       pc.Spec.Webhooks,
+			providerConfig: providerConfigLabel(pc),
+			guardrails:     pc.Spec.Guardrails,
+			naming:         pc.Spec.Naming,
+			usageExport:    pc.Spec.UsageExport,
+			clusterDNS:     pc.Spec.ClusterDNS,
+			exporter:       c.exporter,
+			template:       template,
+			kind:           v1beta1.ContainerGroupVersionKind,
+			registryAuths:  registryAuths(c.kube, pc),
+			recorder:       recordOn(c.recorder, cr),
+			kube:           c.kube,
 		},
 		v1beta1Container:  cr,
 		v1alpha1Container: v1alpha1Container,
@@ -1208,9 +2528,16 @@ func (e *v1beta1External) Observe(ctx context.Context, mg resource.Managed) (man
 		return obs, err
 	}
 
-	// Copy status from v1alpha1 to v1beta1
+	// Copy status, including conditions such as readiness and failed
+	// post-conditions, from v1alpha1 to v1beta1. A container removed to be
+	// recreated still reports the calls and remediation that removed it.
 	if obs.ResourceExists {
 		e.v1beta1Container.Status.AtProvider = v1beta1.ContainerObservation(e.v1alpha1Container.Status.AtProvider)
+		e.v1beta1Container.SetAnnotations(e.v1alpha1Container.GetAnnotations())
+		e.v1beta1Container.SetConditions(e.v1alpha1Container.Status.Conditions...)
+	} else {
+		e.v1beta1Container.Status.AtProvider.AuditLog = e.v1alpha1Container.Status.AtProvider.AuditLog
+		e.v1beta1Container.Status.AtProvider.Remediation = e.v1alpha1Container.Status.AtProvider.Remediation
 	}
 
 	return obs, nil
@@ -1218,17 +2545,40 @@ func (e *v1beta1External) Observe(ctx context.Context, mg resource.Managed) (man
 
 // Create creates the external resource.
 func (e *v1beta1External) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	return e.external.Create(ctx, e.v1alpha1Container)
+	cre, err := e.external.Create(ctx, e.v1alpha1Container)
+	e.v1beta1Container.Status.AtProvider.AuditLog = e.v1alpha1Container.Status.AtProvider.AuditLog
+	e.v1beta1Container.Status.AtProvider.ProjectedVolumes = e.v1alpha1Container.Status.AtProvider.ProjectedVolumes
+	e.v1beta1Container.Status.AtProvider.Bandwidth = e.v1alpha1Container.Status.AtProvider.Bandwidth
+	if c := e.v1alpha1Container.GetCondition(v1alpha1.TypeImagePulled); c.Type == v1alpha1.TypeImagePulled {
+		e.v1beta1Container.SetConditions(c)
+	}
+	if err != nil {
+		return cre, err
+	}
+
+	// Propagate the external name and container ID back to the v1beta1 resource
+	e.v1beta1Container.SetAnnotations(e.v1alpha1Container.GetAnnotations())
+	e.v1beta1Container.Status.AtProvider.ID = e.v1alpha1Container.Status.AtProvider.ID
+	e.v1beta1Container.Status.AtProvider.Phase = e.v1alpha1Container.Status.AtProvider.Phase
+
+	return cre, nil
 }
 
 // Update updates the external resource.
 func (e *v1beta1External) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	return e.external.Update(ctx, e.v1alpha1Container)
+	upd, err := e.external.Update(ctx, e.v1alpha1Container)
+	e.v1beta1Container.Status.AtProvider.AuditLog = e.v1alpha1Container.Status.AtProvider.AuditLog
+	e.v1beta1Container.Status.AtProvider.ProjectedVolumes = e.v1alpha1Container.Status.AtProvider.ProjectedVolumes
+	e.v1beta1Container.Status.AtProvider.Bandwidth = e.v1alpha1Container.Status.AtProvider.Bandwidth
+	return upd, err
 }
 
 // Delete deletes the external resource.
 func (e *v1beta1External) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
-	return e.external.Delete(ctx, e.v1alpha1Container)
+	del, err := e.external.Delete(ctx, e.v1alpha1Container)
+	e.v1beta1Container.Status.AtProvider.Phase = e.v1alpha1Container.Status.AtProvider.Phase
+	e.v1beta1Container.Status.AtProvider.AuditLog = e.v1alpha1Container.Status.AtProvider.AuditLog
+	return del, err
 }
 
 // convertV1Beta1ToV1Alpha1 converts a v1beta1 Container to v1alpha1 for business logic reuse.
@@ -18,11 +18,13 @@ package container
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/system"
@@ -30,10 +32,15 @@ import (
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
 	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/internal/webhook"
+	"github.com/rossigee/provider-docker/pkg/labels"
 	"io"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -41,20 +48,36 @@ import (
 // Mock DockerClient for testing - implements complete DockerClient interface
 type mockDockerClient struct {
 	// Container operations
-	containerCreateFunc  func(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.CreateResponse, error)
-	containerStartFunc   func(ctx context.Context, containerID string, options container.StartOptions) error
-	containerStopFunc    func(ctx context.Context, containerID string, options container.StopOptions) error
-	containerRestartFunc func(ctx context.Context, containerID string, options container.StopOptions) error
-	containerRemoveFunc  func(ctx context.Context, containerID string, options container.RemoveOptions) error
-	containerInspectFunc func(ctx context.Context, containerID string) (container.InspectResponse, error)
-	containerListFunc    func(ctx context.Context, options container.ListOptions) ([]container.Summary, error)
-	containerLogsFunc    func(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error)
-	// containerStatsFunc temporarily disabled
-	// containerStatsFunc    func(ctx context.Context, containerID string, stream bool) (container.StatsResponseReader, error)
-	containerUpdateFunc  func(ctx context.Context, containerID string, updateConfig container.UpdateConfig) (container.UpdateResponse, error)
-	containerRenameFunc  func(ctx context.Context, containerID, newContainerName string) error
-	containerPauseFunc   func(ctx context.Context, containerID string) error
-	containerUnpauseFunc func(ctx context.Context, containerID string) error
+	containerCreateFunc   func(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.CreateResponse, error)
+	containerStartFunc    func(ctx context.Context, containerID string, options container.StartOptions) error
+	containerStopFunc     func(ctx context.Context, containerID string, options container.StopOptions) error
+	containerRestartFunc  func(ctx context.Context, containerID string, options container.StopOptions) error
+	containerRemoveFunc   func(ctx context.Context, containerID string, options container.RemoveOptions) error
+	containerInspectFunc  func(ctx context.Context, containerID string) (container.InspectResponse, error)
+	containerListFunc     func(ctx context.Context, options container.ListOptions) ([]container.Summary, error)
+	containerLogsFunc     func(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error)
+	containerStatsFunc    func(ctx context.Context, containerID string) (container.StatsResponseReader, error)
+	containerUpdateFunc   func(ctx context.Context, containerID string, updateConfig container.UpdateConfig) (container.UpdateResponse, error)
+	containerRenameFunc   func(ctx context.Context, containerID, newContainerName string) error
+	containerPauseFunc    func(ctx context.Context, containerID string) error
+	containerUnpauseFunc  func(ctx context.Context, containerID string) error
+	copyFromContainerFunc func(ctx context.Context, containerID, srcPath string) (io.ReadCloser, container.PathStat, error)
+	copyToContainerFunc   func(ctx context.Context, containerID, dstPath string, content io.Reader, options container.CopyToContainerOptions) error
+	execCreateFunc        func(ctx context.Context, containerID string, options container.ExecOptions) (container.ExecCreateResponse, error)
+	execStartFunc         func(ctx context.Context, execID string, config container.ExecStartOptions) error
+	execInspectFunc       func(ctx context.Context, execID string) (container.ExecInspect, error)
+	execAttachFunc        func(ctx context.Context, execID string, config container.ExecAttachOptions) (types.HijackedResponse, error)
+
+	// Volume operations
+	volumeCreateFunc func(ctx context.Context, options volume.CreateOptions) (volume.Volume, error)
+
+	// Network operations
+	networkCreateFunc  func(ctx context.Context, name string, options network.CreateOptions) (network.CreateResponse, error)
+	networkInspectFunc func(ctx context.Context, networkID string, options network.InspectOptions) (network.Inspect, error)
+	networkRemoveFunc  func(ctx context.Context, networkID string) error
+
+	// System operations
+	infoFunc func(ctx context.Context) (system.Info, error)
 
 	// Close operation
 	closeFunc func() error
@@ -117,10 +140,12 @@ func (m *mockDockerClient) ContainerLogs(ctx context.Context, containerID string
 	return io.NopCloser(strings.NewReader("")), nil
 }
 
-// ContainerStats is temporarily disabled due to complex interface mocking
-// func (m *mockDockerClient) ContainerStats(ctx context.Context, containerID string, stream bool) (container.StatsResponseReader, error) {
-//	return nil, errors.New("stats not implemented in mock")
-// }
+func (m *mockDockerClient) ContainerStatsOneShot(ctx context.Context, containerID string) (container.StatsResponseReader, error) {
+	if m.containerStatsFunc != nil {
+		return m.containerStatsFunc(ctx, containerID)
+	}
+	return container.StatsResponseReader{}, errors.New("stats not implemented in mock")
+}
 
 func (m *mockDockerClient) ContainerUpdate(ctx context.Context, containerID string, updateConfig container.UpdateConfig) (container.UpdateResponse, error) {
 	if m.containerUpdateFunc != nil {
@@ -150,6 +175,48 @@ func (m *mockDockerClient) ContainerUnpause(ctx context.Context, containerID str
 	return nil
 }
 
+func (m *mockDockerClient) CopyFromContainer(ctx context.Context, containerID, srcPath string) (io.ReadCloser, container.PathStat, error) {
+	if m.copyFromContainerFunc != nil {
+		return m.copyFromContainerFunc(ctx, containerID, srcPath)
+	}
+	return nil, container.PathStat{}, errors.New("no such file")
+}
+
+func (m *mockDockerClient) CopyToContainer(ctx context.Context, containerID, dstPath string, content io.Reader, options container.CopyToContainerOptions) error {
+	if m.copyToContainerFunc != nil {
+		return m.copyToContainerFunc(ctx, containerID, dstPath, content, options)
+	}
+	return nil
+}
+
+func (m *mockDockerClient) ContainerExecCreate(ctx context.Context, containerID string, options container.ExecOptions) (container.ExecCreateResponse, error) {
+	if m.execCreateFunc != nil {
+		return m.execCreateFunc(ctx, containerID, options)
+	}
+	return container.ExecCreateResponse{ID: "exec"}, nil
+}
+
+func (m *mockDockerClient) ContainerExecStart(ctx context.Context, execID string, config container.ExecStartOptions) error {
+	if m.execStartFunc != nil {
+		return m.execStartFunc(ctx, execID, config)
+	}
+	return nil
+}
+
+func (m *mockDockerClient) ContainerExecInspect(ctx context.Context, execID string) (container.ExecInspect, error) {
+	if m.execInspectFunc != nil {
+		return m.execInspectFunc(ctx, execID)
+	}
+	return container.ExecInspect{ExecID: execID}, nil
+}
+
+func (m *mockDockerClient) ContainerExecAttach(ctx context.Context, execID string, config container.ExecAttachOptions) (types.HijackedResponse, error) {
+	if m.execAttachFunc != nil {
+		return m.execAttachFunc(ctx, execID, config)
+	}
+	return types.HijackedResponse{}, errors.New("exec attach not mocked")
+}
+
 // Image operations - stub implementations
 func (m *mockDockerClient) ImagePull(ctx context.Context, refStr string, options image.PullOptions) (io.ReadCloser, error) {
 	return io.NopCloser(strings.NewReader("")), nil
@@ -169,6 +236,9 @@ func (m *mockDockerClient) ImageRemove(ctx context.Context, imageID string, opti
 
 // Volume operations - stub implementations
 func (m *mockDockerClient) VolumeCreate(ctx context.Context, options volume.CreateOptions) (volume.Volume, error) {
+	if m.volumeCreateFunc != nil {
+		return m.volumeCreateFunc(ctx, options)
+	}
 	return volume.Volume{}, nil
 }
 
@@ -186,14 +256,23 @@ func (m *mockDockerClient) VolumeList(ctx context.Context, options volume.ListOp
 
 // Network operations - stub implementations
 func (m *mockDockerClient) NetworkCreate(ctx context.Context, name string, options network.CreateOptions) (network.CreateResponse, error) {
+	if m.networkCreateFunc != nil {
+		return m.networkCreateFunc(ctx, name, options)
+	}
 	return network.CreateResponse{}, nil
 }
 
 func (m *mockDockerClient) NetworkInspect(ctx context.Context, networkID string, options network.InspectOptions) (network.Inspect, error) {
+	if m.networkInspectFunc != nil {
+		return m.networkInspectFunc(ctx, networkID, options)
+	}
 	return network.Inspect{}, nil
 }
 
 func (m *mockDockerClient) NetworkRemove(ctx context.Context, networkID string) error {
+	if m.networkRemoveFunc != nil {
+		return m.networkRemoveFunc(ctx, networkID)
+	}
 	return nil
 }
 
@@ -215,6 +294,9 @@ func (m *mockDockerClient) Ping(ctx context.Context) (types.Ping, error) {
 }
 
 func (m *mockDockerClient) Info(ctx context.Context) (system.Info, error) {
+	if m.infoFunc != nil {
+		return m.infoFunc(ctx)
+	}
 	return system.Info{}, nil
 }
 
@@ -222,6 +304,16 @@ func (m *mockDockerClient) ServerVersion(ctx context.Context) (types.Version, er
 	return types.Version{}, nil
 }
 
+func (m *mockDockerClient) DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+	return types.DiskUsage{}, nil
+}
+
+func (m *mockDockerClient) Events(ctx context.Context, options events.ListOptions) (<-chan events.Message, <-chan error) {
+	errs := make(chan error, 1)
+	errs <- io.EOF
+	return make(chan events.Message), errs
+}
+
 // Close operation
 func (m *mockDockerClient) Close() error {
 	if m.closeFunc != nil {
@@ -235,7 +327,7 @@ type mockContainerConfigBuilder struct {
 	buildFunc func(cr *v1alpha1.Container) (*container.Config, *container.HostConfig, *network.NetworkingConfig, *specs.Platform, error)
 }
 
-func (m *mockContainerConfigBuilder) BuildContainerConfig(cr *v1alpha1.Container) (*container.Config, *container.HostConfig, *network.NetworkingConfig, *specs.Platform, error) {
+func (m *mockContainerConfigBuilder) BuildContainerConfig(ctx context.Context, cr *v1alpha1.Container) (*container.Config, *container.HostConfig, *network.NetworkingConfig, *specs.Platform, error) {
 	if m.buildFunc != nil {
 		return m.buildFunc(cr)
 	}
@@ -309,6 +401,8 @@ func TestExternalObserve(t *testing.T) {
 		mockFunc     func() *mockDockerClient
 		wantExists   bool
 		wantUpToDate bool
+		wantLateInit bool
+		wantExtName  string
 		wantError    bool
 		errorMsg     string
 	}{
@@ -354,6 +448,42 @@ func TestExternalObserve(t *testing.T) {
 			wantUpToDate: true,
 			wantError:    false,
 		},
+		{
+			name: "LegacyIDExternalNameCanonicalized",
+			setupMG: func() resource.Managed {
+				return &v1alpha1.Container{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-container",
+						Annotations: map[string]string{
+							AnnotationKeyExternalName: "legacy-container-id",
+						},
+					},
+					Spec: v1alpha1.ContainerSpec{
+						ForProvider: v1alpha1.ContainerParameters{
+							Image: "nginx:latest",
+						},
+					},
+				}
+			},
+			mockFunc: func() *mockDockerClient {
+				return &mockDockerClient{
+					containerInspectFunc: func(ctx context.Context, containerID string) (container.InspectResponse, error) {
+						return container.InspectResponse{
+							ContainerJSONBase: &container.ContainerJSONBase{
+								ID:    "legacy-container-id",
+								Name:  "/my-container",
+								State: &container.State{Status: "running"},
+							},
+							Config: &container.Config{Image: "nginx:latest"},
+						}, nil
+					},
+				}
+			},
+			wantExists:   true,
+			wantUpToDate: true,
+			wantLateInit: true,
+			wantExtName:  "my-container",
+		},
 		{
 			name: "ContainerNotExists_NoExternalName",
 			setupMG: func() resource.Managed {
@@ -438,7 +568,8 @@ func TestExternalObserve(t *testing.T) {
 				logger:        logging.NewNopLogger(),
 			}
 
-			obs, err := e.Observe(context.Background(), tt.setupMG())
+			mg := tt.setupMG()
+			obs, err := e.Observe(context.Background(), mg)
 
 			if tt.wantError {
 				if err == nil {
@@ -463,6 +594,14 @@ func TestExternalObserve(t *testing.T) {
 			if obs.ResourceUpToDate != tt.wantUpToDate {
 				t.Errorf("Observe() ResourceUpToDate = %v, want %v", obs.ResourceUpToDate, tt.wantUpToDate)
 			}
+
+			if obs.ResourceLateInitialized != tt.wantLateInit {
+				t.Errorf("Observe() ResourceLateInitialized = %v, want %v", obs.ResourceLateInitialized, tt.wantLateInit)
+			}
+
+			if tt.wantExtName != "" && mg.GetAnnotations()[AnnotationKeyExternalName] != tt.wantExtName {
+				t.Errorf("Observe() external name = %v, want %v", mg.GetAnnotations()[AnnotationKeyExternalName], tt.wantExtName)
+			}
 		})
 	}
 }
@@ -516,9 +655,123 @@ func TestExternalCreate(t *testing.T) {
 			wantError: false,
 			validateResult: func(cr *v1alpha1.Container) bool {
 				annotations := cr.GetAnnotations()
-				return annotations != nil && annotations[AnnotationKeyExternalName] == "created-container-id"
+				return annotations != nil && annotations[AnnotationKeyExternalName] == "my-container" &&
+					cr.Status.AtProvider.ID == "created-container-id"
+			},
+		},
+		{
+			name: "GeneratedNameUsedAsExternalName",
+			setupMG: func() resource.Managed {
+				return &v1alpha1.Container{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-container"},
+					Spec: v1alpha1.ContainerSpec{
+						ForProvider: v1alpha1.ContainerParameters{
+							Image: "nginx:latest",
+						},
+					},
+				}
+			},
+			mockClient: func() *mockDockerClient {
+				return &mockDockerClient{
+					containerCreateFunc: func(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.CreateResponse, error) {
+						if containerName != "" {
+							return container.CreateResponse{}, errors.New("unexpected container name")
+						}
+						return container.CreateResponse{ID: "generated-container-id"}, nil
+					},
+					containerInspectFunc: func(ctx context.Context, containerID string) (container.InspectResponse, error) {
+						return container.InspectResponse{
+							ContainerJSONBase: &container.ContainerJSONBase{
+								ID:   containerID,
+								Name: "/happy_turing",
+							},
+						}, nil
+					},
+				}
+			},
+			mockBuilder: func() *mockContainerConfigBuilder { return &mockContainerConfigBuilder{} },
+			validateResult: func(cr *v1alpha1.Container) bool {
+				return cr.GetAnnotations()[AnnotationKeyExternalName] == "happy_turing" &&
+					cr.Status.AtProvider.ID == "generated-container-id"
 			},
 		},
+		{
+			name: "BandwidthLimitsAppliedByHelper",
+			setupMG: func() resource.Managed {
+				return &v1alpha1.Container{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-container"},
+					Spec: v1alpha1.ContainerSpec{
+						ForProvider: v1alpha1.ContainerParameters{
+							Image: "nginx:latest",
+							Name:  stringPtrCtrl("my-container"),
+							Bandwidth: &v1alpha1.BandwidthLimits{
+								Egress:  stringPtrCtrl("10mbit"),
+								Ingress: stringPtrCtrl("20mbit"),
+							},
+						},
+					},
+				}
+			},
+			mockClient: func() *mockDockerClient {
+				return &mockDockerClient{
+					containerCreateFunc: func(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.CreateResponse, error) {
+						if containerName == "my-container" {
+							return container.CreateResponse{ID: "created-container-id"}, nil
+						}
+						// The tc helper shares the container's network namespace
+						if hostConfig.NetworkMode != "container:created-container-id" || config.Labels[labels.HelperFor] != "created-container-id" {
+							return container.CreateResponse{}, errors.New("unexpected helper container configuration")
+						}
+						script := strings.Join(config.Cmd, " ")
+						if !strings.Contains(script, "tbf rate 10mbit") || !strings.Contains(script, "police rate 20mbit") {
+							return container.CreateResponse{}, errors.Errorf("unexpected helper script %q", script)
+						}
+						return container.CreateResponse{ID: "helper-container-id"}, nil
+					},
+				}
+			},
+			mockBuilder: func() *mockContainerConfigBuilder { return &mockContainerConfigBuilder{} },
+			validateResult: func(cr *v1alpha1.Container) bool {
+				return cr.Status.AtProvider.ID == "created-container-id" &&
+					cr.Status.AtProvider.Bandwidth != nil && cr.Status.AtProvider.Bandwidth.Hash != ""
+			},
+		},
+		{
+			name: "BandwidthHelperFailed",
+			setupMG: func() resource.Managed {
+				return &v1alpha1.Container{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-container"},
+					Spec: v1alpha1.ContainerSpec{
+						ForProvider: v1alpha1.ContainerParameters{
+							Image:     "nginx:latest",
+							Name:      stringPtrCtrl("my-container"),
+							Bandwidth: &v1alpha1.BandwidthLimits{Egress: stringPtrCtrl("10mbit")},
+						},
+					},
+				}
+			},
+			mockClient: func() *mockDockerClient {
+				return &mockDockerClient{
+					containerCreateFunc: func(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.CreateResponse, error) {
+						if containerName == "my-container" {
+							return container.CreateResponse{ID: "created-container-id"}, nil
+						}
+						return container.CreateResponse{ID: "helper-container-id"}, nil
+					},
+					containerInspectFunc: func(ctx context.Context, containerID string) (container.InspectResponse, error) {
+						if containerID == "helper-container-id" {
+							return container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+								State: &container.State{Status: "exited", ExitCode: 2},
+							}}, nil
+						}
+						return container.InspectResponse{}, nil
+					},
+				}
+			},
+			mockBuilder: func() *mockContainerConfigBuilder { return &mockContainerConfigBuilder{} },
+			wantError:   true,
+			errorMsg:    "bandwidth helper exited with code 2",
+		},
 		{
 			name: "InvalidManagedResource",
 			setupMG: func() resource.Managed {
@@ -690,6 +943,132 @@ func TestExternalCreateErrorHandling(t *testing.T) {
 	}
 }
 
+func TestExternalCreatePullsMissingImage(t *testing.T) {
+	creates := 0
+	ext := &external{
+		client: &mockDockerClient{
+			containerCreateFunc: func(_ context.Context, _ *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+				creates++
+				if creates == 1 {
+					return container.CreateResponse{}, errors.New("Error response from daemon: No such image: nginx:latest")
+				}
+				return container.CreateResponse{ID: "test-container-id"}, nil
+			},
+		},
+		configBuilder: &defaultContainerConfigBuilder{},
+		logger:        logging.NewNopLogger(),
+	}
+
+	cr := &v1alpha1.Container{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-container"},
+		Spec: v1alpha1.ContainerSpec{
+			ForProvider: v1alpha1.ContainerParameters{Image: "nginx:latest"},
+		},
+	}
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if creates != 2 {
+		t.Errorf("Create() made %d ContainerCreate calls, want 2", creates)
+	}
+	if cr.Status.AtProvider.Phase != v1alpha1.PhaseStarting {
+		t.Errorf("Create() phase = %q, want %q", cr.Status.AtProvider.Phase, v1alpha1.PhaseStarting)
+	}
+}
+
+func TestExternalCreateNotifiesWebhooks(t *testing.T) {
+	var phases []string
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var tr webhook.Transition
+		if err := json.NewDecoder(r.Body).Decode(&tr); err != nil {
+			t.Errorf("cannot decode transition: %v", err)
+		}
+		if tr.Resource.Kind != v1alpha1.ContainerKind || tr.Resource.Name != "test-container" {
+			t.Errorf("transition resource = %+v", tr.Resource)
+		}
+		phases = append(phases, tr.PreviousPhase+"->"+tr.Phase)
+	}))
+	defer srv.Close()
+
+	ext := &external{
+		client:        &mockDockerClient{},
+		configBuilder: &defaultContainerConfigBuilder{},
+		logger:        logging.NewNopLogger(),
+		notifier:      webhook.NewNotifier(logging.NewNopLogger()),
+		webhooks:      []apisv1beta1.Webhook{{URL: srv.URL}},
+		kind:          v1alpha1.ContainerGroupVersionKind,
+	}
+
+	cr := &v1alpha1.Container{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-container"},
+		Spec: v1alpha1.ContainerSpec{
+			ForProvider: v1alpha1.ContainerParameters{Image: "nginx:latest"},
+		},
+	}
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	want := []string{"->Creating", "Creating->Starting"}
+	if strings.Join(phases, ",") != strings.Join(want, ",") {
+		t.Errorf("Create() notified %v, want %v", phases, want)
+	}
+}
+
+func TestExternalCreateRollback(t *testing.T) {
+	tests := []struct {
+		name       string
+		cancel     bool
+		wantRemove bool
+	}{
+		{name: "start interrupted by shutdown", cancel: true, wantRemove: true},
+		{name: "start fails", cancel: false, wantRemove: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var removed string
+			ext := &external{
+				client: &mockDockerClient{
+					containerStartFunc: func(_ context.Context, _ string, _ container.StartOptions) error {
+						if tt.cancel {
+							cancel()
+							return context.Canceled
+						}
+						return errors.New("docker start failed")
+					},
+					containerRemoveFunc: func(ctx context.Context, containerID string, _ container.RemoveOptions) error {
+						if ctx.Err() != nil {
+							t.Errorf("ContainerRemove() called with a done context")
+						}
+						removed = containerID
+						return nil
+					},
+				},
+				configBuilder: &defaultContainerConfigBuilder{},
+				logger:        logging.NewNopLogger(),
+			}
+
+			cr := &v1alpha1.Container{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-container"},
+				Spec: v1alpha1.ContainerSpec{
+					ForProvider: v1alpha1.ContainerParameters{Image: "nginx:latest"},
+				},
+			}
+			if _, err := ext.Create(ctx, cr); err == nil {
+				t.Fatal("Create() error = nil, want error")
+			}
+
+			if got := removed == "test-container-id"; got != tt.wantRemove {
+				t.Errorf("Create() removed %q, want removal %v", removed, tt.wantRemove)
+			}
+		})
+	}
+}
+
 func TestExternalDeleteErrorHandling(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -791,30 +1170,107 @@ func TestExternalDeleteErrorHandling(t *testing.T) {
 	}
 }
 
-func TestExternalUpdateNotImplemented(t *testing.T) {
-	logger := logging.NewNopLogger()
-	ext := &external{
-		client: &mockDockerClient{},
-		logger: logger,
+func TestManagedNetworks(t *testing.T) {
+	newContainer := func() *v1alpha1.Container {
+		return &v1alpha1.Container{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-container",
+				Annotations: map[string]string{
+					"crossplane.io/external-name": "test-container",
+				},
+			},
+			Spec: v1alpha1.ContainerSpec{
+				ForProvider: v1alpha1.ContainerParameters{
+					Image: "nginx:latest",
+					Networks: []v1alpha1.NetworkAttachment{
+						{
+							Name:             "app-net",
+							CreateIfMissing:  boolPtr(true),
+							Subnet:           stringPtrCtrl("10.10.0.0/24"),
+							RemoveWhenUnused: boolPtr(true),
+						},
+						{Name: "existing-net"},
+					},
+				},
+			},
+		}
 	}
 
-	mg := &v1alpha1.Container{
-		ObjectMeta: metav1.ObjectMeta{Name: "test-container"},
-		Spec: v1alpha1.ContainerSpec{
-			ForProvider: v1alpha1.ContainerParameters{
-				Image: "nginx:latest",
+	t.Run("MissingNetworkCreatedBeforeContainer", func(t *testing.T) {
+		var created []string
+		mock := &mockDockerClient{
+			networkInspectFunc: func(ctx context.Context, networkID string, options network.InspectOptions) (network.Inspect, error) {
+				return network.Inspect{}, errors.New("network " + networkID + " not found")
 			},
-		},
-	}
+			networkCreateFunc: func(ctx context.Context, name string, options network.CreateOptions) (network.CreateResponse, error) {
+				if options.Labels[labels.ManagedBy] != labels.ManagedByProvider {
+					return network.CreateResponse{}, errors.New("network created without the managed-by label")
+				}
+				if options.Driver != "bridge" || options.IPAM == nil || options.IPAM.Config[0].Subnet != "10.10.0.0/24" {
+					return network.CreateResponse{}, errors.New("unexpected network options")
+				}
+				created = append(created, name)
+				return network.CreateResponse{ID: "app-net-id"}, nil
+			},
+			containerCreateFunc: func(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.CreateResponse, error) {
+				if len(created) != 1 {
+					return container.CreateResponse{}, errors.New("container created before its network")
+				}
+				return container.CreateResponse{ID: "test-container-id"}, nil
+			},
+		}
+		ext := &external{client: mock, configBuilder: &defaultContainerConfigBuilder{}, logger: logging.NewNopLogger()}
 
-	_, err := ext.Update(context.Background(), mg)
+		if _, err := ext.Create(context.Background(), newContainer()); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if len(created) != 1 || created[0] != "app-net" {
+			t.Errorf("Create() created networks = %v, want [app-net]", created)
+		}
+	})
 
-	if err == nil {
-		t.Errorf("Update() expected error for not implemented, got nil")
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		containers  map[string]network.EndpointResource
+		wantRemoved bool
+	}{
+		{
+			name:        "UnusedManagedNetworkRemoved",
+			labels:      map[string]string{labels.ManagedBy: labels.ManagedByProvider},
+			wantRemoved: true,
+		},
+		{
+			name:       "ManagedNetworkInUseKept",
+			labels:     map[string]string{labels.ManagedBy: labels.ManagedByProvider},
+			containers: map[string]network.EndpointResource{"other-id": {Name: "other"}},
+		},
+		{
+			name: "UnmanagedNetworkKept",
+		},
 	}
 
-	if !strings.Contains(err.Error(), "not implemented") {
-		t.Errorf("Update() error = %v, want error containing 'not implemented'", err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var removed []string
+			mock := &mockDockerClient{
+				networkInspectFunc: func(ctx context.Context, networkID string, options network.InspectOptions) (network.Inspect, error) {
+					return network.Inspect{ID: networkID + "-id", Name: networkID, Labels: tt.labels, Containers: tt.containers}, nil
+				},
+				networkRemoveFunc: func(ctx context.Context, networkID string) error {
+					removed = append(removed, networkID)
+					return nil
+				},
+			}
+			ext := &external{client: mock, configBuilder: &defaultContainerConfigBuilder{}, logger: logging.NewNopLogger()}
+
+			if _, err := ext.Delete(context.Background(), newContainer()); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if got := len(removed) == 1 && removed[0] == "app-net-id"; got != tt.wantRemoved {
+				t.Errorf("Delete() removed networks = %v, want removed %v", removed, tt.wantRemoved)
+			}
+		})
 	}
 }
 
@@ -876,7 +1332,7 @@ func TestBuildContainerConfigEdgeCases(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			configBuilder := &mockContainerConfigBuilder{}
-			containerConfig, _, _, _, err := configBuilder.BuildContainerConfig(tt.cr)
+			containerConfig, _, _, _, err := configBuilder.BuildContainerConfig(context.Background(), tt.cr)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("BuildContainerConfig() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/apis/container/v1beta1"
+	networkv1alpha1 "github.com/rossigee/provider-docker/apis/network/v1alpha1"
+	volumev1alpha1 "github.com/rossigee/provider-docker/apis/volume/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const errGetDependency = "cannot get dependency"
+
+// observeMissing observes a container that does not exist. It is created,
+// unless it is waiting for its dependencies, in which case it is reported as
+// existing and up to date so that it is not created yet.
+func (c *external) observeMissing(ctx context.Context, cr *v1alpha1.Container, previous v1alpha1.ContainerPhase) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(cr) || len(cr.Spec.ForProvider.DependsOn) == 0 {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	waiting, err := c.waitForDependencies(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !waiting {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	c.notifyTransition(ctx, cr, previous)
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+// waitForDependencies keeps a container that does not exist Pending, and
+// unavailable, until every managed resource it depends on is Ready. It
+// reports whether the container is still waiting.
+func (c *external) waitForDependencies(ctx context.Context, cr *v1alpha1.Container) (bool, error) {
+	for _, dep := range cr.Spec.ForProvider.DependsOn {
+		if dep.Kind == v1alpha1.ContainerKind && dep.Name == cr.GetName() {
+			return false, errors.Errorf("spec.forProvider.dependsOn: container %s depends on itself", dep.Name)
+		}
+		obj, key := c.dependency(cr, dep)
+		if obj == nil {
+			return false, errors.Errorf("spec.forProvider.dependsOn: unknown kind %q", dep.Kind)
+		}
+		msg := fmt.Sprintf("Waiting for %s %s to be ready", dep.Kind, dep.Name)
+		if err := c.kube.Get(ctx, key, obj); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return false, errors.Wrapf(err, "%s %s %s", errGetDependency, dep.Kind, dep.Name)
+			}
+			msg = fmt.Sprintf("Waiting for %s %s to exist", dep.Kind, dep.Name)
+		} else if obj.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue {
+			continue
+		}
+		cr.Status.AtProvider.Phase = v1alpha1.PhasePending
+		cr.SetConditions(xpv1.Unavailable().WithMessage(msg))
+		return true, nil
+	}
+	return false, nil
+}
+
+// dependency returns an empty managed resource of the kind a container
+// depends on, and the key it is found by. Containers are of the API group of
+// the container's own kind, in its namespace. Volumes and Networks are
+// cluster scoped, as they are when a container references them.
+func (c *external) dependency(cr *v1alpha1.Container, dep v1alpha1.Dependency) (resource.Managed, types.NamespacedName) {
+	switch dep.Kind {
+	case v1alpha1.ContainerKind:
+		key := types.NamespacedName{Namespace: cr.GetNamespace(), Name: dep.Name}
+		if c.kind.Group == v1beta1.Group {
+			return &v1beta1.Container{}, key
+		}
+		return &v1alpha1.Container{}, key
+	case volumev1alpha1.VolumeKind:
+		return &volumev1alpha1.Volume{}, types.NamespacedName{Name: dep.Name}
+	case networkv1alpha1.NetworkKind:
+		return &networkv1alpha1.Network{}, types.NamespacedName{Name: dep.Name}
+	}
+	return nil, types.NamespacedName{}
+}
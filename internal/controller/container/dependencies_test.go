@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-docker/apis"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/apis/container/v1beta1"
+	volumev1alpha1 "github.com/rossigee/provider-docker/apis/volume/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestObserveMissingWaitsForDependencies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apis.AddToScheme(scheme)
+
+	ready := &volumev1alpha1.Volume{ObjectMeta: metav1.ObjectMeta{Name: "data"}}
+	ready.SetConditions(xpv1.Available())
+	db := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"}}
+	db.SetConditions(xpv1.Creating())
+	api := &v1beta1.Container{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"}}
+	api.SetConditions(xpv1.Available())
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ready, db, api).Build()
+
+	tests := map[string]struct {
+		kind       string
+		deps       []v1alpha1.Dependency
+		wantExists bool
+		wantMsg    string
+		wantErr    bool
+	}{
+		"NoDependencies": {},
+		"Ready": {
+			deps: []v1alpha1.Dependency{{Kind: "Volume", Name: "data"}},
+		},
+		"NotReady": {
+			deps:       []v1alpha1.Dependency{{Kind: "Volume", Name: "data"}, {Kind: "Container", Name: "db"}},
+			wantExists: true,
+			wantMsg:    "Waiting for Container db to be ready",
+		},
+		"Missing": {
+			deps:       []v1alpha1.Dependency{{Kind: "Network", Name: "backend"}},
+			wantExists: true,
+			wantMsg:    "Waiting for Network backend to exist",
+		},
+		"NamespacedReady": {
+			kind: v1beta1.Group,
+			deps: []v1alpha1.Dependency{{Kind: "Container", Name: "api"}, {Kind: "Volume", Name: "data"}},
+		},
+		"Self": {
+			deps:    []v1alpha1.Dependency{{Kind: "Container", Name: "web"}},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &external{kube: kube, kind: v1alpha1.ContainerGroupVersionKind}
+			if tt.kind == v1beta1.Group {
+				c.kind = v1beta1.ContainerGroupVersionKind
+			}
+			cr := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}}
+			cr.Spec.ForProvider.DependsOn = tt.deps
+
+			obs, err := c.observeMissing(context.Background(), cr, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("observeMissing() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if obs.ResourceExists != tt.wantExists {
+				t.Errorf("observeMissing() ResourceExists = %v, want %v", obs.ResourceExists, tt.wantExists)
+			}
+			if !tt.wantExists {
+				return
+			}
+			if !obs.ResourceUpToDate {
+				t.Error("observeMissing() reported a waiting container out of date, which would update it")
+			}
+			if cr.Status.AtProvider.Phase != v1alpha1.PhasePending {
+				t.Errorf("observeMissing() phase = %q, want Pending", cr.Status.AtProvider.Phase)
+			}
+			if c := cr.GetCondition(xpv1.TypeReady); c.Status != corev1.ConditionFalse || c.Message != tt.wantMsg {
+				t.Errorf("observeMissing() Ready condition = %v, want unavailable with %q", c, tt.wantMsg)
+			}
+		})
+	}
+}
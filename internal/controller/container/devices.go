@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"maps"
+	"slices"
+)
+
+const (
+	// defaultCgroupPermissions are the access a container has to a device
+	// that does not say, as with docker run --device.
+	defaultCgroupPermissions = "rwm"
+
+	// defaultDeviceCapability is the capability requested devices have
+	// when a request does not say, as with docker run --gpus.
+	defaultDeviceCapability = "gpu"
+)
+
+// deviceMappings converts the devices of a container spec to those of its
+// host config, filling in the defaults Docker would.
+func deviceMappings(devices []v1alpha1.DeviceMapping) []container.DeviceMapping {
+	if len(devices) == 0 {
+		return nil
+	}
+	mappings := make([]container.DeviceMapping, 0, len(devices))
+	for _, d := range devices {
+		m := container.DeviceMapping{
+			PathOnHost:        d.HostPath,
+			PathInContainer:   d.ContainerPath,
+			CgroupPermissions: d.CgroupPermissions,
+		}
+		if m.PathInContainer == "" {
+			m.PathInContainer = d.HostPath
+		}
+		if m.CgroupPermissions == "" {
+			m.CgroupPermissions = defaultCgroupPermissions
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings
+}
+
+// deviceRequests converts the device requests of a container spec to those
+// of its host config. A request for neither a count nor device IDs is for
+// all devices.
+func deviceRequests(requests []v1alpha1.DeviceRequest) []container.DeviceRequest {
+	if len(requests) == 0 {
+		return nil
+	}
+	out := make([]container.DeviceRequest, 0, len(requests))
+	for _, r := range requests {
+		req := container.DeviceRequest{
+			Driver:    r.Driver,
+			DeviceIDs: slices.Clone(r.DeviceIDs),
+			Options:   maps.Clone(r.Options),
+		}
+		switch {
+		case r.Count != nil:
+			req.Count = int(*r.Count)
+		case len(r.DeviceIDs) == 0:
+			req.Count = -1
+		}
+		caps := r.Capabilities
+		if len(caps) == 0 {
+			caps = []string{defaultDeviceCapability}
+		}
+		req.Capabilities = [][]string{slices.Clone(caps)}
+		out = append(out, req)
+	}
+	return out
+}
+
+// deviceRequestsMatch reports whether a container has the device requests
+// its spec sets. Options Docker reports as empty match options not set.
+func deviceRequestsMatch(want, have []container.DeviceRequest) bool {
+	return slices.EqualFunc(want, have, func(w, h container.DeviceRequest) bool {
+		return w.Driver == h.Driver && w.Count == h.Count &&
+			slices.Equal(w.DeviceIDs, h.DeviceIDs) &&
+			slices.EqualFunc(w.Capabilities, h.Capabilities, slices.Equal[[]string]) &&
+			maps.Equal(w.Options, h.Options)
+	})
+}
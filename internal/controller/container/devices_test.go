@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"testing"
+)
+
+func TestDeviceMappings(t *testing.T) {
+	got := deviceMappings([]v1alpha1.DeviceMapping{
+		{HostPath: "/dev/ttyUSB0"},
+		{HostPath: "/dev/video0", ContainerPath: "/dev/camera", CgroupPermissions: "r"},
+	})
+	want := []container.DeviceMapping{
+		{PathOnHost: "/dev/ttyUSB0", PathInContainer: "/dev/ttyUSB0", CgroupPermissions: "rwm"},
+		{PathOnHost: "/dev/video0", PathInContainer: "/dev/camera", CgroupPermissions: "r"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("deviceMappings(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestDeviceRequests(t *testing.T) {
+	two := int32(2)
+	tests := map[string]struct {
+		req  v1alpha1.DeviceRequest
+		want container.DeviceRequest
+	}{
+		"AllGPUs": {
+			req:  v1alpha1.DeviceRequest{Driver: "nvidia"},
+			want: container.DeviceRequest{Driver: "nvidia", Count: -1, Capabilities: [][]string{{"gpu"}}},
+		},
+		"Count": {
+			req:  v1alpha1.DeviceRequest{Count: &two, Capabilities: []string{"gpu", "compute"}},
+			want: container.DeviceRequest{Count: 2, Capabilities: [][]string{{"gpu", "compute"}}},
+		},
+		"DeviceIDs": {
+			req: v1alpha1.DeviceRequest{Driver: "nvidia", DeviceIDs: []string{"0", "3"}, Options: map[string]string{"mig": "1g.5gb"}},
+			want: container.DeviceRequest{
+				Driver:       "nvidia",
+				DeviceIDs:    []string{"0", "3"},
+				Capabilities: [][]string{{"gpu"}},
+				Options:      map[string]string{"mig": "1g.5gb"},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := deviceRequests([]v1alpha1.DeviceRequest{tt.req})
+			if diff := cmp.Diff([]container.DeviceRequest{tt.want}, got); diff != "" {
+				t.Errorf("deviceRequests(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDevicesDrift(t *testing.T) {
+	cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+		Image:          "pytorch/pytorch:latest",
+		Devices:        []v1alpha1.DeviceMapping{{HostPath: "/dev/ttyUSB0"}},
+		DeviceRequests: []v1alpha1.DeviceRequest{{Driver: "nvidia"}},
+	}}}
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			State: &container.State{Running: true},
+			HostConfig: &container.HostConfig{Resources: container.Resources{
+				Devices:        []container.DeviceMapping{{PathOnHost: "/dev/ttyUSB0", PathInContainer: "/dev/ttyUSB0", CgroupPermissions: "rwm"}},
+				DeviceRequests: []container.DeviceRequest{{Driver: "nvidia", Count: 1, Capabilities: [][]string{{"gpu"}}, Options: map[string]string{}}},
+			}},
+		},
+		Config: &container.Config{Image: "pytorch/pytorch:latest"},
+	}
+	c := &external{}
+	if c.isUpToDate(cr, info) {
+		t.Error("isUpToDate() = true for a container created with one GPU, not all of them")
+	}
+	info.HostConfig.DeviceRequests[0].Count = -1
+	if !c.isUpToDate(cr, info) {
+		t.Error("isUpToDate() = false for a container created with the devices its spec sets")
+	}
+	info.HostConfig.Devices = nil
+	if c.isUpToDate(cr, info) {
+		t.Error("isUpToDate() = true for a container created without its devices")
+	}
+}
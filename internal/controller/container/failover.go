@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
+)
+
+const (
+	errPrimaryUnreachable = "Docker host is unreachable; failing over to the standby host in %s"
+	errConnectStandby     = "cannot connect to standby Docker host"
+	errRemoveStandby      = "cannot remove container from standby Docker host"
+	errCreateVolume       = "cannot create volume %s"
+
+	// defaultUnreachableFor is how long a container's host must have been
+	// unreachable before it fails over, when its failover does not say.
+	defaultUnreachableFor = 5 * time.Minute
+
+	// pingTimeout bounds checking whether a container's host is reachable.
+	pingTimeout = 10 * time.Second
+)
+
+// connectDocker connects to the Docker host a container is managed on,
+// returning a client and the host's ProviderConfig. A container with a
+// failover block is managed on its standby host once its own host has been
+// unreachable for long enough, and from then on. Progress towards failing
+// over is recorded in obs. The usage of the standby ProviderConfig is tracked
+// alongside that of the container's own while it has a failover block.
+func connectDocker(ctx context.Context, kube client.Client, mg resource.Managed, fo *v1alpha1.Failover, obs *v1alpha1.ContainerObservation) (clients.DockerClient, *apisv1beta1.ProviderConfig, error) {
+	if fo == nil {
+		if err := leaveStandby(ctx, kube, mg, obs); err != nil {
+			return nil, nil, err
+		}
+		obs.Failover = nil
+		return connectHost(ctx, kube, mg)
+	}
+
+	ref := fo.StandbyProviderConfigRef
+	if err := clients.TrackStandbyProviderConfigUsage(ctx, kube, mg, &ref); err != nil {
+		return nil, nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	if obs.Failover != nil && obs.Failover.FailedOverAt != nil {
+		obs.Failover.StandbyProviderConfigRef = &ref
+		dc, pc, err := connectStandby(ctx, kube, mg, &ref)
+		return dc, pc, errors.Wrap(err, errConnectStandby)
+	}
+
+	dc, pc, err := connectHost(ctx, kube, mg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	_, err = dc.Ping(pingCtx)
+	cancel()
+	if err == nil {
+		obs.Failover = nil
+		return dc, pc, nil
+	}
+	_ = dc.Close()
+
+	now := metav1.Now()
+	if obs.Failover == nil {
+		obs.Failover = &v1alpha1.FailoverStatus{}
+	}
+	if obs.Failover.PrimaryUnreachableSince == nil {
+		obs.Failover.PrimaryUnreachableSince = &now
+	}
+
+	unreachableFor := defaultUnreachableFor
+	if fo.UnreachableFor != nil {
+		unreachableFor = fo.UnreachableFor.Duration
+	}
+	if remaining := unreachableFor - now.Sub(obs.Failover.PrimaryUnreachableSince.Time); remaining > 0 {
+		return nil, nil, errors.Wrapf(err, errPrimaryUnreachable, remaining.Round(time.Second))
+	}
+
+	dc, pc, err = connectStandby(ctx, kube, mg, &ref)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errConnectStandby)
+	}
+	obs.Failover.FailedOverAt = &now
+	obs.Failover.StandbyProviderConfigRef = &ref
+	return dc, pc, nil
+}
+
+// leaveStandby removes a container that failed over from its standby host
+// once its failover block is removed, since it is then managed on its own
+// host again, and releases the usage of the standby ProviderConfig.
+// Containers whose management policies do not permit deleting them are left
+// on the standby host.
+func leaveStandby(ctx context.Context, kube client.Client, mg resource.Managed, obs *v1alpha1.ContainerObservation) error {
+	fs := obs.Failover
+	if fs != nil && fs.FailedOverAt != nil && fs.StandbyProviderConfigRef != nil && allows(mg, xpv1.ManagementActionDelete) {
+		if err := removeFromStandby(ctx, kube, mg, fs.StandbyProviderConfigRef); err != nil {
+			return errors.Wrap(err, errRemoveStandby)
+		}
+	}
+	return clients.ReleaseStandbyProviderConfigUsage(ctx, kube, mg)
+}
+
+// removeFromStandby removes a container from the Docker host of a standby
+// ProviderConfig.
+func removeFromStandby(ctx context.Context, kube client.Client, mg resource.Managed, ref *xpv1.ProviderConfigReference) error {
+	name := meta.GetExternalName(mg)
+	if name == "" {
+		return nil
+	}
+	dc, err := clients.NewDockerClientFor(ctx, kube, mg, ref)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dc.Close() }()
+	if err := dc.ContainerRemove(ctx, name, container.RemoveOptions{Force: true}); err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// connectHost connects to the Docker host of the ProviderConfig a managed
+// resource refers to.
+func connectHost(ctx context.Context, kube client.Client, mg resource.Managed) (clients.DockerClient, *apisv1beta1.ProviderConfig, error) {
+	dc, err := clients.NewDockerClient(ctx, kube, mg)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errNewClient)
+	}
+
+	pc, err := clients.GetProviderConfig(ctx, kube, mg)
+	if err != nil {
+		_ = dc.Close()
+		return nil, nil, errors.Wrap(err, errGetPC)
+	}
+
+	return dc, pc, nil
+}
+
+// connectStandby connects to the Docker host of a managed resource's standby
+// ProviderConfig, without replacing the usage of its own ProviderConfig.
+func connectStandby(ctx context.Context, kube client.Client, mg resource.Managed, ref *xpv1.ProviderConfigReference) (clients.DockerClient, *apisv1beta1.ProviderConfig, error) {
+	dc, err := clients.NewDockerClientFor(ctx, kube, mg, ref)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errNewClient)
+	}
+
+	pc, err := clients.GetProviderConfig(ctx, kube, clients.WithProviderConfig(mg, ref))
+	if err != nil {
+		_ = dc.Close()
+		return nil, nil, errors.Wrap(err, errGetPC)
+	}
+
+	return dc, pc, nil
+}
+
+// ensureVolumes creates the named Docker volumes a container mounts, so
+// that they exist, empty, on a standby host it fails over to. Their
+// contents cannot be copied from a host that is unreachable.
+func (c *external) ensureVolumes(ctx context.Context, cr *v1alpha1.Container) error {
+	for _, v := range cr.Spec.ForProvider.Volumes {
+		if v.VolumeSource.Volume == nil {
+			continue
+		}
+		name := v.VolumeSource.Volume.VolumeName
+		if _, err := c.client.VolumeCreate(ctx, volume.CreateOptions{
+			Name:   name,
+			Labels: map[string]string{labels.ManagedBy: labels.ManagedByProvider},
+		}); err != nil {
+			return errors.Wrapf(err, errCreateVolume, name)
+		}
+	}
+	return nil
+}
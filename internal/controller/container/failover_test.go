@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-docker/apis"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConnectDockerFailover(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	// Nothing listens on port 1, so the primary host is unreachable
+	pc := func(name, host string) *apisv1beta1.ProviderConfig {
+		return &apisv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apisv1beta1.ProviderConfigSpec{
+				Host:        &host,
+				Credentials: apisv1beta1.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+			},
+		}
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(pc("primary", "tcp://127.0.0.1:1"), pc("standby", "tcp://standby.example.com:2376"), pc("unreachable", "tcp://127.0.0.1:1")).
+		Build()
+
+	fo := &v1alpha1.Failover{
+		StandbyProviderConfigRef: xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "standby"},
+		UnreachableFor:           &metav1.Duration{Duration: time.Minute},
+	}
+	newContainer := func(fs *v1alpha1.FailoverStatus) *v1alpha1.Container {
+		cr := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "web", UID: "1234"}}
+		cr.SetProviderConfigReference(&xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "primary"})
+		cr.Spec.ForProvider.Failover = fo
+		cr.Status.AtProvider.Failover = fs
+		return cr
+	}
+	ago := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(time.Now().Add(-d))
+		return &t
+	}
+	usageOf := func(name string) (string, bool) {
+		u := &apisv1beta1.ProviderConfigUsage{}
+		if err := kube.Get(context.Background(), client.ObjectKey{Name: name}, u); err != nil {
+			return "", false
+		}
+		return u.ProviderConfigReference.Name, true
+	}
+
+	t.Run("WaitsWhilePrimaryRecentlyUnreachable", func(t *testing.T) {
+		cr := newContainer(nil)
+		if _, _, err := connectDocker(context.Background(), kube, cr, fo, &cr.Status.AtProvider); err == nil {
+			t.Fatal("connectDocker() error = nil, want primary unreachable")
+		}
+		fs := cr.Status.AtProvider.Failover
+		if fs == nil || fs.PrimaryUnreachableSince == nil || fs.FailedOverAt != nil {
+			t.Errorf("connectDocker() failover status = %+v, want unreachable since set only", fs)
+		}
+	})
+
+	t.Run("FailsOverOncePrimaryUnreachableLongEnough", func(t *testing.T) {
+		cr := newContainer(&v1alpha1.FailoverStatus{PrimaryUnreachableSince: ago(2 * time.Minute)})
+		dc, got, err := connectDocker(context.Background(), kube, cr, fo, &cr.Status.AtProvider)
+		if err != nil {
+			t.Fatalf("connectDocker() error = %v", err)
+		}
+		defer func() { _ = dc.Close() }()
+		if got.Name != "standby" {
+			t.Errorf("connectDocker() ProviderConfig = %q, want %q", got.Name, "standby")
+		}
+		if cr.Status.AtProvider.Failover.FailedOverAt == nil {
+			t.Error("connectDocker() did not record failing over")
+		}
+		if ref := cr.GetProviderConfigReference(); ref.Name != "primary" {
+			t.Errorf("connectDocker() changed providerConfigRef to %q", ref.Name)
+		}
+		if ref := cr.Status.AtProvider.Failover.StandbyProviderConfigRef; ref == nil || ref.Name != "standby" {
+			t.Errorf("connectDocker() recorded standby %+v, want %q", ref, "standby")
+		}
+		if name, _ := usageOf("1234"); name != "primary" {
+			t.Errorf("usage of own ProviderConfig refers to %q, want %q", name, "primary")
+		}
+		if name, _ := usageOf("1234-standby"); name != "standby" {
+			t.Errorf("usage of standby ProviderConfig refers to %q, want %q", name, "standby")
+		}
+	})
+
+	t.Run("StaysOnStandby", func(t *testing.T) {
+		cr := newContainer(&v1alpha1.FailoverStatus{FailedOverAt: ago(time.Hour)})
+		dc, got, err := connectDocker(context.Background(), kube, cr, fo, &cr.Status.AtProvider)
+		if err != nil {
+			t.Fatalf("connectDocker() error = %v", err)
+		}
+		defer func() { _ = dc.Close() }()
+		if got.Name != "standby" {
+			t.Errorf("connectDocker() ProviderConfig = %q, want %q", got.Name, "standby")
+		}
+	})
+
+	t.Run("WithoutFailover", func(t *testing.T) {
+		cr := newContainer(&v1alpha1.FailoverStatus{FailedOverAt: ago(time.Hour)})
+		dc, got, err := connectDocker(context.Background(), kube, cr, nil, &cr.Status.AtProvider)
+		if err != nil {
+			t.Fatalf("connectDocker() error = %v", err)
+		}
+		defer func() { _ = dc.Close() }()
+		if got.Name != "primary" {
+			t.Errorf("connectDocker() ProviderConfig = %q, want %q", got.Name, "primary")
+		}
+		if cr.Status.AtProvider.Failover != nil {
+			t.Errorf("connectDocker() failover status = %+v, want nil", cr.Status.AtProvider.Failover)
+		}
+		if _, ok := usageOf("1234-standby"); ok {
+			t.Error("connectDocker() did not release the usage of the standby ProviderConfig")
+		}
+	})
+
+	t.Run("RemovesFromStandbyWithoutFailover", func(t *testing.T) {
+		cr := newContainer(&v1alpha1.FailoverStatus{
+			FailedOverAt:             ago(time.Hour),
+			StandbyProviderConfigRef: &xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "unreachable"},
+		})
+		meta.SetExternalName(cr, "web")
+		_, _, err := connectDocker(context.Background(), kube, cr, nil, &cr.Status.AtProvider)
+		if err == nil || !strings.Contains(err.Error(), errRemoveStandby) {
+			t.Fatalf("connectDocker() error = %v, want %q", err, errRemoveStandby)
+		}
+		if cr.Status.AtProvider.Failover == nil {
+			t.Error("connectDocker() forgot the standby host before removing the container from it")
+		}
+	})
+
+	t.Run("LeavesObserveOnlyContainerOnStandby", func(t *testing.T) {
+		cr := newContainer(&v1alpha1.FailoverStatus{
+			FailedOverAt:             ago(time.Hour),
+			StandbyProviderConfigRef: &xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "unreachable"},
+		})
+		meta.SetExternalName(cr, "web")
+		cr.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+		dc, _, err := connectDocker(context.Background(), kube, cr, nil, &cr.Status.AtProvider)
+		if err != nil {
+			t.Fatalf("connectDocker() error = %v", err)
+		}
+		defer func() { _ = dc.Close() }()
+		if cr.Status.AtProvider.Failover != nil {
+			t.Errorf("connectDocker() failover status = %+v, want nil", cr.Status.AtProvider.Failover)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	errListGuarded    = "cannot list the containers created through the ProviderConfig"
+	errInspectGuarded = "cannot inspect a container created through the ProviderConfig"
+	errMaxContainers  = "creating the container would exceed the ProviderConfig's limit of %d containers"
+	errMaxTotalMemory = "creating the container would exceed the ProviderConfig's limit of %s of memory in total, of which %s is in use"
+)
+
+// providerConfigLabel returns the labels.ProviderConfig label value of containers
+// created through a ProviderConfig.
+func providerConfigLabel(pc *apisv1beta1.ProviderConfig) string {
+	if ns := pc.GetNamespace(); ns != "" {
+		return ns + "." + pc.GetName()
+	}
+	return pc.GetName()
+}
+
+// checkGuardrails returns an error if creating a container with hostConfig
+// would exceed the guardrails of its ProviderConfig, given the containers
+// already created through it on the host.
+func (c *external) checkGuardrails(ctx context.Context, hostConfig *container.HostConfig) error {
+	g := c.guardrails
+	if g == nil || (g.MaxContainersPerProviderConfig == nil && g.MaxTotalMemory == nil) {
+		return nil
+	}
+
+	existing, err := c.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: labels.Filter(labels.Selector(labels.ProviderConfig, c.providerConfig)),
+	})
+	if err != nil {
+		return errors.Wrap(err, errListGuarded)
+	}
+
+	if limit := g.MaxContainersPerProviderConfig; limit != nil && len(existing) >= int(*limit) {
+		return errors.Errorf(errMaxContainers, *limit)
+	}
+
+	if g.MaxTotalMemory == nil {
+		return nil
+	}
+	var used int64
+	for _, s := range existing {
+		info, err := c.client.ContainerInspect(ctx, s.ID)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, errInspectGuarded)
+		}
+		used += memoryOf(info.HostConfig)
+	}
+	if used+memoryOf(hostConfig) > g.MaxTotalMemory.Value() {
+		inUse := resource.NewQuantity(used, resource.BinarySI)
+		return errors.Errorf(errMaxTotalMemory, g.MaxTotalMemory.String(), inUse.String())
+	}
+	return nil
+}
+
+// memoryOf returns the memory limit of a container, or its reservation if
+// it has no limit.
+func memoryOf(hc *container.HostConfig) int64 {
+	if hc == nil {
+		return 0
+	}
+	if hc.Memory > 0 {
+		return hc.Memory
+	}
+	return hc.MemoryReservation
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"strings"
+	"testing"
+)
+
+func TestCheckGuardrails(t *testing.T) {
+	gi := int64(1 << 30)
+	three, four := int32(3), int32(4)
+	twoGi := resource.MustParse("2Gi")
+	existing := map[string]*container.HostConfig{
+		"limited":  {Resources: container.Resources{Memory: gi}},
+		"reserved": {Resources: container.Resources{MemoryReservation: gi / 2}},
+		"unbound":  {},
+	}
+
+	tests := []struct {
+		name       string
+		guardrails *apisv1beta1.Guardrails
+		memory     int64
+		wantErr    string
+	}{
+		{
+			name: "NoGuardrails",
+		},
+		{
+			name:       "UnderContainerLimit",
+			guardrails: &apisv1beta1.Guardrails{MaxContainersPerProviderConfig: &four},
+		},
+		{
+			name:       "AtContainerLimit",
+			guardrails: &apisv1beta1.Guardrails{MaxContainersPerProviderConfig: &three},
+			wantErr:    "limit of 3 containers",
+		},
+		{
+			name:       "UnderMemoryLimit",
+			guardrails: &apisv1beta1.Guardrails{MaxTotalMemory: &twoGi},
+			memory:     gi / 2,
+		},
+		{
+			name:       "OverMemoryLimit",
+			guardrails: &apisv1beta1.Guardrails{MaxTotalMemory: &twoGi},
+			memory:     gi,
+			wantErr:    "limit of 2Gi of memory in total, of which 1536Mi is in use",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter string
+			e := &external{
+				providerConfig: "docker-config",
+				guardrails:     tt.guardrails,
+				client: &mockDockerClient{
+					containerListFunc: func(_ context.Context, opts container.ListOptions) ([]container.Summary, error) {
+						filter = strings.Join(opts.Filters.Get("label"), ",")
+						var out []container.Summary
+						for id := range existing {
+							out = append(out, container.Summary{ID: id})
+						}
+						return out, nil
+					},
+					containerInspectFunc: func(_ context.Context, id string) (container.InspectResponse, error) {
+						return container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{ID: id, HostConfig: existing[id]}}, nil
+					},
+				},
+			}
+
+			err := e.checkGuardrails(context.Background(), &container.HostConfig{Resources: container.Resources{Memory: tt.memory}})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkGuardrails() error = %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkGuardrails() error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.guardrails != nil && filter != labels.ProviderConfig+"=docker-config" {
+				t.Errorf("checkGuardrails() listed containers with label %q", filter)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/docker/docker/api/types/system"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	corev1 "k8s.io/api/core/v1"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	errHostInfo = "cannot get Docker host information"
+
+	reasonUnsupportedOnHost event.Reason = "UnsupportedOnHost"
+
+	// usernsRemap is the usernsMode that requires the Docker daemon to
+	// remap user namespaces.
+	usernsRemap = "remap"
+
+	// hostInfoTTL is how long what a Docker daemon reports about itself is
+	// reused for. Its runtimes and storage driver only change when it is
+	// reconfigured, which restarts it.
+	hostInfoTTL = 5 * time.Minute
+)
+
+// hostInfo shares what the Docker daemons of containers report about
+// themselves.
+var hostInfo = clients.NewInfoCache(hostInfoTTL)
+
+// checkHostSupport checks that the Docker host of a container supports the
+// features it requests: its runtime, GPUs, storage options and user
+// namespace remapping. A container that requests a feature its host does not
+// support fails to be created with an UnsupportedOnHost condition naming
+// each such feature and its alternatives, rather than with Docker's error.
+// The host is not asked about a container that requests none of them.
+func (c *external) checkHostSupport(ctx context.Context, cr *v1alpha1.Container) error {
+	p := &cr.Spec.ForProvider
+	var unsupported []string
+	if requestsHostFeatures(p) {
+		info, err := c.infos.Get(ctx, c.client, c.host)
+		if err != nil {
+			return errors.Wrap(err, errHostInfo)
+		}
+		unsupported = hostUnsupported(p, info)
+	}
+
+	if len(unsupported) == 0 {
+		if cr.GetCondition(v1alpha1.TypeUnsupportedOnHost).Status == corev1.ConditionTrue {
+			cr.SetConditions(v1alpha1.SupportedOnHost())
+		}
+		return nil
+	}
+	msg := strings.Join(unsupported, "; ")
+	cr.SetConditions(v1alpha1.UnsupportedOnHost(msg))
+	err := errors.New(msg)
+	c.record(cr, event.Warning(reasonUnsupportedOnHost, err))
+	return err
+}
+
+// requestsHostFeatures reports whether a container requests any feature its
+// Docker host might not support.
+func requestsHostFeatures(p *v1alpha1.ContainerParameters) bool {
+	return p.Runtime != nil || len(p.StorageOpt) > 0 || len(p.DeviceRequests) > 0 ||
+		(p.UsernsMode != nil && *p.UsernsMode == usernsRemap)
+}
+
+// hostUnsupported returns the features of a container a Docker host does not
+// support, each with why and the alternatives to it.
+func hostUnsupported(p *v1alpha1.ContainerParameters, info system.Info) []string {
+	var unsupported []string
+	if p.Runtime != nil {
+		if _, ok := info.Runtimes[*p.Runtime]; !ok {
+			unsupported = append(unsupported, "spec.forProvider.runtime: runtime "+*p.Runtime+
+				" is not configured on the Docker host (available: "+strings.Join(slices.Sorted(maps.Keys(info.Runtimes)), ", ")+")")
+		}
+	}
+	if reason := deviceRequestsUnsupported(p.DeviceRequests, info); reason != "" {
+		unsupported = append(unsupported, "spec.forProvider.deviceRequests: "+reason)
+	}
+	if len(p.StorageOpt) > 0 {
+		if reason := storageOptUnsupported(info); reason != "" {
+			unsupported = append(unsupported, "spec.forProvider.storageOpt: "+reason+
+				" (alternatives: a host using btrfs, zfs, or overlay2 on XFS mounted with pquota; or volumes for data that would grow the writable layer)")
+		}
+	}
+	if p.UsernsMode != nil && *p.UsernsMode == usernsRemap && !slices.ContainsFunc(info.SecurityOptions, isUserns) {
+		unsupported = append(unsupported, "spec.forProvider.usernsMode: the Docker daemon does not remap user namespaces"+
+			" (alternatives: securityContext.runAsUser to run as an unprivileged user, or a daemon configured with userns-remap)")
+	}
+	return unsupported
+}
+
+// deviceRequestsUnsupported returns why a Docker host cannot provide the
+// devices a container requests, or "" if it can. Docker reports neither its
+// device drivers nor the GPUs of its host, so a host is taken to provide
+// NVIDIA GPUs when it has the nvidia runtime the NVIDIA Container Toolkit
+// configures, and CDI devices when CDI is enabled. Other drivers are assumed
+// to be supported.
+func deviceRequestsUnsupported(requests []v1alpha1.DeviceRequest, info system.Info) string {
+	_, nvidia := info.Runtimes["nvidia"]
+	cdi := len(info.CDISpecDirs) > 0
+	for _, r := range requests {
+		switch {
+		case r.Driver == "cdi" && !cdi:
+			return "the cdi driver needs CDI enabled on the Docker daemon (alternatives: driver nvidia on a host with the NVIDIA Container Toolkit)"
+		case requestsGPUs(r) && !nvidia && cdi:
+			return "the Docker host has no nvidia runtime to provide GPUs (alternatives: driver cdi with deviceIDs such as nvidia.com/gpu=all)"
+		case requestsGPUs(r) && !nvidia:
+			return "the Docker host has neither the nvidia runtime nor CDI to provide GPUs (alternatives: a host with the NVIDIA Container Toolkit installed)"
+		}
+	}
+	return ""
+}
+
+// requestsGPUs reports whether a device request is for NVIDIA GPUs, which
+// it is when it names the nvidia driver, or no driver and the default gpu
+// capability.
+func requestsGPUs(r v1alpha1.DeviceRequest) bool {
+	switch r.Driver {
+	case "nvidia":
+		return true
+	case "":
+		return len(r.Capabilities) == 0 || slices.Contains(r.Capabilities, defaultDeviceCapability)
+	}
+	return false
+}
+
+// isUserns reports whether a security option the Docker daemon reports is
+// user namespace remapping.
+func isUserns(opt string) bool {
+	return slices.Contains(strings.Split(opt, ","), "name=userns")
+}
+
+// usernsModeMatch reports whether a container runs in the user namespace
+// its spec requests.
+func usernsModeMatch(want, have string) bool {
+	if want == usernsRemap {
+		return have != "host"
+	}
+	return have == want
+}
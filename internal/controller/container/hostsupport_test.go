@@ -0,0 +1,218 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/system"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	corev1 "k8s.io/api/core/v1"
+	"strings"
+	"testing"
+)
+
+func TestCheckHostSupport(t *testing.T) {
+	size := map[string]string{"size": "20G"}
+	runsc, remap := "runsc", "remap"
+	gpus := []v1alpha1.DeviceRequest{{}}
+	runtimes := map[string]system.RuntimeWithStatus{"runc": {}, "io.containerd.runc.v2": {}}
+
+	tests := []struct {
+		name      string
+		params    v1alpha1.ContainerParameters
+		info      system.Info
+		wantErr   string
+		wantCalls int
+	}{
+		{
+			name: "NoFeatures",
+			info: system.Info{Driver: "overlay2"},
+		},
+		{
+			name:      "OverlayOnXFS",
+			params:    v1alpha1.ContainerParameters{StorageOpt: size},
+			info:      system.Info{Driver: "overlay2", DriverStatus: [][2]string{{"Backing Filesystem", "xfs"}}},
+			wantCalls: 1,
+		},
+		{
+			name:      "OverlayOnExt4",
+			params:    v1alpha1.ContainerParameters{StorageOpt: size},
+			info:      system.Info{Driver: "overlay2", DriverStatus: [][2]string{{"Backing Filesystem", "extfs"}}},
+			wantErr:   "only on XFS, and the Docker host's backing filesystem is extfs",
+			wantCalls: 1,
+		},
+		{
+			name:      "ZFS",
+			params:    v1alpha1.ContainerParameters{StorageOpt: size},
+			info:      system.Info{Driver: "zfs"},
+			wantCalls: 1,
+		},
+		{
+			name:      "ContainerdSnapshotter",
+			params:    v1alpha1.ContainerParameters{StorageOpt: size},
+			info:      system.Info{Driver: "overlayfs"},
+			wantErr:   "storage driver overlayfs of the Docker host does not support storage options",
+			wantCalls: 1,
+		},
+		{
+			name:      "RuntimeConfigured",
+			params:    v1alpha1.ContainerParameters{Runtime: &runsc},
+			info:      system.Info{Runtimes: map[string]system.RuntimeWithStatus{"runc": {}, "runsc": {}}},
+			wantCalls: 1,
+		},
+		{
+			name:      "RuntimeMissing",
+			params:    v1alpha1.ContainerParameters{Runtime: &runsc},
+			info:      system.Info{Runtimes: runtimes},
+			wantErr:   "spec.forProvider.runtime: runtime runsc is not configured on the Docker host (available: io.containerd.runc.v2, runc)",
+			wantCalls: 1,
+		},
+		{
+			name:      "GPUsWithNvidiaRuntime",
+			params:    v1alpha1.ContainerParameters{DeviceRequests: gpus},
+			info:      system.Info{Runtimes: map[string]system.RuntimeWithStatus{"nvidia": {}}},
+			wantCalls: 1,
+		},
+		{
+			name:      "GPUsWithCDI",
+			params:    v1alpha1.ContainerParameters{DeviceRequests: gpus},
+			info:      system.Info{Runtimes: runtimes, CDISpecDirs: []string{"/etc/cdi"}},
+			wantErr:   "driver cdi with deviceIDs such as nvidia.com/gpu=all",
+			wantCalls: 1,
+		},
+		{
+			name:      "GPUsMissing",
+			params:    v1alpha1.ContainerParameters{DeviceRequests: gpus},
+			info:      system.Info{Runtimes: runtimes},
+			wantErr:   "neither the nvidia runtime nor CDI",
+			wantCalls: 1,
+		},
+		{
+			name:      "CDIDevices",
+			params:    v1alpha1.ContainerParameters{DeviceRequests: []v1alpha1.DeviceRequest{{Driver: "cdi", DeviceIDs: []string{"nvidia.com/gpu=all"}}}},
+			info:      system.Info{CDISpecDirs: []string{"/etc/cdi"}},
+			wantCalls: 1,
+		},
+		{
+			name:      "UsernsRemapped",
+			params:    v1alpha1.ContainerParameters{UsernsMode: &remap},
+			info:      system.Info{SecurityOptions: []string{"name=seccomp,profile=builtin", "name=userns"}},
+			wantCalls: 1,
+		},
+		{
+			name:      "UsernsNotRemapped",
+			params:    v1alpha1.ContainerParameters{UsernsMode: &remap},
+			info:      system.Info{SecurityOptions: []string{"name=seccomp,profile=builtin"}},
+			wantErr:   "spec.forProvider.usernsMode: the Docker daemon does not remap user namespaces",
+			wantCalls: 1,
+		},
+		{
+			name:      "Several",
+			params:    v1alpha1.ContainerParameters{Runtime: &runsc, StorageOpt: size},
+			info:      system.Info{Driver: "overlayfs", Runtimes: runtimes},
+			wantErr:   "is not configured on the Docker host (available: io.containerd.runc.v2, runc); spec.forProvider.storageOpt: ",
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			c := &external{client: &mockDockerClient{
+				infoFunc: func(context.Context) (system.Info, error) {
+					calls++
+					return tt.info, nil
+				},
+			}}
+			cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: tt.params}}
+			err := c.checkHostSupport(context.Background(), cr)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("checkHostSupport() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("checkHostSupport() error = %v, want %q", err, tt.wantErr)
+			}
+			if got, want := cr.GetCondition(v1alpha1.TypeUnsupportedOnHost).Status == corev1.ConditionTrue, tt.wantErr != ""; got != want {
+				t.Errorf("checkHostSupport() UnsupportedOnHost = %v, want %v", got, want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("checkHostSupport() asked the host %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCheckHostSupportCached(t *testing.T) {
+	runsc := "runsc"
+	calls := 0
+	c := &external{
+		client: &mockDockerClient{infoFunc: func(context.Context) (system.Info, error) {
+			calls++
+			return system.Info{Runtimes: map[string]system.RuntimeWithStatus{"runc": {}}}, nil
+		}},
+		infos: clients.NewInfoCache(hostInfoTTL),
+		host:  "tcp://docker:2376",
+	}
+	cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{Runtime: &runsc}}}
+	for range 2 {
+		if err := c.checkHostSupport(context.Background(), cr); err == nil {
+			t.Fatal("checkHostSupport() error = nil for a runtime the host lacks")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("checkHostSupport() asked the host %d times, want once", calls)
+	}
+
+	// Dropping the runtime clears the condition without asking the host
+	cr.Spec.ForProvider.Runtime = nil
+	if err := c.checkHostSupport(context.Background(), cr); err != nil {
+		t.Fatalf("checkHostSupport() error = %v", err)
+	}
+	if got := cr.GetCondition(v1alpha1.TypeUnsupportedOnHost).Status; got != corev1.ConditionFalse {
+		t.Errorf("checkHostSupport() UnsupportedOnHost = %q, want False", got)
+	}
+}
+
+func TestRuntimeDrift(t *testing.T) {
+	runsc, host := "runsc", "host"
+	cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+		Image:      "app:latest",
+		Runtime:    &runsc,
+		UsernsMode: &host,
+	}}}
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			State:      &container.State{Running: true},
+			HostConfig: &container.HostConfig{Runtime: "runc", UsernsMode: "host"},
+		},
+		Config: &container.Config{Image: "app:latest"},
+	}
+	c := &external{}
+	if c.isUpToDate(cr, info) {
+		t.Error("isUpToDate() = true for a container created with another runtime")
+	}
+	info.HostConfig.Runtime = "runsc"
+	if !c.isUpToDate(cr, info) {
+		t.Error("isUpToDate() = false for a container created with the runtime its spec sets")
+	}
+	info.HostConfig.UsernsMode = ""
+	if c.isUpToDate(cr, info) {
+		t.Error("isUpToDate() = true for a container created in another user namespace")
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+)
+
+// forProviderPrefix prefixes the fields listed by AnnotationIgnoreFields.
+const forProviderPrefix = "spec.forProvider."
+
+// ignoredFields are the spec fields of a container whose drift is tolerated,
+// keyed by their name under spec.forProvider. A field of a map or list, such
+// as a single label or environment variable, is keyed as name[key].
+type ignoredFields map[string]bool
+
+// ignoredFieldsOf parses the AnnotationIgnoreFields annotation of a resource.
+// The environment may be named env, as in a pod spec, as well as environment.
+func ignoredFieldsOf(o metav1.Object) ignoredFields {
+	f := ignoredFields{}
+	for _, field := range strings.Split(o.GetAnnotations()[labels.AnnotationIgnoreFields], ",") {
+		field = strings.TrimPrefix(strings.TrimSpace(field), forProviderPrefix)
+		if rest, ok := strings.CutPrefix(field, "env"); ok && !strings.HasPrefix(rest, "ironment") {
+			field = "environment" + rest
+		}
+		if field != "" {
+			f[field] = true
+		}
+	}
+	return f
+}
+
+// ignores reports whether drift on a whole field is tolerated.
+func (f ignoredFields) ignores(field string) bool {
+	return f[field]
+}
+
+// ignoresKey reports whether drift on a single key of a map or list field is
+// tolerated, either by itself or as part of the whole field.
+func (f ignoredFields) ignoresKey(field, key string) bool {
+	return f[field] || f[field+"["+key+"]"]
+}
+
+// environment returns the environment variables whose drift is not tolerated.
+func (f ignoredFields) environment(env []v1alpha1.EnvVar) []v1alpha1.EnvVar {
+	if len(f) == 0 {
+		return env
+	}
+	var checked []v1alpha1.EnvVar
+	for _, e := range env {
+		if !f.ignoresKey("environment", e.Name) {
+			checked = append(checked, e)
+		}
+	}
+	return checked
+}
+
+// labels returns the labels whose drift is not tolerated.
+func (f ignoredFields) labels(l map[string]string) map[string]string {
+	if len(f) == 0 || l == nil {
+		return l
+	}
+	checked := make(map[string]string, len(l))
+	for k, v := range l {
+		if !f.ignoresKey("labels", k) {
+			checked[k] = v
+		}
+	}
+	return checked
+}
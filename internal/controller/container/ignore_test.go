@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestIsUpToDateIgnoredFields(t *testing.T) {
+	debug, level := "false", "info"
+	privileged := false
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			HostConfig: &container.HostConfig{Privileged: true},
+		},
+		Config: &container.Config{
+			Image:  "nginx:1.27",
+			Env:    []string{"DEBUG=true", "LEVEL=info"},
+			Labels: map[string]string{"app": "web", "owner": "ops"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		ignore string
+		want   bool
+	}{
+		{name: "NothingIgnored", want: false},
+		{name: "SomeIgnored", ignore: "spec.forProvider.env[DEBUG],spec.forProvider.labels", want: false},
+		{name: "AllIgnored", ignore: "spec.forProvider.image, spec.forProvider.env[DEBUG],spec.forProvider.labels,spec.forProvider.privileged", want: true},
+		{name: "WholeFieldsIgnored", ignore: "spec.forProvider.image,spec.forProvider.environment,spec.forProvider.labels[owner],spec.forProvider.privileged", want: true},
+		{name: "OtherKeyIgnored", ignore: "spec.forProvider.image,spec.forProvider.env[LEVEL],spec.forProvider.labels,spec.forProvider.privileged", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1alpha1.Container{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{v1alpha1.AnnotationIgnoreFields: tt.ignore}},
+				Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+					Image: "nginx:1.28",
+					Environment: []v1alpha1.EnvVar{
+						{Name: "DEBUG", Value: &debug},
+						{Name: "LEVEL", Value: &level},
+					},
+					Labels:     map[string]string{"app": "web", "owner": "dev"},
+					Privileged: &privileged,
+				}},
+			}
+
+			e := &external{}
+			if got := e.isUpToDate(cr, info); got != tt.want {
+				t.Errorf("isUpToDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"maps"
+)
+
+// logConfig converts the log config of a container spec to that of its host
+// config. A container without one logs with the Docker host's log driver.
+func logConfig(lc *v1alpha1.LogConfig) container.LogConfig {
+	if lc == nil {
+		return container.LogConfig{}
+	}
+	return container.LogConfig{Type: lc.Driver, Config: maps.Clone(lc.Options)}
+}
+
+// logConfigMatch reports whether a container logs as its spec says. Docker
+// adds the Docker host's default log options to a container that uses the
+// host's log driver, so options the spec does not set are not compared.
+func logConfigMatch(want *v1alpha1.LogConfig, have container.LogConfig) bool {
+	if want.Driver != "" && want.Driver != have.Type {
+		return false
+	}
+	for k, v := range want.Options {
+		if got, ok := have.Config[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"testing"
+)
+
+func TestLogConfigMatch(t *testing.T) {
+	rotated := &v1alpha1.LogConfig{Driver: "json-file", Options: map[string]string{"max-size": "10m"}}
+
+	tests := map[string]struct {
+		want *v1alpha1.LogConfig
+		have container.LogConfig
+		ok   bool
+	}{
+		"Same": {
+			want: rotated,
+			have: container.LogConfig{Type: "json-file", Config: map[string]string{"max-size": "10m"}},
+			ok:   true,
+		},
+		"HostDefaultOptions": {
+			want: rotated,
+			have: container.LogConfig{Type: "json-file", Config: map[string]string{"max-size": "10m", "compress": "true"}},
+			ok:   true,
+		},
+		"OtherDriver": {
+			want: rotated,
+			have: container.LogConfig{Type: "journald"},
+		},
+		"OtherOption": {
+			want: rotated,
+			have: container.LogConfig{Type: "json-file", Config: map[string]string{"max-size": "100m"}},
+		},
+		"HostDefaultDriver": {
+			want: &v1alpha1.LogConfig{Options: map[string]string{"max-size": "10m"}},
+			have: container.LogConfig{Type: "local", Config: map[string]string{"max-size": "10m"}},
+			ok:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := logConfigMatch(tt.want, tt.have); got != tt.ok {
+				t.Errorf("logConfigMatch() = %v, want %v", got, tt.ok)
+			}
+		})
+	}
+}
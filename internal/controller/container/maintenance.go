@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/maintenance"
+	"time"
+)
+
+const errMaintenanceWindow = "cannot evaluate maintenance window"
+
+// deferDrift reports whether a container is to be treated as up to date at
+// now. Drift of a container whose maintenance window is closed is deferred
+// until the window opens, and recorded in its Deferred condition.
+func deferDrift(cr *v1alpha1.Container, upToDate bool, now time.Time) (bool, error) {
+	mw := cr.Spec.ForProvider.MaintenanceWindow
+	if mw == nil {
+		return upToDate, nil
+	}
+	if upToDate {
+		cr.SetConditions(v1alpha1.NotDeferred())
+		return true, nil
+	}
+
+	tz := ""
+	if mw.TimeZone != nil {
+		tz = *mw.TimeZone
+	}
+	w, err := maintenance.New(mw.Schedule, mw.Duration.Duration, tz)
+	if err != nil {
+		return false, errors.Wrap(err, errMaintenanceWindow)
+	}
+	deferred, message := w.Defer(now)
+	if !deferred {
+		cr.SetConditions(v1alpha1.NotDeferred())
+		return false, nil
+	}
+	cr.SetConditions(v1alpha1.Deferred(message))
+	return true, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestDeferDrift(t *testing.T) {
+	// Saturdays from 02:00 to 04:00 UTC; 2026-10-17 is a Saturday
+	window := &v1alpha1.MaintenanceWindow{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 2 * time.Hour}}
+	closed := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	open := time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		window       *v1alpha1.MaintenanceWindow
+		upToDate     bool
+		now          time.Time
+		wantUpToDate bool
+		wantDeferred corev1.ConditionStatus
+	}{
+		"NoWindow":         {upToDate: false, now: closed, wantUpToDate: false, wantDeferred: corev1.ConditionUnknown},
+		"UpToDate":         {window: window, upToDate: true, now: closed, wantUpToDate: true, wantDeferred: corev1.ConditionFalse},
+		"DriftWhileClosed": {window: window, upToDate: false, now: closed, wantUpToDate: true, wantDeferred: corev1.ConditionTrue},
+		"DriftWhileOpen":   {window: window, upToDate: false, now: open, wantUpToDate: false, wantDeferred: corev1.ConditionFalse},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{MaintenanceWindow: tt.window}}}
+			got, err := deferDrift(cr, tt.upToDate, tt.now)
+			if err != nil {
+				t.Fatalf("deferDrift(): %v", err)
+			}
+			if got != tt.wantUpToDate {
+				t.Errorf("deferDrift() = %v, want %v", got, tt.wantUpToDate)
+			}
+			if s := cr.GetCondition(v1alpha1.TypeDeferred).Status; s != tt.wantDeferred {
+				t.Errorf("Deferred condition = %s, want %s", s, tt.wantDeferred)
+			}
+		})
+	}
+}
+
+func TestDeferDriftInvalidWindow(t *testing.T) {
+	cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+		MaintenanceWindow: &v1alpha1.MaintenanceWindow{Schedule: "whenever", Duration: metav1.Duration{Duration: time.Hour}},
+	}}}
+	if _, err := deferDrift(cr, false, time.Now()); err == nil {
+		t.Error("deferDrift() succeeded with an invalid schedule, want error")
+	}
+}
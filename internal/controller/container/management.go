@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"slices"
+)
+
+// allows reports whether the management policies of a container permit the
+// provider to take action on its Docker container. A container without
+// management policies is fully managed.
+//
+// The managed reconciler only calls Create, Update and Delete when their
+// action is permitted. The provider also changes containers while observing
+// them, and checks here before doing so.
+func allows(cr resource.Managed, action xpv1.ManagementAction) bool {
+	p := cr.GetManagementPolicies()
+	return len(p) == 0 || slices.Contains(p, xpv1.ManagementActionAll) || slices.Contains(p, action)
+}
+
+// allowsRemediation reports whether the management policies of a container
+// permit its remediation policy to be applied. Restarting a container
+// updates it, and recreating it also deletes and creates it.
+func allowsRemediation(cr *v1alpha1.Container, action v1alpha1.RemediationAction) bool {
+	if !allows(cr, xpv1.ManagementActionUpdate) {
+		return false
+	}
+	if action == v1alpha1.RemediationRecreate {
+		return allows(cr, xpv1.ManagementActionDelete) && allows(cr, xpv1.ManagementActionCreate)
+	}
+	return true
+}
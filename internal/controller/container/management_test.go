@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"testing"
+)
+
+func TestAllows(t *testing.T) {
+	tests := map[string]struct {
+		policies xpv1.ManagementPolicies
+		action   xpv1.ManagementAction
+		want     bool
+	}{
+		"NoPolicies":  {action: xpv1.ManagementActionUpdate, want: true},
+		"All":         {policies: xpv1.ManagementPolicies{xpv1.ManagementActionAll}, action: xpv1.ManagementActionDelete, want: true},
+		"Listed":      {policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate}, action: xpv1.ManagementActionCreate, want: true},
+		"NotListed":   {policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate}, action: xpv1.ManagementActionUpdate},
+		"ObserveOnly": {policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve}, action: xpv1.ManagementActionDelete},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Container{}
+			cr.SetManagementPolicies(tc.policies)
+			if got := allows(cr, tc.action); got != tc.want {
+				t.Errorf("allows(%v, %s) = %t, want %t", tc.policies, tc.action, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestManagementPolicyGating(t *testing.T) {
+	var calls []string
+	ext := &external{
+		logger: logging.NewNopLogger(),
+		client: &mockDockerClient{
+			containerStopFunc: func(_ context.Context, _ string, _ container.StopOptions) error {
+				calls = append(calls, "stop")
+				return nil
+			},
+			containerRemoveFunc: func(_ context.Context, _ string, _ container.RemoveOptions) error {
+				calls = append(calls, "remove")
+				return nil
+			},
+		},
+	}
+
+	// Observe, Create and Delete, but never Update: drift must not be
+	// repaired by recreating the container.
+	cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{Image: "nginx:latest"}}}
+	cr.SetName("licensed")
+	cr.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionDelete})
+	meta.SetExternalName(cr, "licensed")
+
+	if _, err := ext.Update(context.Background(), cr); err != nil {
+		t.Errorf("Update(...) without the Update policy: %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("Update(...) without the Update policy called Docker: %v", calls)
+	}
+
+	// Observe only: the container is neither created nor deleted.
+	cr.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+	if _, err := ext.Create(context.Background(), cr); err == nil {
+		t.Errorf("Create(...) without the Create policy: want error, got nil")
+	}
+	if _, err := ext.Delete(context.Background(), cr); err != nil {
+		t.Errorf("Delete(...) without the Delete policy: %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("Create and Delete without their policies called Docker: %v", calls)
+	}
+}
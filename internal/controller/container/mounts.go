@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"fmt"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"path/filepath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
+	"strings"
+)
+
+// mountDrift lists how the mounts of a container differ from the volumes its
+// spec mounts: volumes that are not mounted, or are mounted from another
+// source, with another type, read-only flag or bind propagation, and volumes
+// that are mounted but are no longer in the spec. It lists nothing for a spec
+// that does not build, which fails when the container is created instead.
+func mountDrift(owner client.ObjectKey, volumes []v1alpha1.VolumeMount, info *container.InspectResponse) []string {
+	binds, mounts, err := (&defaultContainerConfigBuilder{}).buildVolumeConfiguration(owner, volumes)
+	if err != nil {
+		return nil
+	}
+	want := slices.Clone(mounts)
+	for _, bind := range binds {
+		want = append(want, parseBind(bind))
+	}
+	slices.SortFunc(want, func(a, b mount.Mount) int {
+		return strings.Compare(a.Target, b.Target)
+	})
+
+	have := make(map[string]container.MountPoint, len(info.Mounts))
+	for _, m := range info.Mounts {
+		have[m.Destination] = m
+	}
+
+	var drift []string
+	wanted := make(map[string]bool, len(want))
+	for _, w := range want {
+		wanted[w.Target] = true
+		m, ok := have[w.Target]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("%s: not mounted", w.Target))
+			continue
+		}
+		if m.Type != w.Type {
+			drift = append(drift, fmt.Sprintf("%s: %s mount, want %s", w.Target, m.Type, w.Type))
+			continue
+		}
+		if source := mountSource(m); w.Type != mount.TypeTmpfs && source != filepath.Clean(w.Source) {
+			drift = append(drift, fmt.Sprintf("%s: mounted from %s, want %s", w.Target, source, w.Source))
+		}
+		if m.RW == w.ReadOnly {
+			drift = append(drift, fmt.Sprintf("%s: %s, want %s", w.Target, access(!m.RW), access(w.ReadOnly)))
+		}
+		if w.BindOptions != nil && w.BindOptions.Propagation != "" && m.Propagation != w.BindOptions.Propagation {
+			drift = append(drift, fmt.Sprintf("%s: %s propagation, want %s", w.Target, m.Propagation, w.BindOptions.Propagation))
+		}
+	}
+
+	// Mounts Docker was asked for that the spec no longer has. Bind mounts
+	// may have been injected by the ProviderConfig, and other mounts may be
+	// volumes of the image, so only those configured with the container,
+	// other than bind mounts, are known to be the spec's.
+	var removed []string
+	if info.HostConfig != nil {
+		for _, bind := range info.HostConfig.Binds {
+			if target := bindTarget(bind); !wanted[target] {
+				removed = append(removed, target)
+			}
+		}
+		for _, m := range info.HostConfig.Mounts {
+			if m.Type != mount.TypeBind && !wanted[m.Target] {
+				removed = append(removed, m.Target)
+			}
+		}
+	}
+	slices.Sort(removed)
+	for _, target := range removed {
+		drift = append(drift, fmt.Sprintf("%s: mounted, but not in the spec", target))
+	}
+	return drift
+}
+
+// parseBind parses a source:target[:options] bind as the mount it makes.
+func parseBind(bind string) mount.Mount {
+	parts := strings.Split(bind, ":")
+	m := mount.Mount{Type: mount.TypeBind, Source: parts[0], Target: bindTarget(bind)}
+	if len(parts) > 2 {
+		m.ReadOnly = slices.Contains(strings.Split(parts[2], ","), "ro")
+	}
+	return m
+}
+
+// mountSource returns what a mount is mounted from: the volume of a volume
+// mount, and the host path of any other.
+func mountSource(m container.MountPoint) string {
+	if m.Type == mount.TypeVolume {
+		return m.Name
+	}
+	return filepath.Clean(m.Source)
+}
+
+// access describes whether a mount is read-only.
+func access(readOnly bool) string {
+	if readOnly {
+		return "read-only"
+	}
+	return "read-write"
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/google/go-cmp/cmp"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"testing"
+)
+
+func TestMountDrift(t *testing.T) {
+	readOnly := true
+	rshared := "rshared"
+	volumes := []v1alpha1.VolumeMount{
+		{Name: "data", MountPath: "/var/lib/data", VolumeSource: v1alpha1.VolumeSource{Volume: &v1alpha1.VolumeVolumeSource{VolumeName: "data"}}},
+		{Name: "conf", MountPath: "/etc/app", ReadOnly: &readOnly, VolumeSource: v1alpha1.VolumeSource{HostPath: &v1alpha1.HostPathVolumeSource{Path: "/srv/conf"}}},
+		{Name: "run", MountPath: "/run/app", VolumeSource: v1alpha1.VolumeSource{Bind: &v1alpha1.BindVolumeSource{SourcePath: "/run/app", Propagation: &rshared}}},
+		{Name: "cache", MountPath: "/cache", VolumeSource: v1alpha1.VolumeSource{EmptyDir: &v1alpha1.EmptyDirVolumeSource{}}},
+	}
+	mounted := func() []container.MountPoint {
+		return []container.MountPoint{
+			{Type: mount.TypeVolume, Name: "data", Source: "/var/lib/docker/volumes/data/_data", Destination: "/var/lib/data", RW: true},
+			{Type: mount.TypeBind, Source: "/srv/conf", Destination: "/etc/app", Propagation: mount.PropagationRPrivate},
+			{Type: mount.TypeBind, Source: "/run/app", Destination: "/run/app", RW: true, Propagation: mount.PropagationRShared},
+			{Type: mount.TypeTmpfs, Destination: "/cache", RW: true},
+			// A volume of the image, and a mount injected by the ProviderConfig
+			{Type: mount.TypeVolume, Name: "0f3c9a", Destination: "/var/log/app", RW: true},
+			{Type: mount.TypeBind, Source: "/var/run/log.sock", Destination: "/var/run/log.sock", RW: true},
+		}
+	}
+	configured := func() *container.HostConfig {
+		return &container.HostConfig{
+			Binds: []string{"/srv/conf:/etc/app:ro"},
+			Mounts: []mount.Mount{
+				{Type: mount.TypeVolume, Source: "data", Target: "/var/lib/data"},
+				{Type: mount.TypeBind, Source: "/run/app", Target: "/run/app"},
+				{Type: mount.TypeTmpfs, Target: "/cache"},
+				{Type: mount.TypeBind, Source: "/var/run/log.sock", Target: "/var/run/log.sock"},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		volumes []v1alpha1.VolumeMount
+		change  func(mounts []container.MountPoint, hostConfig *container.HostConfig) []container.MountPoint
+		want    []string
+	}{
+		"UpToDate": {
+			volumes: volumes,
+		},
+		"NoVolumes": {
+			change: func(_ []container.MountPoint, hostConfig *container.HostConfig) []container.MountPoint {
+				hostConfig.Binds, hostConfig.Mounts = nil, nil
+				return nil
+			},
+		},
+		"ReadOnlyChanged": {
+			volumes: volumes,
+			change: func(mounts []container.MountPoint, _ *container.HostConfig) []container.MountPoint {
+				mounts[0].RW = false
+				mounts[1].RW = true
+				return mounts
+			},
+			want: []string{
+				"/etc/app: read-write, want read-only",
+				"/var/lib/data: read-only, want read-write",
+			},
+		},
+		"SourceChanged": {
+			volumes: volumes,
+			change: func(mounts []container.MountPoint, _ *container.HostConfig) []container.MountPoint {
+				mounts[0].Name = "data-old"
+				mounts[1].Source = "/srv/conf-old"
+				return mounts
+			},
+			want: []string{
+				"/etc/app: mounted from /srv/conf-old, want /srv/conf",
+				"/var/lib/data: mounted from data-old, want data",
+			},
+		},
+		"PropagationChanged": {
+			volumes: volumes,
+			change: func(mounts []container.MountPoint, _ *container.HostConfig) []container.MountPoint {
+				mounts[2].Propagation = mount.PropagationRPrivate
+				return mounts
+			},
+			want: []string{"/run/app: rprivate propagation, want rshared"},
+		},
+		"TypeChanged": {
+			volumes: volumes,
+			change: func(mounts []container.MountPoint, _ *container.HostConfig) []container.MountPoint {
+				mounts[3] = container.MountPoint{Type: mount.TypeVolume, Name: "cache", Destination: "/cache", RW: true}
+				return mounts
+			},
+			want: []string{"/cache: volume mount, want tmpfs"},
+		},
+		"Added": {
+			volumes: append(volumes, v1alpha1.VolumeMount{
+				Name: "logs", MountPath: "/logs", VolumeSource: v1alpha1.VolumeSource{HostPath: &v1alpha1.HostPathVolumeSource{Path: "/srv/logs"}},
+			}),
+			want: []string{"/logs: not mounted"},
+		},
+		"Removed": {
+			volumes: volumes[1:3],
+			want: []string{
+				"/cache: mounted, but not in the spec",
+				"/var/lib/data: mounted, but not in the spec",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			info := &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{HostConfig: configured()},
+				Mounts:            mounted(),
+			}
+			if tt.change != nil {
+				info.Mounts = tt.change(info.Mounts, info.HostConfig)
+			}
+			got := mountDrift(client.ObjectKey{Namespace: "default", Name: "web"}, tt.volumes, info)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mountDrift() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	"strings"
+)
+
+// nameHashLength is the number of hex digits of the hash that ends a
+// truncated container name.
+const nameHashLength = 8
+
+// policyName returns the name the naming policy of its ProviderConfig gives
+// a container, or "" if the policy does not apply. It applies only to
+// containers that do not set forProvider.name and whose external-name is
+// still the name of their managed resource, that is, that have not been
+// created yet.
+func policyName(naming *apisv1beta1.ContainerNaming, cr *v1alpha1.Container) string {
+	if naming == nil {
+		return ""
+	}
+	if cr.Spec.ForProvider.Name != nil && *cr.Spec.ForProvider.Name != "" {
+		return ""
+	}
+	if meta.GetExternalName(cr) != cr.GetName() {
+		return ""
+	}
+	return applyNaming(naming, cr.GetName())
+}
+
+// applyNaming adds the prefix and suffix of a naming policy to name, then
+// truncates the result to the policy's maximum length, ending it with a hash
+// of the untruncated name so that names sharing a long prefix stay distinct.
+func applyNaming(naming *apisv1beta1.ContainerNaming, name string) string {
+	name = naming.Prefix + name + naming.Suffix
+	if naming.MaxLength == nil || len(name) <= int(*naming.MaxLength) {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])[:nameHashLength]
+	keep := strings.TrimRight(name[:int(*naming.MaxLength)-nameHashLength-1], "-_.")
+	return keep + "-" + hash
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+	"testing"
+)
+
+func TestPolicyName(t *testing.T) {
+	sixteen := int32(16)
+	named := "explicit"
+	long := strings.Repeat("a", 20)
+
+	tests := []struct {
+		name         string
+		naming       *apisv1beta1.ContainerNaming
+		resource     string
+		externalName string
+		specName     *string
+		want         string
+	}{
+		{
+			name:     "NoPolicy",
+			resource: "web",
+		},
+		{
+			name:     "PrefixAndSuffix",
+			naming:   &apisv1beta1.ContainerNaming{Prefix: "eu-1-", Suffix: "-blue"},
+			resource: "web",
+			want:     "eu-1-web-blue",
+		},
+		{
+			name:     "ExplicitName",
+			naming:   &apisv1beta1.ContainerNaming{Prefix: "eu-1-"},
+			resource: "web",
+			specName: &named,
+		},
+		{
+			name:         "AlreadyCreated",
+			naming:       &apisv1beta1.ContainerNaming{Prefix: "eu-1-"},
+			resource:     "web",
+			externalName: "eu-1-web",
+		},
+		{
+			name:     "ShortEnough",
+			naming:   &apisv1beta1.ContainerNaming{Prefix: "eu-1-", MaxLength: &sixteen},
+			resource: "web",
+			want:     "eu-1-web",
+		},
+		{
+			name:     "Truncated",
+			naming:   &apisv1beta1.ContainerNaming{Prefix: "eu-1-", MaxLength: &sixteen},
+			resource: long,
+			want:     "eu-1-aa-2f847a78",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: tt.resource}}
+			cr.Spec.ForProvider.Name = tt.specName
+			externalName := tt.externalName
+			if externalName == "" {
+				externalName = tt.resource
+			}
+			meta.SetExternalName(cr, externalName)
+
+			got := policyName(tt.naming, cr)
+			if got != tt.want {
+				t.Errorf("policyName() = %q, want %q", got, tt.want)
+			}
+			if tt.naming != nil && tt.naming.MaxLength != nil && len(got) > int(*tt.naming.MaxLength) {
+				t.Errorf("policyName() = %q, longer than %d", got, *tt.naming.MaxLength)
+			}
+		})
+	}
+}
+
+func TestApplyNamingKeepsTruncatedNamesDistinct(t *testing.T) {
+	sixteen := int32(16)
+	naming := &apisv1beta1.ContainerNaming{Prefix: "cluster-", MaxLength: &sixteen}
+	a := applyNaming(naming, "frontend-web-1")
+	b := applyNaming(naming, "frontend-web-2")
+	if a == b {
+		t.Errorf("applyNaming() gave %q to both frontend-web-1 and frontend-web-2", a)
+	}
+	if len(a) != 16 || len(b) != 16 {
+		t.Errorf("applyNaming() = %q and %q, want 16 characters each", a, b)
+	}
+}
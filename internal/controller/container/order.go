@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"cmp"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"slices"
+	"strings"
+)
+
+// sortConfig puts the environment variables and mounts of a container's
+// configuration in a deterministic order, whatever the order of the spec
+// and the ProviderConfig injection they were built from. Variables are
+// sorted by name and mounts by target; a variable set twice keeps its
+// order, so that the last value still wins. Labels and exposed ports are
+// maps, which Docker does not order.
+func sortConfig(config *container.Config, hostConfig *container.HostConfig) {
+	slices.SortStableFunc(config.Env, func(a, b string) int {
+		return strings.Compare(envName(a), envName(b))
+	})
+	slices.SortStableFunc(hostConfig.Binds, func(a, b string) int {
+		return strings.Compare(bindTarget(a), bindTarget(b))
+	})
+	slices.SortStableFunc(hostConfig.Mounts, func(a, b mount.Mount) int {
+		return strings.Compare(a.Target, b.Target)
+	})
+}
+
+// envName returns the name of a NAME=value environment variable.
+func envName(env string) string {
+	name, _, _ := strings.Cut(env, "=")
+	return name
+}
+
+// bindTarget returns the container path of a source:target[:options] bind.
+func bindTarget(bind string) string {
+	if parts := strings.Split(bind, ":"); len(parts) > 1 {
+		return parts[1]
+	}
+	return bind
+}
+
+// sameElements reports whether two lists have the same elements, in any
+// order.
+func sameElements[T cmp.Ordered](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// sameDevices reports whether two lists of devices have the same devices, in
+// any order.
+func sameDevices(a, b []container.DeviceMapping) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	byPath := func(x, y container.DeviceMapping) int {
+		return cmp.Or(strings.Compare(x.PathInContainer, y.PathInContainer), strings.Compare(x.PathOnHost, y.PathOnHost),
+			strings.Compare(x.CgroupPermissions, y.CgroupPermissions))
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.SortFunc(a, byPath)
+	slices.SortFunc(b, byPath)
+	return slices.Equal(a, b)
+}
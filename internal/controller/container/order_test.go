@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"slices"
+	"testing"
+)
+
+func TestBuildContainerConfigOrder(t *testing.T) {
+	a, b, c, c2 := "1", "2", "3", "4"
+	env := []v1alpha1.EnvVar{{Name: "ZONE", Value: &a}, {Name: "APP", Value: &b}, {Name: "MODE", Value: &c}, {Name: "APP", Value: &c2}}
+	volumes := []v1alpha1.VolumeMount{
+		{Name: "data", MountPath: "/var/lib/data", VolumeSource: v1alpha1.VolumeSource{Volume: &v1alpha1.VolumeVolumeSource{VolumeName: "data"}}},
+		{Name: "cache", MountPath: "/cache", VolumeSource: v1alpha1.VolumeSource{EmptyDir: &v1alpha1.EmptyDirVolumeSource{}}},
+		{Name: "conf", MountPath: "/etc/app", VolumeSource: v1alpha1.VolumeSource{HostPath: &v1alpha1.HostPathVolumeSource{Path: "/srv/conf"}}},
+		{Name: "logs", MountPath: "/logs", VolumeSource: v1alpha1.VolumeSource{HostPath: &v1alpha1.HostPathVolumeSource{Path: "/srv/logs"}}},
+	}
+	build := func(env []v1alpha1.EnvVar, volumes []v1alpha1.VolumeMount) (*container.Config, *container.HostConfig) {
+		t.Helper()
+		cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+			Image:       "nginx:1.27",
+			Environment: env,
+			Volumes:     volumes,
+		}}}
+		config, hostConfig, _, _, err := NewContainerConfigBuilder().BuildContainerConfig(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("BuildContainerConfig() unexpected error: %v", err)
+		}
+		return config, hostConfig
+	}
+
+	config, hostConfig := build(env, volumes)
+	// The later of two values of a variable still wins
+	if diff := cmp.Diff([]string{"APP=2", "APP=4", "MODE=3", "ZONE=1"}, config.Env); diff != "" {
+		t.Errorf("BuildContainerConfig() config.Env mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"/srv/conf:/etc/app", "/srv/logs:/logs"}, hostConfig.Binds); diff != "" {
+		t.Errorf("BuildContainerConfig() hostConfig.Binds mismatch (-want +got):\n%s", diff)
+	}
+	if hostConfig.Mounts[0].Target != "/cache" || hostConfig.Mounts[1].Target != "/var/lib/data" {
+		t.Errorf("BuildContainerConfig() mounts are not sorted by target: %v", hostConfig.Mounts)
+	}
+
+	// The same spec in another order builds the same configuration
+	reversedEnv := []v1alpha1.EnvVar{env[2], env[0], env[1], env[3]}
+	reversedVolumes := slices.Clone(volumes)
+	slices.Reverse(reversedVolumes)
+	otherConfig, otherHostConfig := build(reversedEnv, reversedVolumes)
+	if diff := cmp.Diff(config.Env, otherConfig.Env); diff != "" {
+		t.Errorf("BuildContainerConfig() config.Env depends on spec order (-first +second):\n%s", diff)
+	}
+	if diff := cmp.Diff(hostConfig, otherHostConfig); diff != "" {
+		t.Errorf("BuildContainerConfig() hostConfig depends on spec order (-first +second):\n%s", diff)
+	}
+}
+
+func TestIsUpToDateOrderInsensitive(t *testing.T) {
+	cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+		Image:             "alpine:3.20",
+		DeviceCgroupRules: []string{"c 189:* rmw", "c 81:* rmw"},
+		Devices:           []v1alpha1.DeviceMapping{{HostPath: "/dev/ttyUSB0"}, {HostPath: "/dev/video0"}},
+	}}}
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			State: &container.State{Running: true},
+			HostConfig: &container.HostConfig{Resources: container.Resources{
+				DeviceCgroupRules: []string{"c 81:* rmw", "c 189:* rmw"},
+				Devices: []container.DeviceMapping{
+					{PathOnHost: "/dev/video0", PathInContainer: "/dev/video0", CgroupPermissions: "rwm"},
+					{PathOnHost: "/dev/ttyUSB0", PathInContainer: "/dev/ttyUSB0", CgroupPermissions: "rwm"},
+				},
+			}},
+		},
+		Config: &container.Config{Image: "alpine:3.20"},
+	}
+	c := &external{}
+	if !c.isUpToDate(cr, info) {
+		t.Error("isUpToDate() = false for a container that reports its devices in another order")
+	}
+	info.HostConfig.DeviceCgroupRules = []string{"c 81:* rmw"}
+	if c.isUpToDate(cr, info) {
+		t.Error("isUpToDate() = true for a container missing a device cgroup rule")
+	}
+}
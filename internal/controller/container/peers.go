@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"slices"
+	"strings"
+)
+
+const errInspectPeer = "cannot inspect bridge peer %q"
+
+// peerHostname returns the name a bridge peer is resolved as.
+func peerHostname(p v1alpha1.BridgePeer) string {
+	if p.Hostname != nil && *p.Hostname != "" {
+		return *p.Hostname
+	}
+	return p.Container
+}
+
+// peerHosts returns the /etc/hosts entries, as hostname:address, that
+// resolve the bridge peers of a container. Peers that do not exist, or that
+// have no address on the default bridge network, have no entry.
+func (c *external) peerHosts(ctx context.Context, peers []v1alpha1.BridgePeer) ([]string, error) {
+	var hosts []string
+	for _, p := range peers {
+		info, err := c.client.ContainerInspect(ctx, p.Container)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, errInspectPeer, p.Container)
+		}
+		if addr := bridgeAddress(&info); addr != "" {
+			hosts = append(hosts, peerHostname(p)+":"+addr)
+		}
+	}
+	return hosts, nil
+}
+
+// bridgeAddress returns the address of a container on the default bridge
+// network, if it has one.
+func bridgeAddress(info *container.InspectResponse) string {
+	if info.NetworkSettings == nil {
+		return ""
+	}
+	if ep, ok := info.NetworkSettings.Networks[network.NetworkBridge]; ok && ep != nil {
+		return ep.IPAddress
+	}
+	return ""
+}
+
+// arePeersUpToDate reports whether the /etc/hosts entries of a container
+// still resolve its bridge peers to their current addresses.
+func (c *external) arePeersUpToDate(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse) (bool, error) {
+	peers := cr.Spec.ForProvider.BridgePeers
+	if len(peers) == 0 {
+		return true, nil
+	}
+	want, err := c.peerHosts(ctx, peers)
+	if err != nil {
+		return false, err
+	}
+
+	// Only the entries of peer hostnames are compared, as any others come
+	// from the container's ExtraHosts.
+	names := make(map[string]bool, len(peers))
+	for _, p := range peers {
+		names[peerHostname(p)] = true
+	}
+	var have []string
+	if info.HostConfig != nil {
+		for _, h := range info.HostConfig.ExtraHosts {
+			if name, _, ok := strings.Cut(h, ":"); ok && names[name] {
+				have = append(have, h)
+			}
+		}
+	}
+
+	slices.Sort(want)
+	slices.Sort(have)
+	return slices.Equal(want, have), nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"slices"
+	"testing"
+)
+
+func TestArePeersUpToDate(t *testing.T) {
+	db := "db"
+	addresses := map[string]string{"postgres": "172.17.0.2", "cache": "172.17.0.3", "stopped": ""}
+	inspect := func(_ context.Context, id string) (container.InspectResponse, error) {
+		addr, ok := addresses[id]
+		if !ok {
+			return container.InspectResponse{}, clients.NewNotFoundError("container", id)
+		}
+		networks := map[string]*network.EndpointSettings{}
+		if addr != "" {
+			networks[network.NetworkBridge] = &network.EndpointSettings{IPAddress: addr}
+		}
+		return container.InspectResponse{
+			NetworkSettings: &container.NetworkSettings{Networks: networks},
+		}, nil
+	}
+
+	tests := []struct {
+		name       string
+		peers      []v1alpha1.BridgePeer
+		extraHosts []string
+		inspectErr error
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name:       "NoPeers",
+			extraHosts: []string{"example.com:10.0.0.1"},
+			want:       true,
+		},
+		{
+			name:       "Resolved",
+			peers:      []v1alpha1.BridgePeer{{Container: "postgres", Hostname: &db}, {Container: "cache"}},
+			extraHosts: []string{"example.com:10.0.0.1", "cache:172.17.0.3", "db:172.17.0.2"},
+			want:       true,
+		},
+		{
+			name:       "AddressChanged",
+			peers:      []v1alpha1.BridgePeer{{Container: "postgres", Hostname: &db}},
+			extraHosts: []string{"db:172.17.0.9"},
+			want:       false,
+		},
+		{
+			name:  "PeerStarted",
+			peers: []v1alpha1.BridgePeer{{Container: "cache"}},
+			want:  false,
+		},
+		{
+			name:       "PeerStopped",
+			peers:      []v1alpha1.BridgePeer{{Container: "stopped"}},
+			extraHosts: []string{"stopped:172.17.0.4"},
+			want:       false,
+		},
+		{
+			name:  "PeerMissing",
+			peers: []v1alpha1.BridgePeer{{Container: "missing"}},
+			want:  true,
+		},
+		{
+			name:       "InspectFailed",
+			peers:      []v1alpha1.BridgePeer{{Container: "postgres"}},
+			inspectErr: errors.New("boom"),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &external{client: &mockDockerClient{
+				containerInspectFunc: func(ctx context.Context, id string) (container.InspectResponse, error) {
+					if tt.inspectErr != nil {
+						return container.InspectResponse{}, tt.inspectErr
+					}
+					return inspect(ctx, id)
+				},
+			}}
+			cr := &v1alpha1.Container{}
+			cr.Spec.ForProvider.BridgePeers = tt.peers
+			info := &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					HostConfig: &container.HostConfig{ExtraHosts: tt.extraHosts},
+				},
+			}
+
+			got, err := e.arePeersUpToDate(context.Background(), cr, info)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("arePeersUpToDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("arePeersUpToDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalCreateResolvesBridgePeers(t *testing.T) {
+	var extraHosts []string
+	ext := &external{
+		client: &mockDockerClient{
+			containerInspectFunc: func(_ context.Context, _ string) (container.InspectResponse, error) {
+				return container.InspectResponse{
+					NetworkSettings: &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+						network.NetworkBridge: {IPAddress: "172.17.0.2"},
+					}},
+				}, nil
+			},
+			containerCreateFunc: func(_ context.Context, _ *container.Config, hc *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+				extraHosts = hc.ExtraHosts
+				return container.CreateResponse{ID: "test-container-id"}, nil
+			},
+		},
+		configBuilder: &defaultContainerConfigBuilder{},
+		logger:        logging.NewNopLogger(),
+	}
+
+	cr := &v1alpha1.Container{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-container"},
+		Spec: v1alpha1.ContainerSpec{
+			ForProvider: v1alpha1.ContainerParameters{
+				Image:       "nginx:latest",
+				ExtraHosts:  []string{"example.com:10.0.0.1"},
+				BridgePeers: []v1alpha1.BridgePeer{{Container: "postgres"}},
+			},
+		},
+	}
+	if _, err := ext.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	want := []string{"example.com:10.0.0.1", "postgres:172.17.0.2"}
+	if !slices.Equal(extraHosts, want) {
+		t.Errorf("Create() ExtraHosts = %v, want %v", extraHosts, want)
+	}
+}
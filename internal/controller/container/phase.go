@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"slices"
+)
+
+// phaseSteps lists the phases a container may be in when the provider moves
+// it to each phase other than Creating and Removing, as it creates it. Every
+// other move is observed, and may happen from any phase, since a container
+// can change outside of the provider.
+var phaseSteps = map[v1alpha1.ContainerPhase][]v1alpha1.ContainerPhase{
+	v1alpha1.PhasePulling:  {v1alpha1.PhaseCreating},
+	v1alpha1.PhaseStarting: {v1alpha1.PhaseCreating},
+	v1alpha1.PhasePending:  {v1alpha1.PhaseCreating},
+}
+
+// setPhase moves a container to a phase the provider is driving it to, and
+// reports whether the move was allowed. Any container may move to Removing,
+// and any container that is not Removing may move to Creating.
+func setPhase(cr *v1alpha1.Container, to v1alpha1.ContainerPhase) bool {
+	from := cr.Status.AtProvider.Phase
+	switch {
+	case to == v1alpha1.PhaseRemoving:
+	case from == v1alpha1.PhaseRemoving:
+		return false
+	case to == v1alpha1.PhaseCreating:
+	case !slices.Contains(phaseSteps[to], from):
+		return false
+	}
+	cr.Status.AtProvider.Phase = to
+	return true
+}
+
+// nextPhase returns the phase of a container that has just been inspected.
+// A container stays Removing until it is gone.
+func nextPhase(cr *v1alpha1.Container, info *container.InspectResponse) v1alpha1.ContainerPhase {
+	if cr.Status.AtProvider.Phase == v1alpha1.PhaseRemoving && meta.WasDeleted(cr) {
+		return v1alpha1.PhaseRemoving
+	}
+	return observedPhase(info)
+}
+
+// observedPhase derives the phase of a container from its inspected state.
+func observedPhase(info *container.InspectResponse) v1alpha1.ContainerPhase {
+	if info.ContainerJSONBase == nil || info.State == nil {
+		return v1alpha1.PhasePending
+	}
+
+	switch info.State.Status {
+	case container.StateRunning:
+		if info.State.Health != nil {
+			switch info.State.Health.Status {
+			case container.Starting:
+				return v1alpha1.PhaseStarting
+			case container.Unhealthy:
+				return v1alpha1.PhaseDegraded
+			}
+		}
+		return v1alpha1.PhaseRunning
+	case container.StateRestarting:
+		return v1alpha1.PhaseStarting
+	case container.StatePaused, container.StateDead:
+		return v1alpha1.PhaseDegraded
+	case container.StateExited:
+		return v1alpha1.PhaseExited
+	case container.StateRemoving:
+		return v1alpha1.PhaseRemoving
+	}
+	return v1alpha1.PhasePending
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+)
+
+// portsMatch reports whether a container publishes the ports its spec sets,
+// on the host addresses and ports it sets, and publishes no others. The
+// bindings of a running container are compared as Docker reports them in its
+// network settings, and those of a stopped container as it was configured.
+// Ports a container only exposes may also be exposed by its image, so only
+// those the spec sets are checked.
+func portsMatch(ports []v1alpha1.PortSpec, info *container.InspectResponse) bool {
+	// Containers that share a network namespace publish no ports of their
+	// own
+	if info.HostConfig != nil && !publishesPorts(info.HostConfig.NetworkMode) {
+		return true
+	}
+
+	exposed, want, err := (&defaultContainerConfigBuilder{}).buildPortConfiguration(ports)
+	if err != nil {
+		return false
+	}
+	if info.Config != nil {
+		for port := range exposed {
+			if _, ok := info.Config.ExposedPorts[port]; !ok {
+				return false
+			}
+		}
+	}
+
+	var have nat.PortMap
+	switch {
+	case info.NetworkSettings != nil && len(info.NetworkSettings.Ports) > 0:
+		have = info.NetworkSettings.Ports
+	case info.HostConfig != nil:
+		have = info.HostConfig.PortBindings
+	}
+	for port, bindings := range have {
+		if len(bindings) > 0 && len(want[port]) == 0 {
+			return false
+		}
+	}
+	for port, bindings := range want {
+		if !bindingsMatch(bindings[0], have[port]) {
+			return false
+		}
+	}
+	return true
+}
+
+// publishesPorts reports whether a container in a network mode has ports of
+// its own to publish.
+func publishesPorts(mode container.NetworkMode) bool {
+	return !mode.IsHost() && !mode.IsNone() && !mode.IsContainer()
+}
+
+// bindingsMatch reports whether a port is published as a spec binds it.
+// Docker publishes a port bound to no address on every address, once for
+// IPv4 and once for IPv6, and a port bound to host port 0 on any free port.
+func bindingsMatch(want nat.PortBinding, have []nat.PortBinding) bool {
+	if len(have) == 0 {
+		return false
+	}
+	for _, b := range have {
+		if want.HostPort != "0" && b.HostPort != want.HostPort {
+			return false
+		}
+		if want.HostIP == "" {
+			if b.HostIP != "" && b.HostIP != "0.0.0.0" && b.HostIP != "::" {
+				return false
+			}
+		} else if b.HostIP != want.HostIP {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"testing"
+)
+
+func TestPortsMatch(t *testing.T) {
+	port := func(containerPort, hostPort int32, hostIP, protocol string) v1alpha1.PortSpec {
+		p := v1alpha1.PortSpec{ContainerPort: containerPort}
+		if hostPort >= 0 {
+			p.HostPort = &hostPort
+		}
+		if hostIP != "" {
+			p.HostIP = &hostIP
+		}
+		if protocol != "" {
+			p.Protocol = &protocol
+		}
+		return p
+	}
+	everywhere := func(hostPort string) []nat.PortBinding {
+		return []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: hostPort}, {HostIP: "::", HostPort: hostPort}}
+	}
+	running := func(ports nat.PortMap) *container.InspectResponse {
+		return &container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{HostConfig: &container.HostConfig{}},
+			Config:            &container.Config{ExposedPorts: nat.PortSet{"80/tcp": {}, "53/udp": {}, "9090/tcp": {}}},
+			NetworkSettings:   &container.NetworkSettings{NetworkSettingsBase: container.NetworkSettingsBase{Ports: ports}},
+		}
+	}
+
+	tests := map[string]struct {
+		ports []v1alpha1.PortSpec
+		info  *container.InspectResponse
+		want  bool
+	}{
+		"NoPorts": {
+			info: running(nat.PortMap{"80/tcp": nil}),
+			want: true,
+		},
+		"Published": {
+			ports: []v1alpha1.PortSpec{port(80, 8080, "", ""), port(53, 5353, "", "UDP")},
+			info:  running(nat.PortMap{"80/tcp": everywhere("8080"), "53/udp": everywhere("5353")}),
+			want:  true,
+		},
+		"ExposedOnly": {
+			ports: []v1alpha1.PortSpec{port(9090, -1, "", "")},
+			info:  running(nat.PortMap{"9090/tcp": nil, "80/tcp": nil}),
+			want:  true,
+		},
+		"NotExposed": {
+			ports: []v1alpha1.PortSpec{port(8443, -1, "", "")},
+			info:  running(nat.PortMap{"80/tcp": nil}),
+		},
+		"HostPortChanged": {
+			ports: []v1alpha1.PortSpec{port(80, 8081, "", "")},
+			info:  running(nat.PortMap{"80/tcp": everywhere("8080")}),
+		},
+		"AnyHostPort": {
+			ports: []v1alpha1.PortSpec{port(80, 0, "", "")},
+			info:  running(nat.PortMap{"80/tcp": everywhere("32768")}),
+			want:  true,
+		},
+		"HostIP": {
+			ports: []v1alpha1.PortSpec{port(80, 8080, "127.0.0.1", "")},
+			info:  running(nat.PortMap{"80/tcp": {{HostIP: "127.0.0.1", HostPort: "8080"}}}),
+			want:  true,
+		},
+		"HostIPChanged": {
+			ports: []v1alpha1.PortSpec{port(80, 8080, "127.0.0.1", "")},
+			info:  running(nat.PortMap{"80/tcp": everywhere("8080")}),
+		},
+		"ProtocolChanged": {
+			ports: []v1alpha1.PortSpec{port(53, 5353, "", "UDP")},
+			info:  running(nat.PortMap{"53/tcp": everywhere("5353")}),
+		},
+		"PortRemoved": {
+			ports: []v1alpha1.PortSpec{port(80, 8080, "", "")},
+			info:  running(nat.PortMap{"80/tcp": everywhere("8080"), "9090/tcp": everywhere("9090")}),
+		},
+		"Stopped": {
+			ports: []v1alpha1.PortSpec{port(80, 8080, "", "")},
+			info: &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{HostConfig: &container.HostConfig{
+					PortBindings: nat.PortMap{"80/tcp": {{HostPort: "8080"}}},
+				}},
+				Config: &container.Config{ExposedPorts: nat.PortSet{"80/tcp": {}}},
+			},
+			want: true,
+		},
+		"StoppedHostPortChanged": {
+			ports: []v1alpha1.PortSpec{port(80, 8081, "", "")},
+			info: &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{HostConfig: &container.HostConfig{
+					PortBindings: nat.PortMap{"80/tcp": {{HostPort: "8080"}}},
+				}},
+				Config: &container.Config{ExposedPorts: nat.PortSet{"80/tcp": {}}},
+			},
+		},
+		"HostNetwork": {
+			ports: []v1alpha1.PortSpec{port(80, 8080, "", "")},
+			info: &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{HostConfig: &container.HostConfig{NetworkMode: "host"}},
+				Config:            &container.Config{},
+			},
+			want: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := portsMatch(tt.ports, tt.info); got != tt.want {
+				t.Errorf("portsMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"fmt"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+const (
+	errCreateExec  = "cannot create exec instance"
+	errStartExec   = "cannot start exec instance"
+	errInspectExec = "cannot inspect exec instance"
+
+	// defaultPostConditionTimeout is how long a post-condition may run,
+	// when it does not say.
+	defaultPostConditionTimeout = 10 * time.Second
+)
+
+// execPollInterval is how often a running post-condition is checked for
+// completion.
+var execPollInterval = 250 * time.Millisecond
+
+// checkPostConditions runs the post-conditions of a running container, once
+// each time it starts, until they all pass. A container whose checks fail
+// is unavailable, and has a true PostConditionFailed condition.
+func (c *external) checkPostConditions(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse) error {
+	checks := cr.Spec.ForProvider.PostConditions
+	if len(checks) == 0 || !info.State.Running {
+		return nil
+	}
+	obs := &cr.Status.AtProvider
+	if obs.PostConditionsPassedAt != nil {
+		return nil
+	}
+	// Checks wait for the container to log its readiness line, if any, and
+	// for its post-start commands to succeed
+	if r := cr.Spec.ForProvider.Readiness; r != nil && r.WaitForLogLine != nil && obs.LogLineSeenAt == nil {
+		return nil
+	}
+	if !postStartSucceeded(cr) {
+		return nil
+	}
+
+	for _, pc := range checks {
+		ok, err := c.runPostCondition(ctx, info.ID, pc)
+		if err != nil {
+			return errors.Wrapf(err, "cannot check post-condition %q", pc.Name)
+		}
+		if !ok {
+			msg := fmt.Sprintf("Post-condition %q failed", pc.Name)
+			obs.Phase = v1alpha1.PhaseStarting
+			cr.SetConditions(v1alpha1.PostConditionFailed(msg), xpv1.Unavailable().WithMessage(msg))
+			return nil
+		}
+	}
+
+	now := metav1.Now()
+	obs.PostConditionsPassedAt = &now
+	cr.SetConditions(v1alpha1.PostConditionsMet())
+	return nil
+}
+
+// runPostCondition reports whether a post-condition passes in a container.
+// A check that does not finish within its timeout fails.
+func (c *external) runPostCondition(ctx context.Context, containerID string, pc v1alpha1.PostCondition) (bool, error) {
+	cmd := postConditionCommand(pc)
+	if cmd == nil {
+		return false, errors.New("exactly one of fileExists, command and portListening must be set")
+	}
+	timeout := defaultPostConditionTimeout
+	if pc.Timeout != nil {
+		timeout = pc.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	exec, err := c.client.ContainerExecCreate(ctx, containerID, container.ExecOptions{Cmd: cmd})
+	if err != nil {
+		return false, errors.Wrap(err, errCreateExec)
+	}
+	if err := c.client.ContainerExecStart(ctx, exec.ID, container.ExecStartOptions{Detach: true}); err != nil {
+		return false, errors.Wrap(err, errStartExec)
+	}
+	for {
+		res, err := c.client.ContainerExecInspect(ctx, exec.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, nil
+			}
+			return false, errors.Wrap(err, errInspectExec)
+		}
+		if !res.Running {
+			return res.ExitCode == 0, nil
+		}
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case <-time.After(execPollInterval):
+		}
+	}
+}
+
+// postConditionCommand returns the command that checks a post-condition,
+// or nil unless exactly one check is set.
+func postConditionCommand(pc v1alpha1.PostCondition) []string {
+	var cmds [][]string
+	if pc.FileExists != nil {
+		cmds = append(cmds, []string{"test", "-e", *pc.FileExists})
+	}
+	if len(pc.Command) > 0 {
+		cmds = append(cmds, pc.Command)
+	}
+	if pc.PortListening != nil {
+		// A listening socket has state 0A in /proc/net/tcp{,6}, with its
+		// local port in upper case hex
+		pattern := fmt.Sprintf(":%04X [0-9A-F]+:[0-9A-F]{4} 0A ", *pc.PortListening)
+		cmds = append(cmds, []string{"sh", "-c", fmt.Sprintf("grep -qE '%s' /proc/net/tcp /proc/net/tcp6 2>/dev/null", pattern)})
+	}
+	if len(cmds) != 1 {
+		return nil
+	}
+	return cmds[0]
+}
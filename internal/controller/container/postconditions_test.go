@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"errors"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCheckPostConditions(t *testing.T) {
+	path := "/var/run/app.ready"
+	port := int32(8080)
+	checks := []v1alpha1.PostCondition{
+		{Name: "ready-file", FileExists: &path},
+		{Name: "migrated", Command: []string{"app", "migrate", "--check"}},
+		{Name: "http", PortListening: &port},
+	}
+
+	tests := []struct {
+		name         string
+		exitCodes    map[string]int
+		running      bool
+		expectPassed bool
+		expectFailed corev1.ConditionStatus
+		expectReady  corev1.ConditionStatus
+		expectCmds   int
+	}{
+		{
+			name:         "AllPass",
+			exitCodes:    map[string]int{},
+			expectPassed: true,
+			expectFailed: corev1.ConditionFalse,
+			expectReady:  corev1.ConditionTrue,
+			expectCmds:   3,
+		},
+		{
+			name:         "CommandFails",
+			exitCodes:    map[string]int{"app": 1},
+			expectFailed: corev1.ConditionTrue,
+			expectReady:  corev1.ConditionFalse,
+			expectCmds:   2,
+		},
+		{
+			name:         "StillRunningAtTimeout",
+			exitCodes:    map[string]int{},
+			running:      true,
+			expectFailed: corev1.ConditionTrue,
+			expectReady:  corev1.ConditionFalse,
+			expectCmds:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:    "abc123",
+					State: &container.State{Status: container.StateRunning, Running: true, StartedAt: time.Now().UTC().Format(time.RFC3339Nano)},
+				},
+				Config: &container.Config{Image: "app:latest"},
+			}
+			cr := &v1alpha1.Container{
+				Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+					Image:          "app:latest",
+					PostConditions: append([]v1alpha1.PostCondition(nil), checks...),
+				}},
+			}
+			if tt.running {
+				for i := range cr.Spec.ForProvider.PostConditions {
+					cr.Spec.ForProvider.PostConditions[i].Timeout = &metav1.Duration{Duration: 10 * time.Millisecond}
+				}
+			}
+
+			var cmds [][]string
+			e := &external{client: &mockDockerClient{
+				execCreateFunc: func(_ context.Context, _ string, opts container.ExecOptions) (container.ExecCreateResponse, error) {
+					cmds = append(cmds, opts.Cmd)
+					return container.ExecCreateResponse{ID: opts.Cmd[0]}, nil
+				},
+				execInspectFunc: func(_ context.Context, id string) (container.ExecInspect, error) {
+					return container.ExecInspect{ExecID: id, Running: tt.running, ExitCode: tt.exitCodes[id]}, nil
+				},
+			}}
+			e.updateStatus(cr, info)
+			if err := e.checkPostConditions(context.Background(), cr, info); err != nil {
+				t.Fatalf("checkPostConditions() error = %v", err)
+			}
+
+			if len(cmds) != tt.expectCmds {
+				t.Errorf("checkPostConditions() ran %d checks, want %d", len(cmds), tt.expectCmds)
+			}
+			if got := cr.Status.AtProvider.PostConditionsPassedAt != nil; got != tt.expectPassed {
+				t.Errorf("checkPostConditions() passed = %v, want %v", got, tt.expectPassed)
+			}
+			if got := cr.GetCondition(v1alpha1.TypePostConditionFailed).Status; got != tt.expectFailed {
+				t.Errorf("checkPostConditions() PostConditionFailed = %q, want %q", got, tt.expectFailed)
+			}
+			if got := cr.GetCondition(xpv1.TypeReady).Status; got != tt.expectReady {
+				t.Errorf("checkPostConditions() Ready = %q, want %q", got, tt.expectReady)
+			}
+		})
+	}
+}
+
+func TestCheckPostConditionsOncePerStart(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+	passed := metav1.NewTime(started.Add(time.Second))
+	cr := &v1alpha1.Container{}
+	cr.Spec.ForProvider.PostConditions = []v1alpha1.PostCondition{{Name: "true", Command: []string{"true"}}}
+	cr.Status.AtProvider.PostConditionsPassedAt = &passed
+
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			State: &container.State{Status: container.StateRunning, Running: true, StartedAt: started.UTC().Format(time.RFC3339Nano)},
+		},
+		Config: &container.Config{},
+	}
+	e := &external{client: &mockDockerClient{
+		execCreateFunc: func(context.Context, string, container.ExecOptions) (container.ExecCreateResponse, error) {
+			return container.ExecCreateResponse{}, errors.New("checked again")
+		},
+	}}
+	e.updateStatus(cr, info)
+	if err := e.checkPostConditions(context.Background(), cr, info); err != nil {
+		t.Errorf("checkPostConditions() checked again after passing: %v", err)
+	}
+
+	// A restart runs the checks again
+	info.State.StartedAt = time.Now().UTC().Format(time.RFC3339Nano)
+	e.updateStatus(cr, info)
+	if cr.Status.AtProvider.PostConditionsPassedAt != nil {
+		t.Error("updateStatus() kept post-conditions passed before the container restarted")
+	}
+}
+
+func TestPostConditionCommand(t *testing.T) {
+	path := "/data"
+	port := int32(443)
+	tests := map[string]struct {
+		pc   v1alpha1.PostCondition
+		want []string
+	}{
+		"FileExists": {
+			pc:   v1alpha1.PostCondition{FileExists: &path},
+			want: []string{"test", "-e", "/data"},
+		},
+		"Command": {
+			pc:   v1alpha1.PostCondition{Command: []string{"pg_isready"}},
+			want: []string{"pg_isready"},
+		},
+		"PortListening": {
+			pc:   v1alpha1.PostCondition{PortListening: &port},
+			want: []string{"sh", "-c", "grep -qE ':01BB [0-9A-F]+:[0-9A-F]{4} 0A ' /proc/net/tcp /proc/net/tcp6 2>/dev/null"},
+		},
+		"None": {
+			pc: v1alpha1.PostCondition{},
+		},
+		"Several": {
+			pc: v1alpha1.PostCondition{FileExists: &path, PortListening: &port},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := postConditionCommand(tt.pc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postConditionCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"os"
+	"time"
+)
+
+const (
+	errRunPostStart = "cannot run post-start command %q"
+
+	// Defaults of a post-start command that does not say.
+	defaultPostStartTimeout = time.Minute
+	defaultPostStartRetries = 3
+	defaultPostStartBackoff = 10 * time.Second
+
+	// maxPostStartBackoff is the longest a retry of a post-start command
+	// waits.
+	maxPostStartBackoff = 5 * time.Minute
+
+	// maxCommandOutput is the most of the output of a command kept in the
+	// status of its container.
+	maxCommandOutput = 4096
+)
+
+// runPostStartCommands runs the post-start commands of a running container
+// in order, once each time it starts, retrying each that fails with backoff
+// until it has no retries left. A container whose commands have not all
+// succeeded is unavailable, and has a true PostStartFailed condition once
+// one has failed. Commands change the container, so they are not run in
+// dry-run mode, nor unless the management policies permit updates.
+func (c *external) runPostStartCommands(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse) error {
+	cmds := cr.Spec.ForProvider.PostStartCommands
+	if len(cmds) == 0 || !info.State.Running || c.readOnly || !allows(cr, xpv1.ManagementActionUpdate) {
+		return nil
+	}
+	obs := &cr.Status.AtProvider
+	// Commands wait for the container to log its readiness line, if any
+	if r := cr.Spec.ForProvider.Readiness; r != nil && r.WaitForLogLine != nil && obs.LogLineSeenAt == nil {
+		return nil
+	}
+
+	now := time.Now()
+	for _, cmd := range cmds {
+		result := commandResult(obs, cmd.Name)
+		if result.Succeeded {
+			continue
+		}
+		if result.Attempts == 0 || (result.NextAttemptTime != nil && !now.Before(result.NextAttemptTime.Time)) {
+			if err := c.runPostStartCommand(ctx, info.ID, cmd, result); err != nil {
+				return errors.Wrapf(err, errRunPostStart, cmd.Name)
+			}
+			if result.Succeeded {
+				continue
+			}
+		}
+
+		msg := fmt.Sprintf("Post-start command %q timed out", cmd.Name)
+		if result.ExitCode != nil {
+			msg = fmt.Sprintf("Post-start command %q exited %d", cmd.Name, *result.ExitCode)
+		}
+		if result.NextAttemptTime != nil {
+			msg += fmt.Sprintf(", retrying at %s", result.NextAttemptTime.UTC().Format(time.RFC3339))
+		} else {
+			msg += fmt.Sprintf(" after %d attempts", result.Attempts)
+		}
+		obs.Phase = v1alpha1.PhaseStarting
+		cr.SetConditions(v1alpha1.PostStartFailed(msg), xpv1.Unavailable().WithMessage(msg))
+		return nil
+	}
+
+	cr.SetConditions(v1alpha1.PostStartSucceeded())
+	return nil
+}
+
+// postStartSucceeded reports whether every post-start command of a container
+// has succeeded since it last started.
+func postStartSucceeded(cr *v1alpha1.Container) bool {
+	for _, cmd := range cr.Spec.ForProvider.PostStartCommands {
+		ok := false
+		for _, r := range cr.Status.AtProvider.PostStartCommands {
+			if r.Name == cmd.Name {
+				ok = r.Succeeded
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// commandResult returns the result of the named post-start command in the
+// status of a container, adding one if the command has not been run.
+func commandResult(obs *v1alpha1.ContainerObservation, name string) *v1alpha1.CommandResult {
+	for i := range obs.PostStartCommands {
+		if obs.PostStartCommands[i].Name == name {
+			return &obs.PostStartCommands[i]
+		}
+	}
+	obs.PostStartCommands = append(obs.PostStartCommands, v1alpha1.CommandResult{Name: name})
+	return &obs.PostStartCommands[len(obs.PostStartCommands)-1]
+}
+
+// runPostStartCommand runs a post-start command in a container, recording
+// the attempt in its result and when it is next retried, if it failed. A
+// command that does not finish within its timeout fails.
+func (c *external) runPostStartCommand(ctx context.Context, containerID string, cmd v1alpha1.PostStartCommand, result *v1alpha1.CommandResult) error {
+	timeout := defaultPostStartTimeout
+	if cmd.Timeout != nil {
+		timeout = cmd.Timeout.Duration
+	}
+	start := metav1.Now()
+	exitCode, output, err := c.execCommand(ctx, containerID, cmd.Command, timeout)
+	if err != nil {
+		return err
+	}
+
+	result.Attempts++
+	result.LastAttemptTime = start
+	result.ExitCode = exitCode
+	result.Output = output
+	result.Succeeded = exitCode != nil && *exitCode == 0
+	result.NextAttemptTime = nil
+	retries := int32(defaultPostStartRetries)
+	if cmd.Retries != nil {
+		retries = *cmd.Retries
+	}
+	if !result.Succeeded && result.Attempts <= retries {
+		next := metav1.NewTime(start.Add(postStartBackoff(cmd, result.Attempts)))
+		result.NextAttemptTime = &next
+	}
+	return nil
+}
+
+// postStartBackoff returns how long a post-start command that has failed a
+// number of times waits before it is retried: its backoff, doubled for each
+// attempt after the first, up to maxPostStartBackoff.
+func postStartBackoff(cmd v1alpha1.PostStartCommand, attempts int32) time.Duration {
+	backoff := defaultPostStartBackoff
+	if cmd.Backoff != nil {
+		backoff = cmd.Backoff.Duration
+	}
+	for i := int32(1); i < attempts && backoff < maxPostStartBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxPostStartBackoff)
+}
+
+// execCommand runs a command in a container and returns its exit code and
+// the tail of what it wrote to stdout and stderr. The exit code is nil if
+// the command did not finish within timeout.
+func (c *external) execCommand(ctx context.Context, containerID string, cmd []string, timeout time.Duration) (*int32, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	exec, err := c.client.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, "", errors.Wrap(err, errCreateExec)
+	}
+	resp, err := c.client.ContainerExecAttach(ctx, exec.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return nil, "", errors.Wrap(err, errAttachExec)
+	}
+	defer resp.Close()
+
+	// Reading the hijacked connection does not heed the context
+	deadline, _ := ctx.Deadline()
+	_ = resp.Conn.SetReadDeadline(deadline)
+
+	var out bytes.Buffer
+	_, err = stdcopy.StdCopy(&out, &out, resp.Reader)
+	output := tail(out.Bytes(), maxCommandOutput)
+	if errors.Is(err, os.ErrDeadlineExceeded) || ctx.Err() != nil {
+		return nil, output, nil
+	}
+	if err != nil {
+		return nil, "", errors.Wrap(err, errAttachExec)
+	}
+
+	// The output ends as the command exits, which Docker may not have
+	// recorded yet
+	for {
+		res, err := c.client.ContainerExecInspect(ctx, exec.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, output, nil
+			}
+			return nil, "", errors.Wrap(err, errInspectExec)
+		}
+		if !res.Running {
+			code := int32(res.ExitCode)
+			return &code, output, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, output, nil
+		case <-time.After(execPollInterval):
+		}
+	}
+}
+
+// tail returns the last limit bytes of b.
+func tail(b []byte, limit int) string {
+	if len(b) > limit {
+		b = b[len(b)-limit:]
+	}
+	return string(b)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"bufio"
+	"context"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestRunPostStartCommands(t *testing.T) {
+	zero := int32(0)
+	later := metav1.NewTime(time.Now().Add(time.Minute))
+	exitCode := int32(1)
+
+	tests := []struct {
+		name          string
+		retries       *int32
+		previous      []v1alpha1.CommandResult
+		exitCodes     map[string]int
+		hang          bool
+		expectRun     []string
+		expectFailed  corev1.ConditionStatus
+		expectReady   corev1.ConditionStatus
+		expectResults int
+		expectRetry   bool
+	}{
+		{
+			name:          "AllSucceed",
+			exitCodes:     map[string]int{},
+			expectRun:     []string{"migrate", "seed"},
+			expectFailed:  corev1.ConditionFalse,
+			expectReady:   corev1.ConditionTrue,
+			expectResults: 2,
+		},
+		{
+			name:          "FailsAndRetries",
+			exitCodes:     map[string]int{"migrate": 1},
+			expectRun:     []string{"migrate"},
+			expectFailed:  corev1.ConditionTrue,
+			expectReady:   corev1.ConditionFalse,
+			expectResults: 1,
+			expectRetry:   true,
+		},
+		{
+			name:          "NoRetriesLeft",
+			retries:       &zero,
+			exitCodes:     map[string]int{"migrate": 1},
+			expectRun:     []string{"migrate"},
+			expectFailed:  corev1.ConditionTrue,
+			expectReady:   corev1.ConditionFalse,
+			expectResults: 1,
+		},
+		{
+			name: "RetryNotDue",
+			previous: []v1alpha1.CommandResult{
+				{Name: "migrate", Attempts: 1, ExitCode: &exitCode, LastAttemptTime: metav1.Now(), NextAttemptTime: &later},
+			},
+			expectFailed:  corev1.ConditionTrue,
+			expectReady:   corev1.ConditionFalse,
+			expectResults: 1,
+			expectRetry:   true,
+		},
+		{
+			name: "AlreadySucceeded",
+			previous: []v1alpha1.CommandResult{
+				{Name: "migrate", Succeeded: true, Attempts: 2, LastAttemptTime: metav1.Now()},
+			},
+			exitCodes:     map[string]int{},
+			expectRun:     []string{"seed"},
+			expectFailed:  corev1.ConditionFalse,
+			expectReady:   corev1.ConditionTrue,
+			expectResults: 2,
+		},
+		{
+			name:          "TimesOut",
+			hang:          true,
+			expectRun:     []string{"migrate"},
+			expectFailed:  corev1.ConditionTrue,
+			expectReady:   corev1.ConditionFalse,
+			expectResults: 1,
+			expectRetry:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:    "abc123",
+					State: &container.State{Status: container.StateRunning, Running: true, StartedAt: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339Nano)},
+				},
+				Config: &container.Config{Image: "app:latest"},
+			}
+			timeout := &metav1.Duration{Duration: time.Second}
+			if tt.hang {
+				timeout = &metav1.Duration{Duration: 10 * time.Millisecond}
+			}
+			cr := &v1alpha1.Container{
+				Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+					Image: "app:latest",
+					PostStartCommands: []v1alpha1.PostStartCommand{
+						{Name: "migrate", Command: []string{"migrate", "up"}, Timeout: timeout, Retries: tt.retries},
+						{Name: "seed", Command: []string{"seed"}, Timeout: timeout},
+					},
+				}},
+			}
+			cr.Status.AtProvider.PostStartCommands = tt.previous
+
+			var run []string
+			e := &external{client: &mockDockerClient{
+				execCreateFunc: func(_ context.Context, _ string, opts container.ExecOptions) (container.ExecCreateResponse, error) {
+					run = append(run, opts.Cmd[0])
+					return container.ExecCreateResponse{ID: opts.Cmd[0]}, nil
+				},
+				execAttachFunc: func(ctx context.Context, id string, opts container.ExecAttachOptions) (types.HijackedResponse, error) {
+					if tt.hang {
+						conn, _ := net.Pipe()
+						return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(conn)}, nil
+					}
+					return execOutput("ran "+id+"\n")(ctx, id, opts)
+				},
+				execInspectFunc: func(_ context.Context, id string) (container.ExecInspect, error) {
+					return container.ExecInspect{ExecID: id, ExitCode: tt.exitCodes[id]}, nil
+				},
+			}}
+			e.updateStatus(cr, info)
+			if err := e.runPostStartCommands(context.Background(), cr, info); err != nil {
+				t.Fatalf("runPostStartCommands() error = %v", err)
+			}
+
+			if len(run) != len(tt.expectRun) || (len(run) > 0 && run[0] != tt.expectRun[0]) {
+				t.Errorf("runPostStartCommands() ran %q, want %q", run, tt.expectRun)
+			}
+			results := cr.Status.AtProvider.PostStartCommands
+			if len(results) != tt.expectResults {
+				t.Fatalf("runPostStartCommands() recorded %d results, want %d", len(results), tt.expectResults)
+			}
+			if got := results[0].NextAttemptTime != nil; got != tt.expectRetry {
+				t.Errorf("runPostStartCommands() scheduled a retry = %v, want %v", got, tt.expectRetry)
+			}
+			if len(tt.previous) == 0 && !tt.hang && results[0].Output != "ran migrate\n" {
+				t.Errorf("runPostStartCommands() output = %q, want the command's", results[0].Output)
+			}
+			if tt.hang && results[0].ExitCode != nil {
+				t.Errorf("runPostStartCommands() exit code of a command that timed out = %d, want none", *results[0].ExitCode)
+			}
+			if got := cr.GetCondition(v1alpha1.TypePostStartFailed).Status; got != tt.expectFailed {
+				t.Errorf("runPostStartCommands() PostStartFailed = %q, want %q", got, tt.expectFailed)
+			}
+			if got := cr.GetCondition(xpv1.TypeReady).Status; got != tt.expectReady {
+				t.Errorf("runPostStartCommands() Ready = %q, want %q", got, tt.expectReady)
+			}
+		})
+	}
+}
+
+func TestPostStartCommandsNotPermitted(t *testing.T) {
+	tests := map[string]struct {
+		policies xpv1.ManagementPolicies
+		readOnly bool
+	}{
+		"ObserveOnly": {policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve}},
+		"DryRun":      {readOnly: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Container{}
+			cr.SetManagementPolicies(tt.policies)
+			cr.Spec.ForProvider.PostStartCommands = []v1alpha1.PostStartCommand{{Name: "migrate", Command: []string{"migrate"}}}
+			info := &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				ID:    "abc123",
+				State: &container.State{Status: container.StateRunning, Running: true},
+			}}
+
+			var run []string
+			e := &external{readOnly: tt.readOnly, client: &mockDockerClient{
+				execCreateFunc: func(_ context.Context, _ string, opts container.ExecOptions) (container.ExecCreateResponse, error) {
+					run = append(run, opts.Cmd[0])
+					return container.ExecCreateResponse{ID: opts.Cmd[0]}, nil
+				},
+			}}
+			if err := e.runPostStartCommands(context.Background(), cr, info); err != nil {
+				t.Fatalf("runPostStartCommands() error = %v", err)
+			}
+			if len(run) != 0 || len(cr.Status.AtProvider.PostStartCommands) != 0 {
+				t.Errorf("runPostStartCommands() ran %q, want nothing run", run)
+			}
+		})
+	}
+}
+
+func TestPostStartCommandsOncePerStart(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+	cr := &v1alpha1.Container{}
+	cr.Spec.ForProvider.PostStartCommands = []v1alpha1.PostStartCommand{{Name: "migrate", Command: []string{"migrate"}}}
+	cr.Status.AtProvider.PostStartCommands = []v1alpha1.CommandResult{
+		{Name: "migrate", Succeeded: true, Attempts: 1, LastAttemptTime: metav1.NewTime(started.Add(time.Second))},
+	}
+
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			State: &container.State{Status: container.StateRunning, Running: true, StartedAt: started.UTC().Format(time.RFC3339Nano)},
+		},
+		Config: &container.Config{},
+	}
+	e := &external{client: &mockDockerClient{}}
+	e.updateStatus(cr, info)
+	if !postStartSucceeded(cr) {
+		t.Error("updateStatus() dropped post-start commands run since the container started")
+	}
+
+	// A restart runs the commands again
+	info.State.StartedAt = time.Now().Add(time.Second).UTC().Format(time.RFC3339Nano)
+	e.updateStatus(cr, info)
+	if postStartSucceeded(cr) {
+		t.Error("updateStatus() kept post-start commands run before the container restarted")
+	}
+}
+
+func TestPostStartBackoff(t *testing.T) {
+	cmd := v1alpha1.PostStartCommand{}
+	for attempts, want := range map[int32]time.Duration{1: 10 * time.Second, 2: 20 * time.Second, 3: 40 * time.Second, 10: 5 * time.Minute} {
+		if got := postStartBackoff(cmd, attempts); got != want {
+			t.Errorf("postStartBackoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
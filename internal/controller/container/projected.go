@@ -0,0 +1,464 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"path"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
+	"strings"
+)
+
+const (
+	errGetProjected    = "cannot get %s %s"
+	errProjectedKey    = "key %s is not in %s %s"
+	errProjectedPath   = "path %s of key %s must be relative, and must not start with .."
+	errProjectVolume   = "cannot write the files of volume %s"
+	errProjectHolder   = "cannot start the container that keeps volume %s mounted"
+	errRemoveProjected = "cannot remove volume %s"
+
+	// projectedMountPath is where the holder of a projected volume mounts it.
+	projectedMountPath = "/projected"
+
+	// projectedHolderImage is the image of the holders of projected volumes,
+	// which do nothing but run until they are stopped.
+	projectedHolderImage = "registry.k8s.io/pause:3.10"
+
+	// projectedDataLink links to the directory of a projected volume that
+	// holds the files it was last written with. The files at the top of the
+	// volume link through it.
+	projectedDataLink = "..data"
+
+	// defaultProjectedMode is the mode of the files of a projected volume
+	// that does not say.
+	defaultProjectedMode = 0o644
+)
+
+// projectedVolumeOptions are the options of the local driver that keep the
+// files of a projected volume in memory, so that the Secrets written into
+// it are never written to the disk of the Docker host.
+var projectedVolumeOptions = map[string]string{"type": "tmpfs", "device": "tmpfs"}
+
+// A projectedFile is a file a Secret or ConfigMap volume is written with.
+type projectedFile struct {
+	path string
+	mode int64
+	data []byte
+}
+
+// isProjected reports whether a volume projects a Secret or ConfigMap.
+func isProjected(src v1alpha1.VolumeSource) bool {
+	return src.Secret != nil || src.ConfigMap != nil
+}
+
+// projectedVolumeName returns the name of the Docker volume a Secret or
+// ConfigMap volume of a container is projected into. Containers of the same
+// name in different namespaces have volumes of their own.
+func projectedVolumeName(owner client.ObjectKey, volume string) string {
+	h := sha256.Sum256([]byte(owner.String()))
+	return fmt.Sprintf("%s-%s-%s", owner.Name, volume, hex.EncodeToString(h[:4]))
+}
+
+// projectedFiles returns the files a Secret or ConfigMap volume is written
+// with, sorted by path. An optional Secret or ConfigMap that does not exist
+// has none.
+func (c *external) projectedFiles(ctx context.Context, namespace string, src v1alpha1.VolumeSource) ([]projectedFile, error) {
+	var (
+		kind, name string
+		optional   *bool
+		mode       *int32
+		items      []v1alpha1.KeyToPath
+		data       map[string][]byte
+		err        error
+	)
+	switch {
+	case src.Secret != nil:
+		kind, name = "Secret", src.Secret.SecretName
+		optional, mode, items = src.Secret.Optional, src.Secret.DefaultMode, src.Secret.Items
+		s := &corev1.Secret{}
+		if err = c.kube.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, s); err == nil {
+			data = s.Data
+		}
+	case src.ConfigMap != nil:
+		kind, name = "ConfigMap", src.ConfigMap.Name
+		optional, mode, items = src.ConfigMap.Optional, src.ConfigMap.DefaultMode, src.ConfigMap.Items
+		cm := &corev1.ConfigMap{}
+		if err = c.kube.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err == nil {
+			data = make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+			for k, v := range cm.Data {
+				data[k] = []byte(v)
+			}
+			for k, v := range cm.BinaryData {
+				data[k] = v
+			}
+		}
+	default:
+		return nil, nil
+	}
+	isOptional := optional != nil && *optional
+	if kerrors.IsNotFound(err) && isOptional {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, errGetProjected, kind, name)
+	}
+
+	defaultMode := int64(defaultProjectedMode)
+	if mode != nil {
+		defaultMode = int64(*mode)
+	}
+
+	// Without items, each key is written to a file of its own name
+	if len(items) == 0 {
+		for k := range data {
+			items = append(items, v1alpha1.KeyToPath{Key: k, Path: k})
+		}
+	}
+
+	files := make([]projectedFile, 0, len(items))
+	for _, item := range items {
+		v, ok := data[item.Key]
+		if !ok {
+			if isOptional {
+				continue
+			}
+			return nil, errors.Errorf(errProjectedKey, item.Key, kind, name)
+		}
+		p := path.Clean(item.Path)
+		if path.IsAbs(p) || p == "." || strings.HasPrefix(p, "..") {
+			return nil, errors.Errorf(errProjectedPath, item.Path, item.Key)
+		}
+		f := projectedFile{path: p, mode: defaultMode, data: v}
+		if item.Mode != nil {
+			f.mode = int64(*item.Mode)
+		}
+		files = append(files, f)
+	}
+	slices.SortFunc(files, func(a, b projectedFile) int { return strings.Compare(a.path, b.path) })
+	return files, nil
+}
+
+// projectedHash returns a hash of the files of a projected volume, which
+// changes when any of them does.
+func projectedHash(files []projectedFile) string {
+	h := sha256.New()
+	for _, f := range files {
+		_, _ = fmt.Fprintf(h, "%s\x00%o\x00%d\x00", f.path, f.mode, len(f.data))
+		_, _ = h.Write(f.data)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// projectedArchive archives the files of a projected volume for copying into
+// it. Revisions alternate between two directories, so that the files are
+// written into the one not in use, and the volume switches to them at once
+// when its ..data link is replaced. The directory is first replaced with a
+// file, so that none of the files of the revision before last are left in
+// it. A file that is no longer projected is left as a dangling link, which
+// reads as missing.
+func projectedArchive(files []projectedFile, revision int64) (*bytes.Buffer, error) {
+	dir := fmt.Sprintf("..%d", revision%2)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	write := func(h *tar.Header, data []byte) error {
+		if err := tw.WriteHeader(h); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err := write(&tar.Header{Name: dir, Typeflag: tar.TypeReg, Mode: 0o600}, nil); err != nil {
+		return nil, err
+	}
+	if err := write(&tar.Header{Name: dir + "/", Typeflag: tar.TypeDir, Mode: 0o755}, nil); err != nil {
+		return nil, err
+	}
+
+	dirs := map[string]bool{}
+	var top []string
+	for _, f := range files {
+		parts := strings.Split(f.path, "/")
+		for i := 1; i < len(parts); i++ {
+			d := strings.Join(parts[:i], "/")
+			if dirs[d] {
+				continue
+			}
+			dirs[d] = true
+			if err := write(&tar.Header{Name: dir + "/" + d + "/", Typeflag: tar.TypeDir, Mode: 0o755}, nil); err != nil {
+				return nil, err
+			}
+		}
+		h := &tar.Header{Name: dir + "/" + f.path, Typeflag: tar.TypeReg, Mode: f.mode, Size: int64(len(f.data))}
+		if err := write(h, f.data); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(top, parts[0]) {
+			top = append(top, parts[0])
+		}
+	}
+
+	if err := write(&tar.Header{Name: projectedDataLink, Typeflag: tar.TypeSymlink, Linkname: dir}, nil); err != nil {
+		return nil, err
+	}
+	for _, name := range top {
+		if err := write(&tar.Header{Name: name, Typeflag: tar.TypeSymlink, Linkname: projectedDataLink + "/" + name}, nil); err != nil {
+			return nil, err
+		}
+	}
+	return &buf, tw.Close()
+}
+
+// createProjectedVolumes creates the Docker volumes the Secrets and
+// ConfigMaps a container mounts are projected into, and starts the holders
+// that keep them mounted.
+func (c *external) createProjectedVolumes(ctx context.Context, cr *v1alpha1.Container) error {
+	owner := client.ObjectKeyFromObject(cr)
+	for _, v := range cr.Spec.ForProvider.Volumes {
+		if !isProjected(v.VolumeSource) {
+			continue
+		}
+		if _, err := c.startProjectedHolder(ctx, projectedVolumeName(owner, v.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// projectedHolderName returns the name of the holder of a projected volume.
+func projectedHolderName(volumeName string) string {
+	return volumeName + "-holder"
+}
+
+// startProjectedHolder starts the holder of a projected volume unless it is
+// running, creating the volume and the holder if need be, and returns when
+// the holder started, as its Docker host reports it. The files of a
+// projected volume are kept in memory, on a tmpfs that is only mounted while
+// a running container mounts the volume, so the holder mounts it for as long
+// as it runs. A holder that starts again mounts an empty tmpfs.
+func (c *external) startProjectedHolder(ctx context.Context, volumeName string) (string, error) {
+	name := projectedHolderName(volumeName)
+	info, err := c.client.ContainerInspect(ctx, name)
+	if err != nil && !isNotFound(err) {
+		return "", errors.Wrapf(err, errProjectHolder, volumeName)
+	}
+	if err != nil {
+		if _, err := c.client.VolumeCreate(ctx, volume.CreateOptions{
+			Name:       volumeName,
+			Driver:     "local",
+			DriverOpts: projectedVolumeOptions,
+			Labels:     map[string]string{labels.ManagedBy: labels.ManagedByProvider},
+		}); err != nil {
+			return "", errors.Wrapf(err, errCreateVolume, volumeName)
+		}
+		if err := c.createProjectedHolder(ctx, name, volumeName); err != nil {
+			return "", errors.Wrapf(err, errProjectHolder, volumeName)
+		}
+	}
+	if err != nil || info.State == nil || !info.State.Running {
+		if err := c.client.ContainerStart(ctx, name, container.StartOptions{}); err != nil {
+			return "", errors.Wrapf(err, errProjectHolder, volumeName)
+		}
+		if info, err = c.client.ContainerInspect(ctx, name); err != nil {
+			return "", errors.Wrapf(err, errProjectHolder, volumeName)
+		}
+	}
+	if info.State == nil {
+		return "", nil
+	}
+	return info.State.StartedAt, nil
+}
+
+// createProjectedHolder creates the holder of a projected volume, pulling
+// its image if it is not on the Docker host.
+func (c *external) createProjectedHolder(ctx context.Context, name, volumeName string) error {
+	config := &container.Config{
+		Image:  projectedHolderImage,
+		Labels: map[string]string{labels.ManagedBy: labels.ManagedByProvider},
+	}
+	hostConfig := &container.HostConfig{
+		Mounts:        []mount.Mount{{Type: mount.TypeVolume, Source: volumeName, Target: projectedMountPath}},
+		RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+	}
+	_, err := c.client.ContainerCreate(ctx, config, hostConfig, nil, nil, name)
+	if clients.IsNoSuchImage(err) {
+		if err := clients.PullImage(ctx, c.client, projectedHolderImage, "", nil, nil); err != nil {
+			return err
+		}
+		_, err = c.client.ContainerCreate(ctx, config, hostConfig, nil, nil, name)
+	}
+	return err
+}
+
+// projectVolumes writes the Secrets and ConfigMaps a container mounts into
+// the Docker volumes they are projected into, through the holders that keep
+// them mounted. Unless all are written, those that have not changed since
+// they were last written, and whose holders have not started again since,
+// are left as they are.
+func (c *external) projectVolumes(ctx context.Context, cr *v1alpha1.Container, all bool) error {
+	owner := client.ObjectKeyFromObject(cr)
+	var projected []v1alpha1.ProjectedVolume
+	for _, v := range cr.Spec.ForProvider.Volumes {
+		if !isProjected(v.VolumeSource) {
+			continue
+		}
+		files, err := c.projectedFiles(ctx, cr.GetNamespace(), v.VolumeSource)
+		if err != nil {
+			return errors.Wrapf(err, errProjectVolume, v.Name)
+		}
+		pv := v1alpha1.ProjectedVolume{Name: v.Name, VolumeName: projectedVolumeName(owner, v.Name), Hash: projectedHash(files)}
+		if pv.MountedAt, err = c.startProjectedHolder(ctx, pv.VolumeName); err != nil {
+			return errors.Wrapf(err, errProjectVolume, v.Name)
+		}
+		if prev := projectedStatus(cr, v.Name); prev != nil {
+			if !all && prev.Hash == pv.Hash && prev.VolumeName == pv.VolumeName && prev.MountedAt == pv.MountedAt {
+				projected = append(projected, *prev)
+				continue
+			}
+			pv.Revision = prev.Revision
+		}
+		pv.Revision++
+
+		if err := c.writeProjectedVolume(ctx, pv.VolumeName, files, pv.Revision); err != nil {
+			return errors.Wrapf(err, errProjectVolume, v.Name)
+		}
+		setProjectedStatus(cr, pv)
+		projected = append(projected, pv)
+	}
+	cr.Status.AtProvider.ProjectedVolumes = projected
+	return nil
+}
+
+// writeProjectedVolume writes the files of a projected volume into its Docker
+// volume, by copying them into the holder that keeps it mounted.
+func (c *external) writeProjectedVolume(ctx context.Context, volumeName string, files []projectedFile, revision int64) error {
+	archive, err := projectedArchive(files, revision)
+	if err != nil {
+		return err
+	}
+	return c.client.CopyToContainer(ctx, projectedHolderName(volumeName), projectedMountPath, archive, container.CopyToContainerOptions{AllowOverwriteDirWithFile: true})
+}
+
+// areProjectedVolumesUpToDate reports whether the Secrets and ConfigMaps a
+// container mounts are as they were when last written into its volumes, and
+// whether the holders of the volumes have kept them mounted since.
+func (c *external) areProjectedVolumesUpToDate(ctx context.Context, cr *v1alpha1.Container) (bool, error) {
+	owner := client.ObjectKeyFromObject(cr)
+	for _, v := range cr.Spec.ForProvider.Volumes {
+		if !isProjected(v.VolumeSource) {
+			continue
+		}
+		files, err := c.projectedFiles(ctx, cr.GetNamespace(), v.VolumeSource)
+		if err != nil {
+			return false, errors.Wrapf(err, errProjectVolume, v.Name)
+		}
+		prev := projectedStatus(cr, v.Name)
+		if prev == nil || prev.Hash != projectedHash(files) || prev.VolumeName != projectedVolumeName(owner, v.Name) {
+			return false, nil
+		}
+		holder, err := c.client.ContainerInspect(ctx, projectedHolderName(prev.VolumeName))
+		if isNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, errProjectHolder, prev.VolumeName)
+		}
+		if holder.State == nil || !holder.State.Running || holder.State.StartedAt != prev.MountedAt {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// refreshProjectedVolumes writes the Secrets and ConfigMaps a container
+// mounts into its volumes again where they have changed, or where their
+// holders have started again.
+func (c *external) refreshProjectedVolumes(ctx context.Context, cr *v1alpha1.Container) error {
+	restore, err := c.withTemplate(cr)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	return c.projectVolumes(ctx, cr, false)
+}
+
+// removeProjectedVolumes removes the Docker volumes the Secrets and
+// ConfigMaps of a removed container were projected into, and their holders.
+func (c *external) removeProjectedVolumes(ctx context.Context, cr *v1alpha1.Container) error {
+	owner := client.ObjectKeyFromObject(cr)
+	var names []string
+	for _, v := range cr.Spec.ForProvider.Volumes {
+		if isProjected(v.VolumeSource) {
+			names = append(names, projectedVolumeName(owner, v.Name))
+		}
+	}
+	for _, pv := range cr.Status.AtProvider.ProjectedVolumes {
+		if !slices.Contains(names, pv.VolumeName) {
+			names = append(names, pv.VolumeName)
+		}
+	}
+
+	for _, name := range names {
+		if err := c.client.ContainerRemove(ctx, projectedHolderName(name), container.RemoveOptions{Force: true}); err != nil && !isNotFound(err) {
+			return errors.Wrapf(err, errRemoveProjected, name)
+		}
+		if err := c.client.VolumeRemove(ctx, name, false); err != nil && !isNotFound(err) {
+			return errors.Wrapf(err, errRemoveProjected, name)
+		}
+	}
+	cr.Status.AtProvider.ProjectedVolumes = nil
+	return nil
+}
+
+// projectedStatus returns the status of a projected volume of a container,
+// if it has been written.
+func projectedStatus(cr *v1alpha1.Container, name string) *v1alpha1.ProjectedVolume {
+	for i := range cr.Status.AtProvider.ProjectedVolumes {
+		if pv := &cr.Status.AtProvider.ProjectedVolumes[i]; pv.Name == name {
+			return pv
+		}
+	}
+	return nil
+}
+
+// setProjectedStatus records that a projected volume of a container has been
+// written, so that it is not written into the same directory again should
+// writing a later volume fail.
+func setProjectedStatus(cr *v1alpha1.Container, pv v1alpha1.ProjectedVolume) {
+	if prev := projectedStatus(cr, pv.Name); prev != nil {
+		*prev = pv
+		return
+	}
+	cr.Status.AtProvider.ProjectedVolumes = append(cr.Status.AtProvider.ProjectedVolumes, pv)
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"maps"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestProjectedFiles(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tls"},
+		Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"},
+		Data:       map[string]string{"app.yaml": "debug: true"},
+		BinaryData: map[string][]byte{"logo.png": {0x89, 0x50}},
+	}
+	c := &external{kube: fake.NewClientBuilder().WithObjects(secret, cm).Build()}
+
+	mode := int32(0o400)
+	optional := true
+	tests := map[string]struct {
+		src     v1alpha1.VolumeSource
+		want    []string
+		wantErr bool
+	}{
+		"AllSecretKeys": {
+			src:  v1alpha1.VolumeSource{Secret: &v1alpha1.SecretVolumeSource{SecretName: "tls"}},
+			want: []string{"tls.crt 644 cert", "tls.key 644 key"},
+		},
+		"SecretItems": {
+			src: v1alpha1.VolumeSource{Secret: &v1alpha1.SecretVolumeSource{
+				SecretName:  "tls",
+				DefaultMode: &mode,
+				Items:       []v1alpha1.KeyToPath{{Key: "tls.key", Path: "private/key.pem"}},
+			}},
+			want: []string{"private/key.pem 400 key"},
+		},
+		"ConfigMapAndBinaryData": {
+			src:  v1alpha1.VolumeSource{ConfigMap: &v1alpha1.ConfigMapVolumeSource{Name: "app"}},
+			want: []string{"app.yaml 644 debug: true", "logo.png 644 \x89P"},
+		},
+		"MissingKey": {
+			src:     v1alpha1.VolumeSource{ConfigMap: &v1alpha1.ConfigMapVolumeSource{Name: "app", Items: []v1alpha1.KeyToPath{{Key: "other", Path: "other"}}}},
+			wantErr: true,
+		},
+		"MissingOptionalKey": {
+			src:  v1alpha1.VolumeSource{ConfigMap: &v1alpha1.ConfigMapVolumeSource{Name: "app", Optional: &optional, Items: []v1alpha1.KeyToPath{{Key: "other", Path: "other"}}}},
+			want: []string{},
+		},
+		"MissingSecret": {
+			src:     v1alpha1.VolumeSource{Secret: &v1alpha1.SecretVolumeSource{SecretName: "missing"}},
+			wantErr: true,
+		},
+		"MissingOptionalSecret": {
+			src:  v1alpha1.VolumeSource{Secret: &v1alpha1.SecretVolumeSource{SecretName: "missing", Optional: &optional}},
+			want: []string{},
+		},
+		"PathOutsideVolume": {
+			src:     v1alpha1.VolumeSource{Secret: &v1alpha1.SecretVolumeSource{SecretName: "tls", Items: []v1alpha1.KeyToPath{{Key: "tls.key", Path: "../key.pem"}}}},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			files, err := c.projectedFiles(context.Background(), "default", tc.src)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("projectedFiles(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			got := []string{}
+			for _, f := range files {
+				got = append(got, fmt.Sprintf("%s %o %s", f.path, f.mode, f.data))
+			}
+			if !tc.wantErr && strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+				t.Errorf("projectedFiles(...) = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// archiveEntries returns the entries of a tar archive, as name, type and
+// link or content.
+func archiveEntries(t *testing.T, r io.Reader) []string {
+	t.Helper()
+	var entries []string
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatalf("cannot read archive: %v", err)
+		}
+		switch h.Typeflag {
+		case tar.TypeSymlink:
+			entries = append(entries, h.Name+" -> "+h.Linkname)
+		case tar.TypeDir:
+			entries = append(entries, h.Name)
+		default:
+			data, _ := io.ReadAll(tr)
+			entries = append(entries, fmt.Sprintf("%s %o %q", h.Name, h.Mode, data))
+		}
+	}
+}
+
+func TestProjectVolumes(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "creds"},
+		Data:       map[string][]byte{"password": []byte("s3cret")},
+	}
+	kube := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	// The holder of the volume runs once it is created, and started at
+	// startedAt
+	var (
+		copied    [][]string
+		created   []string
+		volumes   []volume.CreateOptions
+		startedAt string
+	)
+	client := &mockDockerClient{
+		containerInspectFunc: func(_ context.Context, name string) (container.InspectResponse, error) {
+			if !slices.Contains(created, name) {
+				return container.InspectResponse{}, errdefs.NotFound(errors.New("no such container"))
+			}
+			return container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				State: &container.State{Running: startedAt != "", StartedAt: startedAt},
+			}}, nil
+		},
+		containerCreateFunc: func(_ context.Context, config *container.Config, hostConfig *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, name string) (container.CreateResponse, error) {
+			if config.Image != projectedHolderImage || len(hostConfig.Mounts) != 1 || hostConfig.Mounts[0].Target != projectedMountPath {
+				t.Errorf("ContainerCreate(...) %s from %s mounting %+v, want a holder mounting its volume", name, config.Image, hostConfig.Mounts)
+			}
+			created = append(created, name)
+			return container.CreateResponse{ID: name}, nil
+		},
+		containerStartFunc: func(context.Context, string, container.StartOptions) error {
+			startedAt = "2025-01-01T00:00:00Z"
+			return nil
+		},
+		volumeCreateFunc: func(_ context.Context, options volume.CreateOptions) (volume.Volume, error) {
+			volumes = append(volumes, options)
+			return volume.Volume{Name: options.Name}, nil
+		},
+		copyToContainerFunc: func(_ context.Context, id, dstPath string, content io.Reader, options container.CopyToContainerOptions) error {
+			if !slices.Contains(created, id) || dstPath != projectedMountPath || !options.AllowOverwriteDirWithFile {
+				t.Errorf("CopyToContainer(...) to %s:%s with %+v, want the holder's %s replacing directories", id, dstPath, options, projectedMountPath)
+			}
+			copied = append(copied, archiveEntries(t, content))
+			return nil
+		},
+	}
+	c := &external{client: client, kube: kube}
+
+	cr := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db"}}
+	cr.Spec.ForProvider.Volumes = []v1alpha1.VolumeMount{{
+		Name:         "creds",
+		MountPath:    "/run/secrets",
+		VolumeSource: v1alpha1.VolumeSource{Secret: &v1alpha1.SecretVolumeSource{SecretName: "creds"}},
+	}}
+	ctx := context.Background()
+
+	if err := c.projectVolumes(ctx, cr, true); err != nil {
+		t.Fatalf("projectVolumes(...): %v", err)
+	}
+	want := []string{
+		`..1 600 ""`,
+		"..1/",
+		`..1/password 644 "s3cret"`,
+		"..data -> ..1",
+		"password -> ..data/password",
+	}
+	if len(copied) != 1 || strings.Join(copied[0], "\n") != strings.Join(want, "\n") {
+		t.Fatalf("projectVolumes(...) copied %q, want %q", copied, want)
+	}
+	if pv := projectedStatus(cr, "creds"); pv == nil || pv.Revision != 1 || !strings.HasPrefix(pv.VolumeName, "db-creds-") || pv.MountedAt != startedAt {
+		t.Errorf("projectVolumes(...) status = %+v, want revision 1 mounted at %s", pv, startedAt)
+	}
+	// Secrets are kept in memory, rather than on the disk of the host
+	if len(volumes) != 1 || !maps.Equal(volumes[0].DriverOpts, map[string]string{"type": "tmpfs", "device": "tmpfs"}) {
+		t.Errorf("VolumeCreate(...) with %+v, want a tmpfs volume", volumes)
+	}
+
+	// The status survives the container being observed
+	c.updateStatus(cr, &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{State: &container.State{Status: "running", Running: true}},
+		Config:            &container.Config{Image: "postgres:16"},
+	})
+
+	// Unchanged volumes are not written again
+	if upToDate, err := c.areProjectedVolumesUpToDate(ctx, cr); err != nil || !upToDate {
+		t.Errorf("areProjectedVolumesUpToDate(...) = %v, %v, want true", upToDate, err)
+	}
+	if err := c.projectVolumes(ctx, cr, false); err != nil {
+		t.Fatalf("projectVolumes(...): %v", err)
+	}
+	if len(copied) != 1 {
+		t.Errorf("projectVolumes(...) wrote an unchanged volume")
+	}
+
+	// A changed Secret is written into the other directory
+	secret.Data["password"] = []byte("rotated")
+	if err := kube.Update(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	if upToDate, err := c.areProjectedVolumesUpToDate(ctx, cr); err != nil || upToDate {
+		t.Errorf("areProjectedVolumesUpToDate(...) = %v, %v, want false", upToDate, err)
+	}
+	if err := c.projectVolumes(ctx, cr, false); err != nil {
+		t.Fatalf("projectVolumes(...): %v", err)
+	}
+	if len(copied) != 2 || copied[1][3] != "..data -> ..0" {
+		t.Errorf("projectVolumes(...) copied %q, want the files written into ..0", copied)
+	}
+	if pv := projectedStatus(cr, "creds"); pv == nil || pv.Revision != 2 {
+		t.Errorf("projectVolumes(...) status = %+v, want revision 2", pv)
+	}
+
+	// A holder that started again mounted an empty tmpfs, which is written
+	// again
+	startedAt = "2025-01-02T00:00:00Z"
+	if upToDate, err := c.areProjectedVolumesUpToDate(ctx, cr); err != nil || upToDate {
+		t.Errorf("areProjectedVolumesUpToDate(...) = %v, %v, want false once the holder started again", upToDate, err)
+	}
+	if err := c.projectVolumes(ctx, cr, false); err != nil {
+		t.Fatalf("projectVolumes(...): %v", err)
+	}
+	if len(copied) != 3 {
+		t.Errorf("projectVolumes(...) did not write the volume again once its holder started again")
+	}
+	if pv := projectedStatus(cr, "creds"); pv == nil || pv.MountedAt != startedAt {
+		t.Errorf("projectVolumes(...) status = %+v, want it mounted at %s", pv, startedAt)
+	}
+	if len(created) != 1 {
+		t.Errorf("ContainerCreate(...) created holders %q, want one", created)
+	}
+}
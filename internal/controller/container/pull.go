@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"k8s.io/apimachinery/pkg/runtime"
+	"strings"
+)
+
+const (
+	reasonPullingImage    event.Reason = "PullingImage"
+	reasonPulledImage     event.Reason = "PulledImage"
+	reasonImagePullFailed event.Reason = "ImagePullFailed"
+)
+
+// pullPolicy returns when the image of a container is pulled.
+func pullPolicy(params *v1alpha1.ContainerParameters) v1alpha1.ImagePullPolicy {
+	if params.ImagePullPolicy == nil {
+		return v1alpha1.PullIfNotPresent
+	}
+	return *params.ImagePullPolicy
+}
+
+// pullContainerImage pulls the image of a container, recording the progress
+// of the pull as events on the container, and its outcome as the container's
+// ImagePulled condition.
+func (c *external) pullContainerImage(ctx context.Context, cr *v1alpha1.Container, ref string) error {
+	c.record(cr, event.Normal(reasonPullingImage, "Pulling image "+ref))
+	var platform string
+	if cr.Spec.ForProvider.Platform != nil {
+		platform = *cr.Spec.ForProvider.Platform
+	}
+	err := c.pull(ctx, ref, platform, func(m jsonmessage.JSONMessage) {
+		// The progress of each layer is left out
+		if m.Status != "" && (m.ID == "" || strings.HasPrefix(m.Status, "Pulling from")) {
+			c.record(cr, event.Normal(reasonPullingImage, m.Status))
+		}
+	})
+	if err != nil {
+		cr.SetConditions(v1alpha1.ImagePullFailed(err.Error()))
+		c.record(cr, event.Warning(reasonImagePullFailed, err))
+		return err
+	}
+	cr.SetConditions(v1alpha1.ImagePulled(ref))
+	c.record(cr, event.Normal(reasonPulledImage, "Pulled image "+ref))
+	return nil
+}
+
+// pull pulls an image for a platform, or that of the Docker host if it is
+// empty, with the registry credentials of the ProviderConfig, passing each
+// message of its progress to progress, if set. The pull only completes once
+// its progress has been read to the end, and fails with an error in its
+// progress.
+func (c *external) pull(ctx context.Context, ref, platform string, progress func(jsonmessage.JSONMessage)) error {
+	return clients.PullImage(ctx, c.client, ref, platform, c.registryAuths, progress)
+}
+
+// record records an event on a container, if events are recorded.
+func (c *external) record(cr *v1alpha1.Container, e event.Event) {
+	if c.recorder != nil {
+		c.recorder.Event(cr, e)
+	}
+}
+
+// recordOn returns a recorder that records the events of the v1alpha1 form
+// of a v1beta1 container on the v1beta1 container itself.
+func recordOn(r event.Recorder, mg resource.Managed) event.Recorder {
+	if r == nil {
+		return nil
+	}
+	return &redirectRecorder{Recorder: r, mg: mg}
+}
+
+type redirectRecorder struct {
+	event.Recorder
+	mg resource.Managed
+}
+
+func (r *redirectRecorder) Event(_ runtime.Object, e event.Event) {
+	r.Recorder.Event(r.mg, e)
+}
+
+func (r *redirectRecorder) WithAnnotations(keysAndValues ...string) event.Recorder {
+	return recordOn(r.Recorder.WithAnnotations(keysAndValues...), r.mg)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/docker/docker/api/types/image"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"strings"
+	"testing"
+)
+
+// pullingClient serves an image pull with the given progress.
+type pullingClient struct {
+	mockDockerClient
+	progress string
+	auth     string
+}
+
+func (c *pullingClient) ImagePull(_ context.Context, _ string, options image.PullOptions) (io.ReadCloser, error) {
+	c.auth = options.RegistryAuth
+	return io.NopCloser(strings.NewReader(c.progress)), nil
+}
+
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func TestPullContainerImage(t *testing.T) {
+	tests := map[string]struct {
+		progress   string
+		wantErr    bool
+		wantStatus corev1.ConditionStatus
+		wantEvents []string
+	}{
+		"Pulled": {
+			progress: `{"status":"Pulling from library/nginx","id":"1.27"}
+{"status":"Downloading","id":"a1b2c3","progressDetail":{"current":1,"total":2}}
+{"status":"Pull complete","id":"a1b2c3"}
+{"status":"Digest: sha256:0123"}
+{"status":"Status: Downloaded newer image for nginx:1.27"}
+`,
+			wantStatus: corev1.ConditionTrue,
+			wantEvents: []string{
+				"Pulling image nginx:1.27",
+				"Pulling from library/nginx",
+				"Digest: sha256:0123",
+				"Status: Downloaded newer image for nginx:1.27",
+				"Pulled image nginx:1.27",
+			},
+		},
+		"Failed": {
+			progress: `{"status":"Pulling from library/nginx","id":"1.27"}
+{"errorDetail":{"message":"unauthorized: authentication required"},"error":"unauthorized: authentication required"}
+`,
+			wantErr:    true,
+			wantStatus: corev1.ConditionFalse,
+			wantEvents: []string{
+				"Pulling image nginx:1.27",
+				"Pulling from library/nginx",
+				"cannot pull image nginx:1.27: unauthorized: authentication required",
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventRecorder{}
+			c := &external{client: &pullingClient{progress: tc.progress}, recorder: rec}
+			cr := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "web"}}
+
+			err := c.pullContainerImage(context.Background(), cr, "nginx:1.27")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("pullContainerImage(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got := cr.GetCondition(v1alpha1.TypeImagePulled).Status; got != tc.wantStatus {
+				t.Errorf("ImagePulled condition status = %s, want %s", got, tc.wantStatus)
+			}
+			var got []string
+			for _, e := range rec.events {
+				got = append(got, e.Message)
+			}
+			if strings.Join(got, "\n") != strings.Join(tc.wantEvents, "\n") {
+				t.Errorf("events:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tc.wantEvents, "\n"))
+			}
+		})
+	}
+}
+
+func TestPullWithRegistryAuth(t *testing.T) {
+	client := &pullingClient{}
+	c := &external{
+		client: client,
+		registryAuths: func(context.Context) (map[string]clients.RegistryAuth, error) {
+			return map[string]clients.RegistryAuth{"ghcr.io": {Username: "bot", Password: "secret"}}, nil
+		},
+	}
+
+	if err := c.pullImage(context.Background(), "nginx:1.27"); err != nil {
+		t.Fatalf("pullImage(...): %v", err)
+	}
+	if client.auth != "" {
+		t.Errorf("pullImage(...) from Docker Hub sent credentials %q, want none", client.auth)
+	}
+
+	if err := c.pullImage(context.Background(), "ghcr.io/acme/app:1"); err != nil {
+		t.Fatalf("pullImage(...): %v", err)
+	}
+	if client.auth == "" {
+		t.Error("pullImage(...) from ghcr.io sent no credentials")
+	}
+}
+
+func TestPullPolicy(t *testing.T) {
+	always := v1alpha1.PullAlways
+	if got := pullPolicy(&v1alpha1.ContainerParameters{}); got != v1alpha1.PullIfNotPresent {
+		t.Errorf("pullPolicy(...) = %s, want IfNotPresent by default", got)
+	}
+	if got := pullPolicy(&v1alpha1.ContainerParameters{ImagePullPolicy: &always}); got != v1alpha1.PullAlways {
+		t.Errorf("pullPolicy(...) = %s, want Always", got)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+)
+
+// The range of OOM score adjustments the kubelet gives burstable pods, between
+// those of guaranteed pods (-997) and best effort pods (1000).
+const (
+	burstableMinOOMScoreAdj = 2
+	burstableMaxOOMScoreAdj = 999
+)
+
+// protectWorkingSet reserves the memory a container requests when it sets no
+// memory limit, and adjusts its OOM score the way the kubelet does for a
+// burstable pod, so that under host memory pressure the containers that
+// requested the most memory are the last to be killed.
+func (c *external) protectWorkingSet(ctx context.Context, resources *v1alpha1.ResourceRequirements, hostConfig *container.HostConfig) error {
+	if resources == nil || !protectsWorkingSet(resources) {
+		return nil
+	}
+	if _, limited := resources.Limits["memory"]; limited {
+		return nil
+	}
+	request, ok := resources.Requests["memory"]
+	if !ok {
+		return nil
+	}
+
+	reservation, err := parseByteSize(request.String())
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resources.requests.memory")
+	}
+	hostConfig.MemoryReservation = reservation
+
+	info, err := c.client.Info(ctx)
+	if err != nil {
+		return errors.Wrap(err, "cannot get Docker host memory")
+	}
+	if info.MemTotal > 0 {
+		hostConfig.OomScoreAdj = burstableOOMScoreAdj(reservation, info.MemTotal)
+	}
+	return nil
+}
+
+// protectsWorkingSet reports whether the memory a container requests is
+// reserved for it, which it is unless protectWorkingSet is false.
+func protectsWorkingSet(resources *v1alpha1.ResourceRequirements) bool {
+	return resources.ProtectWorkingSet == nil || *resources.ProtectWorkingSet
+}
+
+// burstableOOMScoreAdj returns the OOM score adjustment of a container that
+// requests the given bytes of a host's memory capacity.
+func burstableOOMScoreAdj(request, capacity int64) int {
+	adj := 1000 - int((1000*request)/capacity)
+	return min(max(adj, burstableMinOOMScoreAdj), burstableMaxOOMScoreAdj)
+}
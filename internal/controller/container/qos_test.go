@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/system"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"testing"
+)
+
+func TestProtectWorkingSet(t *testing.T) {
+	const gi = 1024 * 1024 * 1024
+
+	disabled := false
+	tests := []struct {
+		name              string
+		resources         *v1alpha1.ResourceRequirements
+		expectReservation int64
+		expectOOMScoreAdj int
+	}{
+		{
+			name: "NoResources",
+		},
+		{
+			name: "MemoryRequestWithoutLimit",
+			resources: &v1alpha1.ResourceRequirements{
+				Requests: v1alpha1.ResourceList{"memory": intstr.FromString("4Gi")},
+			},
+			expectReservation: 4 * gi,
+			expectOOMScoreAdj: 750,
+		},
+		{
+			name: "MemoryLimit",
+			resources: &v1alpha1.ResourceRequirements{
+				Limits:   v1alpha1.ResourceList{"memory": intstr.FromString("4Gi")},
+				Requests: v1alpha1.ResourceList{"memory": intstr.FromString("4Gi")},
+			},
+		},
+		{
+			name: "OptedOut",
+			resources: &v1alpha1.ResourceRequirements{
+				Requests:          v1alpha1.ResourceList{"memory": intstr.FromString("4Gi")},
+				ProtectWorkingSet: &disabled,
+			},
+		},
+		{
+			name: "RequestExceedsHostMemory",
+			resources: &v1alpha1.ResourceRequirements{
+				Requests: v1alpha1.ResourceList{"memory": intstr.FromString("32Gi")},
+			},
+			expectReservation: 32 * gi,
+			expectOOMScoreAdj: burstableMinOOMScoreAdj,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &external{client: &mockDockerClient{
+				infoFunc: func(ctx context.Context) (system.Info, error) {
+					return system.Info{MemTotal: 16 * gi}, nil
+				},
+			}}
+
+			hostConfig := &container.HostConfig{}
+			if err := c.protectWorkingSet(context.Background(), tt.resources, hostConfig); err != nil {
+				t.Fatalf("protectWorkingSet() error = %v", err)
+			}
+			if hostConfig.MemoryReservation != tt.expectReservation {
+				t.Errorf("MemoryReservation = %d, want %d", hostConfig.MemoryReservation, tt.expectReservation)
+			}
+			if hostConfig.OomScoreAdj != tt.expectOOMScoreAdj {
+				t.Errorf("OomScoreAdj = %d, want %d", hostConfig.OomScoreAdj, tt.expectOOMScoreAdj)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/probe"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	errLogLinePattern = "cannot compile readiness log line pattern"
+	errReadLogs       = "cannot read container logs"
+	errReadinessProbe = "cannot run readiness probe"
+
+	// defaultLogLineTimeout is how long a container has to log its
+	// readiness line, when its readiness does not say.
+	defaultLogLineTimeout = 5 * time.Minute
+
+	// maxLogLineLength is the longest log line matched against a readiness
+	// pattern. Longer lines fail the check rather than being truncated.
+	maxLogLineLength = 1024 * 1024
+)
+
+// checkLogReadiness keeps a running container that waits for a log line
+// unavailable, and Starting, until it has logged a matching line since it
+// started. It is reported Degraded once the line is overdue.
+func (c *external) checkLogReadiness(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse) error {
+	r := cr.Spec.ForProvider.Readiness
+	if r == nil || r.WaitForLogLine == nil || !info.State.Running {
+		return nil
+	}
+	obs := &cr.Status.AtProvider
+	if obs.LogLineSeenAt != nil {
+		return nil
+	}
+
+	w := r.WaitForLogLine
+	re, err := regexp.Compile(w.Pattern)
+	if err != nil {
+		return errors.Wrap(err, errLogLinePattern)
+	}
+
+	seen, err := c.logLineLogged(ctx, info, re, obs.State.StartedAt)
+	if err != nil {
+		return errors.Wrap(err, errReadLogs)
+	}
+	if seen {
+		now := metav1.Now()
+		obs.LogLineSeenAt = &now
+		return nil
+	}
+
+	timeout := defaultLogLineTimeout
+	if w.Timeout != nil {
+		timeout = w.Timeout.Duration
+	}
+	if obs.State.StartedAt != nil && time.Since(obs.State.StartedAt.Time) > timeout {
+		obs.Phase = v1alpha1.PhaseDegraded
+		cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("Container did not log a line matching %q within %s", w.Pattern, timeout)))
+		return nil
+	}
+	obs.Phase = v1alpha1.PhaseStarting
+	cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("Waiting for container to log a line matching %q", w.Pattern)))
+	return nil
+}
+
+// checkReadinessProbe keeps a running container with a readiness probe
+// unavailable while the probe fails. The probe waits for any readiness log
+// line and post-conditions, and for its initial delay. A container is
+// Starting until its probe first passes, and Degraded if it fails after.
+func (c *external) checkReadinessProbe(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse) error {
+	p := cr.Spec.ForProvider.ReadinessProbe
+	if p == nil || !info.State.Running {
+		return nil
+	}
+	obs := &cr.Status.AtProvider
+	if r := cr.Spec.ForProvider.Readiness; r != nil && r.WaitForLogLine != nil && obs.LogLineSeenAt == nil {
+		return nil
+	}
+	if len(cr.Spec.ForProvider.PostConditions) > 0 && obs.PostConditionsPassedAt == nil {
+		return nil
+	}
+	if p.InitialDelay != nil && obs.State.StartedAt != nil && time.Since(obs.State.StartedAt.Time) < p.InitialDelay.Duration {
+		obs.Phase = v1alpha1.PhaseStarting
+		cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("Waiting %s after starting to probe readiness", p.InitialDelay.Duration)))
+		return nil
+	}
+
+	ready, msg, err := probe.Check(ctx, c.client, info, p)
+	if err != nil {
+		return errors.Wrap(err, errReadinessProbe)
+	}
+	wasReady := obs.ReadinessProbe != nil && obs.ReadinessProbe.Ready
+	obs.ReadinessProbe = &v1alpha1.ProbeResult{Ready: ready, Message: msg, LastProbeTime: metav1.Now()}
+	if ready {
+		return nil
+	}
+	obs.Phase = v1alpha1.PhaseStarting
+	if wasReady {
+		obs.Phase = v1alpha1.PhaseDegraded
+	}
+	cr.SetConditions(xpv1.Unavailable().WithMessage(msg))
+	return nil
+}
+
+// logLineLogged reports whether a container has logged a line matching re
+// to stdout or stderr since it started.
+func (c *external) logLineLogged(ctx context.Context, info *container.InspectResponse, re *regexp.Regexp, started *metav1.Time) (bool, error) {
+	opts := container.LogsOptions{ShowStdout: true, ShowStderr: true}
+	if started != nil {
+		opts.Since = strconv.FormatInt(started.Unix(), 10)
+	}
+	logs, err := c.client.ContainerLogs(ctx, info.ID, opts)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = logs.Close() }()
+
+	// Containers without a TTY interleave stdout and stderr in one stream
+	var r io.Reader = logs
+	if info.Config == nil || !info.Config.Tty {
+		pr, pw := io.Pipe()
+		defer func() { _ = pr.Close() }()
+		go func() {
+			_, err := stdcopy.StdCopy(pw, pw, logs)
+			pw.CloseWithError(err)
+		}()
+		r = pr
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLogLineLength)
+	for sc.Scan() {
+		if re.Match(sc.Bytes()) {
+			return true, nil
+		}
+	}
+	return false, sc.Err()
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"bytes"
+	"context"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+// multiplexed returns logs as Docker streams them for a container without
+// a TTY.
+func multiplexed(stdout, stderr string) []byte {
+	var buf bytes.Buffer
+	_, _ = stdcopy.NewStdWriter(&buf, stdcopy.Stdout).Write([]byte(stdout))
+	_, _ = stdcopy.NewStdWriter(&buf, stdcopy.Stderr).Write([]byte(stderr))
+	return buf.Bytes()
+}
+
+func TestCheckLogReadiness(t *testing.T) {
+	tests := []struct {
+		name        string
+		logs        []byte
+		tty         bool
+		startedAgo  time.Duration
+		seen        bool
+		expectPhase v1alpha1.ContainerPhase
+		expectReady corev1.ConditionStatus
+	}{
+		{
+			name:        "LineLoggedToStdout",
+			logs:        multiplexed("booting\nserver listening on :8080\n", ""),
+			startedAgo:  time.Second,
+			seen:        true,
+			expectPhase: v1alpha1.PhaseRunning,
+			expectReady: corev1.ConditionTrue,
+		},
+		{
+			name:        "LineLoggedToStderr",
+			logs:        multiplexed("booting\n", "server listening on :8080\n"),
+			startedAgo:  time.Second,
+			seen:        true,
+			expectPhase: v1alpha1.PhaseRunning,
+			expectReady: corev1.ConditionTrue,
+		},
+		{
+			name:        "LineLoggedWithTTY",
+			logs:        []byte("booting\r\nserver listening on :8080\r\n"),
+			tty:         true,
+			startedAgo:  time.Second,
+			seen:        true,
+			expectPhase: v1alpha1.PhaseRunning,
+			expectReady: corev1.ConditionTrue,
+		},
+		{
+			name:        "WaitingForLine",
+			logs:        multiplexed("booting\n", ""),
+			startedAgo:  time.Second,
+			expectPhase: v1alpha1.PhaseStarting,
+			expectReady: corev1.ConditionFalse,
+		},
+		{
+			name:        "LineOverdue",
+			logs:        multiplexed("booting\n", ""),
+			startedAgo:  time.Hour,
+			expectPhase: v1alpha1.PhaseDegraded,
+			expectReady: corev1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := time.Now().Add(-tt.startedAgo).UTC().Format(time.RFC3339Nano)
+			info := &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:    "abc123",
+					State: &container.State{Status: container.StateRunning, Running: true, StartedAt: started},
+				},
+				Config: &container.Config{Image: "legacy:latest", Tty: tt.tty},
+			}
+			cr := &v1alpha1.Container{
+				Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+					Image: "legacy:latest",
+					Readiness: &v1alpha1.Readiness{WaitForLogLine: &v1alpha1.WaitForLogLine{
+						Pattern: `listening on :\d+`,
+						Timeout: &metav1.Duration{Duration: time.Minute},
+					}},
+				}},
+			}
+
+			var since string
+			e := &external{client: &mockDockerClient{
+				containerLogsFunc: func(_ context.Context, _ string, opts container.LogsOptions) (io.ReadCloser, error) {
+					since = opts.Since
+					return io.NopCloser(bytes.NewReader(tt.logs)), nil
+				},
+			}}
+			e.updateStatus(cr, info)
+			if err := e.checkLogReadiness(context.Background(), cr, info); err != nil {
+				t.Fatalf("checkLogReadiness() error = %v", err)
+			}
+
+			if since == "" {
+				t.Error("checkLogReadiness() read logs from before the container started")
+			}
+			if got := cr.Status.AtProvider.LogLineSeenAt != nil; got != tt.seen {
+				t.Errorf("checkLogReadiness() seen = %v, want %v", got, tt.seen)
+			}
+			if cr.Status.AtProvider.Phase != tt.expectPhase {
+				t.Errorf("checkLogReadiness() phase = %q, want %q", cr.Status.AtProvider.Phase, tt.expectPhase)
+			}
+			if got := cr.GetCondition(xpv1.TypeReady).Status; got != tt.expectReady {
+				t.Errorf("checkLogReadiness() Ready = %q, want %q", got, tt.expectReady)
+			}
+		})
+	}
+}
+
+func TestLogLineSeenResetOnRestart(t *testing.T) {
+	seen := metav1.NewTime(time.Now().Add(-time.Hour))
+	cr := &v1alpha1.Container{}
+	cr.Status.AtProvider.LogLineSeenAt = &seen
+
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			State: &container.State{Status: container.StateRunning, Running: true, StartedAt: time.Now().UTC().Format(time.RFC3339Nano)},
+		},
+		Config: &container.Config{},
+	}
+	(&external{}).updateStatus(cr, info)
+
+	if cr.Status.AtProvider.LogLineSeenAt != nil {
+		t.Error("updateStatus() kept a log line seen before the container restarted")
+	}
+}
+
+func TestCheckReadinessProbe(t *testing.T) {
+	tests := []struct {
+		name        string
+		exitCode    int
+		startedAgo  time.Duration
+		wasReady    bool
+		expectRun   bool
+		expectPhase v1alpha1.ContainerPhase
+		expectReady corev1.ConditionStatus
+	}{
+		{
+			name:        "Passes",
+			startedAgo:  time.Minute,
+			expectRun:   true,
+			expectPhase: v1alpha1.PhaseRunning,
+			expectReady: corev1.ConditionTrue,
+		},
+		{
+			name:        "NotYetReady",
+			exitCode:    1,
+			startedAgo:  time.Minute,
+			expectRun:   true,
+			expectPhase: v1alpha1.PhaseStarting,
+			expectReady: corev1.ConditionFalse,
+		},
+		{
+			name:        "NoLongerReady",
+			exitCode:    1,
+			startedAgo:  time.Minute,
+			wasReady:    true,
+			expectRun:   true,
+			expectPhase: v1alpha1.PhaseDegraded,
+			expectReady: corev1.ConditionFalse,
+		},
+		{
+			name:        "InitialDelay",
+			startedAgo:  time.Second,
+			expectPhase: v1alpha1.PhaseStarting,
+			expectReady: corev1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := time.Now().Add(-tt.startedAgo).UTC()
+			info := &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:    "abc123",
+					State: &container.State{Status: container.StateRunning, Running: true, StartedAt: started.Format(time.RFC3339Nano)},
+				},
+				Config: &container.Config{Image: "postgres:16"},
+			}
+			cr := &v1alpha1.Container{
+				Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+					Image: "postgres:16",
+					ReadinessProbe: &v1alpha1.ReadinessProbe{
+						Exec:         &v1alpha1.ExecProbe{Command: []string{"pg_isready"}},
+						InitialDelay: &metav1.Duration{Duration: 10 * time.Second},
+					},
+				}},
+			}
+			if tt.wasReady {
+				cr.Status.AtProvider.ReadinessProbe = &v1alpha1.ProbeResult{Ready: true, LastProbeTime: metav1.NewTime(started.Add(time.Second))}
+			}
+
+			var ran bool
+			e := &external{client: &mockDockerClient{
+				execInspectFunc: func(context.Context, string) (container.ExecInspect, error) {
+					ran = true
+					return container.ExecInspect{ExitCode: tt.exitCode}, nil
+				},
+			}}
+			e.updateStatus(cr, info)
+			if err := e.checkReadinessProbe(context.Background(), cr, info); err != nil {
+				t.Fatalf("checkReadinessProbe() error = %v", err)
+			}
+
+			if ran != tt.expectRun {
+				t.Errorf("checkReadinessProbe() ran probe = %v, want %v", ran, tt.expectRun)
+			}
+			if cr.Status.AtProvider.Phase != tt.expectPhase {
+				t.Errorf("checkReadinessProbe() phase = %q, want %q", cr.Status.AtProvider.Phase, tt.expectPhase)
+			}
+			if got := cr.GetCondition(xpv1.TypeReady).Status; got != tt.expectReady {
+				t.Errorf("checkReadinessProbe() Ready = %q, want %q", got, tt.expectReady)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/apis/container/v1beta1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	kevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+)
+
+const (
+	errStartRecovered = "cannot start container stopped by its Docker daemon restarting"
+	errListRequeued   = "cannot list the containers to requeue"
+
+	reasonRecovered event.Reason = "RecoveredFromDaemonRestart"
+)
+
+// daemons notices the Docker daemons of containers restarting.
+var daemons = clients.NewDaemonWatch(DefaultSnapshotTTL)
+
+// checkDaemon checks whether the Docker daemon of a container has restarted,
+// returning the last restart seen, if any. The container that first sees a
+// restart drops the snapshot and information of its host, which are stale,
+// and requeues the containers that share its ProviderConfig.
+func (c *external) checkDaemon(ctx context.Context, cr *v1alpha1.Container) *clients.DaemonRestart {
+	restart, fresh := c.daemons.Check(ctx, c.client, c.host)
+	if !fresh {
+		return restart
+	}
+	c.snapshots.ForgetHost(c.host)
+	c.infos.Forget(c.host)
+	if c.logger != nil {
+		c.logger.Info("Docker daemon restarted", "host", c.host, "lastSeenRunning", restart.Since)
+	}
+	if err := c.requeuer.requeue(ctx, cr); err != nil && c.logger != nil {
+		c.logger.Debug("Cannot requeue containers after their Docker daemon restarted", "error", err)
+	}
+	return restart
+}
+
+// recoverFromDaemonRestart starts a container again that stopped when its
+// Docker daemon restarted, returning whether it did. Docker restarts the
+// containers whose restart policy says to; any other container that exited
+// while its daemon restarted, between when the daemon was last seen running
+// and first seen running again, is started unless it is not started when
+// created. Nothing is started in dry-run mode, or when the management
+// policies of the container do not permit updating it.
+func (c *external) recoverFromDaemonRestart(ctx context.Context, cr *v1alpha1.Container, restart *clients.DaemonRestart, info *container.InspectResponse) (bool, error) {
+	if restart == nil || info.State == nil || info.State.Status != container.StateExited {
+		return false, nil
+	}
+	if c.readOnly || !allows(cr, xpv1.ManagementActionUpdate) {
+		return false, nil
+	}
+	if s := cr.Spec.ForProvider.StartOnCreate; s != nil && !*s {
+		return false, nil
+	}
+	finished, err := time.Parse(time.RFC3339Nano, info.State.FinishedAt)
+	if err != nil || !finished.After(restart.Since) || finished.After(restart.At) {
+		return false, nil
+	}
+
+	if err := c.client.ContainerStart(ctx, info.ID, container.StartOptions{}); err != nil {
+		return false, errors.Wrap(err, errStartRecovered)
+	}
+	c.snapshots.Forget(c.host, observedContainerName(info))
+	c.record(cr, event.Normal(reasonRecovered, fmt.Sprintf("Started the container, which stopped at %s when its Docker daemon restarted", finished.UTC().Format(time.RFC3339))))
+	return true, nil
+}
+
+// A restartRequeuer requeues the containers of a kind that share the
+// ProviderConfig of one whose Docker daemon restarted, so that they recover
+// without waiting for their next poll.
+type restartRequeuer struct {
+	kube   client.Client
+	list   func(ctx context.Context, kube client.Client, namespace string) ([]client.Object, error)
+	events chan kevent.GenericEvent
+}
+
+func newRestartRequeuer(kube client.Client, list func(ctx context.Context, kube client.Client, namespace string) ([]client.Object, error)) *restartRequeuer {
+	return &restartRequeuer{kube: kube, list: list, events: make(chan kevent.GenericEvent, 64)}
+}
+
+// source is the source of the containers requeued, for their controller.
+func (r *restartRequeuer) source() source.Source {
+	return source.Channel(r.events, &handler.EnqueueRequestForObject{})
+}
+
+// requeue requeues the containers, in the namespace of the supplied one,
+// that share its ProviderConfig. A nil restartRequeuer does nothing.
+func (r *restartRequeuer) requeue(ctx context.Context, cr *v1alpha1.Container) error {
+	if r == nil {
+		return nil
+	}
+	objs, err := r.list(ctx, r.kube, cr.GetNamespace())
+	if err != nil {
+		return errors.Wrap(err, errListRequeued)
+	}
+	for _, o := range objs {
+		if o.GetName() == cr.GetName() || !sameProviderConfig(o, cr.GetProviderConfigReference()) {
+			continue
+		}
+		select {
+		case r.events <- kevent.GenericEvent{Object: o}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// sameProviderConfig reports whether a container refers to the supplied
+// ProviderConfig.
+func sameProviderConfig(o client.Object, ref *xpv1.ProviderConfigReference) bool {
+	r, ok := o.(interface {
+		GetProviderConfigReference() *xpv1.ProviderConfigReference
+	})
+	if !ok {
+		return false
+	}
+	other := r.GetProviderConfigReference()
+	if ref == nil || other == nil {
+		return ref == other
+	}
+	return other.Kind == ref.Kind && other.Name == ref.Name
+}
+
+// listContainers lists the v1alpha1 Containers.
+func listContainers(ctx context.Context, kube client.Client, _ string) ([]client.Object, error) {
+	l := &v1alpha1.ContainerList{}
+	if err := kube.List(ctx, l); err != nil {
+		return nil, err
+	}
+	objs := make([]client.Object, 0, len(l.Items))
+	for i := range l.Items {
+		objs = append(objs, &l.Items[i])
+	}
+	return objs, nil
+}
+
+// listV1Beta1Containers lists the v1beta1 Containers in a namespace.
+func listV1Beta1Containers(ctx context.Context, kube client.Client, namespace string) ([]client.Object, error) {
+	l := &v1beta1.ContainerList{}
+	if err := kube.List(ctx, l, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	objs := make([]client.Object, 0, len(l.Items))
+	for i := range l.Items {
+		objs = append(objs, &l.Items[i])
+	}
+	return objs, nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRecoverFromDaemonRestart(t *testing.T) {
+	since := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	restart := &clients.DaemonRestart{Since: since, At: since.Add(time.Minute)}
+	no := false
+
+	tests := map[string]struct {
+		restart       *clients.DaemonRestart
+		status        container.ContainerState
+		finishedAt    time.Time
+		startOnCreate *bool
+		policies      xpv1.ManagementPolicies
+		readOnly      bool
+		want          bool
+	}{
+		"StoppedByRestart": {
+			restart:    restart,
+			status:     container.StateExited,
+			finishedAt: since.Add(30 * time.Second),
+			want:       true,
+		},
+		"NoRestart": {
+			status:     container.StateExited,
+			finishedAt: since.Add(30 * time.Second),
+		},
+		"ExitedBefore": {
+			restart:    restart,
+			status:     container.StateExited,
+			finishedAt: since.Add(-time.Second),
+		},
+		"ExitedAfter": {
+			restart:    restart,
+			status:     container.StateExited,
+			finishedAt: restart.At.Add(time.Second),
+		},
+		"Running": {
+			restart:    restart,
+			status:     container.StateRunning,
+			finishedAt: since.Add(30 * time.Second),
+		},
+		"NotStartedOnCreate": {
+			restart:       restart,
+			status:        container.StateExited,
+			finishedAt:    since.Add(30 * time.Second),
+			startOnCreate: &no,
+		},
+		"ObserveOnly": {
+			restart:    restart,
+			status:     container.StateExited,
+			finishedAt: since.Add(30 * time.Second),
+			policies:   xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+		},
+		"DryRun": {
+			restart:    restart,
+			status:     container.StateExited,
+			finishedAt: since.Add(30 * time.Second),
+			readOnly:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Container{}
+			cr.Spec.ForProvider.StartOnCreate = tt.startOnCreate
+			cr.SetManagementPolicies(tt.policies)
+			info := &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				ID:    "abc123",
+				Name:  "/web",
+				State: &container.State{Status: tt.status, FinishedAt: tt.finishedAt.Format(time.RFC3339Nano)},
+			}}
+
+			var started []string
+			e := &external{readOnly: tt.readOnly, client: &mockDockerClient{
+				containerStartFunc: func(_ context.Context, id string, _ container.StartOptions) error {
+					started = append(started, id)
+					return nil
+				},
+			}}
+			got, err := e.recoverFromDaemonRestart(context.Background(), cr, tt.restart, info)
+			if err != nil {
+				t.Fatalf("recoverFromDaemonRestart() error = %v", err)
+			}
+			if got != tt.want || (len(started) == 1) != tt.want {
+				t.Errorf("recoverFromDaemonRestart() = %v after starting %q, want %v", got, started, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestartRequeuer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apis.AddToScheme(scheme)
+
+	withRef := func(name, pc string) *v1alpha1.Container {
+		cr := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		cr.SetProviderConfigReference(&xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: pc})
+		return cr
+	}
+	web, api, db := withRef("web", "edge"), withRef("api", "edge"), withRef("db", "core")
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(web, api, db).Build()
+
+	r := newRestartRequeuer(kube, listContainers)
+	if err := r.requeue(context.Background(), web); err != nil {
+		t.Fatalf("requeue() error = %v", err)
+	}
+	close(r.events)
+	var requeued []string
+	for e := range r.events {
+		requeued = append(requeued, e.Object.GetName())
+	}
+	if !slices.Equal(requeued, []string{"api"}) {
+		t.Errorf("requeue() requeued %q, want the other container of the ProviderConfig", requeued)
+	}
+
+	var none *restartRequeuer
+	if err := none.requeue(context.Background(), web); err != nil {
+		t.Errorf("requeue() on nil error = %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-docker/apis"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/apis/container/v1beta1"
+	networkv1alpha1 "github.com/rossigee/provider-docker/apis/network/v1alpha1"
+	volumev1alpha1 "github.com/rossigee/provider-docker/apis/volume/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestResolveVolumeReferences(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apis.AddToScheme(scheme)
+
+	created := &volumev1alpha1.Volume{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Labels: map[string]string{"app": "db"}},
+		Status:     volumev1alpha1.VolumeStatus{AtProvider: volumev1alpha1.VolumeObservation{Name: "db-data"}},
+	}
+	pending := &volumev1alpha1.Volume{ObjectMeta: metav1.ObjectMeta{Name: "pending"}}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(created, pending).Build()
+
+	mount := func(src *v1alpha1.VolumeVolumeSource) []v1alpha1.VolumeMount {
+		return []v1alpha1.VolumeMount{{Name: "data", MountPath: "/data", VolumeSource: v1alpha1.VolumeSource{Volume: src}}}
+	}
+
+	tests := map[string]struct {
+		src     *v1alpha1.VolumeVolumeSource
+		want    string
+		wantErr bool
+	}{
+		"Reference": {
+			src:  &v1alpha1.VolumeVolumeSource{VolumeRef: &xpv1.Reference{Name: "data"}},
+			want: "db-data",
+		},
+		"Selector": {
+			src:  &v1alpha1.VolumeVolumeSource{VolumeSelector: &xpv1.Selector{MatchLabels: map[string]string{"app": "db"}}},
+			want: "db-data",
+		},
+		"NotYetCreated": {
+			src:     &v1alpha1.VolumeVolumeSource{VolumeRef: &xpv1.Reference{Name: "pending"}},
+			wantErr: true,
+		},
+		"Name": {
+			src:  &v1alpha1.VolumeVolumeSource{VolumeName: "scratch"},
+			want: "scratch",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v1 := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}}
+			v1.Spec.ForProvider.Volumes = mount(tt.src.DeepCopy())
+			err := v1.ResolveReferences(context.Background(), kube)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("v1alpha1 ResolveReferences(...): error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := v1.Spec.ForProvider.Volumes[0].VolumeSource.Volume.VolumeName; !tt.wantErr && got != tt.want {
+				t.Errorf("v1alpha1 resolved volume name = %q, want %q", got, tt.want)
+			}
+
+			v2 := &v1beta1.Container{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}}
+			v2.Spec.ForProvider.Volumes = mount(tt.src.DeepCopy())
+			err = v2.ResolveReferences(context.Background(), kube)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("v1beta1 ResolveReferences(...): error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := v2.Spec.ForProvider.Volumes[0].VolumeSource.Volume.VolumeName; !tt.wantErr && got != tt.want {
+				t.Errorf("v1beta1 resolved volume name = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveNetworkReferences(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apis.AddToScheme(scheme)
+
+	created := &networkv1alpha1.Network{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Labels: map[string]string{"tier": "backend"}},
+		Status:     networkv1alpha1.NetworkStatus{AtProvider: networkv1alpha1.NetworkObservation{Name: "app-backend"}},
+	}
+	pending := &networkv1alpha1.Network{ObjectMeta: metav1.ObjectMeta{Name: "pending"}}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(created, pending).Build()
+
+	tests := map[string]struct {
+		attachment v1alpha1.NetworkAttachment
+		want       string
+		wantErr    bool
+	}{
+		"Reference": {
+			attachment: v1alpha1.NetworkAttachment{NetworkRef: &xpv1.Reference{Name: "backend"}},
+			want:       "app-backend",
+		},
+		"Selector": {
+			attachment: v1alpha1.NetworkAttachment{NetworkSelector: &xpv1.Selector{MatchLabels: map[string]string{"tier": "backend"}}},
+			want:       "app-backend",
+		},
+		"NotYetCreated": {
+			attachment: v1alpha1.NetworkAttachment{NetworkRef: &xpv1.Reference{Name: "pending"}},
+			wantErr:    true,
+		},
+		"Name": {
+			attachment: v1alpha1.NetworkAttachment{Name: "bridge"},
+			want:       "bridge",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v1 := &v1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+			v1.Spec.ForProvider.Networks = []v1alpha1.NetworkAttachment{*tt.attachment.DeepCopy()}
+			err := v1.ResolveReferences(context.Background(), kube)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("v1alpha1 ResolveReferences(...): error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := v1.Spec.ForProvider.Networks[0].Name; !tt.wantErr && got != tt.want {
+				t.Errorf("v1alpha1 resolved network name = %q, want %q", got, tt.want)
+			}
+
+			v2 := &v1beta1.Container{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+			v2.Spec.ForProvider.Networks = []v1alpha1.NetworkAttachment{*tt.attachment.DeepCopy()}
+			err = v2.ResolveReferences(context.Background(), kube)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("v1beta1 ResolveReferences(...): error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := v2.Spec.ForProvider.Networks[0].Name; !tt.wantErr && got != tt.want {
+				t.Errorf("v1beta1 resolved network name = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
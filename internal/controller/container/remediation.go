@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultUnhealthyThreshold is how many consecutive unhealthy observations
+// are tolerated when the container does not say.
+const defaultUnhealthyThreshold = 3
+
+const (
+	errRestartUnhealthy = "cannot restart unhealthy container"
+	errRemoveUnhealthy  = "cannot remove unhealthy container"
+)
+
+// remediate repairs a container whose health check has reported it
+// unhealthy for too many consecutive observations, as its remediation
+// policy says. It returns true if the container was removed so that it can
+// be created afresh.
+func (c *external) remediate(ctx context.Context, cr *v1alpha1.Container, containerInfo *container.InspectResponse) (bool, error) {
+	r := cr.Spec.ForProvider.Remediation
+	if r == nil || r.OnUnhealthy == "" || r.OnUnhealthy == v1alpha1.RemediationNone {
+		cr.Status.AtProvider.Remediation = nil
+		return false, nil
+	}
+
+	status := cr.Status.AtProvider.Remediation
+	if status == nil {
+		status = &v1alpha1.RemediationStatus{}
+		cr.Status.AtProvider.Remediation = status
+	}
+
+	health := containerInfo.State.Health
+	if health == nil || health.Status != container.Unhealthy {
+		status.UnhealthyObservations = 0
+		return false, nil
+	}
+
+	status.UnhealthyObservations++
+	threshold := int32(defaultUnhealthyThreshold)
+	if r.UnhealthyThreshold != nil && *r.UnhealthyThreshold > 0 {
+		threshold = *r.UnhealthyThreshold
+	}
+	if status.UnhealthyObservations < threshold {
+		return false, nil
+	}
+
+	// A container whose management policies do not permit its remediation
+	// is left as it is for its owner to repair.
+	if !allowsRemediation(cr, r.OnUnhealthy) {
+		return false, nil
+	}
+
+	id := containerInfo.ID
+	timeout := stopTimeout(&cr.Spec.ForProvider)
+	c.logger.Info("Remediating unhealthy container", "container", cr.Name, "id", id, "action", string(r.OnUnhealthy))
+	defer c.snapshots.Forget(c.host, id)
+
+	recreate := r.OnUnhealthy == v1alpha1.RemediationRecreate
+	if recreate {
+		if err := c.client.ContainerStop(ctx, id, container.StopOptions{Timeout: &timeout}); err != nil && !isNotFound(err) {
+			return false, errors.Wrap(err, errRemoveUnhealthy)
+		}
+		if err := c.client.ContainerRemove(ctx, id, container.RemoveOptions{Force: true}); err != nil && !isNotFound(err) {
+			return false, errors.Wrap(err, errRemoveUnhealthy)
+		}
+		status.Recreates++
+	} else {
+		if err := c.client.ContainerRestart(ctx, id, container.StopOptions{Timeout: &timeout}); err != nil {
+			return false, errors.Wrap(err, errRestartUnhealthy)
+		}
+		status.Restarts++
+	}
+
+	now := metav1.Now()
+	status.UnhealthyObservations = 0
+	status.LastRemediatedAt = &now
+	return recreate, nil
+}
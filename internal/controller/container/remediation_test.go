@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"testing"
+)
+
+func TestRemediate(t *testing.T) {
+	unhealthy := &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+		ID:    "abc",
+		State: &container.State{Health: &container.Health{Status: container.Unhealthy}},
+	}}
+	healthy := &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+		ID:    "abc",
+		State: &container.State{Health: &container.Health{Status: container.Healthy}},
+	}}
+
+	tests := []struct {
+		name         string
+		remediation  *v1alpha1.Remediation
+		policies     xpv1.ManagementPolicies
+		status       *v1alpha1.RemediationStatus
+		info         *container.InspectResponse
+		wantRecreate bool
+		wantStatus   *v1alpha1.RemediationStatus
+		wantRestart  bool
+		wantRemove   bool
+	}{
+		{
+			name: "NoPolicy",
+			info: unhealthy,
+		},
+		{
+			name:        "BelowThreshold",
+			remediation: &v1alpha1.Remediation{OnUnhealthy: v1alpha1.RemediationRestart},
+			status:      &v1alpha1.RemediationStatus{UnhealthyObservations: 1},
+			info:        unhealthy,
+			wantStatus:  &v1alpha1.RemediationStatus{UnhealthyObservations: 2},
+		},
+		{
+			name:        "HealthyResetsCount",
+			remediation: &v1alpha1.Remediation{OnUnhealthy: v1alpha1.RemediationRestart},
+			status:      &v1alpha1.RemediationStatus{UnhealthyObservations: 2, Restarts: 1},
+			info:        healthy,
+			wantStatus:  &v1alpha1.RemediationStatus{Restarts: 1},
+		},
+		{
+			name:        "Restart",
+			remediation: &v1alpha1.Remediation{OnUnhealthy: v1alpha1.RemediationRestart},
+			status:      &v1alpha1.RemediationStatus{UnhealthyObservations: 2},
+			info:        unhealthy,
+			wantStatus:  &v1alpha1.RemediationStatus{Restarts: 1},
+			wantRestart: true,
+		},
+		{
+			name:         "Recreate",
+			remediation:  &v1alpha1.Remediation{OnUnhealthy: v1alpha1.RemediationRecreate, UnhealthyThreshold: int32Ptr(1)},
+			info:         unhealthy,
+			wantRecreate: true,
+			wantStatus:   &v1alpha1.RemediationStatus{Recreates: 1},
+			wantRemove:   true,
+		},
+		{
+			name:        "RestartNotAllowed",
+			remediation: &v1alpha1.Remediation{OnUnhealthy: v1alpha1.RemediationRestart},
+			policies:    xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionDelete},
+			status:      &v1alpha1.RemediationStatus{UnhealthyObservations: 2},
+			info:        unhealthy,
+			wantStatus:  &v1alpha1.RemediationStatus{UnhealthyObservations: 3},
+		},
+		{
+			name:        "RecreateNotAllowed",
+			remediation: &v1alpha1.Remediation{OnUnhealthy: v1alpha1.RemediationRecreate, UnhealthyThreshold: int32Ptr(1)},
+			policies:    xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate},
+			info:        unhealthy,
+			wantStatus:  &v1alpha1.RemediationStatus{UnhealthyObservations: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var restarted, removed bool
+			c := &external{
+				logger: logging.NewNopLogger(),
+				client: &mockDockerClient{
+					containerRestartFunc: func(_ context.Context, _ string, _ container.StopOptions) error {
+						restarted = true
+						return nil
+					},
+					containerRemoveFunc: func(_ context.Context, _ string, _ container.RemoveOptions) error {
+						removed = true
+						return nil
+					},
+				},
+			}
+			cr := &v1alpha1.Container{}
+			cr.Spec.ForProvider.Remediation = tt.remediation
+			cr.SetManagementPolicies(tt.policies)
+			cr.Status.AtProvider.Remediation = tt.status
+
+			recreate, err := c.remediate(context.Background(), cr, tt.info)
+			if err != nil {
+				t.Fatalf("remediate(...): %v", err)
+			}
+			if recreate != tt.wantRecreate {
+				t.Errorf("remediate(...) = %t, want %t", recreate, tt.wantRecreate)
+			}
+			if restarted != tt.wantRestart || removed != tt.wantRemove {
+				t.Errorf("restarted, removed = %t, %t, want %t, %t", restarted, removed, tt.wantRestart, tt.wantRemove)
+			}
+
+			got := cr.Status.AtProvider.Remediation
+			if (got == nil) != (tt.wantStatus == nil) {
+				t.Fatalf("Remediation = %+v, want %+v", got, tt.wantStatus)
+			}
+			if got == nil {
+				return
+			}
+			if got.UnhealthyObservations != tt.wantStatus.UnhealthyObservations ||
+				got.Restarts != tt.wantStatus.Restarts || got.Recreates != tt.wantStatus.Recreates {
+				t.Errorf("Remediation = %+v, want %+v", got, tt.wantStatus)
+			}
+			if (tt.wantRestart || tt.wantRemove) && got.LastRemediatedAt == nil {
+				t.Errorf("LastRemediatedAt not set")
+			}
+		})
+	}
+}
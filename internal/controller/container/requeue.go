@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/apis/container/v1beta1"
+)
+
+// PollIntervals are the delays before a container is observed again,
+// depending on its observed state. A zero interval falls back to the
+// controller's poll interval.
+type PollIntervals struct {
+	// Transitioning applies while a container is being pulled, created or
+	// started, is restarting or its health check is still starting. A
+	// container that was created without being started is not
+	// transitioning.
+	Transitioning time.Duration
+
+	// Stable applies to containers that have been running for at least
+	// Settle, and are not reporting an unhealthy or starting health check.
+	Stable time.Duration
+
+	// Settle is how long a container must have been running before it is
+	// considered stable.
+	Settle time.Duration
+}
+
+// DefaultPollIntervals are used unless SetPollIntervals is called.
+var DefaultPollIntervals = PollIntervals{
+	Transitioning: 5 * time.Second,
+	Stable:        5 * time.Minute,
+	Settle:        2 * time.Minute,
+}
+
+var pollIntervals = DefaultPollIntervals
+
+// SetPollIntervals configures the state based poll intervals of the container
+// controllers. It must be called before they are set up.
+func SetPollIntervals(p PollIntervals) {
+	pollIntervals = p
+}
+
+// A pollIntervalHook picks the poll interval of a container from its observed
+// state, as of the time returned by now.
+type pollIntervalHook struct {
+	intervals PollIntervals
+	now       func() time.Time
+}
+
+func newPollIntervalHook(p PollIntervals) *pollIntervalHook {
+	return &pollIntervalHook{intervals: p, now: time.Now}
+}
+
+// Interval implements managed.PollIntervalHook.
+func (h *pollIntervalHook) Interval(mg resource.Managed, pollInterval time.Duration) time.Duration {
+	var obs v1alpha1.ContainerObservation
+	switch cr := mg.(type) {
+	case *v1alpha1.Container:
+		obs = cr.Status.AtProvider
+	case *v1beta1.Container:
+		obs = v1alpha1.ContainerObservation(cr.Status.AtProvider)
+	default:
+		return pollInterval
+	}
+
+	state := obs.State
+	health := ""
+	if state.Health != nil {
+		health = state.Health.Status
+	}
+
+	switch {
+	case startPending(obs.Phase), state.Restarting, state.Status == "restarting", health == "starting":
+		return orDefault(h.intervals.Transitioning, pollInterval)
+	case state.Running && !state.Paused && health != "unhealthy" &&
+		state.StartedAt != nil && h.now().Sub(state.StartedAt.Time) >= h.intervals.Settle:
+		return orDefault(h.intervals.Stable, pollInterval)
+	}
+	return pollInterval
+}
+
+// startPending reports whether the provider is yet to start a container in
+// a phase.
+func startPending(phase v1alpha1.ContainerPhase) bool {
+	switch phase {
+	case v1alpha1.PhasePulling, v1alpha1.PhaseCreating, v1alpha1.PhaseStarting:
+		return true
+	}
+	return false
+}
+
+func orDefault(d, fallback time.Duration) time.Duration {
+	if d <= 0 {
+		return fallback
+	}
+	return d
+}
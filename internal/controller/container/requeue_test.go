@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/apis/container/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestPollIntervalHook(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	startedAt := func(ago time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-ago))
+		return &t
+	}
+	intervals := PollIntervals{Transitioning: 5 * time.Second, Stable: 10 * time.Minute, Settle: 2 * time.Minute}
+	poll := time.Minute
+
+	cases := map[string]struct {
+		intervals PollIntervals
+		phase     v1alpha1.ContainerPhase
+		state     v1alpha1.ContainerState
+		want      time.Duration
+	}{
+		"NotObservedYet": {
+			intervals: intervals,
+			want:      poll,
+		},
+		"Pulling": {
+			intervals: intervals,
+			phase:     v1alpha1.PhasePulling,
+			want:      5 * time.Second,
+		},
+		"CreatedStartPending": {
+			intervals: intervals,
+			phase:     v1alpha1.PhaseCreating,
+			state:     v1alpha1.ContainerState{Status: "created"},
+			want:      5 * time.Second,
+		},
+		"CreatedNeverStarted": {
+			intervals: intervals,
+			phase:     v1alpha1.PhasePending,
+			state:     v1alpha1.ContainerState{Status: "created"},
+			want:      poll,
+		},
+		"Restarting": {
+			intervals: intervals,
+			state:     v1alpha1.ContainerState{Status: "restarting", Restarting: true},
+			want:      5 * time.Second,
+		},
+		"HealthStarting": {
+			intervals: intervals,
+			state: v1alpha1.ContainerState{
+				Status: "running", Running: true, StartedAt: startedAt(time.Hour),
+				Health: &v1alpha1.ContainerHealth{Status: "starting"},
+			},
+			want: 5 * time.Second,
+		},
+		"RecentlyStarted": {
+			intervals: intervals,
+			state:     v1alpha1.ContainerState{Status: "running", Running: true, StartedAt: startedAt(30 * time.Second)},
+			want:      poll,
+		},
+		"StableRunning": {
+			intervals: intervals,
+			state:     v1alpha1.ContainerState{Status: "running", Running: true, StartedAt: startedAt(time.Hour)},
+			want:      10 * time.Minute,
+		},
+		"Unhealthy": {
+			intervals: intervals,
+			state: v1alpha1.ContainerState{
+				Status: "running", Running: true, StartedAt: startedAt(time.Hour),
+				Health: &v1alpha1.ContainerHealth{Status: "unhealthy"},
+			},
+			want: poll,
+		},
+		"Exited": {
+			intervals: intervals,
+			state:     v1alpha1.ContainerState{Status: "exited", ExitCode: 1},
+			want:      poll,
+		},
+		"ZeroIntervalFallsBack": {
+			state: v1alpha1.ContainerState{Status: "restarting", Restarting: true},
+			want:  poll,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := newPollIntervalHook(tc.intervals)
+			h.now = func() time.Time { return now }
+
+			cr := &v1alpha1.Container{}
+			cr.Status.AtProvider.Phase = tc.phase
+			cr.Status.AtProvider.State = tc.state
+			if got := h.Interval(cr, poll); got != tc.want {
+				t.Errorf("Interval(v1alpha1) = %v, want %v", got, tc.want)
+			}
+
+			ncr := &v1beta1.Container{}
+			ncr.Status.AtProvider.Phase = tc.phase
+			ncr.Status.AtProvider.State = tc.state
+			if got := h.Interval(ncr, poll); got != tc.want {
+				t.Errorf("Interval(v1beta1) = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
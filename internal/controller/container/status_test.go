@@ -18,6 +18,7 @@ package container
 
 import (
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/go-connections/nat"
 	"github.com/google/go-cmp/cmp"
@@ -74,9 +75,74 @@ func TestUpdateStatus(t *testing.T) {
 			validateFunction: func(status *v1alpha1.ContainerStatus) bool {
 				return status.AtProvider.ID == "abc123" &&
 					status.AtProvider.State.Status == "running" &&
+					status.AtProvider.Phase == v1alpha1.PhaseRunning &&
 					status.AtProvider.Started != nil
 			},
 		},
+		{
+			name: "ClockCheckKept",
+			container: &v1alpha1.Container{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-container"},
+				Spec: v1alpha1.ContainerSpec{
+					ForProvider: v1alpha1.ContainerParameters{
+						Image:      "nginx:latest",
+						ClockCheck: &v1alpha1.ClockCheck{},
+					},
+				},
+				Status: v1alpha1.ContainerStatus{
+					AtProvider: v1alpha1.ContainerObservation{
+						ClockCheckedAt: &metav1.Time{Time: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)},
+						ClockOffset:    &metav1.Duration{Duration: 2 * time.Second},
+					},
+				},
+			},
+			containerInfo: &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:    "clk123",
+					Name:  "/test-container",
+					State: &container.State{Status: "running", Running: true},
+				},
+				Config: &container.Config{
+					Image: "nginx:latest",
+				},
+			},
+			validateFunction: func(status *v1alpha1.ContainerStatus) bool {
+				return status.AtProvider.ClockCheckedAt != nil &&
+					status.AtProvider.ClockCheckedAt.Equal(&metav1.Time{Time: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)}) &&
+					status.AtProvider.ClockOffset != nil && status.AtProvider.ClockOffset.Duration == 2*time.Second
+			},
+		},
+		{
+			name: "ContainerWithSecurityOpts",
+			container: &v1alpha1.Container{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-container"},
+				Spec: v1alpha1.ContainerSpec{
+					ForProvider: v1alpha1.ContainerParameters{
+						Image: "nginx:latest",
+					},
+				},
+			},
+			containerInfo: &container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:   "sec123",
+					Name: "/test-container",
+					State: &container.State{
+						Status:  "running",
+						Running: true,
+					},
+					HostConfig: &container.HostConfig{
+						SecurityOpt: []string{"no-new-privileges:true"},
+					},
+				},
+				Config: &container.Config{
+					Image: "nginx:latest",
+				},
+			},
+			validateFunction: func(status *v1alpha1.ContainerStatus) bool {
+				return len(status.AtProvider.SecurityOpts) == 1 &&
+					status.AtProvider.SecurityOpts[0] == "no-new-privileges:true"
+			},
+		},
 		{
 			name: "ExitedContainer",
 			container: &v1alpha1.Container{
@@ -108,6 +174,7 @@ func TestUpdateStatus(t *testing.T) {
 			validateFunction: func(status *v1alpha1.ContainerStatus) bool {
 				return status.AtProvider.ID == "def456" &&
 					status.AtProvider.State.Status == "exited" &&
+					status.AtProvider.Phase == v1alpha1.PhaseExited &&
 					status.AtProvider.State.ExitCode == 1
 			},
 		},
@@ -152,7 +219,8 @@ func TestUpdateStatus(t *testing.T) {
 			},
 			validateFunction: func(status *v1alpha1.ContainerStatus) bool {
 				return status.AtProvider.ID == "ghi789" &&
-					status.AtProvider.State.Status == "running"
+					status.AtProvider.State.Status == "running" &&
+					status.AtProvider.Phase == v1alpha1.PhaseRunning
 			},
 		},
 	}
@@ -170,6 +238,120 @@ func TestUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestBuildObservedEnvironment(t *testing.T) {
+	level, stale := "info", "stale"
+	spec := []v1alpha1.EnvVar{
+		{Name: "LEVEL", Value: &level},
+		{Name: "PASSWORD", ValueFrom: &v1alpha1.EnvVarSource{SecretKeyRef: &v1alpha1.SecretKeySelector{Name: "db", Key: "password"}}},
+		{Name: "THEME", ValueFrom: &v1alpha1.EnvVarSource{ConfigMapKeyRef: &v1alpha1.ConfigMapKeySelector{Name: "ui", Key: "theme"}}},
+		{Name: "TOKEN", ValueFrom: &v1alpha1.EnvVarSource{StoreKeyRef: &v1alpha1.StoreKeySelector{Store: "env", Key: "TOKEN"}}},
+		{Name: "OPTIONAL", ValueFrom: &v1alpha1.EnvVarSource{SecretKeyRef: &v1alpha1.SecretKeySelector{Name: "missing", Key: "key"}}},
+		{Name: "LEVEL", Value: &stale},
+	}
+	info := &container.InspectResponse{Config: &container.Config{Env: []string{
+		"PATH=/usr/bin",
+		"LEVEL=info",
+		"PASSWORD=s3cret",
+		"THEME=dark=mode",
+		"TOKEN=abc",
+	}}}
+
+	got := (&external{}).buildObservedEnvironment(spec, info)
+	want := []v1alpha1.ObservedEnvVar{
+		{Name: "PASSWORD", Origin: v1alpha1.EnvVarOriginSecret, Redacted: true},
+		{Name: "THEME", Value: "dark=mode", Origin: v1alpha1.EnvVarOriginConfigMap},
+		{Name: "TOKEN", Origin: v1alpha1.EnvVarOriginStore, Redacted: true},
+		{Name: "LEVEL", Value: "info", Origin: v1alpha1.EnvVarOriginValue},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("buildObservedEnvironment(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestObservedPhase(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  *container.State
+		expect v1alpha1.ContainerPhase
+	}{
+		{name: "NoState", state: nil, expect: v1alpha1.PhasePending},
+		{name: "Created", state: &container.State{Status: container.StateCreated}, expect: v1alpha1.PhasePending},
+		{name: "Running", state: &container.State{Status: container.StateRunning}, expect: v1alpha1.PhaseRunning},
+		{name: "HealthStarting", state: &container.State{Status: container.StateRunning, Health: &container.Health{Status: container.Starting}}, expect: v1alpha1.PhaseStarting},
+		{name: "Healthy", state: &container.State{Status: container.StateRunning, Health: &container.Health{Status: container.Healthy}}, expect: v1alpha1.PhaseRunning},
+		{name: "Unhealthy", state: &container.State{Status: container.StateRunning, Health: &container.Health{Status: container.Unhealthy}}, expect: v1alpha1.PhaseDegraded},
+		{name: "Restarting", state: &container.State{Status: container.StateRestarting}, expect: v1alpha1.PhaseStarting},
+		{name: "Paused", state: &container.State{Status: container.StatePaused}, expect: v1alpha1.PhaseDegraded},
+		{name: "Dead", state: &container.State{Status: container.StateDead}, expect: v1alpha1.PhaseDegraded},
+		{name: "Exited", state: &container.State{Status: container.StateExited}, expect: v1alpha1.PhaseExited},
+		{name: "Removing", state: &container.State{Status: container.StateRemoving}, expect: v1alpha1.PhaseRemoving},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{State: tt.state}}
+			if got := observedPhase(info); got != tt.expect {
+				t.Errorf("observedPhase() = %q, want %q", got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestSetPhase(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   v1alpha1.ContainerPhase
+		to     v1alpha1.ContainerPhase
+		expect bool
+	}{
+		{name: "NewToCreating", from: "", to: v1alpha1.PhaseCreating, expect: true},
+		{name: "ExitedToCreating", from: v1alpha1.PhaseExited, to: v1alpha1.PhaseCreating, expect: true},
+		{name: "CreatingToPulling", from: v1alpha1.PhaseCreating, to: v1alpha1.PhasePulling, expect: true},
+		{name: "CreatingToStarting", from: v1alpha1.PhaseCreating, to: v1alpha1.PhaseStarting, expect: true},
+		{name: "PullingToStarting", from: v1alpha1.PhasePulling, to: v1alpha1.PhaseStarting, expect: false},
+		{name: "RunningToPending", from: v1alpha1.PhaseRunning, to: v1alpha1.PhasePending, expect: false},
+		{name: "RunningToRemoving", from: v1alpha1.PhaseRunning, to: v1alpha1.PhaseRemoving, expect: true},
+		{name: "RemovingToCreating", from: v1alpha1.PhaseRemoving, to: v1alpha1.PhaseCreating, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1alpha1.Container{}
+			cr.Status.AtProvider.Phase = tt.from
+
+			if got := setPhase(cr, tt.to); got != tt.expect {
+				t.Errorf("setPhase() = %v, want %v", got, tt.expect)
+			}
+
+			want := tt.from
+			if tt.expect {
+				want = tt.to
+			}
+			if cr.Status.AtProvider.Phase != want {
+				t.Errorf("setPhase() left phase %q, want %q", cr.Status.AtProvider.Phase, want)
+			}
+		})
+	}
+}
+
+func TestNextPhaseWhileRemoving(t *testing.T) {
+	info := &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+		State: &container.State{Status: container.StateRunning},
+	}}
+
+	cr := &v1alpha1.Container{}
+	cr.Status.AtProvider.Phase = v1alpha1.PhaseRemoving
+	if got := nextPhase(cr, info); got != v1alpha1.PhaseRunning {
+		t.Errorf("nextPhase() without deletion = %q, want %q", got, v1alpha1.PhaseRunning)
+	}
+
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+	if got := nextPhase(cr, info); got != v1alpha1.PhaseRemoving {
+		t.Errorf("nextPhase() while deleted = %q, want %q", got, v1alpha1.PhaseRemoving)
+	}
+}
+
 func TestIsUpToDate(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -646,6 +828,43 @@ func TestBuildObservedNetworks(t *testing.T) {
 	}
 }
 
+func TestBuildObservedMounts(t *testing.T) {
+	tests := []struct {
+		name          string
+		containerInfo *container.InspectResponse
+		expected      []v1alpha1.MountInfo
+	}{
+		{
+			name:          "NoMounts",
+			containerInfo: &container.InspectResponse{},
+		},
+		{
+			name: "VolumeBindAndTmpfs",
+			containerInfo: &container.InspectResponse{
+				Mounts: []container.MountPoint{
+					{Type: mount.TypeVolume, Name: "data", Source: "/var/lib/docker/volumes/data/_data", Destination: "/data", Driver: "local", RW: true},
+					{Type: mount.TypeBind, Source: "/etc/app", Destination: "/config", RW: false},
+					{Type: mount.TypeTmpfs, Destination: "/tmp", RW: true},
+				},
+			},
+			expected: []v1alpha1.MountInfo{
+				{Type: "volume", Name: "data", Source: "/var/lib/docker/volumes/data/_data", Destination: "/data", Driver: "local", RW: true},
+				{Type: "bind", Source: "/etc/app", Destination: "/config"},
+				{Type: "tmpfs", Destination: "/tmp", RW: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &external{}
+			if diff := cmp.Diff(tt.expected, e.buildObservedMounts(tt.containerInfo)); diff != "" {
+				t.Errorf("buildObservedMounts() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestIsNotFound(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/docker/docker/api/types/system"
+	"maps"
+	"strings"
+)
+
+// storageOptUnsupported returns why the storage driver of a Docker host does
+// not support storage options, or "" if it does. overlay2 and vfs only
+// support them on XFS, which must also be mounted with project quotas.
+func storageOptUnsupported(info system.Info) string {
+	switch info.Driver {
+	case "btrfs", "zfs", "devicemapper", "windowsfilter":
+		return ""
+	case "overlay2", "vfs":
+		fs := backingFilesystem(info)
+		if fs == "xfs" {
+			return ""
+		}
+		return "storage driver " + info.Driver + " supports storage options only on XFS, and the Docker host's backing filesystem is " + fs
+	case "":
+		return "the Docker host did not report its storage driver"
+	}
+	return "storage driver " + info.Driver + " of the Docker host does not support storage options"
+}
+
+// backingFilesystem returns the filesystem a storage driver stores layers
+// on, as the driver reports it.
+func backingFilesystem(info system.Info) string {
+	for _, status := range info.DriverStatus {
+		if status[0] == "Backing Filesystem" {
+			return strings.ToLower(status[1])
+		}
+	}
+	return "unknown"
+}
+
+// storageOptMatch reports whether a container has the storage options its
+// spec sets.
+func storageOptMatch(want, have map[string]string) bool {
+	return maps.Equal(want, have)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"testing"
+)
+
+func TestStorageOptDrift(t *testing.T) {
+	cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{
+		Image:      "postgres:16",
+		StorageOpt: map[string]string{"size": "20G"},
+	}}}
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			State:      &container.State{Running: true},
+			HostConfig: &container.HostConfig{StorageOpt: map[string]string{"size": "10G"}},
+		},
+		Config: &container.Config{Image: "postgres:16"},
+	}
+	c := &external{}
+	if c.isUpToDate(cr, info) {
+		t.Error("isUpToDate() = true for a container created with another size")
+	}
+	info.HostConfig.StorageOpt["size"] = "20G"
+	if !c.isUpToDate(cr, info) {
+		t.Error("isUpToDate() = false for a container created with the size its spec sets")
+	}
+}
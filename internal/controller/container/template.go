@@ -0,0 +1,187 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"maps"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+)
+
+const (
+	errGetTemplate   = "cannot get ContainerTemplate"
+	errMergeTemplate = "cannot merge ContainerTemplate"
+	errNoImage       = "image must be set by the container or its template"
+)
+
+// templateListKeys are the list fields of a container that are merged with
+// its template's rather than replacing them, and the fields that identify
+// their items.
+var templateListKeys = map[string][]string{
+	"environment":       {"name"},
+	"ports":             {"containerPort"},
+	"volumes":           {"mountPath"},
+	"networks":          {"name"},
+	"postStartCommands": {"name"},
+	"postConditions":    {"name"},
+	"dependsOn":         {"kind", "name"},
+}
+
+// getTemplate returns the configuration of the ContainerTemplate a container
+// is created from, or nil if it is not created from one.
+func getTemplate(ctx context.Context, kube client.Reader, namespace string, params *v1alpha1.ContainerParameters) (*v1alpha1.ContainerParameters, error) {
+	if params.FromTemplateRef == nil {
+		return nil, nil
+	}
+	t := &v1alpha1.ContainerTemplate{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: params.FromTemplateRef.Name}, t); err != nil {
+		return nil, errors.Wrap(err, errGetTemplate)
+	}
+	return &t.Spec.Template, nil
+}
+
+// templatedParameters returns the configuration of a container overlaid on
+// its template, if it has one.
+func templatedParameters(template, params *v1alpha1.ContainerParameters) (*v1alpha1.ContainerParameters, error) {
+	if template != nil {
+		return mergeTemplate(template, params)
+	}
+	if params.Image == "" {
+		return nil, errors.New(errNoImage)
+	}
+	return params, nil
+}
+
+// withTemplate fills in the spec of a container from its template for the
+// duration of an operation. The returned function restores the spec, so that
+// the template is never written back to the container and later changes to
+// it still apply.
+func (c *external) withTemplate(cr *v1alpha1.Container) (func(), error) {
+	if c.template == nil {
+		return func() {}, nil
+	}
+	original := cr.Spec.ForProvider
+	merged, err := mergeTemplate(c.template, &original)
+	if err != nil {
+		return nil, err
+	}
+	cr.Spec.ForProvider = *merged
+	return func() { cr.Spec.ForProvider = original }, nil
+}
+
+// mergeTemplate returns a container's configuration overlaid on its template.
+// Fields set on the container replace the template's, except that maps are
+// merged key by key and the lists in templateListKeys item by item. The
+// template's name is never used, since each container needs its own.
+func mergeTemplate(template, params *v1alpha1.ContainerParameters) (*v1alpha1.ContainerParameters, error) {
+	base, err := toJSONMap(template)
+	if err != nil {
+		return nil, err
+	}
+	over, err := toJSONMap(params)
+	if err != nil {
+		return nil, err
+	}
+	delete(base, "name")
+	delete(base, "fromTemplateRef")
+
+	for field, value := range over {
+		if keys, ok := templateListKeys[field]; ok {
+			base[field] = mergeList(base[field], value, keys)
+			continue
+		}
+		base[field] = mergeValue(base[field], value)
+	}
+
+	b, err := json.Marshal(base)
+	if err != nil {
+		return nil, errors.Wrap(err, errMergeTemplate)
+	}
+	merged := &v1alpha1.ContainerParameters{}
+	if err := json.Unmarshal(b, merged); err != nil {
+		return nil, errors.Wrap(err, errMergeTemplate)
+	}
+	if merged.Image == "" {
+		return nil, errors.New(errNoImage)
+	}
+	return merged, nil
+}
+
+// toJSONMap returns the JSON object form of a container's configuration.
+func toJSONMap(params *v1alpha1.ContainerParameters) (map[string]any, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil, errors.Wrap(err, errMergeTemplate)
+	}
+	m := map[string]any{}
+	return m, errors.Wrap(json.Unmarshal(b, &m), errMergeTemplate)
+}
+
+// mergeValue overlays a value on a template's, merging objects key by key.
+func mergeValue(base, over any) any {
+	b, ok := base.(map[string]any)
+	o, isMap := over.(map[string]any)
+	if !ok || !isMap {
+		return over
+	}
+	merged := maps.Clone(b)
+	for k, v := range o {
+		merged[k] = mergeValue(b[k], v)
+	}
+	return merged
+}
+
+// mergeList overlays the items of a list on a template's. Items are matched
+// by the given keys; matching items replace the template's in place, and the
+// rest are appended.
+func mergeList(base, over any, keys []string) any {
+	b, _ := base.([]any)
+	o, ok := over.([]any)
+	if !ok {
+		return over
+	}
+
+	merged := append([]any{}, b...)
+	index := make(map[string]int, len(merged))
+	for i, item := range merged {
+		index[itemKey(item, keys)] = i
+	}
+	for _, item := range o {
+		if i, ok := index[itemKey(item, keys)]; ok {
+			merged[i] = item
+			continue
+		}
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+// itemKey identifies a list item by the values of the given keys.
+func itemKey(item any, keys []string) string {
+	m, _ := item.(map[string]any)
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = fmt.Sprint(m[k])
+	}
+	return strings.Join(values, "/")
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"reflect"
+	"testing"
+)
+
+func TestMergeTemplate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	port := func(p int32) *int32 { return &p }
+
+	template := &v1alpha1.ContainerParameters{
+		Image:         "registry.local/agent:1.4",
+		Name:          str("template"),
+		RestartPolicy: str("unless-stopped"),
+		Environment: []v1alpha1.EnvVar{
+			{Name: "LOG_LEVEL", Value: str("info")},
+			{Name: "REGION", Value: str("eu")},
+		},
+		Ports:  []v1alpha1.PortSpec{{ContainerPort: 9100, HostPort: port(9100)}},
+		Labels: map[string]string{"fleet": "edge", "tier": "agent"},
+	}
+	params := &v1alpha1.ContainerParameters{
+		Name: str("agent-site-7"),
+		Environment: []v1alpha1.EnvVar{
+			{Name: "REGION", Value: str("us")},
+			{Name: "SITE", Value: str("7")},
+		},
+		Ports:  []v1alpha1.PortSpec{{ContainerPort: 9100, HostPort: port(19107)}},
+		Labels: map[string]string{"site": "7"},
+	}
+
+	got, err := mergeTemplate(template, params)
+	if err != nil {
+		t.Fatalf("mergeTemplate() error = %v", err)
+	}
+
+	if got.Image != "registry.local/agent:1.4" {
+		t.Errorf("Image = %q, want the template's", got.Image)
+	}
+	if got.Name == nil || *got.Name != "agent-site-7" {
+		t.Errorf("Name = %v, want agent-site-7", got.Name)
+	}
+	if got.RestartPolicy == nil || *got.RestartPolicy != "unless-stopped" {
+		t.Errorf("RestartPolicy = %v, want the template's", got.RestartPolicy)
+	}
+
+	env := map[string]string{}
+	for _, e := range got.Environment {
+		env[e.Name] = *e.Value
+	}
+	if want := map[string]string{"LOG_LEVEL": "info", "REGION": "us", "SITE": "7"}; !reflect.DeepEqual(env, want) {
+		t.Errorf("Environment = %v, want %v", env, want)
+	}
+	if len(got.Ports) != 1 || *got.Ports[0].HostPort != 19107 {
+		t.Errorf("Ports = %+v, want the container's port 9100 only", got.Ports)
+	}
+	if want := map[string]string{"fleet": "edge", "tier": "agent", "site": "7"}; !reflect.DeepEqual(got.Labels, want) {
+		t.Errorf("Labels = %v, want %v", got.Labels, want)
+	}
+}
+
+func TestMergeTemplateNoImage(t *testing.T) {
+	if _, err := mergeTemplate(&v1alpha1.ContainerParameters{}, &v1alpha1.ContainerParameters{}); err == nil {
+		t.Error("mergeTemplate() error = nil, want an error for a missing image")
+	}
+}
+
+func TestWithTemplate(t *testing.T) {
+	name := "web-1"
+	cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: v1alpha1.ContainerParameters{Name: &name}}}
+	e := &external{template: &v1alpha1.ContainerParameters{Image: "nginx:1.27"}}
+
+	restore, err := e.withTemplate(cr)
+	if err != nil {
+		t.Fatalf("withTemplate() error = %v", err)
+	}
+	if cr.Spec.ForProvider.Image != "nginx:1.27" {
+		t.Errorf("Image during operation = %q, want the template's", cr.Spec.ForProvider.Image)
+	}
+
+	restore()
+	if cr.Spec.ForProvider.Image != "" || cr.Spec.ForProvider.Name != &name {
+		t.Errorf("ForProvider after operation = %+v, want it restored", cr.Spec.ForProvider)
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strconv"
+)
+
+const (
+	// defaultTerminationMessageBytes is the most of a termination message
+	// kept when the container does not say, as for a pod.
+	defaultTerminationMessageBytes = 4096
+
+	// terminationLogLines is how many lines of logs a termination message
+	// is taken from, as for a pod's FallbackToLogsOnError.
+	terminationLogLines = 80
+
+	// defaultCaptureLogLines and defaultCaptureLogBytes bound the logs of a
+	// failed container that are captured when it does not say.
+	defaultCaptureLogLines = 50
+	defaultCaptureLogBytes = 4096
+
+	// maxLogEventBytes bounds the logs attached to an event, which the
+	// events API truncates at 1kB.
+	maxLogEventBytes = 768
+
+	// Why the logs of a container were captured.
+	logTailExited    = "Exited"
+	logTailUnhealthy = "Unhealthy"
+
+	reasonContainerFailed event.Reason = "ContainerFailed"
+)
+
+// captureTerminationMessage records why a container exited, once each time
+// it exits, from the file its termination message is written to or else the
+// tail of its logs. A message that cannot be read is left empty rather than
+// failing the observation.
+func (c *external) captureTerminationMessage(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse, previousFinish *metav1.Time) {
+	tm := cr.Spec.ForProvider.TerminationMessage
+	obs := &cr.Status.AtProvider
+	if tm == nil {
+		obs.TerminationMessage = ""
+		return
+	}
+	if info.State.Status != "exited" && info.State.Status != "dead" {
+		return
+	}
+	finished := obs.State.FinishedAt
+	if finished == nil || (previousFinish != nil && previousFinish.Equal(finished)) {
+		return
+	}
+
+	limit := defaultTerminationMessageBytes
+	if tm.MaxBytes != nil && *tm.MaxBytes > 0 {
+		limit = int(*tm.MaxBytes)
+	}
+
+	obs.TerminationMessage = ""
+	if tm.Path != nil && *tm.Path != "" {
+		msg, err := c.readTerminationFile(ctx, info.ID, *tm.Path, limit)
+		if err != nil {
+			c.logger.Debug("Cannot read termination message file", "container", cr.Name, "path", *tm.Path, "error", err)
+		}
+		if msg != "" {
+			obs.TerminationMessage = msg
+			return
+		}
+	}
+
+	msg, err := c.readLogTail(ctx, info, terminationLogLines, limit)
+	if err != nil {
+		c.logger.Debug("Cannot read termination message from logs", "container", cr.Name, "error", err)
+		return
+	}
+	obs.TerminationMessage = msg
+}
+
+// captureFailureLogs records the tail of a container's logs in its status,
+// and in a warning event, once each time it exits non-zero or becomes
+// unhealthy. Logs that cannot be read are not captured rather than failing
+// the observation.
+func (c *external) captureFailureLogs(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse, previous v1alpha1.ContainerState) {
+	cl := cr.Spec.ForProvider.CaptureLogs
+	obs := &cr.Status.AtProvider
+	if cl == nil {
+		obs.LastLogTail = nil
+		return
+	}
+	reason, msg := failure(info, obs.State, previous)
+	if reason == "" {
+		return
+	}
+
+	lines, limit := defaultCaptureLogLines, defaultCaptureLogBytes
+	if cl.TailLines != nil && *cl.TailLines > 0 {
+		lines = int(*cl.TailLines)
+	}
+	if cl.MaxBytes != nil && *cl.MaxBytes > 0 {
+		limit = int(*cl.MaxBytes)
+	}
+	tail, err := c.readLogTail(ctx, info, lines, limit)
+	if err != nil {
+		c.logger.Debug("Cannot capture the logs of a failed container", "container", cr.Name, "error", err)
+		return
+	}
+
+	obs.LastLogTail = &v1alpha1.LogTail{Reason: reason, CapturedAt: metav1.Now(), Lines: tail}
+	if tail != "" {
+		if len(tail) > maxLogEventBytes {
+			tail = "..." + tail[len(tail)-maxLogEventBytes:]
+		}
+		msg += "; last log lines:\n" + tail
+	}
+	c.record(cr, event.Warning(reasonContainerFailed, errors.New(msg)))
+}
+
+// failure returns why a container has newly failed, and a message saying
+// so, or nothing if it has not: it has exited non-zero since it was last
+// observed, or has become unhealthy.
+func failure(info *container.InspectResponse, state, previous v1alpha1.ContainerState) (string, string) {
+	if info.State == nil {
+		return "", ""
+	}
+	exited := info.State.Status == container.StateExited || info.State.Status == container.StateDead
+	if exited && info.State.ExitCode != 0 && state.FinishedAt != nil &&
+		(previous.FinishedAt == nil || !previous.FinishedAt.Equal(state.FinishedAt)) {
+		return logTailExited, fmt.Sprintf("Container exited with code %d", info.State.ExitCode)
+	}
+	unhealthy := func(h *v1alpha1.ContainerHealth) bool { return h != nil && h.Status == container.Unhealthy }
+	if unhealthy(state.Health) && !unhealthy(previous.Health) {
+		return logTailUnhealthy, "Container became unhealthy"
+	}
+	return "", ""
+}
+
+// readTerminationFile reads at most limit bytes of a file in a container.
+func (c *external) readTerminationFile(ctx context.Context, id, path string, limit int) (string, error) {
+	rc, _, err := c.client.CopyFromContainer(ctx, id, path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rc.Close() }()
+
+	// The file is returned as the only entry of a tar archive
+	tr := tar.NewReader(rc)
+	if _, err := tr.Next(); err != nil {
+		return "", err
+	}
+	b, err := io.ReadAll(io.LimitReader(tr, int64(limit)))
+	return string(b), err
+}
+
+// readLogTail returns the last limit bytes of the last lines a container
+// wrote to stdout and stderr.
+func (c *external) readLogTail(ctx context.Context, info *container.InspectResponse, lines, limit int) (string, error) {
+	logs, err := c.client.ContainerLogs(ctx, info.ID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.Itoa(lines),
+	})
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = logs.Close() }()
+
+	// Containers without a TTY interleave stdout and stderr in one stream
+	var buf bytes.Buffer
+	if info.Config == nil || !info.Config.Tty {
+		_, err = stdcopy.StdCopy(&buf, &buf, logs)
+	} else {
+		_, err = io.Copy(&buf, logs)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	b := buf.Bytes()
+	if len(b) > limit {
+		b = b[len(b)-limit:]
+	}
+	return string(b), nil
+}
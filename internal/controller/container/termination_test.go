@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestCaptureTerminationMessage(t *testing.T) {
+	finished := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	earlier := metav1.NewTime(finished.Add(-time.Hour))
+
+	tarFile := func(content string) io.ReadCloser {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		_ = tw.WriteHeader(&tar.Header{Name: "termination-log", Mode: 0o644, Size: int64(len(content))})
+		_, _ = tw.Write([]byte(content))
+		_ = tw.Close()
+		return io.NopCloser(&buf)
+	}
+	logs := func(content string) io.ReadCloser {
+		var buf bytes.Buffer
+		w := stdcopy.NewStdWriter(&buf, stdcopy.Stderr)
+		_, _ = w.Write([]byte(content))
+		return io.NopCloser(&buf)
+	}
+
+	path := "/dev/termination-log"
+	tests := []struct {
+		name     string
+		tm       *v1alpha1.TerminationMessage
+		status   string
+		previous *metav1.Time
+		existing string
+		file     string
+		logs     string
+		want     string
+	}{
+		{
+			name:     "NotCaptured",
+			status:   "exited",
+			existing: "stale",
+			logs:     "panic: boom\n",
+		},
+		{
+			name:   "StillRunning",
+			tm:     &v1alpha1.TerminationMessage{},
+			status: "running",
+			logs:   "starting\n",
+		},
+		{
+			name:   "FromLogs",
+			tm:     &v1alpha1.TerminationMessage{},
+			status: "exited",
+			logs:   "panic: boom\n",
+			want:   "panic: boom\n",
+		},
+		{
+			name:   "LogsTruncatedToTail",
+			tm:     &v1alpha1.TerminationMessage{MaxBytes: int32Ptr(5)},
+			status: "exited",
+			logs:   "panic: boom\n",
+			want:   "boom\n",
+		},
+		{
+			name:   "FromFile",
+			tm:     &v1alpha1.TerminationMessage{Path: &path},
+			status: "exited",
+			file:   "database unreachable",
+			logs:   "panic: boom\n",
+			want:   "database unreachable",
+		},
+		{
+			name:   "MissingFileFallsBackToLogs",
+			tm:     &v1alpha1.TerminationMessage{Path: &path},
+			status: "exited",
+			logs:   "panic: boom\n",
+			want:   "panic: boom\n",
+		},
+		{
+			name:     "SameExitNotRecaptured",
+			tm:       &v1alpha1.TerminationMessage{},
+			status:   "exited",
+			previous: &finished,
+			existing: "first",
+			logs:     "second\n",
+			want:     "first",
+		},
+		{
+			name:     "NewExitRecaptured",
+			tm:       &v1alpha1.TerminationMessage{},
+			status:   "exited",
+			previous: &earlier,
+			existing: "first",
+			logs:     "second\n",
+			want:     "second\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &external{
+				logger: logging.NewNopLogger(),
+				client: &mockDockerClient{
+					copyFromContainerFunc: func(_ context.Context, _, _ string) (io.ReadCloser, container.PathStat, error) {
+						if tt.file == "" {
+							return nil, container.PathStat{}, errors.New("no such file")
+						}
+						return tarFile(tt.file), container.PathStat{}, nil
+					},
+					containerLogsFunc: func(_ context.Context, _ string, _ container.LogsOptions) (io.ReadCloser, error) {
+						return logs(tt.logs), nil
+					},
+				},
+			}
+			cr := &v1alpha1.Container{}
+			cr.Spec.ForProvider.TerminationMessage = tt.tm
+			cr.Status.AtProvider.State.FinishedAt = &finished
+			cr.Status.AtProvider.TerminationMessage = tt.existing
+			info := &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				ID:    "abc",
+				State: &container.State{Status: tt.status},
+			}}
+
+			c.captureTerminationMessage(context.Background(), cr, info, tt.previous)
+			if got := cr.Status.AtProvider.TerminationMessage; got != tt.want {
+				t.Errorf("TerminationMessage = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaptureFailureLogs(t *testing.T) {
+	finished := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	unhealthy := &v1alpha1.ContainerHealth{Status: container.Unhealthy}
+	healthy := &v1alpha1.ContainerHealth{Status: container.Healthy}
+
+	tests := []struct {
+		name       string
+		cl         *v1alpha1.CaptureLogs
+		status     container.ContainerState
+		exitCode   int
+		health     *v1alpha1.ContainerHealth
+		previous   v1alpha1.ContainerState
+		logs       string
+		wantReason string
+		wantLines  string
+		wantEvent  string
+	}{
+		{
+			name:     "NotCaptured",
+			status:   container.StateExited,
+			exitCode: 1,
+			logs:     "panic: boom\n",
+		},
+		{
+			name:       "ExitedNonZero",
+			cl:         &v1alpha1.CaptureLogs{},
+			status:     container.StateExited,
+			exitCode:   2,
+			logs:       "connecting\npanic: boom\n",
+			wantReason: "Exited",
+			wantLines:  "connecting\npanic: boom\n",
+			wantEvent:  "Container exited with code 2; last log lines:\nconnecting\npanic: boom\n",
+		},
+		{
+			name:   "ExitedZero",
+			cl:     &v1alpha1.CaptureLogs{},
+			status: container.StateExited,
+			logs:   "done\n",
+		},
+		{
+			name:     "SameExitNotRecaptured",
+			cl:       &v1alpha1.CaptureLogs{},
+			status:   container.StateExited,
+			exitCode: 1,
+			previous: v1alpha1.ContainerState{FinishedAt: &finished},
+			logs:     "panic: boom\n",
+		},
+		{
+			name:       "BecameUnhealthy",
+			cl:         &v1alpha1.CaptureLogs{MaxBytes: int32Ptr(8)},
+			status:     container.StateRunning,
+			health:     unhealthy,
+			previous:   v1alpha1.ContainerState{Health: healthy},
+			logs:       "GET /healthz 503\n",
+			wantReason: "Unhealthy",
+			wantLines:  "thz 503\n",
+			wantEvent:  "Container became unhealthy; last log lines:\nthz 503\n",
+		},
+		{
+			name:     "StillUnhealthy",
+			cl:       &v1alpha1.CaptureLogs{},
+			status:   container.StateRunning,
+			health:   unhealthy,
+			previous: v1alpha1.ContainerState{Health: unhealthy},
+			logs:     "GET /healthz 503\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tail string
+			rec := &eventRecorder{}
+			c := &external{
+				logger:   logging.NewNopLogger(),
+				recorder: rec,
+				client: &mockDockerClient{
+					containerLogsFunc: func(_ context.Context, _ string, opts container.LogsOptions) (io.ReadCloser, error) {
+						tail = opts.Tail
+						var buf bytes.Buffer
+						_, _ = stdcopy.NewStdWriter(&buf, stdcopy.Stdout).Write([]byte(tt.logs))
+						return io.NopCloser(&buf), nil
+					},
+				},
+			}
+			cr := &v1alpha1.Container{}
+			cr.Spec.ForProvider.CaptureLogs = tt.cl
+			cr.Status.AtProvider.State = v1alpha1.ContainerState{FinishedAt: &finished, Health: tt.health}
+			info := &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				ID:    "abc",
+				State: &container.State{Status: tt.status, ExitCode: tt.exitCode},
+			}}
+
+			c.captureFailureLogs(context.Background(), cr, info, tt.previous)
+			got := cr.Status.AtProvider.LastLogTail
+			if tt.wantReason == "" {
+				if got != nil || len(rec.events) > 0 {
+					t.Errorf("captureFailureLogs() captured %+v with %d events, want nothing", got, len(rec.events))
+				}
+				return
+			}
+			if got == nil || got.Reason != tt.wantReason || got.Lines != tt.wantLines {
+				t.Fatalf("LastLogTail = %+v, want %s with %q", got, tt.wantReason, tt.wantLines)
+			}
+			if tail != "50" {
+				t.Errorf("captureFailureLogs() read a tail of %q lines, want the default 50", tail)
+			}
+			if len(rec.events) != 1 || rec.events[0].Message != tt.wantEvent || rec.events[0].Type != event.TypeWarning {
+				t.Errorf("captureFailureLogs() events = %+v, want a warning %q", rec.events, tt.wantEvent)
+			}
+		})
+	}
+}
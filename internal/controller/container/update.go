@@ -0,0 +1,292 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/internal/shutdown"
+)
+
+const (
+	errInspectUpdate      = "cannot inspect container to update"
+	errUpdateInPlace      = "cannot update container in place"
+	errNotInPlace         = "container has changes that cannot be made in place, and its update strategy is InPlace"
+	errRecreateNotAllowed = "management policies do not permit recreating the container"
+	errRecreateBuild      = "cannot build configuration to recreate container"
+	errRecreateStop       = "cannot stop container to recreate it"
+	errRecreateRemove     = "cannot remove container to recreate it"
+	errRecreatePullImage  = "cannot pull image to recreate container"
+
+	// minCPUShares is the fewest CPU shares the kubelet gives a container
+	// that requests CPU.
+	minCPUShares = 2
+)
+
+// updateStrategyOf returns how a drifted container is updated.
+func updateStrategyOf(cr *v1alpha1.Container) v1alpha1.UpdateStrategy {
+	if s := cr.Spec.ForProvider.UpdateStrategy; s != nil && *s != "" {
+		return *s
+	}
+	return v1alpha1.UpdateRecreate
+}
+
+// resourceLimits returns the resources a container is limited to and
+// requests, as Docker configures them.
+func resourceLimits(resources *v1alpha1.ResourceRequirements) (container.Resources, error) {
+	var r container.Resources
+	if resources == nil {
+		return r, nil
+	}
+	if memory, ok := resources.Limits["memory"]; ok {
+		bytes, err := parseByteSize(memory.String())
+		if err != nil {
+			return r, errors.Wrap(err, "spec.forProvider.resources.limits.memory")
+		}
+		r.Memory = bytes
+	}
+	if cpu, ok := resources.Limits["cpu"]; ok {
+		nanos, err := parseCPU(cpu.String())
+		if err != nil {
+			return r, errors.Wrap(err, "spec.forProvider.resources.limits.cpu")
+		}
+		r.NanoCPUs = nanos
+	}
+	if memory, ok := resources.Requests["memory"]; ok && protectsWorkingSet(resources) {
+		bytes, err := parseByteSize(memory.String())
+		if err != nil {
+			return r, errors.Wrap(err, "spec.forProvider.resources.requests.memory")
+		}
+		r.MemoryReservation = bytes
+	}
+	if cpu, ok := resources.Requests["cpu"]; ok {
+		nanos, err := parseCPU(cpu.String())
+		if err != nil {
+			return r, errors.Wrap(err, "spec.forProvider.resources.requests.cpu")
+		}
+		r.CPUShares = cpuShares(nanos)
+	}
+	if swap := resources.MemorySwap; swap != nil {
+		if swap.String() == "-1" {
+			r.MemorySwap = -1
+		} else {
+			bytes, err := parseByteSize(swap.String())
+			if err != nil {
+				return r, errors.Wrap(err, "spec.forProvider.resources.memorySwap")
+			}
+			if bytes < r.Memory {
+				return r, errors.New("spec.forProvider.resources.memorySwap: must be at least the memory limit")
+			}
+			r.MemorySwap = bytes
+		}
+	}
+	if resources.PidsLimit != nil {
+		pids := *resources.PidsLimit
+		r.PidsLimit = &pids
+	}
+	if w := resources.BlkioWeight; w != nil {
+		if *w < 10 || *w > 1000 {
+			return r, errors.Errorf("spec.forProvider.resources.blkioWeight: %d is not from 10 to 1000", *w)
+		}
+		r.BlkioWeight = uint16(*w)
+	}
+	return r, nil
+}
+
+// cpuShares converts billionths of a CPU requested to CPU shares the way
+// the kubelet does, at 1024 shares a CPU and no fewer than 2.
+func cpuShares(nanos int64) int64 {
+	return max(nanos*1024/1_000_000_000, minCPUShares)
+}
+
+// pidsLimit returns the process limit of a container, with 0 meaning
+// unlimited, as Docker treats zero and negative limits.
+func pidsLimit(limit *int64) int64 {
+	if limit == nil || *limit < 0 {
+		return 0
+	}
+	return *limit
+}
+
+// setLimits sets the resources a container's spec configures. Memory swap
+// is left to Docker's default unless the spec sets it.
+func setLimits(r *container.Resources, limits container.Resources) {
+	r.Memory = limits.Memory
+	r.NanoCPUs = limits.NanoCPUs
+	r.MemoryReservation = limits.MemoryReservation
+	r.CPUShares = limits.CPUShares
+	r.PidsLimit = limits.PidsLimit
+	r.BlkioWeight = limits.BlkioWeight
+	if limits.MemorySwap != 0 {
+		r.MemorySwap = limits.MemorySwap
+	}
+}
+
+// limitsMatch reports whether a container has the resource limits and
+// requests it should.
+func limitsMatch(want, have container.Resources) bool {
+	return want.Memory == have.Memory && want.NanoCPUs == have.NanoCPUs &&
+		want.MemoryReservation == have.MemoryReservation && want.CPUShares == have.CPUShares &&
+		pidsLimit(want.PidsLimit) == pidsLimit(have.PidsLimit) && want.BlkioWeight == have.BlkioWeight &&
+		(want.MemorySwap == 0 || want.MemorySwap == have.MemorySwap)
+}
+
+// inPlaceUpdate returns the update that brings the restart policy and
+// resource limits of a container in line with its spec, and whether any of
+// them differ.
+func inPlaceUpdate(cr *v1alpha1.Container, info *container.InspectResponse) (container.UpdateConfig, bool, error) {
+	have := info.HostConfig
+	if have == nil {
+		have = &container.HostConfig{}
+	}
+	update := container.UpdateConfig{
+		Resources:     have.Resources,
+		RestartPolicy: have.RestartPolicy,
+	}
+
+	if p := cr.Spec.ForProvider.RestartPolicy; p != nil {
+		update.RestartPolicy = container.RestartPolicy{Name: container.RestartPolicyMode(*p)}
+		if n := cr.Spec.ForProvider.MaximumRetryCount; n != nil {
+			update.RestartPolicy.MaximumRetryCount = *n
+		}
+	}
+	if cr.Spec.ForProvider.Resources != nil {
+		limits, err := resourceLimits(cr.Spec.ForProvider.Resources)
+		if err != nil {
+			return container.UpdateConfig{}, false, err
+		}
+		setLimits(&update.Resources, limits)
+	}
+
+	changed := update.RestartPolicy != have.RestartPolicy || !limitsMatch(update.Resources, have.Resources)
+	return update, changed, nil
+}
+
+// needsRecreate reports whether a container differs from its spec in ways
+// that updating it in place would not repair. A container that is only
+// unhealthy does not need recreating, as repairing it is left to its
+// remediation policy.
+func (c *external) needsRecreate(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse, update container.UpdateConfig) (bool, error) {
+	base := *info.ContainerJSONBase
+	updated := *info
+	updated.ContainerJSONBase = &base
+	base.State = nil
+	if info.HostConfig != nil {
+		hc := *info.HostConfig
+		hc.RestartPolicy = update.RestartPolicy
+		hc.Resources = update.Resources
+		base.HostConfig = &hc
+	}
+	if !c.isUpToDate(cr, &updated) {
+		return true, nil
+	}
+	upToDate, err := c.arePeersUpToDate(ctx, cr, info)
+	return !upToDate, err
+}
+
+// update brings a drifted container in line with its spec, as its update
+// strategy says. It returns true if the container was removed so that it
+// can be created afresh.
+func (c *external) update(ctx context.Context, cr *v1alpha1.Container) (bool, error) {
+	strategy := updateStrategyOf(cr)
+	if strategy == v1alpha1.UpdateNever {
+		return false, nil
+	}
+
+	restore, err := c.withTemplate(cr)
+	if err != nil {
+		return false, err
+	}
+	defer restore()
+
+	done, err := shutdown.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer done()
+
+	id := meta.GetExternalName(cr)
+	info, err := c.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return false, errors.Wrap(err, errInspectUpdate)
+	}
+	if info.ContainerJSONBase == nil || info.Config == nil {
+		return false, errors.New(errInspectUpdate)
+	}
+	defer c.snapshots.Forget(c.host, id)
+
+	update, changed, err := inPlaceUpdate(cr, &info)
+	if err != nil {
+		return false, err
+	}
+	recreate, err := c.needsRecreate(ctx, cr, &info, update)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case recreate && strategy == v1alpha1.UpdateInPlace:
+		return false, errors.New(errNotInPlace)
+	case recreate:
+		return true, c.removeForRecreate(ctx, cr, &info)
+	case changed:
+		c.logger.Debug("Updating container in place", "container", cr.Name, "id", info.ID)
+		if _, err := c.client.ContainerUpdate(ctx, info.ID, update); err != nil {
+			return false, errors.Wrap(err, errUpdateInPlace)
+		}
+	}
+	return false, nil
+}
+
+// removeForRecreate removes a container so that it can be created afresh
+// from its spec. The new container's configuration is built, and its image
+// pulled, before the old container is stopped, so that a spec that cannot be
+// created does not leave the container removed.
+func (c *external) removeForRecreate(ctx context.Context, cr *v1alpha1.Container, info *container.InspectResponse) error {
+	if !allows(cr, xpv1.ManagementActionDelete) || !allows(cr, xpv1.ManagementActionCreate) {
+		return errors.New(errRecreateNotAllowed)
+	}
+
+	containerConfig, _, _, _, err := c.configBuilder.BuildContainerConfig(ctx, cr)
+	if err != nil {
+		return errors.Wrap(err, errRecreateBuild)
+	}
+	if err := c.checkHostSupport(ctx, cr); err != nil {
+		return errors.Wrap(err, errRecreateBuild)
+	}
+	_, _, err = c.client.ImageInspectWithRaw(ctx, containerConfig.Image)
+	if pullPolicy(&cr.Spec.ForProvider) == v1alpha1.PullAlways || isNotFound(err) || clients.IsNoSuchImage(err) {
+		if err := c.pullContainerImage(ctx, cr, containerConfig.Image); err != nil {
+			return errors.Wrap(err, errRecreatePullImage)
+		}
+	}
+
+	c.logger.Info("Recreating container to update it", "container", cr.Name, "id", info.ID)
+	timeout := stopTimeout(&cr.Spec.ForProvider)
+	if err := c.client.ContainerStop(ctx, info.ID, container.StopOptions{Timeout: &timeout}); err != nil && !isNotFound(err) {
+		return errors.Wrap(err, errRecreateStop)
+	}
+	if err := c.client.ContainerRemove(ctx, info.ID, container.RemoveOptions{Force: true}); err != nil && !isNotFound(err) {
+		return errors.Wrap(err, errRecreateRemove)
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExternalUpdate(t *testing.T) {
+	always, unlessStopped := "always", "unless-stopped"
+	inPlace, never := v1alpha1.UpdateInPlace, v1alpha1.UpdateNever
+	limits := &v1alpha1.ResourceRequirements{Limits: v1alpha1.ResourceList{
+		"memory": intstr.FromString("256Mi"),
+		"cpu":    intstr.FromString("500m"),
+	}}
+
+	tests := []struct {
+		name      string
+		params    v1alpha1.ContainerParameters
+		policies  xpv1.ManagementPolicies
+		observed  container.InspectResponse
+		wantCalls []string
+		wantErr   string
+	}{
+		{
+			name:      "RestartPolicyInPlace",
+			params:    v1alpha1.ContainerParameters{Image: "nginx:1.27", RestartPolicy: &always},
+			observed:  observed("nginx:1.27", unlessStopped, container.Resources{}),
+			wantCalls: []string{"update restart=always memory=0 cpus=0"},
+		},
+		{
+			name:      "ResourceLimitsInPlace",
+			params:    v1alpha1.ContainerParameters{Image: "nginx:1.27", Resources: limits, UpdateStrategy: &inPlace},
+			observed:  observed("nginx:1.27", "no", container.Resources{Memory: 128 << 20}),
+			wantCalls: []string{"update restart=no memory=268435456 cpus=500000000"},
+		},
+		{
+			name:      "ImageRecreated",
+			params:    v1alpha1.ContainerParameters{Image: "nginx:1.28", RestartPolicy: &always},
+			observed:  observed("nginx:1.27", unlessStopped, container.Resources{}),
+			wantCalls: []string{"stop", "remove", "create nginx:1.28 restart=always", "start"},
+		},
+		{
+			name:     "ImageNotInPlace",
+			params:   v1alpha1.ContainerParameters{Image: "nginx:1.28", UpdateStrategy: &inPlace},
+			observed: observed("nginx:1.27", "no", container.Resources{}),
+			wantErr:  "cannot be made in place",
+		},
+		{
+			name:     "Never",
+			params:   v1alpha1.ContainerParameters{Image: "nginx:1.28", RestartPolicy: &always, UpdateStrategy: &never},
+			observed: observed("nginx:1.27", unlessStopped, container.Resources{}),
+		},
+		{
+			name:     "RecreateNotPermitted",
+			params:   v1alpha1.ContainerParameters{Image: "nginx:1.28"},
+			policies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionUpdate},
+			observed: observed("nginx:1.27", "no", container.Resources{}),
+			wantErr:  "do not permit recreating",
+		},
+		{
+			name:   "OnlyUnhealthy",
+			params: v1alpha1.ContainerParameters{Image: "nginx:1.27"},
+			observed: func() container.InspectResponse {
+				info := observed("nginx:1.27", "no", container.Resources{})
+				info.State = &container.State{Health: &container.Health{Status: container.Unhealthy}}
+				return info
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			ext := &external{
+				client: &mockDockerClient{
+					containerInspectFunc: func(_ context.Context, _ string) (container.InspectResponse, error) {
+						return tt.observed, nil
+					},
+					containerUpdateFunc: func(_ context.Context, _ string, u container.UpdateConfig) (container.UpdateResponse, error) {
+						calls = append(calls, "update restart="+string(u.RestartPolicy.Name)+" memory="+itoa(u.Memory)+" cpus="+itoa(u.NanoCPUs))
+						return container.UpdateResponse{}, nil
+					},
+					containerStopFunc: func(_ context.Context, _ string, _ container.StopOptions) error {
+						calls = append(calls, "stop")
+						return nil
+					},
+					containerRemoveFunc: func(_ context.Context, _ string, _ container.RemoveOptions) error {
+						calls = append(calls, "remove")
+						return nil
+					},
+					containerCreateFunc: func(_ context.Context, c *container.Config, hc *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.CreateResponse, error) {
+						calls = append(calls, "create "+c.Image+" restart="+string(hc.RestartPolicy.Name))
+						return container.CreateResponse{ID: "new-id"}, nil
+					},
+					containerStartFunc: func(_ context.Context, _ string, _ container.StartOptions) error {
+						calls = append(calls, "start")
+						return nil
+					},
+				},
+				configBuilder: &defaultContainerConfigBuilder{},
+				logger:        logging.NewNopLogger(),
+			}
+
+			cr := &v1alpha1.Container{Spec: v1alpha1.ContainerSpec{ForProvider: tt.params}}
+			cr.SetName("web")
+			cr.SetManagementPolicies(tt.policies)
+			meta.SetExternalName(cr, "web")
+
+			_, err := ext.Update(context.Background(), cr)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Update() error = %v, want error containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("Update() calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestResourceLimits(t *testing.T) {
+	swap := intstr.FromString("2Gi")
+	pids := int64(512)
+	weight := int32(300)
+	got, err := resourceLimits(&v1alpha1.ResourceRequirements{
+		Limits:      v1alpha1.ResourceList{"memory": intstr.FromString("1Gi"), "cpu": intstr.FromString("1.5")},
+		Requests:    v1alpha1.ResourceList{"memory": intstr.FromString("512Mi"), "cpu": intstr.FromString("250m")},
+		MemorySwap:  &swap,
+		PidsLimit:   &pids,
+		BlkioWeight: &weight,
+	})
+	if err != nil {
+		t.Fatalf("resourceLimits() error = %v", err)
+	}
+	if got.Memory != 1<<30 || got.NanoCPUs != 1_500_000_000 || got.MemoryReservation != 512<<20 {
+		t.Errorf("resourceLimits() = memory %d, cpus %d, reservation %d", got.Memory, got.NanoCPUs, got.MemoryReservation)
+	}
+	if got.CPUShares != 256 || got.MemorySwap != 2<<30 || pidsLimit(got.PidsLimit) != 512 || got.BlkioWeight != 300 {
+		t.Errorf("resourceLimits() = shares %d, swap %d, pids %d, blkio weight %d", got.CPUShares, got.MemorySwap, pidsLimit(got.PidsLimit), got.BlkioWeight)
+	}
+
+	unlimited := intstr.FromInt32(-1)
+	if got, err := resourceLimits(&v1alpha1.ResourceRequirements{MemorySwap: &unlimited}); err != nil || got.MemorySwap != -1 {
+		t.Errorf("resourceLimits() = swap %d, %v, want unlimited swap", got.MemorySwap, err)
+	}
+	disabled := false
+	if got, _ := resourceLimits(&v1alpha1.ResourceRequirements{
+		Requests:          v1alpha1.ResourceList{"memory": intstr.FromString("512Mi")},
+		ProtectWorkingSet: &disabled,
+	}); got.MemoryReservation != 0 {
+		t.Errorf("resourceLimits() = reservation %d with protectWorkingSet false, want none", got.MemoryReservation)
+	}
+	if got, _ := resourceLimits(&v1alpha1.ResourceRequirements{Requests: v1alpha1.ResourceList{"cpu": intstr.FromString("1m")}}); got.CPUShares != minCPUShares {
+		t.Errorf("resourceLimits() = shares %d for 1m CPU, want %d", got.CPUShares, minCPUShares)
+	}
+
+	small := intstr.FromString("512Mi")
+	if _, err := resourceLimits(&v1alpha1.ResourceRequirements{
+		Limits:     v1alpha1.ResourceList{"memory": intstr.FromString("1Gi")},
+		MemorySwap: &small,
+	}); err == nil || !strings.Contains(err.Error(), "memorySwap") {
+		t.Errorf("resourceLimits() error = %v, want memorySwap below the memory limit", err)
+	}
+
+	if _, err := resourceLimits(&v1alpha1.ResourceRequirements{
+		Limits: v1alpha1.ResourceList{"cpu": intstr.FromString("lots")},
+	}); err == nil || !strings.Contains(err.Error(), "limits.cpu") {
+		t.Errorf("resourceLimits() error = %v, want invalid limits.cpu", err)
+	}
+}
+
+func TestLimitsMatch(t *testing.T) {
+	pids := int64(100)
+	unlimited := int64(-1)
+	want := container.Resources{Memory: 1 << 30, MemoryReservation: 512 << 20, CPUShares: 512, PidsLimit: &pids, BlkioWeight: 500}
+	with := func(change func(r *container.Resources)) container.Resources {
+		r := want
+		change(&r)
+		return r
+	}
+
+	tests := map[string]struct {
+		want container.Resources
+		have container.Resources
+		same bool
+	}{
+		"Same": {want: want, have: want, same: true},
+		// Docker sets swap to twice the memory limit unless told otherwise
+		"DefaultSwap": {want: want, have: with(func(r *container.Resources) { r.MemorySwap = 2 << 30 }), same: true},
+		"Swap": {
+			want: with(func(r *container.Resources) { r.MemorySwap = -1 }),
+			have: with(func(r *container.Resources) { r.MemorySwap = 2 << 30 }),
+		},
+		"Reservation":      {want: want, have: with(func(r *container.Resources) { r.MemoryReservation = 0 })},
+		"Shares":           {want: want, have: with(func(r *container.Resources) { r.CPUShares = 1024 })},
+		"PidsLimit":        {want: want, have: with(func(r *container.Resources) { r.PidsLimit = nil })},
+		"BlkioWeight":      {want: want, have: with(func(r *container.Resources) { r.BlkioWeight = 0 })},
+		"UnlimitedPids":    {want: container.Resources{PidsLimit: &unlimited}, have: container.Resources{}, same: true},
+		"NothingRequested": {want: container.Resources{}, have: container.Resources{}, same: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := limitsMatch(tt.want, tt.have); got != tt.same {
+				t.Errorf("limitsMatch() = %v, want %v", got, tt.same)
+			}
+		})
+	}
+}
+
+// observed returns a running container as inspected.
+func observed(image, restart string, resources container.Resources) container.InspectResponse {
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			ID:    "old-id",
+			State: &container.State{Running: true},
+			HostConfig: &container.HostConfig{
+				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyMode(restart)},
+				Resources:     resources,
+			},
+		},
+		Config: &container.Config{Image: image},
+	}
+}
+
+func TestIsBandwidthUpToDate(t *testing.T) {
+	limits := &v1alpha1.BandwidthLimits{Egress: stringPtr("10mbit")}
+	script, err := bandwidthScript(limits)
+	if err != nil {
+		t.Fatalf("bandwidthScript() error = %v", err)
+	}
+	applied := &v1alpha1.AppliedBandwidth{Hash: bandwidthHash(script), StartedAt: "2026-01-01T00:00:00Z"}
+	running := func(startedAt string) *container.InspectResponse {
+		return &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+			State: &container.State{Running: true, StartedAt: startedAt},
+		}}
+	}
+
+	tests := map[string]struct {
+		limits   *v1alpha1.BandwidthLimits
+		applied  *v1alpha1.AppliedBandwidth
+		info     *container.InspectResponse
+		expected bool
+	}{
+		"NoLimits": {
+			info:     running("2026-01-01T00:00:00Z"),
+			expected: true,
+		},
+		"Applied": {
+			limits:   limits,
+			applied:  applied,
+			info:     running("2026-01-01T00:00:00Z"),
+			expected: true,
+		},
+		"NeverApplied": {
+			limits: limits,
+			info:   running("2026-01-01T00:00:00Z"),
+		},
+		"StartedAgain": {
+			limits:  limits,
+			applied: applied,
+			info:    running("2026-01-02T00:00:00Z"),
+		},
+		"LimitsChanged": {
+			limits:  &v1alpha1.BandwidthLimits{Egress: stringPtr("20mbit")},
+			applied: applied,
+			info:    running("2026-01-01T00:00:00Z"),
+		},
+		"Stopped": {
+			limits: limits,
+			info: &container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				State: &container.State{Status: "exited"},
+			}},
+			expected: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Container{}
+			cr.Spec.ForProvider.Bandwidth = tc.limits
+			cr.Status.AtProvider.Bandwidth = tc.applied
+			if got := isBandwidthUpToDate(cr, tc.info); got != tc.expected {
+				t.Errorf("isBandwidthUpToDate() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func itoa(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package containeroperation reconciles ContainerOperations: one-shot actions,
+// such as restarts, performed in bulk on the managed containers of a Docker
+// host that match a label selector.
+package containeroperation
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+	"github.com/rossigee/provider-docker/internal/shutdown"
+	"github.com/rossigee/provider-docker/internal/tracing"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
+	"strings"
+)
+
+const (
+	errNotContainerOperation = "managed resource is not a ContainerOperation custom resource"
+	errTrackPCUsage          = "cannot track ProviderConfig usage"
+	errNewClient             = "cannot create new Docker client"
+
+	errSelector       = "cannot parse selector"
+	errListContainers = "cannot list containers"
+	errUnknownAction  = "unknown action %q"
+	errRecordResult   = "cannot record the result of the operation"
+	errReadResult     = "cannot read the recorded result of the operation"
+)
+
+// SetupContainerOperation adds a controller that reconciles ContainerOperation
+// managed resources.
+func SetupContainerOperation(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ContainerOperationGroupKind.Kind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
+
+	backoff := clients.NewTerminalBackoff(o.PollInterval)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ContainerOperationGroupVersionKind),
+		managed.WithExternalConnector(backoff.Connector(dryrun.Connector(&connector{
+			kube:   mgr.GetClient(),
+			usage:  resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			logger: o.Logger,
+		}, recorder))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithFinalizer(clients.NewUsageFinalizer(mgr.GetClient())),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(recorder))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.ContainerOperation{}).
+		Complete(ratelimiter.NewReconciler(name, backoff.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube   client.Client
+	usage  resource.Tracker
+	logger logging.Logger
+}
+
+// Connect produces an ExternalClient for the Docker host of the operation's
+// ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.ContainerOperation); !ok {
+		return nil, errors.New(errNotContainerOperation)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	client, err := clients.NewDockerClient(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{client: client, logger: c.logger}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client clients.DockerClient
+	logger logging.Logger
+}
+
+// Observe reports whether an operation has been performed. An operation that
+// has completed exists, and is never performed again. Its result is recorded
+// in an annotation when it is performed, since the status set by Create is not
+// saved, and is restored to its status from there. An operation that is being
+// deleted no longer exists, as there is nothing to delete.
+func (c *external) Observe(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ContainerOperation)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotContainerOperation)
+	}
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if result, ok := cr.GetAnnotations()[labels.AnnotationOperationResult]; ok && cr.Status.AtProvider.CompletedAt == nil {
+		var obs v1alpha1.ContainerOperationObservation
+		if err := json.Unmarshal([]byte(result), &obs); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errReadResult)
+		}
+		complete(cr, obs)
+	}
+	if cr.Status.AtProvider.CompletedAt == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+// Create performs an operation's action on each of the containers it selects,
+// recording the result for each. A container the action fails on does not
+// stop it being performed on the others, and is not retried.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, span := tracing.StartSpan(ctx, "containeroperation.create",
+		tracing.SpanAttrs("containeroperation", mg.GetName(), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.ContainerOperation)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotContainerOperation)
+	}
+
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	defer done()
+
+	p := cr.Spec.ForProvider
+	if !slices.Contains([]v1alpha1.ContainerAction{v1alpha1.ContainerActionRestart, v1alpha1.ContainerActionStop, v1alpha1.ContainerActionPause}, p.Action) {
+		return managed.ExternalCreation{}, errors.Errorf(errUnknownAction, p.Action)
+	}
+	targets, err := c.targets(ctx, p.Selector)
+	if err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
+	}
+
+	obs := v1alpha1.ContainerOperationObservation{Targets: make([]v1alpha1.OperationTarget, 0, len(targets))}
+	for _, t := range targets {
+		result := v1alpha1.OperationTarget{Name: containerName(t), ID: t.ID, Succeeded: true}
+		if err := c.perform(ctx, p, t); err != nil {
+			result.Succeeded, result.Error = false, err.Error()
+			obs.Failed++
+		} else {
+			obs.Succeeded++
+		}
+		obs.Targets = append(obs.Targets, result)
+	}
+	c.logger.Debug("Performed container operation", "operation", cr.GetName(), "action", p.Action, "succeeded", obs.Succeeded, "failed", obs.Failed)
+
+	completed := metav1.Now()
+	obs.CompletedAt = &completed
+	result, err := json.Marshal(obs)
+	if err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, errRecordResult))
+	}
+	meta.AddAnnotations(cr, map[string]string{labels.AnnotationOperationResult: string(result)})
+	meta.SetExternalName(cr, cr.GetName())
+	complete(cr, obs)
+
+	return managed.ExternalCreation{}, nil
+}
+
+// complete records the result of an operation's action in its status.
+func complete(cr *v1alpha1.ContainerOperation, obs v1alpha1.ContainerOperationObservation) {
+	cr.Status.AtProvider = obs
+	msg := fmt.Sprintf("%s succeeded on %d of %d containers", cr.Spec.ForProvider.Action, obs.Succeeded, len(obs.Targets))
+	if obs.Failed > 0 {
+		cr.SetConditions(v1alpha1.OperationFailed(msg))
+	} else {
+		cr.SetConditions(v1alpha1.OperationCompleted(msg))
+	}
+}
+
+// Update does nothing: an operation is performed once, and a changed
+// operation is not performed again.
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete does nothing: an operation that has been performed cannot be undone.
+// Observe reports that an operation being deleted no longer exists, so that
+// its deletion completes.
+func (c *external) Delete(_ context.Context, _ resource.Managed) (managed.ExternalDelete, error) {
+	return managed.ExternalDelete{}, nil
+}
+
+// Disconnect is called when the controller is shutting down.
+func (c *external) Disconnect(_ context.Context) error {
+	return c.client.Close()
+}
+
+// targets returns the containers an operation selects, by name: those the
+// provider manages whose Docker labels match its selector.
+func (c *external) targets(ctx context.Context, ls metav1.LabelSelector) ([]container.Summary, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&ls)
+	if err != nil {
+		return nil, errors.Wrap(err, errSelector)
+	}
+	all, err := c.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, errors.Wrap(err, errListContainers)
+	}
+
+	var targets []container.Summary
+	for _, t := range all {
+		if isManaged(t.Labels) && selector.Matches(klabels.Set(t.Labels)) {
+			targets = append(targets, t)
+		}
+	}
+	slices.SortFunc(targets, func(a, b container.Summary) int {
+		return cmp.Compare(containerName(a), containerName(b))
+	})
+	return targets, nil
+}
+
+// perform performs an operation's action on a container. Pausing a container
+// that is already paused does nothing.
+func (c *external) perform(ctx context.Context, p v1alpha1.ContainerOperationParameters, t container.Summary) error {
+	var opts container.StopOptions
+	if p.StopTimeout != nil {
+		seconds := int(p.StopTimeout.Seconds())
+		opts.Timeout = &seconds
+	}
+
+	switch p.Action {
+	case v1alpha1.ContainerActionRestart:
+		return c.client.ContainerRestart(ctx, t.ID, opts)
+	case v1alpha1.ContainerActionStop:
+		return c.client.ContainerStop(ctx, t.ID, opts)
+	case v1alpha1.ContainerActionPause:
+		if t.State == container.StatePaused {
+			return nil
+		}
+		return c.client.ContainerPause(ctx, t.ID)
+	}
+	return errors.Errorf(errUnknownAction, p.Action)
+}
+
+// isManaged reports whether a container is managed by the provider, as a
+// Container or a service of a ComposeStack.
+func isManaged(l map[string]string) bool {
+	return l[labels.ManagedBy] != "" || l[labels.StackUID] != ""
+}
+
+// containerName returns the name of a container, without its leading slash.
+func containerName(t container.Summary) string {
+	if len(t.Names) == 0 {
+		return t.ID
+	}
+	return strings.TrimPrefix(t.Names[0], "/")
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containeroperation
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeClient serves the Docker calls an operation makes from a list of
+// containers, recording the action performed on each by ID.
+type fakeClient struct {
+	clients.DockerClient
+	containers []container.Summary
+	failOn     string
+	performed  []string
+	timeout    *int
+}
+
+func (f *fakeClient) ContainerList(_ context.Context, _ container.ListOptions) ([]container.Summary, error) {
+	return f.containers, nil
+}
+
+func (f *fakeClient) perform(action, id string) error {
+	if id == f.failOn {
+		return errors.New("cannot " + action + " " + id)
+	}
+	f.performed = append(f.performed, action+" "+id)
+	return nil
+}
+
+func (f *fakeClient) ContainerRestart(_ context.Context, id string, opts container.StopOptions) error {
+	f.timeout = opts.Timeout
+	return f.perform("restart", id)
+}
+
+func (f *fakeClient) ContainerStop(_ context.Context, id string, opts container.StopOptions) error {
+	f.timeout = opts.Timeout
+	return f.perform("stop", id)
+}
+
+func (f *fakeClient) ContainerPause(_ context.Context, id string) error {
+	return f.perform("pause", id)
+}
+
+func summary(id, name, state string, l map[string]string) container.Summary {
+	return container.Summary{ID: id, Names: []string{"/" + name}, State: container.ContainerState(state), Labels: l}
+}
+
+func TestCreate(t *testing.T) {
+	edge := map[string]string{labels.ManagedBy: "provider-docker", "app": "edge-agent"}
+	stack := map[string]string{labels.StackUID: "uid", "app": "edge-agent"}
+	containers := []container.Summary{
+		summary("c3", "edge-c", "running", edge),
+		summary("c1", "edge-a", "running", stack),
+		summary("c2", "edge-b", "paused", edge),
+		summary("c4", "db", "running", map[string]string{labels.ManagedBy: "provider-docker", "app": "db"}),
+		summary("c5", "unmanaged", "running", map[string]string{"app": "edge-agent"}),
+	}
+	selector := metav1.LabelSelector{MatchLabels: map[string]string{"app": "edge-agent"}}
+
+	tests := map[string]struct {
+		action      v1alpha1.ContainerAction
+		stopTimeout *metav1.Duration
+		failOn      string
+		performed   []string
+		timeout     *int
+		targets     []string
+		failed      int32
+		ready       corev1.ConditionStatus
+	}{
+		"Restart": {
+			action:      v1alpha1.ContainerActionRestart,
+			stopTimeout: &metav1.Duration{Duration: 30 * time.Second},
+			performed:   []string{"restart c1", "restart c2", "restart c3"},
+			timeout:     func() *int { s := 30; return &s }(),
+			targets:     []string{"edge-a", "edge-b", "edge-c"},
+			ready:       corev1.ConditionTrue,
+		},
+		"Stop": {
+			action:    v1alpha1.ContainerActionStop,
+			performed: []string{"stop c1", "stop c2", "stop c3"},
+			targets:   []string{"edge-a", "edge-b", "edge-c"},
+			ready:     corev1.ConditionTrue,
+		},
+		"PauseSkipsPaused": {
+			action:    v1alpha1.ContainerActionPause,
+			performed: []string{"pause c1", "pause c3"},
+			targets:   []string{"edge-a", "edge-b", "edge-c"},
+			ready:     corev1.ConditionTrue,
+		},
+		"FailureDoesNotStopOthers": {
+			action:    v1alpha1.ContainerActionRestart,
+			failOn:    "c2",
+			performed: []string{"restart c1", "restart c3"},
+			targets:   []string{"edge-a", "edge-b", "edge-c"},
+			failed:    1,
+			ready:     corev1.ConditionFalse,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &fakeClient{containers: containers, failOn: tt.failOn}
+			e := &external{client: f, logger: logging.NewNopLogger()}
+			cr := &v1alpha1.ContainerOperation{ObjectMeta: metav1.ObjectMeta{Name: "restart-edge-agents"}}
+			cr.Spec.ForProvider = v1alpha1.ContainerOperationParameters{Action: tt.action, Selector: selector, StopTimeout: tt.stopTimeout}
+
+			if _, err := e.Create(context.Background(), cr); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if _, ok := cr.GetAnnotations()[labels.AnnotationOperationResult]; !ok {
+				t.Fatalf("Create() did not record the result in annotation %s", labels.AnnotationOperationResult)
+			}
+
+			// The status set by Create is not saved, so Observe restores it
+			// from the recorded result.
+			cr.Status = v1alpha1.ContainerOperationStatus{}
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe() error = %v", err)
+			}
+			if !got.ResourceExists || !got.ResourceUpToDate {
+				t.Errorf("Observe() of a completed operation = %+v, want it to exist and be up to date", got)
+			}
+			if !reflect.DeepEqual(f.performed, tt.performed) {
+				t.Errorf("Create() performed %q, want %q", f.performed, tt.performed)
+			}
+			if !reflect.DeepEqual(f.timeout, tt.timeout) {
+				t.Errorf("Create() stop timeout = %v, want %v", f.timeout, tt.timeout)
+			}
+
+			obs := cr.Status.AtProvider
+			var targets []string
+			for _, target := range obs.Targets {
+				targets = append(targets, target.Name)
+			}
+			if !reflect.DeepEqual(targets, tt.targets) {
+				t.Errorf("Create() targets = %q, want %q", targets, tt.targets)
+			}
+			if obs.Failed != tt.failed || obs.Succeeded != int32(len(tt.targets))-tt.failed {
+				t.Errorf("Create() succeeded %d and failed %d, want %d failed", obs.Succeeded, obs.Failed, tt.failed)
+			}
+			if got := cr.GetCondition(xpv1.TypeReady).Status; got != tt.ready {
+				t.Errorf("Create() Ready = %q, want %q", got, tt.ready)
+			}
+			if obs.CompletedAt == nil {
+				t.Error("Observe() did not restore when the operation completed")
+			}
+		})
+	}
+}
+
+func TestObserveNotPerformed(t *testing.T) {
+	e := &external{client: &fakeClient{}}
+	got, err := e.Observe(context.Background(), &v1alpha1.ContainerOperation{})
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	if got.ResourceExists {
+		t.Error("Observe() of an operation not yet performed reports it exists")
+	}
+}
+
+func TestObserveDeleted(t *testing.T) {
+	now := metav1.Now()
+	cr := &v1alpha1.ContainerOperation{ObjectMeta: metav1.ObjectMeta{
+		DeletionTimestamp: &now,
+		Annotations:       map[string]string{labels.AnnotationOperationResult: `{"completedAt":"2025-01-01T00:00:00Z"}`},
+	}}
+	e := &external{client: &fakeClient{}}
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	if got.ResourceExists {
+		t.Error("Observe() of a completed operation being deleted reports it exists, so its deletion never completes")
+	}
+}
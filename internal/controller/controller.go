@@ -19,39 +19,90 @@ package controller
 
 import (
 	xpcontroller "github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/pkg/errors"
 	"github.com/rossigee/provider-docker/internal/controller/compose"
 	"github.com/rossigee/provider-docker/internal/controller/container"
+	"github.com/rossigee/provider-docker/internal/controller/containeroperation"
+	"github.com/rossigee/provider-docker/internal/controller/debugsession"
+	"github.com/rossigee/provider-docker/internal/controller/estatereport"
+	"github.com/rossigee/provider-docker/internal/controller/image"
+	"github.com/rossigee/provider-docker/internal/controller/imageprefetch"
 	"github.com/rossigee/provider-docker/internal/controller/network"
 	"github.com/rossigee/provider-docker/internal/controller/volume"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"slices"
+	"strings"
 )
 
-// Setup Docker controllers with the manager.
-func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
-	// Setup v1alpha1 container controller (cluster-scoped for backwards compatibility)
-	if err := container.Setup(mgr, o); err != nil {
-		return err
-	}
+// Names of the controllers that can be enabled.
+const (
+	Container          = "container"
+	Compose            = "compose"
+	Volume             = "volume"
+	Network            = "network"
+	DebugSession       = "debugsession"
+	ContainerOperation = "containeroperation"
+	ImagePrefetch      = "imageprefetch"
+	Image              = "image"
+	EstateReport       = "estatereport"
+)
 
-	// Setup v1beta1 container controller (namespaced for v2 compatibility)
-	if err := container.SetupV1Beta1(mgr, o); err != nil {
-		return err
-	}
+// setups are the functions that set up each named controller, in the order
+// they are set up.
+var setups = []struct {
+	name  string
+	setup []func(ctrl.Manager, xpcontroller.Options) error
+}{
+	// v1alpha1 container controller (cluster-scoped for backwards
+	// compatibility) and v1beta1 (namespaced for v2 compatibility)
+	{Container, []func(ctrl.Manager, xpcontroller.Options) error{container.Setup, container.SetupV1Beta1}},
+	// Compose controllers (v1alpha1 only for now)
+	{Compose, []func(ctrl.Manager, xpcontroller.Options) error{compose.Setup}},
+	// Volume controllers (v1alpha1 cluster-scoped)
+	{Volume, []func(ctrl.Manager, xpcontroller.Options) error{volume.SetupVolume}},
+	// Network controllers (v1alpha1 cluster-scoped)
+	{Network, []func(ctrl.Manager, xpcontroller.Options) error{network.SetupNetwork}},
+	// Debug session controller (v1alpha1 namespaced)
+	{DebugSession, []func(ctrl.Manager, xpcontroller.Options) error{debugsession.SetupDebugSession}},
+	// Container operation controller (v1alpha1 namespaced)
+	{ContainerOperation, []func(ctrl.Manager, xpcontroller.Options) error{containeroperation.SetupContainerOperation}},
+	// Image prefetch controller (v1alpha1 cluster-scoped)
+	{ImagePrefetch, []func(ctrl.Manager, xpcontroller.Options) error{imageprefetch.SetupImagePrefetch}},
+	// Image controller (v1alpha1 cluster-scoped)
+	{Image, []func(ctrl.Manager, xpcontroller.Options) error{image.SetupImage}},
+	// Estate report controller (v1beta1 cluster-scoped)
+	{EstateReport, []func(ctrl.Manager, xpcontroller.Options) error{estatereport.SetupDockerEstateReport}},
+}
 
-	// Setup compose controllers (v1alpha1 only for now)
-	if err := compose.Setup(mgr, o); err != nil {
-		return err
+// Names returns the names of all controllers, in the order they are set up.
+func Names() []string {
+	names := make([]string, 0, len(setups))
+	for _, s := range setups {
+		names = append(names, s.name)
 	}
+	return names
+}
 
-	// Setup volume controllers (v1alpha1 cluster-scoped)
-	if err := volume.SetupVolume(mgr, o); err != nil {
-		return err
+// Setup Docker controllers with the manager. Only the named controllers are
+// set up, or all of them if none are named.
+func Setup(mgr ctrl.Manager, o xpcontroller.Options, enabled ...string) error {
+	want := make(map[string]bool, len(enabled))
+	for _, name := range enabled {
+		if !slices.Contains(Names(), name) {
+			return errors.Errorf("unknown controller %q, must be one of %s", name, strings.Join(Names(), ", "))
+		}
+		want[name] = true
 	}
 
-	// Setup network controllers (v1alpha1 cluster-scoped)
-	if err := network.SetupNetwork(mgr, o); err != nil {
-		return err
+	for _, s := range setups {
+		if len(want) > 0 && !want[s.name] {
+			continue
+		}
+		for _, setup := range s.setup {
+			if err := setup(mgr, o); err != nil {
+				return err
+			}
+		}
 	}
-
 	return nil
 }
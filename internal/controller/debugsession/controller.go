@@ -0,0 +1,398 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package debugsession reconciles DebugSessions: short-lived helper
+// containers that share the namespaces of a container to debug it, in the
+// manner of kubectl debug.
+package debugsession
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+	"github.com/rossigee/provider-docker/internal/shutdown"
+	"github.com/rossigee/provider-docker/internal/tracing"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	errNotDebugSession = "managed resource is not a DebugSession custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errNewClient       = "cannot create new Docker client"
+
+	errInspect          = "cannot inspect helper container"
+	errCreate           = "cannot create helper container"
+	errStart            = "cannot start helper container"
+	errRemove           = "cannot remove helper container"
+	errGetTarget        = "cannot get target Container"
+	errInspectTarget    = "cannot inspect target container"
+	errNoTarget         = "one of target or targetRef must be set"
+	errTargetNotRunning = "target container %s is not running"
+
+	// defaultTTL is how long a session runs when it does not say.
+	defaultTTL = time.Hour
+)
+
+// SetupDebugSession adds a controller that reconciles DebugSession managed
+// resources.
+func SetupDebugSession(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.DebugSessionGroupKind.Kind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
+
+	backoff := clients.NewTerminalBackoff(o.PollInterval)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.DebugSessionGroupVersionKind),
+		managed.WithExternalConnector(backoff.Connector(dryrun.Connector(&connector{
+			kube:   mgr.GetClient(),
+			usage:  resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			logger: o.Logger,
+		}, recorder))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithFinalizer(clients.NewUsageFinalizer(mgr.GetClient())),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(nil))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.DebugSession{}).
+		Complete(ratelimiter.NewReconciler(name, backoff.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube   client.Client
+	usage  resource.Tracker
+	logger logging.Logger
+}
+
+// Connect produces an ExternalClient for the Docker host of the session's
+// ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.DebugSession)
+	if !ok {
+		return nil, errors.New(errNotDebugSession)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	client, err := clients.NewDockerClient(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{
+		kube:   c.kube,
+		client: client,
+		logger: c.logger,
+		registryAuths: func(ctx context.Context) (map[string]clients.RegistryAuth, error) {
+			return clients.RegistryAuths(ctx, c.kube, mg)
+		},
+		readOnly: dryrun.Enabled(),
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube   client.Client
+	client clients.DockerClient
+	logger logging.Logger
+
+	// registryAuths returns the registry credentials of the ProviderConfig,
+	// which are only read when the helper's image is pulled.
+	registryAuths clients.RegistryAuthsFunc
+
+	// readOnly is true in dry-run mode, when expired helper containers are
+	// left to exit on their own.
+	readOnly bool
+}
+
+// Observe reports the helper container of a session, removing it once the
+// session's TTL has passed, if its management policies allow it to be deleted.
+// An expired session is reported as existing so that its helper container is
+// not started again, unless it is being deleted.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ctx, span := tracing.StartSpan(ctx, "debugsession.observe",
+		tracing.SpanAttrs("debugsession", mg.GetName(), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.DebugSession)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDebugSession)
+	}
+
+	if cr.Status.AtProvider.Expired {
+		return managed.ExternalObservation{ResourceExists: !meta.WasDeleted(cr), ResourceUpToDate: true}, nil
+	}
+
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	info, err := c.client.ContainerInspect(ctx, name)
+	if err != nil && !isNotFound(err) {
+		return managed.ExternalObservation{}, tracing.RecordError(span, errors.Wrap(err, errInspect))
+	}
+	found := err == nil
+
+	// The status set by Create is not saved, so the session's expiry and
+	// helper are observed here.
+	expiresAt := metav1.NewTime(expiry(cr))
+	cr.Status.AtProvider.ExpiresAt = &expiresAt
+	if found {
+		cr.Status.AtProvider.ID = info.ID
+		if info.Config != nil {
+			cr.Status.AtProvider.TargetID = info.Config.Labels[labels.HelperFor]
+		}
+	}
+
+	if expired(cr, time.Now()) {
+		cr.SetConditions(v1alpha1.SessionExpired())
+		if found {
+			if c.readOnly || !allows(cr, xpv1.ManagementActionDelete) {
+				return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+			}
+			if err := c.client.ContainerRemove(ctx, name, container.RemoveOptions{Force: true}); err != nil && !isNotFound(err) {
+				return managed.ExternalObservation{}, tracing.RecordError(span, errors.Wrap(err, errRemove))
+			}
+		}
+		cr.Status.AtProvider.Expired = true
+		return managed.ExternalObservation{ResourceExists: !meta.WasDeleted(cr), ResourceUpToDate: true}, nil
+	}
+
+	// A helper container that exited early is started afresh
+	if !found {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if info.State != nil && info.State.Running {
+		cr.SetConditions(xpv1.Available())
+	} else {
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	// A session cannot be changed once started; it is deleted and created
+	// again instead.
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+// Create starts a helper container in the namespaces of the session's target.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, span := tracing.StartSpan(ctx, "debugsession.create",
+		tracing.SpanAttrs("debugsession", mg.GetName(), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.DebugSession)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDebugSession)
+	}
+
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	defer done()
+
+	targetID, err := c.resolveTarget(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
+	}
+
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		name = cr.GetName()
+	}
+
+	config, hostConfig := buildHelper(cr, targetID)
+	resp, err := c.client.ContainerCreate(ctx, config, hostConfig, nil, nil, name)
+	if clients.IsNoSuchImage(err) {
+		if err := clients.PullImage(ctx, c.client, config.Image, "", c.registryAuths, nil); err != nil {
+			return managed.ExternalCreation{}, tracing.RecordError(span, err)
+		}
+		resp, err = c.client.ContainerCreate(ctx, config, hostConfig, nil, nil, name)
+	}
+	if err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, errCreate))
+	}
+
+	// A helper that cannot be started is removed, so that its name is free
+	// when the session is created again.
+	if err := c.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		if rmErr := c.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{Force: true}); rmErr != nil && !isNotFound(rmErr) {
+			c.logger.Debug("Cannot remove debug session helper that failed to start", "name", name, "error", rmErr)
+		}
+		return managed.ExternalCreation{}, tracing.RecordError(span, errors.Wrap(err, errStart))
+	}
+
+	c.logger.Debug("Started debug session", "name", name, "target", targetID)
+
+	meta.SetExternalName(cr, name)
+
+	return managed.ExternalCreation{}, nil
+}
+
+// Update does nothing: a session cannot be changed once started.
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete removes the helper container of a session, if it is still running.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	ctx, span := tracing.StartSpan(ctx, "debugsession.delete",
+		tracing.SpanAttrs("debugsession", mg.GetName(), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.DebugSession)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotDebugSession)
+	}
+
+	name := meta.GetExternalName(cr)
+	if name == "" || cr.Status.AtProvider.Expired {
+		return managed.ExternalDelete{}, nil
+	}
+
+	err := c.client.ContainerRemove(ctx, name, container.RemoveOptions{Force: true})
+	if err != nil && !isNotFound(err) {
+		return managed.ExternalDelete{}, tracing.RecordError(span, errors.Wrap(err, errRemove))
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+// Disconnect is called when the controller is shutting down.
+func (c *external) Disconnect(_ context.Context) error {
+	return c.client.Close()
+}
+
+// resolveTarget returns the ID of the running container a session debugs.
+func (c *external) resolveTarget(ctx context.Context, cr *v1alpha1.DebugSession) (string, error) {
+	var target string
+	switch p := cr.Spec.ForProvider; {
+	case p.Target != nil && *p.Target != "":
+		target = *p.Target
+	case p.TargetRef != nil:
+		ref := &v1alpha1.Container{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: p.TargetRef.Name}, ref); err != nil {
+			return "", errors.Wrap(err, errGetTarget)
+		}
+		target = meta.GetExternalName(ref)
+	}
+	if target == "" {
+		return "", errors.New(errNoTarget)
+	}
+
+	info, err := c.client.ContainerInspect(ctx, target)
+	if err != nil {
+		return "", errors.Wrap(err, errInspectTarget)
+	}
+	if info.State == nil || !info.State.Running {
+		return "", errors.Errorf(errTargetNotRunning, target)
+	}
+	return info.ID, nil
+}
+
+// buildHelper returns the configuration of a session's helper container. By
+// default it sleeps for the session's TTL and is then removed by Docker, so
+// it does not outlive the session even if the provider is not running.
+func buildHelper(cr *v1alpha1.DebugSession, targetID string) (*container.Config, *container.HostConfig) {
+	p := cr.Spec.ForProvider
+
+	cmd := p.Command
+	if len(cmd) == 0 {
+		cmd = []string{"sleep", strconv.Itoa(int(ttl(cr).Seconds()))}
+	}
+
+	config := &container.Config{
+		Image:     p.Image,
+		Cmd:       cmd,
+		Tty:       true,
+		OpenStdin: true,
+		Labels: map[string]string{
+			labels.ManagedBy: labels.ManagedByProvider,
+			labels.HelperFor: targetID,
+		},
+	}
+	hostConfig := &container.HostConfig{AutoRemove: true}
+	if p.ShareNetwork == nil || *p.ShareNetwork {
+		hostConfig.NetworkMode = container.NetworkMode("container:" + targetID)
+	}
+	if p.SharePID == nil || *p.SharePID {
+		hostConfig.PidMode = container.PidMode("container:" + targetID)
+	}
+	return config, hostConfig
+}
+
+// ttl returns how long a session runs.
+func ttl(cr *v1alpha1.DebugSession) time.Duration {
+	if cr.Spec.ForProvider.TTL == nil {
+		return defaultTTL
+	}
+	return cr.Spec.ForProvider.TTL.Duration
+}
+
+// expiry returns when a session's TTL passes, counted from when it was
+// created.
+func expiry(cr *v1alpha1.DebugSession) time.Time {
+	return cr.GetCreationTimestamp().Add(ttl(cr))
+}
+
+// expired reports whether a session's TTL has passed.
+func expired(cr *v1alpha1.DebugSession, now time.Time) bool {
+	return !now.Before(expiry(cr))
+}
+
+// allows reports whether the management policies of a session permit the
+// provider to take action on its helper container. A session without
+// management policies is fully managed.
+func allows(cr *v1alpha1.DebugSession, action xpv1.ManagementAction) bool {
+	p := cr.GetManagementPolicies()
+	return len(p) == 0 || slices.Contains(p, xpv1.ManagementActionAll) || slices.Contains(p, action)
+}
+
+// isNotFound reports whether err is Docker not finding a container.
+func isNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	errorMessage := strings.ToLower(err.Error())
+	return strings.Contains(errorMessage, "not found") ||
+		strings.Contains(errorMessage, "no such container")
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugsession
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClient serves the Docker calls a debug session makes from a map of
+// containers by name.
+type fakeClient struct {
+	clients.DockerClient
+	containers map[string]container.InspectResponse
+	removed    []string
+	pullAuths  []string
+	pulled     bool
+	startErr   error
+}
+
+func (f *fakeClient) ContainerCreate(_ context.Context, config *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, name string) (container.CreateResponse, error) {
+	if !f.pulled {
+		return container.CreateResponse{}, errors.New("No such image: " + config.Image)
+	}
+	f.containers[name] = running("helper123")
+	return container.CreateResponse{ID: name}, nil
+}
+
+func (f *fakeClient) ContainerStart(_ context.Context, _ string, _ container.StartOptions) error {
+	return f.startErr
+}
+
+func (f *fakeClient) ImagePull(_ context.Context, _ string, o image.PullOptions) (io.ReadCloser, error) {
+	f.pullAuths = append(f.pullAuths, o.RegistryAuth)
+	f.pulled = true
+	return io.NopCloser(strings.NewReader("{}")), nil
+}
+
+func (f *fakeClient) ContainerInspect(_ context.Context, name string) (container.InspectResponse, error) {
+	info, ok := f.containers[name]
+	if !ok {
+		return container.InspectResponse{}, errors.New("No such container: " + name)
+	}
+	return info, nil
+}
+
+func (f *fakeClient) ContainerRemove(_ context.Context, name string, _ container.RemoveOptions) error {
+	f.removed = append(f.removed, name)
+	delete(f.containers, name)
+	return nil
+}
+
+func running(id string) container.InspectResponse {
+	return container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+		ID:    id,
+		State: &container.State{Running: true},
+	}}
+}
+
+func TestBuildHelper(t *testing.T) {
+	off := false
+	tests := []struct {
+		name        string
+		params      v1alpha1.DebugSessionParameters
+		wantCmd     []string
+		wantNetwork container.NetworkMode
+		wantPID     container.PidMode
+	}{
+		{
+			name:        "Defaults",
+			params:      v1alpha1.DebugSessionParameters{Image: "nicolaka/netshoot"},
+			wantCmd:     []string{"sleep", "3600"},
+			wantNetwork: "container:abc123",
+			wantPID:     "container:abc123",
+		},
+		{
+			name: "NetworkOnly",
+			params: v1alpha1.DebugSessionParameters{
+				Image:    "nicolaka/netshoot",
+				Command:  []string{"tcpdump", "-i", "eth0"},
+				SharePID: &off,
+				TTL:      &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			wantCmd:     []string{"tcpdump", "-i", "eth0"},
+			wantNetwork: "container:abc123",
+		},
+		{
+			name: "ShortTTL",
+			params: v1alpha1.DebugSessionParameters{
+				Image:        "busybox",
+				ShareNetwork: &off,
+				TTL:          &metav1.Duration{Duration: 90 * time.Second},
+			},
+			wantCmd: []string{"sleep", "90"},
+			wantPID: "container:abc123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1alpha1.DebugSession{Spec: v1alpha1.DebugSessionSpec{ForProvider: tt.params}}
+			config, hostConfig := buildHelper(cr, "abc123")
+
+			if !reflect.DeepEqual([]string(config.Cmd), tt.wantCmd) {
+				t.Errorf("Cmd = %v, want %v", config.Cmd, tt.wantCmd)
+			}
+			if hostConfig.NetworkMode != tt.wantNetwork {
+				t.Errorf("NetworkMode = %q, want %q", hostConfig.NetworkMode, tt.wantNetwork)
+			}
+			if hostConfig.PidMode != tt.wantPID {
+				t.Errorf("PidMode = %q, want %q", hostConfig.PidMode, tt.wantPID)
+			}
+			if !hostConfig.AutoRemove {
+				t.Error("AutoRemove = false, want true")
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	// Sessions last the default TTL of an hour from when they were created.
+	past := metav1.NewTime(time.Now().Add(-time.Hour - time.Minute))
+	future := metav1.NewTime(time.Now())
+	now := metav1.Now()
+
+	tests := []struct {
+		name        string
+		createdAt   metav1.Time
+		deleted     bool
+		expired     bool
+		policies    xpv1.ManagementPolicies
+		readOnly    bool
+		containers  map[string]container.InspectResponse
+		wantExists  bool
+		wantExpired bool
+		wantRemoved []string
+		wantReady   xpv1.ConditionReason
+	}{
+		{
+			name:       "NotStarted",
+			createdAt:  future,
+			containers: map[string]container.InspectResponse{},
+		},
+		{
+			name:       "Running",
+			createdAt:  future,
+			containers: map[string]container.InspectResponse{"debug": running("helper123")},
+			wantExists: true,
+			wantReady:  xpv1.ReasonAvailable,
+		},
+		{
+			name:       "ExitedEarly",
+			createdAt:  future,
+			containers: map[string]container.InspectResponse{},
+		},
+		{
+			name:        "Expired",
+			createdAt:   past,
+			containers:  map[string]container.InspectResponse{"debug": running("helper123")},
+			wantExists:  true,
+			wantExpired: true,
+			wantRemoved: []string{"debug"},
+			wantReady:   v1alpha1.ReasonSessionExpired,
+		},
+		{
+			name:        "RemovedByDocker",
+			createdAt:   past,
+			containers:  map[string]container.InspectResponse{},
+			wantExists:  true,
+			wantExpired: true,
+			wantReady:   v1alpha1.ReasonSessionExpired,
+		},
+		{
+			name:       "ExpiredObserveOnly",
+			createdAt:  past,
+			policies:   xpv1.ManagementPolicies{xpv1.ManagementActionObserve},
+			containers: map[string]container.InspectResponse{"debug": running("helper123")},
+			wantExists: true,
+			wantReady:  v1alpha1.ReasonSessionExpired,
+		},
+		{
+			name:       "ExpiredDryRun",
+			createdAt:  past,
+			readOnly:   true,
+			containers: map[string]container.InspectResponse{"debug": running("helper123")},
+			wantExists: true,
+			wantReady:  v1alpha1.ReasonSessionExpired,
+		},
+		{
+			name:        "ExpiredBeingDeleted",
+			createdAt:   past,
+			deleted:     true,
+			expired:     true,
+			containers:  map[string]container.InspectResponse{},
+			wantExpired: true,
+		},
+		{
+			name:        "ExpiringBeingDeleted",
+			createdAt:   past,
+			deleted:     true,
+			containers:  map[string]container.InspectResponse{"debug": running("helper123")},
+			wantExpired: true,
+			wantRemoved: []string{"debug"},
+			wantReady:   v1alpha1.ReasonSessionExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1alpha1.DebugSession{ObjectMeta: metav1.ObjectMeta{Name: "debug", CreationTimestamp: tt.createdAt}}
+			if tt.deleted {
+				cr.SetDeletionTimestamp(&now)
+			}
+			meta.SetExternalName(cr, "debug")
+			cr.SetManagementPolicies(tt.policies)
+			cr.Status.AtProvider.Expired = tt.expired
+
+			dc := &fakeClient{containers: tt.containers}
+			e := &external{client: dc, readOnly: tt.readOnly}
+			obs, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe() error = %v", err)
+			}
+
+			if obs.ResourceExists != tt.wantExists {
+				t.Errorf("ResourceExists = %v, want %v", obs.ResourceExists, tt.wantExists)
+			}
+			if cr.Status.AtProvider.Expired != tt.wantExpired {
+				t.Errorf("Expired = %v, want %v", cr.Status.AtProvider.Expired, tt.wantExpired)
+			}
+			if !reflect.DeepEqual(dc.removed, tt.wantRemoved) {
+				t.Errorf("removed %v, want %v", dc.removed, tt.wantRemoved)
+			}
+			if got := cr.GetCondition(xpv1.TypeReady).Reason; got != tt.wantReady {
+				t.Errorf("Ready reason = %q, want %q", got, tt.wantReady)
+			}
+			if want := tt.createdAt.Add(time.Hour); !tt.expired && !cr.Status.AtProvider.ExpiresAt.Time.Equal(want) {
+				t.Errorf("ExpiresAt = %v, want %v", cr.Status.AtProvider.ExpiresAt, want)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	auths := func(context.Context) (map[string]clients.RegistryAuth, error) {
+		return map[string]clients.RegistryAuth{"registry.example.com": {Username: "ci", Password: "secret"}}, nil
+	}
+	tests := map[string]struct {
+		startErr    error
+		wantErr     string
+		wantRemoved []string
+	}{
+		"Started": {},
+		"StartFails": {
+			startErr:    errors.New("cannot join network namespace"),
+			wantErr:     errStart,
+			wantRemoved: []string{"debug"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			target := "web"
+			cr := &v1alpha1.DebugSession{ObjectMeta: metav1.ObjectMeta{Name: "debug"}}
+			cr.Spec.ForProvider = v1alpha1.DebugSessionParameters{Image: "registry.example.com/tools/netshoot:1.0", Target: &target}
+
+			dc := &fakeClient{containers: map[string]container.InspectResponse{"web": running("web123")}, startErr: tt.startErr}
+			e := &external{client: dc, logger: logging.NewNopLogger(), registryAuths: auths}
+			_, err := e.Create(context.Background(), cr)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("Create() error = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("Create() error = %v, want %q", err, tt.wantErr)
+			}
+			if len(dc.pullAuths) != 1 || dc.pullAuths[0] == "" {
+				t.Errorf("Create() pulled the missing image with auths %q, want the registry's credentials", dc.pullAuths)
+			}
+			if !reflect.DeepEqual(dc.removed, tt.wantRemoved) {
+				t.Errorf("Create() removed %v, want %v", dc.removed, tt.wantRemoved)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package estatereport refreshes DockerEstateReports: summaries of the
+// Docker resources managed by the provider, per ProviderConfig, and of the
+// containers on their Docker hosts that it does not manage.
+package estatereport
+
+import (
+	"cmp"
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	composev1beta1 "github.com/rossigee/provider-docker/apis/compose/v1beta1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	containerv1beta1 "github.com/rossigee/provider-docker/apis/container/v1beta1"
+	networkv1alpha1 "github.com/rossigee/provider-docker/apis/network/v1alpha1"
+	networkv1beta1 "github.com/rossigee/provider-docker/apis/network/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	volumev1alpha1 "github.com/rossigee/provider-docker/apis/volume/v1alpha1"
+	volumev1beta1 "github.com/rossigee/provider-docker/apis/volume/v1beta1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	errGetReport    = "cannot get DockerEstateReport"
+	errUpdateStatus = "cannot update DockerEstateReport status"
+	errList         = "cannot list %T"
+	errNotManaged   = "%T is not a managed resource"
+	errConnect      = "cannot connect to the Docker host"
+	errListDocker   = "cannot list containers"
+
+	// maxUnmanagedContainers is the most unmanaged containers listed for a
+	// ProviderConfig, so that a busy host does not outgrow the report.
+	maxUnmanagedContainers = 100
+
+	// defaultRefreshInterval is how often a report is refreshed when it
+	// does not say.
+	defaultRefreshInterval = 5 * time.Minute
+
+	// defaultProviderConfig is the ProviderConfig of resources that do not
+	// reference one.
+	defaultProviderConfig = "default"
+)
+
+// A kind of managed resource counted by a report, and where it is counted.
+type kind struct {
+	list  func() client.ObjectList
+	count func(e *apisv1beta1.ProviderConfigEstate) *apisv1beta1.EstateCounts
+}
+
+func containers(e *apisv1beta1.ProviderConfigEstate) *apisv1beta1.EstateCounts {
+	return &e.Containers
+}
+
+func composeStacks(e *apisv1beta1.ProviderConfigEstate) *apisv1beta1.EstateCounts {
+	return &e.ComposeStacks
+}
+
+func volumes(e *apisv1beta1.ProviderConfigEstate) *apisv1beta1.EstateCounts {
+	return &e.Volumes
+}
+
+func networks(e *apisv1beta1.ProviderConfigEstate) *apisv1beta1.EstateCounts {
+	return &e.Networks
+}
+
+// kinds are the managed resources counted by a report, of both their
+// cluster scoped and namespaced API versions.
+var kinds = []kind{
+	{func() client.ObjectList { return &containerv1alpha1.ContainerList{} }, containers},
+	{func() client.ObjectList { return &containerv1beta1.ContainerList{} }, containers},
+	{func() client.ObjectList { return &composev1alpha1.ComposeStackList{} }, composeStacks},
+	{func() client.ObjectList { return &composev1beta1.ComposeStackList{} }, composeStacks},
+	{func() client.ObjectList { return &volumev1alpha1.VolumeList{} }, volumes},
+	{func() client.ObjectList { return &volumev1beta1.VolumeList{} }, volumes},
+	{func() client.ObjectList { return &networkv1alpha1.NetworkList{} }, networks},
+	{func() client.ObjectList { return &networkv1beta1.NetworkList{} }, networks},
+}
+
+// A managed resource, of any API version, as counted by a report.
+type managed interface {
+	resource.Managed
+	GetProviderConfigReference() *xpv1.ProviderConfigReference
+}
+
+// A configKey identifies a ProviderConfig in a report.
+type configKey struct {
+	kind, namespace, name string
+}
+
+func keyOf(e apisv1beta1.ProviderConfigEstate) configKey {
+	return configKey{kind: e.Kind, namespace: e.Namespace, name: e.Name}
+}
+
+// containerReader is the part of a Docker client an inventory of unmanaged
+// containers needs.
+type containerReader interface {
+	ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error)
+	ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error)
+	Close() error
+}
+
+// connect returns a client for the Docker host of a ProviderConfig, found
+// through one of the resources managed through it.
+func connect(ctx context.Context, kube client.Client, mg resource.Managed, ref *xpv1.ProviderConfigReference) (containerReader, error) {
+	return clients.NewDockerClientFor(ctx, kube, mg, ref)
+}
+
+// SetupDockerEstateReport adds a controller that refreshes
+// DockerEstateReports.
+func SetupDockerEstateReport(mgr ctrl.Manager, o controller.Options) error {
+	name := "estatereport/" + strings.ToLower(apisv1beta1.DockerEstateReportGroupKind.String())
+
+	r := &Reconciler{
+		kube:      mgr.GetClient(),
+		logger:    o.Logger.WithValues("controller", name),
+		now:       time.Now,
+		newClient: connect,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&apisv1beta1.DockerEstateReport{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A Reconciler refreshes a DockerEstateReport from the managed resources in
+// the cluster, and from the Docker hosts of their ProviderConfigs if it lists
+// unmanaged containers, then requeues it to be refreshed again.
+type Reconciler struct {
+	kube      client.Client
+	logger    logging.Logger
+	now       func() time.Time
+	newClient func(ctx context.Context, kube client.Client, mg resource.Managed, ref *xpv1.ProviderConfigReference) (containerReader, error)
+}
+
+// Reconcile refreshes a DockerEstateReport.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	report := &apisv1beta1.DockerEstateReport{}
+	if err := r.kube.Get(ctx, req.NamespacedName, report); err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errGetReport)
+	}
+
+	estate, resources, err := r.survey(ctx)
+	if err != nil {
+		r.logger.Debug("Cannot refresh DockerEstateReport", "report", report.GetName(), "error", err)
+		report.SetConditions(xpv1.ReconcileError(err))
+		return reconcile.Result{}, errors.Wrap(r.kube.Status().Update(ctx, report), errUpdateStatus)
+	}
+
+	refreshedAt := metav1.NewTime(r.now())
+	report.Status.RefreshedAt = &refreshedAt
+	report.Status.ProviderConfigs = estate
+	report.Status.Total, report.Status.Drifted, report.Status.Failing = 0, 0, 0
+	for _, e := range estate {
+		for _, c := range []apisv1beta1.EstateCounts{e.Containers, e.ComposeStacks, e.Volumes, e.Networks} {
+			report.Status.Total += c.Total
+			report.Status.Drifted += c.Drifted
+			report.Status.Failing += c.Failing
+		}
+	}
+	report.Status.Unmanaged = 0
+	if report.Spec.ListUnmanagedContainers {
+		report.Status.Unmanaged = r.inventory(ctx, estate, resources)
+	}
+	report.SetConditions(xpv1.Available(), xpv1.ReconcileSuccess())
+
+	return reconcile.Result{RequeueAfter: refreshInterval(report)}, errors.Wrap(r.kube.Status().Update(ctx, report), errUpdateStatus)
+}
+
+// survey counts the managed resources of each ProviderConfig, returning
+// them sorted by the kind, namespace and name of their ProviderConfig, with
+// one of the resources managed through each.
+func (r *Reconciler) survey(ctx context.Context) ([]apisv1beta1.ProviderConfigEstate, map[configKey]managed, error) {
+	byConfig := map[configKey]*apisv1beta1.ProviderConfigEstate{}
+	resources := map[configKey]managed{}
+	for _, k := range kinds {
+		l := k.list()
+		if err := r.kube.List(ctx, l); err != nil {
+			return nil, nil, errors.Wrapf(err, errList, l)
+		}
+		items, err := meta.ExtractList(l)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, errList, l)
+		}
+		for _, o := range items {
+			mg, ok := o.(managed)
+			if !ok {
+				return nil, nil, errors.Errorf(errNotManaged, o)
+			}
+			pc := providerConfigOf(mg)
+			key := keyOf(pc)
+			e, ok := byConfig[key]
+			if !ok {
+				e = &pc
+				byConfig[key] = e
+				resources[key] = mg
+			}
+			count(k.count(e), mg)
+		}
+	}
+
+	estate := make([]apisv1beta1.ProviderConfigEstate, 0, len(byConfig))
+	for _, e := range byConfig {
+		estate = append(estate, *e)
+	}
+	slices.SortFunc(estate, func(a, b apisv1beta1.ProviderConfigEstate) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return estate, resources, nil
+}
+
+// providerConfigOf returns the ProviderConfig a resource is managed through,
+// with none of its resources counted.
+func providerConfigOf(mg managed) apisv1beta1.ProviderConfigEstate {
+	e := apisv1beta1.ProviderConfigEstate{Kind: apisv1beta1.ProviderConfigKind, Name: defaultProviderConfig}
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		e.Name = ref.Name
+		if ref.Kind != "" {
+			e.Kind = ref.Kind
+		}
+	}
+	// A namespaced resource's ProviderConfig is in its own namespace
+	if e.Kind == apisv1beta1.ProviderConfigKind {
+		e.Namespace = mg.GetNamespace()
+	}
+	return e
+}
+
+// count counts a resource, as drifted while acting on its drift is deferred
+// to a maintenance window, and as failing while it cannot be synced or is
+// unavailable.
+func count(c *apisv1beta1.EstateCounts, mg managed) {
+	c.Total++
+	if mg.GetCondition(containerv1alpha1.TypeDeferred).Status == corev1.ConditionTrue {
+		c.Drifted++
+	}
+	if mg.GetCondition(xpv1.TypeSynced).Status == corev1.ConditionFalse ||
+		mg.GetCondition(xpv1.TypeReady).Reason == xpv1.ReasonUnavailable {
+		c.Failing++
+	}
+}
+
+// refreshInterval returns how often a report is refreshed.
+func refreshInterval(report *apisv1beta1.DockerEstateReport) time.Duration {
+	if i := report.Spec.RefreshInterval; i != nil && i.Duration > 0 {
+		return i.Duration
+	}
+	return defaultRefreshInterval
+}
+
+// inventory lists the containers on the Docker host of each ProviderConfig
+// that the provider does not manage, returning how many there are in all.
+// A ProviderConfig whose host cannot be listed records why, rather than
+// failing the report.
+func (r *Reconciler) inventory(ctx context.Context, estate []apisv1beta1.ProviderConfigEstate, resources map[configKey]managed) int32 {
+	var total int32
+	for i := range estate {
+		e := &estate[i]
+		unmanaged, n, err := r.unmanagedContainers(ctx, resources[keyOf(*e)], *e)
+		if err != nil {
+			r.logger.Debug("Cannot list unmanaged containers", "kind", e.Kind, "namespace", e.Namespace, "name", e.Name, "error", err)
+			e.InventoryError = err.Error()
+			continue
+		}
+		e.UnmanagedContainers = unmanaged
+		total += n
+	}
+	return total
+}
+
+// unmanagedContainers lists the containers on the Docker host of a
+// ProviderConfig that the provider does not manage, sorted by name and
+// limited to maxUnmanagedContainers, and returns how many there are.
+func (r *Reconciler) unmanagedContainers(ctx context.Context, mg managed, e apisv1beta1.ProviderConfigEstate) ([]apisv1beta1.UnmanagedContainer, int32, error) {
+	dc, err := r.newClient(ctx, r.kube, mg, &xpv1.ProviderConfigReference{Kind: e.Kind, Name: e.Name})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, errConnect)
+	}
+	defer func() { _ = dc.Close() }()
+
+	list, err := dc.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, errListDocker)
+	}
+	var unmanaged []apisv1beta1.UnmanagedContainer
+	for _, c := range list {
+		if isManaged(c.Labels) {
+			continue
+		}
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		unmanaged = append(unmanaged, apisv1beta1.UnmanagedContainer{Name: name, ID: c.ID, Image: c.Image, State: string(c.State)})
+	}
+	slices.SortFunc(unmanaged, func(a, b apisv1beta1.UnmanagedContainer) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	n := int32(len(unmanaged))
+	unmanaged = unmanaged[:min(len(unmanaged), maxUnmanagedContainers)]
+
+	// A container list only says when each container was created
+	for i := range unmanaged {
+		info, err := dc.ContainerInspect(ctx, unmanaged[i].ID)
+		if err != nil || info.ContainerJSONBase == nil || info.State == nil {
+			// It may have been removed since it was listed
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339Nano, info.State.StartedAt); err == nil && !t.IsZero() {
+			startedAt := metav1.NewTime(t)
+			unmanaged[i].StartedAt = &startedAt
+		}
+	}
+	return unmanaged, n, nil
+}
+
+// isManaged reports whether a container with the supplied labels was
+// created by the provider, for a Container, a ComposeStack or as a helper.
+func isManaged(l map[string]string) bool {
+	return l[labels.ManagedBy] == labels.ManagedByProvider || l[labels.StackUID] != ""
+}
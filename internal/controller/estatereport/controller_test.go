@@ -0,0 +1,231 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estatereport
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis"
+	composev1alpha1 "github.com/rossigee/provider-docker/apis/compose/v1alpha1"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	containerv1beta1 "github.com/rossigee/provider-docker/apis/container/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-docker/apis/v1beta1"
+	volumev1beta1 "github.com/rossigee/provider-docker/apis/volume/v1beta1"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+	"time"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	ref := func(kind, name string) *xpv1.ProviderConfigReference {
+		return &xpv1.ProviderConfigReference{Kind: kind, Name: name}
+	}
+	deferred := xpv1.Condition{Type: containerv1alpha1.TypeDeferred, Status: corev1.ConditionTrue}
+	unsynced := xpv1.Condition{Type: xpv1.TypeSynced, Status: corev1.ConditionFalse}
+
+	web := &containerv1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "web"}}
+	web.SetProviderConfigReference(ref("ProviderConfig", "edge"))
+	web.SetConditions(deferred)
+	db := &containerv1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "db"}}
+	db.SetConditions(xpv1.Unavailable())
+	stack := &composev1alpha1.ComposeStack{ObjectMeta: metav1.ObjectMeta{Name: "stack"}}
+	stack.SetProviderConfigReference(ref("ProviderConfig", "edge"))
+	stack.SetConditions(unsynced, deferred)
+	app := &containerv1beta1.Container{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team"}}
+	app.SetProviderConfigReference(ref("ProviderConfig", "local"))
+	data := &volumev1beta1.Volume{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "team"}}
+	data.SetProviderConfigReference(ref("ClusterProviderConfig", "shared"))
+	report := &apisv1beta1.DockerEstateReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "estate"},
+		Spec:       apisv1beta1.DockerEstateReportSpec{RefreshInterval: &metav1.Duration{Duration: time.Minute}},
+	}
+
+	kube := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(web, db, stack, app, data, report).
+		WithStatusSubresource(report).
+		Build()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	r := &Reconciler{kube: kube, logger: logging.NewNopLogger(), now: func() time.Time { return now }}
+
+	got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "estate"}})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got.RequeueAfter != time.Minute {
+		t.Errorf("Reconcile() RequeueAfter = %v, want %v", got.RequeueAfter, time.Minute)
+	}
+
+	if err := kube.Get(context.Background(), client.ObjectKeyFromObject(report), report); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	want := []apisv1beta1.ProviderConfigEstate{
+		{
+			Kind: "ClusterProviderConfig", Name: "shared",
+			Volumes: apisv1beta1.EstateCounts{Total: 1},
+		},
+		{
+			Kind: "ProviderConfig", Name: "default",
+			Containers: apisv1beta1.EstateCounts{Total: 1, Failing: 1},
+		},
+		{
+			Kind: "ProviderConfig", Name: "edge",
+			Containers:    apisv1beta1.EstateCounts{Total: 1, Drifted: 1},
+			ComposeStacks: apisv1beta1.EstateCounts{Total: 1, Drifted: 1, Failing: 1},
+		},
+		{
+			Kind: "ProviderConfig", Name: "local", Namespace: "team",
+			Containers: apisv1beta1.EstateCounts{Total: 1},
+		},
+	}
+	if diff := cmp.Diff(want, report.Status.ProviderConfigs); diff != "" {
+		t.Errorf("Reconcile() ProviderConfigs -want +got:\n%s", diff)
+	}
+	if s := report.Status; s.Total != 5 || s.Drifted != 2 || s.Failing != 2 {
+		t.Errorf("Reconcile() totals = %d/%d/%d, want 5/2/2", s.Total, s.Drifted, s.Failing)
+	}
+	if report.Status.RefreshedAt == nil || !report.Status.RefreshedAt.Equal(&metav1.Time{Time: now}) {
+		t.Errorf("Reconcile() RefreshedAt = %v, want %v", report.Status.RefreshedAt, now)
+	}
+	if c := report.GetCondition(xpv1.TypeReady); c.Status != corev1.ConditionTrue {
+		t.Errorf("Reconcile() Ready = %v, want True", c.Status)
+	}
+}
+
+type fakeHost struct {
+	containers []container.Summary
+	started    map[string]string
+}
+
+func (h *fakeHost) ContainerList(_ context.Context, opts container.ListOptions) ([]container.Summary, error) {
+	if !opts.All {
+		return nil, errors.New("only running containers listed")
+	}
+	return h.containers, nil
+}
+
+func (h *fakeHost) ContainerInspect(_ context.Context, id string) (container.InspectResponse, error) {
+	return container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+		ID:    id,
+		State: &container.State{StartedAt: h.started[id]},
+	}}, nil
+}
+
+func (h *fakeHost) Close() error { return nil }
+
+func TestReconcileUnmanagedContainers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	web := &containerv1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "web"}}
+	web.SetProviderConfigReference(&xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "edge"})
+	db := &containerv1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: "db"}}
+	db.SetProviderConfigReference(&xpv1.ProviderConfigReference{Kind: "ProviderConfig", Name: "down"})
+	report := &apisv1beta1.DockerEstateReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "estate"},
+		Spec:       apisv1beta1.DockerEstateReportSpec{ListUnmanagedContainers: true},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(web, db, report).
+		WithStatusSubresource(report).
+		Build()
+
+	edge := &fakeHost{
+		containers: []container.Summary{
+			{ID: "a1", Names: []string{"/web"}, Image: "nginx:1.27", State: container.StateRunning, Labels: map[string]string{labels.ManagedBy: labels.ManagedByProvider}},
+			{ID: "b2", Names: []string{"/stack-api-1"}, Image: "api:2", State: container.StateRunning, Labels: map[string]string{labels.StackUID: "1234"}},
+			{ID: "c3", Names: []string{"/watchtower"}, Image: "containrrr/watchtower", State: container.StateRunning},
+			{ID: "d4", Names: []string{"/backup"}, Image: "restic/restic", State: container.StateCreated},
+		},
+		started: map[string]string{"c3": "2026-01-02T03:04:05Z", "d4": "0001-01-01T00:00:00Z"},
+	}
+	r := &Reconciler{
+		kube:   kube,
+		logger: logging.NewNopLogger(),
+		now:    time.Now,
+		newClient: func(_ context.Context, _ client.Client, mg resource.Managed, ref *xpv1.ProviderConfigReference) (containerReader, error) {
+			if ref.Name != "edge" {
+				return nil, errors.New("connection refused")
+			}
+			if mg.GetName() != "web" {
+				t.Errorf("newClient() called for %q, want a resource of the ProviderConfig", mg.GetName())
+			}
+			return edge, nil
+		},
+	}
+
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "estate"}}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := kube.Get(context.Background(), client.ObjectKeyFromObject(report), report); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	started := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	want := []apisv1beta1.ProviderConfigEstate{
+		{
+			Kind: "ProviderConfig", Name: "down",
+			Containers:     apisv1beta1.EstateCounts{Total: 1},
+			InventoryError: "cannot connect to the Docker host: connection refused",
+		},
+		{
+			Kind: "ProviderConfig", Name: "edge",
+			Containers: apisv1beta1.EstateCounts{Total: 1},
+			UnmanagedContainers: []apisv1beta1.UnmanagedContainer{
+				{Name: "backup", ID: "d4", Image: "restic/restic", State: "created"},
+				{Name: "watchtower", ID: "c3", Image: "containrrr/watchtower", State: "running", StartedAt: &started},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, report.Status.ProviderConfigs); diff != "" {
+		t.Errorf("Reconcile() ProviderConfigs -want +got:\n%s", diff)
+	}
+	if report.Status.Unmanaged != 2 {
+		t.Errorf("Reconcile() Unmanaged = %d, want 2", report.Status.Unmanaged)
+	}
+}
+
+func TestReconcileNotFound(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	r := &Reconciler{kube: fake.NewClientBuilder().WithScheme(scheme).Build(), logger: logging.NewNopLogger(), now: time.Now}
+
+	got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "gone"}})
+	if err != nil || got != (reconcile.Result{}) {
+		t.Errorf("Reconcile() = %v, %v, want no requeue and no error", got, err)
+	}
+}
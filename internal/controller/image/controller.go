@@ -0,0 +1,394 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package image reconciles Images: images kept pulled on a Docker host,
+// optionally pinned to a digest.
+package image
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/distribution/reference"
+	imagetypes "github.com/docker/docker/api/types/image"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+	"github.com/rossigee/provider-docker/internal/shutdown"
+	"github.com/rossigee/provider-docker/internal/tracing"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"time"
+)
+
+const (
+	errNotImage        = "managed resource is not an Image custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errNewClient       = "cannot create new Docker client"
+	errRegistryAuths   = "cannot get registry credentials"
+	errParseImage      = "cannot parse image reference %q"
+	errPinImage        = "cannot pin image %s to digest %s"
+	errInspect         = "cannot inspect image %s"
+	errPull            = "cannot pull image %s"
+	errPullCredentials = "cannot get credentials to pull image %s"
+	errRemove          = "cannot remove image %s"
+
+	// defaultRefreshInterval is how often an image with the Always pull
+	// policy is pulled again when the Image does not say.
+	defaultRefreshInterval = 24 * time.Hour
+)
+
+// SetupImage adds a controller that reconciles Image managed resources.
+func SetupImage(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ImageGroupKind.Kind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
+
+	backoff := clients.NewTerminalBackoff(o.PollInterval)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ImageGroupVersionKind),
+		managed.WithExternalConnector(backoff.Connector(dryrun.Connector(&connector{
+			kube:   mgr.GetClient(),
+			usage:  resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			logger: o.Logger,
+		}, recorder))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithFinalizer(clients.NewUsageFinalizer(mgr.GetClient())),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(nil))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Image{}).
+		Complete(ratelimiter.NewReconciler(name, backoff.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube   client.Client
+	usage  resource.Tracker
+	logger logging.Logger
+}
+
+// Connect produces an ExternalClient for the Docker host of the Image's
+// ProviderConfig, with the registry credentials to pull images with.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.Image)
+	if !ok {
+		return nil, errors.New(errNotImage)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	client, err := clients.NewDockerClient(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	auths, err := clients.RegistryAuths(ctx, c.kube, mg)
+	if err != nil {
+		_ = client.Close()
+		return nil, errors.Wrap(err, errRegistryAuths)
+	}
+
+	return &external{client: client, auths: auths, logger: c.logger, now: time.Now}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client clients.DockerClient
+	auths  map[string]clients.RegistryAuth
+	logger logging.Logger
+	now    func() time.Time
+}
+
+// Observe reports whether the image is on the host. It is up to date unless
+// its pull policy is Always and its refresh interval has passed. An image
+// pinned to a digest is never pulled again, since its content cannot change.
+// Once the Image is being deleted, an image it keeps on purpose no longer
+// counts as existing, so that its deletion completes.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ctx, span := tracing.StartSpan(ctx, "image.observe",
+		tracing.SpanAttrs("image", mg.GetName(), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.Image)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotImage)
+	}
+
+	ref, err := pullReference(cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, tracing.RecordError(span, err)
+	}
+
+	info, _, err := c.client.ImageInspectWithRaw(ctx, ref)
+	if isNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, tracing.RecordError(span, errors.Wrapf(err, errInspect, ref))
+	}
+
+	if meta.WasDeleted(cr) {
+		removable, err := c.removable(ctx, cr, ref)
+		if err != nil {
+			return managed.ExternalObservation{}, tracing.RecordError(span, err)
+		}
+		if !removable {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+	}
+
+	obs := &cr.Status.AtProvider
+	obs.ID = info.ID
+	obs.Digest = repoDigest(info.RepoDigests, ref)
+	obs.Size = info.Size
+	obs.Layers = len(info.RootFS.Layers)
+	cr.SetConditions(xpv1.Available())
+
+	// The status set by Create is not saved, so an image Create pulled is
+	// recorded as pulled when it succeeded.
+	if obs.PulledAt == nil {
+		if created := meta.GetExternalCreateSucceeded(cr); !created.IsZero() {
+			pulledAt := metav1.NewTime(created)
+			obs.PulledAt = &pulledAt
+		}
+	}
+
+	upToDate := true
+	if cr.Spec.ForProvider.Digest == nil && pullPolicy(cr) == v1alpha1.PullAlways {
+		upToDate = !refreshDue(cr, c.now())
+	}
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate}, nil
+}
+
+// Create pulls the image.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, span := tracing.StartSpan(ctx, "image.create",
+		tracing.SpanAttrs("image", mg.GetName(), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.Image)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotImage)
+	}
+
+	if err := c.pull(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+// Update pulls the image again once its refresh interval has passed, so
+// that a tag that has moved is updated.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	ctx, span := tracing.StartSpan(ctx, "image.update",
+		tracing.SpanAttrs("image", mg.GetName(), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.Image)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotImage)
+	}
+
+	if err := c.pull(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, tracing.RecordError(span, err)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete removes the image from the host if the Image says to. An image that
+// is in use by a container is kept.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	ctx, span := tracing.StartSpan(ctx, "image.delete",
+		tracing.SpanAttrs("image", mg.GetName(), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.Image)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotImage)
+	}
+
+	if p := cr.Spec.ForProvider.RemoveOnDelete; p == nil || !*p {
+		return managed.ExternalDelete{}, nil
+	}
+
+	ref, err := pullReference(cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalDelete{}, tracing.RecordError(span, err)
+	}
+
+	_, err = c.client.ImageRemove(ctx, ref, imagetypes.RemoveOptions{})
+	switch {
+	case err == nil, isNotFound(err):
+	case isInUse(err):
+		c.logger.Debug("Keeping image in use", "image", ref)
+	default:
+		return managed.ExternalDelete{}, tracing.RecordError(span, errors.Wrapf(err, errRemove, ref))
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+// Disconnect is called when the controller is shutting down.
+func (c *external) Disconnect(_ context.Context) error {
+	return c.client.Close()
+}
+
+// removable reports whether Delete would remove the image from the host:
+// whether the Image says to remove it, and it is not in use by a container.
+func (c *external) removable(ctx context.Context, cr *v1alpha1.Image, ref string) (bool, error) {
+	if p := cr.Spec.ForProvider.RemoveOnDelete; p == nil || !*p {
+		return false, nil
+	}
+	inUse, err := clients.ImageInUse(ctx, c.client, ref)
+	return !inUse, err
+}
+
+// pull pulls the image with the credentials for its registry, waiting for
+// the pull to complete, and records when it was pulled.
+func (c *external) pull(ctx context.Context, cr *v1alpha1.Image) error {
+	done, err := shutdown.Begin()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	ref, err := pullReference(cr.Spec.ForProvider)
+	if err != nil {
+		return err
+	}
+	auth, err := clients.PullAuth(c.auths, ref)
+	if err != nil {
+		return errors.Wrapf(err, errPullCredentials, ref)
+	}
+
+	pull, err := c.client.ImagePull(ctx, ref, imagetypes.PullOptions{RegistryAuth: auth})
+	if err != nil {
+		return errors.Wrapf(err, errPull, ref)
+	}
+	defer func() { _ = pull.Close() }()
+
+	if err := clients.ReadPullProgress(pull, nil); err != nil {
+		return errors.Wrapf(err, errPull, ref)
+	}
+
+	pulledAt := metav1.NewTime(c.now())
+	cr.Status.AtProvider.PulledAt = &pulledAt
+	return nil
+}
+
+// pullReference returns the reference the image is pulled and inspected by:
+// its repository and digest if it is pinned to one, and its reference as
+// given otherwise.
+func pullReference(p v1alpha1.ImageParameters) (string, error) {
+	if p.Digest == nil {
+		return p.Image, nil
+	}
+	named, err := reference.ParseNormalizedNamed(p.Image)
+	if err != nil {
+		return "", errors.Wrapf(err, errParseImage, p.Image)
+	}
+	pinned, err := reference.ParseNormalizedNamed(reference.TrimNamed(named).Name() + "@" + *p.Digest)
+	if err != nil {
+		return "", errors.Wrapf(err, errPinImage, p.Image, *p.Digest)
+	}
+	return reference.FamiliarString(pinned), nil
+}
+
+// repoDigest returns the digest of the image in the repository of ref, or
+// in its first repository if it has none there.
+func repoDigest(repoDigests []string, ref string) string {
+	repo := ""
+	if named, err := reference.ParseNormalizedNamed(ref); err == nil {
+		repo = named.Name()
+	}
+	first := ""
+	for _, rd := range repoDigests {
+		canonical, err := reference.ParseNormalizedNamed(rd)
+		if err != nil {
+			continue
+		}
+		digested, ok := canonical.(reference.Digested)
+		if !ok {
+			continue
+		}
+		if canonical.Name() == repo {
+			return digested.Digest().String()
+		}
+		if first == "" {
+			first = digested.Digest().String()
+		}
+	}
+	return first
+}
+
+// pullPolicy returns when the image is pulled.
+func pullPolicy(cr *v1alpha1.Image) v1alpha1.ImagePullPolicy {
+	if cr.Spec.ForProvider.PullPolicy == nil {
+		return v1alpha1.PullIfNotPresent
+	}
+	return *cr.Spec.ForProvider.PullPolicy
+}
+
+// refreshInterval returns how often the image is pulled again.
+func refreshInterval(cr *v1alpha1.Image) time.Duration {
+	if cr.Spec.ForProvider.RefreshInterval == nil || cr.Spec.ForProvider.RefreshInterval.Duration <= 0 {
+		return defaultRefreshInterval
+	}
+	return cr.Spec.ForProvider.RefreshInterval.Duration
+}
+
+// refreshDue reports whether the image is due to be pulled again at now.
+func refreshDue(cr *v1alpha1.Image, now time.Time) bool {
+	pulledAt := cr.Status.AtProvider.PulledAt
+	return pulledAt == nil || !now.Before(pulledAt.Add(refreshInterval(cr)))
+}
+
+// isNotFound reports whether err is Docker not finding an image.
+func isNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	errorMessage := strings.ToLower(err.Error())
+	return strings.Contains(errorMessage, "not found") ||
+		strings.Contains(errorMessage, "no such image")
+}
+
+// isInUse reports whether err is Docker declining to remove an image that a
+// container uses.
+func isInUse(err error) bool {
+	if err == nil {
+		return false
+	}
+	errorMessage := strings.ToLower(err.Error())
+	return strings.Contains(errorMessage, "is using its referenced image") ||
+		strings.Contains(errorMessage, "is being used by")
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	imagetypes "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const digest = "sha256:4d2d3bc4a8ea0dd7f6a4d3eb22c4e4b4a5e5f0f1c3b4a2e1d0c9b8a7f6e5d4c3"
+
+// fakeClient serves the Docker calls an Image makes from a map of images by
+// reference.
+type fakeClient struct {
+	clients.DockerClient
+	images  map[string]imagetypes.InspectResponse
+	inUse   bool
+	pulled  []string
+	auths   []string
+	removed []string
+
+	// pullError is reported in the progress of pulls, which fail after
+	// they have started.
+	pullError string
+}
+
+func (f *fakeClient) ImageInspectWithRaw(_ context.Context, ref string) (imagetypes.InspectResponse, []byte, error) {
+	info, ok := f.images[ref]
+	if !ok {
+		return imagetypes.InspectResponse{}, nil, errors.New("No such image: " + ref)
+	}
+	return info, nil, nil
+}
+
+func (f *fakeClient) ImagePull(_ context.Context, ref string, o imagetypes.PullOptions) (io.ReadCloser, error) {
+	f.pulled = append(f.pulled, ref)
+	f.auths = append(f.auths, o.RegistryAuth)
+	if f.pullError != "" {
+		return io.NopCloser(strings.NewReader(`{"status":"Pulling"}` + "\n" + `{"error":"` + f.pullError + `","errorDetail":{"message":"` + f.pullError + `"}}`)), nil
+	}
+	f.images[ref] = imagetypes.InspectResponse{ID: "sha256:" + ref}
+	return io.NopCloser(strings.NewReader("{}")), nil
+}
+
+func (f *fakeClient) ContainerList(_ context.Context, _ container.ListOptions) ([]container.Summary, error) {
+	if f.inUse {
+		return []container.Summary{{ID: "abc"}}, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) ImageRemove(_ context.Context, ref string, _ imagetypes.RemoveOptions) ([]imagetypes.DeleteResponse, error) {
+	if f.inUse {
+		return nil, errors.New("conflict: unable to remove repository reference \"" + ref + "\" (must force) - container abc is using its referenced image def")
+	}
+	f.removed = append(f.removed, ref)
+	delete(f.images, ref)
+	return nil, nil
+}
+
+var now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func img(ref string, policy v1alpha1.ImagePullPolicy, pulledAgo time.Duration) *v1alpha1.Image {
+	cr := &v1alpha1.Image{Spec: v1alpha1.ImageSpec{ForProvider: v1alpha1.ImageParameters{
+		Image:           ref,
+		PullPolicy:      &policy,
+		RefreshInterval: &metav1.Duration{Duration: time.Hour},
+	}}}
+	if pulledAgo > 0 {
+		t := metav1.NewTime(now.Add(-pulledAgo))
+		cr.Status.AtProvider.PulledAt = &t
+	}
+	return cr
+}
+
+func pinned(ref string) *v1alpha1.Image {
+	cr := img(ref, v1alpha1.PullAlways, 0)
+	d := digest
+	cr.Spec.ForProvider.Digest = &d
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	nginx := imagetypes.InspectResponse{
+		ID:          "sha256:a",
+		RepoDigests: []string{"mirror.local/nginx@sha256:" + strings.Repeat("0", 64), "nginx@" + digest},
+		Size:        1024,
+		RootFS:      imagetypes.RootFS{Layers: []string{"sha256:1", "sha256:2", "sha256:3"}},
+	}
+	remove := true
+	deleting := func(cr *v1alpha1.Image, removeOnDelete *bool) *v1alpha1.Image {
+		deleted := metav1.NewTime(now)
+		cr.SetDeletionTimestamp(&deleted)
+		cr.Spec.ForProvider.RemoveOnDelete = removeOnDelete
+		return cr
+	}
+	created := func(cr *v1alpha1.Image) *v1alpha1.Image {
+		meta.SetExternalCreateSucceeded(cr, now.Add(-time.Minute))
+		return cr
+	}
+
+	tests := map[string]struct {
+		cr           *v1alpha1.Image
+		images       map[string]imagetypes.InspectResponse
+		inUse        bool
+		wantExists   bool
+		wantUpToDate bool
+		wantReady    corev1.ConditionStatus
+	}{
+		"Missing": {
+			cr:        img("nginx:1.27", v1alpha1.PullIfNotPresent, 0),
+			images:    map[string]imagetypes.InspectResponse{},
+			wantReady: corev1.ConditionUnknown,
+		},
+		"IfNotPresent": {
+			cr:           img("nginx:1.27", v1alpha1.PullIfNotPresent, 0),
+			images:       map[string]imagetypes.InspectResponse{"nginx:1.27": nginx},
+			wantExists:   true,
+			wantUpToDate: true,
+			wantReady:    corev1.ConditionTrue,
+		},
+		"AlwaysRecentlyPulled": {
+			cr:           img("nginx:1.27", v1alpha1.PullAlways, time.Minute),
+			images:       map[string]imagetypes.InspectResponse{"nginx:1.27": nginx},
+			wantExists:   true,
+			wantUpToDate: true,
+			wantReady:    corev1.ConditionTrue,
+		},
+		"AlwaysRefreshDue": {
+			cr:         img("nginx:1.27", v1alpha1.PullAlways, 2*time.Hour),
+			images:     map[string]imagetypes.InspectResponse{"nginx:1.27": nginx},
+			wantExists: true,
+			wantReady:  corev1.ConditionTrue,
+		},
+		"PinnedPresent": {
+			cr:           pinned("nginx:1.27"),
+			images:       map[string]imagetypes.InspectResponse{"nginx@" + digest: nginx},
+			wantExists:   true,
+			wantUpToDate: true,
+			wantReady:    corev1.ConditionTrue,
+		},
+		"PinnedMissing": {
+			cr:        pinned("nginx:1.27"),
+			images:    map[string]imagetypes.InspectResponse{"nginx:1.27": nginx},
+			wantReady: corev1.ConditionUnknown,
+		},
+		"AlwaysPulledByCreate": {
+			cr:           created(img("nginx:1.27", v1alpha1.PullAlways, 0)),
+			images:       map[string]imagetypes.InspectResponse{"nginx:1.27": nginx},
+			wantExists:   true,
+			wantUpToDate: true,
+			wantReady:    corev1.ConditionTrue,
+		},
+		"DeletingKept": {
+			cr:        deleting(img("nginx:1.27", v1alpha1.PullIfNotPresent, 0), nil),
+			images:    map[string]imagetypes.InspectResponse{"nginx:1.27": nginx},
+			wantReady: corev1.ConditionUnknown,
+		},
+		"DeletingInUse": {
+			cr:        deleting(img("nginx:1.27", v1alpha1.PullIfNotPresent, 0), &remove),
+			images:    map[string]imagetypes.InspectResponse{"nginx:1.27": nginx},
+			inUse:     true,
+			wantReady: corev1.ConditionUnknown,
+		},
+		"DeletingRemovable": {
+			cr:           deleting(img("nginx:1.27", v1alpha1.PullIfNotPresent, 0), &remove),
+			images:       map[string]imagetypes.InspectResponse{"nginx:1.27": nginx},
+			wantExists:   true,
+			wantUpToDate: true,
+			wantReady:    corev1.ConditionTrue,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: &fakeClient{images: tt.images, inUse: tt.inUse}, logger: logging.NewNopLogger(), now: func() time.Time { return now }}
+			obs, err := e.Observe(context.Background(), tt.cr)
+			if err != nil {
+				t.Fatalf("Observe(): %v", err)
+			}
+			if obs.ResourceExists != tt.wantExists || obs.ResourceUpToDate != tt.wantUpToDate {
+				t.Errorf("Observe() = exists %v, up to date %v, want %v, %v", obs.ResourceExists, obs.ResourceUpToDate, tt.wantExists, tt.wantUpToDate)
+			}
+			if got := tt.cr.GetCondition(xpv1.TypeReady).Status; got != tt.wantReady {
+				t.Errorf("Ready = %s, want %s", got, tt.wantReady)
+			}
+			if !tt.wantExists {
+				return
+			}
+			want := v1alpha1.ImageObservation{ID: "sha256:a", Digest: digest, Size: 1024, Layers: 3, PulledAt: tt.cr.Status.AtProvider.PulledAt}
+			if got := tt.cr.Status.AtProvider; !reflect.DeepEqual(got, want) {
+				t.Errorf("AtProvider = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestPull(t *testing.T) {
+	auths := map[string]clients.RegistryAuth{"registry.example.com": {Username: "ci", Password: "secret"}}
+	tests := map[string]struct {
+		cr         *v1alpha1.Image
+		wantPulled string
+		wantUser   string
+	}{
+		"Tag": {
+			cr:         img("nginx:1.27", v1alpha1.PullAlways, 0),
+			wantPulled: "nginx:1.27",
+		},
+		"Digest": {
+			cr:         pinned("nginx:1.27"),
+			wantPulled: "nginx@" + digest,
+		},
+		"PrivateRegistry": {
+			cr:         img("registry.example.com/team/app:2.4", v1alpha1.PullIfNotPresent, 0),
+			wantPulled: "registry.example.com/team/app:2.4",
+			wantUser:   "ci",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &fakeClient{images: map[string]imagetypes.InspectResponse{}}
+			e := &external{client: f, auths: auths, logger: logging.NewNopLogger(), now: func() time.Time { return now }}
+			if _, err := e.Create(context.Background(), tt.cr); err != nil {
+				t.Fatalf("Create(): %v", err)
+			}
+			if want := []string{tt.wantPulled}; !reflect.DeepEqual(f.pulled, want) {
+				t.Fatalf("pulled %v, want %v", f.pulled, want)
+			}
+			if got := pullUser(t, f.auths[0]); got != tt.wantUser {
+				t.Errorf("pulled as %q, want %q", got, tt.wantUser)
+			}
+			if p := tt.cr.Status.AtProvider.PulledAt; p == nil || !p.Time.Equal(now) {
+				t.Errorf("PulledAt = %v, want %v", p, now)
+			}
+		})
+	}
+}
+
+func TestPullFailedInProgress(t *testing.T) {
+	f := &fakeClient{images: map[string]imagetypes.InspectResponse{}, pullError: "manifest unknown"}
+	e := &external{client: f, logger: logging.NewNopLogger(), now: func() time.Time { return now }}
+	cr := img("nginx:1.27", v1alpha1.PullAlways, time.Hour)
+	pulledAt := cr.Status.AtProvider.PulledAt
+
+	_, err := e.Update(context.Background(), cr)
+	if err == nil || !strings.Contains(err.Error(), "manifest unknown") {
+		t.Fatalf("Update(): %v, want the error reported in the pull's progress", err)
+	}
+	if cr.Status.AtProvider.PulledAt != pulledAt {
+		t.Errorf("PulledAt = %v, want it unchanged by a failed pull", cr.Status.AtProvider.PulledAt)
+	}
+}
+
+// pullUser decodes the username of the credentials a pull was made with.
+func pullUser(t *testing.T, encoded string) string {
+	t.Helper()
+	if encoded == "" {
+		return ""
+	}
+	raw, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode registry auth: %v", err)
+	}
+	var auth registry.AuthConfig
+	if err := json.Unmarshal(raw, &auth); err != nil {
+		t.Fatalf("cannot unmarshal registry auth: %v", err)
+	}
+	return auth.Username
+}
+
+func TestDelete(t *testing.T) {
+	remove := true
+	tests := map[string]struct {
+		removeOnDelete *bool
+		inUse          bool
+		wantRemoved    []string
+	}{
+		"Kept":    {},
+		"Removed": {removeOnDelete: &remove, wantRemoved: []string{"nginx:1.27"}},
+		"InUse":   {removeOnDelete: &remove, inUse: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := img("nginx:1.27", v1alpha1.PullIfNotPresent, 0)
+			cr.Spec.ForProvider.RemoveOnDelete = tt.removeOnDelete
+			f := &fakeClient{images: map[string]imagetypes.InspectResponse{"nginx:1.27": {ID: "sha256:a"}}, inUse: tt.inUse}
+			e := &external{client: f, logger: logging.NewNopLogger(), now: func() time.Time { return now }}
+			if _, err := e.Delete(context.Background(), cr); err != nil {
+				t.Fatalf("Delete(): %v", err)
+			}
+			if !reflect.DeepEqual(f.removed, tt.wantRemoved) {
+				t.Errorf("removed %v, want %v", f.removed, tt.wantRemoved)
+			}
+		})
+	}
+}
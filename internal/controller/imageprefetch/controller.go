@@ -0,0 +1,371 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imageprefetch reconciles ImagePrefetches: sets of images kept
+// pulled on a Docker host, so that they are present before the workloads
+// that use them are scheduled.
+package imageprefetch
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/image"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+	"github.com/rossigee/provider-docker/internal/shutdown"
+	"github.com/rossigee/provider-docker/internal/tracing"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"time"
+)
+
+const (
+	errNotImagePrefetch = "managed resource is not an ImagePrefetch custom resource"
+	errTrackPCUsage     = "cannot track ProviderConfig usage"
+	errNewClient        = "cannot create new Docker client"
+	errRegistryAuths    = "cannot get registry credentials"
+
+	errInspect = "cannot inspect image %s"
+	errPull    = "cannot pull image %s"
+	errRemove  = "cannot remove image %s"
+
+	// defaultRefreshInterval is how often images are pulled again when the
+	// ImagePrefetch does not say.
+	defaultRefreshInterval = 24 * time.Hour
+)
+
+// SetupImagePrefetch adds a controller that reconciles ImagePrefetch managed
+// resources.
+func SetupImagePrefetch(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ImagePrefetchGroupKind.Kind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
+
+	backoff := clients.NewTerminalBackoff(o.PollInterval)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ImagePrefetchGroupVersionKind),
+		managed.WithExternalConnector(backoff.Connector(dryrun.Connector(&connector{
+			kube:   mgr.GetClient(),
+			usage:  resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			logger: o.Logger,
+		}, recorder))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithFinalizer(clients.NewUsageFinalizer(mgr.GetClient())),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(nil))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.ImagePrefetch{}).
+		Complete(ratelimiter.NewReconciler(name, backoff.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube   client.Client
+	usage  resource.Tracker
+	logger logging.Logger
+}
+
+// Connect produces an ExternalClient for the Docker host of the
+// ImagePrefetch's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.ImagePrefetch)
+	if !ok {
+		return nil, errors.New(errNotImagePrefetch)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	client, err := clients.NewDockerClient(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	auths, err := clients.RegistryAuths(ctx, c.kube, mg)
+	if err != nil {
+		_ = client.Close()
+		return nil, errors.Wrap(err, errRegistryAuths)
+	}
+
+	return &external{client: client, auths: auths, logger: c.logger, now: time.Now}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client clients.DockerClient
+	auths  map[string]clients.RegistryAuth
+	logger logging.Logger
+	now    func() time.Time
+}
+
+// Observe reports which of the images are present on the host. The images
+// exist once any of them is present, and are up to date while all of them
+// are present and their refresh interval has not passed. Once the
+// ImagePrefetch is being deleted, images it keeps on purpose no longer count
+// as existing, so that its deletion completes.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ctx, span := tracing.StartSpan(ctx, "imageprefetch.observe",
+		tracing.SpanAttrs("imageprefetch", mg.GetName(), "observe")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.ImagePrefetch)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotImagePrefetch)
+	}
+
+	images := make([]v1alpha1.PrefetchedImage, 0, len(cr.Spec.ForProvider.Images))
+	present := 0
+	for _, ref := range cr.Spec.ForProvider.Images {
+		observed := v1alpha1.PrefetchedImage{Image: ref}
+		info, _, err := c.client.ImageInspectWithRaw(ctx, ref)
+		switch {
+		case err == nil:
+			observed.ID = info.ID
+			observed.Present = true
+			present++
+		case !isNotFound(err):
+			return managed.ExternalObservation{}, tracing.RecordError(span, errors.Wrapf(err, errInspect, ref))
+		}
+		images = append(images, observed)
+	}
+	cr.Status.AtProvider.Images = images
+	cr.Status.AtProvider.Present = present
+
+	if present == 0 {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if meta.WasDeleted(cr) {
+		removable, err := c.removable(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, tracing.RecordError(span, err)
+		}
+		if !removable {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+	}
+
+	// The status set by Create is not saved, so images Create pulled are
+	// recorded as refreshed when it succeeded.
+	if cr.Status.AtProvider.RefreshedAt == nil && present == len(images) {
+		if created := meta.GetExternalCreateSucceeded(cr); !created.IsZero() {
+			refreshedAt := metav1.NewTime(created)
+			cr.Status.AtProvider.RefreshedAt = &refreshedAt
+		}
+	}
+
+	if present == len(images) {
+		cr.SetConditions(xpv1.Available())
+	} else {
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: present == len(images) && !refreshDue(cr, c.now()),
+	}, nil
+}
+
+// Create pulls the images.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, span := tracing.StartSpan(ctx, "imageprefetch.create",
+		tracing.SpanAttrs("imageprefetch", mg.GetName(), "create")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.ImagePrefetch)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotImagePrefetch)
+	}
+
+	if err := c.pullAll(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, tracing.RecordError(span, err)
+	}
+	return managed.ExternalCreation{}, nil
+}
+
+// Update pulls the images again, both those that are missing and, once the
+// refresh interval has passed, those that are present so that tags that
+// have moved are updated.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	ctx, span := tracing.StartSpan(ctx, "imageprefetch.update",
+		tracing.SpanAttrs("imageprefetch", mg.GetName(), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.ImagePrefetch)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotImagePrefetch)
+	}
+
+	if err := c.pullAll(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, tracing.RecordError(span, err)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete removes the images from the host if the ImagePrefetch says to.
+// Images that are in use by a container are kept.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	ctx, span := tracing.StartSpan(ctx, "imageprefetch.delete",
+		tracing.SpanAttrs("imageprefetch", mg.GetName(), "delete")...)
+	defer span.End()
+
+	cr, ok := mg.(*v1alpha1.ImagePrefetch)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotImagePrefetch)
+	}
+
+	if p := cr.Spec.ForProvider.RemoveOnDelete; p == nil || !*p {
+		return managed.ExternalDelete{}, nil
+	}
+
+	for _, ref := range cr.Spec.ForProvider.Images {
+		_, err := c.client.ImageRemove(ctx, ref, image.RemoveOptions{})
+		switch {
+		case err == nil, isNotFound(err):
+		case isInUse(err):
+			c.logger.Debug("Keeping image in use", "image", ref)
+		default:
+			return managed.ExternalDelete{}, tracing.RecordError(span, errors.Wrapf(err, errRemove, ref))
+		}
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+// Disconnect is called when the controller is shutting down.
+func (c *external) Disconnect(_ context.Context) error {
+	return c.client.Close()
+}
+
+// removable reports whether Delete would remove any of the images present on
+// the host: whether the ImagePrefetch says to remove them, and any of them is
+// not in use by a container.
+func (c *external) removable(ctx context.Context, cr *v1alpha1.ImagePrefetch) (bool, error) {
+	if p := cr.Spec.ForProvider.RemoveOnDelete; p == nil || !*p {
+		return false, nil
+	}
+	for _, observed := range cr.Status.AtProvider.Images {
+		if !observed.Present {
+			continue
+		}
+		inUse, err := clients.ImageInUse(ctx, c.client, observed.Image)
+		if err != nil {
+			return false, err
+		}
+		if !inUse {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// pullAll pulls each of the images, recording when they were refreshed once
+// all of them have been pulled. An image that cannot be pulled, such as
+// while the host is out of reach of its registry, does not stop the others
+// from being pulled.
+func (c *external) pullAll(ctx context.Context, cr *v1alpha1.ImagePrefetch) error {
+	done, err := shutdown.Begin()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	var failed []string
+	var firstErr error
+	for _, ref := range cr.Spec.ForProvider.Images {
+		if err := c.pull(ctx, ref); err != nil {
+			c.logger.Debug("Cannot pull image", "image", ref, "error", err)
+			failed = append(failed, ref)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr != nil {
+		return errors.Wrapf(firstErr, "cannot pull %d of %d images (%s)", len(failed), len(cr.Spec.ForProvider.Images), strings.Join(failed, ", "))
+	}
+
+	refreshedAt := metav1.NewTime(c.now())
+	cr.Status.AtProvider.RefreshedAt = &refreshedAt
+	return nil
+}
+
+// pull pulls an image with the credentials for its registry, waiting for the
+// pull to complete.
+func (c *external) pull(ctx context.Context, ref string) error {
+	auth, err := clients.PullAuth(c.auths, ref)
+	if err != nil {
+		return err
+	}
+	pull, err := c.client.ImagePull(ctx, ref, image.PullOptions{RegistryAuth: auth})
+	if err != nil {
+		return errors.Wrapf(err, errPull, ref)
+	}
+	defer func() { _ = pull.Close() }()
+
+	return errors.Wrapf(clients.ReadPullProgress(pull, nil), errPull, ref)
+}
+
+// refreshInterval returns how often the images are pulled again.
+func refreshInterval(cr *v1alpha1.ImagePrefetch) time.Duration {
+	if cr.Spec.ForProvider.RefreshInterval == nil || cr.Spec.ForProvider.RefreshInterval.Duration <= 0 {
+		return defaultRefreshInterval
+	}
+	return cr.Spec.ForProvider.RefreshInterval.Duration
+}
+
+// refreshDue reports whether the images are due to be pulled again at now.
+func refreshDue(cr *v1alpha1.ImagePrefetch, now time.Time) bool {
+	refreshedAt := cr.Status.AtProvider.RefreshedAt
+	return refreshedAt == nil || !now.Before(refreshedAt.Add(refreshInterval(cr)))
+}
+
+// isNotFound reports whether err is Docker not finding an image.
+func isNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	errorMessage := strings.ToLower(err.Error())
+	return strings.Contains(errorMessage, "not found") ||
+		strings.Contains(errorMessage, "no such image")
+}
+
+// isInUse reports whether err is Docker declining to remove an image that a
+// container uses.
+func isInUse(err error) bool {
+	if err == nil {
+		return false
+	}
+	errorMessage := strings.ToLower(err.Error())
+	return strings.Contains(errorMessage, "is using its referenced image") ||
+		strings.Contains(errorMessage, "is being used by")
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imageprefetch
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClient serves the Docker calls an ImagePrefetch makes from a map of
+// image IDs by reference. Pulling an image in unreachable fails.
+type fakeClient struct {
+	clients.DockerClient
+	images      map[string]string
+	unreachable map[string]bool
+	inUse       map[string]bool
+	pulled      []string
+	auths       []string
+	removed     []string
+}
+
+func (f *fakeClient) ImageInspectWithRaw(_ context.Context, ref string) (image.InspectResponse, []byte, error) {
+	id, ok := f.images[ref]
+	if !ok {
+		return image.InspectResponse{}, nil, errors.New("No such image: " + ref)
+	}
+	return image.InspectResponse{ID: id}, nil, nil
+}
+
+func (f *fakeClient) ImagePull(_ context.Context, ref string, opts image.PullOptions) (io.ReadCloser, error) {
+	if f.unreachable[ref] {
+		return nil, errors.New("dial tcp: lookup registry: no such host")
+	}
+	f.pulled = append(f.pulled, ref)
+	f.auths = append(f.auths, opts.RegistryAuth)
+	f.images[ref] = "sha256:" + ref
+	return io.NopCloser(strings.NewReader("{}")), nil
+}
+
+func (f *fakeClient) ContainerList(_ context.Context, o container.ListOptions) ([]container.Summary, error) {
+	var list []container.Summary
+	for ref, inUse := range f.inUse {
+		if inUse && o.Filters.ExactMatch("ancestor", ref) {
+			list = append(list, container.Summary{Image: ref})
+		}
+	}
+	return list, nil
+}
+
+func (f *fakeClient) ImageRemove(_ context.Context, ref string, _ image.RemoveOptions) ([]image.DeleteResponse, error) {
+	if f.inUse[ref] {
+		return nil, errors.New("conflict: unable to remove repository reference \"" + ref + "\" (must force) - container abc is using its referenced image def")
+	}
+	if _, ok := f.images[ref]; !ok {
+		return nil, errors.New("No such image: " + ref)
+	}
+	f.removed = append(f.removed, ref)
+	delete(f.images, ref)
+	return nil, nil
+}
+
+func prefetch(refreshedAgo time.Duration, images ...string) *v1alpha1.ImagePrefetch {
+	cr := &v1alpha1.ImagePrefetch{Spec: v1alpha1.ImagePrefetchSpec{ForProvider: v1alpha1.ImagePrefetchParameters{
+		Images:          images,
+		RefreshInterval: &metav1.Duration{Duration: time.Hour},
+	}}}
+	if refreshedAgo > 0 {
+		t := metav1.NewTime(now.Add(-refreshedAgo))
+		cr.Status.AtProvider.RefreshedAt = &t
+	}
+	return cr
+}
+
+var now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func TestObserve(t *testing.T) {
+	remove := true
+	deleting := func(cr *v1alpha1.ImagePrefetch, removeOnDelete *bool) *v1alpha1.ImagePrefetch {
+		deleted := metav1.NewTime(now)
+		cr.SetDeletionTimestamp(&deleted)
+		cr.Spec.ForProvider.RemoveOnDelete = removeOnDelete
+		return cr
+	}
+	created := func(cr *v1alpha1.ImagePrefetch) *v1alpha1.ImagePrefetch {
+		cr.Status.AtProvider.RefreshedAt = nil
+		meta.SetExternalCreateSucceeded(cr, now.Add(-time.Minute))
+		return cr
+	}
+
+	tests := map[string]struct {
+		cr           *v1alpha1.ImagePrefetch
+		images       map[string]string
+		inUse        map[string]bool
+		wantExists   bool
+		wantUpToDate bool
+		wantPresent  int
+		wantReady    corev1.ConditionStatus
+	}{
+		"NonePresent": {
+			cr:         prefetch(0, "nginx:1.27", "redis:7"),
+			images:     map[string]string{},
+			wantExists: false,
+			wantReady:  corev1.ConditionUnknown,
+		},
+		"SomeMissing": {
+			cr:          prefetch(time.Minute, "nginx:1.27", "redis:7"),
+			images:      map[string]string{"nginx:1.27": "sha256:a"},
+			wantExists:  true,
+			wantPresent: 1,
+			wantReady:   corev1.ConditionFalse,
+		},
+		"AllPresent": {
+			cr:           prefetch(time.Minute, "nginx:1.27", "redis:7"),
+			images:       map[string]string{"nginx:1.27": "sha256:a", "redis:7": "sha256:b"},
+			wantExists:   true,
+			wantUpToDate: true,
+			wantPresent:  2,
+			wantReady:    corev1.ConditionTrue,
+		},
+		"RefreshDue": {
+			cr:          prefetch(2*time.Hour, "nginx:1.27", "redis:7"),
+			images:      map[string]string{"nginx:1.27": "sha256:a", "redis:7": "sha256:b"},
+			wantExists:  true,
+			wantPresent: 2,
+			wantReady:   corev1.ConditionTrue,
+		},
+		"PulledByCreate": {
+			cr:           created(prefetch(0, "nginx:1.27", "redis:7")),
+			images:       map[string]string{"nginx:1.27": "sha256:a", "redis:7": "sha256:b"},
+			wantExists:   true,
+			wantUpToDate: true,
+			wantPresent:  2,
+			wantReady:    corev1.ConditionTrue,
+		},
+		"DeletingKept": {
+			cr:          deleting(prefetch(time.Minute, "nginx:1.27", "redis:7"), nil),
+			images:      map[string]string{"nginx:1.27": "sha256:a", "redis:7": "sha256:b"},
+			wantPresent: 2,
+			wantReady:   corev1.ConditionUnknown,
+		},
+		"DeletingInUse": {
+			cr:          deleting(prefetch(time.Minute, "nginx:1.27", "redis:7"), &remove),
+			images:      map[string]string{"nginx:1.27": "sha256:a", "redis:7": "sha256:b"},
+			inUse:       map[string]bool{"nginx:1.27": true, "redis:7": true},
+			wantPresent: 2,
+			wantReady:   corev1.ConditionUnknown,
+		},
+		"DeletingRemovable": {
+			cr:           deleting(prefetch(time.Minute, "nginx:1.27", "redis:7"), &remove),
+			images:       map[string]string{"nginx:1.27": "sha256:a", "redis:7": "sha256:b"},
+			inUse:        map[string]bool{"redis:7": true},
+			wantExists:   true,
+			wantUpToDate: true,
+			wantPresent:  2,
+			wantReady:    corev1.ConditionTrue,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: &fakeClient{images: tt.images, inUse: tt.inUse}, logger: logging.NewNopLogger(), now: func() time.Time { return now }}
+			obs, err := e.Observe(context.Background(), tt.cr)
+			if err != nil {
+				t.Fatalf("Observe(): %v", err)
+			}
+			if obs.ResourceExists != tt.wantExists || obs.ResourceUpToDate != tt.wantUpToDate {
+				t.Errorf("Observe() = exists %v, up to date %v, want %v, %v", obs.ResourceExists, obs.ResourceUpToDate, tt.wantExists, tt.wantUpToDate)
+			}
+			if got := tt.cr.Status.AtProvider.Present; got != tt.wantPresent {
+				t.Errorf("Present = %d, want %d", got, tt.wantPresent)
+			}
+			if got := tt.cr.GetCondition(xpv1.TypeReady).Status; got != tt.wantReady {
+				t.Errorf("Ready = %s, want %s", got, tt.wantReady)
+			}
+		})
+	}
+}
+
+func TestPullAll(t *testing.T) {
+	t.Run("AllPulled", func(t *testing.T) {
+		cr := prefetch(0, "nginx:1.27", "redis:7")
+		f := &fakeClient{images: map[string]string{"nginx:1.27": "sha256:a"}}
+		e := &external{client: f, logger: logging.NewNopLogger(), now: func() time.Time { return now }}
+		if _, err := e.Update(context.Background(), cr); err != nil {
+			t.Fatalf("Update(): %v", err)
+		}
+		if want := []string{"nginx:1.27", "redis:7"}; !reflect.DeepEqual(f.pulled, want) {
+			t.Errorf("pulled %v, want %v", f.pulled, want)
+		}
+		if r := cr.Status.AtProvider.RefreshedAt; r == nil || !r.Time.Equal(now) {
+			t.Errorf("RefreshedAt = %v, want %v", r, now)
+		}
+	})
+
+	t.Run("WithRegistryAuth", func(t *testing.T) {
+		cr := prefetch(0, "ghcr.io/org/app:1", "redis:7")
+		f := &fakeClient{images: map[string]string{}}
+		e := &external{
+			client: f,
+			auths:  map[string]clients.RegistryAuth{"ghcr.io": {Username: "gh-user", Password: "gh-token"}},
+			logger: logging.NewNopLogger(),
+			now:    func() time.Time { return now },
+		}
+		if _, err := e.Create(context.Background(), cr); err != nil {
+			t.Fatalf("Create(): %v", err)
+		}
+		if len(f.auths) != 2 || f.auths[0] == "" || f.auths[1] != "" {
+			t.Errorf("pulled with credentials %q, want them only for ghcr.io", f.auths)
+		}
+	})
+
+	t.Run("SomeUnreachable", func(t *testing.T) {
+		cr := prefetch(0, "registry.local/app:1", "redis:7")
+		f := &fakeClient{images: map[string]string{}, unreachable: map[string]bool{"registry.local/app:1": true}}
+		e := &external{client: f, logger: logging.NewNopLogger(), now: func() time.Time { return now }}
+		if _, err := e.Create(context.Background(), cr); err == nil {
+			t.Fatal("Create() succeeded, want error")
+		}
+		if want := []string{"redis:7"}; !reflect.DeepEqual(f.pulled, want) {
+			t.Errorf("pulled %v, want %v", f.pulled, want)
+		}
+		if cr.Status.AtProvider.RefreshedAt != nil {
+			t.Error("RefreshedAt set although an image could not be pulled")
+		}
+	})
+}
+
+func TestDelete(t *testing.T) {
+	remove := true
+	tests := map[string]struct {
+		removeOnDelete *bool
+		wantRemoved    []string
+	}{
+		"Kept":    {},
+		"Removed": {removeOnDelete: &remove, wantRemoved: []string{"nginx:1.27"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := prefetch(0, "nginx:1.27", "redis:7", "busybox")
+			cr.Spec.ForProvider.RemoveOnDelete = tt.removeOnDelete
+			f := &fakeClient{
+				images: map[string]string{"nginx:1.27": "sha256:a", "redis:7": "sha256:b"},
+				inUse:  map[string]bool{"redis:7": true},
+			}
+			e := &external{client: f, logger: logging.NewNopLogger(), now: func() time.Time { return now }}
+			if _, err := e.Delete(context.Background(), cr); err != nil {
+				t.Fatalf("Delete(): %v", err)
+			}
+			if !reflect.DeepEqual(f.removed, tt.wantRemoved) {
+				t.Errorf("removed %v, want %v", f.removed, tt.wantRemoved)
+			}
+		})
+	}
+}
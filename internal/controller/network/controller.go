@@ -19,6 +19,7 @@ package network
 import (
 	"context"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
@@ -29,10 +30,16 @@ import (
 	"github.com/pkg/errors"
 	networkv1alpha1 "github.com/rossigee/provider-docker/apis/network/v1alpha1"
 	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+	"github.com/rossigee/provider-docker/internal/shutdown"
 	"github.com/rossigee/provider-docker/internal/tracing"
+	"github.com/rossigee/provider-docker/pkg/labels"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"math"
+	"net/netip"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
 	"strings"
 )
 
@@ -47,20 +54,24 @@ const (
 	errNetworkInspect = "cannot inspect network"
 	errNetworkCreate  = "cannot create network"
 	errNetworkRemove  = "cannot remove network"
+	errNetworkInUse   = "network is in use by %d container(s); set the %s annotation to \"true\" to delete it anyway"
 )
 
 // SetupNetwork adds a controller that reconciles Network managed resources.
 func SetupNetwork(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(networkv1alpha1.NetworkGroupKind.Kind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
 
+	backoff := clients.NewTerminalBackoff(o.PollInterval)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(networkv1alpha1.NetworkGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(backoff.Connector(dryrun.Connector(&connector{
 			kube:   mgr.GetClient(),
 			usage:  resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
 			logger: o.Logger,
-		}),
+		}, recorder))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithFinalizer(clients.NewUsageFinalizer(mgr.GetClient())),
 		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(nil))
 
@@ -69,7 +80,7 @@ func SetupNetwork(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&networkv1alpha1.Network{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, backoff.Reconciler(r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -111,7 +122,7 @@ type external struct {
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	_, span := tracing.StartSpan(ctx, "network.observe",
+	ctx, span := tracing.StartSpan(ctx, "network.observe",
 		tracing.SpanAttrs("network", mg.GetName(), "observe")...)
 	defer span.End()
 
@@ -146,7 +157,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	_, span := tracing.StartSpan(ctx, "network.create",
+	ctx, span := tracing.StartSpan(ctx, "network.create",
 		tracing.SpanAttrs("network", mg.GetName(), "create")...)
 	defer span.End()
 
@@ -155,6 +166,12 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotNetwork)
 	}
 
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	defer done()
+
 	c.logger.Debug("Creating network", "name", cr.Name)
 
 	name, opts := c.buildCreateOptions(cr)
@@ -184,7 +201,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
-	_, span := tracing.StartSpan(ctx, "network.delete",
+	ctx, span := tracing.StartSpan(ctx, "network.delete",
 		tracing.SpanAttrs("network", mg.GetName(), "delete")...)
 	defer span.End()
 
@@ -193,14 +210,31 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalDelete{}, errors.New(errNotNetwork)
 	}
 
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	defer done()
+
 	networkName := meta.GetExternalName(cr)
 	if networkName == "" {
 		return managed.ExternalDelete{}, nil
 	}
 
+	if getBoolValue(cr.Spec.ForProvider.PreventDeleteWhileInUse, false) &&
+		cr.GetAnnotations()[labels.AnnotationForceDelete] != "true" {
+		netInspect, err := c.client.NetworkInspect(ctx, networkName, network.InspectOptions{})
+		if err != nil && !isNotFoundError(err) {
+			return managed.ExternalDelete{}, errors.Wrap(err, errNetworkInspect)
+		}
+		if n := len(netInspect.Containers); n > 0 {
+			return managed.ExternalDelete{}, errors.Errorf(errNetworkInUse, n, labels.AnnotationForceDelete)
+		}
+	}
+
 	c.logger.Debug("Deleting network", "name", networkName)
 
-	err := c.client.NetworkRemove(ctx, networkName)
+	err = c.client.NetworkRemove(ctx, networkName)
 	if err != nil && !isNotFoundError(err) {
 		return managed.ExternalDelete{}, errors.Wrap(err, errNetworkRemove)
 	}
@@ -316,9 +350,71 @@ func (c *external) updateStatus(cr *networkv1alpha1.Network, netInspect network.
 		}
 	}
 
+	ids := make([]string, 0, len(netInspect.Containers))
+	for id := range netInspect.Containers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	cr.Status.AtProvider.ConnectedContainers = len(ids)
+	cr.Status.AtProvider.ConnectedContainerIDs = ids
+	cr.Status.AtProvider.SubnetUtilization = subnetUtilization(netInspect)
+
 	cr.SetConditions(xpv1.Available())
 }
 
+// subnetUtilization counts the container addresses allocated in each IPAM
+// subnet of the network.
+func subnetUtilization(netInspect network.Inspect) []networkv1alpha1.SubnetUtilization {
+	var addrs []netip.Addr
+	for _, container := range netInspect.Containers {
+		for _, a := range []string{container.IPv4Address, container.IPv6Address} {
+			if prefix, err := netip.ParsePrefix(a); err == nil {
+				addrs = append(addrs, prefix.Addr())
+			}
+		}
+	}
+
+	var utilization []networkv1alpha1.SubnetUtilization
+	for _, cfg := range netInspect.IPAM.Config {
+		subnet, err := netip.ParsePrefix(cfg.Subnet)
+		if err != nil {
+			continue
+		}
+
+		u := networkv1alpha1.SubnetUtilization{
+			Subnet:   subnet.String(),
+			Capacity: subnetCapacity(subnet),
+		}
+		for _, addr := range addrs {
+			if subnet.Contains(addr) {
+				u.Allocated++
+			}
+		}
+		if u.Capacity > 0 {
+			u.UtilizationPercent = int32(u.Allocated * 100 / u.Capacity)
+		}
+		utilization = append(utilization, u)
+	}
+	return utilization
+}
+
+// subnetCapacity returns the number of addresses in a subnet that can be
+// assigned to containers. The network, broadcast and gateway addresses are
+// reserved, except in IPv4 /31 and /32 subnets, which have no network or
+// broadcast address. IPv6 subnets too large to count are reported as
+// math.MaxInt64.
+func subnetCapacity(subnet netip.Prefix) int64 {
+	hostBits := subnet.Addr().BitLen() - subnet.Bits()
+	if hostBits >= 63 {
+		return math.MaxInt64
+	}
+	reserved := int64(3)
+	if subnet.Addr().Is4() && hostBits <= 1 {
+		reserved = 1
+	}
+	return max(int64(1)<<hostBits-reserved, 0)
+}
+
 // isUpToDate checks if the current network matches the desired specification.
 func (c *external) isUpToDate(cr *networkv1alpha1.Network, netInspect network.Inspect) bool {
 	spec := cr.Spec.ForProvider
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/docker/docker/api/types/network"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	networkv1alpha1 "github.com/rossigee/provider-docker/apis/network/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	"math"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+// fakeClient serves the Docker calls deleting a network makes from a map of
+// the containers attached to each network by name.
+type fakeClient struct {
+	clients.DockerClient
+	attached map[string]map[string]network.EndpointResource
+	removed  []string
+}
+
+func (f *fakeClient) NetworkInspect(_ context.Context, name string, _ network.InspectOptions) (network.Inspect, error) {
+	containers, ok := f.attached[name]
+	if !ok {
+		return network.Inspect{}, errors.New("network " + name + " not found")
+	}
+	return network.Inspect{Name: name, Containers: containers}, nil
+}
+
+func (f *fakeClient) NetworkRemove(_ context.Context, name string) error {
+	f.removed = append(f.removed, name)
+	return nil
+}
+
+func TestSubnetCapacity(t *testing.T) {
+	tests := map[string]struct {
+		subnet string
+		want   int64
+	}{
+		"IPv4Slash24":  {subnet: "172.20.0.0/24", want: 253},
+		"IPv4Slash16":  {subnet: "172.20.0.0/16", want: 65533},
+		"IPv4Slash31":  {subnet: "172.20.0.0/31", want: 1},
+		"IPv4Slash32":  {subnet: "172.20.0.1/32", want: 0},
+		"IPv6Slash64":  {subnet: "fd00:1::/64", want: math.MaxInt64},
+		"IPv6Slash65":  {subnet: "fd00:1::/65", want: math.MaxInt64},
+		"IPv6Slash66":  {subnet: "fd00:1::/66", want: 1<<62 - 3},
+		"IPv6Slash120": {subnet: "fd00:1::/120", want: 253},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := subnetCapacity(netip.MustParsePrefix(tt.subnet)); got != tt.want {
+				t.Errorf("subnetCapacity(%s) = %d, want %d", tt.subnet, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubnetUtilization(t *testing.T) {
+	tests := map[string]struct {
+		inspect network.Inspect
+		want    []networkv1alpha1.SubnetUtilization
+	}{
+		"NoIPAM": {},
+		"IPv4": {
+			inspect: network.Inspect{
+				IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.20.0.0/24"}}},
+				Containers: map[string]network.EndpointResource{
+					"a": {IPv4Address: "172.20.0.2/24"},
+					"b": {IPv4Address: "172.20.0.3/24"},
+					"c": {IPv4Address: "10.0.0.2/24"},
+				},
+			},
+			want: []networkv1alpha1.SubnetUtilization{{Subnet: "172.20.0.0/24", Capacity: 253, Allocated: 2}},
+		},
+		"DualStack": {
+			inspect: network.Inspect{
+				IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.20.0.0/30"}, {Subnet: "fd00:1::/64"}}},
+				Containers: map[string]network.EndpointResource{
+					"a": {IPv4Address: "172.20.0.2/30", IPv6Address: "fd00:1::2/64"},
+				},
+			},
+			want: []networkv1alpha1.SubnetUtilization{
+				{Subnet: "172.20.0.0/30", Capacity: 1, Allocated: 1, UtilizationPercent: 100},
+				{Subnet: "fd00:1::/64", Capacity: math.MaxInt64, Allocated: 1},
+			},
+		},
+		"UnparsableSubnet": {
+			inspect: network.Inspect{
+				IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "not-a-subnet"}, {Subnet: "172.20.0.0/24"}}},
+				Containers: map[string]network.EndpointResource{
+					"a": {IPv4Address: "not-an-address"},
+					"b": {IPv4Address: "172.20.0.2/24"},
+				},
+			},
+			want: []networkv1alpha1.SubnetUtilization{{Subnet: "172.20.0.0/24", Capacity: 253, Allocated: 1}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, subnetUtilization(tt.inspect)); diff != "" {
+				t.Errorf("subnetUtilization(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	yes := true
+	attached := map[string]network.EndpointResource{"abc123": {Name: "web"}}
+
+	tests := map[string]struct {
+		prevent     *bool
+		annotations map[string]string
+		attached    map[string]network.EndpointResource
+		wantErr     string
+		wantRemoved bool
+	}{
+		"GuardOff": {
+			attached:    attached,
+			wantRemoved: true,
+		},
+		"GuardOnUnused": {
+			prevent:     &yes,
+			attached:    map[string]network.EndpointResource{},
+			wantRemoved: true,
+		},
+		"GuardOnInUse": {
+			prevent:  &yes,
+			attached: attached,
+			wantErr:  "network is in use by 1 container(s)",
+		},
+		"GuardOnForceDelete": {
+			prevent:     &yes,
+			annotations: map[string]string{labels.AnnotationForceDelete: "true"},
+			attached:    attached,
+			wantRemoved: true,
+		},
+		"GuardOnForceDeleteNotTrue": {
+			prevent:     &yes,
+			annotations: map[string]string{labels.AnnotationForceDelete: "yes"},
+			attached:    attached,
+			wantErr:     "network is in use by 1 container(s)",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &networkv1alpha1.Network{}
+			cr.SetName("backend")
+			cr.SetAnnotations(tt.annotations)
+			meta.SetExternalName(cr, "backend")
+			cr.Spec.ForProvider.PreventDeleteWhileInUse = tt.prevent
+
+			f := &fakeClient{attached: map[string]map[string]network.EndpointResource{"backend": tt.attached}}
+			e := &external{client: f, logger: logging.NewNopLogger()}
+			_, err := e.Delete(context.Background(), cr)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("Delete() error = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("Delete() error = %v, want %q", err, tt.wantErr)
+			}
+			if removed := len(f.removed) == 1; removed != tt.wantRemoved {
+				t.Errorf("Delete() removed %v, want removed %v", f.removed, tt.wantRemoved)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package volume
 import (
 	"context"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
@@ -29,6 +30,8 @@ import (
 	"github.com/pkg/errors"
 	volumev1alpha1 "github.com/rossigee/provider-docker/apis/volume/v1alpha1"
 	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/internal/dryrun"
+	"github.com/rossigee/provider-docker/internal/shutdown"
 	"github.com/rossigee/provider-docker/internal/tracing"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -48,20 +51,33 @@ const (
 	errVolumeInspect = "cannot inspect volume"
 	errVolumeCreate  = "cannot create volume"
 	errVolumeRemove  = "cannot remove volume"
+	errDiskUsage     = "cannot get volume disk usage"
+
+	// usageRefreshInterval bounds how often volume usage is recomputed, since
+	// the engine sizes every volume on the host to answer a disk usage query.
+	usageRefreshInterval = 5 * time.Minute
 )
 
+// volumeUsage shares the disk usage of the volumes on each Docker host
+// between the volumes on it, so that the host is asked once per
+// usageRefreshInterval however many volumes it has.
+var volumeUsage = clients.NewVolumeUsageCache(usageRefreshInterval)
+
 // SetupVolume adds a controller that reconciles Volume managed resources.
 func SetupVolume(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(volumev1alpha1.VolumeGroupKind.Kind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorder(name))
 
+	backoff := clients.NewTerminalBackoff(o.PollInterval)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(volumev1alpha1.VolumeGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(backoff.Connector(dryrun.Connector(&connector{
 			kube:   mgr.GetClient(),
 			usage:  resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
 			logger: o.Logger,
-		}),
+		}, recorder))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithFinalizer(clients.NewUsageFinalizer(mgr.GetClient())),
 		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(nil))
 
@@ -70,7 +86,7 @@ func SetupVolume(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&volumev1alpha1.Volume{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, backoff.Reconciler(r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -101,18 +117,59 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{client: client, logger: c.logger}, nil
+	pc, err := clients.GetProviderConfig(ctx, c.kube, mg)
+	if err != nil {
+		_ = client.Close()
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	return &external{
+		client: client,
+		kube:   c.kube,
+		logger: c.logger,
+		host:   getStringValue(pc.Spec.Host, ""),
+		usage:  volumeUsage,
+		connectTo: func(ctx context.Context, mg resource.Managed, ref *xpv1.ProviderConfigReference) (clients.DockerClient, error) {
+			return clients.NewDockerClientFor(ctx, c.kube, mg, ref)
+		},
+		hostOf: func(ctx context.Context, mg resource.Managed, ref *xpv1.ProviderConfigReference) (string, error) {
+			pc, err := clients.GetProviderConfig(ctx, c.kube, clients.WithProviderConfig(mg, ref))
+			if err != nil {
+				return "", err
+			}
+			return getStringValue(pc.Spec.Host, ""), nil
+		},
+		registryAuthsOf: func(ctx context.Context, mg resource.Managed, ref *xpv1.ProviderConfigReference) (map[string]clients.RegistryAuth, error) {
+			return clients.RegistryAuths(ctx, c.kube, clients.WithProviderConfig(mg, ref))
+		},
+		now: time.Now,
+	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
 	client clients.DockerClient
+	kube   client.Client
 	logger logging.Logger
+
+	// usage shares the disk usage of the volumes on host between them.
+	host  string
+	usage *clients.VolumeUsageCache
+
+	// connectTo and hostOf connect to, and return the host of, the Docker
+	// host of another ProviderConfig, which a volume is migrated from.
+	connectTo func(ctx context.Context, mg resource.Managed, ref *xpv1.ProviderConfigReference) (clients.DockerClient, error)
+	hostOf    func(ctx context.Context, mg resource.Managed, ref *xpv1.ProviderConfigReference) (string, error)
+
+	// registryAuthsOf returns the registry credentials of a ProviderConfig,
+	// which the migration helpers on its Docker host are pulled with.
+	registryAuthsOf func(ctx context.Context, mg resource.Managed, ref *xpv1.ProviderConfigReference) (map[string]clients.RegistryAuth, error)
+	now             func() time.Time
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	_, span := tracing.StartSpan(ctx, "volume.observe",
+	ctx, span := tracing.StartSpan(ctx, "volume.observe",
 		tracing.SpanAttrs("volume", mg.GetName(), "observe")...)
 	defer span.End()
 
@@ -121,6 +178,17 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotVolume)
 	}
 
+	// The volume being migrated from may no longer be on this host. A volume
+	// deleted while it is migrated still exists, so that Delete cancels its
+	// migration.
+	if migrating(cr) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
+
+	// Only the status of a volume is saved after it is updated, so the volume
+	// becomes the target of a completed migration when next observed
+	switched := switchToTarget(cr)
+
 	volumeName := meta.GetExternalName(cr)
 	if volumeName == "" {
 		return managed.ExternalObservation{ResourceExists: false}, nil
@@ -128,26 +196,37 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	vol, err := c.client.VolumeInspect(ctx, volumeName)
 	if err != nil {
-		if isNotFoundError(err) {
-			return managed.ExternalObservation{ResourceExists: false}, nil
+		if !isNotFoundError(err) {
+			return managed.ExternalObservation{}, errors.Wrap(err, errVolumeInspect)
+		}
+		// A volume that moved to another host is migrated there by Update,
+		// since only the annotations of a volume are saved after Create, and
+		// the migration it starts is recorded in its status.
+		if movedHost(cr) && !meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
 		}
-		return managed.ExternalObservation{}, errors.Wrap(err, errVolumeInspect)
+		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
 	// Update observed state
 	c.updateStatus(cr, vol)
+	c.updateUsage(ctx, cr)
+	if !movedHost(cr) {
+		cr.Status.AtProvider.ProviderConfigName = providerConfigName(cr)
+	}
 
 	// Check if volume is up to date
-	upToDate := c.isUpToDate(cr, vol)
+	upToDate := c.isUpToDate(cr, vol) && !needsMigration(cr, vol.Driver, vol.Options)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: upToDate,
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: switched,
 	}, nil
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	_, span := tracing.StartSpan(ctx, "volume.create",
+	ctx, span := tracing.StartSpan(ctx, "volume.create",
 		tracing.SpanAttrs("volume", mg.GetName(), "create")...)
 	defer span.End()
 
@@ -156,6 +235,12 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotVolume)
 	}
 
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	defer done()
+
 	c.logger.Debug("Creating volume", "name", cr.Name)
 
 	opts := c.buildCreateOptions(cr)
@@ -167,18 +252,48 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	meta.SetExternalName(cr, vol.Name)
 	c.updateStatus(cr, vol)
+	cr.Status.AtProvider.ProviderConfigName = providerConfigName(cr)
 
 	return managed.ExternalCreation{}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// Docker volumes cannot be updated - they are immutable
-	// Any changes require recreation
-	return managed.ExternalUpdate{}, nil
+	ctx, span := tracing.StartSpan(ctx, "volume.update",
+		tracing.SpanAttrs("volume", mg.GetName(), "update")...)
+	defer span.End()
+
+	cr, ok := mg.(*volumev1alpha1.Volume)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotVolume)
+	}
+
+	// Docker volumes cannot be updated - they are immutable. A volume whose
+	// driver, driver options, or host changed has its data migrated to a
+	// new volume if migration is enabled, and must be recreated otherwise.
+	if cr.Spec.ForProvider.Migration == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+	// A volume being migrated, or moved to another host, need not be on
+	// this host yet
+	vol, err := c.client.VolumeInspect(ctx, meta.GetExternalName(cr))
+	if err != nil && !migrating(cr) && !(movedHost(cr) && isNotFoundError(err)) {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errVolumeInspect)
+	}
+	if !needsMigration(cr, vol.Driver, vol.Options) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	defer done()
+
+	return managed.ExternalUpdate{}, c.migrate(ctx, cr)
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
-	_, span := tracing.StartSpan(ctx, "volume.delete",
+	ctx, span := tracing.StartSpan(ctx, "volume.delete",
 		tracing.SpanAttrs("volume", mg.GetName(), "delete")...)
 	defer span.End()
 
@@ -187,6 +302,12 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalDelete{}, errors.New(errNotVolume)
 	}
 
+	done, err := shutdown.Begin()
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	defer done()
+
 	volumeName := meta.GetExternalName(cr)
 	if volumeName == "" {
 		return managed.ExternalDelete{}, nil
@@ -194,7 +315,25 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	c.logger.Debug("Deleting volume", "name", volumeName)
 
-	err := c.client.VolumeRemove(ctx, volumeName, true) // force=true
+	// A migration that has not completed is cancelled, rather than leave its
+	// helpers and target volume behind, and the containers it paused. Once
+	// it is cleared the volume is no longer reported as existing while it
+	// is migrated.
+	if m := cr.Status.AtProvider.Migration; m != nil && m.Phase != volumev1alpha1.MigrationCompleted {
+		if src, done, err := c.sourceClient(ctx, cr, m.SourceProviderConfigName); err == nil {
+			c.removeHelpers(ctx, src, m.Target)
+			c.resumeConsumers(ctx, src, m)
+			done()
+		}
+		if m.Target != volumeName {
+			if err := c.client.VolumeRemove(ctx, m.Target, true); err != nil && !isNotFoundError(err) {
+				return managed.ExternalDelete{}, errors.Wrap(err, errVolumeRemove)
+			}
+		}
+		cr.Status.AtProvider.Migration = nil
+	}
+
+	err = c.client.VolumeRemove(ctx, volumeName, true) // force=true
 	if err != nil && !isNotFoundError(err) {
 		return managed.ExternalDelete{}, errors.Wrap(err, errVolumeRemove)
 	}
@@ -249,6 +388,47 @@ func (c *external) updateStatus(cr *volumev1alpha1.Volume, vol volume.Volume) {
 	cr.SetConditions(xpv1.Available())
 }
 
+// updateUsage refreshes the volume's used bytes from the engine's disk usage
+// data at most once per usageRefreshInterval, sharing the data with the other
+// volumes on its host, and reports whether usage is above the configured
+// capacity alert threshold. Usage is best effort: drivers that don't report
+// it leave UsedBytes unset.
+func (c *external) updateUsage(ctx context.Context, cr *volumev1alpha1.Volume) {
+	obs := &cr.Status.AtProvider
+	if obs.UsageCheckedAt == nil || time.Since(obs.UsageCheckedAt.Time) >= usageRefreshInterval {
+		volumes, err := c.usage.Get(ctx, c.client, c.host)
+		if err != nil {
+			c.logger.Debug(errDiskUsage, "name", obs.Name, "error", err)
+		} else {
+			obs.UsageCheckedAt = &metav1.Time{Time: time.Now()}
+			for _, v := range volumes {
+				if v == nil || v.Name != obs.Name || v.UsageData == nil {
+					continue
+				}
+				obs.UsageData = &volumev1alpha1.VolumeUsageData{
+					Size:     v.UsageData.Size,
+					RefCount: v.UsageData.RefCount,
+				}
+				// The engine reports -1 when the size is unavailable
+				if v.UsageData.Size >= 0 {
+					size := v.UsageData.Size
+					obs.UsedBytes = &size
+				}
+			}
+		}
+	}
+
+	threshold := cr.Spec.ForProvider.CapacityAlertThreshold
+	if threshold == nil || obs.UsedBytes == nil {
+		return
+	}
+	if limit := threshold.Value(); *obs.UsedBytes > limit {
+		cr.SetConditions(volumev1alpha1.CapacityExceeded(*obs.UsedBytes, limit))
+	} else {
+		cr.SetConditions(volumev1alpha1.CapacityWithinThreshold())
+	}
+}
+
 // isUpToDate checks if the current volume matches the desired specification.
 func (c *external) isUpToDate(cr *volumev1alpha1.Volume, vol volume.Volume) bool {
 	spec := cr.Spec.ForProvider
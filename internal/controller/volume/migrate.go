@@ -0,0 +1,650 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"github.com/pkg/errors"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	containerv1beta1 "github.com/rossigee/provider-docker/apis/container/v1beta1"
+	namespacedv1beta1 "github.com/rossigee/provider-docker/apis/namespaced/v1beta1"
+	volumev1alpha1 "github.com/rossigee/provider-docker/apis/volume/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"github.com/rossigee/provider-docker/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	errTargetExists     = "cannot migrate to volume %s: it already exists"
+	errSameHost         = "cannot migrate between ProviderConfigs %s and %s: they are the same Docker host"
+	errConnectSource    = "cannot connect to the Docker host of ProviderConfig %s"
+	errSourceAddress    = "cannot reach the Docker host of ProviderConfig %s from another host: it must be a tcp:// host"
+	errResolveSource    = "cannot resolve the address of the Docker host of ProviderConfig %s"
+	errMigrationToken   = "cannot generate a volume migration token"
+	errPauseConsumers   = "cannot pause the containers using volume %s"
+	errRegistryAuth     = "ProviderConfig %s"
+	errStartHelper      = "cannot start volume migration helper %s"
+	errHelperGone       = "volume migration helper %s is gone"
+	errHelperFailed     = "volume migration helper %s exited with code %d: %s"
+	errChecksumMismatch = "checksum %s of volume %s does not match checksum %s of volume %s"
+	errSwitchConsumers  = "cannot switch containers to volume %s"
+
+	// defaultMigrationHelperImage provides the sh, tar, nc and sha256sum the
+	// migration helpers need, with an nc that can run a program with -e.
+	defaultMigrationHelperImage = "busybox:1.36"
+
+	// defaultMigrationPort is the port the data of a volume is served on.
+	defaultMigrationPort = 7070
+
+	// migrationMountPath is where the helpers mount the volumes.
+	migrationMountPath = "/data"
+
+	// checksumPrefix starts the line a helper logs the checksum of its
+	// volume on.
+	checksumPrefix = "checksum "
+
+	// helperLogLines is how many of the last lines a failed helper logged
+	// are reported.
+	helperLogLines = 5
+
+	// tokenEnv is the environment variable the helpers are given the token
+	// of their migration in.
+	tokenEnv = "MIGRATION_TOKEN"
+
+	// tokenTimeout is how many seconds the sending helper waits for a
+	// client to send the token.
+	tokenTimeout = 10
+)
+
+// checksumScript logs the checksum of the files of the volume, which is the
+// same for volumes with the same files and contents.
+const checksumScript = `echo "` + checksumPrefix + `$(cd ` + migrationMountPath + ` && find . -type f -exec sha256sum {} + | sort | sha256sum | cut -d' ' -f1)"`
+
+// sendScript serves the files of the volume as a tar archive on the given
+// port, to the first client that sends the token of the migration. Clients
+// that do not are disconnected, and the archive is served again.
+func sendScript(port int32) string {
+	return fmt.Sprintf("%s && cd %s && until nc -l -p %d -e sh -c 'read -r -t %d token && [ \"$token\" = \"$%s\" ] && tar -cf - .'; do sleep 1; done",
+		checksumScript, migrationMountPath, port, tokenTimeout, tokenEnv)
+}
+
+// receiveScript sends the token of the migration to the sender at addr,
+// and extracts the tar archive it serves into the volume, retrying until
+// the sender is listening.
+func receiveScript(addr string, port int32) string {
+	return fmt.Sprintf("for i in $(seq 60); do if echo \"$%s\" | nc %s %d | tar -xf - -C %s; then ok=1; break; fi; sleep 1; done; [ -n \"$ok\" ] && %s",
+		tokenEnv, addr, port, migrationMountPath, checksumScript)
+}
+
+// migrationToken returns a random token the receiving helper of a migration
+// authenticates to the sending helper with.
+func migrationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, errMigrationToken)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// An endpoint is a Docker volume on the Docker host of a ProviderConfig.
+type endpoint struct {
+	volume         string
+	providerConfig string
+}
+
+// providerConfigName returns the name of the ProviderConfig the volume is
+// meant to be on.
+func providerConfigName(cr *volumev1alpha1.Volume) string {
+	if ref := cr.GetProviderConfigReference(); ref != nil {
+		return ref.Name
+	}
+	return ""
+}
+
+// migrating reports whether the data of the volume is being copied.
+func migrating(cr *volumev1alpha1.Volume) bool {
+	m := cr.Status.AtProvider.Migration
+	return m != nil && m.Phase == volumev1alpha1.MigrationCopying
+}
+
+// movedHost reports whether the volume is to be migrated to the Docker host
+// of another ProviderConfig than the one it was last observed on.
+func movedHost(cr *volumev1alpha1.Volume) bool {
+	observed := cr.Status.AtProvider.ProviderConfigName
+	return cr.Spec.ForProvider.Migration != nil && observed != "" && observed != providerConfigName(cr)
+}
+
+// needsMigration reports whether the volume's data is to be migrated to a
+// new Docker volume, either because it is being copied, it is to move to
+// another Docker host, or its driver or driver options have changed.
+func needsMigration(cr *volumev1alpha1.Volume, driver string, options map[string]string) bool {
+	spec := cr.Spec.ForProvider
+	if spec.Migration == nil {
+		return false
+	}
+	if migrating(cr) || movedHost(cr) {
+		return true
+	}
+	if driver != getStringValue(spec.Driver, "local") {
+		return true
+	}
+	return len(spec.DriverOpts) > 0 && !mapsEqual(options, spec.DriverOpts)
+}
+
+// migrationTarget returns the name of the Docker volume a volume is migrated
+// to on the same Docker host, which depends on its driver and driver options
+// so that it differs from the volume it is migrated from.
+func migrationTarget(cr *volumev1alpha1.Volume) string {
+	spec := cr.Spec.ForProvider
+	h := sha256.New()
+	h.Write([]byte(getStringValue(spec.Driver, "local")))
+	keys := make([]string, 0, len(spec.DriverOpts))
+	for k := range spec.DriverOpts {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "\x00%s=%s", k, spec.DriverOpts[k])
+	}
+	return getStringValue(spec.Name, cr.Name) + "-" + hex.EncodeToString(h.Sum(nil))[:8]
+}
+
+// senderName and receiverName return the names of the helper containers
+// that migrate data to a volume.
+func senderName(target string) string   { return target + "-migrate-send" }
+func receiverName(target string) string { return target + "-migrate-receive" }
+
+// migrate starts migrating the data of the volume, or carries on with a
+// migration that has started. A migration that failed starts again.
+func (c *external) migrate(ctx context.Context, cr *volumev1alpha1.Volume) error {
+	if migrating(cr) {
+		return c.progressMigration(ctx, cr)
+	}
+
+	current := providerConfigName(cr)
+	from := endpoint{volume: meta.GetExternalName(cr), providerConfig: current}
+	to := endpoint{volume: migrationTarget(cr), providerConfig: current}
+	if movedHost(cr) {
+		// The volume keeps its name on its new host
+		from.providerConfig = cr.Status.AtProvider.ProviderConfigName
+		to.volume = from.volume
+	}
+	return c.startMigration(ctx, cr, from, to)
+}
+
+// startMigration creates the target volume, and starts the helpers that
+// stream the data of the source volume into it.
+func (c *external) startMigration(ctx context.Context, cr *volumev1alpha1.Volume, from, to endpoint) error {
+	src, done, err := c.sourceClient(ctx, cr, from.providerConfig)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if from.providerConfig != to.providerConfig {
+		if err := c.checkDifferentHosts(ctx, src, from, to); err != nil {
+			return err
+		}
+	}
+
+	// Helpers and a target left over from a failed attempt are removed
+	c.removeHelpers(ctx, src, to.volume)
+	if _, err := c.client.VolumeInspect(ctx, to.volume); err == nil {
+		last := cr.Status.AtProvider.Migration
+		if last == nil || last.Target != to.volume || last.Phase != volumev1alpha1.MigrationFailed {
+			return errors.Errorf(errTargetExists, to.volume)
+		}
+		if err := c.client.VolumeRemove(ctx, to.volume, true); err != nil && !isNotFoundError(err) {
+			return errors.Wrap(err, errVolumeRemove)
+		}
+	} else if !isNotFoundError(err) {
+		return errors.Wrap(err, errVolumeInspect)
+	}
+
+	opts := c.buildCreateOptions(cr)
+	opts.Name = to.volume
+	if _, err := c.client.VolumeCreate(ctx, opts); err != nil {
+		return errors.Wrap(err, errVolumeCreate)
+	}
+
+	now := metav1.NewTime(c.now())
+	m := &volumev1alpha1.VolumeMigrationStatus{
+		Phase:                    volumev1alpha1.MigrationCopying,
+		Source:                   from.volume,
+		SourceProviderConfigName: from.providerConfig,
+		Target:                   to.volume,
+		StartedAt:                &now,
+	}
+	cr.Status.AtProvider.Migration = m
+	c.logger.Debug("Migrating volume", "from", from.volume, "fromProviderConfig", from.providerConfig, "to", to.volume)
+
+	// Writes made while the data is copied would be lost
+	if err := c.pauseConsumers(ctx, src, m); err != nil {
+		return c.failMigration(ctx, src, cr, err)
+	}
+
+	token, err := migrationToken()
+	if err != nil {
+		return c.failMigration(ctx, src, cr, err)
+	}
+	env := []string{tokenEnv + "=" + token}
+
+	helperImage := getStringValue(cr.Spec.ForProvider.Migration.HelperImage, defaultMigrationHelperImage)
+	port := int32(defaultMigrationPort)
+	if p := cr.Spec.ForProvider.Migration.Port; p != nil {
+		port = *p
+	}
+
+	sendHost := &container.HostConfig{
+		AutoRemove: false,
+		Mounts:     []mount.Mount{{Type: mount.TypeVolume, Source: from.volume, Target: migrationMountPath, ReadOnly: true}},
+	}
+	exposed := nat.PortSet{}
+	var addr string
+	if from.providerConfig != to.providerConfig {
+		// The data is only served on the address the new host reaches the
+		// old host at, rather than on every address of the old host
+		if addr, err = c.sourceHostAddress(ctx, cr, from); err != nil {
+			return c.failMigration(ctx, src, cr, err)
+		}
+		p := nat.Port(fmt.Sprintf("%d/tcp", port))
+		exposed[p] = struct{}{}
+		sendHost.PortBindings = nat.PortMap{p: {{HostIP: addr, HostPort: strconv.Itoa(int(port))}}}
+	}
+	send := &container.Config{
+		Image:        helperImage,
+		Cmd:          []string{"sh", "-c", sendScript(port)},
+		Env:          env,
+		ExposedPorts: exposed,
+		Labels:       map[string]string{labels.ManagedBy: labels.ManagedByProvider},
+	}
+	if err := c.startHelper(ctx, cr, src, from.providerConfig, senderName(to.volume), send, sendHost); err != nil {
+		return c.failMigration(ctx, src, cr, err)
+	}
+
+	if from.providerConfig == to.providerConfig {
+		if addr, err = bridgeAddress(ctx, src, senderName(to.volume)); err != nil {
+			return c.failMigration(ctx, src, cr, err)
+		}
+	}
+
+	receive := &container.Config{
+		Image:  helperImage,
+		Cmd:    []string{"sh", "-c", receiveScript(addr, port)},
+		Env:    env,
+		Labels: map[string]string{labels.ManagedBy: labels.ManagedByProvider},
+	}
+	receiveHost := &container.HostConfig{
+		Mounts: []mount.Mount{{Type: mount.TypeVolume, Source: to.volume, Target: migrationMountPath}},
+	}
+	if err := c.startHelper(ctx, cr, c.client, to.providerConfig, receiverName(to.volume), receive, receiveHost); err != nil {
+		return c.failMigration(ctx, src, cr, err)
+	}
+	return nil
+}
+
+// progressMigration checks on the helpers of a migration. Once both have
+// exited and the checksums of the source and target volumes match, the
+// containers that use the source volume are switched to the target volume,
+// and the migration completes. The volume becomes the target volume when it
+// is next observed. The containers paused while the data was copied are
+// left paused, so that nothing writes to the source volume, until they are
+// recreated on the target volume.
+func (c *external) progressMigration(ctx context.Context, cr *volumev1alpha1.Volume) error {
+	m := cr.Status.AtProvider.Migration
+	src, done, err := c.sourceClient(ctx, cr, m.SourceProviderConfigName)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	sent, err := c.helperChecksum(ctx, src, senderName(m.Target))
+	if err != nil || sent == "" {
+		return c.failMigrationUnlessRunning(ctx, src, cr, err)
+	}
+	received, err := c.helperChecksum(ctx, c.client, receiverName(m.Target))
+	if err != nil || received == "" {
+		return c.failMigrationUnlessRunning(ctx, src, cr, err)
+	}
+	if sent != received {
+		return c.failMigration(ctx, src, cr, errors.Errorf(errChecksumMismatch, received, m.Target, sent, m.Source))
+	}
+
+	consumers, err := c.switchConsumers(ctx, cr, m)
+	if err != nil {
+		return errors.Wrapf(err, errSwitchConsumers, m.Target)
+	}
+	c.removeHelpers(ctx, src, m.Target)
+
+	now := metav1.NewTime(c.now())
+	m.Phase = volumev1alpha1.MigrationCompleted
+	m.Checksum = sent
+	m.Consumers = consumers
+	m.Message = ""
+	m.CompletedAt = &now
+	cr.Status.AtProvider.ProviderConfigName = providerConfigName(cr)
+	c.logger.Debug("Migrated volume", "from", m.Source, "to", m.Target, "consumers", len(consumers))
+	return nil
+}
+
+// switchToTarget points the volume at the target volume of a migration
+// that has completed, unless it already does, and reports whether it did.
+func switchToTarget(cr *volumev1alpha1.Volume) bool {
+	m := cr.Status.AtProvider.Migration
+	if m == nil || m.Phase != volumev1alpha1.MigrationCompleted || m.Target == "" || m.Source == m.Target {
+		return false
+	}
+	if meta.GetExternalName(cr) != m.Source {
+		return false
+	}
+	meta.SetExternalName(cr, m.Target)
+	return true
+}
+
+// errStillRunning is returned by helperChecksum while a helper is running.
+var errStillRunning = errors.New("still running")
+
+// failMigrationUnlessRunning fails the migration with err, unless err is
+// that a helper is still running.
+func (c *external) failMigrationUnlessRunning(ctx context.Context, src clients.DockerClient, cr *volumev1alpha1.Volume, err error) error {
+	if errors.Is(err, errStillRunning) {
+		return nil
+	}
+	return c.failMigration(ctx, src, cr, err)
+}
+
+// failMigration records that the migration failed with err, resumes the
+// containers it paused, and returns err. Its helpers are left for the next
+// attempt to remove, so that their logs can be read in the meantime.
+func (c *external) failMigration(ctx context.Context, src clients.DockerClient, cr *volumev1alpha1.Volume, err error) error {
+	m := cr.Status.AtProvider.Migration
+	if m != nil {
+		m.Phase = volumev1alpha1.MigrationFailed
+		m.Message = err.Error()
+		c.resumeConsumers(ctx, src, m)
+	}
+	return err
+}
+
+// pauseConsumers pauses the running containers on the source Docker host
+// that mount the source volume of a migration, recording those it paused.
+func (c *external) pauseConsumers(ctx context.Context, src clients.DockerClient, m *volumev1alpha1.VolumeMigrationStatus) error {
+	running, err := src.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("volume", m.Source), filters.Arg("status", "running")),
+	})
+	if err != nil {
+		return errors.Wrapf(err, errPauseConsumers, m.Source)
+	}
+	for _, ct := range running {
+		if err := src.ContainerPause(ctx, ct.ID); err != nil {
+			return errors.Wrapf(err, errPauseConsumers, m.Source)
+		}
+		m.PausedContainers = append(m.PausedContainers, ct.ID)
+	}
+	return nil
+}
+
+// resumeConsumers unpauses the containers a migration paused. Containers
+// that cannot be unpaused are left paused.
+func (c *external) resumeConsumers(ctx context.Context, src clients.DockerClient, m *volumev1alpha1.VolumeMigrationStatus) {
+	for _, id := range m.PausedContainers {
+		if err := src.ContainerUnpause(ctx, id); err != nil && !isNotFoundError(err) {
+			c.logger.Debug("Cannot unpause container paused by volume migration", "id", id, "error", err)
+		}
+	}
+	m.PausedContainers = nil
+}
+
+// helperChecksum returns the checksum a helper logged once it has exited
+// successfully, or errStillRunning while it is running.
+func (c *external) helperChecksum(ctx context.Context, client clients.DockerClient, name string) (string, error) {
+	info, err := client.ContainerInspect(ctx, name)
+	if err != nil {
+		if isNotFoundError(err) {
+			return "", errors.Errorf(errHelperGone, name)
+		}
+		return "", err
+	}
+	if info.State != nil && info.State.Running {
+		return "", errStillRunning
+	}
+
+	logs, err := client.ContainerLogs(ctx, info.ID, container.LogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = logs.Close() }()
+	var buf bytes.Buffer
+	if _, err := stdcopy.StdCopy(&buf, &buf, logs); err != nil {
+		return "", err
+	}
+
+	var checksum string
+	var lines []string
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if sum, ok := strings.CutPrefix(line, checksumPrefix); ok {
+			checksum = sum
+		}
+		lines = append(lines, line)
+	}
+
+	if info.State != nil && info.State.ExitCode != 0 {
+		if len(lines) > helperLogLines {
+			lines = lines[len(lines)-helperLogLines:]
+		}
+		return "", errors.Errorf(errHelperFailed, name, info.State.ExitCode, strings.Join(lines, "\n"))
+	}
+	return checksum, nil
+}
+
+// startHelper pulls the image of a helper container with the registry
+// credentials of the ProviderConfig of the Docker host it runs on, then
+// creates and starts it.
+func (c *external) startHelper(ctx context.Context, cr *volumev1alpha1.Volume, client clients.DockerClient, providerConfig, name string, config *container.Config, hostConfig *container.HostConfig) error {
+	var auths clients.RegistryAuthsFunc
+	if c.registryAuthsOf != nil {
+		auths = func(ctx context.Context) (map[string]clients.RegistryAuth, error) {
+			a, err := c.registryAuthsOf(ctx, cr, referenceTo(cr, providerConfig))
+			return a, errors.Wrapf(err, errRegistryAuth, providerConfig)
+		}
+	}
+	if err := clients.PullImage(ctx, client, config.Image, "", auths, nil); err != nil {
+		return errors.Wrapf(err, errStartHelper, name)
+	}
+	resp, err := client.ContainerCreate(ctx, config, hostConfig, nil, nil, name)
+	if err != nil {
+		return errors.Wrapf(err, errStartHelper, name)
+	}
+	return errors.Wrapf(client.ContainerStart(ctx, resp.ID, container.StartOptions{}), errStartHelper, name)
+}
+
+// removeHelpers removes the helper containers of a migration to target.
+// Helpers that cannot be removed are left behind.
+func (c *external) removeHelpers(ctx context.Context, src clients.DockerClient, target string) {
+	for _, h := range []struct {
+		client clients.DockerClient
+		name   string
+	}{{src, senderName(target)}, {c.client, receiverName(target)}} {
+		if err := h.client.ContainerRemove(ctx, h.name, container.RemoveOptions{Force: true}); err != nil && !isNotFoundError(err) {
+			c.logger.Debug("Cannot remove volume migration helper", "name", h.name, "error", err)
+		}
+	}
+}
+
+// sourceClient returns a client for the Docker host of the source volume,
+// and a function that closes it once done.
+func (c *external) sourceClient(ctx context.Context, cr *volumev1alpha1.Volume, providerConfig string) (clients.DockerClient, func(), error) {
+	if providerConfig == providerConfigName(cr) {
+		return c.client, func() {}, nil
+	}
+	src, err := c.connectTo(ctx, cr, referenceTo(cr, providerConfig))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, errConnectSource, providerConfig)
+	}
+	return src, func() { _ = src.Close() }, nil
+}
+
+// referenceTo returns a reference to the named ProviderConfig, of the kind
+// the volume refers to.
+func referenceTo(cr *volumev1alpha1.Volume, providerConfig string) *xpv1.ProviderConfigReference {
+	ref := cr.GetProviderConfigReference().DeepCopy()
+	ref.Name = providerConfig
+	return ref
+}
+
+// checkDifferentHosts makes sure the ProviderConfigs of a migration between
+// hosts are for different Docker hosts, since the target volume has the
+// name of the source volume.
+func (c *external) checkDifferentHosts(ctx context.Context, src clients.DockerClient, from, to endpoint) error {
+	srcInfo, err := src.Info(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errConnectSource, from.providerConfig)
+	}
+	info, err := c.client.Info(ctx)
+	if err != nil {
+		return err
+	}
+	if srcInfo.ID != "" && srcInfo.ID == info.ID {
+		return errors.Errorf(errSameHost, from.providerConfig, to.providerConfig)
+	}
+	return nil
+}
+
+// bridgeAddress returns the address of the sending helper on the default
+// bridge network, which the receiving helper reaches it at when both are on
+// the same Docker host.
+func bridgeAddress(ctx context.Context, src clients.DockerClient, name string) (string, error) {
+	info, err := src.ContainerInspect(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if info.NetworkSettings != nil {
+		if ep, ok := info.NetworkSettings.Networks[network.NetworkBridge]; ok && ep != nil && ep.IPAddress != "" {
+			return ep.IPAddress, nil
+		}
+	}
+	return "", errors.Errorf(errHelperGone, name)
+}
+
+// sourceHostAddress returns the IP address of the source Docker host of a
+// migration between hosts, which the receiving helper reaches the sending
+// helper at.
+func (c *external) sourceHostAddress(ctx context.Context, cr *volumev1alpha1.Volume, from endpoint) (string, error) {
+	host, err := c.hostOf(ctx, cr, referenceTo(cr, from.providerConfig))
+	if err != nil {
+		return "", errors.Wrapf(err, errConnectSource, from.providerConfig)
+	}
+	u, err := url.Parse(host)
+	if err != nil || u.Scheme != "tcp" || u.Hostname() == "" {
+		return "", errors.Errorf(errSourceAddress, from.providerConfig)
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		return ip.String(), nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return "", errors.Wrapf(err, errResolveSource, from.providerConfig)
+	}
+	if len(addrs) == 0 {
+		return "", errors.Errorf(errResolveSource, from.providerConfig)
+	}
+	return addrs[0].IP.String(), nil
+}
+
+// switchConsumers switches the Containers that mount the source volume of a
+// migration to its target volume, and to the target's ProviderConfig if the
+// volume moved hosts. It returns the containers it switched.
+func (c *external) switchConsumers(ctx context.Context, cr *volumev1alpha1.Volume, m *volumev1alpha1.VolumeMigrationStatus) ([]string, error) {
+	target := providerConfigName(cr)
+	var switched []string
+
+	switchMounts := func(mounts []containerv1alpha1.VolumeMount) bool {
+		changed := false
+		for i := range mounts {
+			if v := mounts[i].VolumeSource.Volume; v != nil && v.VolumeName == m.Source && m.Source != m.Target {
+				v.VolumeName = m.Target
+				changed = true
+			}
+		}
+		return changed
+	}
+	mounts := func(mounts []containerv1alpha1.VolumeMount) bool {
+		return slices.ContainsFunc(mounts, func(vm containerv1alpha1.VolumeMount) bool {
+			return vm.VolumeSource.Volume != nil && vm.VolumeSource.Volume.VolumeName == m.Source
+		})
+	}
+
+	cluster := &containerv1alpha1.ContainerList{}
+	if err := c.kube.List(ctx, cluster); err != nil {
+		return nil, err
+	}
+	for i := range cluster.Items {
+		ct := &cluster.Items[i]
+		ref := ct.GetProviderConfigReference()
+		if ref == nil || ref.Name != m.SourceProviderConfigName || !mounts(ct.Spec.ForProvider.Volumes) {
+			continue
+		}
+		switchMounts(ct.Spec.ForProvider.Volumes)
+		ref.Name = target
+		if err := c.kube.Update(ctx, ct); err != nil {
+			return nil, err
+		}
+		switched = append(switched, containerv1alpha1.ContainerKind+"/"+ct.GetName())
+	}
+
+	namespaced := &containerv1beta1.ContainerList{}
+	if err := c.kube.List(ctx, namespaced); err != nil {
+		return nil, err
+	}
+	for i := range namespaced.Items {
+		ct := &namespaced.Items[i]
+		ref := ct.GetProviderConfigReference()
+		// Only a ClusterProviderConfig can be the ProviderConfig of a
+		// cluster scoped Volume
+		if ref == nil || ref.Kind != namespacedv1beta1.ClusterProviderConfigKind || ref.Name != m.SourceProviderConfigName || !mounts(ct.Spec.ForProvider.Volumes) {
+			continue
+		}
+		switchMounts(ct.Spec.ForProvider.Volumes)
+		ref.Name = target
+		if err := c.kube.Update(ctx, ct); err != nil {
+			return nil, err
+		}
+		switched = append(switched, containerv1beta1.ContainerKind+"/"+ct.GetNamespace()+"/"+ct.GetName())
+	}
+	return switched, nil
+}
@@ -0,0 +1,760 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rossigee/provider-docker/apis"
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	containerv1beta1 "github.com/rossigee/provider-docker/apis/container/v1beta1"
+	volumev1alpha1 "github.com/rossigee/provider-docker/apis/volume/v1alpha1"
+	"github.com/rossigee/provider-docker/internal/clients"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func migratingVolume(pc string, spec volumev1alpha1.VolumeParameters, obs volumev1alpha1.VolumeObservation) *volumev1alpha1.Volume {
+	cr := &volumev1alpha1.Volume{ObjectMeta: metav1.ObjectMeta{Name: "data"}}
+	cr.Spec.ProviderConfigReference = &xpv1.ProviderConfigReference{Name: pc}
+	cr.Spec.ForProvider = spec
+	cr.Status.AtProvider = obs
+	return cr
+}
+
+func TestNeedsMigration(t *testing.T) {
+	enabled := &volumev1alpha1.VolumeMigration{}
+
+	tests := map[string]struct {
+		cr      *volumev1alpha1.Volume
+		driver  string
+		options map[string]string
+		want    bool
+	}{
+		"Disabled": {
+			cr:     migratingVolume("b", volumev1alpha1.VolumeParameters{Driver: stringPtr("nfs")}, volumev1alpha1.VolumeObservation{ProviderConfigName: "a"}),
+			driver: "local",
+		},
+		"UpToDate": {
+			cr:     migratingVolume("a", volumev1alpha1.VolumeParameters{Migration: enabled}, volumev1alpha1.VolumeObservation{ProviderConfigName: "a"}),
+			driver: "local",
+		},
+		"DriverChanged": {
+			cr:     migratingVolume("a", volumev1alpha1.VolumeParameters{Migration: enabled, Driver: stringPtr("nfs")}, volumev1alpha1.VolumeObservation{ProviderConfigName: "a"}),
+			driver: "local",
+			want:   true,
+		},
+		"DriverOptsChanged": {
+			cr:      migratingVolume("a", volumev1alpha1.VolumeParameters{Migration: enabled, DriverOpts: map[string]string{"type": "tmpfs"}}, volumev1alpha1.VolumeObservation{ProviderConfigName: "a"}),
+			driver:  "local",
+			options: map[string]string{"type": "none"},
+			want:    true,
+		},
+		"MovedHost": {
+			cr:     migratingVolume("b", volumev1alpha1.VolumeParameters{Migration: enabled}, volumev1alpha1.VolumeObservation{ProviderConfigName: "a"}),
+			driver: "local",
+			want:   true,
+		},
+		"Copying": {
+			cr: migratingVolume("a", volumev1alpha1.VolumeParameters{Migration: enabled}, volumev1alpha1.VolumeObservation{
+				ProviderConfigName: "a",
+				Migration:          &volumev1alpha1.VolumeMigrationStatus{Phase: volumev1alpha1.MigrationCopying},
+			}),
+			driver: "local",
+			want:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := needsMigration(tt.cr, tt.driver, tt.options); got != tt.want {
+				t.Errorf("needsMigration(...) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrationTarget(t *testing.T) {
+	nfs := migratingVolume("a", volumev1alpha1.VolumeParameters{Name: stringPtr("db"), Driver: stringPtr("nfs")}, volumev1alpha1.VolumeObservation{})
+	local := migratingVolume("a", volumev1alpha1.VolumeParameters{Name: stringPtr("db")}, volumev1alpha1.VolumeObservation{})
+
+	got := migrationTarget(nfs)
+	if len(got) != len("db-")+8 || got[:3] != "db-" {
+		t.Errorf("migrationTarget(...) = %q, want db- and 8 hex digits", got)
+	}
+	if again := migrationTarget(nfs.DeepCopy()); again != got {
+		t.Errorf("migrationTarget(...) = %q, then %q: want the same target for the same spec", got, again)
+	}
+	if other := migrationTarget(local); other == got {
+		t.Errorf("migrationTarget(...) = %q for both drivers, want different targets", got)
+	}
+}
+
+func TestSwitchConsumers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apis.AddToScheme(scheme)
+
+	mount := func(name string) []containerv1alpha1.VolumeMount {
+		return []containerv1alpha1.VolumeMount{{Name: "data", MountPath: "/data", VolumeSource: containerv1alpha1.VolumeSource{
+			Volume: &containerv1alpha1.VolumeVolumeSource{VolumeName: name},
+		}}}
+	}
+	cluster := func(name, pc, volume string) *containerv1alpha1.Container {
+		ct := &containerv1alpha1.Container{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		ct.Spec.ProviderConfigReference = &xpv1.ProviderConfigReference{Name: pc}
+		ct.Spec.ForProvider.Volumes = mount(volume)
+		return ct
+	}
+	namespaced := func(name, kind, pc, volume string) *containerv1beta1.Container {
+		ct := &containerv1beta1.Container{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		ct.Spec.ProviderConfigReference = &xpv1.ProviderConfigReference{Kind: kind, Name: pc}
+		ct.Spec.ForProvider.Volumes = mount(volume)
+		return ct
+	}
+
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		cluster("db", "a", "data"),
+		cluster("other-host", "c", "data"),
+		cluster("other-volume", "a", "cache"),
+		namespaced("app", "ClusterProviderConfig", "a", "data"),
+		namespaced("namespaced-pc", "ProviderConfig", "a", "data"),
+	).Build()
+
+	cr := migratingVolume("b", volumev1alpha1.VolumeParameters{Migration: &volumev1alpha1.VolumeMigration{}}, volumev1alpha1.VolumeObservation{})
+	e := &external{kube: kube}
+	got, err := e.switchConsumers(context.Background(), cr, &volumev1alpha1.VolumeMigrationStatus{
+		Source:                   "data",
+		SourceProviderConfigName: "a",
+		Target:                   "data",
+	})
+	if err != nil {
+		t.Fatalf("switchConsumers(...): %v", err)
+	}
+	want := []string{"Container/db", "Container/default/app"}
+	if !slices.Equal(got, want) {
+		t.Errorf("switchConsumers(...) = %v, want %v", got, want)
+	}
+
+	db := &containerv1alpha1.Container{}
+	if err := kube.Get(context.Background(), ktypes.NamespacedName{Name: "db"}, db); err != nil {
+		t.Fatal(err)
+	}
+	if pc := db.GetProviderConfigReference().Name; pc != "b" {
+		t.Errorf("switched container ProviderConfig = %q, want b", pc)
+	}
+	other := &containerv1alpha1.Container{}
+	if err := kube.Get(context.Background(), ktypes.NamespacedName{Name: "other-host"}, other); err != nil {
+		t.Fatal(err)
+	}
+	if pc := other.GetProviderConfigReference().Name; pc != "c" {
+		t.Errorf("container on another host ProviderConfig = %q, want c", pc)
+	}
+
+	// A migration on the same host renames the volume the containers mount
+	kube = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster("db", "a", "data")).Build()
+	cr = migratingVolume("a", volumev1alpha1.VolumeParameters{Migration: &volumev1alpha1.VolumeMigration{}}, volumev1alpha1.VolumeObservation{})
+	e = &external{kube: kube}
+	if _, err := e.switchConsumers(context.Background(), cr, &volumev1alpha1.VolumeMigrationStatus{
+		Source:                   "data",
+		SourceProviderConfigName: "a",
+		Target:                   "data-0123abcd",
+	}); err != nil {
+		t.Fatalf("switchConsumers(...): %v", err)
+	}
+	if err := kube.Get(context.Background(), ktypes.NamespacedName{Name: "db"}, db); err != nil {
+		t.Fatal(err)
+	}
+	if got := db.Spec.ForProvider.Volumes[0].VolumeSource.Volume.VolumeName; got != "data-0123abcd" {
+		t.Errorf("switched container volume = %q, want data-0123abcd", got)
+	}
+}
+
+// fakeHost serves the Docker calls a migration makes from maps of volumes
+// and containers by name. Its consumers are running containers that mount
+// its volumes, and are paused or not.
+type fakeHost struct {
+	clients.DockerClient
+	id          string
+	volumes     map[string]volume.Volume
+	containers  map[string]container.InspectResponse
+	configs     map[string]*container.Config
+	hostConfigs map[string]*container.HostConfig
+	logs        map[string]string
+	consumers   map[string]bool
+	pullAuths   []string
+}
+
+func newFakeHost(volumes ...volume.Volume) *fakeHost {
+	h := &fakeHost{
+		volumes:     map[string]volume.Volume{},
+		containers:  map[string]container.InspectResponse{},
+		configs:     map[string]*container.Config{},
+		hostConfigs: map[string]*container.HostConfig{},
+		logs:        map[string]string{},
+		consumers:   map[string]bool{},
+	}
+	for _, v := range volumes {
+		h.volumes[v.Name] = v
+	}
+	return h
+}
+
+func (h *fakeHost) VolumeInspect(_ context.Context, name string) (volume.Volume, error) {
+	v, ok := h.volumes[name]
+	if !ok {
+		return volume.Volume{}, errors.New("no such volume: " + name)
+	}
+	return v, nil
+}
+
+func (h *fakeHost) VolumeCreate(_ context.Context, o volume.CreateOptions) (volume.Volume, error) {
+	v := volume.Volume{Name: o.Name, Driver: o.Driver, Options: o.DriverOpts}
+	h.volumes[o.Name] = v
+	return v, nil
+}
+
+func (h *fakeHost) VolumeRemove(_ context.Context, name string, _ bool) error {
+	delete(h.volumes, name)
+	return nil
+}
+
+func (h *fakeHost) DiskUsage(context.Context, types.DiskUsageOptions) (types.DiskUsage, error) {
+	return types.DiskUsage{}, nil
+}
+
+func (h *fakeHost) ImagePull(_ context.Context, _ string, o image.PullOptions) (io.ReadCloser, error) {
+	h.pullAuths = append(h.pullAuths, o.RegistryAuth)
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+func (h *fakeHost) Info(context.Context) (system.Info, error) {
+	return system.Info{ID: h.id}, nil
+}
+
+func (h *fakeHost) Close() error {
+	return nil
+}
+
+func (h *fakeHost) ContainerList(context.Context, container.ListOptions) ([]container.Summary, error) {
+	var running []container.Summary
+	for id, paused := range h.consumers {
+		if !paused {
+			running = append(running, container.Summary{ID: id})
+		}
+	}
+	return running, nil
+}
+
+func (h *fakeHost) ContainerPause(_ context.Context, id string) error {
+	h.consumers[id] = true
+	return nil
+}
+
+func (h *fakeHost) ContainerUnpause(_ context.Context, id string) error {
+	h.consumers[id] = false
+	return nil
+}
+
+func (h *fakeHost) ContainerCreate(_ context.Context, config *container.Config, hostConfig *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, name string) (container.CreateResponse, error) {
+	h.configs[name] = config
+	h.hostConfigs[name] = hostConfig
+	h.containers[name] = container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{ID: name, Name: "/" + name, State: &container.State{}},
+		NetworkSettings: &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+			network.NetworkBridge: {IPAddress: "172.17.0.2"},
+		}},
+	}
+	return container.CreateResponse{ID: name}, nil
+}
+
+func (h *fakeHost) ContainerStart(_ context.Context, id string, _ container.StartOptions) error {
+	h.containers[id].State.Running = true
+	return nil
+}
+
+func (h *fakeHost) ContainerInspect(_ context.Context, name string) (container.InspectResponse, error) {
+	info, ok := h.containers[name]
+	if !ok {
+		return container.InspectResponse{}, errors.New("No such container: " + name)
+	}
+	return info, nil
+}
+
+func (h *fakeHost) ContainerRemove(_ context.Context, name string, _ container.RemoveOptions) error {
+	delete(h.containers, name)
+	return nil
+}
+
+func (h *fakeHost) ContainerLogs(_ context.Context, id string, _ container.LogsOptions) (io.ReadCloser, error) {
+	var buf bytes.Buffer
+	_, _ = stdcopy.NewStdWriter(&buf, stdcopy.Stdout).Write([]byte(h.logs[id]))
+	return io.NopCloser(&buf), nil
+}
+
+// exit makes a helper exit with code, having logged output.
+func (h *fakeHost) exit(name string, code int, output string) {
+	info := h.containers[name]
+	info.State.Running = false
+	info.State.ExitCode = code
+	h.logs[name] = output
+}
+
+func TestMigration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = apis.AddToScheme(scheme)
+
+	newVolume := func() *volumev1alpha1.Volume {
+		cr := migratingVolume("a", volumev1alpha1.VolumeParameters{
+			Driver:    stringPtr("nfs"),
+			Migration: &volumev1alpha1.VolumeMigration{},
+		}, volumev1alpha1.VolumeObservation{ProviderConfigName: "a"})
+		meta.SetExternalName(cr, "data")
+		return cr
+	}
+	newExternal := func(h *fakeHost) *external {
+		return &external{
+			client: h,
+			kube:   fake.NewClientBuilder().WithScheme(scheme).Build(),
+			logger: logging.NewNopLogger(),
+			now:    time.Now,
+		}
+	}
+	phase := func(cr *volumev1alpha1.Volume) volumev1alpha1.VolumeMigrationPhase {
+		if m := cr.Status.AtProvider.Migration; m != nil {
+			return m.Phase
+		}
+		return ""
+	}
+
+	t.Run("Completes", func(t *testing.T) {
+		h := newFakeHost(volume.Volume{Name: "data", Driver: "local"})
+		h.consumers["app"] = false
+		e := newExternal(h)
+		cr := newVolume()
+		target := migrationTarget(cr)
+
+		if err := e.migrate(context.Background(), cr); err != nil {
+			t.Fatalf("migrate(...): start: %v", err)
+		}
+		if got := phase(cr); got != volumev1alpha1.MigrationCopying {
+			t.Fatalf("migrate(...): start: phase %q, want %q", got, volumev1alpha1.MigrationCopying)
+		}
+		if _, ok := h.volumes[target]; !ok {
+			t.Errorf("migrate(...): start: target volume %s not created", target)
+		}
+		for _, name := range []string{senderName(target), receiverName(target)} {
+			if info, ok := h.containers[name]; !ok || !info.State.Running {
+				t.Errorf("migrate(...): start: helper %s not running", name)
+			}
+		}
+		if !h.consumers["app"] || !slices.Equal(cr.Status.AtProvider.Migration.PausedContainers, []string{"app"}) {
+			t.Errorf("migrate(...): start: consumer paused %v, recorded %v: want it paused while copying", h.consumers["app"], cr.Status.AtProvider.Migration.PausedContainers)
+		}
+
+		// The helpers share a token that is not part of their commands
+		sendEnv, receiveEnv := h.configs[senderName(target)].Env, h.configs[receiverName(target)].Env
+		if len(sendEnv) != 1 || !strings.HasPrefix(sendEnv[0], tokenEnv+"=") || !slices.Equal(sendEnv, receiveEnv) {
+			t.Errorf("migrate(...): start: sender env %v, receiver env %v, want the same token", sendEnv, receiveEnv)
+		}
+		token := strings.TrimPrefix(sendEnv[0], tokenEnv+"=")
+		if len(token) != 64 || strings.Contains(strings.Join(h.configs[senderName(target)].Cmd, " "), token) {
+			t.Errorf("migrate(...): start: token %q, want 32 random bytes passed by environment", token)
+		}
+		if b := h.hostConfigs[senderName(target)].PortBindings; len(b) != 0 {
+			t.Errorf("migrate(...): start: sender publishes %v on the same host, want no ports", b)
+		}
+
+		// The volume being copied is reported as existing but not up to date
+		obs, err := e.Observe(context.Background(), cr)
+		if err != nil || !obs.ResourceExists || obs.ResourceUpToDate {
+			t.Errorf("Observe(...): copying: %+v, %v, want existing and not up to date", obs, err)
+		}
+
+		if err := e.migrate(context.Background(), cr); err != nil {
+			t.Fatalf("migrate(...): helpers running: %v", err)
+		}
+		if got := phase(cr); got != volumev1alpha1.MigrationCopying {
+			t.Fatalf("migrate(...): helpers running: phase %q, want %q", got, volumev1alpha1.MigrationCopying)
+		}
+
+		h.exit(senderName(target), 0, "checksum 0123\n")
+		h.exit(receiverName(target), 0, "checksum 0123\n")
+		if err := e.migrate(context.Background(), cr); err != nil {
+			t.Fatalf("migrate(...): helpers exited: %v", err)
+		}
+		if got := phase(cr); got != volumev1alpha1.MigrationCompleted {
+			t.Fatalf("migrate(...): helpers exited: phase %q, want %q", got, volumev1alpha1.MigrationCompleted)
+		}
+		if got := cr.Status.AtProvider.Migration.Checksum; got != "0123" {
+			t.Errorf("migrate(...): checksum %q, want 0123", got)
+		}
+		if len(h.containers) != 0 {
+			t.Errorf("migrate(...): helpers left behind: %v", h.containers)
+		}
+		if !h.consumers["app"] {
+			t.Errorf("migrate(...): consumer resumed, want it paused until it is recreated on the target volume")
+		}
+		// Only the status is saved after Update, so the volume is not
+		// switched to the target until it is observed
+		if got := meta.GetExternalName(cr); got != "data" {
+			t.Errorf("migrate(...): external name %q, want data until observed", got)
+		}
+
+		obs, err = e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("Observe(...): completed: %v", err)
+		}
+		if got := meta.GetExternalName(cr); got != target {
+			t.Errorf("Observe(...): completed: external name %q, want %q", got, target)
+		}
+		if !obs.ResourceExists || !obs.ResourceUpToDate || !obs.ResourceLateInitialized {
+			t.Errorf("Observe(...): completed: %+v, want existing, up to date, and late initialized to save the external name", obs)
+		}
+
+		obs, err = e.Observe(context.Background(), cr)
+		if err != nil || obs.ResourceLateInitialized || !obs.ResourceUpToDate {
+			t.Errorf("Observe(...): switched: %+v, %v, want up to date and not late initialized", obs, err)
+		}
+	})
+
+	t.Run("ChecksumMismatchFailsThenRetries", func(t *testing.T) {
+		h := newFakeHost(volume.Volume{Name: "data", Driver: "local"})
+		h.consumers["app"] = false
+		e := newExternal(h)
+		cr := newVolume()
+		target := migrationTarget(cr)
+
+		if err := e.migrate(context.Background(), cr); err != nil {
+			t.Fatalf("migrate(...): start: %v", err)
+		}
+		h.exit(senderName(target), 0, "checksum 0123\n")
+		h.exit(receiverName(target), 0, "checksum 4567\n")
+		if err := e.migrate(context.Background(), cr); err == nil {
+			t.Fatal("migrate(...): checksum mismatch: want error")
+		}
+		if got := phase(cr); got != volumev1alpha1.MigrationFailed {
+			t.Fatalf("migrate(...): checksum mismatch: phase %q, want %q", got, volumev1alpha1.MigrationFailed)
+		}
+		if got := meta.GetExternalName(cr); got != "data" {
+			t.Errorf("migrate(...): checksum mismatch: external name %q, want data", got)
+		}
+		if h.consumers["app"] || len(cr.Status.AtProvider.Migration.PausedContainers) != 0 {
+			t.Errorf("migrate(...): checksum mismatch: consumer still paused")
+		}
+
+		// A failed migration starts again, replacing its target and helpers
+		if err := e.migrate(context.Background(), cr); err != nil {
+			t.Fatalf("migrate(...): retry: %v", err)
+		}
+		if got := phase(cr); got != volumev1alpha1.MigrationCopying {
+			t.Fatalf("migrate(...): retry: phase %q, want %q", got, volumev1alpha1.MigrationCopying)
+		}
+		if info := h.containers[senderName(target)]; info.ContainerJSONBase == nil || !info.State.Running {
+			t.Errorf("migrate(...): retry: sender not restarted")
+		}
+		if !h.consumers["app"] {
+			t.Errorf("migrate(...): retry: consumer not paused again")
+		}
+	})
+
+	t.Run("HelperFails", func(t *testing.T) {
+		h := newFakeHost(volume.Volume{Name: "data", Driver: "local"})
+		e := newExternal(h)
+		cr := newVolume()
+		target := migrationTarget(cr)
+
+		if err := e.migrate(context.Background(), cr); err != nil {
+			t.Fatalf("migrate(...): start: %v", err)
+		}
+		h.exit(senderName(target), 0, "checksum 0123\n")
+		h.exit(receiverName(target), 1, "nc: can't connect\n")
+		err := e.migrate(context.Background(), cr)
+		if err == nil || !strings.Contains(err.Error(), "nc: can't connect") {
+			t.Fatalf("migrate(...): helper failed: %v, want the helper's logs", err)
+		}
+		if got := phase(cr); got != volumev1alpha1.MigrationFailed {
+			t.Errorf("migrate(...): helper failed: phase %q, want %q", got, volumev1alpha1.MigrationFailed)
+		}
+	})
+
+	t.Run("HelperGone", func(t *testing.T) {
+		h := newFakeHost(volume.Volume{Name: "data", Driver: "local"})
+		e := newExternal(h)
+		cr := newVolume()
+
+		if err := e.migrate(context.Background(), cr); err != nil {
+			t.Fatalf("migrate(...): start: %v", err)
+		}
+		_ = h.ContainerRemove(context.Background(), senderName(migrationTarget(cr)), container.RemoveOptions{})
+		if err := e.migrate(context.Background(), cr); err == nil {
+			t.Fatal("migrate(...): helper gone: want error")
+		}
+		if got := phase(cr); got != volumev1alpha1.MigrationFailed {
+			t.Errorf("migrate(...): helper gone: phase %q, want %q", got, volumev1alpha1.MigrationFailed)
+		}
+	})
+
+	t.Run("AcrossHosts", func(t *testing.T) {
+		src := newFakeHost(volume.Volume{Name: "data", Driver: "local"})
+		src.id = "old"
+		src.consumers["app"] = false
+		h := newFakeHost()
+		h.id = "new"
+		e := newExternal(h)
+		e.connectTo = func(context.Context, resource.Managed, *xpv1.ProviderConfigReference) (clients.DockerClient, error) {
+			return src, nil
+		}
+		e.hostOf = func(context.Context, resource.Managed, *xpv1.ProviderConfigReference) (string, error) {
+			return "tcp://10.0.0.5:2376", nil
+		}
+		// Each helper is pulled with the registry credentials of the
+		// ProviderConfig of its host
+		e.registryAuthsOf = func(_ context.Context, _ resource.Managed, ref *xpv1.ProviderConfigReference) (map[string]clients.RegistryAuth, error) {
+			return map[string]clients.RegistryAuth{"registry.example.com": {Username: "user-" + ref.Name, Password: "secret"}}, nil
+		}
+		cr := migratingVolume("b", volumev1alpha1.VolumeParameters{Migration: &volumev1alpha1.VolumeMigration{
+			HelperImage: stringPtr("registry.example.com/tools/busybox:1.36"),
+		}}, volumev1alpha1.VolumeObservation{ProviderConfigName: "a"})
+		meta.SetExternalName(cr, "data")
+
+		if err := e.migrate(context.Background(), cr); err != nil {
+			t.Fatalf("migrate(...): start: %v", err)
+		}
+		for _, pulled := range []struct {
+			host *fakeHost
+			user string
+		}{{src, "user-a"}, {h, "user-b"}} {
+			if len(pulled.host.pullAuths) != 1 || pullUser(t, pulled.host.pullAuths[0]) != pulled.user {
+				t.Errorf("migrate(...): helper pulled with %q, want the credentials of %s", pulled.host.pullAuths, pulled.user)
+			}
+		}
+		// The sender is only published on the address the new host reaches
+		// the old host at
+		bindings := src.hostConfigs[senderName("data")].PortBindings
+		want := nat.PortMap{"7070/tcp": {{HostIP: "10.0.0.5", HostPort: "7070"}}}
+		if !reflect.DeepEqual(bindings, want) {
+			t.Errorf("migrate(...): sender published on %v, want %v", bindings, want)
+		}
+		if cmd := strings.Join(h.configs[receiverName("data")].Cmd, " "); !strings.Contains(cmd, "nc 10.0.0.5 7070") {
+			t.Errorf("migrate(...): receiver runs %q, want it to reach the old host", cmd)
+		}
+		if !src.consumers["app"] {
+			t.Errorf("migrate(...): consumer on the old host not paused")
+		}
+	})
+
+	t.Run("SourceNotReachable", func(t *testing.T) {
+		src := newFakeHost(volume.Volume{Name: "data", Driver: "local"})
+		src.id = "old"
+		src.consumers["app"] = false
+		h := newFakeHost()
+		h.id = "new"
+		e := newExternal(h)
+		e.connectTo = func(context.Context, resource.Managed, *xpv1.ProviderConfigReference) (clients.DockerClient, error) {
+			return src, nil
+		}
+		e.hostOf = func(context.Context, resource.Managed, *xpv1.ProviderConfigReference) (string, error) {
+			return "unix:///var/run/docker.sock", nil
+		}
+		cr := migratingVolume("b", volumev1alpha1.VolumeParameters{Migration: &volumev1alpha1.VolumeMigration{}}, volumev1alpha1.VolumeObservation{ProviderConfigName: "a"})
+		meta.SetExternalName(cr, "data")
+
+		err := e.migrate(context.Background(), cr)
+		if err == nil || !strings.Contains(err.Error(), "must be a tcp:// host") {
+			t.Fatalf("migrate(...): %v, want the old host to be a tcp:// host", err)
+		}
+		if _, ok := src.containers[senderName("data")]; ok {
+			t.Errorf("migrate(...): sender started")
+		}
+		if src.consumers["app"] {
+			t.Errorf("migrate(...): consumer left paused after failing")
+		}
+	})
+
+	t.Run("TargetExists", func(t *testing.T) {
+		cr := newVolume()
+		h := newFakeHost(volume.Volume{Name: "data", Driver: "local"}, volume.Volume{Name: migrationTarget(cr), Driver: "nfs"})
+		e := newExternal(h)
+
+		err := e.migrate(context.Background(), cr)
+		if err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Fatalf("migrate(...): target exists: %v, want it to exist already", err)
+		}
+		if cr.Status.AtProvider.Migration != nil {
+			t.Errorf("migrate(...): target exists: started %+v", cr.Status.AtProvider.Migration)
+		}
+	})
+
+	t.Run("HostMoveStartsOnUpdate", func(t *testing.T) {
+		src := newFakeHost(volume.Volume{Name: "data", Driver: "local"})
+		src.id = "old"
+		h := newFakeHost()
+		h.id = "new"
+		e := newExternal(h)
+		e.connectTo = func(context.Context, resource.Managed, *xpv1.ProviderConfigReference) (clients.DockerClient, error) {
+			return src, nil
+		}
+		e.hostOf = func(context.Context, resource.Managed, *xpv1.ProviderConfigReference) (string, error) {
+			return "tcp://10.0.0.5:2376", nil
+		}
+		cr := migratingVolume("b", volumev1alpha1.VolumeParameters{Migration: &volumev1alpha1.VolumeMigration{}}, volumev1alpha1.VolumeObservation{ProviderConfigName: "a"})
+		meta.SetExternalName(cr, "data")
+
+		// Only the annotations of a volume are saved after Create, so the
+		// volume is not created on its new host, but updated
+		obs, err := e.Observe(context.Background(), cr)
+		if err != nil || !obs.ResourceExists || obs.ResourceUpToDate {
+			t.Fatalf("Observe(...): moved: %+v, %v, want existing and not up to date", obs, err)
+		}
+		if _, err := e.Update(context.Background(), cr); err != nil {
+			t.Fatalf("Update(...): moved: %v", err)
+		}
+		if got := phase(cr); got != volumev1alpha1.MigrationCopying {
+			t.Fatalf("Update(...): moved: phase %q, want %q", got, volumev1alpha1.MigrationCopying)
+		}
+
+		// A volume being deleted is not migrated to its new host
+		cr = migratingVolume("b", volumev1alpha1.VolumeParameters{Migration: &volumev1alpha1.VolumeMigration{}}, volumev1alpha1.VolumeObservation{ProviderConfigName: "a"})
+		meta.SetExternalName(cr, "data")
+		cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+		obs, err = newExternal(newFakeHost()).Observe(context.Background(), cr)
+		if err != nil || obs.ResourceExists {
+			t.Errorf("Observe(...): moved and deleted: %+v, %v, want not existing", obs, err)
+		}
+	})
+
+	t.Run("DeletedWhileCopying", func(t *testing.T) {
+		h := newFakeHost(volume.Volume{Name: "data", Driver: "local"})
+		h.consumers["app"] = false
+		e := newExternal(h)
+		cr := newVolume()
+
+		if err := e.migrate(context.Background(), cr); err != nil {
+			t.Fatalf("migrate(...): start: %v", err)
+		}
+		cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+		if _, err := e.Delete(context.Background(), cr); err != nil {
+			t.Fatalf("Delete(...): %v", err)
+		}
+		if cr.Status.AtProvider.Migration != nil {
+			t.Errorf("Delete(...): migration %+v, want it cleared", cr.Status.AtProvider.Migration)
+		}
+		if len(h.containers) != 0 || len(h.volumes) != 0 {
+			t.Errorf("Delete(...): left %v and %v behind", h.containers, h.volumes)
+		}
+		if h.consumers["app"] {
+			t.Errorf("Delete(...): consumer left paused")
+		}
+
+		obs, err := e.Observe(context.Background(), cr)
+		if err != nil || obs.ResourceExists {
+			t.Errorf("Observe(...): deleted: %+v, %v, want not existing", obs, err)
+		}
+	})
+}
+
+func TestSwitchToTarget(t *testing.T) {
+	tests := map[string]struct {
+		externalName string
+		migration    *volumev1alpha1.VolumeMigrationStatus
+		want         string
+		wantSwitched bool
+	}{
+		"NoMigration": {
+			externalName: "data",
+			want:         "data",
+		},
+		"Copying": {
+			externalName: "data",
+			migration:    &volumev1alpha1.VolumeMigrationStatus{Phase: volumev1alpha1.MigrationCopying, Source: "data", Target: "data-0123abcd"},
+			want:         "data",
+		},
+		"Failed": {
+			externalName: "data",
+			migration:    &volumev1alpha1.VolumeMigrationStatus{Phase: volumev1alpha1.MigrationFailed, Source: "data", Target: "data-0123abcd"},
+			want:         "data",
+		},
+		"Completed": {
+			externalName: "data",
+			migration:    &volumev1alpha1.VolumeMigrationStatus{Phase: volumev1alpha1.MigrationCompleted, Source: "data", Target: "data-0123abcd"},
+			want:         "data-0123abcd",
+			wantSwitched: true,
+		},
+		"AlreadySwitched": {
+			externalName: "data-0123abcd",
+			migration:    &volumev1alpha1.VolumeMigrationStatus{Phase: volumev1alpha1.MigrationCompleted, Source: "data", Target: "data-0123abcd"},
+			want:         "data-0123abcd",
+		},
+		"MovedHost": {
+			externalName: "data",
+			migration:    &volumev1alpha1.VolumeMigrationStatus{Phase: volumev1alpha1.MigrationCompleted, Source: "data", Target: "data"},
+			want:         "data",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := migratingVolume("a", volumev1alpha1.VolumeParameters{}, volumev1alpha1.VolumeObservation{Migration: tt.migration})
+			meta.SetExternalName(cr, tt.externalName)
+			switched := switchToTarget(cr)
+			if got := meta.GetExternalName(cr); got != tt.want || switched != tt.wantSwitched {
+				t.Errorf("switchToTarget(...) = %v, external name %q: want %v, %q", switched, got, tt.wantSwitched, tt.want)
+			}
+		})
+	}
+}
+
+// pullUser decodes the username of the credentials a pull was made with.
+func pullUser(t *testing.T, encoded string) string {
+	t.Helper()
+	raw, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode registry auth: %v", err)
+	}
+	var auth registry.AuthConfig
+	if err := json.Unmarshal(raw, &auth); err != nil {
+		t.Fatalf("cannot unmarshal registry auth: %v", err)
+	}
+	return auth.Username
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dryrun lets the provider observe resources and report the changes
+// it would make to Docker hosts, without making them, so that a new version
+// of the provider can be validated against production hosts.
+package dryrun
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+// ErrDryRun is returned instead of making a change to a Docker host while
+// the provider runs in dry-run mode.
+var ErrDryRun = errors.New("provider is running in dry-run mode")
+
+// ReasonDryRun is the reason of the events recording the changes that would
+// have been made.
+const ReasonDryRun event.Reason = "DryRun"
+
+var enabled atomic.Bool
+
+// Enable puts the provider in dry-run mode.
+func Enable() {
+	enabled.Store(true)
+}
+
+// Enabled reports whether the provider is in dry-run mode.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Connector wraps c so that, in dry-run mode, the external clients it
+// connects observe resources as usual but never create, update or delete
+// them. The change that would have been made is recorded as an event, and
+// returned as an ErrDryRun error, which the managed reconciler reports in
+// the resource's Synced condition.
+func Connector(c managed.ExternalConnector, recorder event.Recorder) managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		if err != nil || !Enabled() {
+			return ec, err
+		}
+		return &client{client: ec, recorder: recorder}, nil
+	})
+}
+
+type client struct {
+	client   managed.ExternalClient
+	recorder event.Recorder
+
+	// diff is the difference between the desired and observed state of
+	// the resource, as last observed.
+	diff string
+}
+
+func (c *client) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.client.Observe(ctx, mg)
+	c.diff = o.Diff
+	return o, err
+}
+
+func (c *client) Create(_ context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, c.skip(mg, "create")
+}
+
+func (c *client) Update(_ context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, c.skip(mg, "update")
+}
+
+func (c *client) Delete(_ context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	return managed.ExternalDelete{}, c.skip(mg, "delete")
+}
+
+func (c *client) Disconnect(ctx context.Context) error {
+	return c.client.Disconnect(ctx)
+}
+
+// skip records the action that would have been taken on mg, and returns the
+// error reporting it.
+func (c *client) skip(mg resource.Managed, action string) error {
+	msg := "would " + action + " the external resource"
+	if action == "update" && c.diff != "" {
+		msg += ": " + c.diff
+	}
+	if c.recorder != nil {
+		c.recorder.Event(mg, event.Normal(ReasonDryRun, msg))
+	}
+	return errors.Wrap(ErrDryRun, msg)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func TestConnector(t *testing.T) {
+	mutated := false
+	inner := managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{ResourceExists: true, Diff: "image: nginx:1.27 -> nginx:1.28"}, nil
+			},
+			CreateFn: func(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+				mutated = true
+				return managed.ExternalCreation{}, nil
+			},
+			UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+				mutated = true
+				return managed.ExternalUpdate{}, nil
+			},
+			DeleteFn: func(context.Context, resource.Managed) (managed.ExternalDelete, error) {
+				mutated = true
+				return managed.ExternalDelete{}, nil
+			},
+			DisconnectFn: func(context.Context) error { return nil },
+		}, nil
+	})
+	ctx := context.Background()
+	mg := &fake.Managed{}
+
+	// Outside dry-run mode the connected client is used as it is.
+	rec := &recorder{}
+	ec, err := Connector(inner, rec).Connect(ctx, mg)
+	if err != nil {
+		t.Fatalf("Connect(...): %v", err)
+	}
+	if _, err := ec.Create(ctx, mg); err != nil || !mutated {
+		t.Fatalf("Create(...) outside dry-run mode: error = %v, created = %t", err, mutated)
+	}
+
+	mutated = false
+	Enable()
+	t.Cleanup(func() { enabled.Store(false) })
+
+	ec, err = Connector(inner, rec).Connect(ctx, mg)
+	if err != nil {
+		t.Fatalf("Connect(...): %v", err)
+	}
+	if o, err := ec.Observe(ctx, mg); err != nil || !o.ResourceExists {
+		t.Errorf("Observe(...) = %+v, %v, want the wrapped observation", o, err)
+	}
+	_, createErr := ec.Create(ctx, mg)
+	_, updateErr := ec.Update(ctx, mg)
+	_, deleteErr := ec.Delete(ctx, mg)
+	for action, err := range map[string]error{"create": createErr, "update": updateErr, "delete": deleteErr} {
+		if !errors.Is(err, ErrDryRun) {
+			t.Errorf("%s error = %v, want %v", action, err, ErrDryRun)
+		}
+	}
+	if mutated {
+		t.Error("external resource was changed in dry-run mode")
+	}
+
+	if len(rec.events) != 3 {
+		t.Fatalf("recorded %d events, want 3", len(rec.events))
+	}
+	if msg := rec.events[1].Message; !strings.Contains(msg, "would update") || !strings.Contains(msg, "nginx:1.28") {
+		t.Errorf("update event message = %q, want the intended update and its diff", msg)
+	}
+	for _, e := range rec.events {
+		if e.Reason != ReasonDryRun || e.Type != event.TypeNormal {
+			t.Errorf("event %+v, want a normal %s event", e, ReasonDryRun)
+		}
+	}
+}
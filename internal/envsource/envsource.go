@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envsource resolves container environment variable values from
+// stores registered with the provider, such as Kubernetes Secrets. New
+// stores, for example Vault or SSM, plug in by implementing Source.
+package envsource
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Names of the built-in stores.
+const (
+	StoreSecret = "secret"
+	StoreEnv    = "env"
+)
+
+// DefaultEnvPrefix is the prefix the env store adds to keys, so that only
+// provider environment variables meant for containers can be read.
+const DefaultEnvPrefix = "DOCKER_ENV_"
+
+const (
+	errUnknownStore = "no value store named %q is registered"
+	errNoNamespace  = "a namespace is required to read a Secret"
+	errGetSecret    = "cannot get Secret"
+)
+
+// ErrNotFound is returned, possibly wrapped, by a Source when the value
+// does not exist. Optional env vars are skipped rather than failing.
+var ErrNotFound = errors.New("value not found")
+
+// IsNotFound reports whether err indicates that a value does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// A Ref identifies a value within a store.
+type Ref struct {
+	// Namespace of the resource the value is resolved for. It is empty for
+	// cluster scoped resources.
+	Namespace string
+
+	// Name of the object within the store holding the value, if the store
+	// has objects.
+	Name string
+
+	// Key of the value.
+	Key string
+}
+
+// A Source supplies environment variable values from a store.
+type Source interface {
+	Resolve(ctx context.Context, ref Ref) (string, error)
+}
+
+// A SourceFn is a function that satisfies Source.
+type SourceFn func(ctx context.Context, ref Ref) (string, error)
+
+// Resolve calls fn.
+func (fn SourceFn) Resolve(ctx context.Context, ref Ref) (string, error) {
+	return fn(ctx, ref)
+}
+
+// A Registry maps store names to sources.
+type Registry struct {
+	mu      sync.RWMutex
+	sources map[string]Source
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{sources: map[string]Source{}}
+}
+
+// Register makes s available as the named store, replacing any source
+// already registered under that name.
+func (r *Registry) Register(name string, s Source) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources[name] = s
+}
+
+// Stores returns the names of the registered stores, sorted.
+func (r *Registry) Stores() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.sources))
+	for n := range r.sources {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve reads ref from the named store.
+func (r *Registry) Resolve(ctx context.Context, store string, ref Ref) (string, error) {
+	r.mu.RLock()
+	s, ok := r.sources[store]
+	r.mu.RUnlock()
+	if !ok {
+		return "", errors.Errorf(errUnknownStore, store)
+	}
+	return s.Resolve(ctx, ref)
+}
+
+var defaultRegistry = NewRegistry()
+
+// Default returns the registry the provider registers its stores with.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register makes s available as the named store of the default registry.
+func Register(name string, s Source) {
+	defaultRegistry.Register(name, s)
+}
+
+// NewSecretSource returns a Source that reads keys of Secrets in the
+// namespace of the resource.
+func NewSecretSource(kube client.Reader) Source {
+	return SourceFn(func(ctx context.Context, ref Ref) (string, error) {
+		if ref.Namespace == "" {
+			return "", errors.New(errNoNamespace)
+		}
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			if kerrors.IsNotFound(err) {
+				return "", errors.Wrapf(ErrNotFound, "Secret %s/%s", ref.Namespace, ref.Name)
+			}
+			return "", errors.Wrap(err, errGetSecret)
+		}
+		v, ok := s.Data[ref.Key]
+		if !ok {
+			return "", errors.Wrapf(ErrNotFound, "key %q of Secret %s/%s", ref.Key, ref.Namespace, ref.Name)
+		}
+		return string(v), nil
+	})
+}
+
+// NewEnvSource returns a Source that reads the provider's own environment
+// variable named prefix followed by the key.
+func NewEnvSource(prefix string) Source {
+	return SourceFn(func(_ context.Context, ref Ref) (string, error) {
+		v, ok := os.LookupEnv(prefix + ref.Key)
+		if !ok {
+			return "", errors.Wrapf(ErrNotFound, "environment variable %s", prefix+ref.Key)
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envsource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Register("static", SourceFn(func(_ context.Context, ref Ref) (string, error) {
+		return ref.Key, nil
+	}))
+
+	got, err := r.Resolve(context.Background(), "static", Ref{Key: "value"})
+	if err != nil || got != "value" {
+		t.Errorf("Resolve(static) = %q, %v, want %q, nil", got, err, "value")
+	}
+
+	if _, err := r.Resolve(context.Background(), "vault", Ref{Key: "value"}); err == nil {
+		t.Error("Resolve(vault) error = nil, want unknown store error")
+	}
+
+	if diff := cmp.Diff([]string{"static"}, r.Stores()); diff != "" {
+		t.Errorf("Stores(): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSecretSource(t *testing.T) {
+	kube := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "db"},
+		Data:       map[string][]byte{"password": []byte("s3cret")},
+	}).Build()
+	s := NewSecretSource(kube)
+
+	cases := map[string]struct {
+		ref      Ref
+		want     string
+		notFound bool
+		err      bool
+	}{
+		"Found": {
+			ref:  Ref{Namespace: "team-a", Name: "db", Key: "password"},
+			want: "s3cret",
+		},
+		"MissingKey": {
+			ref:      Ref{Namespace: "team-a", Name: "db", Key: "user"},
+			notFound: true,
+		},
+		"MissingSecret": {
+			ref:      Ref{Namespace: "team-b", Name: "db", Key: "password"},
+			notFound: true,
+		},
+		"NoNamespace": {
+			ref: Ref{Name: "db", Key: "password"},
+			err: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := s.Resolve(context.Background(), tc.ref)
+			if IsNotFound(err) != tc.notFound {
+				t.Errorf("IsNotFound(%v) = %t, want %t", err, !tc.notFound, tc.notFound)
+			}
+			if (err != nil) != (tc.err || tc.notFound) {
+				t.Fatalf("Resolve(...) error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Resolve(...) = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEnvSource(t *testing.T) {
+	t.Setenv(DefaultEnvPrefix+"API_TOKEN", "token")
+	s := NewEnvSource(DefaultEnvPrefix)
+
+	got, err := s.Resolve(context.Background(), Ref{Key: "API_TOKEN"})
+	if err != nil || got != "token" {
+		t.Errorf("Resolve(API_TOKEN) = %q, %v, want %q, nil", got, err, "token")
+	}
+
+	if _, err := s.Resolve(context.Background(), Ref{Key: "UNSET"}); !IsNotFound(err) {
+		t.Errorf("Resolve(UNSET) error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/pkg/errors"
+)
+
+// Feature gates of subsystems that ship disabled until they are proven, and
+// are enabled with --feature-gates.
+const (
+	// EventsWatcher reconciles containers as soon as the Docker events of
+	// their host report a change, rather than only when they are polled.
+	EventsWatcher feature.Flag = "EventsWatcher"
+
+	// AsyncPulls pulls images in the background, so that a slow pull does
+	// not hold a reconcile open.
+	AsyncPulls feature.Flag = "AsyncPulls"
+
+	// SwarmResources enables the controllers of Docker Swarm resources.
+	SwarmResources feature.Flag = "SwarmResources"
+
+	// BuildKit builds images with BuildKit rather than the legacy builder.
+	BuildKit feature.Flag = "BuildKit"
+)
+
+// A Stage is how mature a feature gate is.
+type Stage string
+
+// Stages of feature gates. Alpha gates are disabled by default and may
+// change or be removed; beta gates are enabled by default.
+const (
+	StageAlpha Stage = "Alpha"
+	StageBeta  Stage = "Beta"
+)
+
+// A Gate is a feature that can be enabled or disabled when the provider
+// starts.
+type Gate struct {
+	Name        feature.Flag `json:"name"`
+	Stage       Stage        `json:"stage"`
+	Default     bool         `json:"default"`
+	Description string       `json:"description"`
+}
+
+// Gates are the feature gates of the provider, in the order they are
+// reported.
+var Gates = []Gate{
+	{Name: EventsWatcher, Stage: StageAlpha, Description: "Reconcile containers on Docker events as well as when they are polled."},
+	{Name: AsyncPulls, Stage: StageAlpha, Description: "Pull images in the background rather than within a reconcile."},
+	{Name: SwarmResources, Stage: StageAlpha, Description: "Run the controllers of Docker Swarm resources."},
+	{Name: BuildKit, Stage: StageAlpha, Description: "Build images with BuildKit."},
+}
+
+const (
+	errUnknownGate = "unknown feature gate %q, want one of %s"
+	errGateValue   = "feature gate %q must be set to true or false"
+	errGateSpec    = "feature gate %q must be set as Name=true or Name=false"
+)
+
+// A GateSet records which feature gates are enabled.
+type GateSet struct {
+	enabled map[feature.Flag]bool
+}
+
+// ParseGates parses a comma-separated list of Name=true and Name=false
+// pairs, as passed to --feature-gates, over the defaults of the gates.
+// Unknown gates are an error, so that a misspelt gate is not silently left
+// disabled.
+func ParseGates(spec string) (*GateSet, error) {
+	s := &GateSet{enabled: make(map[feature.Flag]bool, len(Gates))}
+	for _, g := range Gates {
+		s.enabled[g.Name] = g.Default
+	}
+	for pair := range strings.SplitSeq(spec, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, errors.Errorf(errGateSpec, pair)
+		}
+		flag := feature.Flag(strings.TrimSpace(name))
+		if _, known := s.enabled[flag]; !known {
+			return nil, errors.Errorf(errUnknownGate, flag, strings.Join(GateNames(), ", "))
+		}
+		on, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, errors.Errorf(errGateValue, flag)
+		}
+		s.enabled[flag] = on
+	}
+	return s, nil
+}
+
+// GateNames returns the names of the feature gates.
+func GateNames() []string {
+	names := make([]string, 0, len(Gates))
+	for _, g := range Gates {
+		names = append(names, string(g.Name))
+	}
+	return names
+}
+
+// Enabled returns the gates that are enabled, in the order of Gates.
+func (s *GateSet) Enabled() []feature.Flag {
+	var enabled []feature.Flag
+	for _, g := range Gates {
+		if s.enabled[g.Name] {
+			enabled = append(enabled, g.Name)
+		}
+	}
+	return enabled
+}
+
+// Apply enables the enabled gates in fs, where controllers check them.
+func (s *GateSet) Apply(fs *feature.Flags) {
+	for _, flag := range s.Enabled() {
+		fs.Enable(flag)
+	}
+}
+
+// A GateStatus is a feature gate and whether it is enabled.
+type GateStatus struct {
+	Gate
+	Enabled bool `json:"enabled"`
+}
+
+// Status returns every gate and whether it is enabled.
+func (s *GateSet) Status() []GateStatus {
+	status := make([]GateStatus, 0, len(Gates))
+	for _, g := range Gates {
+		status = append(status, GateStatus{Gate: g, Enabled: s.enabled[g.Name]})
+	}
+	return status
+}
+
+// ServeHTTP serves the status of the gates as JSON.
+func (s *GateSet) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.Status())
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseGates(t *testing.T) {
+	tests := map[string]struct {
+		spec    string
+		want    []feature.Flag
+		wantErr bool
+	}{
+		"Defaults":      {spec: ""},
+		"Enabled":       {spec: "EventsWatcher=true, BuildKit=true", want: []feature.Flag{EventsWatcher, BuildKit}},
+		"LastWins":      {spec: "AsyncPulls=true,AsyncPulls=false"},
+		"UnknownGate":   {spec: "Telepathy=true", wantErr: true},
+		"InvalidValue":  {spec: "AsyncPulls=yes please", wantErr: true},
+		"MissingValue":  {spec: "AsyncPulls", wantErr: true},
+		"TrailingComma": {spec: "SwarmResources=1,", want: []feature.Flag{SwarmResources}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := ParseGates(tc.spec)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseGates(%q): error = %v, wantErr %v", tc.spec, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, s.Enabled()); diff != "" {
+				t.Errorf("ParseGates(%q).Enabled(): -want, +got:\n%s", tc.spec, diff)
+			}
+		})
+	}
+}
+
+func TestGateSetApply(t *testing.T) {
+	s, err := ParseGates("AsyncPulls=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := &feature.Flags{}
+	s.Apply(fs)
+	if !fs.Enabled(AsyncPulls) || fs.Enabled(EventsWatcher) {
+		t.Errorf("Apply(...) enabled AsyncPulls=%t EventsWatcher=%t, want only AsyncPulls", fs.Enabled(AsyncPulls), fs.Enabled(EventsWatcher))
+	}
+}
+
+func TestGateSetServeHTTP(t *testing.T) {
+	s, err := ParseGates("BuildKit=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", "/features", nil))
+
+	var got []map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("cannot decode %s: %v", rec.Body, err)
+	}
+	if len(got) != len(Gates) {
+		t.Fatalf("ServeHTTP(...) reported %d gates, want %d", len(got), len(Gates))
+	}
+	for _, g := range got {
+		if want := g["name"] == string(BuildKit); g["enabled"] != want {
+			t.Errorf("ServeHTTP(...) reported %v enabled=%v, want %t", g["name"], g["enabled"], want)
+		}
+		if g["stage"] != string(StageAlpha) {
+			t.Errorf("ServeHTTP(...) reported %v at stage %v, want Alpha", g["name"], g["stage"])
+		}
+	}
+}
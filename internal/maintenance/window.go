@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package maintenance decides when disruptive operations, such as recreating
+// a container, may run, given the recurring maintenance window of a resource.
+package maintenance
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// searchLimit bounds how far ahead the next opening of a window is looked
+// for, so that a schedule that never fires, such as the 30th of February,
+// does not loop forever.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+// A Window is a recurring period in which disruptive operations may run. It
+// opens at each time matched by a cron schedule and stays open for a
+// duration.
+type Window struct {
+	schedule *schedule
+	duration time.Duration
+	location *time.Location
+}
+
+// New returns the window opened by a standard five-field cron schedule, of
+// minute, hour, day of month, month and day of week, in the given IANA time
+// zone, or in UTC if it is empty.
+func New(cron string, duration time.Duration, timeZone string) (*Window, error) {
+	s, err := parseSchedule(cron)
+	if err != nil {
+		return nil, err
+	}
+	if duration <= 0 {
+		return nil, errors.Errorf("maintenance window duration must be positive, not %s", duration)
+	}
+	loc := time.UTC
+	if timeZone != "" {
+		if loc, err = time.LoadLocation(timeZone); err != nil {
+			return nil, errors.Wrapf(err, "invalid maintenance window time zone %q", timeZone)
+		}
+	}
+	return &Window{schedule: s, duration: duration, location: loc}, nil
+}
+
+// Open reports whether the window is open at t.
+func (w *Window) Open(t time.Time) bool {
+	// The window is open if it last opened within its duration of t
+	from := t.Add(-w.duration)
+	start := from.Truncate(time.Minute)
+	if !start.After(from) {
+		start = start.Add(time.Minute)
+	}
+	opened, ok := w.schedule.next(start.In(w.location))
+	return ok && !opened.After(t)
+}
+
+// Next returns when the window next opens after t, and false if it never
+// does.
+func (w *Window) Next(t time.Time) (time.Time, bool) {
+	return w.schedule.next(t.Truncate(time.Minute).Add(time.Minute).In(w.location))
+}
+
+// A schedule is a parsed cron expression: the values each field matches.
+type schedule struct {
+	minute, hour, dom, month, dow map[int]bool
+
+	// Days match either field when both the day of month and the day of
+	// week are restricted, as in cron.
+	domAny, dowAny bool
+}
+
+// parseSchedule parses a standard five-field cron expression. Each field is
+// *, a value, a range a-b, either with a step /n, or a comma-separated list
+// of these.
+func parseSchedule(cron string) (*schedule, error) {
+	fields := strings.Fields(cron)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("maintenance window schedule %q must have 5 fields", cron)
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	sets := make([]map[int]bool, 5)
+	for i, f := range fields {
+		set, err := parseField(f, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid maintenance window schedule %q", cron)
+		}
+		sets[i] = set
+	}
+
+	// Sunday is both 0 and 7
+	if sets[4][7] {
+		sets[4][0] = true
+	}
+
+	return &schedule{
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		domAny: fields[2] == "*", dowAny: fields[4] == "*",
+	}, nil
+}
+
+// parseField returns the values within [lo, hi] a cron field matches.
+func parseField(field string, lo, hi int) (map[int]bool, error) {
+	set := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			s, err := strconv.Atoi(stepStr)
+			if err != nil || s <= 0 {
+				return nil, errors.Errorf("invalid step %q", stepStr)
+			}
+			step = s
+		}
+
+		from, to := lo, hi
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if from, err = strconv.Atoi(a); err != nil {
+				return nil, errors.Errorf("invalid value %q", a)
+			}
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(b); err != nil {
+					return nil, errors.Errorf("invalid value %q", b)
+				}
+			} else if hasStep {
+				to = hi
+			}
+		}
+		if from < lo || to > hi || from > to {
+			return nil, errors.Errorf("%q is out of range %d-%d", part, lo, hi)
+		}
+
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// matchesDay reports whether the schedule fires on the day of t.
+func (s *schedule) matchesDay(t time.Time) bool {
+	dom, dow := s.dom[t.Day()], s.dow[int(t.Weekday())]
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// next returns the first time at or after t, which must be on a minute, that
+// the schedule fires.
+func (s *schedule) next(t time.Time) (time.Time, bool) {
+	limit := t.Add(searchLimit)
+	for t.Before(limit) {
+		switch {
+		case !s.month[int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !s.hour[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !s.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Defer reports whether acting on drift observed at t must wait for the
+// window to open, with a message saying until when.
+func (w *Window) Defer(t time.Time) (bool, string) {
+	if w.Open(t) {
+		return false, ""
+	}
+	next, ok := w.Next(t)
+	if !ok {
+		return true, "changes are deferred: the maintenance window never opens"
+	}
+	return true, "changes are deferred until the maintenance window opens at " + next.Format(time.RFC3339)
+}
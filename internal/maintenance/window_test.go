@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewInvalid(t *testing.T) {
+	tests := map[string]struct {
+		cron     string
+		duration time.Duration
+		timeZone string
+	}{
+		"TooFewFields":    {cron: "0 2 * *", duration: time.Hour},
+		"OutOfRange":      {cron: "0 24 * * *", duration: time.Hour},
+		"BadStep":         {cron: "*/0 * * * *", duration: time.Hour},
+		"BackwardsRange":  {cron: "0 5-2 * * *", duration: time.Hour},
+		"NotANumber":      {cron: "0 two * * *", duration: time.Hour},
+		"NoDuration":      {cron: "0 2 * * *"},
+		"UnknownTimeZone": {cron: "0 2 * * *", duration: time.Hour, timeZone: "Nowhere/Special"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New(tt.cron, tt.duration, tt.timeZone); err == nil {
+				t.Errorf("New(%q, %s, %q) succeeded, want error", tt.cron, tt.duration, tt.timeZone)
+			}
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	// 2026-10-17 is a Saturday
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	tests := map[string]struct {
+		cron     string
+		duration time.Duration
+		timeZone string
+		now      string
+		open     bool
+		next     string
+	}{
+		"Before": {
+			cron: "0 2 * * 6", duration: 2 * time.Hour,
+			now: "2026-10-17T01:59:00Z", open: false, next: "2026-10-17T02:00:00Z",
+		},
+		"Opening": {
+			cron: "0 2 * * 6", duration: 2 * time.Hour,
+			now: "2026-10-17T02:00:00Z", open: true, next: "2026-10-24T02:00:00Z",
+		},
+		"During": {
+			cron: "0 2 * * 6", duration: 2 * time.Hour,
+			now: "2026-10-17T03:59:30Z", open: true, next: "2026-10-24T02:00:00Z",
+		},
+		"Closing": {
+			cron: "0 2 * * 6", duration: 2 * time.Hour,
+			now: "2026-10-17T04:00:00Z", open: false, next: "2026-10-24T02:00:00Z",
+		},
+		"SpansMidnight": {
+			cron: "0 23 * * 5", duration: 3 * time.Hour,
+			now: "2026-10-17T01:00:00Z", open: true, next: "2026-10-23T23:00:00Z",
+		},
+		"SundayAsSeven": {
+			cron: "30 1 * * 7", duration: time.Hour,
+			now: "2026-10-17T12:00:00Z", open: false, next: "2026-10-18T01:30:00Z",
+		},
+		"StepsAndLists": {
+			cron: "0 */6 1,15 * *", duration: time.Hour,
+			now: "2026-10-15T06:30:00Z", open: true, next: "2026-10-15T12:00:00Z",
+		},
+		"DayOfMonthOrWeek": {
+			cron: "0 0 1 * 1", duration: time.Hour,
+			now: "2026-10-17T00:00:00Z", open: false, next: "2026-10-19T00:00:00Z",
+		},
+		"TimeZone": {
+			cron: "0 2 * * *", duration: time.Hour, timeZone: "Europe/London",
+			now: "2026-10-17T01:30:00Z", open: true, next: "2026-10-18T01:00:00Z",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w, err := New(tt.cron, tt.duration, tt.timeZone)
+			if err != nil {
+				t.Fatalf("New(): %v", err)
+			}
+			now := at(tt.now)
+			if got := w.Open(now); got != tt.open {
+				t.Errorf("Open(%s) = %v, want %v", tt.now, got, tt.open)
+			}
+			next, ok := w.Next(now)
+			if !ok || !next.Equal(at(tt.next)) {
+				t.Errorf("Next(%s) = %s, %v, want %s", tt.now, next, ok, tt.next)
+			}
+		})
+	}
+}
+
+func TestNeverOpens(t *testing.T) {
+	w, err := New("0 0 30 2 *", time.Hour, "")
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	if _, ok := w.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("Next() found an opening of a window on the 30th of February")
+	}
+	if deferred, msg := w.Defer(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); !deferred || msg == "" {
+		t.Errorf("Defer() = %v, %q, want deferred with a message", deferred, msg)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package probe checks the readiness probes of running containers.
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+)
+
+const (
+	errCreateExec  = "cannot create exec instance"
+	errStartExec   = "cannot start exec instance"
+	errInspectExec = "cannot inspect exec instance"
+
+	// defaultTimeout is how long a probe may take, when it does not say.
+	defaultTimeout = time.Second
+)
+
+// pollInterval is how often a running exec probe is checked for completion.
+var pollInterval = 100 * time.Millisecond
+
+// An ExecClient runs commands in containers.
+type ExecClient interface {
+	ContainerExecCreate(ctx context.Context, containerID string, options container.ExecOptions) (container.ExecCreateResponse, error)
+	ContainerExecStart(ctx context.Context, execID string, config container.ExecStartOptions) error
+	ContainerExecInspect(ctx context.Context, execID string) (container.ExecInspect, error)
+}
+
+// Check runs a readiness probe against a running container. It reports
+// whether the container is ready and, if not, why. A probe that fails is not
+// an error; one is returned only when the probe cannot be run at all.
+func Check(ctx context.Context, c ExecClient, info *container.InspectResponse, p *v1alpha1.ReadinessProbe) (bool, string, error) {
+	timeout := defaultTimeout
+	if p.Timeout != nil && p.Timeout.Duration > 0 {
+		timeout = p.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch {
+	case p.Exec != nil && p.HTTPGet == nil && p.TCPSocket == nil:
+		return checkExec(ctx, c, info.ID, p.Exec, timeout)
+	case p.HTTPGet != nil && p.Exec == nil && p.TCPSocket == nil:
+		return checkHTTPGet(ctx, info, p.HTTPGet)
+	case p.TCPSocket != nil && p.Exec == nil && p.HTTPGet == nil:
+		return checkTCPSocket(ctx, info, p.TCPSocket)
+	}
+	return false, "", errors.New("exactly one of exec, httpGet and tcpSocket must be set")
+}
+
+// checkExec runs a command in a container, which is ready if it exits zero
+// within the timeout.
+func checkExec(ctx context.Context, c ExecClient, containerID string, p *v1alpha1.ExecProbe, timeout time.Duration) (bool, string, error) {
+	exec, err := c.ContainerExecCreate(ctx, containerID, container.ExecOptions{Cmd: p.Command})
+	if err != nil {
+		return false, "", errors.Wrap(err, errCreateExec)
+	}
+	if err := c.ContainerExecStart(ctx, exec.ID, container.ExecStartOptions{Detach: true}); err != nil {
+		return false, "", errors.Wrap(err, errStartExec)
+	}
+	timedOut := fmt.Sprintf("Readiness command did not finish within %s", timeout)
+	for {
+		res, err := c.ContainerExecInspect(ctx, exec.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, timedOut, nil
+			}
+			return false, "", errors.Wrap(err, errInspectExec)
+		}
+		if !res.Running {
+			if res.ExitCode != 0 {
+				return false, fmt.Sprintf("Readiness command exited with code %d", res.ExitCode), nil
+			}
+			return true, "", nil
+		}
+		select {
+		case <-ctx.Done():
+			return false, timedOut, nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// checkHTTPGet sends a GET request to a container, which is ready if it
+// responds with a status from 200 to 399. Redirects are not followed.
+func checkHTTPGet(ctx context.Context, info *container.InspectResponse, p *v1alpha1.HTTPGetProbe) (bool, string, error) {
+	host, ok := probeHost(info, p.Host)
+	if !ok {
+		return false, "Container has no IP address to probe", nil
+	}
+	scheme := "http"
+	if p.Scheme == "HTTPS" {
+		scheme = "https"
+	}
+	path := p.Path
+	if path == "" || path[0] != '/' {
+		path = "/" + path
+	}
+	url := scheme + "://" + net.JoinHostPort(host, strconv.Itoa(int(p.Port))) + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, "", errors.Wrap(err, "cannot build readiness request")
+	}
+	client := &http.Client{
+		// Like Kubernetes, the certificate of the container is not
+		// verified, as it is rarely issued for its IP address
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}, //nolint:gosec // Probes do not verify certificates.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	defer client.CloseIdleConnections()
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Sprintf("Readiness request to %s failed: %v", url, err), nil
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return false, fmt.Sprintf("Readiness request to %s returned %s", url, resp.Status), nil
+	}
+	return true, "", nil
+}
+
+// checkTCPSocket opens a TCP connection to a container, which is ready if
+// the connection is accepted.
+func checkTCPSocket(ctx context.Context, info *container.InspectResponse, p *v1alpha1.TCPSocketProbe) (bool, string, error) {
+	host, ok := probeHost(info, p.Host)
+	if !ok {
+		return false, "Container has no IP address to probe", nil
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(int(p.Port)))
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return false, fmt.Sprintf("Readiness connection to %s failed: %v", addr, err), nil
+	}
+	_ = conn.Close()
+	return true, "", nil
+}
+
+// probeHost returns the host a container is probed at: the given host, if
+// any, or the IP address the container has on the first of its networks by
+// name.
+func probeHost(info *container.InspectResponse, host *string) (string, bool) {
+	if host != nil && *host != "" {
+		return *host, true
+	}
+	if info.NetworkSettings == nil {
+		return "", false
+	}
+	networks := info.NetworkSettings.Networks
+	for _, name := range slices.Sorted(maps.Keys(networks)) {
+		if ep := networks[name]; ep != nil && ep.IPAddress != "" {
+			return ep.IPAddress, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rossigee/provider-docker/apis/container/v1alpha1"
+)
+
+// fakeExec runs commands that exit with a code, after a number of polls.
+type fakeExec struct {
+	exitCode int
+	polls    int
+	cmd      []string
+}
+
+func (f *fakeExec) ContainerExecCreate(_ context.Context, _ string, options container.ExecOptions) (container.ExecCreateResponse, error) {
+	f.cmd = options.Cmd
+	return container.ExecCreateResponse{ID: "exec"}, nil
+}
+
+func (f *fakeExec) ContainerExecStart(context.Context, string, container.ExecStartOptions) error {
+	return nil
+}
+
+func (f *fakeExec) ContainerExecInspect(context.Context, string) (container.ExecInspect, error) {
+	if f.polls > 0 {
+		f.polls--
+		return container.ExecInspect{Running: true}, nil
+	}
+	return container.ExecInspect{ExitCode: f.exitCode}, nil
+}
+
+// hostPort splits the address of a test server into its host and port.
+func hostPort(t *testing.T, addr string) (string, int32) {
+	t.Helper()
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("cannot split %s: %v", addr, err)
+	}
+	p, _ := strconv.Atoi(port)
+	return host, int32(p)
+}
+
+func TestCheck(t *testing.T) {
+	pollInterval = time.Millisecond
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusNoContent)
+		case "/moved":
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	host, port := hostPort(t, u.Host)
+
+	// A port that nothing listens on, once its listener is closed
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %v", err)
+	}
+	_, closed := hostPort(t, l.Addr().String())
+	_ = l.Close()
+
+	// Without a host, containers are probed at their address on the
+	// first of their networks by name
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{ID: "abc123"},
+		NetworkSettings: &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+			"frontend": {IPAddress: host},
+			"backend":  {},
+		}},
+	}
+
+	tests := map[string]struct {
+		probe     v1alpha1.ReadinessProbe
+		exec      *fakeExec
+		wantReady bool
+		wantErr   bool
+	}{
+		"ExecPasses": {
+			probe:     v1alpha1.ReadinessProbe{Exec: &v1alpha1.ExecProbe{Command: []string{"pg_isready"}}},
+			exec:      &fakeExec{polls: 2},
+			wantReady: true,
+		},
+		"ExecFails": {
+			probe: v1alpha1.ReadinessProbe{Exec: &v1alpha1.ExecProbe{Command: []string{"pg_isready"}}},
+			exec:  &fakeExec{exitCode: 2},
+		},
+		"ExecTimesOut": {
+			probe: v1alpha1.ReadinessProbe{
+				Exec:    &v1alpha1.ExecProbe{Command: []string{"pg_isready"}},
+				Timeout: &metav1.Duration{Duration: 5 * time.Millisecond},
+			},
+			exec: &fakeExec{polls: 1000},
+		},
+		"HTTPGetPasses": {
+			probe:     v1alpha1.ReadinessProbe{HTTPGet: &v1alpha1.HTTPGetProbe{Path: "/healthz", Port: port}},
+			wantReady: true,
+		},
+		"HTTPGetRedirected": {
+			probe:     v1alpha1.ReadinessProbe{HTTPGet: &v1alpha1.HTTPGetProbe{Path: "/moved", Port: port}},
+			wantReady: true,
+		},
+		"HTTPGetUnavailable": {
+			probe: v1alpha1.ReadinessProbe{HTTPGet: &v1alpha1.HTTPGetProbe{Port: port}},
+		},
+		"TCPSocketAccepted": {
+			probe:     v1alpha1.ReadinessProbe{TCPSocket: &v1alpha1.TCPSocketProbe{Port: port}},
+			wantReady: true,
+		},
+		"TCPSocketRefused": {
+			probe: v1alpha1.ReadinessProbe{TCPSocket: &v1alpha1.TCPSocketProbe{Port: closed, Host: &host}},
+		},
+		"NoAction": {
+			probe:   v1alpha1.ReadinessProbe{},
+			wantErr: true,
+		},
+		"TwoActions": {
+			probe: v1alpha1.ReadinessProbe{
+				Exec:      &v1alpha1.ExecProbe{Command: []string{"true"}},
+				TCPSocket: &v1alpha1.TCPSocketProbe{Port: port},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			exec := tt.exec
+			if exec == nil {
+				exec = &fakeExec{}
+			}
+			ready, msg, err := Check(context.Background(), exec, info, &tt.probe)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ready != tt.wantReady {
+				t.Errorf("Check() ready = %v, want %v", ready, tt.wantReady)
+			}
+			if !ready && !tt.wantErr && msg == "" {
+				t.Error("Check() did not say why the container is not ready")
+			}
+		})
+	}
+}
+
+func TestCheckWithoutAddress(t *testing.T) {
+	info := &container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{ID: "abc123"},
+		NetworkSettings:   &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{"none": {}}},
+	}
+	p := &v1alpha1.ReadinessProbe{TCPSocket: &v1alpha1.TCPSocketProbe{Port: 5432}}
+	ready, msg, err := Check(context.Background(), &fakeExec{}, info, p)
+	if err != nil || ready || msg == "" {
+		t.Errorf("Check() = %v, %q, %v, want not ready with a reason", ready, msg, err)
+	}
+}
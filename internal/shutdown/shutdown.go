@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shutdown lets the provider finish in-flight Docker operations
+// before it exits, rather than cancelling them part way through.
+package shutdown
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrDraining is returned by Begin once the provider has started shutting
+// down. The operation is retried by whichever replica takes over.
+var ErrDraining = errors.New("provider is shutting down")
+
+// A Drainer tracks in-flight external operations so that shutdown can wait
+// for them.
+type Drainer struct {
+	mu       sync.Mutex
+	inFlight int
+	draining bool
+	idle     chan struct{}
+}
+
+// Begin records the start of an operation. The returned function must be
+// called once it completes. Begin returns ErrDraining, and no function, once
+// Drain has been called.
+func (d *Drainer) Begin() (func(), error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.draining {
+		return nil, ErrDraining
+	}
+	d.inFlight++
+
+	var once sync.Once
+	return func() { once.Do(d.end) }, nil
+}
+
+func (d *Drainer) end() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.inFlight--
+	if d.inFlight == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// Drain stops new operations from beginning and waits until those in flight
+// have completed, or ctx is done. It returns the number of operations still
+// in flight.
+func (d *Drainer) Drain(ctx context.Context) int {
+	d.mu.Lock()
+	d.draining = true
+	if d.inFlight == 0 {
+		d.mu.Unlock()
+		return 0
+	}
+	if d.idle == nil {
+		d.idle = make(chan struct{})
+	}
+	idle := d.idle
+	d.mu.Unlock()
+
+	select {
+	case <-idle:
+	case <-ctx.Done():
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.inFlight
+}
+
+var drainer = &Drainer{}
+
+// Begin records the start of an operation with the provider's Drainer.
+func Begin() (func(), error) {
+	return drainer.Begin()
+}
+
+// Drain drains the provider's Drainer.
+func Drain(ctx context.Context) int {
+	return drainer.Drain(ctx)
+}
+
+// Rollback returns a context for undoing a partially completed operation
+// after ctx was cancelled. It keeps ctx's values, such as the trace, but is
+// bounded by timeout rather than by ctx.
+func Rollback(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), timeout)
+}
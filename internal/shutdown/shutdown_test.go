@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shutdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestDrainer(t *testing.T) {
+	d := &Drainer{}
+
+	done, err := d.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	drained := make(chan int)
+	go func() { drained <- d.Drain(context.Background()) }()
+
+	// Wait for Drain to start refusing new operations.
+	for {
+		d.mu.Lock()
+		draining := d.draining
+		d.mu.Unlock()
+		if draining {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := d.Begin(); !errors.Is(err, ErrDraining) {
+		t.Errorf("Begin() while draining error = %v, want %v", err, ErrDraining)
+	}
+
+	select {
+	case <-drained:
+		t.Fatal("Drain() returned with an operation in flight")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	done()
+	done()
+	if n := <-drained; n != 0 {
+		t.Errorf("Drain() = %d, want 0", n)
+	}
+}
+
+func TestDrainerTimeout(t *testing.T) {
+	d := &Drainer{}
+
+	if _, err := d.Begin(); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if n := d.Drain(ctx); n != 1 {
+		t.Errorf("Drain() = %d, want 1", n)
+	}
+}
+
+func TestDrainerIdle(t *testing.T) {
+	d := &Drainer{}
+
+	done, err := d.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	done()
+
+	if n := d.Drain(context.Background()); n != 0 {
+		t.Errorf("Drain() = %d, want 0", n)
+	}
+}
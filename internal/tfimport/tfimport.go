@@ -0,0 +1,457 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tfimport converts the Docker resources in a Terraform state, as
+// managed by the kreuzwerker/docker Terraform provider, to the managed
+// resources of this provider. Each resource is annotated with the external
+// name of the Docker object it was created for, so that the provider adopts
+// the object rather than creating a new one.
+package tfimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	networkv1alpha1 "github.com/rossigee/provider-docker/apis/network/v1alpha1"
+	volumev1alpha1 "github.com/rossigee/provider-docker/apis/volume/v1alpha1"
+)
+
+const (
+	errParseState       = "cannot parse Terraform state"
+	errStateVersion     = "unsupported Terraform state version %d; only version 4 is supported"
+	errParseAttributes  = "cannot parse attributes of %s"
+	terraformProvider   = "kreuzwerker/docker"
+	providerConfigKind  = "ProviderConfig"
+	defaultPortHostIP   = "0.0.0.0"
+	defaultPortProtocol = "tcp"
+)
+
+// Options configure how Terraform resources are converted.
+type Options struct {
+	// ProviderConfig is the name of the ProviderConfig the converted
+	// resources refer to. No reference is set when it is empty.
+	ProviderConfig string
+}
+
+// Managed is a converted managed resource.
+type Managed interface {
+	resource.Managed
+	resource.TypedProviderConfigReferencer
+}
+
+// Result holds the converted managed resources, and warnings about resources
+// or attributes that could not be converted.
+type Result struct {
+	Resources []Managed
+	Warnings  []string
+}
+
+// state is the subset of a version 4 Terraform state that is converted.
+type state struct {
+	Version   int             `json:"version"`
+	Resources []stateResource `json:"resources"`
+}
+
+type stateResource struct {
+	Mode      string          `json:"mode"`
+	Type      string          `json:"type"`
+	Name      string          `json:"name"`
+	Provider  string          `json:"provider"`
+	Instances []stateInstance `json:"instances"`
+}
+
+type stateInstance struct {
+	IndexKey   any             `json:"index_key"`
+	Attributes json.RawMessage `json:"attributes"`
+}
+
+type label struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+type containerAttributes struct {
+	Name          string   `json:"name"`
+	Image         string   `json:"image"`
+	Command       []string `json:"command"`
+	Entrypoint    []string `json:"entrypoint"`
+	Env           []string `json:"env"`
+	Hostname      string   `json:"hostname"`
+	User          string   `json:"user"`
+	WorkingDir    string   `json:"working_dir"`
+	Restart       string   `json:"restart"`
+	MaxRetryCount int      `json:"max_retry_count"`
+	Privileged    bool     `json:"privileged"`
+	Init          bool     `json:"init"`
+	Start         *bool    `json:"start"`
+	StopSignal    string   `json:"stop_signal"`
+	StopTimeout   int      `json:"stop_timeout"`
+	NetworkMode   string   `json:"network_mode"`
+	DNS           []string `json:"dns"`
+	DNSSearch     []string `json:"dns_search"`
+	DNSOpts       []string `json:"dns_opts"`
+	Labels        []label  `json:"labels"`
+	Hosts         []struct {
+		Host string `json:"host"`
+		IP   string `json:"ip"`
+	} `json:"host"`
+	Ports []struct {
+		Internal int32  `json:"internal"`
+		External int32  `json:"external"`
+		IP       string `json:"ip"`
+		Protocol string `json:"protocol"`
+	} `json:"ports"`
+	NetworksAdvanced []struct {
+		Name        string   `json:"name"`
+		Aliases     []string `json:"aliases"`
+		IPv4Address string   `json:"ipv4_address"`
+		IPv6Address string   `json:"ipv6_address"`
+	} `json:"networks_advanced"`
+	Volumes []struct {
+		ContainerPath string `json:"container_path"`
+		HostPath      string `json:"host_path"`
+		VolumeName    string `json:"volume_name"`
+		ReadOnly      bool   `json:"read_only"`
+		FromContainer string `json:"from_container"`
+	} `json:"volumes"`
+	Mounts []struct {
+		Target   string `json:"target"`
+		Source   string `json:"source"`
+		Type     string `json:"type"`
+		ReadOnly bool   `json:"read_only"`
+	} `json:"mounts"`
+}
+
+type networkAttributes struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Driver      string            `json:"driver"`
+	Internal    bool              `json:"internal"`
+	Attachable  bool              `json:"attachable"`
+	Ingress     bool              `json:"ingress"`
+	IPv6        bool              `json:"ipv6"`
+	Options     map[string]string `json:"options"`
+	IPAMDriver  string            `json:"ipam_driver"`
+	IPAMOptions map[string]string `json:"ipam_options"`
+	IPAMConfig  []struct {
+		Subnet     string            `json:"subnet"`
+		IPRange    string            `json:"ip_range"`
+		Gateway    string            `json:"gateway"`
+		AuxAddress map[string]string `json:"aux_address"`
+	} `json:"ipam_config"`
+	Labels []label `json:"labels"`
+}
+
+type volumeAttributes struct {
+	Name       string            `json:"name"`
+	Driver     string            `json:"driver"`
+	DriverOpts map[string]string `json:"driver_opts"`
+	Labels     []label           `json:"labels"`
+}
+
+// Convert converts the docker_container, docker_network and docker_volume
+// resources of a Terraform state. Resources of other Terraform providers are
+// ignored; other resources of the Docker Terraform provider are skipped with
+// a warning.
+func Convert(data []byte, o Options) (*Result, error) {
+	s := &state{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, errors.Wrap(err, errParseState)
+	}
+	if s.Version != 4 {
+		return nil, errors.Errorf(errStateVersion, s.Version)
+	}
+
+	r := &Result{}
+	for _, sr := range s.Resources {
+		if sr.Mode != "managed" || !strings.Contains(sr.Provider, terraformProvider) {
+			continue
+		}
+		for _, inst := range sr.Instances {
+			address := address(sr, inst)
+			var (
+				mg       Managed
+				warnings []string
+				err      error
+			)
+			switch sr.Type {
+			case "docker_container":
+				mg, warnings, err = convertContainer(inst.Attributes)
+			case "docker_network":
+				mg, err = convertNetwork(inst.Attributes)
+			case "docker_volume":
+				mg, err = convertVolume(inst.Attributes)
+			default:
+				r.Warnings = append(r.Warnings, fmt.Sprintf("%s: skipped, %s is not managed by this provider", address, sr.Type))
+				continue
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, errParseAttributes, address)
+			}
+			for _, w := range warnings {
+				r.Warnings = append(r.Warnings, fmt.Sprintf("%s: %s", address, w))
+			}
+
+			mg.SetName(objectName(sr.Name, inst.IndexKey))
+			if o.ProviderConfig != "" {
+				mg.SetProviderConfigReference(&xpv1.ProviderConfigReference{Kind: providerConfigKind, Name: o.ProviderConfig})
+			}
+			r.Resources = append(r.Resources, mg)
+		}
+	}
+	return r, nil
+}
+
+func convertContainer(raw json.RawMessage) (Managed, []string, error) {
+	a := &containerAttributes{}
+	if err := json.Unmarshal(raw, a); err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []string
+	p := containerv1alpha1.ContainerParameters{
+		Image:      a.Image,
+		Name:       optional(a.Name),
+		Command:    a.Command,
+		Hostname:   optional(a.Hostname),
+		User:       optional(a.User),
+		WorkingDir: optional(a.WorkingDir),
+		DNS:        a.DNS,
+		DNSSearch:  a.DNSSearch,
+		DNSOptions: a.DNSOpts,
+		Labels:     labels(a.Labels),
+		StopSignal: optional(a.StopSignal),
+	}
+	if strings.HasPrefix(a.Image, "sha256:") {
+		warnings = append(warnings, "image is an image ID; replace it with the image reference")
+	}
+	if len(a.Entrypoint) > 0 {
+		warnings = append(warnings, "entrypoint is not supported and was dropped")
+	}
+	for _, env := range a.Env {
+		name, value, _ := strings.Cut(env, "=")
+		p.Environment = append(p.Environment, containerv1alpha1.EnvVar{Name: name, Value: &value})
+	}
+	if a.Restart != "" {
+		p.RestartPolicy = &a.Restart
+		if a.Restart == "on-failure" && a.MaxRetryCount > 0 {
+			p.MaximumRetryCount = &a.MaxRetryCount
+		}
+	}
+	if a.Privileged {
+		p.Privileged = &a.Privileged
+	}
+	if a.Init {
+		p.Init = &a.Init
+	}
+	if a.Start != nil && !*a.Start {
+		p.StartOnCreate = a.Start
+	}
+	if a.StopTimeout > 0 {
+		p.StopGracePeriod = &metav1.Duration{Duration: time.Duration(a.StopTimeout) * time.Second}
+	}
+	if a.NetworkMode != "" {
+		p.NetworkMode = &a.NetworkMode
+	}
+	for _, h := range a.Hosts {
+		p.ExtraHosts = append(p.ExtraHosts, h.Host+":"+h.IP)
+	}
+	for _, port := range a.Ports {
+		spec := containerv1alpha1.PortSpec{ContainerPort: port.Internal}
+		if port.External != 0 {
+			spec.HostPort = &port.External
+		}
+		if port.IP != "" && port.IP != defaultPortHostIP {
+			spec.HostIP = &port.IP
+		}
+		if port.Protocol != "" && port.Protocol != defaultPortProtocol {
+			spec.Protocol = optional(strings.ToUpper(port.Protocol))
+		}
+		p.Ports = append(p.Ports, spec)
+	}
+	for _, n := range a.NetworksAdvanced {
+		p.Networks = append(p.Networks, containerv1alpha1.NetworkAttachment{
+			Name:        n.Name,
+			Aliases:     n.Aliases,
+			IPAddress:   optional(n.IPv4Address),
+			IPv6Address: optional(n.IPv6Address),
+		})
+	}
+	for i, v := range a.Volumes {
+		vm := containerv1alpha1.VolumeMount{
+			Name:      fmt.Sprintf("volume-%d", i),
+			MountPath: v.ContainerPath,
+		}
+		switch {
+		case v.VolumeName != "":
+			vm.Name = v.VolumeName
+			vm.VolumeSource.Volume = &containerv1alpha1.VolumeVolumeSource{VolumeName: v.VolumeName}
+		case v.HostPath != "":
+			vm.VolumeSource.HostPath = &containerv1alpha1.HostPathVolumeSource{Path: v.HostPath}
+		default:
+			warnings = append(warnings, fmt.Sprintf("volume at %s is not supported and was dropped", v.ContainerPath))
+			continue
+		}
+		if v.ReadOnly {
+			vm.ReadOnly = &v.ReadOnly
+		}
+		p.Volumes = append(p.Volumes, vm)
+	}
+	for i, m := range a.Mounts {
+		vm := containerv1alpha1.VolumeMount{
+			Name:      fmt.Sprintf("mount-%d", i),
+			MountPath: m.Target,
+		}
+		switch m.Type {
+		case "volume":
+			vm.Name = m.Source
+			vm.VolumeSource.Volume = &containerv1alpha1.VolumeVolumeSource{VolumeName: m.Source}
+		case "bind":
+			vm.VolumeSource.Bind = &containerv1alpha1.BindVolumeSource{SourcePath: m.Source}
+		default:
+			warnings = append(warnings, fmt.Sprintf("%s mount at %s is not supported and was dropped", m.Type, m.Target))
+			continue
+		}
+		if m.ReadOnly {
+			vm.ReadOnly = &m.ReadOnly
+		}
+		p.Volumes = append(p.Volumes, vm)
+	}
+
+	cr := &containerv1alpha1.Container{}
+	cr.SetGroupVersionKind(containerv1alpha1.ContainerGroupVersionKind)
+	cr.Spec.ForProvider = p
+	meta.SetExternalName(cr, a.Name)
+	return cr, warnings, nil
+}
+
+func convertNetwork(raw json.RawMessage) (Managed, error) {
+	a := &networkAttributes{}
+	if err := json.Unmarshal(raw, a); err != nil {
+		return nil, err
+	}
+
+	p := networkv1alpha1.NetworkParameters{
+		Name:    optional(a.Name),
+		Driver:  optional(a.Driver),
+		Options: a.Options,
+		Labels:  labels(a.Labels),
+	}
+	if a.Internal {
+		p.Internal = &a.Internal
+	}
+	if a.Attachable {
+		p.Attachable = &a.Attachable
+	}
+	if a.Ingress {
+		p.Ingress = &a.Ingress
+	}
+	if a.IPv6 {
+		p.EnableIPv6 = &a.IPv6
+	}
+	if a.IPAMDriver != "" || len(a.IPAMConfig) > 0 || len(a.IPAMOptions) > 0 {
+		p.IPAM = &networkv1alpha1.IPAMConfig{
+			Driver:  optional(a.IPAMDriver),
+			Options: a.IPAMOptions,
+		}
+		for _, c := range a.IPAMConfig {
+			p.IPAM.Config = append(p.IPAM.Config, networkv1alpha1.IPAMConfigEntry{
+				Subnet:       optional(c.Subnet),
+				IPRange:      optional(c.IPRange),
+				Gateway:      optional(c.Gateway),
+				AuxAddresses: c.AuxAddress,
+			})
+		}
+	}
+
+	cr := &networkv1alpha1.Network{}
+	cr.SetGroupVersionKind(networkv1alpha1.NetworkGroupVersionKind)
+	cr.Spec.ForProvider = p
+	// Networks are identified by ID
+	meta.SetExternalName(cr, a.ID)
+	return cr, nil
+}
+
+func convertVolume(raw json.RawMessage) (Managed, error) {
+	a := &volumeAttributes{}
+	if err := json.Unmarshal(raw, a); err != nil {
+		return nil, err
+	}
+
+	cr := &volumev1alpha1.Volume{}
+	cr.SetGroupVersionKind(volumev1alpha1.VolumeGroupVersionKind)
+	cr.Spec.ForProvider = volumev1alpha1.VolumeParameters{
+		Name:       optional(a.Name),
+		Driver:     optional(a.Driver),
+		DriverOpts: a.DriverOpts,
+		Labels:     labels(a.Labels),
+	}
+	meta.SetExternalName(cr, a.Name)
+	return cr, nil
+}
+
+// address returns the Terraform address of a resource instance.
+func address(sr stateResource, inst stateInstance) string {
+	switch k := inst.IndexKey.(type) {
+	case float64:
+		return fmt.Sprintf("%s.%s[%d]", sr.Type, sr.Name, int(k))
+	case string:
+		return fmt.Sprintf("%s.%s[%q]", sr.Type, sr.Name, k)
+	}
+	return sr.Type + "." + sr.Name
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// objectName returns a Kubernetes object name for a resource instance, from
+// its Terraform name and its count or for_each key.
+func objectName(name string, key any) string {
+	switch k := key.(type) {
+	case float64:
+		name = fmt.Sprintf("%s-%d", name, int(k))
+	case string:
+		name = name + "-" + k
+	}
+	return strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+}
+
+func labels(l []label) map[string]string {
+	if len(l) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(l))
+	for _, kv := range l {
+		m[kv.Label] = kv.Value
+	}
+	return m
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tfimport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/google/go-cmp/cmp"
+
+	containerv1alpha1 "github.com/rossigee/provider-docker/apis/container/v1alpha1"
+	networkv1alpha1 "github.com/rossigee/provider-docker/apis/network/v1alpha1"
+	volumev1alpha1 "github.com/rossigee/provider-docker/apis/volume/v1alpha1"
+)
+
+const testState = `{
+  "version": 4,
+  "terraform_version": "1.9.0",
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "docker_network",
+      "name": "backend",
+      "provider": "provider[\"registry.terraform.io/kreuzwerker/docker\"]",
+      "instances": [
+        {
+          "attributes": {
+            "id": "4f2c9a1d",
+            "name": "backend",
+            "driver": "bridge",
+            "internal": true,
+            "ipam_driver": "default",
+            "ipam_config": [{"subnet": "10.10.0.0/24", "gateway": "10.10.0.1", "ip_range": "", "aux_address": {}}],
+            "labels": [{"label": "team", "value": "payments"}]
+          }
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "docker_volume",
+      "name": "db_data",
+      "provider": "provider[\"registry.terraform.io/kreuzwerker/docker\"]",
+      "instances": [
+        {"attributes": {"id": "db-data", "name": "db-data", "driver": "local", "driver_opts": {}, "labels": []}}
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "docker_container",
+      "name": "api",
+      "provider": "provider[\"registry.terraform.io/kreuzwerker/docker\"]",
+      "instances": [
+        {
+          "index_key": 0,
+          "attributes": {
+            "id": "8d3e0c",
+            "name": "api-0",
+            "image": "sha256:0a1b2c",
+            "entrypoint": ["/docker-entrypoint.sh"],
+            "env": ["MODE=production", "EMPTY="],
+            "restart": "on-failure",
+            "max_retry_count": 3,
+            "start": true,
+            "stop_timeout": 30,
+            "network_mode": "bridge",
+            "ports": [{"internal": 8080, "external": 80, "ip": "0.0.0.0", "protocol": "tcp"}],
+            "networks_advanced": [{"name": "backend", "aliases": ["api"], "ipv4_address": "", "ipv6_address": ""}],
+            "volumes": [
+              {"container_path": "/data", "host_path": "", "volume_name": "db-data", "read_only": false, "from_container": ""},
+              {"container_path": "/shared", "host_path": "", "volume_name": "", "read_only": false, "from_container": "sidecar"}
+            ],
+            "labels": [{"label": "app", "value": "api"}]
+          }
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "docker_image",
+      "name": "api",
+      "provider": "provider[\"registry.terraform.io/kreuzwerker/docker\"]",
+      "instances": [{"attributes": {"name": "example/api:1.2"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "host",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"attributes": {}}]
+    }
+  ]
+}`
+
+func TestConvert(t *testing.T) {
+	result, err := Convert([]byte(testState), Options{ProviderConfig: "docker-host"})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if len(result.Resources) != 3 {
+		t.Fatalf("Convert() returned %d resources, want 3", len(result.Resources))
+	}
+
+	for _, mg := range result.Resources {
+		if ref := mg.GetProviderConfigReference(); ref == nil || ref.Name != "docker-host" || ref.Kind != "ProviderConfig" {
+			t.Errorf("%s provider config reference = %v, want ProviderConfig docker-host", mg.GetName(), ref)
+		}
+	}
+
+	network, ok := result.Resources[0].(*networkv1alpha1.Network)
+	if !ok {
+		t.Fatalf("Convert() resource 0 is a %T, want a Network", result.Resources[0])
+	}
+	if network.GetName() != "backend" || meta.GetExternalName(network) != "4f2c9a1d" {
+		t.Errorf("Network name = %s, external name = %s, want backend and 4f2c9a1d", network.GetName(), meta.GetExternalName(network))
+	}
+	if network.Spec.ForProvider.IPAM == nil || len(network.Spec.ForProvider.IPAM.Config) != 1 || *network.Spec.ForProvider.IPAM.Config[0].Subnet != "10.10.0.0/24" {
+		t.Errorf("Network IPAM = %+v, want subnet 10.10.0.0/24", network.Spec.ForProvider.IPAM)
+	}
+
+	volume, ok := result.Resources[1].(*volumev1alpha1.Volume)
+	if !ok {
+		t.Fatalf("Convert() resource 1 is a %T, want a Volume", result.Resources[1])
+	}
+	if volume.GetName() != "db-data" || meta.GetExternalName(volume) != "db-data" {
+		t.Errorf("Volume name = %s, external name = %s, want db-data and db-data", volume.GetName(), meta.GetExternalName(volume))
+	}
+
+	cr, ok := result.Resources[2].(*containerv1alpha1.Container)
+	if !ok {
+		t.Fatalf("Convert() resource 2 is a %T, want a Container", result.Resources[2])
+	}
+	if cr.GetName() != "api-0" || meta.GetExternalName(cr) != "api-0" {
+		t.Errorf("Container name = %s, external name = %s, want api-0 and api-0", cr.GetName(), meta.GetExternalName(cr))
+	}
+	p := cr.Spec.ForProvider
+	if len(p.Environment) != 2 || p.Environment[0].Name != "MODE" || *p.Environment[0].Value != "production" || *p.Environment[1].Value != "" {
+		t.Errorf("Container environment = %+v, want MODE=production and EMPTY=", p.Environment)
+	}
+	if p.MaximumRetryCount == nil || *p.MaximumRetryCount != 3 {
+		t.Errorf("Container maximum retry count = %v, want 3", p.MaximumRetryCount)
+	}
+	if p.StartOnCreate != nil {
+		t.Errorf("Container start on create = %v, want unset", *p.StartOnCreate)
+	}
+	if p.StopGracePeriod == nil || p.StopGracePeriod.Duration != 30*time.Second {
+		t.Errorf("Container stop grace period = %v, want 30s", p.StopGracePeriod)
+	}
+	if len(p.Ports) != 1 || p.Ports[0].HostIP != nil || p.Ports[0].Protocol != nil || *p.Ports[0].HostPort != 80 {
+		t.Errorf("Container ports = %+v, want 8080 published on 80", p.Ports)
+	}
+	if len(p.Volumes) != 1 || p.Volumes[0].VolumeSource.Volume == nil || p.Volumes[0].VolumeSource.Volume.VolumeName != "db-data" {
+		t.Errorf("Container volumes = %+v, want the db-data volume", p.Volumes)
+	}
+
+	var warnings []string
+	for _, w := range result.Warnings {
+		warnings = append(warnings, strings.SplitN(w, " ", 2)[0])
+	}
+	want := []string{
+		"docker_container.api[0]:",
+		"docker_container.api[0]:",
+		"docker_container.api[0]:",
+		"docker_image.api:",
+	}
+	if diff := cmp.Diff(want, warnings); diff != "" {
+		t.Errorf("Convert() warnings mismatch (-want +got):\n%s\n%v", diff, result.Warnings)
+	}
+}
+
+func TestConvertUnsupportedVersion(t *testing.T) {
+	if _, err := Convert([]byte(`{"version": 3, "modules": []}`), Options{}); err == nil {
+		t.Error("Convert() error = nil, want an error for a version 3 state")
+	}
+}
+
+func TestObjectName(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		key  any
+		want string
+	}{
+		"Plain":      {name: "web", want: "web"},
+		"Count":      {name: "web", key: float64(2), want: "web-2"},
+		"ForEach":    {name: "web", key: "Blue_Green", want: "web-blue-green"},
+		"Invalid":    {name: "My_App", want: "my-app"},
+		"TrimDashes": {name: "_app_", want: "app"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := objectName(tt.name, tt.key); got != tt.want {
+				t.Errorf("objectName(%q, %v) = %q, want %q", tt.name, tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
@@ -11,8 +12,6 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
-	"os"
-	"strconv"
 )
 
 const (
@@ -22,28 +21,40 @@ const (
 	operationAttr    = "crossplane.operation"
 )
 
-var tracer trace.Tracer
+var tracer = otel.Tracer(tracerName)
 var tp *sdktrace.TracerProvider
 
-func Init(serviceName string) func(context.Context) {
+// Config configures span export.
+type Config struct {
+	// Enabled turns on span export. Spans are still created, but dropped,
+	// when disabled.
+	Enabled bool
+
+	// Endpoint is the OTLP gRPC collector endpoint.
+	Endpoint string
+
+	// Insecure disables TLS for the connection to the collector.
+	Insecure bool
+
+	// SamplingRatio is the fraction of traces to sample, between 0 and 1.
+	SamplingRatio float64
+
+	// ServiceName is reported as the service.name resource attribute.
+	ServiceName string
+}
+
+func Init(cfg Config) func(context.Context) {
 	tracer = otel.Tracer(tracerName)
 
-	enabled, _ := strconv.ParseBool(getEnv("OTEL_TRACING_ENABLED", "false"))
-	if !enabled {
+	if !cfg.Enabled {
 		return func(context.Context) {}
 	}
 
-	endpoint := getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
-	samplingRatio := 0.1
-	if v, err := strconv.ParseFloat(getEnv("OTEL_SAMPLING_RATIO", "0.1"), 64); err == nil {
-		samplingRatio = v
-	}
-
 	ctx := context.Background()
 
 	res, err := resource.New(ctx,
 		resource.WithAttributes(
-			semconv.ServiceNameKey.String(getEnv("OTEL_SERVICE_NAME", serviceName)),
+			semconv.ServiceNameKey.String(cfg.ServiceName),
 			attribute.String("provider.type", "crossplane"),
 		),
 	)
@@ -51,12 +62,12 @@ func Init(serviceName string) func(context.Context) {
 		return func(context.Context) {}
 	}
 
-	exporter, err := otlptrace.New(ctx,
-		otlptracegrpc.NewClient(
-			otlptracegrpc.WithEndpoint(endpoint),
-			otlptracegrpc.WithInsecure(),
-		),
-	)
+	clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptrace.New(ctx, otlptracegrpc.NewClient(clientOpts...))
 	if err != nil {
 		return func(context.Context) {}
 	}
@@ -64,7 +75,7 @@ func Init(serviceName string) func(context.Context) {
 	tp = sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter),
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(samplingRatio)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplingRatio))),
 	)
 
 	otel.SetTracerProvider(tp)
@@ -104,9 +115,12 @@ func SpanAttrs(resourceType, resourceName, operation string) []attribute.KeyValu
 	}
 }
 
-func getEnv(key, def string) string {
-	if v := os.Getenv(key); v != "" {
-		return v
+// RecordError marks the span as failed if err is non-nil, and returns err
+// unchanged so it can be used inline in return statements.
+func RecordError(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
-	return def
+	return err
 }
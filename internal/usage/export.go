@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+)
+
+// defaultJob is the job usage is pushed as when a Pushgateway does not say.
+const defaultJob = "provider-docker"
+
+// invalidLabelChars matches the characters of a Kubernetes label key that
+// cannot appear in a Prometheus label name.
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// Key names the resource and service a report is for, as the key of its
+// entry in a ConfigMap and the instance it is pushed as.
+func (r Report) Key() string {
+	parts := []string{strings.ToLower(r.Kind)}
+	if r.Namespace != "" {
+		parts = append(parts, r.Namespace)
+	}
+	parts = append(parts, r.Name)
+	if r.Service != "" {
+		parts = append(parts, r.Service)
+	}
+	return strings.Join(parts, ".")
+}
+
+// writeConfigMap writes a report to its entry in a ConfigMap, creating the
+// ConfigMap if it does not exist.
+func (e *Exporter) writeConfigMap(ctx context.Context, ref *v1beta1.UsageConfigMapReference, report Report) error {
+	value, err := json.Marshal(report)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode usage")
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm)
+		if kerrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ref.Namespace, Name: ref.Name},
+				Data:       map[string]string{report.Key(): string(value)},
+			}
+			return errors.Wrap(e.kube.Create(ctx, cm), "cannot create usage ConfigMap")
+		}
+		if err != nil {
+			return errors.Wrap(err, "cannot get usage ConfigMap")
+		}
+		if cm.Data == nil {
+			cm.Data = make(map[string]string, 1)
+		}
+		cm.Data[report.Key()] = string(value)
+		return e.kube.Update(ctx, cm)
+	})
+}
+
+// push pushes a report to a Pushgateway, replacing the metrics last pushed
+// for the same resource and service.
+func (e *Exporter) push(ctx context.Context, gw *v1beta1.Pushgateway, report Report) error {
+	labels := prometheus.Labels{
+		"kind":         report.Kind,
+		"namespace":    report.Namespace,
+		"name":         report.Name,
+		"service":      report.Service,
+		"container_id": report.ContainerID,
+	}
+	for k, v := range report.Labels {
+		labels["label_"+invalidLabelChars.ReplaceAllString(k, "_")] = v
+	}
+
+	cpu := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "provider_docker_container_cpu_seconds_total",
+		Help:        "CPU time used by the container, in seconds.",
+		ConstLabels: labels,
+	})
+	cpu.Add(report.CPUSeconds)
+	memory := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "provider_docker_container_memory_byte_hours_total",
+		Help:        "Memory used by the container, integrated over time, in byte-hours.",
+		ConstLabels: labels,
+	})
+	memory.Add(report.MemoryByteHours)
+
+	job := gw.Job
+	if job == "" {
+		job = defaultJob
+	}
+	err := push.New(gw.URL, job).
+		Client(e.client).
+		Grouping("instance", report.Key()).
+		Collector(cpu).
+		Collector(memory).
+		PushContext(ctx)
+	return errors.Wrap(err, "cannot push usage")
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package usage meters the CPU and memory used by containers and exports it
+// for chargeback.
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+)
+
+const (
+	// defaultInterval is how often usage is sampled when the UsageExport
+	// of a ProviderConfig does not say.
+	defaultInterval = 5 * time.Minute
+
+	// exportTimeout bounds each export, which is made during a reconcile.
+	exportTimeout = 5 * time.Second
+
+	// staleAfter is how long the meter of a container that is no longer
+	// sampled, such as one that was replaced, is kept.
+	staleAfter = 24 * time.Hour
+)
+
+// A StatsClient reads the resource usage of containers.
+type StatsClient interface {
+	ContainerStatsOneShot(ctx context.Context, containerID string) (container.StatsResponseReader, error)
+}
+
+// A Resource identifies the managed resource a container belongs to.
+type Resource struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// Service is the service of a stack the container runs.
+	Service string `json:"service,omitempty"`
+
+	// Labels of the managed resource, by which usage is attributed.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// A Report is the usage of a container, as it is exported. The totals count
+// from when the provider started metering the container, so consumers should
+// treat them as counters that may reset.
+type Report struct {
+	Resource
+	ContainerID     string    `json:"containerID"`
+	CPUSeconds      float64   `json:"cpuSeconds"`
+	MemoryByteHours float64   `json:"memoryByteHours"`
+	Time            time.Time `json:"time"`
+}
+
+// A sample is the usage of a container when it was last sampled.
+type sample struct {
+	cpu    uint64
+	memory uint64
+	time   time.Time
+}
+
+// A meter accumulates the usage of a container across samples.
+type meter struct {
+	last            sample
+	cpuSeconds      float64
+	memoryByteHours float64
+}
+
+// add accumulates the usage between the last sample and s. The CPU time of
+// a container restarts from zero when the container does.
+func (m *meter) add(s sample) {
+	cpu := s.cpu
+	if cpu >= m.last.cpu {
+		cpu -= m.last.cpu
+	}
+	m.cpuSeconds += float64(cpu) / float64(time.Second)
+
+	// Memory is integrated over the interval as the mean of its ends
+	hours := s.time.Sub(m.last.time).Hours()
+	m.memoryByteHours += float64(m.last.memory+s.memory) / 2 * hours
+	m.last = s
+}
+
+// An Exporter samples the usage of containers and exports it to the
+// ConfigMaps and Pushgateways of their ProviderConfigs.
+type Exporter struct {
+	kube   client.Client
+	client *http.Client
+	logger logging.Logger
+	now    func() time.Time
+
+	mu     sync.Mutex
+	meters map[string]*meter
+}
+
+// NewExporter returns an Exporter that writes ConfigMaps with kube and logs
+// failed exports to logger.
+func NewExporter(kube client.Client, logger logging.Logger) *Exporter {
+	return &Exporter{
+		kube:   kube,
+		client: &http.Client{Timeout: exportTimeout},
+		logger: logger,
+		now:    time.Now,
+		meters: make(map[string]*meter),
+	}
+}
+
+// Export samples the usage of a container, if the interval of cfg has
+// passed since it was last sampled, and exports it. It is best effort:
+// failures are logged rather than returned, so that an export that fails
+// does not hold up reconciling the container.
+func (e *Exporter) Export(ctx context.Context, cfg *v1beta1.UsageExport, sc StatsClient, containerID string, r Resource) {
+	if e == nil || cfg == nil || containerID == "" {
+		return
+	}
+	report, due, err := e.sample(ctx, cfg, sc, containerID, r)
+	if err != nil {
+		e.logger.Debug("Cannot sample container usage", "container", containerID, "error", err)
+		return
+	}
+	if !due {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+	if cfg.ConfigMapRef != nil {
+		if err := e.writeConfigMap(ctx, cfg.ConfigMapRef, report); err != nil {
+			e.logger.Info("Cannot write container usage to ConfigMap",
+				"configMap", cfg.ConfigMapRef.Namespace+"/"+cfg.ConfigMapRef.Name, "resource", r.Name, "error", err)
+		}
+	}
+	if cfg.Pushgateway != nil {
+		if err := e.push(ctx, cfg.Pushgateway, report); err != nil {
+			e.logger.Info("Cannot push container usage to Pushgateway",
+				"url", cfg.Pushgateway.URL, "resource", r.Name, "error", err)
+		}
+	}
+}
+
+// Forget drops the usage of a container that has been removed.
+func (e *Exporter) Forget(containerID string) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.meters, containerID)
+}
+
+// sample reads the usage of a container and adds it to its meter, unless
+// the container was sampled less than the interval of cfg ago.
+func (e *Exporter) sample(ctx context.Context, cfg *v1beta1.UsageExport, sc StatsClient, containerID string, r Resource) (Report, bool, error) {
+	now := e.now()
+	interval := defaultInterval
+	if cfg.Interval != nil && cfg.Interval.Duration > 0 {
+		interval = cfg.Interval.Duration
+	}
+
+	e.mu.Lock()
+	m, ok := e.meters[containerID]
+	recent := ok && now.Sub(m.last.time) < interval
+	e.mu.Unlock()
+	if recent {
+		return Report{}, false, nil
+	}
+
+	s, err := readStats(ctx, sc, containerID)
+	if err != nil {
+		return Report{}, false, err
+	}
+	s.time = now
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if m, ok = e.meters[containerID]; ok {
+		m.add(s)
+	} else {
+		// The CPU time a container used before it was first sampled is
+		// counted, but its memory cannot be
+		m = &meter{last: s, cpuSeconds: float64(s.cpu) / float64(time.Second)}
+		e.meters[containerID] = m
+		for id, other := range e.meters {
+			if now.Sub(other.last.time) > staleAfter {
+				delete(e.meters, id)
+			}
+		}
+	}
+	return Report{
+		Resource:        r,
+		ContainerID:     containerID,
+		CPUSeconds:      m.cpuSeconds,
+		MemoryByteHours: m.memoryByteHours,
+		Time:            now,
+	}, true, nil
+}
+
+// readStats reads the CPU time a container has used and the memory it is
+// using. Like docker stats, the memory excludes the inactive page cache,
+// which the kernel reclaims under pressure.
+func readStats(ctx context.Context, sc StatsClient, containerID string) (sample, error) {
+	resp, err := sc.ContainerStatsOneShot(ctx, containerID)
+	if err != nil {
+		return sample{}, errors.Wrap(err, "cannot read container stats")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var stats container.StatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return sample{}, errors.Wrap(err, "cannot decode container stats")
+	}
+	memory := stats.MemoryStats.Usage
+	for _, key := range []string{"inactive_file", "total_inactive_file"} {
+		if inactive, ok := stats.MemoryStats.Stats[key]; ok && inactive < memory {
+			memory -= inactive
+			break
+		}
+	}
+	return sample{cpu: stats.CPUStats.CPUUsage.TotalUsage, memory: memory}, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+)
+
+// fakeStats reports the CPU time and memory of a container, and counts how
+// often it was asked.
+type fakeStats struct {
+	cpu    time.Duration
+	memory uint64
+	calls  int
+}
+
+func (f *fakeStats) ContainerStatsOneShot(context.Context, string) (container.StatsResponseReader, error) {
+	f.calls++
+	body := fmt.Sprintf(`{"cpu_stats":{"cpu_usage":{"total_usage":%d}},"memory_stats":{"usage":%d,"stats":{"inactive_file":%d}}}`,
+		f.cpu, f.memory+1024, 1024)
+	return container.StatsResponseReader{Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestExport(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	kube := fake.NewClientBuilder().Build()
+	e := NewExporter(kube, logging.NewNopLogger())
+	e.now = func() time.Time { return now }
+
+	cfg := &v1beta1.UsageExport{
+		Interval:     &metav1.Duration{Duration: time.Hour},
+		ConfigMapRef: &v1beta1.UsageConfigMapReference{Namespace: "billing", Name: "docker-usage"},
+	}
+	res := Resource{Kind: "Container", Namespace: "shop", Name: "web", Labels: map[string]string{"team": "checkout"}}
+	stats := &fakeStats{cpu: 10 * time.Second, memory: 1 << 30}
+
+	// The first sample counts the CPU used so far; then 1GiB for an hour,
+	// then rising to 3GiB over the next hour, while using 90s of CPU
+	e.Export(context.Background(), cfg, stats, "abc", res)
+	now = now.Add(30 * time.Minute)
+	e.Export(context.Background(), cfg, stats, "abc", res)
+	now = now.Add(30 * time.Minute)
+	e.Export(context.Background(), cfg, stats, "abc", res)
+	stats.cpu, stats.memory = 100*time.Second, 3<<30
+	now = now.Add(time.Hour)
+	e.Export(context.Background(), cfg, stats, "abc", res)
+
+	if stats.calls != 3 {
+		t.Errorf("Export(...) read stats %d times, want 3: once per interval", stats.calls)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: "billing", Name: "docker-usage"}, cm); err != nil {
+		t.Fatalf("cannot get usage ConfigMap: %v", err)
+	}
+	var got Report
+	if err := json.Unmarshal([]byte(cm.Data["container.shop.web"]), &got); err != nil {
+		t.Fatalf("cannot decode usage %q: %v", cm.Data["container.shop.web"], err)
+	}
+	want := Report{
+		Resource:        res,
+		ContainerID:     "abc",
+		CPUSeconds:      100,
+		MemoryByteHours: 1<<30 + 2<<30,
+		Time:            start.Add(2 * time.Hour),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Export(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestMeterCountsRestarts(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := &meter{last: sample{cpu: uint64(50 * time.Second), time: start}}
+	m.add(sample{cpu: uint64(5 * time.Second), time: start.Add(time.Minute)})
+	if m.cpuSeconds != 5 {
+		t.Errorf("add(...) counted %vs of CPU after a restart, want 5s", m.cpuSeconds)
+	}
+}
+
+func TestExportPushgateway(t *testing.T) {
+	var path, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Pushgateway got %s, want PUT", r.Method)
+		}
+		b, _ := io.ReadAll(r.Body)
+		path, body = r.URL.Path, string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	e := NewExporter(fake.NewClientBuilder().Build(), logging.NewNopLogger())
+	cfg := &v1beta1.UsageExport{Pushgateway: &v1beta1.Pushgateway{URL: srv.URL}}
+	res := Resource{Kind: "ComposeStack", Namespace: "shop", Name: "stack", Service: "db", Labels: map[string]string{"cost-center": "42"}}
+	e.Export(context.Background(), cfg, &fakeStats{cpu: 3 * time.Second}, "def", res)
+
+	if want := "/metrics/job/provider-docker/instance/composestack.shop.stack.db"; path != want {
+		t.Errorf("Export(...) pushed to %s, want %s", path, want)
+	}
+	// Metrics are pushed in the protobuf format, which holds their names and
+	// label names as they are
+	for _, want := range []string{"provider_docker_container_cpu_seconds_total", "provider_docker_container_memory_byte_hours_total", "label_cost_center", "service"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Export(...) pushed %q, want it to contain %s", body, want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook notifies external systems of container lifecycle
+// transitions.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/pkg/errors"
+
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+)
+
+// deliveryTimeout bounds each delivery, which is made during a reconcile.
+const deliveryTimeout = 5 * time.Second
+
+// A Resource identifies the managed resource a transition happened to.
+type Resource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	UID        string `json:"uid"`
+}
+
+// A Transition is posted to webhooks as JSON.
+type Transition struct {
+	Resource      Resource  `json:"resource"`
+	Phase         string    `json:"phase"`
+	PreviousPhase string    `json:"previousPhase,omitempty"`
+	ContainerID   string    `json:"containerID,omitempty"`
+	Host          string    `json:"host,omitempty"`
+	Time          time.Time `json:"time"`
+}
+
+// A Notifier posts transitions to webhooks.
+type Notifier struct {
+	client *http.Client
+	logger logging.Logger
+}
+
+// NewNotifier returns a Notifier that logs failed deliveries to logger.
+func NewNotifier(logger logging.Logger) *Notifier {
+	return &Notifier{client: &http.Client{Timeout: deliveryTimeout}, logger: logger}
+}
+
+// Notify posts a transition to each webhook interested in its phase. It
+// is best effort: failures are logged rather than returned, so that a
+// webhook that is down does not hold up reconciling containers.
+func (n *Notifier) Notify(ctx context.Context, hooks []v1beta1.Webhook, t Transition) {
+	if n == nil || len(hooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(t)
+	if err != nil {
+		n.logger.Info("Cannot encode lifecycle transition", "error", err)
+		return
+	}
+
+	for _, h := range hooks {
+		if len(h.Phases) > 0 && !slices.Contains(h.Phases, t.Phase) {
+			continue
+		}
+		if err := n.post(ctx, h.URL, body); err != nil {
+			n.logger.Info("Cannot notify webhook of lifecycle transition",
+				"url", h.URL, "resource", t.Resource.Name, "phase", t.Phase, "error", err)
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot post transition")
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/rossigee/provider-docker/apis/v1beta1"
+)
+
+func TestNotify(t *testing.T) {
+	transition := Transition{
+		Resource:      Resource{APIVersion: "container.docker.crossplane.io/v1alpha1", Kind: "Container", Name: "web", UID: "1234"},
+		Phase:         "Running",
+		PreviousPhase: "Starting",
+		ContainerID:   "abc123",
+		Host:          "tcp://docker.example.com:2376",
+		Time:          time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var received []Transition
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("webhook got %s with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		var got Transition
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("cannot decode transition: %v", err)
+		}
+		received = append(received, got)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	hooks := []v1beta1.Webhook{
+		{URL: srv.URL + "/all"},
+		{URL: srv.URL + "/running", Phases: []string{"Running"}},
+		{URL: srv.URL + "/exited", Phases: []string{"Exited"}},
+		{URL: srv.URL + "/fail"},
+	}
+	NewNotifier(logging.NewNopLogger()).Notify(context.Background(), hooks, transition)
+
+	// Every interested webhook is notified, even after one fails
+	want := []Transition{transition, transition, transition}
+	if diff := cmp.Diff(want, received); diff != "" {
+		t.Errorf("Notify(): -want, +got:\n%s", diff)
+	}
+}
+
+func TestNotifyNil(t *testing.T) {
+	var n *Notifier
+	n.Notify(context.Background(), []v1beta1.Webhook{{URL: "http://127.0.0.1:1"}}, Transition{Phase: "Running"})
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package labels defines the labels the provider sets on Docker objects, and
+// the annotations it reads from managed resources, with helpers to select
+// Docker objects by their labels.
+package labels
+
+import (
+	"strings"
+
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Labels set on the Docker objects the provider creates.
+const (
+	// ManagedBy marks Docker objects the provider created, with the value
+	// ManagedByProvider.
+	ManagedBy = "docker.crossplane.io/managed-by"
+
+	// ManagedByProvider is the value of ManagedBy.
+	ManagedByProvider = "provider-docker"
+
+	// HelperFor marks helper containers with the ID of the container they
+	// were started for.
+	HelperFor = "docker.crossplane.io/helper-for"
+
+	// ProviderConfig marks containers with the ProviderConfig they were
+	// created through.
+	ProviderConfig = "docker.crossplane.io/provider-config"
+
+	// StackUID is the UID of the ComposeStack that owns the object.
+	StackUID = StackPrefix + "stack-uid"
+
+	// ConfigHash is a hash of the configuration a container of a
+	// ComposeStack was created from.
+	ConfigHash = StackPrefix + "config-hash"
+
+	// ModelHash is a hash of the rendered compose model a container of a
+	// ComposeStack was created from.
+	ModelHash = StackPrefix + "model-hash"
+
+	// ServiceHash is a hash of the rendered compose definition of the
+	// service a container of a ComposeStack was created for.
+	ServiceHash = StackPrefix + "service-hash"
+
+	// StackPrefix prefixes the labels the provider sets on the objects of a
+	// ComposeStack.
+	StackPrefix = "compose.docker.crossplane.io/"
+)
+
+// Labels Docker Compose sets, which the provider sets too so that stacks it
+// creates can be managed with the docker compose CLI.
+const (
+	// ComposeProject is the Docker Compose project of a container.
+	ComposeProject = ComposePrefix + "project"
+
+	// ComposeService is the Docker Compose service of a container.
+	ComposeService = ComposePrefix + "service"
+
+	// ComposeContainerNumber is the number of the replica of its service a
+	// container is, from 1.
+	ComposeContainerNumber = ComposePrefix + "container-number"
+
+	// ComposePrefix prefixes the labels Docker Compose sets.
+	ComposePrefix = "com.docker.compose."
+)
+
+// Annotations of managed resources that change how the provider treats them.
+const (
+	// AnnotationSkipInjection opts a container out of its ProviderConfig's
+	// injection when set to "true".
+	AnnotationSkipInjection = "docker.crossplane.io/skip-injection"
+
+	// AnnotationIgnoreFields lists, comma separated, the spec fields of a
+	// container whose drift the provider tolerates, such as
+	// spec.forProvider.labels or spec.forProvider.env[DEBUG].
+	AnnotationIgnoreFields = "docker.crossplane.io/ignore-fields"
+
+	// AnnotationForceDelete overrides a network's PreventDeleteWhileInUse
+	// when set to "true".
+	AnnotationForceDelete = "docker.crossplane.io/force-delete"
+
+	// AnnotationExportConfigMap names a ConfigMap in the namespace of a
+	// ComposeStack that it is exported to.
+	AnnotationExportConfigMap = StackPrefix + "export-configmap"
+
+	// AnnotationInspectService names a service of a ComposeStack whose
+	// container's inspect output is captured to a ConfigMap.
+	AnnotationInspectService = StackPrefix + "inspect-service"
+)
+
+// Selector returns a Docker label filter value that matches objects whose
+// label key has value.
+func Selector(key, value string) string {
+	return key + "=" + value
+}
+
+// Filter returns Docker filters matching the objects that have all of the
+// given labels, each a key or a Selector.
+func Filter(selectors ...string) filters.Args {
+	args := filters.NewArgs()
+	for _, s := range selectors {
+		args.Add("label", s)
+	}
+	return args
+}
+
+// IsStackLabel reports whether a label key is one Docker Compose or the
+// provider sets on the containers of a stack, rather than one specified by
+// users.
+func IsStackLabel(key string) bool {
+	return strings.HasPrefix(key, ComposePrefix) || strings.HasPrefix(key, StackPrefix)
+}
+
+// Annotations the provider sets on managed resources to record the result of
+// creating their external resource. The managed reconciler saves only the
+// annotations of a resource it has just created, not its status.
+const (
+	// AnnotationOperationResult is the result of a ContainerOperation, as
+	// JSON, recorded when its action is performed.
+	AnnotationOperationResult = "docker.crossplane.io/operation-result"
+)
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	f := Filter(Selector(ManagedBy, ManagedByProvider), StackUID)
+	got := f.Get("label")
+	want := []string{"compose.docker.crossplane.io/stack-uid", "docker.crossplane.io/managed-by=provider-docker"}
+	if len(got) != len(want) {
+		t.Fatalf("Filter() labels = %v, want %v", got, want)
+	}
+	for _, w := range want {
+		if !f.ExactMatch("label", w) {
+			t.Errorf("Filter() labels = %v, want %q", got, w)
+		}
+	}
+}
+
+func TestIsStackLabel(t *testing.T) {
+	got := map[string]bool{}
+	for _, k := range []string{ComposeProject, ComposeService, StackUID, ConfigHash, ManagedBy, "team"} {
+		got[k] = IsStackLabel(k)
+	}
+	want := map[string]bool{
+		ComposeProject: true,
+		ComposeService: true,
+		StackUID:       true,
+		ConfigHash:     true,
+		ManagedBy:      false,
+		"team":         false,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("IsStackLabel() = %v, want %v", got, want)
+	}
+}